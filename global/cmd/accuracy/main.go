@@ -0,0 +1,105 @@
+// Command accuracy compares the prayer-time calculator with the authority
+// timetables in -fixtures and exits non-zero when any prayer deviates by more
+// than -tolerance. Without -fixtures it runs the same check against the
+// embedded go-prayer snapshots, which catches regressions but measures no
+// accuracy, and says so.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/escalopa/prayer-bot/global/internal/core/accuracy"
+	"github.com/escalopa/prayer-bot/global/internal/core/prayertime"
+	"github.com/escalopa/prayer-bot/global/internal/port"
+)
+
+func main() {
+	directory := flag.String("fixtures", "", "directory with manifest.json and the authorities' published timetables")
+	flag.Bool("snapshots", true, "check against the embedded go-prayer snapshots, the default without -fixtures")
+	tolerance := flag.Duration("tolerance", time.Minute, "largest accepted deviation of any prayer")
+	engine := flag.String("engine", "go-prayer", "calculator to check: go-prayer or astronomical")
+	record := flag.String("record", "", "print the calculator's timetable for this fixture instead of checking")
+	year := flag.Int("year", time.Now().Year(), "year printed by -record")
+	flag.Parse()
+
+	var calculator port.Calculator
+	switch *engine {
+	case "go-prayer":
+		calculator = prayertime.New()
+	case "astronomical":
+		calculator = prayertime.NewAstronomical()
+	default:
+		fatal(fmt.Errorf("unknown engine %q", *engine))
+	}
+	fsys := accuracy.Snapshots()
+	switch {
+	case *directory != "":
+		fsys = os.DirFS(*directory)
+	case *record == "":
+		fmt.Fprintln(os.Stderr, "no authority timetables are committed; checking the go-prayer snapshots, which measure no accuracy")
+	}
+	manifest, err := accuracy.Manifest(fsys)
+	if err != nil {
+		fatal(err)
+	}
+	ctx := context.Background()
+	if *record != "" {
+		for _, fixture := range manifest {
+			if fixture.Name == *record {
+				if err := accuracy.Record(ctx, calculator, fixture, *year, os.Stdout); err != nil {
+					fatal(err)
+				}
+				return
+			}
+		}
+		fatal(fmt.Errorf("no fixture named %q", *record))
+	}
+	status := "ACCURACY_STATUS"
+	if *directory == "" {
+		status = "SNAPSHOT_STATUS"
+	}
+	if err := check(ctx, calculator, fsys, manifest, *tolerance, status); err != nil {
+		fatal(err)
+	}
+}
+
+func check(ctx context.Context, calculator port.Calculator, fsys fs.FS, manifest []accuracy.Fixture, tolerance time.Duration, status string) error {
+	reports := make([]accuracy.Report, 0, len(manifest))
+	for _, fixture := range manifest {
+		report, err := accuracy.Check(ctx, calculator, fsys, fixture)
+		if err != nil {
+			return fmt.Errorf("fixture %s: %w", fixture.Name, err)
+		}
+		reports = append(reports, report)
+		fmt.Printf("%s · %s, %s, %s · %s\n", fixture.Name, fixture.Method, fixture.Madhab, fixture.HighLatitudeRule, fixture.Source)
+		table := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(table, "  prayer\tdays\tmissing\tmean\tmean abs\tmax\ton")
+		for _, stats := range report.Prayers {
+			fmt.Fprintf(table, "  %s\t%d\t%d\t%s\t%s\t%s\t%s\n",
+				stats.Prayer, stats.Days, stats.Missing, stats.Mean.Round(time.Second), stats.MeanAbs.Round(time.Second), stats.Max, stats.MaxDate.Format(time.DateOnly))
+		}
+		if err := table.Flush(); err != nil {
+			return err
+		}
+		fmt.Println()
+	}
+	if exceeding := accuracy.Exceeding(reports, tolerance); len(exceeding) > 0 {
+		for _, report := range exceeding {
+			fmt.Fprintf(os.Stderr, "%s deviates by up to %s\n", report.Fixture.Name, report.Worst())
+		}
+		return fmt.Errorf("%d of %d fixtures exceed the %s tolerance", len(exceeding), len(reports), tolerance)
+	}
+	fmt.Printf("%s=ok fixtures=%d tolerance=%s\n", status, len(reports), tolerance)
+	return nil
+}
+
+func fatal(err error) {
+	_, _ = fmt.Fprintln(os.Stderr, err)
+	os.Exit(1)
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/escalopa/prayer-bot/global/internal/core/reminders"
 	"github.com/escalopa/prayer-bot/global/internal/domain"
 	"github.com/escalopa/prayer-bot/global/internal/httpx"
+	"github.com/escalopa/prayer-bot/global/internal/port"
 )
 
 func main() {
@@ -35,7 +36,12 @@ func main() {
 		logger.Error("Telegram client initialization failed", "error", err)
 		os.Exit(1)
 	}
-	planner := reminders.NewPlanner(storage, prayertime.New())
+	var calculator port.Calculator = prayertime.New()
+	if cfg.CalculatorComparison > 0 {
+		calculator = prayertime.NewComparison(calculator, prayertime.NewAstronomical(), cfg.CalculatorComparison, logger)
+	}
+	calculator = prayertime.NewOfficial(calculator, storage)
+	planner := reminders.NewPlanner(storage, calculator)
 	sender := reminders.NewSender(storage, planner, telegramBot)
 
 	mux := http.NewServeMux()
@@ -68,6 +74,20 @@ func main() {
 		}
 		w.WriteHeader(http.StatusNoContent)
 	})
+	mux.HandleFunc("POST /tasks/announce", func(w http.ResponseWriter, r *http.Request) {
+		r.Body = http.MaxBytesReader(w, r.Body, 64<<10)
+		var task domain.HijriAnnouncementTask
+		if err := json.NewDecoder(r.Body).Decode(&task); err != nil {
+			http.Error(w, "invalid task", http.StatusBadRequest)
+			return
+		}
+		if err := sender.Announce(r.Context(), task); err != nil {
+			logger.Error("Hijri announcement task failed", "task_key", task.TaskKey, "error", err)
+			http.Error(w, "temporary failure", http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
 	logger.Info("sender service listening", "port", cfg.Port)
 	if err := httpx.Serve(cfg.Port, mux); err != nil {
 		logger.Error("HTTP server failed", "error", err)
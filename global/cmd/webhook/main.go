@@ -19,6 +19,7 @@ import (
 	"github.com/escalopa/prayer-bot/global/internal/core/prayertime"
 	"github.com/escalopa/prayer-bot/global/internal/core/reminders"
 	"github.com/escalopa/prayer-bot/global/internal/httpx"
+	"github.com/escalopa/prayer-bot/global/internal/port"
 )
 
 func main() {
@@ -39,7 +40,11 @@ func main() {
 		logger.Error("Telegram client initialization failed", "error", err)
 		os.Exit(1)
 	}
-	calculator := prayertime.New()
+	var calculator port.Calculator = prayertime.New()
+	if cfg.CalculatorComparison > 0 {
+		calculator = prayertime.NewComparison(calculator, prayertime.NewAstronomical(), cfg.CalculatorComparison, logger)
+	}
+	calculator = prayertime.NewOfficial(calculator, storage)
 	planner := reminders.NewPlanner(storage, calculator)
 	resolver := location.NewGoogleMaps(cfg.GoogleMapsAPIKey, cfg.HTTPTimeout)
 	handler := telegramhandler.NewHandler(
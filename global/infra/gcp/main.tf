@@ -15,6 +15,7 @@ locals {
     "cloudresourcemanager.googleapis.com",
     "cloudscheduler.googleapis.com",
     "cloudtasks.googleapis.com",
+    "elevation-backend.googleapis.com",
     "geocoding-backend.googleapis.com",
     "iam.googleapis.com",
     "iamcredentials.googleapis.com",
@@ -164,6 +165,9 @@ resource "google_apikeys_key" "maps" {
     api_targets {
       service = "geocoding-backend.googleapis.com"
     }
+    api_targets {
+      service = "elevation-backend.googleapis.com"
+    }
   }
 
   depends_on = [google_project_service.required]
@@ -22,7 +22,28 @@ type calendarSubscriptionResponse struct {
 	Path    string `json:"path,omitempty"`
 }
 
+// fastingCalendarDays is longer than the prayer feed, so the fasting days of
+// the coming months can be planned around.
+const fastingCalendarDays = 90
+
+// requestedFeed reads the feed query parameter; without one the request is
+// about the prayer calendar.
+func requestedFeed(r *http.Request) (domain.CalendarFeed, error) {
+	feed := domain.CalendarFeed(r.URL.Query().Get("feed"))
+	if feed == "" {
+		return domain.CalendarFeedPrayer, nil
+	}
+	if !feed.Valid() {
+		return "", badRequest("invalid_feed")
+	}
+	return feed, nil
+}
+
 func (h *Handler) createCalendarSubscription(w http.ResponseWriter, r *http.Request, identity Identity) error {
+	feed, err := requestedFeed(r)
+	if err != nil {
+		return err
+	}
 	if _, err := h.store.Profile(r.Context(), identity.UserID); domain.IsNotFound(err) {
 		return conflict("location_required")
 	} else if err != nil {
@@ -33,7 +54,7 @@ func (h *Handler) createCalendarSubscription(w http.ResponseWriter, r *http.Requ
 		return fmt.Errorf("create calendar credentials: %w", err)
 	}
 	subscription, err := h.store.EnableCalendarSubscription(
-		r.Context(), identity.UserID, feedToken, uidNamespace,
+		r.Context(), identity.UserID, feed, feedToken, uidNamespace,
 	)
 	if err != nil {
 		return fmt.Errorf("enable calendar subscription: %w", err)
@@ -45,7 +66,11 @@ func (h *Handler) createCalendarSubscription(w http.ResponseWriter, r *http.Requ
 }
 
 func (h *Handler) disableCalendarSubscription(w http.ResponseWriter, r *http.Request, identity Identity) error {
-	if err := h.store.DisableCalendarSubscription(r.Context(), identity.UserID); err != nil {
+	feed, err := requestedFeed(r)
+	if err != nil {
+		return err
+	}
+	if err := h.store.DisableCalendarSubscription(r.Context(), identity.UserID, feed); err != nil {
 		return fmt.Errorf("disable calendar subscription: %w", err)
 	}
 	return writeJSON(w, calendarSubscriptionResponse{Enabled: false})
@@ -79,7 +104,7 @@ func (h *Handler) calendarDownload(w http.ResponseWriter, r *http.Request) {
 		http.NotFound(w, r)
 		return
 	}
-	location, err := time.LoadLocation(profile.Timezone)
+	location, err := domain.LoadLocation(profile.Timezone)
 	if err != nil {
 		h.logger.Error("Calendar feed timezone lookup failed", "timezone", profile.Timezone, "error", err)
 		http.Error(w, "calendar generation failed", http.StatusInternalServerError)
@@ -87,16 +112,22 @@ func (h *Handler) calendarDownload(w http.ResponseWriter, r *http.Request) {
 	}
 	start := h.now().In(location)
 	createdAt := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, location)
-	data, err := calendarfile.Generate(
-		r.Context(),
-		h.calculator,
-		profile,
-		i18n.Resolve(chat.LanguageCode),
-		start,
-		rollingCalendarDays,
-		createdAt,
-		subscription.UIDNamespace,
-	)
+	locale := i18n.Resolve(chat.LanguageCode)
+	var data []byte
+	if subscription.Feed == domain.CalendarFeedFasting {
+		data, err = calendarfile.GenerateFasting(profile, locale, start, fastingCalendarDays, createdAt, subscription.UIDNamespace)
+	} else {
+		data, err = calendarfile.Generate(
+			r.Context(),
+			h.calculator,
+			profile,
+			locale,
+			start,
+			rollingCalendarDays,
+			createdAt,
+			subscription.UIDNamespace,
+		)
+	}
 	if err != nil {
 		h.logger.Error("Calendar feed generation failed", "error", err)
 		http.Error(w, "calendar generation failed", http.StatusInternalServerError)
@@ -110,7 +141,11 @@ func (h *Handler) calendarDownload(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
-	w.Header().Set("Content-Disposition", `inline; filename="prayer-times.ics"`)
+	filename := "prayer-times.ics"
+	if subscription.Feed == domain.CalendarFeedFasting {
+		filename = "fasting.ics"
+	}
+	w.Header().Set("Content-Disposition", `inline; filename="`+filename+`"`)
 	w.Header().Set("Content-Length", strconv.Itoa(len(data)))
 	w.WriteHeader(http.StatusOK)
 	_, _ = w.Write(data)
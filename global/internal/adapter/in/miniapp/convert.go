@@ -0,0 +1,85 @@
+package miniapp
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/escalopa/prayer-bot/global/internal/core/dateconvert"
+	"github.com/escalopa/prayer-bot/global/internal/core/hijri"
+	"github.com/escalopa/prayer-bot/global/internal/core/i18n"
+	"github.com/escalopa/prayer-bot/global/internal/domain"
+)
+
+type convertRequest struct {
+	Date string `json:"date"`
+}
+
+// convertResponse lists the typed calendar first. Gregorian has two days,
+// and Ambiguous explains them, when a Hijri 30th may not exist.
+type convertResponse struct {
+	HijriFirst bool     `json:"hijri_first"`
+	Hijri      string   `json:"hijri"`
+	Note       string   `json:"note"`
+	Gregorian  []string `json:"gregorian"`
+	Ambiguous  string   `json:"ambiguous,omitempty"`
+}
+
+// convertDate converts a typed date in the user's Hijri calendar and
+// correction, or in the default calendar before a location is shared.
+func (h *Handler) convertDate(w http.ResponseWriter, r *http.Request, identity Identity) error {
+	var request convertRequest
+	if err := decodeJSON(w, r, &request); err != nil {
+		return badRequest("invalid_request")
+	}
+	query, err := dateconvert.Parse(request.Date)
+	if err != nil {
+		return badRequest("invalid_date")
+	}
+	profile, err := h.store.Profile(r.Context(), identity.UserID)
+	if err != nil && !domain.IsNotFound(err) {
+		return fmt.Errorf("load profile: %w", err)
+	}
+	locale := i18n.Resolve(identity.LanguageCode)
+	if chat, err := h.store.Chat(r.Context(), identity.UserID); err == nil {
+		locale = i18n.Resolve(chat.LanguageCode)
+	} else if !domain.IsNotFound(err) {
+		return fmt.Errorf("load chat: %w", err)
+	}
+	location := time.UTC
+	if profile.Timezone != "" {
+		if location, err = domain.LoadLocation(profile.Timezone); err != nil {
+			return fmt.Errorf("load profile timezone: %w", err)
+		}
+	}
+	calendar, err := hijri.ForProfile(profile)
+	if err != nil {
+		return fmt.Errorf("load Hijri calendar: %w", err)
+	}
+	result, err := dateconvert.Convert(query, calendar, h.now().In(location))
+	if err != nil {
+		return badRequest("invalid_date")
+	}
+	return writeJSON(w, formatConversion(query, result, profile, locale))
+}
+
+func formatConversion(query dateconvert.Query, result dateconvert.Result, profile domain.PrayerProfile, locale i18n.Locale) convertResponse {
+	note := "hijri_note"
+	if _, announced := hijri.Announcement(profile, result.Gregorian[0]); announced {
+		note = "hijri_note_announced"
+	}
+	response := convertResponse{
+		HijriFirst: query.Hijri,
+		Hijri: fmt.Sprintf("%d %s %d %s", result.Hijri.Day, locale.HijriMonth(result.Hijri.Month),
+			result.Hijri.Year, locale.Message("hijri_era")),
+		Note: fmt.Sprintf(locale.Message(note), locale.HijriCalendar(profile.HijriCalendar)),
+	}
+	for _, day := range result.Gregorian {
+		response.Gregorian = append(response.Gregorian, fmt.Sprintf("%s, %d %s %d",
+			locale.Weekday(day.Weekday()), day.Day(), locale.Month(int(day.Month())), day.Year()))
+	}
+	if result.Ambiguous() {
+		response.Ambiguous = fmt.Sprintf(locale.Message("convert_ambiguous"), response.Gregorian[0], response.Gregorian[1])
+	}
+	return response
+}
@@ -0,0 +1,149 @@
+package miniapp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/escalopa/prayer-bot/global/internal/core/i18n"
+	"github.com/escalopa/prayer-bot/global/internal/core/reminders"
+	"github.com/escalopa/prayer-bot/global/internal/domain"
+)
+
+// customReminderJSON is one of the user's own reminders. Weekdays are
+// time.Weekday numbers, Sunday being 0; Summary is the localized repeat and
+// time, and is ignored in requests.
+type customReminderJSON struct {
+	ID            int64             `json:"id"`
+	Text          string            `json:"text"`
+	LocalTime     string            `json:"local_time"`
+	Anchor        domain.Prayer     `json:"anchor"`
+	OffsetMinutes int               `json:"offset_minutes"`
+	Recurrence    domain.Recurrence `json:"recurrence"`
+	Weekdays      []int             `json:"weekdays"`
+	HijriDay      int               `json:"hijri_day"`
+	Date          string            `json:"date"`
+	Summary       string            `json:"summary,omitempty"`
+}
+
+func customReminderResponse(reminder domain.CustomReminder, locale i18n.Locale) customReminderJSON {
+	weekdays := []int{}
+	for _, day := range reminder.Weekdays.Days() {
+		weekdays = append(weekdays, int(day))
+	}
+	return customReminderJSON{
+		ID: reminder.RuleID, Text: reminder.Text, LocalTime: reminder.LocalTime,
+		Anchor: reminder.Anchor, OffsetMinutes: reminder.OffsetMinutes, Recurrence: reminder.Recurrence,
+		Weekdays: weekdays, HijriDay: reminder.HijriDay, Date: reminder.Date,
+		Summary: locale.CustomReminderSchedule(reminder),
+	}
+}
+
+// reminder reads the request, keeping only the fields its time and
+// recurrence use, so a form that still holds a hidden value stays valid.
+func (request customReminderJSON) reminder(chatID int64) (domain.CustomReminder, error) {
+	reminder := domain.CustomReminder{
+		RuleID: request.ID, ChatID: chatID, Text: request.Text, Recurrence: request.Recurrence,
+	}
+	if request.Anchor != "" {
+		reminder.Anchor, reminder.OffsetMinutes = request.Anchor, request.OffsetMinutes
+	} else {
+		reminder.LocalTime = request.LocalTime
+	}
+	switch request.Recurrence {
+	case domain.RecurrenceWeekly:
+		for _, day := range request.Weekdays {
+			if day < int(time.Sunday) || day > int(time.Saturday) {
+				return domain.CustomReminder{}, fmt.Errorf("invalid weekday %d", day)
+			}
+			reminder.Weekdays |= domain.WeekdaysOf(time.Weekday(day))
+		}
+	case domain.RecurrenceHijriMonthly:
+		reminder.HijriDay = request.HijriDay
+	case domain.RecurrenceOnce:
+		reminder.Date = request.Date
+	}
+	return reminder, reminder.Validate()
+}
+
+// saveCustomReminder adds a reminder, or replaces the one the request names.
+func (h *Handler) saveCustomReminder(w http.ResponseWriter, r *http.Request, identity Identity) error {
+	var request customReminderJSON
+	if err := decodeJSON(w, r, &request); err != nil {
+		return badRequest("invalid_request")
+	}
+	reminder, err := request.reminder(identity.UserID)
+	if err != nil {
+		return badRequest("invalid_custom_reminder")
+	}
+	profile, err := h.store.Profile(r.Context(), identity.UserID)
+	if domain.IsNotFound(err) {
+		return conflict("location_required")
+	} else if err != nil {
+		return fmt.Errorf("load profile: %w", err)
+	}
+	upcoming, err := reminders.CustomUpcoming(r.Context(), h.calculator, profile, reminder, h.now())
+	if err != nil {
+		return fmt.Errorf("plan custom reminder: %w", err)
+	}
+	if !upcoming {
+		return badRequest("custom_reminder_past")
+	}
+	if reminder.RuleID == 0 {
+		_, err = h.store.AddCustomReminder(r.Context(), reminder)
+	} else {
+		err = h.store.UpdateCustomReminder(r.Context(), reminder)
+	}
+	switch {
+	case errors.Is(err, domain.ErrCustomReminderLimit):
+		return conflict("custom_reminder_limit")
+	case domain.IsNotFound(err):
+		return badRequest("not_found")
+	case err != nil:
+		return fmt.Errorf("save custom reminder: %w", err)
+	}
+	return h.writeCustomReminders(w, r, identity)
+}
+
+// deleteCustomReminder removes the reminder named by ?id=.
+func (h *Handler) deleteCustomReminder(w http.ResponseWriter, r *http.Request, identity Identity) error {
+	ruleID, err := strconv.ParseInt(r.URL.Query().Get("id"), 10, 64)
+	if err != nil {
+		return badRequest("invalid_request")
+	}
+	switch err := h.store.DeleteCustomReminder(r.Context(), identity.UserID, ruleID); {
+	case domain.IsNotFound(err):
+		return badRequest("not_found")
+	case err != nil:
+		return fmt.Errorf("delete custom reminder: %w", err)
+	}
+	return h.writeCustomReminders(w, r, identity)
+}
+
+// writeCustomReminders plans the changed reminders and answers with the
+// whole app state, like the other reminder settings.
+func (h *Handler) writeCustomReminders(w http.ResponseWriter, r *http.Request, identity Identity) error {
+	if err := h.planner.RebuildChat(r.Context(), identity.UserID, h.now()); err != nil {
+		return fmt.Errorf("rebuild reminders: %w", err)
+	}
+	data, err := h.build(r.Context(), identity)
+	if err != nil {
+		return err
+	}
+	return writeJSON(w, data)
+}
+
+func (h *Handler) customReminders(ctx context.Context, chatID int64, locale i18n.Locale) ([]customReminderJSON, error) {
+	saved, err := h.store.CustomReminders(ctx, chatID)
+	if err != nil {
+		return nil, fmt.Errorf("load custom reminders: %w", err)
+	}
+	result := []customReminderJSON{}
+	for _, reminder := range saved {
+		result = append(result, customReminderResponse(reminder, locale))
+	}
+	return result, nil
+}
@@ -0,0 +1,261 @@
+package miniapp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/escalopa/prayer-bot/global/internal/core/fasting"
+	"github.com/escalopa/prayer-bot/global/internal/core/hijri"
+	"github.com/escalopa/prayer-bot/global/internal/core/i18n"
+	"github.com/escalopa/prayer-bot/global/internal/core/monthsheet"
+	"github.com/escalopa/prayer-bot/global/internal/domain"
+)
+
+type fastingRequest struct {
+	Month string `json:"month"`
+}
+
+// fastLogRequest logs one day and answers with the month it is shown in. An
+// empty status clears the day.
+type fastLogRequest struct {
+	Month  string            `json:"month"`
+	Date   string            `json:"date"`
+	Status domain.FastStatus `json:"status"`
+}
+
+type qadaRequest struct {
+	Month   string `json:"month"`
+	Carried int    `json:"carried"`
+}
+
+const fastingSuggestions = 3
+
+// fastingMonthResponse is one Gregorian month of the fasting calendar with
+// the chat's log. Previous and Next are empty at the edge of the months a
+// timetable reaches.
+type fastingMonthResponse struct {
+	Month    string                 `json:"month"`
+	Title    string                 `json:"title"`
+	Previous string                 `json:"previous,omitempty"`
+	Next     string                 `json:"next,omitempty"`
+	Weekdays []string               `json:"weekdays"`
+	Days     []fastingDayResponse   `json:"days"`
+	Note     string                 `json:"note"`
+	Balance  fastingBalanceResponse `json:"balance"`
+}
+
+type fastingDayResponse struct {
+	Date string `json:"date"`
+	Day  int    `json:"day"`
+	// Weekday counts from Sunday, as JavaScript's Date does.
+	Weekday int            `json:"weekday"`
+	Hijri   string         `json:"hijri"`
+	Status  fasting.Status `json:"status,omitempty"`
+	Emoji   string         `json:"emoji,omitempty"`
+	Label   string         `json:"label,omitempty"`
+	Reasons string         `json:"reasons,omitempty"`
+	Today   bool           `json:"today,omitempty"`
+	// Loggable days are those up to tomorrow; Logged is what was logged.
+	Loggable    bool              `json:"loggable,omitempty"`
+	Logged      domain.FastStatus `json:"logged,omitempty"`
+	LoggedLabel string            `json:"logged_label,omitempty"`
+}
+
+// fastingBalanceResponse suggests days to make up only while fasts are owed.
+type fastingBalanceResponse struct {
+	Kept        int                         `json:"kept"`
+	MadeUp      int                         `json:"made_up"`
+	Missed      int                         `json:"missed"`
+	Carried     int                         `json:"carried"`
+	Owed        int                         `json:"owed"`
+	Suggestions []fastingSuggestionResponse `json:"suggestions"`
+}
+
+type fastingSuggestionResponse struct {
+	Date    string `json:"date"`
+	Label   string `json:"label"`
+	Reasons string `json:"reasons"`
+}
+
+// fastingMonth returns the fasting days of a month. An empty month means the
+// current one in the profile's timezone.
+func (h *Handler) fastingMonth(w http.ResponseWriter, r *http.Request, identity Identity) error {
+	var request fastingRequest
+	if err := decodeJSON(w, r, &request); err != nil {
+		return badRequest("invalid_request")
+	}
+	profile, locale, err := h.sheetOwner(r.Context(), identity)
+	if err != nil {
+		return err
+	}
+	return h.writeFastingMonth(r.Context(), w, profile, locale, request.Month)
+}
+
+// logFast saves or clears what the user logged for a day up to tomorrow.
+func (h *Handler) logFast(w http.ResponseWriter, r *http.Request, identity Identity) error {
+	var request fastLogRequest
+	if err := decodeJSON(w, r, &request); err != nil {
+		return badRequest("invalid_request")
+	}
+	date, err := time.Parse(time.DateOnly, request.Date)
+	if err != nil {
+		return badRequest("invalid_date")
+	}
+	if request.Status != "" && !request.Status.Valid() {
+		return badRequest("invalid_status")
+	}
+	profile, locale, err := h.sheetOwner(r.Context(), identity)
+	if err != nil {
+		return err
+	}
+	location, err := domain.LoadLocation(profile.Timezone)
+	if err != nil {
+		return fmt.Errorf("load profile timezone: %w", err)
+	}
+	if !fasting.Loggable(date, h.now().In(location)) {
+		return badRequest("invalid_date")
+	}
+	if request.Status == "" {
+		if err := h.store.ClearFast(r.Context(), identity.UserID, date); err != nil {
+			return fmt.Errorf("clear fast: %w", err)
+		}
+		return h.writeFastingMonth(r.Context(), w, profile, locale, request.Month)
+	}
+	calendar, err := hijri.ForProfile(profile)
+	if err != nil {
+		return fmt.Errorf("load Hijri calendar: %w", err)
+	}
+	entry, err := fasting.Log(identity.UserID, date, request.Status, calendar, profile.CountryCode)
+	switch {
+	case errors.Is(err, domain.ErrFastProhibited):
+		return badRequest("fasting_prohibited")
+	case errors.Is(err, domain.ErrMakeUpInRamadan):
+		return badRequest("ramadan_makeup")
+	case err != nil:
+		return fmt.Errorf("check fast: %w", err)
+	}
+	if err := h.store.LogFast(r.Context(), entry); err != nil {
+		return fmt.Errorf("log fast: %w", err)
+	}
+	return h.writeFastingMonth(r.Context(), w, profile, locale, request.Month)
+}
+
+// setQadaCarried records the make-up fasts owed from before the log.
+func (h *Handler) setQadaCarried(w http.ResponseWriter, r *http.Request, identity Identity) error {
+	var request qadaRequest
+	if err := decodeJSON(w, r, &request); err != nil {
+		return badRequest("invalid_request")
+	}
+	if request.Carried < 0 || request.Carried > domain.MaxQadaCarried {
+		return badRequest("invalid_carried")
+	}
+	profile, locale, err := h.sheetOwner(r.Context(), identity)
+	if err != nil {
+		return err
+	}
+	if err := h.store.SetQadaCarried(r.Context(), identity.UserID, request.Carried); err != nil {
+		return fmt.Errorf("set carried make-up fasts: %w", err)
+	}
+	return h.writeFastingMonth(r.Context(), w, profile, locale, request.Month)
+}
+
+// writeFastingMonth answers every fasting request with the month the
+// Mini App shows, so a change to the log redraws it in one round trip.
+func (h *Handler) writeFastingMonth(ctx context.Context, w http.ResponseWriter, profile domain.PrayerProfile, locale i18n.Locale, requested string) error {
+	location, err := domain.LoadLocation(profile.Timezone)
+	if err != nil {
+		return fmt.Errorf("load profile timezone: %w", err)
+	}
+	now := h.now().In(location)
+	month := now
+	if requested != "" {
+		if month, err = monthsheet.ParseMonth(requested, now, profile.Timezone); err != nil {
+			return badRequest("invalid_month")
+		}
+	}
+	first := monthsheet.FirstOfMonth(month)
+	last := first.AddDate(0, 1, -1)
+	calendar, err := hijri.ForProfile(profile)
+	if err != nil {
+		return fmt.Errorf("load Hijri calendar: %w", err)
+	}
+	days, err := fasting.Between(first, last.Day(), calendar, profile.CountryCode)
+	if err != nil {
+		return fmt.Errorf("calculate fasting days: %w", err)
+	}
+	entries, err := h.store.FastLog(ctx, profile.ChatID, first, last)
+	if err != nil {
+		return fmt.Errorf("load fasting log: %w", err)
+	}
+	logged := make(map[string]domain.FastLogEntry, len(entries))
+	for _, entry := range entries {
+		logged[entry.Date.Format(time.DateOnly)] = entry
+	}
+	response := fastingMonthResponse{
+		Month: first.Format("2006-01"),
+		Title: fmt.Sprintf("%s %d", locale.Month(int(first.Month())), first.Year()),
+		Note:  locale.Message("fasting_note"),
+	}
+	if response.Balance, err = h.fastingBalance(ctx, profile.ChatID, now, calendar, profile.CountryCode, locale); err != nil {
+		return err
+	}
+	if previous := first.AddDate(0, -1, 0); monthsheet.WithinReach(previous, now) {
+		response.Previous = previous.Format("2006-01")
+	}
+	if next := first.AddDate(0, 1, 0); monthsheet.WithinReach(next, now) {
+		response.Next = next.Format("2006-01")
+	}
+	for weekday := range 7 {
+		response.Weekdays = append(response.Weekdays, locale.Weekday(time.Weekday(weekday)))
+	}
+	today := now.Format(time.DateOnly)
+	for _, day := range days {
+		date := day.Date.Format(time.DateOnly)
+		item := fastingDayResponse{
+			Date: date, Day: day.Date.Day(), Weekday: int(day.Date.Weekday()),
+			Hijri:    fmt.Sprintf("%d %s", day.Hijri.Day, locale.HijriMonth(day.Hijri.Month)),
+			Today:    date == today,
+			Loggable: fasting.Loggable(day.Date, now),
+		}
+		if day.Status != "" {
+			item.Status, item.Emoji = day.Status, day.Status.Emoji()
+			item.Label, item.Reasons = locale.FastingStatus(day.Status), locale.FastingReasons(day)
+		}
+		if entry, ok := logged[date]; ok {
+			item.Logged, item.LoggedLabel = entry.Status, locale.FastLogged(entry)
+		}
+		response.Days = append(response.Days, item)
+	}
+	return writeJSON(w, response)
+}
+
+func (h *Handler) fastingBalance(ctx context.Context, chatID int64, now time.Time, calendar hijri.Calendar, country string, locale i18n.Locale) (fastingBalanceResponse, error) {
+	balance, err := h.store.FastingBalance(ctx, chatID)
+	if err != nil {
+		return fastingBalanceResponse{}, fmt.Errorf("load fasting balance: %w", err)
+	}
+	response := fastingBalanceResponse{
+		Kept: balance.Kept, MadeUp: balance.MadeUp, Missed: balance.Missed,
+		Carried: balance.Carried, Owed: balance.Owed(), Suggestions: []fastingSuggestionResponse{},
+	}
+	if response.Owed == 0 {
+		return response, nil
+	}
+	tomorrow := time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, time.UTC)
+	days, err := fasting.MakeUpDays(tomorrow, fastingSuggestions, calendar, country)
+	if err != nil {
+		return fastingBalanceResponse{}, fmt.Errorf("suggest make-up days: %w", err)
+	}
+	for _, day := range days {
+		response.Suggestions = append(response.Suggestions, fastingSuggestionResponse{
+			Date: day.Date.Format(time.DateOnly),
+			Label: fmt.Sprintf("%s %d %s", locale.Weekday(day.Date.Weekday()), day.Date.Day(),
+				locale.Month(int(day.Date.Month()))),
+			Reasons: locale.FastingReasons(day),
+		})
+	}
+	return response, nil
+}
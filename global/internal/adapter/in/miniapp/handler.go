@@ -21,7 +21,9 @@ import (
 
 	"github.com/escalopa/prayer-bot/global/internal/core/hijri"
 	"github.com/escalopa/prayer-bot/global/internal/core/i18n"
+	"github.com/escalopa/prayer-bot/global/internal/core/moon"
 	"github.com/escalopa/prayer-bot/global/internal/core/occasions"
+	"github.com/escalopa/prayer-bot/global/internal/core/prayertime"
 	"github.com/escalopa/prayer-bot/global/internal/core/qibla"
 	"github.com/escalopa/prayer-bot/global/internal/domain"
 	"github.com/escalopa/prayer-bot/global/internal/port"
@@ -47,10 +49,21 @@ type Storage interface {
 	SetWeeklyRule(context.Context, int64, domain.ReminderKind, bool) error
 	SetWhiteDaysRule(context.Context, int64, bool) error
 	SetOccasionRule(context.Context, int64, domain.ReminderKind, bool) error
-	CalendarSubscription(context.Context, int64) (domain.CalendarSubscription, error)
+	SetRamadanRule(context.Context, int64, domain.ReminderKind, int, bool) error
+	SetExtendedTimeRule(context.Context, int64, domain.Prayer, bool) error
+	CalendarSubscription(context.Context, int64, domain.CalendarFeed) (domain.CalendarSubscription, error)
 	CalendarSubscriptionByToken(context.Context, string) (domain.CalendarSubscription, error)
-	EnableCalendarSubscription(context.Context, int64, string, string) (domain.CalendarSubscription, error)
-	DisableCalendarSubscription(context.Context, int64) error
+	EnableCalendarSubscription(context.Context, int64, domain.CalendarFeed, string, string) (domain.CalendarSubscription, error)
+	DisableCalendarSubscription(context.Context, int64, domain.CalendarFeed) error
+	LogFast(context.Context, domain.FastLogEntry) error
+	ClearFast(context.Context, int64, time.Time) error
+	FastLog(context.Context, int64, time.Time, time.Time) ([]domain.FastLogEntry, error)
+	FastingBalance(context.Context, int64) (domain.FastingBalance, error)
+	SetQadaCarried(context.Context, int64, int) error
+	CustomReminders(context.Context, int64) ([]domain.CustomReminder, error)
+	AddCustomReminder(context.Context, domain.CustomReminder) (domain.CustomReminder, error)
+	UpdateCustomReminder(context.Context, domain.CustomReminder) error
+	DeleteCustomReminder(context.Context, int64, int64) error
 }
 
 type ReminderPlanner interface {
@@ -61,15 +74,22 @@ type PhotoSender interface {
 	SendPhoto(context.Context, *botapi.SendPhotoParams) (*models.Message, error)
 }
 
+// DocumentSender is optional on the photo sender; without it the monthly
+// timetable cannot be delivered.
+type DocumentSender interface {
+	SendDocument(context.Context, *botapi.SendDocumentParams) (*models.Message, error)
+}
+
 type Handler struct {
-	botToken    string
-	store       Storage
-	resolver    port.LocationResolver
-	calculator  port.Calculator
-	planner     ReminderPlanner
-	photoSender PhotoSender
-	logger      *slog.Logger
-	now         func() time.Time
+	botToken       string
+	store          Storage
+	resolver       port.LocationResolver
+	calculator     port.Calculator
+	planner        ReminderPlanner
+	photoSender    PhotoSender
+	documentSender DocumentSender
+	logger         *slog.Logger
+	now            func() time.Time
 }
 
 func NewHandler(
@@ -90,6 +110,7 @@ func NewHandler(
 	}
 	if len(photoSenders) > 0 {
 		handler.photoSender = photoSenders[0]
+		handler.documentSender, _ = photoSenders[0].(DocumentSender)
 	}
 	return handler
 }
@@ -111,6 +132,15 @@ func (h *Handler) Register(mux *http.ServeMux) {
 	mux.HandleFunc("PUT /api/miniapp/settings", h.api(h.updateSettings))
 	mux.HandleFunc("PUT /api/miniapp/reminders", h.api(h.updateReminders))
 	mux.HandleFunc("POST /api/miniapp/prayer-card", h.api(h.sendPrayerCard))
+	mux.HandleFunc("POST /api/miniapp/month", h.api(h.monthTimetable))
+	mux.HandleFunc("POST /api/miniapp/ramadan", h.api(h.ramadanTimetable))
+	mux.HandleFunc("POST /api/miniapp/ramadan/days", h.api(h.ramadanDays))
+	mux.HandleFunc("POST /api/miniapp/convert", h.api(h.convertDate))
+	mux.HandleFunc("POST /api/miniapp/fasting", h.api(h.fastingMonth))
+	mux.HandleFunc("PUT /api/miniapp/fasting/log", h.api(h.logFast))
+	mux.HandleFunc("PUT /api/miniapp/fasting/qada", h.api(h.setQadaCarried))
+	mux.HandleFunc("PUT /api/miniapp/custom-reminders", h.api(h.saveCustomReminder))
+	mux.HandleFunc("DELETE /api/miniapp/custom-reminders", h.api(h.deleteCustomReminder))
 	mux.HandleFunc("POST /api/miniapp/calendar-subscription", h.api(h.createCalendarSubscription))
 	mux.HandleFunc("DELETE /api/miniapp/calendar-subscription", h.api(h.disableCalendarSubscription))
 	mux.HandleFunc("GET /api/miniapp/calendar.ics", h.calendarDownload)
@@ -184,15 +214,12 @@ func (h *Handler) updateLocation(w http.ResponseWriter, r *http.Request, identit
 		ChatID: identity.UserID, Latitude: latitude, Longitude: longitude,
 		Timezone: resolved.Timezone, PlaceID: resolved.PlaceID, CountryCode: resolved.CountryCode,
 		Method: domain.RecommendedMethod(resolved.CountryCode), Madhab: domain.MadhabShafii,
-		HighLatitudeRule: domain.HighLatitudeAngleBased,
+		HighLatitudeRule: domain.HighLatitudeAngleBased, ImsakMinutes: domain.DefaultImsakMinutes,
+		ElevationMeters: h.elevation(r.Context(), latitude, longitude),
 	}
 	current, err := h.store.Profile(r.Context(), identity.UserID)
 	if err == nil {
-		profile.Method = current.Method
-		profile.Madhab = current.Madhab
-		profile.HighLatitudeRule = current.HighLatitudeRule
-		profile.Adjustments = current.Adjustments
-		profile.HijriAdjustment = current.HijriAdjustment
+		profile.CopyPreferences(current)
 	} else if !domain.IsNotFound(err) {
 		return fmt.Errorf("load profile: %w", err)
 	}
@@ -210,6 +237,20 @@ func (h *Handler) updateLocation(w http.ResponseWriter, r *http.Request, identit
 	return writeJSON(w, data)
 }
 
+// elevation looks up the ground elevation when the resolver supports it. The
+// lookup is best effort: without it the times are calculated at sea level.
+func (h *Handler) elevation(ctx context.Context, latitude, longitude float64) int {
+	resolver, ok := h.resolver.(port.ElevationResolver)
+	if !ok {
+		return 0
+	}
+	meters, err := resolver.Elevation(ctx, latitude, longitude)
+	if err != nil {
+		return 0
+	}
+	return domain.ClampElevation(meters)
+}
+
 type lookupRequest struct {
 	Latitude  *float64 `json:"latitude"`
 	Longitude *float64 `json:"longitude"`
@@ -244,15 +285,12 @@ func (h *Handler) lookup(w http.ResponseWriter, r *http.Request, identity Identi
 		Latitude: latitude, Longitude: longitude, Timezone: resolved.Timezone,
 		Method:           domain.RecommendedMethod(resolved.CountryCode),
 		Madhab:           domain.MadhabShafii,
-		HighLatitudeRule: domain.HighLatitudeAngleBased,
+		HighLatitudeRule: domain.HighLatitudeAngleBased, ImsakMinutes: domain.DefaultImsakMinutes,
+		ElevationMeters: h.elevation(r.Context(), latitude, longitude),
 	}
 	current, err := h.store.Profile(r.Context(), identity.UserID)
 	if err == nil {
-		profile.Method = current.Method
-		profile.Madhab = current.Madhab
-		profile.HighLatitudeRule = current.HighLatitudeRule
-		profile.Adjustments = current.Adjustments
-		profile.HijriAdjustment = current.HijriAdjustment
+		profile.CopyPreferences(current)
 	} else if !domain.IsNotFound(err) {
 		return fmt.Errorf("load profile: %w", err)
 	}
@@ -317,12 +355,40 @@ func (h *Handler) sendPrayerCard(w http.ResponseWriter, r *http.Request, identit
 }
 
 type settingsRequest struct {
-	Language         string         `json:"language"`
-	Method           domain.Method  `json:"method"`
-	Madhab           domain.Madhab  `json:"madhab"`
-	HighLatitudeRule string         `json:"high_latitude_rule"`
-	HijriAdjustment  int            `json:"hijri_adjustment"`
-	Adjustments      map[string]int `json:"adjustments"`
+	Language         string        `json:"language"`
+	Method           domain.Method `json:"method"`
+	Madhab           domain.Madhab `json:"madhab"`
+	HighLatitudeRule string        `json:"high_latitude_rule"`
+	HijriAdjustment  int           `json:"hijri_adjustment"`
+	// HijriCalendar is optional so cached clients that predate the calendar
+	// choice keep saving; nil keeps the stored calendar.
+	HijriCalendar *domain.HijriCalendar `json:"hijri_calendar"`
+	Adjustments   map[string]int        `json:"adjustments"`
+	// Custom is optional so cached clients that predate the custom method keep
+	// saving; nil preserves the stored parameters.
+	Custom *customMethodJSON `json:"custom"`
+	// The extended-times fields are optional for the same reason; nil keeps
+	// the stored value.
+	ImsakMinutes      *int  `json:"imsak_minutes"`
+	ShowExtendedTimes *bool `json:"show_extended_times"`
+	HideMakruhTimes   *bool `json:"hide_makruh_times"`
+	ElevationMeters   *int  `json:"elevation_meters"`
+	MoonEvents        *bool `json:"moon_events"`
+	IgnoreTimetables  *bool `json:"ignore_timetables"`
+	// Precaution is optional like the fields above; nil keeps the stored
+	// rounding and ihtiyat.
+	Precaution *precautionJSON `json:"precaution"`
+}
+
+type precautionJSON struct {
+	IhtiyatMinutes int               `json:"ihtiyat_minutes"`
+	Rounding       map[string]string `json:"rounding"`
+}
+
+type customMethodJSON struct {
+	FajrAngle           float64 `json:"fajr_angle"`
+	IshaAngle           float64 `json:"isha_angle"`
+	IshaIntervalMinutes int     `json:"isha_interval_minutes"`
 }
 
 func (h *Handler) updateSettings(w http.ResponseWriter, r *http.Request, identity Identity) error {
@@ -341,10 +407,14 @@ func (h *Handler) updateSettings(w http.ResponseWriter, r *http.Request, identit
 	if err != nil {
 		return fmt.Errorf("load profile: %w", err)
 	}
-	profile.Method = request.Method
+	validated.applyMethod(&profile)
+	validated.applyOptionalTimes(&profile)
 	profile.Madhab = request.Madhab
 	profile.HighLatitudeRule = validated.highLatitude
 	profile.HijriAdjustment = request.HijriAdjustment
+	if validated.hijri != nil {
+		profile.HijriCalendar = *validated.hijri
+	}
 	profile.Adjustments = validated.adjustments
 	if err := h.store.SetLanguage(r.Context(), identity.UserID, validated.locale.Code); err != nil {
 		return fmt.Errorf("save language: %w", err)
@@ -373,6 +443,42 @@ type remindersRequest struct {
 	OccasionMajor    *bool `json:"occasion_major"`
 	OccasionFasting  *bool `json:"occasion_fasting"`
 	OccasionObserved *bool `json:"occasion_observed"`
+	// Extended is optional like WhiteDays; nil preserves every extended-time
+	// reminder.
+	Extended *extendedRemindersJSON `json:"extended"`
+	// SuhoorMinutes and Iftar are optional for the same reason. A suhoor lead
+	// time of 0 turns the suhoor reminder off.
+	SuhoorMinutes *int  `json:"suhoor_minutes"`
+	Iftar         *bool `json:"iftar"`
+	// PersonalEvents is optional for the same reason.
+	PersonalEvents *bool `json:"personal_events"`
+}
+
+// extendedRemindersJSON keeps one flag per extended time instead of a list so
+// reminderResponse stays comparable.
+type extendedRemindersJSON struct {
+	Imsak     bool `json:"imsak"`
+	Ishraq    bool `json:"ishraq"`
+	Duha      bool `json:"duha"`
+	Midnight  bool `json:"midnight"`
+	LastThird bool `json:"last_third"`
+}
+
+func (e *extendedRemindersJSON) flag(prayer domain.Prayer) *bool {
+	switch prayer {
+	case domain.PrayerImsak:
+		return &e.Imsak
+	case domain.PrayerIshraq:
+		return &e.Ishraq
+	case domain.PrayerDuha:
+		return &e.Duha
+	case domain.PrayerMidnight:
+		return &e.Midnight
+	case domain.PrayerLastThird:
+		return &e.LastThird
+	default:
+		return nil
+	}
 }
 
 type preferencesRequest struct {
@@ -382,22 +488,101 @@ type preferencesRequest struct {
 
 type validatedSettings struct {
 	locale       i18n.Locale
+	method       domain.Method
+	custom       *domain.CustomMethod
 	highLatitude domain.HighLatitudeRule
+	hijri        *domain.HijriCalendar
 	adjustments  domain.Adjustments
+	imsakMinutes *int
+	showExtended *bool
+	hideMakruh   *bool
+	elevation    *int
+	moonEvents   *bool
+	ignoreTables *bool
+	precaution   *domain.Precaution
+}
+
+// applyMethod sets the method and, when the request carried them, the custom
+// parameters. A first switch to the custom method without parameters starts
+// from the defaults so the profile stays valid.
+func (v validatedSettings) applyMethod(profile *domain.PrayerProfile) {
+	profile.Method = v.method
+	if v.custom != nil {
+		profile.Custom = *v.custom
+	}
+	if profile.Method == domain.MethodCustom && profile.Custom.IsZero() {
+		profile.Custom = domain.DefaultCustomMethod()
+	}
+}
+
+// applyOptionalTimes sets the extended-time, makruh, elevation, precaution,
+// moon calendar and official timetable preferences that the request carried.
+func (v validatedSettings) applyOptionalTimes(profile *domain.PrayerProfile) {
+	if v.imsakMinutes != nil {
+		profile.ImsakMinutes = *v.imsakMinutes
+	}
+	if v.showExtended != nil {
+		profile.ShowExtendedTimes = *v.showExtended
+	}
+	if v.hideMakruh != nil {
+		profile.HideMakruhTimes = *v.hideMakruh
+	}
+	if v.elevation != nil {
+		profile.ElevationMeters = *v.elevation
+	}
+	if v.precaution != nil {
+		profile.Precaution = *v.precaution
+	}
+	if v.moonEvents != nil {
+		profile.MoonEvents = *v.moonEvents
+	}
+	if v.ignoreTables != nil {
+		profile.IgnoreTimetables = *v.ignoreTables
+	}
 }
 
 func validateSettings(request settingsRequest) (validatedSettings, error) {
 	locale, ok := supportedLocale(request.Language)
 	highLatitude := domain.HighLatitudeRule(request.HighLatitudeRule)
 	if !ok || !request.Method.Valid() || !request.Madhab.Valid() || !highLatitude.Valid() ||
-		request.HijriAdjustment < -2 || request.HijriAdjustment > 2 {
+		request.HijriAdjustment < -2 || request.HijriAdjustment > 2 ||
+		(request.HijriCalendar != nil && !request.HijriCalendar.Valid()) {
 		return validatedSettings{}, badRequest("invalid_settings")
 	}
 	adjustments, err := parseAdjustments(request.Adjustments)
 	if err != nil {
 		return validatedSettings{}, badRequest("invalid_adjustments")
 	}
-	return validatedSettings{locale: locale, highLatitude: highLatitude, adjustments: adjustments}, nil
+	if request.ImsakMinutes != nil && (*request.ImsakMinutes < 0 || *request.ImsakMinutes > domain.MaxImsakMinutes) {
+		return validatedSettings{}, badRequest("invalid_settings")
+	}
+	if request.ElevationMeters != nil && (*request.ElevationMeters < 0 || *request.ElevationMeters > domain.MaxElevationMeters) {
+		return validatedSettings{}, badRequest("invalid_settings")
+	}
+	validated := validatedSettings{
+		locale: locale, method: request.Method, highLatitude: highLatitude, hijri: request.HijriCalendar, adjustments: adjustments,
+		imsakMinutes: request.ImsakMinutes, showExtended: request.ShowExtendedTimes, hideMakruh: request.HideMakruhTimes,
+		elevation: request.ElevationMeters, moonEvents: request.MoonEvents, ignoreTables: request.IgnoreTimetables,
+	}
+	if request.Custom != nil {
+		custom := domain.CustomMethod{
+			FajrAngle:           request.Custom.FajrAngle,
+			IshaAngle:           request.Custom.IshaAngle,
+			IshaIntervalMinutes: request.Custom.IshaIntervalMinutes,
+		}
+		if err := custom.Validate(); err != nil {
+			return validatedSettings{}, badRequest("invalid_custom_method")
+		}
+		validated.custom = &custom
+	}
+	if request.Precaution != nil {
+		precaution, err := parsePrecaution(*request.Precaution)
+		if err != nil {
+			return validatedSettings{}, badRequest("invalid_settings")
+		}
+		validated.precaution = &precaution
+	}
+	return validated, nil
 }
 
 func validateReminders(request remindersRequest) error {
@@ -406,6 +591,9 @@ func validateReminders(request remindersRequest) error {
 		!domain.ValidPreReminderMinutes(request.PrePrayerMinutes) {
 		return badRequest("invalid_request")
 	}
+	if request.SuhoorMinutes != nil && *request.SuhoorMinutes != 0 && !domain.ValidSuhoorMinutes(*request.SuhoorMinutes) {
+		return badRequest("invalid_request")
+	}
 	return nil
 }
 
@@ -428,10 +616,14 @@ func (h *Handler) updatePreferences(w http.ResponseWriter, r *http.Request, iden
 	if err != nil {
 		return fmt.Errorf("load profile: %w", err)
 	}
-	profile.Method = request.Settings.Method
+	validated.applyMethod(&profile)
+	validated.applyOptionalTimes(&profile)
 	profile.Madhab = request.Settings.Madhab
 	profile.HighLatitudeRule = validated.highLatitude
 	profile.HijriAdjustment = request.Settings.HijriAdjustment
+	if validated.hijri != nil {
+		profile.HijriCalendar = *validated.hijri
+	}
 	profile.Adjustments = validated.adjustments
 	if err := h.store.SetLanguage(r.Context(), identity.UserID, validated.locale.Code); err != nil {
 		return fmt.Errorf("save language: %w", err)
@@ -472,7 +664,8 @@ func (h *Handler) updateReminders(w http.ResponseWriter, r *http.Request, identi
 		return err
 	}
 	if changed && (desired.Prayer || desired.Fasting || desired.WhiteDays || desired.Kahf ||
-		desired.OccasionMajor || desired.OccasionFasting || desired.OccasionObserved) {
+		desired.OccasionMajor || desired.OccasionFasting || desired.OccasionObserved || desired.PersonalEvents ||
+		desired.Extended != extendedRemindersJSON{} || desired.SuhoorMinutes > 0 || desired.Iftar) {
 		if err := h.planner.RebuildChat(r.Context(), identity.UserID, h.now()); err != nil {
 			return fmt.Errorf("rebuild reminders: %w", err)
 		}
@@ -495,10 +688,26 @@ func (h *Handler) applyReminders(ctx context.Context, chatID int64, request remi
 		OccasionMajor: *request.OccasionMajor, OccasionFasting: *request.OccasionFasting,
 		OccasionObserved: *request.OccasionObserved,
 		WhiteDays:        current.WhiteDays,
+		Extended:         current.Extended,
+		SuhoorMinutes:    current.SuhoorMinutes,
+		Iftar:            current.Iftar,
+		PersonalEvents:   current.PersonalEvents,
+	}
+	if request.PersonalEvents != nil {
+		desired.PersonalEvents = *request.PersonalEvents
+	}
+	if request.SuhoorMinutes != nil {
+		desired.SuhoorMinutes = *request.SuhoorMinutes
+	}
+	if request.Iftar != nil {
+		desired.Iftar = *request.Iftar
 	}
 	if request.WhiteDays != nil {
 		desired.WhiteDays = *request.WhiteDays
 	}
+	if request.Extended != nil {
+		desired.Extended = *request.Extended
+	}
 	if !desired.Prayer {
 		desired.PrePrayerMinutes = 0
 	}
@@ -532,6 +741,7 @@ func (h *Handler) applyReminders(ctx context.Context, chatID int64, request remi
 		{current.OccasionMajor, desired.OccasionMajor, domain.ReminderOccasionMajor, "major occasion"},
 		{current.OccasionFasting, desired.OccasionFasting, domain.ReminderOccasionFasting, "occasion fasting"},
 		{current.OccasionObserved, desired.OccasionObserved, domain.ReminderOccasionObserved, "commonly observed occasion"},
+		{current.PersonalEvents, desired.PersonalEvents, domain.ReminderPersonalEvents, "personal event"},
 	} {
 		if change.current == change.desired {
 			continue
@@ -540,6 +750,26 @@ func (h *Handler) applyReminders(ctx context.Context, chatID int64, request remi
 			return false, reminderResponse{}, fmt.Errorf("update %s reminders: %w", change.name, err)
 		}
 	}
+	for _, prayer := range domain.ExtendedTimes() {
+		enabled := *desired.Extended.flag(prayer)
+		if *current.Extended.flag(prayer) == enabled {
+			continue
+		}
+		if err := h.store.SetExtendedTimeRule(ctx, chatID, prayer, enabled); err != nil {
+			return false, reminderResponse{}, fmt.Errorf("update %s reminders: %w", prayer, err)
+		}
+	}
+	if current.SuhoorMinutes != desired.SuhoorMinutes {
+		err := h.store.SetRamadanRule(ctx, chatID, domain.ReminderSuhoor, desired.SuhoorMinutes, desired.SuhoorMinutes > 0)
+		if err != nil {
+			return false, reminderResponse{}, fmt.Errorf("update suhoor reminders: %w", err)
+		}
+	}
+	if current.Iftar != desired.Iftar {
+		if err := h.store.SetRamadanRule(ctx, chatID, domain.ReminderIftar, 0, desired.Iftar); err != nil {
+			return false, reminderResponse{}, fmt.Errorf("update iftar reminders: %w", err)
+		}
+	}
 	return current != desired, desired, nil
 }
 
@@ -553,11 +783,15 @@ type bootstrapResponse struct {
 	Tomorrow      *scheduleResponse            `json:"tomorrow,omitempty"`
 	Qibla         *qiblaResponse               `json:"qibla,omitempty"`
 	Calendar      calendarSubscriptionResponse `json:"calendar"`
-	Occasions     []occasionResponse           `json:"occasions,omitempty"`
-	Reminders     reminderResponse             `json:"reminders"`
-	Nisab         *nisabResponse               `json:"nisab,omitempty"`
-	Options       optionsResponse              `json:"options"`
-	Labels        map[string]string            `json:"labels"`
+	// FastingCalendar is the separate fasting feed.
+	FastingCalendar calendarSubscriptionResponse `json:"fasting_calendar"`
+	Occasions       []occasionResponse           `json:"occasions,omitempty"`
+	Reminders       reminderResponse             `json:"reminders"`
+	// CustomReminders are the user's own reminders, oldest first.
+	CustomReminders []customReminderJSON `json:"custom_reminders"`
+	Nisab           *nisabResponse       `json:"nisab,omitempty"`
+	Options         optionsResponse      `json:"options"`
+	Labels          map[string]string    `json:"labels"`
 }
 
 // nisabResponse carries everything the Mini App Zakat calculator needs to show
@@ -580,12 +814,24 @@ type userResponse struct {
 }
 
 type profileResponse struct {
-	Timezone         string         `json:"timezone"`
-	Method           domain.Method  `json:"method"`
-	Madhab           domain.Madhab  `json:"madhab"`
-	HighLatitudeRule string         `json:"high_latitude_rule"`
-	HijriAdjustment  int            `json:"hijri_adjustment"`
-	Adjustments      map[string]int `json:"adjustments"`
+	Timezone          string           `json:"timezone"`
+	Method            domain.Method    `json:"method"`
+	Madhab            domain.Madhab    `json:"madhab"`
+	HighLatitudeRule  string           `json:"high_latitude_rule"`
+	HijriAdjustment   int              `json:"hijri_adjustment"`
+	HijriCalendar     string           `json:"hijri_calendar"`
+	Adjustments       map[string]int   `json:"adjustments"`
+	Custom            customMethodJSON `json:"custom"`
+	ImsakMinutes      int              `json:"imsak_minutes"`
+	ShowExtendedTimes bool             `json:"show_extended_times"`
+	HideMakruhTimes   bool             `json:"hide_makruh_times"`
+	ElevationMeters   int              `json:"elevation_meters"`
+	Precaution        precautionJSON   `json:"precaution"`
+	MoonEvents        bool             `json:"moon_events"`
+	IgnoreTimetables  bool             `json:"ignore_timetables"`
+	// Timetable names the official timetable covering the profile, even while
+	// the profile ignores it.
+	Timetable string `json:"timetable,omitempty"`
 }
 
 type scheduleResponse struct {
@@ -593,6 +839,30 @@ type scheduleResponse struct {
 	Hijri     string           `json:"hijri"`
 	Timezone  string           `json:"timezone"`
 	Prayers   []prayerResponse `json:"prayers"`
+	// Extended is only filled when the profile shows the extended times.
+	Extended []prayerResponse `json:"extended,omitempty"`
+	// Makruh is left out when the profile hides the makruh windows.
+	Makruh []makruhResponse `json:"makruh,omitempty"`
+	// Timetable labels times taken from an official timetable.
+	Timetable string `json:"timetable,omitempty"`
+	// Moon is left out when the moon cannot be calculated for the day.
+	Moon *moonResponse `json:"moon,omitempty"`
+}
+
+type moonResponse struct {
+	Phase   moon.Phase `json:"phase"`
+	Emoji   string     `json:"emoji"`
+	Summary string     `json:"summary"`
+	// Crescent tells when to look for the next crescent, from a few days
+	// before a new moon until it is expected.
+	Crescent string `json:"crescent,omitempty"`
+}
+
+type makruhResponse struct {
+	ID    domain.MakruhWindow `json:"id"`
+	Name  string              `json:"name"`
+	Start string              `json:"start"`
+	End   string              `json:"end"`
 }
 
 type prayerResponse struct {
@@ -616,6 +886,11 @@ type reminderResponse struct {
 	OccasionMajor    bool `json:"occasion_major"`
 	OccasionFasting  bool `json:"occasion_fasting"`
 	OccasionObserved bool `json:"occasion_observed"`
+	SuhoorMinutes    int  `json:"suhoor_minutes"`
+	Iftar            bool `json:"iftar"`
+	PersonalEvents   bool `json:"personal_events"`
+
+	Extended extendedRemindersJSON `json:"extended"`
 }
 
 type occasionSourceResponse struct {
@@ -624,16 +899,19 @@ type occasionSourceResponse struct {
 }
 
 type occasionResponse struct {
-	ID            string                   `json:"id"`
-	Emoji         string                   `json:"emoji"`
-	Category      string                   `json:"category"`
-	CategoryLabel string                   `json:"category_label"`
-	Title         string                   `json:"title"`
-	Summary       string                   `json:"summary"`
-	Action        string                   `json:"action"`
-	Gregorian     string                   `json:"gregorian"`
-	Hijri         string                   `json:"hijri"`
-	Sources       []occasionSourceResponse `json:"sources"`
+	ID            string `json:"id"`
+	Emoji         string `json:"emoji"`
+	Category      string `json:"category"`
+	CategoryLabel string `json:"category_label"`
+	Title         string `json:"title"`
+	Summary       string `json:"summary"`
+	Action        string `json:"action"`
+	// Day names the listed day of a multi-day observance.
+	Day       string                   `json:"day,omitempty"`
+	NoFasting bool                     `json:"no_fasting,omitempty"`
+	Gregorian string                   `json:"gregorian"`
+	Hijri     string                   `json:"hijri"`
+	Sources   []occasionSourceResponse `json:"sources"`
 }
 
 type option struct {
@@ -642,11 +920,20 @@ type option struct {
 }
 
 type optionsResponse struct {
-	Languages    []option `json:"languages"`
-	Methods      []option `json:"methods"`
-	Madhabs      []option `json:"madhabs"`
-	HighLatitude []option `json:"high_latitude"`
-	PreReminders []option `json:"pre_reminders"`
+	Languages     []option `json:"languages"`
+	Methods       []option `json:"methods"`
+	Madhabs       []option `json:"madhabs"`
+	HighLatitude  []option `json:"high_latitude"`
+	Hijri         []option `json:"hijri_calendars"`
+	PreReminders  []option `json:"pre_reminders"`
+	ImsakMinutes  []option `json:"imsak_minutes"`
+	SuhoorMinutes []option `json:"suhoor_minutes"`
+	ExtendedTimes []option `json:"extended_times"`
+	Ihtiyat       []option `json:"ihtiyat_minutes"`
+	Roundings     []option `json:"roundings"`
+	Recurrences   []option `json:"recurrences"`
+	Anchors       []option `json:"anchors"`
+	Weekdays      []option `json:"weekdays"`
 }
 
 func (h *Handler) build(ctx context.Context, identity Identity) (bootstrapResponse, error) {
@@ -685,16 +972,29 @@ func (h *Handler) build(ctx context.Context, identity Identity) (bootstrapRespon
 	if havePrices {
 		response.Nisab = buildNisab(prices, profile)
 	}
-	subscription, err := h.store.CalendarSubscription(ctx, identity.UserID)
-	if err == nil {
-		response.Calendar.Enabled = subscription.Enabled
-	} else if !domain.IsNotFound(err) {
-		return bootstrapResponse{}, fmt.Errorf("load calendar subscription: %w", err)
+	for feed, target := range map[domain.CalendarFeed]*calendarSubscriptionResponse{
+		domain.CalendarFeedPrayer: &response.Calendar, domain.CalendarFeedFasting: &response.FastingCalendar,
+	} {
+		subscription, err := h.store.CalendarSubscription(ctx, identity.UserID, feed)
+		if err == nil {
+			target.Enabled = subscription.Enabled
+		} else if !domain.IsNotFound(err) {
+			return bootstrapResponse{}, fmt.Errorf("load %s calendar subscription: %w", feed, err)
+		}
+	}
+	if response.CustomReminders, err = h.customReminders(ctx, identity.UserID, locale); err != nil {
+		return bootstrapResponse{}, err
 	}
 	response.Profile = &profileResponse{
 		Timezone: profile.Timezone, Method: profile.Method, Madhab: profile.Madhab,
 		HighLatitudeRule: string(profile.HighLatitudeRule), HijriAdjustment: profile.HijriAdjustment,
-		Adjustments: adjustmentMap(profile.Adjustments),
+		HijriCalendar: string(profile.HijriCalendar.OrDefault()),
+		Adjustments:   adjustmentMap(profile.Adjustments),
+		Custom:        customMethodResponse(profile.Custom),
+		ImsakMinutes:  profile.ImsakMinutes, ShowExtendedTimes: profile.ShowExtendedTimes,
+		HideMakruhTimes: profile.HideMakruhTimes, ElevationMeters: profile.ElevationMeters,
+		Precaution: precautionResponse(profile.Precaution), MoonEvents: profile.MoonEvents,
+		IgnoreTimetables: profile.IgnoreTimetables,
 	}
 	direction, err := qibla.Calculate(profile.Latitude, profile.Longitude)
 	if err != nil {
@@ -714,21 +1014,53 @@ func (h *Handler) build(ctx context.Context, identity Identity) (bootstrapRespon
 	if err != nil {
 		return bootstrapResponse{}, fmt.Errorf("calculate tomorrow: %w", err)
 	}
+	response.Profile.Timetable = today.Timetable
+	if profile.IgnoreTimetables {
+		if response.Profile.Timetable, err = prayertime.CoveringTimetable(ctx, h.calculator, now, profile); err != nil {
+			return bootstrapResponse{}, fmt.Errorf("find the covering timetable: %w", err)
+		}
+	}
 	formattedToday := formatSchedule(today, profile, locale)
 	formattedTomorrow := formatSchedule(tomorrow, profile, locale)
 	response.Today = &formattedToday
 	response.Tomorrow = &formattedTomorrow
-	upcoming, err := occasions.Between(now.In(today.Date.Location()), 400, profile.HijriAdjustment)
+	calendar, err := hijri.ForProfile(profile)
+	if err != nil {
+		return bootstrapResponse{}, fmt.Errorf("load Hijri calendar: %w", err)
+	}
+	upcoming, err := occasions.Between(now.In(today.Date.Location()), 400, calendar, profile.CountryCode, occasions.Personal(profile.PersonalEvents)...)
 	if err != nil {
 		return bootstrapResponse{}, fmt.Errorf("calculate upcoming Islamic occasions: %w", err)
 	}
+	// The list shows the next three catalog occasions and up to three personal
+	// events that come before the last of them. A multi-day observance is
+	// listed once, on its next day.
+	var catalogItems, personalItems int
+	listed := map[string]bool{}
 	for _, occurrence := range upcoming {
+		if listed[occurrence.Definition.ID] {
+			continue
+		}
+		listed[occurrence.Definition.ID] = true
 		copy := locale.Occasion(occurrence.Definition.ID)
+		if occurrence.Definition.Category == occasions.CategoryPersonal {
+			if personalItems == 3 {
+				continue
+			}
+			personalItems++
+			copy = locale.PersonalOccasion(occurrence.Definition.Title, !occurrence.Definition.Gregorian)
+		} else {
+			if catalogItems == 3 {
+				break
+			}
+			catalogItems++
+		}
 		item := occasionResponse{
 			ID: occurrence.Definition.ID, Emoji: occurrence.Definition.Emoji,
 			Category:      string(occurrence.Definition.Category),
 			CategoryLabel: locale.OccasionCategory(string(occurrence.Definition.Category)),
 			Title:         copy.Title, Summary: copy.Summary, Action: copy.Action,
+			Day: locale.OccasionDay(occurrence), NoFasting: occurrence.Definition.NoFasting,
 			Gregorian: fmt.Sprintf("%d %s %d", occurrence.Date.Day(), locale.Month(int(occurrence.Date.Month())), occurrence.Date.Year()),
 			Hijri:     fmt.Sprintf("%d %s %d", occurrence.Hijri.Day, locale.HijriMonth(occurrence.Hijri.Month), occurrence.Hijri.Year),
 		}
@@ -736,9 +1068,6 @@ func (h *Handler) build(ctx context.Context, identity Identity) (bootstrapRespon
 			item.Sources = append(item.Sources, occasionSourceResponse{Label: source.Label, URL: source.URL})
 		}
 		response.Occasions = append(response.Occasions, item)
-		if len(response.Occasions) == 3 {
-			break
-		}
 	}
 	return response, nil
 }
@@ -773,10 +1102,20 @@ func (h *Handler) reminderState(ctx context.Context, chatID int64) (reminderResp
 			state.OccasionFasting = true
 		case domain.ReminderOccasionObserved:
 			state.OccasionObserved = true
+		case domain.ReminderPersonalEvents:
+			state.PersonalEvents = true
 		case domain.ReminderAt:
 			state.Prayer = true
 		case domain.ReminderBefore:
 			state.PrePrayerMinutes = rule.OffsetMinutes
+		case domain.ReminderExtendedTime:
+			if flag := state.Extended.flag(rule.Prayer); flag != nil {
+				*flag = true
+			}
+		case domain.ReminderSuhoor:
+			state.SuhoorMinutes = rule.OffsetMinutes
+		case domain.ReminderIftar:
+			state.Iftar = true
 		}
 	}
 	return state, nil
@@ -787,16 +1126,61 @@ func formatSchedule(schedule domain.DaySchedule, profile domain.PrayerProfile, l
 		Gregorian: fmt.Sprintf("%d %s %d", schedule.Date.Day(), locale.Month(int(schedule.Date.Month())), schedule.Date.Year()),
 		Timezone:  profile.Timezone,
 	}
-	if date, err := hijri.FromGregorian(schedule.Date, profile.HijriAdjustment); err == nil {
-		result.Hijri = fmt.Sprintf("%d %s %d %s", date.Day, locale.HijriMonth(date.Month), date.Year, locale.Message("hijri_era"))
+	if schedule.Timetable != "" {
+		result.Timetable = fmt.Sprintf(locale.Message("official_timetable"), schedule.Timetable)
+	}
+	if calendar, err := hijri.ForProfile(profile); err == nil {
+		if date, err := calendar.Date(schedule.Date); err == nil {
+			result.Hijri = fmt.Sprintf("%d %s %d %s", date.Day, locale.HijriMonth(date.Month), date.Year, locale.Message("hijri_era"))
+		}
 	}
+	result.Moon = formatMoon(schedule.Date, profile, locale)
 	for _, prayer := range prayers() {
+		if khutbah, _, ok := profile.Jumuah.On(schedule.Date); ok && prayer == domain.PrayerDhuhr {
+			// Friday keeps the Dhuhr slot, so cached clients still find it.
+			result.Prayers = append(result.Prayers, prayerResponse{
+				ID: prayer, Name: locale.Message("jumuah"), Emoji: "🕌", Time: khutbah.Format("15:04"),
+			})
+			continue
+		}
 		if at, ok := schedule.At(prayer); ok {
 			result.Prayers = append(result.Prayers, prayerResponse{
 				ID: prayer, Name: locale.Prayer(prayer), Emoji: prayerEmoji(prayer), Time: at.Format("15:04"),
 			})
 		}
 	}
+	if profile.ShowExtendedTimes {
+		for _, prayer := range domain.ExtendedTimes() {
+			if at, ok := schedule.At(prayer); ok {
+				result.Extended = append(result.Extended, prayerResponse{
+					ID: prayer, Name: locale.Prayer(prayer), Emoji: prayerEmoji(prayer), Time: at.Format("15:04"),
+				})
+			}
+		}
+	}
+	if !profile.HideMakruhTimes {
+		for _, window := range domain.MakruhWindows() {
+			if span, ok := schedule.MakruhWindow(window); ok {
+				result.Makruh = append(result.Makruh, makruhResponse{
+					ID: window, Name: locale.MakruhWindow(window),
+					Start: span.Start.Format("15:04"), End: span.End.Format("15:04"),
+				})
+			}
+		}
+	}
+	return result
+}
+
+// formatMoon describes the moon on date and any crescent it waits for.
+func formatMoon(date time.Time, profile domain.PrayerProfile, locale i18n.Locale) *moonResponse {
+	state, err := moon.OnDay(date, profile.Latitude, profile.Longitude)
+	if err != nil {
+		return nil
+	}
+	result := &moonResponse{Phase: state.Phase, Emoji: state.Phase.Emoji(), Summary: locale.MoonSummary(state)}
+	if crescent, ok, err := moon.Watch(date, profile.Latitude, profile.Longitude); err == nil && ok {
+		result.Crescent = locale.CrescentWatch(crescent, date.Location())
+	}
 	return result
 }
 
@@ -846,9 +1230,12 @@ func options(locale i18n.Locale) optionsResponse {
 	for _, madhab := range []domain.Madhab{domain.MadhabShafii, domain.MadhabHanafi} {
 		result.Madhabs = append(result.Madhabs, option{Value: string(madhab), Label: locale.Madhab(madhab)})
 	}
-	for _, rule := range []domain.HighLatitudeRule{domain.HighLatitudeAngleBased, domain.HighLatitudeMiddleNight, domain.HighLatitudeSeventhNight} {
+	for _, rule := range domain.SupportedHighLatitudeRules() {
 		result.HighLatitude = append(result.HighLatitude, option{Value: string(rule), Label: locale.HighLatitudeRule(rule)})
 	}
+	for _, system := range domain.SupportedHijriCalendars() {
+		result.Hijri = append(result.Hijri, option{Value: string(system), Label: locale.HijriCalendar(system)})
+	}
 	for _, minutes := range domain.SupportedPreReminderMinutes() {
 		label := locale.Message("pre_reminder_off")
 		if minutes > 0 {
@@ -856,6 +1243,45 @@ func options(locale i18n.Locale) optionsResponse {
 		}
 		result.PreReminders = append(result.PreReminders, option{Value: fmt.Sprint(minutes), Label: label})
 	}
+	for _, minutes := range domain.SupportedImsakMinutes() {
+		result.ImsakMinutes = append(result.ImsakMinutes, option{
+			Value: fmt.Sprint(minutes), Label: fmt.Sprintf(locale.Message("imsak_minutes"), minutes),
+		})
+	}
+	result.SuhoorMinutes = []option{{Value: "0", Label: locale.Message("suhoor_off")}}
+	for _, minutes := range domain.SupportedSuhoorMinutes() {
+		result.SuhoorMinutes = append(result.SuhoorMinutes, option{
+			Value: fmt.Sprint(minutes), Label: fmt.Sprintf(locale.Message("suhoor_minutes"), minutes),
+		})
+	}
+	for _, prayer := range domain.ExtendedTimes() {
+		result.ExtendedTimes = append(result.ExtendedTimes, option{
+			Value: string(prayer), Label: prayerEmoji(prayer) + " " + locale.Prayer(prayer),
+		})
+	}
+	for _, minutes := range domain.SupportedIhtiyatMinutes() {
+		result.Ihtiyat = append(result.Ihtiyat, option{
+			Value: fmt.Sprint(minutes), Label: fmt.Sprintf(locale.Message("ihtiyat_value"), minutes),
+		})
+	}
+	for _, rounding := range domain.SupportedRoundings() {
+		result.Roundings = append(result.Roundings, option{Value: string(rounding), Label: i18n.RoundingSymbol(rounding)})
+	}
+	recurrenceKeys := map[domain.Recurrence]string{
+		domain.RecurrenceDaily: "custom_daily", domain.RecurrenceWeekly: "custom_weekly_option",
+		domain.RecurrenceHijriMonthly: "custom_hijri_option", domain.RecurrenceOnce: "custom_once_option",
+	}
+	for _, recurrence := range domain.SupportedRecurrences() {
+		result.Recurrences = append(result.Recurrences, option{Value: string(recurrence), Label: locale.Message(recurrenceKeys[recurrence])})
+	}
+	for _, prayer := range prayers() {
+		result.Anchors = append(result.Anchors, option{Value: string(prayer), Label: prayerEmoji(prayer) + " " + locale.Prayer(prayer)})
+	}
+	for _, day := range []time.Weekday{
+		time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday, time.Sunday,
+	} {
+		result.Weekdays = append(result.Weekdays, option{Value: fmt.Sprint(int(day)), Label: locale.Weekday(day)})
+	}
 	return result
 }
 
@@ -884,6 +1310,42 @@ func parseAdjustments(values map[string]int) (domain.Adjustments, error) {
 	}, nil
 }
 
+// customMethodResponse reports the stored custom parameters, or the defaults a
+// switch to the custom method would start from when none were saved yet.
+func customMethodResponse(custom domain.CustomMethod) customMethodJSON {
+	if custom.IsZero() {
+		custom = domain.DefaultCustomMethod()
+	}
+	return customMethodJSON{
+		FajrAngle: custom.FajrAngle, IshaAngle: custom.IshaAngle,
+		IshaIntervalMinutes: custom.IshaIntervalMinutes,
+	}
+}
+
+// parsePrecaution reads the ihtiyat and a rounding for each of the six times.
+func parsePrecaution(value precautionJSON) (domain.Precaution, error) {
+	precaution := domain.Precaution{IhtiyatMinutes: value.IhtiyatMinutes}
+	if len(value.Rounding) != len(prayers()) {
+		return domain.Precaution{}, fmt.Errorf("all prayer roundings are required")
+	}
+	for _, prayer := range prayers() {
+		rounding, ok := value.Rounding[string(prayer)]
+		if !ok {
+			return domain.Precaution{}, fmt.Errorf("all prayer roundings are required")
+		}
+		precaution.SetRounding(prayer, domain.Rounding(rounding))
+	}
+	return precaution, precaution.Validate()
+}
+
+func precautionResponse(value domain.Precaution) precautionJSON {
+	rounding := make(map[string]string, len(prayers()))
+	for _, prayer := range prayers() {
+		rounding[string(prayer)] = string(value.Rounding(prayer))
+	}
+	return precautionJSON{IhtiyatMinutes: value.IhtiyatMinutes, Rounding: rounding}
+}
+
 func adjustmentMap(value domain.Adjustments) map[string]int {
 	return map[string]int{
 		"fajr": value.Fajr, "sunrise": value.Sunrise, "dhuhr": value.Dhuhr,
@@ -907,6 +1369,16 @@ func prayerEmoji(prayer domain.Prayer) string {
 		return "🌤"
 	case domain.PrayerMaghrib:
 		return "🌇"
+	case domain.PrayerImsak:
+		return "⏳"
+	case domain.PrayerIshraq:
+		return "🌄"
+	case domain.PrayerDuha:
+		return "🌞"
+	case domain.PrayerMidnight:
+		return "🕛"
+	case domain.PrayerLastThird:
+		return "🤲"
 	default:
 		return "🌌"
 	}
@@ -952,19 +1424,64 @@ func labels(locale i18n.Locale) map[string]string {
 		"share_location": locale.Button("share_location"), "language": locale.Button(i18n.ActionLanguage),
 		"method": locale.Message("method"), "madhab": locale.Message("madhab"),
 		"highlat": locale.Message("highlat"), "adjustments": locale.Message("adjustments"),
-		"hijri": locale.Message("hijri_date"), "prayer_reminders": locale.Button("prayer_reminders"),
-		"pre_prayer_reminder": locale.Message("pre_prayer_reminder"),
-		"fasting_reminders":   locale.Button("fasting_reminders"), "kahf_reminders": locale.Button("kahf_reminders"),
+		"hijri": locale.Message("hijri_date"), "hijri_calendar": locale.Message("hijri_calendar"),
+		"prayer_reminders":  locale.Button("prayer_reminders"),
+		"custom_fajr_angle": locale.Message("custom_fajr_angle"), "custom_isha_angle": locale.Message("custom_isha_angle"),
+		"custom_isha_interval": fmt.Sprintf("%s (0 = %s)", locale.Message("custom_isha_interval"), locale.Message("custom_isha_by_angle")),
+		"pre_prayer_reminder":  locale.Message("pre_prayer_reminder"),
+		"fasting_reminders":    locale.Button("fasting_reminders"), "kahf_reminders": locale.Button("kahf_reminders"),
 		"fasting_schedule": locale.Message("fasting_schedule"), "kahf_schedule": locale.Message("kahf_schedule"),
 		"white_days_reminders": locale.Button("white_days_reminders"),
 		"white_days_schedule":  locale.Message("white_days_schedule"),
-		"occasions_title":      locale.OccasionUI("title"), "occasions_help": locale.OccasionUI("help"),
+		"extended_times_title": locale.Message("extended_times_title"),
+		"show_extended_times":  locale.Message("show_extended_times"),
+		"imsak":                locale.Prayer(domain.PrayerImsak),
+		"extended_reminders":   locale.Button("extended_reminders"),
+		"makruh_title":         locale.Message("makruh_title"), "show_makruh": locale.Message("show_makruh"),
+		"moon_events": locale.Message("moon_calendar_events"), "moon_events_help": locale.Message("moon_calendar_help"),
+		"timetable_title": locale.Message("timetable_title"), "use_timetable": locale.Message("use_timetable"),
+		"timetable_covers": fmt.Sprintf(locale.Message("timetable_covers"), "{name}"),
+		"elevation":        fmt.Sprintf("%s (m)", locale.Message("elevation")),
+		"precaution":       locale.Message("precaution"),
+		"month_title":      locale.Message("month_title"), "month_help": locale.Message("month_help"),
+		"month_sent": locale.Message("month_sent"), "month_failed": locale.Message("month_failed"),
+		"ramadan_title": locale.Message("ramadan_title"), "ramadan_help": locale.Message("ramadan_help"),
+		"ramadan_suhoor": locale.Message("ramadan_suhoor"), "ramadan_iftar": locale.Message("ramadan_iftar"),
+		"ramadan_schedule": locale.Message("ramadan_schedule"), "iftar_reminder": locale.Message("iftar_reminder"),
+		"ramadan_reminders": locale.Button("ramadan_reminders"),
+		"convert_title":     locale.Message("convert_title"), "convert_invalid": locale.Message("convert_invalid"),
+		"convert_help": copy.ConvertHelp, "convert_action": copy.ConvertAction,
+		"fasting_title": locale.Message("fasting_title"), "fasting_note": locale.Message("fasting_note"),
+		"fasting_obligatory": locale.Message("fasting_obligatory"), "fasting_recommended": locale.Message("fasting_recommended"),
+		"fasting_prohibited": locale.Message("fasting_prohibited"),
+		"fasting_previous":   locale.Message("fasting_previous"), "fasting_next": locale.Message("fasting_next"),
+		"fasting_calendar_help": locale.Message("fasting_calendar_help"),
+		"fasts_title":           locale.Message("fasts_title"), "fasts_kept": locale.Message("fasts_kept"),
+		"fasts_made_up": locale.Message("fasts_made_up"), "fasts_missed": locale.Message("fasts_missed"),
+		"fasts_carried": locale.Message("fasts_carried"), "fasts_owed": locale.Message("fasts_owed"),
+		"fasts_suggestions": locale.Message("fasts_suggestions"), "fasts_fasting": locale.Message("fasts_fasting"),
+		"fasts_makeup": locale.Message("fasts_makeup"), "fasts_not_today": locale.Message("fasts_not_today"),
+		"fasts_clear": locale.Message("fasts_clear"), "fasts_prohibited": locale.Message("fasts_prohibited"),
+		"fasts_ramadan_makeup": locale.Message("fasts_ramadan_makeup"),
+		"custom_title":         locale.Message("custom_title"), "custom_empty": locale.Message("custom_empty"),
+		"custom_limit":   fmt.Sprintf(locale.Message("custom_limit"), domain.MaxCustomReminders),
+		"custom_invalid": locale.Message("custom_invalid"), "custom_past": locale.Message("custom_past"),
+		"custom_text": locale.Message("custom_text"), "custom_repeat": locale.Message("custom_repeat"),
+		"custom_clock": locale.Message("custom_clock"), "custom_prayer": locale.Message("custom_prayer"),
+		"custom_offset": locale.Message("custom_offset"), "custom_weekdays": locale.Message("custom_weekdays"),
+		"custom_hijri_day": locale.Message("custom_hijri_day"), "custom_date": locale.Message("custom_date"),
+		"custom_add": locale.Message("custom_add"), "custom_edit": locale.Message("custom_edit"),
+		"custom_delete": locale.Message("custom_delete"), "custom_cancel": locale.Message("custom_cancel"),
+		"occasions_title": locale.OccasionUI("title"), "occasions_help": locale.OccasionUI("help"),
 		"occasions_disclaimer": locale.OccasionUI("disclaimer"),
 		"occasion_recommended": locale.OccasionUI("recommended"), "occasion_sources": locale.OccasionUI("sources"),
+		"occasion_no_fasting":         locale.OccasionUI("no_fasting"),
 		"occasion_major_reminders":    locale.OccasionUI("major_reminders"),
 		"occasion_fasting_reminders":  locale.OccasionUI("fasting_reminders"),
 		"occasion_observed_reminders": locale.OccasionUI("observed_reminders"),
 		"occasion_schedule":           locale.OccasionUI("schedule"),
+		"personal_event_reminders":    locale.OccasionUI("personal_reminders"),
+		"personal_event_schedule":     locale.OccasionUI("personal_schedule"),
 		"save":                        copy.Save, "saved": copy.Saved, "loading": copy.Loading,
 		"location_help": copy.LocationHelp, "location_error": copy.LocationError,
 		"open_in_telegram": copy.OpenInTelegram, "temporary_failure": copy.TemporaryFailure,
@@ -1020,6 +1537,7 @@ type miniAppCopy struct {
 	ZakatDisclaimer, ZakatUpdated                         string
 	NavPrayer, NavDates, NavZakat, NavPlaces, NavSettings string
 	PlacesTitle, PlacesHelp                               string
+	ConvertHelp, ConvertAction                            string
 }
 
 var miniCopy = map[string]miniAppCopy{
@@ -1056,6 +1574,7 @@ var miniCopy = map[string]miniAppCopy{
 		NavPrayer:       "Prayer", NavDates: "Dates", NavZakat: "Zakat", NavPlaces: "Places", NavSettings: "Settings",
 		PlacesTitle: "Prayer times anywhere",
 		PlacesHelp:  "Drag the map to any place to see its prayer times.",
+		ConvertHelp: "Type a date in either calendar, such as 27 Rajab 1447 or 16.03.2026.", ConvertAction: "Convert",
 	},
 	"ar": {
 		Save: "حفظ التغييرات", Saved: "تم الحفظ", Loading: "جارٍ تحميل مواقيت الصلاة…",
@@ -1090,6 +1609,7 @@ var miniCopy = map[string]miniAppCopy{
 		NavPrayer:       "الصلاة", NavDates: "المناسبات", NavZakat: "الزكاة", NavPlaces: "أماكن", NavSettings: "الإعدادات",
 		PlacesTitle: "مواقيت الصلاة في أي مكان",
 		PlacesHelp:  "حرّك الخريطة إلى أي مكان لعرض مواقيت الصلاة فيه.",
+		ConvertHelp: "اكتب تاريخًا بأي من التقويمين، مثل 27 رجب 1447 أو 16.03.2026.", ConvertAction: "تحويل",
 	},
 	"es": {
 		Save: "Guardar cambios", Saved: "Guardado", Loading: "Cargando horarios de oración…",
@@ -1124,6 +1644,7 @@ var miniCopy = map[string]miniAppCopy{
 		NavPrayer:       "Oración", NavDates: "Fechas", NavZakat: "Zakat", NavPlaces: "Lugares", NavSettings: "Ajustes",
 		PlacesTitle: "Horarios en cualquier lugar",
 		PlacesHelp:  "Arrastra el mapa a cualquier lugar para ver sus horarios de oración.",
+		ConvertHelp: "Escribe una fecha en cualquiera de los dos calendarios, como 27 Rayab 1447 o 16.03.2026.", ConvertAction: "Convertir",
 	},
 	"fr": {
 		Save: "Enregistrer", Saved: "Enregistré", Loading: "Chargement des horaires de prière…",
@@ -1158,6 +1679,7 @@ var miniCopy = map[string]miniAppCopy{
 		NavPrayer:       "Prière", NavDates: "Dates", NavZakat: "Zakat", NavPlaces: "Lieux", NavSettings: "Réglages",
 		PlacesTitle: "Horaires n’importe où",
 		PlacesHelp:  "Faites glisser la carte vers n’importe quel lieu pour voir ses horaires de prière.",
+		ConvertHelp: "Saisissez une date dans l’un ou l’autre calendrier, comme 27 Rajab 1447 ou 16.03.2026.", ConvertAction: "Convertir",
 	},
 	"ru": {
 		Save: "Сохранить", Saved: "Сохранено", Loading: "Загружаем время намаза…",
@@ -1192,6 +1714,7 @@ var miniCopy = map[string]miniAppCopy{
 		NavPrayer:       "Намаз", NavDates: "Даты", NavZakat: "Закят", NavPlaces: "Места", NavSettings: "Настройки",
 		PlacesTitle: "Время намаза где угодно",
 		PlacesHelp:  "Перетащите карту на любое место, чтобы увидеть время намаза.",
+		ConvertHelp: "Введите дату в любом из календарей, например 27 Раджаб 1447 или 16.03.2026.", ConvertAction: "Перевести",
 	},
 	"tr": {
 		Save: "Değişiklikleri kaydet", Saved: "Kaydedildi", Loading: "Namaz vakitleri yükleniyor…",
@@ -1226,6 +1749,7 @@ var miniCopy = map[string]miniAppCopy{
 		NavPrayer:       "Namaz", NavDates: "Günler", NavZakat: "Zekât", NavPlaces: "Yerler", NavSettings: "Ayarlar",
 		PlacesTitle: "Her yerde namaz vakitleri",
 		PlacesHelp:  "Namaz vakitlerini görmek için haritayı istediğiniz yere sürükleyin.",
+		ConvertHelp: "İki takvimden birinde bir tarih yazın, örneğin 27 Recep 1447 veya 16.03.2026.", ConvertAction: "Çevir",
 	},
 	"uz": {
 		Save: "O‘zgarishlarni saqlash", Saved: "Saqlandi", Loading: "Namoz vaqtlari yuklanmoqda…",
@@ -1260,6 +1784,7 @@ var miniCopy = map[string]miniAppCopy{
 		NavPrayer:       "Namoz", NavDates: "Sanalar", NavZakat: "Zakot", NavPlaces: "Joylar", NavSettings: "Sozlamalar",
 		PlacesTitle: "Istalgan joyda namoz vaqtlari",
 		PlacesHelp:  "Namoz vaqtlarini ko‘rish uchun xaritani istalgan joyga suring.",
+		ConvertHelp: "Istalgan taqvimdagi sanani yozing, masalan 27 Rajab 1447 yoki 16.03.2026.", ConvertAction: "O‘girish",
 	},
 	"tt": {
 		Save: "Үзгәрешләрне саклау", Saved: "Сакланды", Loading: "Намаз вакытлары йөкләнә…",
@@ -1294,5 +1819,6 @@ var miniCopy = map[string]miniAppCopy{
 		NavPrayer:       "Намаз", NavDates: "Даталар", NavZakat: "Зәкят", NavPlaces: "Урыннар", NavSettings: "Көйләүләр",
 		PlacesTitle: "Теләсә кайда намаз вакытлары",
 		PlacesHelp:  "Намаз вакытларын күрер өчен картаны теләсә кайсы урынга күчерегез.",
+		ConvertHelp: "Теләсә кайсы календарьда дата языгыз, мәсәлән 27 Рәҗәб 1447 яки 16.03.2026.", ConvertAction: "Күчерү",
 	},
 }
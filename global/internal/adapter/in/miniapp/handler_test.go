@@ -15,7 +15,10 @@ import (
 	"testing"
 	"time"
 
+	"github.com/escalopa/prayer-bot/global/internal/core/fasting"
+	"github.com/escalopa/prayer-bot/global/internal/core/hijri"
 	"github.com/escalopa/prayer-bot/global/internal/core/i18n"
+	"github.com/escalopa/prayer-bot/global/internal/core/moon"
 	"github.com/escalopa/prayer-bot/global/internal/core/occasions"
 	"github.com/escalopa/prayer-bot/global/internal/core/prayertime"
 	"github.com/escalopa/prayer-bot/global/internal/domain"
@@ -83,7 +86,8 @@ func TestStaticMiniAppIsEmbeddedWithSecurityHeaders(t *testing.T) {
 	}
 	if !strings.Contains(string(script), "serviceWorker.register") ||
 		!strings.Contains(string(serviceWorker), "global-prayer-miniapp-shell") ||
-		!strings.Contains(string(script), "delete snapshot.calendar.path") {
+		!strings.Contains(string(script), "delete snapshot.calendar.path") ||
+		!strings.Contains(string(script), "delete snapshot.fasting_calendar.path") {
 		t.Fatal("Mini App is missing its safe offline application shell")
 	}
 	if !strings.Contains(response.Header().Get("Content-Security-Policy"), "telegram.org") {
@@ -223,6 +227,67 @@ func TestLookupReturnsScheduleWithoutChangingProfile(t *testing.T) {
 	}
 }
 
+func TestFormatScheduleIncludesMakruhWindowsUnlessHidden(t *testing.T) {
+	location := time.FixedZone("test", 3*60*60)
+	at := func(hour, minute int) time.Time { return time.Date(2026, time.March, 20, hour, minute, 0, 0, location) }
+	schedule := domain.DaySchedule{
+		Date: at(12, 0), Timezone: "Asia/Riyadh",
+		Makruh: map[domain.MakruhWindow]domain.TimeWindow{
+			domain.MakruhSunrise: {Start: at(6, 14), End: at(6, 29)},
+			domain.MakruhSunset:  {Start: at(18, 4), End: at(18, 24)},
+		},
+	}
+	profile := domain.PrayerProfile{Timezone: "Asia/Riyadh", Method: domain.MethodUmmAlQura}
+
+	result := formatSchedule(schedule, profile, i18n.Resolve("en"))
+	want := []makruhResponse{
+		{ID: domain.MakruhSunrise, Name: "Sunrise", Start: "06:14", End: "06:29"},
+		{ID: domain.MakruhSunset, Name: "Sunset", Start: "18:04", End: "18:24"},
+	}
+	if len(result.Makruh) != len(want) || result.Makruh[0] != want[0] || result.Makruh[1] != want[1] {
+		t.Fatalf("makruh windows = %+v, want %+v", result.Makruh, want)
+	}
+	profile.HideMakruhTimes = true
+	if result := formatSchedule(schedule, profile, i18n.Resolve("en")); len(result.Makruh) != 0 {
+		t.Fatalf("hidden makruh windows were returned: %+v", result.Makruh)
+	}
+}
+
+func TestFormatScheduleDescribesTheMoonAroundANewMoon(t *testing.T) {
+	location := time.FixedZone("test", 3*60*60)
+	profile := domain.PrayerProfile{Latitude: 21.42, Longitude: 39.83, Timezone: "Asia/Riyadh", Method: domain.MethodUmmAlQura}
+	schedule := domain.DaySchedule{Date: time.Date(2025, time.February, 28, 0, 0, 0, 0, location), Timezone: "Asia/Riyadh"}
+
+	result := formatSchedule(schedule, profile, i18n.Resolve("en"))
+	if result.Moon == nil || result.Moon.Phase != moon.PhaseNew || result.Moon.Emoji != "🌑" ||
+		!strings.Contains(result.Moon.Crescent, "look for the crescent after sunset on 1 March") {
+		t.Fatalf("unexpected moon: %+v", result.Moon)
+	}
+	schedule.Date = time.Date(2025, time.March, 10, 0, 0, 0, 0, location)
+	if result := formatSchedule(schedule, profile, i18n.Resolve("en")); result.Moon == nil || result.Moon.Crescent != "" {
+		t.Fatalf("mid-month moon should not wait for a crescent: %+v", result.Moon)
+	}
+}
+
+func TestFormatScheduleShowsJumuahInDhuhrsSlotOnFridays(t *testing.T) {
+	location := time.FixedZone("test", 3*60*60)
+	friday := time.Date(2026, time.March, 20, 0, 0, 0, 0, location)
+	schedule := domain.DaySchedule{
+		Date: friday, Timezone: "Asia/Riyadh",
+		Times: map[domain.Prayer]time.Time{domain.PrayerDhuhr: friday.Add(12*time.Hour + 4*time.Minute)},
+	}
+	profile := domain.PrayerProfile{Timezone: "Asia/Riyadh", Method: domain.MethodUmmAlQura, Jumuah: domain.Jumuah{Khutbah: "12:30"}}
+
+	result := formatSchedule(schedule, profile, i18n.Resolve("en"))
+	if want := (prayerResponse{ID: domain.PrayerDhuhr, Name: "Jumu'ah", Emoji: "🕌", Time: "12:30"}); len(result.Prayers) != 1 || result.Prayers[0] != want {
+		t.Fatalf("Friday prayers = %+v, want %+v", result.Prayers, want)
+	}
+	schedule.Date = friday.AddDate(0, 0, -1)
+	if result := formatSchedule(schedule, profile, i18n.Resolve("en")); result.Prayers[0].Time != "12:04" {
+		t.Fatalf("Thursday should keep Dhuhr: %+v", result.Prayers)
+	}
+}
+
 func TestSettingsIconIsSingleGearAndShellIsNetworkFirst(t *testing.T) {
 	html, err := embeddedStatic.ReadFile("static/index.html")
 	if err != nil {
@@ -328,6 +393,181 @@ func TestPrayerCardFallbackRejectsUnexpectedImageDimensions(t *testing.T) {
 	}
 }
 
+func TestMonthTimetableSendsTheRequestedFormatToTelegramChat(t *testing.T) {
+	now := time.Date(2026, time.October, 17, 12, 0, 0, 0, time.UTC)
+	storage := newFakeStorage()
+	storage.chats[42] = domain.Chat{TelegramChatID: 42, Type: "private", LanguageCode: "fr"}
+	storage.profiles[42] = domain.PrayerProfile{
+		ChatID: 42, Latitude: 48.857, Longitude: 2.352, Timezone: "Europe/Paris",
+		Method: domain.MethodUOIF, Madhab: domain.MadhabShafii,
+		HighLatitudeRule: domain.HighLatitudeAngleBased,
+	}
+	sender := &fakePhotoSender{}
+	handler := NewHandler("test-token", storage, nil, prayertime.New(), nil, nil, sender)
+	handler.now = func() time.Time { return now }
+	mux := http.NewServeMux()
+	handler.Register(mux)
+
+	post := func(body string) *httptest.ResponseRecorder {
+		request := httptest.NewRequest(http.MethodPost, "/api/miniapp/month", strings.NewReader(body))
+		request.Header.Set("X-Telegram-Init-Data", signedInitData(
+			t, "test-token", now, initDataUser{ID: 42, FirstName: "Amina", LanguageCode: "en"},
+		))
+		response := httptest.NewRecorder()
+		mux.ServeHTTP(response, request)
+		return response
+	}
+
+	for _, body := range []string{`{"format":"docx"}`, `{"format":"pdf","month":"2028-01"}`} {
+		if response := post(body); response.Code != http.StatusBadRequest {
+			t.Fatalf("%s: status = %d, body = %s", body, response.Code, response.Body.String())
+		}
+	}
+	if len(sender.content) != 0 {
+		t.Fatal("rejected requests must not send anything")
+	}
+	response := post(`{"format":"csv","month":"2026-11"}`)
+	if response.Code != http.StatusOK || !strings.Contains(response.Body.String(), `"status":"sent"`) {
+		t.Fatalf("status = %d, body = %s", response.Code, response.Body.String())
+	}
+	if sender.chatID != 42 || sender.filename != "prayer-times-2026-11.csv" || !strings.Contains(string(sender.content), "Lever du soleil") {
+		t.Fatalf("unexpected Telegram document upload: chat=%d file=%q", sender.chatID, sender.filename)
+	}
+}
+
+func TestRamadanTimetableAndReminders(t *testing.T) {
+	now := time.Date(2026, time.October, 17, 12, 0, 0, 0, time.UTC)
+	storage := newFakeStorage()
+	storage.chats[42] = domain.Chat{TelegramChatID: 42, Type: "private", LanguageCode: "en"}
+	storage.profiles[42] = domain.PrayerProfile{
+		ChatID: 42, Latitude: 30.044, Longitude: 31.236, Timezone: "Africa/Cairo",
+		Method: domain.MethodEgyptian, Madhab: domain.MadhabShafii,
+		HighLatitudeRule: domain.HighLatitudeAngleBased,
+	}
+	sender := &fakePhotoSender{}
+	planner := &fakePlanner{}
+	handler := NewHandler("test-token", storage, nil, prayertime.New(), planner, nil, sender)
+	handler.now = func() time.Time { return now }
+	mux := http.NewServeMux()
+	handler.Register(mux)
+	send := func(method, path, body string) *httptest.ResponseRecorder {
+		t.Helper()
+		request := httptest.NewRequest(method, path, strings.NewReader(body))
+		request.Header.Set("X-Telegram-Init-Data", signedInitData(t, "test-token", now, initDataUser{ID: 42, FirstName: "Amina", LanguageCode: "en"}))
+		response := httptest.NewRecorder()
+		mux.ServeHTTP(response, request)
+		return response
+	}
+	decode := func(response *httptest.ResponseRecorder) bootstrapResponse {
+		t.Helper()
+		if response.Code != http.StatusOK {
+			t.Fatalf("status = %d, body = %s", response.Code, response.Body.String())
+		}
+		var data bootstrapResponse
+		if err := json.Unmarshal(response.Body.Bytes(), &data); err != nil {
+			t.Fatal(err)
+		}
+		return data
+	}
+
+	reminders := `{"prayer":false,"pre_prayer_minutes":0,"fasting":false,"kahf":false,
+		"occasion_major":false,"occasion_fasting":false,"occasion_observed":false`
+	enabled := decode(send(http.MethodPut, "/api/miniapp/reminders", reminders+`,"suhoor_minutes":30,"iftar":true}`))
+	if enabled.Reminders.SuhoorMinutes != 30 || !enabled.Reminders.Iftar || planner.rebuilds == 0 {
+		t.Fatalf("Ramadan reminders were not saved: %+v", enabled.Reminders)
+	}
+	stale := decode(send(http.MethodPut, "/api/miniapp/reminders", reminders+`}`))
+	if stale.Reminders.SuhoorMinutes != 30 || !stale.Reminders.Iftar {
+		t.Fatalf("stale client save must not disable Ramadan reminders: %+v", stale.Reminders)
+	}
+	if response := send(http.MethodPut, "/api/miniapp/reminders", reminders+`,"suhoor_minutes":17}`); response.Code != http.StatusBadRequest {
+		t.Fatalf("unsupported suhoor lead time should be rejected, got %d", response.Code)
+	}
+	disabled := decode(send(http.MethodPut, "/api/miniapp/reminders", reminders+`,"suhoor_minutes":0,"iftar":false}`))
+	if disabled.Reminders.SuhoorMinutes != 0 || disabled.Reminders.Iftar {
+		t.Fatalf("Ramadan reminders were not disabled: %+v", disabled.Reminders)
+	}
+
+	days := send(http.MethodPost, "/api/miniapp/ramadan/days", `{}`)
+	var ramadan ramadanResponse
+	if err := json.Unmarshal(days.Body.Bytes(), &ramadan); err != nil || days.Code != http.StatusOK {
+		t.Fatalf("status = %d, body = %s", days.Code, days.Body.String())
+	}
+	if len(ramadan.Days) < 29 || ramadan.Days[0].HijriDay != 1 || ramadan.Days[0].Iftar == "" {
+		t.Fatalf("unexpected Ramadan timetable: %+v", ramadan)
+	}
+	if strings.Contains(send(http.MethodPost, "/api/miniapp/bootstrap", "").Body.String(), `"ramadan"`) {
+		t.Fatal("the bootstrap must leave the Ramadan timetable to its own request")
+	}
+
+	response := send(http.MethodPost, "/api/miniapp/ramadan", `{"format":"csv"}`)
+	if response.Code != http.StatusOK {
+		t.Fatalf("status = %d, body = %s", response.Code, response.Body.String())
+	}
+	if sender.chatID != 42 || sender.filename != "ramadan-1448.csv" || !strings.Contains(string(sender.content), "Suhoor ends") {
+		t.Fatalf("unexpected Telegram document upload: chat=%d file=%q", sender.chatID, sender.filename)
+	}
+}
+
+func TestConvertFollowsTheProfileCalendarAndCorrection(t *testing.T) {
+	now := time.Date(2026, time.October, 17, 12, 0, 0, 0, time.UTC)
+	storage := newFakeStorage()
+	storage.chats[42] = domain.Chat{TelegramChatID: 42, Type: "private", LanguageCode: "en"}
+	storage.profiles[42] = domain.PrayerProfile{
+		ChatID: 42, Latitude: 21.4, Longitude: 39.8, Timezone: "Asia/Riyadh",
+		Method: domain.MethodUmmAlQura, Madhab: domain.MadhabShafii, HighLatitudeRule: domain.HighLatitudeAngleBased,
+		HijriCalendar: domain.HijriUmmAlQura, HijriAdjustment: 1,
+	}
+	handler := NewHandler("test-token", storage, nil, prayertime.New(), &fakePlanner{}, nil, nil)
+	handler.now = func() time.Time { return now }
+	mux := http.NewServeMux()
+	handler.Register(mux)
+	convert := func(body string) (*httptest.ResponseRecorder, convertResponse) {
+		t.Helper()
+		request := httptest.NewRequest(http.MethodPost, "/api/miniapp/convert", strings.NewReader(body))
+		request.Header.Set("X-Telegram-Init-Data", signedInitData(t, "test-token", now, initDataUser{ID: 42, FirstName: "Amina", LanguageCode: "en"}))
+		response := httptest.NewRecorder()
+		mux.ServeHTTP(response, request)
+		var data convertResponse
+		_ = json.Unmarshal(response.Body.Bytes(), &data)
+		return response, data
+	}
+
+	// The +1 correction starts Umm al-Qura's Ramadan 1446 a day early.
+	response, data := convert(`{"date":"1 Ramadan 1446"}`)
+	if response.Code != http.StatusOK {
+		t.Fatalf("status = %d, body = %s", response.Code, response.Body.String())
+	}
+	if !data.HijriFirst || data.Hijri != "1 Ramadan 1446 AH" || len(data.Gregorian) != 1 ||
+		data.Gregorian[0] != "Fri, 28 February 2025" || data.Ambiguous != "" {
+		t.Fatalf("unexpected conversion: %+v", data)
+	}
+	response, data = convert(`{"date":"16.03.2026"}`)
+	if response.Code != http.StatusOK || data.HijriFirst || data.Hijri != "28 Ramadan 1447 AH" {
+		t.Fatalf("unexpected Gregorian conversion: %d %+v", response.Code, data)
+	}
+	if response, _ := convert(`{"date":"next Tuesday"}`); response.Code != http.StatusBadRequest ||
+		!strings.Contains(response.Body.String(), "invalid_date") {
+		t.Fatalf("unreadable date should be rejected, got %d %s", response.Code, response.Body.String())
+	}
+
+	script, err := embeddedStatic.ReadFile("static/app.js")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(script), "/api/miniapp/convert") {
+		t.Error("app.js does not call the convert endpoint")
+	}
+	for _, locale := range i18n.Supported() {
+		localized := labels(locale)
+		for _, key := range []string{"convert_title", "convert_help", "convert_action", "convert_invalid"} {
+			if localized[key] == "" {
+				t.Errorf("locale %q has no %q label", locale.Code, key)
+			}
+		}
+	}
+}
+
 func prayerCardUpload(t *testing.T, width, height int) (*bytes.Buffer, string) {
 	t.Helper()
 	var card bytes.Buffer
@@ -399,6 +639,57 @@ func TestBootstrapUsesSignedTelegramIdentityAndReturnsLocationGate(t *testing.T)
 	}
 }
 
+func TestBootstrapListsAMultiDayObservanceOnceWithItsDay(t *testing.T) {
+	profile := domain.PrayerProfile{
+		ChatID: 42, Latitude: 30.044, Longitude: 31.236, Timezone: "UTC",
+		Method: domain.MethodEgyptian, Madhab: domain.MadhabShafii,
+		HighLatitudeRule: domain.HighLatitudeAngleBased,
+	}
+	calendar, err := hijri.ForProfile(profile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	upcoming, err := occasions.Between(time.Date(2026, time.January, 1, 12, 0, 0, 0, time.UTC), 354, calendar, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var now time.Time
+	for _, occurrence := range upcoming {
+		if occurrence.Definition.ID == "dhul_hijjah_start" && occurrence.Day == 5 {
+			now = occurrence.Date
+		}
+	}
+	storage := newFakeStorage()
+	storage.chats[42] = domain.Chat{TelegramChatID: 42, Type: "private", LanguageCode: "en"}
+	storage.profiles[42] = profile
+	handler := NewHandler("test-token", storage, nil, prayertime.New(), &fakePlanner{}, nil)
+	handler.now = func() time.Time { return now }
+	mux := http.NewServeMux()
+	handler.Register(mux)
+
+	request := httptest.NewRequest(http.MethodPost, "/api/miniapp/bootstrap", nil)
+	request.Header.Set("X-Telegram-Init-Data", signedInitData(t, "test-token", now, initDataUser{ID: 42, FirstName: "Amina", LanguageCode: "en"}))
+	response := httptest.NewRecorder()
+	mux.ServeHTTP(response, request)
+	if response.Code != http.StatusOK {
+		t.Fatalf("status = %d, body = %s", response.Code, response.Body.String())
+	}
+	var data bootstrapResponse
+	if err := json.Unmarshal(response.Body.Bytes(), &data); err != nil {
+		t.Fatal(err)
+	}
+	var ids []string
+	for _, occasion := range data.Occasions {
+		ids = append(ids, occasion.ID)
+	}
+	if strings.Join(ids, ",") != "dhul_hijjah_start,arafah,eid_adha" {
+		t.Fatalf("unexpected occasions: %v", ids)
+	}
+	if data.Occasions[0].Day != "day 5 of 10" || data.Occasions[0].NoFasting || !data.Occasions[2].NoFasting {
+		t.Fatalf("unexpected days: %+v", data.Occasions)
+	}
+}
+
 func TestBootstrapIncludesNisabWithCountryDefaultCurrency(t *testing.T) {
 	now := time.Date(2026, time.July, 17, 12, 0, 0, 0, time.UTC)
 	storage := newFakeStorage()
@@ -558,6 +849,124 @@ func TestLocationUpdatePreservesCalculationSettingsAndRoundsCoordinates(t *testi
 	}
 }
 
+func TestLocationUpdateDetectsElevationAndSettingsCorrectIt(t *testing.T) {
+	now := time.Date(2026, time.July, 17, 12, 0, 0, 0, time.UTC)
+	storage := newFakeStorage()
+	storage.chats[42] = domain.Chat{TelegramChatID: 42, Type: "private", LanguageCode: "en"}
+	resolver := &elevationResolver{fakeResolver: fakeResolver{resolved: domain.ResolvedLocation{
+		Timezone: "America/Mexico_City", PlaceID: "cdmx", City: "Mexico City", CountryCode: "MX",
+	}}, meters: 2240.4}
+	handler := NewHandler("test-token", storage, resolver, prayertime.New(), &fakePlanner{}, nil)
+	handler.now = func() time.Time { return now }
+	mux := http.NewServeMux()
+	handler.Register(mux)
+	send := func(path, body string) *httptest.ResponseRecorder {
+		t.Helper()
+		request := httptest.NewRequest(http.MethodPut, path, strings.NewReader(body))
+		request.Header.Set("X-Telegram-Init-Data", signedInitData(t, "test-token", now, initDataUser{ID: 42, FirstName: "Amina", LanguageCode: "en"}))
+		response := httptest.NewRecorder()
+		mux.ServeHTTP(response, request)
+		return response
+	}
+
+	if response := send("/api/miniapp/location", `{"latitude":19.4326,"longitude":-99.1332}`); response.Code != http.StatusOK {
+		t.Fatalf("status = %d, body = %s", response.Code, response.Body.String())
+	}
+	if got := storage.profiles[42].ElevationMeters; got != 2240 {
+		t.Fatalf("stored elevation = %d, want 2240", got)
+	}
+
+	settings := func(extra string) string {
+		return `{"language":"en","method":"isna","madhab":"shafii","high_latitude_rule":"angle_based","hijri_adjustment":0,
+			"adjustments":{"fajr":0,"sunrise":0,"dhuhr":0,"asr":0,"maghrib":0,"isha":0}` + extra + `}`
+	}
+	response := send("/api/miniapp/settings", settings(`,"elevation_meters":2500,"moon_events":true`))
+	if response.Code != http.StatusOK {
+		t.Fatalf("status = %d, body = %s", response.Code, response.Body.String())
+	}
+	var data bootstrapResponse
+	if err := json.Unmarshal(response.Body.Bytes(), &data); err != nil {
+		t.Fatal(err)
+	}
+	if data.Profile == nil || data.Profile.ElevationMeters != 2500 || !data.Profile.MoonEvents {
+		t.Fatalf("response does not echo the corrected elevation and moon events: %+v", data.Profile)
+	}
+	if response := send("/api/miniapp/settings", settings("")); response.Code != http.StatusOK ||
+		storage.profiles[42].ElevationMeters != 2500 || !storage.profiles[42].MoonEvents {
+		t.Fatalf("stale client save changed the elevation or moon events: %d, %+v", response.Code, storage.profiles[42])
+	}
+	if response := send("/api/miniapp/settings", settings(`,"elevation_meters":9001`)); response.Code != http.StatusBadRequest {
+		t.Fatalf("out-of-range elevation should be rejected, got %d", response.Code)
+	}
+}
+
+type fakeTimetableSource struct {
+	timetable domain.Timetable
+	days      []domain.TimetableDay
+}
+
+func (s fakeTimetableSource) Timetables(context.Context) ([]domain.Timetable, error) {
+	return []domain.Timetable{s.timetable}, nil
+}
+
+func (s fakeTimetableSource) TimetableDays(context.Context, int64, time.Time, time.Time) ([]domain.TimetableDay, error) {
+	return s.days, nil
+}
+
+func TestSettingsIgnoreACoveringTimetableAndStillNameIt(t *testing.T) {
+	now := time.Date(2026, time.July, 17, 9, 0, 0, 0, time.UTC)
+	storage := newFakeStorage()
+	storage.chats[42] = domain.Chat{TelegramChatID: 42, Type: "private", LanguageCode: "en"}
+	storage.profiles[42] = domain.PrayerProfile{
+		ChatID: 42, Latitude: 55.79, Longitude: 49.12, Timezone: "Europe/Moscow", CountryCode: "RU",
+		Method: domain.MethodRussia, Madhab: domain.MadhabHanafi, HighLatitudeRule: domain.HighLatitudeAngleBased,
+	}
+	day := domain.TimetableDay{Date: time.Date(2026, time.July, 17, 0, 0, 0, 0, time.UTC), Minutes: map[domain.Prayer]int{
+		domain.PrayerFajr: 120, domain.PrayerSunrise: 230, domain.PrayerDhuhr: 741,
+		domain.PrayerAsr: 1040, domain.PrayerMaghrib: 1250, domain.PrayerIsha: 1350,
+	}}
+	source := fakeTimetableSource{
+		timetable: domain.Timetable{ID: 1, Name: "Russian Muftiate", Timezone: "Europe/Moscow", CountryCode: "RU"},
+		days:      []domain.TimetableDay{day},
+	}
+	handler := NewHandler("test-token", storage, nil, prayertime.NewOfficial(prayertime.New(), source), &fakePlanner{}, nil)
+	handler.now = func() time.Time { return now }
+	mux := http.NewServeMux()
+	handler.Register(mux)
+	save := func(extra string) bootstrapResponse {
+		t.Helper()
+		body := `{"language":"en","method":"russia","madhab":"hanafi","high_latitude_rule":"angle_based","hijri_adjustment":0,
+			"adjustments":{"fajr":0,"sunrise":0,"dhuhr":0,"asr":0,"maghrib":0,"isha":0}` + extra + `}`
+		request := httptest.NewRequest(http.MethodPut, "/api/miniapp/settings", strings.NewReader(body))
+		request.Header.Set("X-Telegram-Init-Data", signedInitData(t, "test-token", now, initDataUser{ID: 42, FirstName: "Amina", LanguageCode: "en"}))
+		response := httptest.NewRecorder()
+		mux.ServeHTTP(response, request)
+		if response.Code != http.StatusOK {
+			t.Fatalf("status = %d, body = %s", response.Code, response.Body.String())
+		}
+		var data bootstrapResponse
+		if err := json.Unmarshal(response.Body.Bytes(), &data); err != nil {
+			t.Fatal(err)
+		}
+		return data
+	}
+
+	data := save("")
+	if data.Profile.Timetable != "Russian Muftiate" || data.Profile.IgnoreTimetables || data.Today.Timetable == "" {
+		t.Fatalf("a covered profile should use and name its timetable: %+v, today %q", data.Profile, data.Today.Timetable)
+	}
+	data = save(`,"ignore_timetables":true`)
+	if !storage.profiles[42].IgnoreTimetables || data.Today.Timetable != "" {
+		t.Fatalf("an ignored timetable must not serve the schedule: today %q", data.Today.Timetable)
+	}
+	if data.Profile.Timetable != "Russian Muftiate" || !data.Profile.IgnoreTimetables {
+		t.Fatalf("settings should still name the ignored timetable: %+v", data.Profile)
+	}
+	if labels := data.Labels; !strings.Contains(labels["timetable_covers"], "{name} covers your location") {
+		t.Fatalf("timetable label should carry a name placeholder: %q", labels["timetable_covers"])
+	}
+}
+
 func TestCalendarSubscriptionProducesRollingThirtyDayFeed(t *testing.T) {
 	now := time.Date(2026, time.July, 17, 12, 0, 0, 0, time.UTC)
 	storage := newFakeStorage()
@@ -588,7 +997,7 @@ func TestCalendarSubscriptionProducesRollingThirtyDayFeed(t *testing.T) {
 	if !link.Enabled || !strings.HasPrefix(link.Path, "/api/miniapp/calendar.ics?token=") {
 		t.Fatalf("unexpected calendar link: %+v", link)
 	}
-	subscription := storage.subscriptions[42]
+	subscription := storage.subscriptions[prayerFeed]
 	if !subscription.Enabled || len(subscription.FeedToken) != 64 || len(subscription.UIDNamespace) != 32 {
 		t.Fatalf("unexpected stored subscription: %+v", subscription)
 	}
@@ -612,11 +1021,16 @@ func TestCalendarSubscriptionProducesRollingThirtyDayFeed(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	upcoming, err := occasions.Between(now.In(local), 30, storage.profiles[42].HijriAdjustment)
+	calendar, err := hijri.ForProfile(storage.profiles[42])
+	if err != nil {
+		t.Fatal(err)
+	}
+	upcoming, err := occasions.Between(now.In(local), 30, calendar, "")
 	if err != nil {
 		t.Fatal(err)
 	}
-	expectedEvents := 180 + len(upcoming)
+	// Thirty days of six prayers and three makruh windows.
+	expectedEvents := 270 + len(upcoming)
 	if events := strings.Count(downloadResponse.Body.String(), "BEGIN:VEVENT\r\n"); events != expectedEvents {
 		t.Fatalf("calendar event count = %d, want %d", events, expectedEvents)
 	}
@@ -686,8 +1100,8 @@ func TestCalendarSubscriptionCanBeRevoked(t *testing.T) {
 	deleteRequest.Header.Set("X-Telegram-Init-Data", initData)
 	deleteResponse := httptest.NewRecorder()
 	mux.ServeHTTP(deleteResponse, deleteRequest)
-	if deleteResponse.Code != http.StatusOK || storage.subscriptions[42].Enabled {
-		t.Fatalf("calendar subscription was not disabled: %d %+v", deleteResponse.Code, storage.subscriptions[42])
+	if deleteResponse.Code != http.StatusOK || storage.subscriptions[prayerFeed].Enabled {
+		t.Fatalf("calendar subscription was not disabled: %d %+v", deleteResponse.Code, storage.subscriptions[prayerFeed])
 	}
 
 	downloadResponse := httptest.NewRecorder()
@@ -696,7 +1110,7 @@ func TestCalendarSubscriptionCanBeRevoked(t *testing.T) {
 		t.Fatalf("revoked calendar status = %d, want 401", downloadResponse.Code)
 	}
 
-	previous := storage.subscriptions[42]
+	previous := storage.subscriptions[prayerFeed]
 	reconnectRequest := httptest.NewRequest(http.MethodPost, "/api/miniapp/calendar-subscription", nil)
 	reconnectRequest.Header.Set("X-Telegram-Init-Data", initData)
 	reconnectResponse := httptest.NewRecorder()
@@ -705,7 +1119,7 @@ func TestCalendarSubscriptionCanBeRevoked(t *testing.T) {
 	if err := json.Unmarshal(reconnectResponse.Body.Bytes(), &reconnected); err != nil {
 		t.Fatal(err)
 	}
-	current := storage.subscriptions[42]
+	current := storage.subscriptions[prayerFeed]
 	if reconnected.Path == created.Path || current.FeedToken == previous.FeedToken {
 		t.Fatal("reconnecting did not replace the revoked private feed token")
 	}
@@ -719,48 +1133,272 @@ func TestCalendarSubscriptionCanBeRevoked(t *testing.T) {
 	}
 }
 
-func TestCalendarCredentialsAreOpaqueAndIndependent(t *testing.T) {
-	firstToken, firstNamespace, err := newCalendarCredentials()
-	if err != nil {
-		t.Fatal(err)
-	}
-	secondToken, secondNamespace, err := newCalendarCredentials()
-	if err != nil {
-		t.Fatal(err)
-	}
-	if len(firstToken) != 64 || len(firstNamespace) != 32 {
-		t.Fatalf("unexpected credential sizes: token=%d namespace=%d", len(firstToken), len(firstNamespace))
-	}
-	if firstToken == secondToken || firstNamespace == secondNamespace {
-		t.Fatal("calendar credentials were unexpectedly reused")
-	}
-}
-
-func TestPreferencesUpdateSavesSettingsAndRemindersTogether(t *testing.T) {
-	now := time.Date(2026, time.July, 17, 12, 0, 0, 0, time.UTC)
+func TestFastingCalendarIsASeparateFeed(t *testing.T) {
+	now := time.Date(2026, time.May, 20, 12, 0, 0, 0, time.UTC)
 	storage := newFakeStorage()
 	storage.chats[42] = domain.Chat{TelegramChatID: 42, Type: "private", LanguageCode: "en"}
 	storage.profiles[42] = domain.PrayerProfile{
-		ChatID: 42, Latitude: 30.044, Longitude: 31.236, Timezone: "UTC",
+		ChatID: 42, Latitude: 30.044, Longitude: 31.236, Timezone: "Africa/Cairo",
 		Method: domain.MethodEgyptian, Madhab: domain.MadhabShafii,
 		HighLatitudeRule: domain.HighLatitudeAngleBased,
 	}
-	planner := &fakePlanner{}
-	handler := NewHandler("test-token", storage, nil, prayertime.New(), planner, nil)
+	handler := NewHandler("test-token", storage, nil, prayertime.New(), nil, nil)
 	handler.now = func() time.Time { return now }
 	mux := http.NewServeMux()
 	handler.Register(mux)
+	initData := signedInitData(t, "test-token", now, initDataUser{ID: 42, FirstName: "Amina", LanguageCode: "en"})
 
-	body := `{
-		"settings":{"language":"ar","method":"isna","madhab":"hanafi","high_latitude_rule":"middle_of_night","hijri_adjustment":1,
-		"adjustments":{"fajr":2,"sunrise":0,"dhuhr":0,"asr":1,"maghrib":0,"isha":-1}},
-		"reminders":{"prayer":true,"pre_prayer_minutes":20,"fasting":true,"kahf":false,
-		"occasion_major":true,"occasion_fasting":true,"occasion_observed":false}
-	}`
-	request := httptest.NewRequest(http.MethodPut, "/api/miniapp/preferences", strings.NewReader(body))
-	request.Header.Set("X-Telegram-Init-Data", signedInitData(t, "test-token", now, initDataUser{ID: 42, FirstName: "Amina", LanguageCode: "en"}))
-	response := httptest.NewRecorder()
-	mux.ServeHTTP(response, request)
+	invalidRequest := httptest.NewRequest(http.MethodPost, "/api/miniapp/calendar-subscription?feed=moon", nil)
+	invalidRequest.Header.Set("X-Telegram-Init-Data", initData)
+	invalidResponse := httptest.NewRecorder()
+	mux.ServeHTTP(invalidResponse, invalidRequest)
+	if invalidResponse.Code != http.StatusBadRequest {
+		t.Fatalf("unknown feed status = %d, want 400", invalidResponse.Code)
+	}
+
+	linkRequest := httptest.NewRequest(http.MethodPost, "/api/miniapp/calendar-subscription?feed=fasting", nil)
+	linkRequest.Header.Set("X-Telegram-Init-Data", initData)
+	linkResponse := httptest.NewRecorder()
+	mux.ServeHTTP(linkResponse, linkRequest)
+	var link calendarSubscriptionResponse
+	if err := json.Unmarshal(linkResponse.Body.Bytes(), &link); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := storage.subscriptions[prayerFeed]; ok || !link.Enabled {
+		t.Fatalf("the fasting link %+v touched the prayer feed", link)
+	}
+	downloadResponse := httptest.NewRecorder()
+	mux.ServeHTTP(downloadResponse, httptest.NewRequest(http.MethodGet, link.Path, nil))
+	body := downloadResponse.Body.String()
+	if downloadResponse.Code != http.StatusOK ||
+		downloadResponse.Header().Get("Content-Disposition") != `inline; filename="fasting.ics"` ||
+		!strings.Contains(body, "X-WR-CALNAME:Fasting calendar") ||
+		!strings.Contains(body, "SUMMARY:⛔ Do not fast · Eid al-Adha") ||
+		strings.Contains(body, "CATEGORIES:Prayer Times") {
+		t.Fatalf("fasting feed status = %d:\n%s", downloadResponse.Code, body)
+	}
+
+	bootstrapRequest := httptest.NewRequest(http.MethodPost, "/api/miniapp/bootstrap", nil)
+	bootstrapRequest.Header.Set("X-Telegram-Init-Data", initData)
+	bootstrapRecorder := httptest.NewRecorder()
+	mux.ServeHTTP(bootstrapRecorder, bootstrapRequest)
+	var bootstrap bootstrapResponse
+	if err := json.Unmarshal(bootstrapRecorder.Body.Bytes(), &bootstrap); err != nil {
+		t.Fatal(err)
+	}
+	if bootstrap.Calendar.Enabled || !bootstrap.FastingCalendar.Enabled {
+		t.Fatalf("bootstrap calendars = %+v, %+v", bootstrap.Calendar, bootstrap.FastingCalendar)
+	}
+
+	deleteRequest := httptest.NewRequest(http.MethodDelete, "/api/miniapp/calendar-subscription?feed=fasting", nil)
+	deleteRequest.Header.Set("X-Telegram-Init-Data", initData)
+	mux.ServeHTTP(httptest.NewRecorder(), deleteRequest)
+	if storage.subscriptions[subscriptionKey{42, domain.CalendarFeedFasting}].Enabled {
+		t.Fatal("the fasting feed was not disabled")
+	}
+}
+
+func TestFastingMonthMarksEveryDay(t *testing.T) {
+	now := time.Date(2026, time.May, 20, 12, 0, 0, 0, time.UTC)
+	storage := newFakeStorage()
+	storage.chats[42] = domain.Chat{TelegramChatID: 42, Type: "private", LanguageCode: "en"}
+	storage.profiles[42] = domain.PrayerProfile{ChatID: 42, Latitude: 30.044, Longitude: 31.236, Timezone: "Africa/Cairo"}
+	handler := NewHandler("test-token", storage, nil, prayertime.New(), nil, nil)
+	handler.now = func() time.Time { return now }
+	mux := http.NewServeMux()
+	handler.Register(mux)
+	initData := signedInitData(t, "test-token", now, initDataUser{ID: 42, FirstName: "Amina", LanguageCode: "en"})
+
+	request := httptest.NewRequest(http.MethodPost, "/api/miniapp/fasting", strings.NewReader(`{"month":""}`))
+	request.Header.Set("X-Telegram-Init-Data", initData)
+	response := httptest.NewRecorder()
+	mux.ServeHTTP(response, request)
+	if response.Code != http.StatusOK {
+		t.Fatalf("status = %d, body = %s", response.Code, response.Body.String())
+	}
+	var month fastingMonthResponse
+	if err := json.Unmarshal(response.Body.Bytes(), &month); err != nil {
+		t.Fatal(err)
+	}
+	if month.Month != "2026-05" || month.Title != "May 2026" || len(month.Days) != 31 ||
+		month.Previous != "2026-04" || month.Next != "2026-06" || len(month.Weekdays) != 7 {
+		t.Fatalf("unexpected month: %+v", month)
+	}
+	arafah, eid := month.Days[25], month.Days[26]
+	if arafah.Status != fasting.StatusRecommended || arafah.Reasons != "Day of Arafah" ||
+		eid.Status != fasting.StatusProhibited || eid.Label != "Do not fast" || !month.Days[19].Today {
+		t.Fatalf("unexpected days: %+v %+v", arafah, eid)
+	}
+
+	request = httptest.NewRequest(http.MethodPost, "/api/miniapp/fasting", strings.NewReader(`{"month":"2030-01"}`))
+	request.Header.Set("X-Telegram-Init-Data", initData)
+	response = httptest.NewRecorder()
+	mux.ServeHTTP(response, request)
+	if response.Code != http.StatusBadRequest {
+		t.Fatalf("a far month status = %d, want 400", response.Code)
+	}
+}
+
+func TestFastingLogKeepsTheQadaBalance(t *testing.T) {
+	now := time.Date(2026, time.April, 5, 12, 0, 0, 0, time.UTC)
+	storage := newFakeStorage()
+	storage.chats[42] = domain.Chat{TelegramChatID: 42, Type: "private", LanguageCode: "en"}
+	storage.profiles[42] = domain.PrayerProfile{ChatID: 42, Latitude: 30.044, Longitude: 31.236, Timezone: "Africa/Cairo"}
+	handler := NewHandler("test-token", storage, nil, prayertime.New(), nil, nil)
+	handler.now = func() time.Time { return now }
+	mux := http.NewServeMux()
+	handler.Register(mux)
+	initData := signedInitData(t, "test-token", now, initDataUser{ID: 42, FirstName: "Amina", LanguageCode: "en"})
+	put := func(path, body string) (int, fastingMonthResponse) {
+		t.Helper()
+		request := httptest.NewRequest(http.MethodPut, path, strings.NewReader(body))
+		request.Header.Set("X-Telegram-Init-Data", initData)
+		response := httptest.NewRecorder()
+		mux.ServeHTTP(response, request)
+		var month fastingMonthResponse
+		if response.Code == http.StatusOK {
+			if err := json.Unmarshal(response.Body.Bytes(), &month); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return response.Code, month
+	}
+
+	// 23 February 2026 was in Ramadan, so not fasting it is owed.
+	if code, _ := put("/api/miniapp/fasting/log", `{"month":"2026-02","date":"2026-02-23","status":"missed"}`); code != http.StatusOK {
+		t.Fatalf("logging a missed Ramadan day = %d", code)
+	}
+	code, month := put("/api/miniapp/fasting/qada", `{"month":"2026-04","carried":2}`)
+	if code != http.StatusOK || month.Balance.Owed != 3 || len(month.Balance.Suggestions) != 3 {
+		t.Fatalf("balance after carrying two days = %d %+v", code, month.Balance)
+	}
+	code, month = put("/api/miniapp/fasting/log", `{"month":"2026-04","date":"2026-04-06","status":"makeup"}`)
+	if code != http.StatusOK || month.Balance.Owed != 2 || month.Days[5].Logged != domain.FastMakeUp ||
+		month.Days[5].LoggedLabel != "Made up" || !month.Days[5].Loggable || month.Days[6].Loggable {
+		t.Fatalf("after a make-up fast tomorrow = %d %+v %+v", code, month.Balance, month.Days[5])
+	}
+	for body, want := range map[string]int{
+		`{"date":"2026-03-20","status":"fasted"}`: http.StatusBadRequest, // Eid al-Fitr
+		`{"date":"2026-02-24","status":"makeup"}`: http.StatusBadRequest, // Ramadan
+		`{"date":"2026-04-08","status":"fasted"}`: http.StatusBadRequest, // the day after tomorrow
+		`{"date":"2026-04-06","status":""}`:       http.StatusOK,
+	} {
+		if code, _ := put("/api/miniapp/fasting/log", body); code != want {
+			t.Fatalf("%s = %d, want %d", body, code, want)
+		}
+	}
+	if _, ok := storage.fasts["2026-04-06"]; ok {
+		t.Fatal("an empty status did not clear the day")
+	}
+	if code, _ := put("/api/miniapp/fasting/qada", `{"carried":1001}`); code != http.StatusBadRequest {
+		t.Fatalf("carrying too many days = %d", code)
+	}
+}
+
+func TestCustomRemindersAreSavedEditedAndDeleted(t *testing.T) {
+	now := time.Date(2026, time.October, 17, 12, 0, 0, 0, time.UTC)
+	storage := newFakeStorage()
+	storage.chats[42] = domain.Chat{TelegramChatID: 42, Type: "private", LanguageCode: "en"}
+	storage.profiles[42] = domain.PrayerProfile{
+		ChatID: 42, Latitude: 30.044, Longitude: 31.236, Timezone: "Africa/Cairo",
+		Method: domain.MethodEgyptian, Madhab: domain.MadhabShafii,
+		HighLatitudeRule: domain.HighLatitudeAngleBased,
+	}
+	planner := &fakePlanner{}
+	handler := NewHandler("test-token", storage, nil, prayertime.New(), planner, nil)
+	handler.now = func() time.Time { return now }
+	mux := http.NewServeMux()
+	handler.Register(mux)
+	send := func(method, path, body string) (int, bootstrapResponse, string) {
+		t.Helper()
+		request := httptest.NewRequest(method, path, strings.NewReader(body))
+		request.Header.Set("X-Telegram-Init-Data", signedInitData(t, "test-token", now, initDataUser{ID: 42, FirstName: "Amina", LanguageCode: "en"}))
+		response := httptest.NewRecorder()
+		mux.ServeHTTP(response, request)
+		var data bootstrapResponse
+		if response.Code == http.StatusOK {
+			if err := json.Unmarshal(response.Body.Bytes(), &data); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return response.Code, data, response.Body.String()
+	}
+
+	// The hidden date of a weekly reminder is dropped rather than rejected.
+	code, data, body := send(http.MethodPut, "/api/miniapp/custom-reminders",
+		`{"text":"Adhkar","anchor":"fajr","offset_minutes":20,"recurrence":"weekly","weekdays":[1,4],"date":"2026-01-01"}`)
+	if code != http.StatusOK || len(data.CustomReminders) != 1 || planner.rebuilds != 1 {
+		t.Fatalf("create = %d %s", code, body)
+	}
+	created := data.CustomReminders[0]
+	if created.Date != "" || len(created.Weekdays) != 2 || created.Summary != "Every Mon, Thu · 20 min after Fajr" {
+		t.Fatalf("unexpected saved reminder: %+v", created)
+	}
+	code, data, body = send(http.MethodPut, "/api/miniapp/custom-reminders",
+		fmt.Sprintf(`{"id":%d,"text":"Witr","local_time":"21:30","recurrence":"daily"}`, created.ID))
+	if code != http.StatusOK || len(data.CustomReminders) != 1 || data.CustomReminders[0].Text != "Witr" || data.CustomReminders[0].Anchor != "" {
+		t.Fatalf("edit = %d %s", code, body)
+	}
+	for request, want := range map[string]string{
+		`{"text":"Call","local_time":"09:00","recurrence":"once","date":"2026-10-17"}`: "custom_reminder_past",
+		`{"text":"","local_time":"09:00","recurrence":"daily"}`:                        "invalid_custom_reminder",
+		`{"id":999,"text":"Call","local_time":"09:00","recurrence":"daily"}`:           "not_found",
+	} {
+		if code, _, body := send(http.MethodPut, "/api/miniapp/custom-reminders", request); code != http.StatusBadRequest || !strings.Contains(body, want) {
+			t.Fatalf("%s = %d %s, want %s", request, code, body, want)
+		}
+	}
+	code, data, body = send(http.MethodDelete, fmt.Sprintf("/api/miniapp/custom-reminders?id=%d", created.ID), "")
+	if code != http.StatusOK || len(data.CustomReminders) != 0 {
+		t.Fatalf("delete = %d %s", code, body)
+	}
+	if code, _, _ := send(http.MethodDelete, fmt.Sprintf("/api/miniapp/custom-reminders?id=%d", created.ID), ""); code != http.StatusBadRequest {
+		t.Fatalf("deleting twice = %d", code)
+	}
+}
+
+func TestCalendarCredentialsAreOpaqueAndIndependent(t *testing.T) {
+	firstToken, firstNamespace, err := newCalendarCredentials()
+	if err != nil {
+		t.Fatal(err)
+	}
+	secondToken, secondNamespace, err := newCalendarCredentials()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(firstToken) != 64 || len(firstNamespace) != 32 {
+		t.Fatalf("unexpected credential sizes: token=%d namespace=%d", len(firstToken), len(firstNamespace))
+	}
+	if firstToken == secondToken || firstNamespace == secondNamespace {
+		t.Fatal("calendar credentials were unexpectedly reused")
+	}
+}
+
+func TestPreferencesUpdateSavesSettingsAndRemindersTogether(t *testing.T) {
+	now := time.Date(2026, time.July, 17, 12, 0, 0, 0, time.UTC)
+	storage := newFakeStorage()
+	storage.chats[42] = domain.Chat{TelegramChatID: 42, Type: "private", LanguageCode: "en"}
+	storage.profiles[42] = domain.PrayerProfile{
+		ChatID: 42, Latitude: 30.044, Longitude: 31.236, Timezone: "UTC",
+		Method: domain.MethodEgyptian, Madhab: domain.MadhabShafii,
+		HighLatitudeRule: domain.HighLatitudeAngleBased,
+	}
+	planner := &fakePlanner{}
+	handler := NewHandler("test-token", storage, nil, prayertime.New(), planner, nil)
+	handler.now = func() time.Time { return now }
+	mux := http.NewServeMux()
+	handler.Register(mux)
+
+	body := `{
+		"settings":{"language":"ar","method":"isna","madhab":"hanafi","high_latitude_rule":"middle_of_night","hijri_adjustment":1,
+		"adjustments":{"fajr":2,"sunrise":0,"dhuhr":0,"asr":1,"maghrib":0,"isha":-1}},
+		"reminders":{"prayer":true,"pre_prayer_minutes":20,"fasting":true,"kahf":false,
+		"occasion_major":true,"occasion_fasting":true,"occasion_observed":false}
+	}`
+	request := httptest.NewRequest(http.MethodPut, "/api/miniapp/preferences", strings.NewReader(body))
+	request.Header.Set("X-Telegram-Init-Data", signedInitData(t, "test-token", now, initDataUser{ID: 42, FirstName: "Amina", LanguageCode: "en"}))
+	response := httptest.NewRecorder()
+	mux.ServeHTTP(response, request)
 
 	if response.Code != http.StatusOK {
 		t.Fatalf("status = %d, body = %s", response.Code, response.Body.String())
@@ -829,6 +1467,268 @@ func TestWhiteDaysToggleAndStaleClientPreservation(t *testing.T) {
 	}
 }
 
+func TestPersonalEventsJoinTheOccasionListAndKeepTheirReminder(t *testing.T) {
+	now := time.Date(2026, time.July, 17, 12, 0, 0, 0, time.UTC)
+	storage := newFakeStorage()
+	storage.chats[42] = domain.Chat{TelegramChatID: 42, Type: "private", LanguageCode: "en"}
+	storage.profiles[42] = domain.PrayerProfile{
+		ChatID: 42, Latitude: 30.044, Longitude: 31.236, Timezone: "UTC",
+		Method: domain.MethodEgyptian, Madhab: domain.MadhabShafii,
+		HighLatitudeRule: domain.HighLatitudeAngleBased,
+		PersonalEvents:   []domain.PersonalEvent{{ID: 5, ChatID: 42, Title: "Wedding", Month: 7, Day: 20}},
+	}
+	planner := &fakePlanner{}
+	handler := NewHandler("test-token", storage, nil, prayertime.New(), planner, nil)
+	handler.now = func() time.Time { return now }
+	mux := http.NewServeMux()
+	handler.Register(mux)
+	send := func(body string) bootstrapResponse {
+		t.Helper()
+		request := httptest.NewRequest(http.MethodPut, "/api/miniapp/reminders", strings.NewReader(body))
+		request.Header.Set("X-Telegram-Init-Data", signedInitData(t, "test-token", now, initDataUser{ID: 42, FirstName: "Amina", LanguageCode: "en"}))
+		response := httptest.NewRecorder()
+		mux.ServeHTTP(response, request)
+		if response.Code != http.StatusOK {
+			t.Fatalf("status = %d, body = %s", response.Code, response.Body.String())
+		}
+		var data bootstrapResponse
+		if err := json.Unmarshal(response.Body.Bytes(), &data); err != nil {
+			t.Fatal(err)
+		}
+		return data
+	}
+
+	enabled := send(`{"prayer":false,"pre_prayer_minutes":0,"fasting":false,"kahf":false,
+		"occasion_major":false,"occasion_fasting":false,"occasion_observed":false,"personal_events":true}`)
+	if !enabled.Reminders.PersonalEvents || planner.rebuilds != 1 {
+		t.Fatalf("personal events reminder was not enabled: %+v (rebuilds %d)", enabled.Reminders, planner.rebuilds)
+	}
+	if len(enabled.Occasions) != 4 {
+		t.Fatalf("want three catalog occasions and the wedding, got %+v", enabled.Occasions)
+	}
+	wedding := enabled.Occasions[0]
+	if wedding.ID != "personal-5" || wedding.Category != "personal" || wedding.Title != "Wedding" ||
+		wedding.Gregorian != "20 July 2026" || wedding.Action != "" || wedding.Summary == "" {
+		t.Fatalf("unexpected personal occasion: %+v", wedding)
+	}
+
+	stale := send(`{"prayer":false,"pre_prayer_minutes":0,"fasting":false,"kahf":false,
+		"occasion_major":false,"occasion_fasting":false,"occasion_observed":false}`)
+	if !stale.Reminders.PersonalEvents {
+		t.Fatalf("stale client save must not disable personal events: %+v", stale.Reminders)
+	}
+}
+
+func TestExtendedTimesSettingsAndReminders(t *testing.T) {
+	now := time.Date(2026, time.July, 17, 12, 0, 0, 0, time.UTC)
+	storage := newFakeStorage()
+	storage.chats[42] = domain.Chat{TelegramChatID: 42, Type: "private", LanguageCode: "en"}
+	storage.profiles[42] = domain.PrayerProfile{
+		ChatID: 42, Latitude: 30.044, Longitude: 31.236, Timezone: "Africa/Cairo",
+		Method: domain.MethodEgyptian, Madhab: domain.MadhabShafii,
+		HighLatitudeRule: domain.HighLatitudeAngleBased, ImsakMinutes: domain.DefaultImsakMinutes,
+	}
+	planner := &fakePlanner{}
+	handler := NewHandler("test-token", storage, nil, prayertime.New(), planner, nil)
+	handler.now = func() time.Time { return now }
+	mux := http.NewServeMux()
+	handler.Register(mux)
+	send := func(path, body string) *httptest.ResponseRecorder {
+		t.Helper()
+		request := httptest.NewRequest(http.MethodPut, path, strings.NewReader(body))
+		request.Header.Set("X-Telegram-Init-Data", signedInitData(t, "test-token", now, initDataUser{ID: 42, FirstName: "Amina", LanguageCode: "en"}))
+		response := httptest.NewRecorder()
+		mux.ServeHTTP(response, request)
+		return response
+	}
+	decode := func(response *httptest.ResponseRecorder) bootstrapResponse {
+		t.Helper()
+		if response.Code != http.StatusOK {
+			t.Fatalf("status = %d, body = %s", response.Code, response.Body.String())
+		}
+		var data bootstrapResponse
+		if err := json.Unmarshal(response.Body.Bytes(), &data); err != nil {
+			t.Fatal(err)
+		}
+		return data
+	}
+	settings := func(extra string) string {
+		return `{"language":"en","method":"egyptian","madhab":"shafii","high_latitude_rule":"angle_based","hijri_adjustment":0,
+			"adjustments":{"fajr":0,"sunrise":0,"dhuhr":0,"asr":0,"maghrib":0,"isha":0}` + extra + `}`
+	}
+
+	hidden := decode(send("/api/miniapp/settings", settings("")))
+	if len(hidden.Today.Extended) != 0 {
+		t.Fatalf("extended times must stay hidden by default: %+v", hidden.Today.Extended)
+	}
+
+	shown := decode(send("/api/miniapp/settings", settings(`,"imsak_minutes":15,"show_extended_times":true`)))
+	if shown.Profile == nil || shown.Profile.ImsakMinutes != 15 || !shown.Profile.ShowExtendedTimes {
+		t.Fatalf("response does not echo the extended-times settings: %+v", shown.Profile)
+	}
+	if len(shown.Today.Extended) != len(domain.ExtendedTimes()) || shown.Today.Extended[0].ID != domain.PrayerImsak {
+		t.Fatalf("unexpected extended times: %+v", shown.Today.Extended)
+	}
+	fajr, _ := time.Parse("15:04", shown.Today.Prayers[0].Time)
+	imsak, _ := time.Parse("15:04", shown.Today.Extended[0].Time)
+	if fajr.Sub(imsak) != 15*time.Minute {
+		t.Fatalf("Imsak %s should be 15 minutes before Fajr %s", shown.Today.Extended[0].Time, shown.Today.Prayers[0].Time)
+	}
+
+	// A cached client without the fields keeps the saved values.
+	decode(send("/api/miniapp/settings", settings("")))
+	if profile := storage.profiles[42]; profile.ImsakMinutes != 15 || !profile.ShowExtendedTimes {
+		t.Fatalf("stale client save changed the extended-times settings: %+v", profile)
+	}
+	if response := send("/api/miniapp/settings", settings(`,"imsak_minutes":61`)); response.Code != http.StatusBadRequest {
+		t.Fatalf("out-of-range Imsak margin should be rejected, got %d", response.Code)
+	}
+
+	reminders := `{"prayer":false,"pre_prayer_minutes":0,"fasting":false,"kahf":false,
+		"occasion_major":false,"occasion_fasting":false,"occasion_observed":false`
+	enabled := decode(send("/api/miniapp/reminders", reminders+`,"extended":{"last_third":true}}`))
+	if want := (extendedRemindersJSON{LastThird: true}); enabled.Reminders.Extended != want {
+		t.Fatalf("extended reminders = %+v, want %+v", enabled.Reminders.Extended, want)
+	}
+	if planner.rebuilds == 0 {
+		t.Fatal("enabling an extended-time reminder must rebuild the schedule")
+	}
+	stale := decode(send("/api/miniapp/reminders", reminders+`}`))
+	if !stale.Reminders.Extended.LastThird {
+		t.Fatalf("stale client save must not disable extended reminders: %+v", stale.Reminders)
+	}
+	disabled := decode(send("/api/miniapp/reminders", reminders+`,"extended":{}}`))
+	if disabled.Reminders.Extended != (extendedRemindersJSON{}) {
+		t.Fatalf("extended reminders were not disabled: %+v", disabled.Reminders.Extended)
+	}
+}
+
+func TestSettingsUpdateSavesCustomMethodParameters(t *testing.T) {
+	now := time.Date(2026, time.July, 17, 12, 0, 0, 0, time.UTC)
+	storage := newFakeStorage()
+	storage.chats[42] = domain.Chat{TelegramChatID: 42, Type: "private", LanguageCode: "en"}
+	storage.profiles[42] = domain.PrayerProfile{
+		ChatID: 42, Latitude: 48.857, Longitude: 2.352, Timezone: "Europe/Paris",
+		Method: domain.MethodMWL, Madhab: domain.MadhabShafii,
+		HighLatitudeRule: domain.HighLatitudeAngleBased,
+	}
+	handler := NewHandler("test-token", storage, nil, prayertime.New(), &fakePlanner{}, nil)
+	handler.now = func() time.Time { return now }
+	mux := http.NewServeMux()
+	handler.Register(mux)
+	send := func(custom string) *httptest.ResponseRecorder {
+		t.Helper()
+		body := `{"language":"fr","method":"custom","madhab":"shafii","high_latitude_rule":"angle_based","hijri_adjustment":0,
+			"adjustments":{"fajr":0,"sunrise":0,"dhuhr":0,"asr":0,"maghrib":0,"isha":0}` + custom + `}`
+		request := httptest.NewRequest(http.MethodPut, "/api/miniapp/settings", strings.NewReader(body))
+		request.Header.Set("X-Telegram-Init-Data", signedInitData(t, "test-token", now, initDataUser{ID: 42, FirstName: "Amina", LanguageCode: "fr"}))
+		response := httptest.NewRecorder()
+		mux.ServeHTTP(response, request)
+		return response
+	}
+
+	// A cached client without the field starts from the default angles.
+	if response := send(""); response.Code != http.StatusOK {
+		t.Fatalf("status = %d, body = %s", response.Code, response.Body.String())
+	}
+	if got := storage.profiles[42].Custom; got != domain.DefaultCustomMethod() {
+		t.Fatalf("custom method should start from the defaults, got %+v", got)
+	}
+
+	response := send(`,"custom":{"fajr_angle":12,"isha_angle":12,"isha_interval_minutes":90}`)
+	if response.Code != http.StatusOK {
+		t.Fatalf("status = %d, body = %s", response.Code, response.Body.String())
+	}
+	want := domain.CustomMethod{FajrAngle: 12, IshaAngle: 12, IshaIntervalMinutes: 90}
+	if profile := storage.profiles[42]; profile.Method != domain.MethodCustom || profile.Custom != want {
+		t.Fatalf("custom parameters were not saved: %+v", profile)
+	}
+	var data bootstrapResponse
+	if err := json.Unmarshal(response.Body.Bytes(), &data); err != nil {
+		t.Fatal(err)
+	}
+	if data.Profile == nil || data.Profile.Custom.FajrAngle != 12 || data.Profile.Custom.IshaIntervalMinutes != 90 {
+		t.Fatalf("response does not echo the custom parameters: %+v", data.Profile)
+	}
+
+	if response := send(`,"custom":{"fajr_angle":30,"isha_angle":12,"isha_interval_minutes":0}`); response.Code != http.StatusBadRequest ||
+		!strings.Contains(response.Body.String(), "invalid_custom_method") {
+		t.Fatalf("out-of-range angle should be rejected, got %d %s", response.Code, response.Body.String())
+	}
+	if storage.profiles[42].Custom != want {
+		t.Fatalf("a rejected request must not change the profile: %+v", storage.profiles[42].Custom)
+	}
+}
+
+func TestSettingsUpdateSavesHijriCalendar(t *testing.T) {
+	now := time.Date(2026, time.July, 17, 12, 0, 0, 0, time.UTC)
+	storage := newFakeStorage()
+	storage.chats[42] = domain.Chat{TelegramChatID: 42, Type: "private", LanguageCode: "tr"}
+	storage.profiles[42] = domain.PrayerProfile{
+		ChatID: 42, Latitude: 41.008, Longitude: 28.978, Timezone: "Europe/Istanbul",
+		Method: domain.MethodDiyanet, Madhab: domain.MadhabHanafi,
+		HighLatitudeRule: domain.HighLatitudeAngleBased,
+	}
+	handler := NewHandler("test-token", storage, nil, prayertime.New(), &fakePlanner{}, nil)
+	handler.now = func() time.Time { return now }
+	mux := http.NewServeMux()
+	handler.Register(mux)
+	send := func(extra string) *httptest.ResponseRecorder {
+		t.Helper()
+		body := `{"language":"tr","method":"diyanet","madhab":"hanafi","high_latitude_rule":"angle_based","hijri_adjustment":0,
+			"adjustments":{"fajr":0,"sunrise":0,"dhuhr":0,"asr":0,"maghrib":0,"isha":0}` + extra + `}`
+		request := httptest.NewRequest(http.MethodPut, "/api/miniapp/settings", strings.NewReader(body))
+		request.Header.Set("X-Telegram-Init-Data", signedInitData(t, "test-token", now, initDataUser{ID: 42, FirstName: "Elif", LanguageCode: "tr"}))
+		response := httptest.NewRecorder()
+		mux.ServeHTTP(response, request)
+		return response
+	}
+
+	response := send(`,"hijri_calendar":"diyanet"`)
+	if response.Code != http.StatusOK {
+		t.Fatalf("status = %d, body = %s", response.Code, response.Body.String())
+	}
+	var data bootstrapResponse
+	if err := json.Unmarshal(response.Body.Bytes(), &data); err != nil {
+		t.Fatal(err)
+	}
+	if data.Profile == nil || data.Profile.HijriCalendar != "diyanet" || len(data.Options.Hijri) != len(domain.SupportedHijriCalendars()) {
+		t.Fatalf("response does not echo the Hijri calendar: %+v", data.Profile)
+	}
+	// A cached client without the field keeps the saved calendar.
+	if response := send(""); response.Code != http.StatusOK {
+		t.Fatalf("status = %d, body = %s", response.Code, response.Body.String())
+	}
+	if got := storage.profiles[42].HijriCalendar; got != domain.HijriDiyanet {
+		t.Fatalf("stale client save changed the Hijri calendar to %q", got)
+	}
+	if response := send(`,"hijri_calendar":"julian"`); response.Code != http.StatusBadRequest {
+		t.Fatalf("unknown calendar should be rejected, got %d", response.Code)
+	}
+}
+
+func TestParsePrecautionRequiresEveryPrayerAndSupportedValues(t *testing.T) {
+	rounding := map[string]string{"fajr": "down", "sunrise": "nearest", "dhuhr": "nearest", "asr": "nearest", "maghrib": "up", "isha": "nearest"}
+	precaution, err := parsePrecaution(precautionJSON{IhtiyatMinutes: 2, Rounding: rounding})
+	if err != nil || precaution.IhtiyatMinutes != 2 || precaution.Fajr != domain.RoundDown || precaution.Maghrib != domain.RoundUp {
+		t.Fatalf("unexpected precaution: %+v, %v", precaution, err)
+	}
+	if got := precautionResponse(precaution); got.Rounding["maghrib"] != "up" || got.Rounding["asr"] != "nearest" {
+		t.Fatalf("response = %+v", got)
+	}
+	if _, err := parsePrecaution(precautionJSON{Rounding: map[string]string{"fajr": "down"}}); err == nil {
+		t.Fatal("expected an incomplete rounding snapshot to fail")
+	}
+	rounding["isha"] = "sideways"
+	if _, err := parsePrecaution(precautionJSON{Rounding: rounding}); err == nil {
+		t.Fatal("expected an unsupported rounding to fail")
+	}
+	rounding["isha"] = "nearest"
+	if _, err := parsePrecaution(precautionJSON{IhtiyatMinutes: domain.MaxIhtiyatMinutes + 1, Rounding: rounding}); err == nil {
+		t.Fatal("expected an out-of-range ihtiyat to fail")
+	}
+}
+
 func TestParseAdjustmentsRequiresCompleteSnapshot(t *testing.T) {
 	if _, err := parseAdjustments(map[string]int{"fajr": 1}); err == nil {
 		t.Fatal("expected an incomplete adjustment snapshot to fail")
@@ -860,10 +1760,20 @@ type fakeStorage struct {
 	chats         map[int64]domain.Chat
 	profiles      map[int64]domain.PrayerProfile
 	rules         map[int64][]domain.ReminderRule
-	subscriptions map[int64]domain.CalendarSubscription
+	subscriptions map[subscriptionKey]domain.CalendarSubscription
 	metalPrices   *domain.MetalPrices
+	fasts         map[string]domain.FastLogEntry
+	qadaCarried   int
+	customs       []domain.CustomReminder
 }
 
+type subscriptionKey struct {
+	chatID int64
+	feed   domain.CalendarFeed
+}
+
+var prayerFeed = subscriptionKey{42, domain.CalendarFeedPrayer}
+
 type fakePhotoSender struct {
 	chatID   int64
 	filename string
@@ -889,11 +1799,31 @@ func (s *fakePhotoSender) SendPhoto(_ context.Context, params *botapi.SendPhotoP
 	return &models.Message{}, nil
 }
 
+func (s *fakePhotoSender) SendDocument(_ context.Context, params *botapi.SendDocumentParams) (*models.Message, error) {
+	chatID, ok := params.ChatID.(int64)
+	if !ok {
+		return nil, fmt.Errorf("unexpected chat ID type %T", params.ChatID)
+	}
+	upload, ok := params.Document.(*models.InputFileUpload)
+	if !ok {
+		return nil, fmt.Errorf("unexpected document type %T", params.Document)
+	}
+	content, err := io.ReadAll(upload.Data)
+	if err != nil {
+		return nil, err
+	}
+	s.chatID = chatID
+	s.filename = upload.Filename
+	s.content = content
+	return &models.Message{}, nil
+}
+
 func newFakeStorage() *fakeStorage {
 	return &fakeStorage{
 		chats: make(map[int64]domain.Chat), profiles: make(map[int64]domain.PrayerProfile),
 		rules:         make(map[int64][]domain.ReminderRule),
-		subscriptions: make(map[int64]domain.CalendarSubscription),
+		subscriptions: make(map[subscriptionKey]domain.CalendarSubscription),
+		fasts:         make(map[string]domain.FastLogEntry),
 	}
 }
 
@@ -1013,8 +1943,36 @@ func (s *fakeStorage) SetOccasionRule(_ context.Context, chatID int64, kind doma
 	return nil
 }
 
-func (s *fakeStorage) CalendarSubscription(_ context.Context, chatID int64) (domain.CalendarSubscription, error) {
-	subscription, ok := s.subscriptions[chatID]
+func (s *fakeStorage) SetRamadanRule(_ context.Context, chatID int64, kind domain.ReminderKind, minutes int, enabled bool) error {
+	for index := range s.rules[chatID] {
+		rule := &s.rules[chatID][index]
+		if rule.Kind == kind {
+			rule.OffsetMinutes, rule.Enabled = minutes, enabled
+			return nil
+		}
+	}
+	s.rules[chatID] = append(s.rules[chatID], domain.ReminderRule{
+		ChatID: chatID, Kind: kind, OffsetMinutes: minutes, Enabled: enabled,
+	})
+	return nil
+}
+
+func (s *fakeStorage) SetExtendedTimeRule(_ context.Context, chatID int64, prayer domain.Prayer, enabled bool) error {
+	for index := range s.rules[chatID] {
+		rule := &s.rules[chatID][index]
+		if rule.Kind == domain.ReminderExtendedTime && rule.Prayer == prayer {
+			rule.Enabled = enabled
+			return nil
+		}
+	}
+	s.rules[chatID] = append(s.rules[chatID], domain.ReminderRule{
+		ChatID: chatID, Kind: domain.ReminderExtendedTime, Prayer: prayer, Enabled: enabled,
+	})
+	return nil
+}
+
+func (s *fakeStorage) CalendarSubscription(_ context.Context, chatID int64, feed domain.CalendarFeed) (domain.CalendarSubscription, error) {
+	subscription, ok := s.subscriptions[subscriptionKey{chatID, feed}]
 	if !ok {
 		return domain.CalendarSubscription{}, domain.ErrNotFound
 	}
@@ -1036,31 +1994,107 @@ func (s *fakeStorage) CalendarSubscriptionByToken(
 func (s *fakeStorage) EnableCalendarSubscription(
 	_ context.Context,
 	chatID int64,
+	feed domain.CalendarFeed,
 	feedToken string,
 	uidNamespace string,
 ) (domain.CalendarSubscription, error) {
-	subscription, ok := s.subscriptions[chatID]
+	key := subscriptionKey{chatID, feed}
+	subscription, ok := s.subscriptions[key]
 	if !ok {
 		subscription = domain.CalendarSubscription{
-			ChatID: chatID, FeedToken: feedToken, UIDNamespace: uidNamespace,
+			ChatID: chatID, Feed: feed, FeedToken: feedToken, UIDNamespace: uidNamespace,
 		}
 	} else if !subscription.Enabled {
 		subscription.FeedToken = feedToken
 	}
 	subscription.Enabled = true
-	s.subscriptions[chatID] = subscription
+	s.subscriptions[key] = subscription
 	return subscription, nil
 }
 
-func (s *fakeStorage) DisableCalendarSubscription(_ context.Context, chatID int64) error {
-	subscription, ok := s.subscriptions[chatID]
+func (s *fakeStorage) DisableCalendarSubscription(_ context.Context, chatID int64, feed domain.CalendarFeed) error {
+	key := subscriptionKey{chatID, feed}
+	subscription, ok := s.subscriptions[key]
 	if ok && subscription.Enabled {
 		subscription.Enabled = false
-		s.subscriptions[chatID] = subscription
+		s.subscriptions[key] = subscription
+	}
+	return nil
+}
+
+func (s *fakeStorage) LogFast(_ context.Context, entry domain.FastLogEntry) error {
+	s.fasts[entry.Date.Format(time.DateOnly)] = entry
+	return nil
+}
+
+func (s *fakeStorage) ClearFast(_ context.Context, _ int64, date time.Time) error {
+	delete(s.fasts, date.Format(time.DateOnly))
+	return nil
+}
+
+func (s *fakeStorage) FastLog(_ context.Context, _ int64, from, to time.Time) ([]domain.FastLogEntry, error) {
+	var entries []domain.FastLogEntry
+	for _, entry := range s.fasts {
+		if !entry.Date.Before(from) && !entry.Date.After(to) {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, nil
+}
+
+func (s *fakeStorage) FastingBalance(context.Context, int64) (domain.FastingBalance, error) {
+	balance := domain.FastingBalance{Carried: s.qadaCarried}
+	for _, entry := range s.fasts {
+		switch {
+		case entry.Owed():
+			balance.Missed++
+		case entry.Status == domain.FastMakeUp:
+			balance.MadeUp++
+		case entry.Status == domain.FastKept:
+			balance.Kept++
+		}
 	}
+	return balance, nil
+}
+
+func (s *fakeStorage) SetQadaCarried(_ context.Context, _ int64, days int) error {
+	s.qadaCarried = days
 	return nil
 }
 
+func (s *fakeStorage) CustomReminders(context.Context, int64) ([]domain.CustomReminder, error) {
+	return s.customs, nil
+}
+
+func (s *fakeStorage) AddCustomReminder(_ context.Context, reminder domain.CustomReminder) (domain.CustomReminder, error) {
+	if len(s.customs) >= domain.MaxCustomReminders {
+		return domain.CustomReminder{}, domain.ErrCustomReminderLimit
+	}
+	reminder.RuleID = int64(100 + len(s.customs))
+	s.customs = append(s.customs, reminder)
+	return reminder, nil
+}
+
+func (s *fakeStorage) UpdateCustomReminder(_ context.Context, reminder domain.CustomReminder) error {
+	for index, custom := range s.customs {
+		if custom.RuleID == reminder.RuleID && custom.ChatID == reminder.ChatID {
+			s.customs[index] = reminder
+			return nil
+		}
+	}
+	return domain.ErrNotFound
+}
+
+func (s *fakeStorage) DeleteCustomReminder(_ context.Context, chatID, ruleID int64) error {
+	for index, custom := range s.customs {
+		if custom.RuleID == ruleID && custom.ChatID == chatID {
+			s.customs = append(s.customs[:index], s.customs[index+1:]...)
+			return nil
+		}
+	}
+	return domain.ErrNotFound
+}
+
 type fakeResolver struct {
 	resolved            domain.ResolvedLocation
 	latitude, longitude float64
@@ -1071,6 +2105,15 @@ func (r *fakeResolver) Resolve(_ context.Context, latitude, longitude float64) (
 	return r.resolved, nil
 }
 
+type elevationResolver struct {
+	fakeResolver
+	meters float64
+}
+
+func (r *elevationResolver) Elevation(context.Context, float64, float64) (float64, error) {
+	return r.meters, nil
+}
+
 func (r *fakeResolver) Search(context.Context, string, string) ([]domain.LocationCandidate, error) {
 	return nil, nil
 }
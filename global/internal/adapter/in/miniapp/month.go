@@ -0,0 +1,94 @@
+package miniapp
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+
+	botapi "github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+
+	"github.com/escalopa/prayer-bot/global/internal/core/i18n"
+	"github.com/escalopa/prayer-bot/global/internal/core/monthsheet"
+	"github.com/escalopa/prayer-bot/global/internal/domain"
+)
+
+type monthRequest struct {
+	Format string `json:"format"`
+	Month  string `json:"month"`
+}
+
+// monthTimetable sends the file to the user's Telegram chat, because WebView
+// downloads are unreliable on Android. An empty month means the current one
+// in the profile's timezone.
+func (h *Handler) monthTimetable(w http.ResponseWriter, r *http.Request, identity Identity) error {
+	if h.documentSender == nil {
+		return fmt.Errorf("monthly timetable sender is unavailable")
+	}
+	var request monthRequest
+	if err := decodeJSON(w, r, &request); err != nil {
+		return badRequest("invalid_request")
+	}
+	format, ok := monthsheet.ParseFormat(request.Format)
+	if !ok {
+		return badRequest("invalid_format")
+	}
+	profile, locale, err := h.sheetOwner(r.Context(), identity)
+	if err != nil {
+		return err
+	}
+	month := h.now()
+	if request.Month != "" {
+		if month, err = monthsheet.ParseMonth(request.Month, h.now(), profile.Timezone); err != nil {
+			return badRequest("invalid_month")
+		}
+	}
+	sheet, err := monthsheet.Build(r.Context(), h.calculator, profile, month)
+	if err != nil {
+		return fmt.Errorf("build monthly timetable: %w", err)
+	}
+	if err := h.sendSheet(r.Context(), identity.UserID, sheet, format, locale); err != nil {
+		return err
+	}
+	return writeJSON(w, map[string]string{"status": "sent"})
+}
+
+// sheetOwner loads the profile a timetable is calculated for and the chat
+// language it is labelled in.
+func (h *Handler) sheetOwner(ctx context.Context, identity Identity) (domain.PrayerProfile, i18n.Locale, error) {
+	profile, err := h.store.Profile(ctx, identity.UserID)
+	if domain.IsNotFound(err) {
+		return domain.PrayerProfile{}, i18n.Locale{}, conflict("location_required")
+	} else if err != nil {
+		return domain.PrayerProfile{}, i18n.Locale{}, fmt.Errorf("load profile: %w", err)
+	}
+	locale := i18n.Resolve(identity.LanguageCode)
+	if chat, err := h.store.Chat(ctx, identity.UserID); err == nil {
+		locale = i18n.Resolve(chat.LanguageCode)
+	} else if !domain.IsNotFound(err) {
+		return domain.PrayerProfile{}, i18n.Locale{}, fmt.Errorf("load chat: %w", err)
+	}
+	return profile, locale, nil
+}
+
+// sendSheet renders a timetable, monthly or Ramadan, and uploads it to the
+// user's chat.
+func (h *Handler) sendSheet(ctx context.Context, chatID int64, sheet monthsheet.Month, format monthsheet.Format, locale i18n.Locale) error {
+	file, err := monthsheet.Render(sheet, locale, format)
+	if err != nil {
+		return fmt.Errorf("render timetable: %w", err)
+	}
+	title, subtitle := monthsheet.Heading(sheet, locale)
+	if _, err := h.documentSender.SendDocument(ctx, &botapi.SendDocumentParams{
+		ChatID: chatID,
+		Document: &models.InputFileUpload{
+			Filename: sheet.Filename(format),
+			Data:     bytes.NewReader(file),
+		},
+		Caption: title + " 🗓\n" + subtitle,
+	}); err != nil {
+		return fmt.Errorf("send timetable to Telegram chat: %w", err)
+	}
+	return nil
+}
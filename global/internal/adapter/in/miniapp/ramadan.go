@@ -0,0 +1,100 @@
+package miniapp
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/escalopa/prayer-bot/global/internal/core/i18n"
+	"github.com/escalopa/prayer-bot/global/internal/core/monthsheet"
+	"github.com/escalopa/prayer-bot/global/internal/domain"
+)
+
+type ramadanResponse struct {
+	Title    string               `json:"title"`
+	Subtitle string               `json:"subtitle"`
+	Days     []ramadanDayResponse `json:"days"`
+}
+
+type ramadanDayResponse struct {
+	HijriDay  int    `json:"hijri_day"`
+	Gregorian string `json:"gregorian"`
+	Weekday   string `json:"weekday"`
+	Imsak     string `json:"imsak"`
+	Suhoor    string `json:"suhoor"`
+	Iftar     string `json:"iftar"`
+	Today     bool   `json:"today"`
+}
+
+type ramadanRequest struct {
+	Format string `json:"format"`
+}
+
+// ramadanTimetable sends the current or next Ramadan to the user's chat, the
+// same way monthTimetable does.
+func (h *Handler) ramadanTimetable(w http.ResponseWriter, r *http.Request, identity Identity) error {
+	if h.documentSender == nil {
+		return fmt.Errorf("Ramadan timetable sender is unavailable")
+	}
+	var request ramadanRequest
+	if err := decodeJSON(w, r, &request); err != nil {
+		return badRequest("invalid_request")
+	}
+	format, ok := monthsheet.ParseFormat(request.Format)
+	if !ok {
+		return badRequest("invalid_format")
+	}
+	profile, locale, err := h.sheetOwner(r.Context(), identity)
+	if err != nil {
+		return err
+	}
+	sheet, err := monthsheet.BuildRamadan(r.Context(), h.calculator, profile, h.now())
+	if err != nil {
+		return fmt.Errorf("build Ramadan timetable: %w", err)
+	}
+	if err := h.sendSheet(r.Context(), identity.UserID, sheet, format, locale); err != nil {
+		return err
+	}
+	return writeJSON(w, map[string]string{"status": "sent"})
+}
+
+// ramadanDays answers with the current or next Ramadan. The app asks for it
+// when the dates view opens rather than on every bootstrap, because finding
+// Ramadan can scan most of a Hijri year.
+func (h *Handler) ramadanDays(w http.ResponseWriter, r *http.Request, identity Identity) error {
+	profile, locale, err := h.sheetOwner(r.Context(), identity)
+	if err != nil {
+		return err
+	}
+	now := h.now()
+	sheet, err := monthsheet.BuildRamadan(r.Context(), h.calculator, profile, now)
+	if err != nil {
+		return fmt.Errorf("build Ramadan timetable: %w", err)
+	}
+	return writeJSON(w, formatRamadan(sheet, now, locale))
+}
+
+func formatRamadan(sheet monthsheet.Month, now time.Time, locale i18n.Locale) ramadanResponse {
+	title, subtitle := monthsheet.Heading(sheet, locale)
+	response := ramadanResponse{Title: title, Subtitle: subtitle}
+	today := now.In(sheet.Start.Location()).Format("2006-01-02")
+	for _, day := range sheet.Days {
+		response.Days = append(response.Days, ramadanDayResponse{
+			HijriDay:  day.Hijri.Day,
+			Gregorian: fmt.Sprintf("%d %s", day.Date.Day(), locale.Month(int(day.Date.Month()))),
+			Weekday:   locale.Weekday(day.Date.Weekday()),
+			Imsak:     clock(day, domain.PrayerImsak),
+			Suhoor:    clock(day, domain.PrayerFajr),
+			Iftar:     clock(day, domain.PrayerMaghrib),
+			Today:     day.Date.Format("2006-01-02") == today,
+		})
+	}
+	return response
+}
+
+func clock(day monthsheet.Day, prayer domain.Prayer) string {
+	if at, ok := day.Times[prayer]; ok && !at.IsZero() {
+		return at.Format("15:04")
+	}
+	return ""
+}
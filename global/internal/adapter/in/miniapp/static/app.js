@@ -8,7 +8,19 @@
   let toastTimer = null;
   let dirty = false;
   let compassStarted = false;
-  let calendarFeedURL = "";
+  // Each calendar feed keeps its private link in memory only, and its
+  // controls share the prefix of their element IDs.
+  const calendarFeeds = {
+    prayer: { key: "calendar", prefix: "calendar", url: "" },
+    fasting: { key: "fasting_calendar", prefix: "fasting-calendar", url: "" },
+  };
+  let fastingMonth = "";
+  let fastingLoaded = false;
+  let ramadanLoaded = false;
+  let fastingDays = [];
+  let fastingSelected = "";
+  // customEditing is the ID of the custom reminder in the form, 0 for a new one.
+  let customEditing = 0;
   let offlineMode = false;
   let homeScreenStatus = "unknown";
   let zakatCurrency = "";
@@ -144,8 +156,9 @@
     // The cache key is already scoped to Telegram's signed user. The numeric
     // account identifier is unnecessary for rendering and is not persisted.
     if (snapshot.user) delete snapshot.user.id;
-    // The calendar path is a bearer credential and must remain memory-only.
+    // Calendar paths are bearer credentials and must remain memory-only.
     if (snapshot.calendar) delete snapshot.calendar.path;
+    if (snapshot.fasting_calendar) delete snapshot.fasting_calendar.path;
     return snapshot;
   }
 
@@ -202,29 +215,91 @@
     setText("occasions-title", labels.occasions_title);
     setText("occasions-help", labels.occasions_help);
     setText("occasions-disclaimer", labels.occasions_disclaimer);
+    setText("convert-title", labels.convert_title);
+    setText("convert-help", labels.convert_help);
+    setText("convert-submit", labels.convert_action);
     setText("occasion-major-reminders-label", labels.occasion_major_reminders);
     setText("occasion-fasting-reminders-label", labels.occasion_fasting_reminders);
     setText("occasion-observed-reminders-label", labels.occasion_observed_reminders);
     ["occasion-major-schedule", "occasion-fasting-schedule", "occasion-observed-schedule"]
       .forEach((id) => setText(id, labels.occasion_schedule));
+    setText("personal-event-reminders-label", labels.personal_event_reminders);
+    setText("personal-event-schedule", labels.personal_event_schedule);
     setText("language-label", labels.language);
     setText("method-label", labels.method);
     setText("madhab-label", labels.madhab);
     setText("highlat-label", labels.highlat);
     setText("hijri-label", labels.hijri);
+    setText("hijri-calendar-label", labels.hijri_calendar);
+    setText("custom-fajr-label", labels.custom_fajr_angle);
+    setText("custom-isha-label", labels.custom_isha_angle);
+    setText("custom-interval-label", labels.custom_isha_interval);
+    setText("extended-times-title", labels.extended_times_title);
+    setText("show-extended-label", labels.show_extended_times);
+    setText("imsak-label", labels.imsak);
+    setText("extended-reminders-label", labels.extended_reminders);
+    setText("suhoor-reminder-label", `${labels.ramadan_reminders} · ${labels.ramadan_suhoor}`);
+    setText("iftar-reminders-label", labels.iftar_reminder);
+    setText("ramadan-schedule", labels.ramadan_schedule);
+    setText("ramadan-help", labels.ramadan_help);
+    setText("ramadan-imsak-label", labels.imsak);
+    setText("ramadan-suhoor-label", labels.ramadan_suhoor);
+    setText("ramadan-iftar-label", labels.ramadan_iftar);
+    setText("makruh-title", labels.makruh_title);
+    setText("makruh-label", labels.makruh_title);
+    setText("show-makruh-label", labels.show_makruh);
+    setText("moon-events-label", labels.moon_events);
+    setText("moon-events-help", labels.moon_events_help);
+    setText("timetable-label", labels.timetable_title);
+    setText("elevation-label", labels.elevation);
     setText("adjustments-label", labels.adjustments);
+    setText("precaution-label", labels.precaution);
     setText("save-preferences", labels.save);
     setText("calculation-note", labels.calculated_locally);
     setText("tools-title", labels.tools);
     setText("qibla-title", labels.qibla_title);
     setText("qibla-help", labels.qibla_help);
     setText("start-compass", labels.compass_start);
+    setText("month-title", labels.month_title);
+    setText("month-help", labels.month_help);
     setText("calendar-title", labels.calendar_title);
     setText("calendar-help", labels.calendar_help);
     setText("calendar-private", labels.calendar_private);
     setText("connect-calendar", labels.calendar_connect);
     setText("copy-calendar-link", labels.calendar_copy);
     setText("disconnect-calendar", labels.calendar_disconnect);
+    setText("fasting-title", labels.fasting_title);
+    setText("fasting-previous", labels.fasting_previous);
+    setText("fasting-next", labels.fasting_next);
+    setText("fasting-obligatory", labels.fasting_obligatory);
+    setText("fasting-recommended", labels.fasting_recommended);
+    setText("fasting-prohibited", labels.fasting_prohibited);
+    setText("fasting-note", labels.fasting_note);
+    setText("fasting-calendar-help", labels.fasting_calendar_help);
+    setText("fasts-title", labels.fasts_title);
+    setText("fasting-kept-label", labels.fasts_kept);
+    setText("fasting-made-up-label", labels.fasts_made_up);
+    setText("fasting-missed-label", labels.fasts_missed);
+    setText("fasting-owed-label", labels.fasts_owed);
+    setText("fasting-carried-label", labels.fasts_carried);
+    setText("fasting-carried-save", labels.save);
+    setText("fasting-suggestions-title", labels.fasts_suggestions);
+    setText("fast-log-fasted", labels.fasts_fasting);
+    setText("fast-log-makeup", labels.fasts_makeup);
+    setText("fast-log-missed", labels.fasts_not_today);
+    setText("fast-log-clear", labels.fasts_clear);
+    setText("custom-reminders-title", labels.custom_title);
+    setText("custom-reminders-empty", labels.custom_empty);
+    setText("custom-text-label", labels.custom_text);
+    setText("custom-clock-label", labels.custom_clock);
+    setText("custom-prayer-label", labels.custom_prayer);
+    setText("custom-offset-label", labels.custom_offset);
+    setText("custom-repeat-label", labels.custom_repeat);
+    setText("custom-weekdays-label", labels.custom_weekdays);
+    setText("custom-hijri-day-label", labels.custom_hijri_day);
+    setText("custom-date-label", labels.custom_date);
+    setText("custom-save", customEditing ? labels.save : labels.custom_add);
+    setText("custom-cancel", labels.custom_cancel);
     setText("home-screen-title", labels.home_title);
     setText("home-screen-help", labels.home_help);
     setText("add-home-screen", homeScreenStatus === "added" ? labels.home_added : labels.home_add);
@@ -268,9 +343,26 @@
     fillSelect("method", state.options.methods, profile.method);
     fillSelect("madhab", state.options.madhabs, profile.madhab);
     fillSelect("highlat", state.options.high_latitude, profile.high_latitude_rule);
+    fillSelect("hijri-calendar", state.options.hijri_calendars || [], profile.hijri_calendar || "umm_al_qura");
     fillSelect("hijri-adjustment", [-2, -1, 0, 1, 2].map((value) => ({
       value: String(value), label: value > 0 ? `+${value}` : String(value),
     })), profile.hijri_adjustment);
+    const custom = profile.custom || { fajr_angle: 18, isha_angle: 17, isha_interval_minutes: 0 };
+    byId("custom-fajr-angle").value = String(custom.fajr_angle);
+    byId("custom-isha-angle").value = String(custom.isha_angle);
+    byId("custom-isha-interval").value = String(custom.isha_interval_minutes);
+    syncCustomMethod();
+    byId("show-extended-times").checked = Boolean(profile.show_extended_times);
+    fillSelect("imsak-minutes", state.options.imsak_minutes || [], profile.imsak_minutes);
+    byId("show-makruh-times").checked = !profile.hide_makruh_times;
+    byId("moon-events").checked = Boolean(profile.moon_events);
+    // The toggle only shows where an official timetable covers the profile.
+    byId("official-timetable-row").classList.toggle("hidden", !profile.timetable);
+    setText("timetable-help", profile.timetable
+      ? `${state.labels.use_timetable} · ${formatLabel(state.labels.timetable_covers, { name: profile.timetable })}`
+      : "");
+    byId("use-timetable").checked = !profile.ignore_timetables;
+    byId("elevation-meters").value = String(profile.elevation_meters || 0);
 
     const names = {};
     [...state.today.prayers].forEach((prayer) => { names[prayer.id] = prayer.name; });
@@ -289,16 +381,74 @@
       label.append(input);
       grid.append(label);
     });
+
+    const precaution = profile.precaution || { ihtiyat_minutes: 0, rounding: {} };
+    fillSelect("ihtiyat-minutes", state.options.ihtiyat_minutes || [], precaution.ihtiyat_minutes);
+    const roundings = byId("rounding-grid");
+    roundings.replaceChildren();
+    ["fajr", "sunrise", "dhuhr", "asr", "maghrib", "isha"].forEach((prayer) => {
+      const label = document.createElement("label");
+      label.textContent = names[prayer] || prayer;
+      const select = document.createElement("select");
+      select.dataset.prayer = prayer;
+      (state.options.roundings || []).forEach((item) => {
+        const option = document.createElement("option");
+        option.value = item.value;
+        option.textContent = item.label;
+        option.selected = item.value === (precaution.rounding[prayer] || "nearest");
+        select.append(option);
+      });
+      label.append(select);
+      roundings.append(label);
+    });
+  }
+
+  // The custom angles only apply to the "custom" method, so the inputs stay
+  // hidden for presets but keep their values for switching back.
+  function syncCustomMethod() {
+    byId("custom-method").classList.toggle("hidden", byId("method").value !== "custom");
   }
 
   function renderSchedule() {
     const schedule = state[activeDay];
     setText("gregorian-date", schedule.gregorian);
     setText("hijri-date", `☾ ${schedule.hijri}`);
-    setText("timezone", schedule.timezone);
-    const grid = byId("prayer-grid");
+    setText("timezone", schedule.timetable ? `${schedule.timezone} · ${schedule.timetable}` : schedule.timezone);
+    fillPrayerGrid(byId("prayer-grid"), schedule.prayers);
+    const extended = schedule.extended || [];
+    fillPrayerGrid(byId("extended-grid"), extended);
+    byId("extended-times").classList.toggle("hidden", extended.length === 0);
+    const makruh = schedule.makruh || [];
+    const makruhList = byId("makruh-list");
+    makruhList.replaceChildren();
+    makruh.forEach((item) => {
+      const row = document.createElement("div");
+      row.className = "makruh-row";
+      const name = document.createElement("span");
+      name.textContent = `🚫 ${item.name}`;
+      const span = document.createElement("strong");
+      span.textContent = `${item.start}–${item.end}`;
+      row.append(name, span);
+      makruhList.append(row);
+    });
+    byId("makruh-times").classList.toggle("hidden", makruh.length === 0);
+    // Cached schedules from older versions have no moon.
+    const moon = schedule.moon;
+    byId("moon-card").classList.toggle("hidden", !moon);
+    if (moon) {
+      setText("moon-emoji", moon.emoji);
+      setText("moon-summary", moon.summary);
+      setText("moon-crescent", moon.crescent || "");
+      byId("moon-crescent").classList.toggle("hidden", !moon.crescent);
+    }
+    setText("share-preview-date", schedule.gregorian);
+    const nextPrayer = schedule.prayers.find((prayer) => prayer.time) || schedule.prayers[0];
+    setText("share-preview-time", nextPrayer ? `${nextPrayer.name} · ${nextPrayer.time}` : "");
+  }
+
+  function fillPrayerGrid(grid, prayers) {
     grid.replaceChildren();
-    schedule.prayers.forEach((prayer) => {
+    prayers.forEach((prayer) => {
       const item = document.createElement("div");
       item.className = "prayer";
       const emoji = document.createElement("span");
@@ -313,9 +463,6 @@
       item.append(emoji, name, time);
       grid.append(item);
     });
-    setText("share-preview-date", schedule.gregorian);
-    const nextPrayer = schedule.prayers.find((prayer) => prayer.time) || schedule.prayers[0];
-    setText("share-preview-time", nextPrayer ? `${nextPrayer.name} · ${nextPrayer.time}` : "");
   }
 
   function formatLabel(template, values) {
@@ -339,17 +486,18 @@
     byId("start-compass").disabled = false;
     setText("start-compass", state.labels.compass_start);
     byId("compass-status").classList.add("hidden");
-    renderCalendarSubscription();
+    Object.keys(calendarFeeds).forEach(renderCalendarSubscription);
     renderHomeScreen();
   }
 
-  function renderCalendarSubscription() {
-    const enabled = Boolean(state.calendar && state.calendar.enabled);
-    byId("disconnect-calendar").classList.toggle("hidden", !enabled);
-    byId("calendar-status").classList.add("hidden");
-    setText("connect-calendar", state.labels.calendar_connect);
-    setText("copy-calendar-link", state.labels.calendar_copy);
-    setText("disconnect-calendar", state.labels.calendar_disconnect);
+  function renderCalendarSubscription(name) {
+    const feed = calendarFeeds[name];
+    const enabled = Boolean(state[feed.key] && state[feed.key].enabled);
+    byId(`disconnect-${feed.prefix}`).classList.toggle("hidden", !enabled);
+    byId(`${feed.prefix}-status`).classList.add("hidden");
+    setText(`connect-${feed.prefix}`, state.labels.calendar_connect);
+    setText(`copy-${feed.prefix}-link`, state.labels.calendar_copy);
+    setText(`disconnect-${feed.prefix}`, state.labels.calendar_disconnect);
   }
 
   function updateHomeScreenStatus(status) {
@@ -392,9 +540,176 @@
     byId("occasion-major-reminders").checked = state.reminders.occasion_major;
     byId("occasion-fasting-reminders").checked = state.reminders.occasion_fasting;
     byId("occasion-observed-reminders").checked = state.reminders.occasion_observed;
+    byId("personal-event-reminders").checked = Boolean(state.reminders.personal_events);
+    fillSelect("suhoor-minutes", state.options.suhoor_minutes || [], state.reminders.suhoor_minutes || 0);
+    byId("iftar-reminders").checked = Boolean(state.reminders.iftar);
+    renderExtendedReminders();
     syncPreReminderAvailability();
   }
 
+  function renderExtendedReminders() {
+    const enabled = state.reminders.extended || {};
+    const list = byId("extended-reminders");
+    list.replaceChildren();
+    (state.options.extended_times || []).forEach((option) => {
+      const row = document.createElement("label");
+      row.className = "toggle-row";
+      const text = document.createElement("span");
+      const name = document.createElement("strong");
+      name.textContent = option.label;
+      text.append(name);
+      const input = document.createElement("input");
+      input.type = "checkbox";
+      input.checked = Boolean(enabled[option.value]);
+      input.dataset.extended = option.value;
+      const toggle = document.createElement("span");
+      toggle.className = "toggle";
+      toggle.setAttribute("aria-hidden", "true");
+      row.append(text, input, toggle);
+      list.append(row);
+    });
+  }
+
+  function renderCustomReminders() {
+    const reminders = state.custom_reminders || [];
+    if (!reminders.some((reminder) => reminder.id === customEditing)) customEditing = 0;
+    const list = byId("custom-reminders");
+    list.replaceChildren();
+    reminders.forEach((reminder) => {
+      const item = document.createElement("li");
+      item.classList.toggle("editing", reminder.id === customEditing);
+      const text = document.createElement("span");
+      const title = document.createElement("strong");
+      title.textContent = reminder.text;
+      const summary = document.createElement("small");
+      summary.textContent = reminder.summary;
+      text.append(title, summary);
+      const edit = document.createElement("button");
+      edit.type = "button";
+      edit.className = "text-button";
+      edit.textContent = state.labels.custom_edit;
+      edit.addEventListener("click", () => editCustomReminder(reminder));
+      const remove = document.createElement("button");
+      remove.type = "button";
+      remove.className = "text-button";
+      remove.textContent = state.labels.custom_delete;
+      remove.dataset.customDelete = String(reminder.id);
+      remove.disabled = offlineMode;
+      remove.addEventListener("click", () => deleteCustomReminder(reminder.id, remove));
+      item.append(text, edit, remove);
+      list.append(item);
+    });
+    byId("custom-reminders-empty").classList.toggle("hidden", reminders.length > 0);
+    const current = reminders.find((reminder) => reminder.id === customEditing);
+    fillCustomForm(current || {
+      text: "", local_time: "21:00", anchor: "", offset_minutes: 0, recurrence: "daily",
+      weekdays: [], hijri_day: 13, date: "",
+    });
+  }
+
+  function fillCustomForm(reminder) {
+    byId("custom-text").value = reminder.text;
+    document.querySelectorAll("input[name='custom-time-kind']").forEach((input) => {
+      input.checked = input.value === (reminder.anchor ? "prayer" : "clock");
+    });
+    byId("custom-local-time").value = reminder.local_time || "21:00";
+    fillSelect("custom-anchor", state.options.anchors || [], reminder.anchor || "fajr");
+    byId("custom-offset").value = reminder.offset_minutes;
+    fillSelect("custom-recurrence", state.options.recurrences || [], reminder.recurrence);
+    const days = byId("custom-weekdays");
+    days.replaceChildren();
+    (state.options.weekdays || []).forEach((option) => {
+      const label = document.createElement("label");
+      const input = document.createElement("input");
+      input.type = "checkbox";
+      input.value = option.value;
+      input.checked = reminder.weekdays.includes(Number(option.value));
+      label.append(input, ` ${option.label}`);
+      days.append(label);
+    });
+    byId("custom-hijri-day").value = reminder.hijri_day || 13;
+    byId("custom-date").value = reminder.date;
+    setText("custom-save", customEditing ? state.labels.save : state.labels.custom_add);
+    byId("custom-cancel").classList.toggle("hidden", !customEditing);
+    syncCustomForm();
+  }
+
+  function syncCustomForm() {
+    const anchored = document.querySelector("input[name='custom-time-kind']:checked").value === "prayer";
+    byId("custom-clock-row").classList.toggle("hidden", anchored);
+    byId("custom-prayer-row").classList.toggle("hidden", !anchored);
+    const recurrence = byId("custom-recurrence").value;
+    byId("custom-weekdays-row").classList.toggle("hidden", recurrence !== "weekly");
+    byId("custom-hijri-row").classList.toggle("hidden", recurrence !== "hijri_monthly");
+    byId("custom-date-row").classList.toggle("hidden", recurrence !== "once");
+  }
+
+  function editCustomReminder(reminder) {
+    customEditing = reminder.id;
+    renderCustomReminders();
+    byId("custom-text").focus();
+  }
+
+  function cancelCustomEdit() {
+    customEditing = 0;
+    renderCustomReminders();
+  }
+
+  function collectCustomReminder() {
+    const anchored = document.querySelector("input[name='custom-time-kind']:checked").value === "prayer";
+    return {
+      id: customEditing,
+      text: byId("custom-text").value.trim(),
+      local_time: anchored ? "" : byId("custom-local-time").value,
+      anchor: anchored ? byId("custom-anchor").value : "",
+      offset_minutes: anchored ? Math.trunc(Number(byId("custom-offset").value)) || 0 : 0,
+      recurrence: byId("custom-recurrence").value,
+      weekdays: Array.from(byId("custom-weekdays").querySelectorAll("input:checked"), (input) => Number(input.value)),
+      hijri_day: Math.trunc(Number(byId("custom-hijri-day").value)) || 0,
+      date: byId("custom-date").value,
+    };
+  }
+
+  function customReminderError(error) {
+    const messages = {
+      invalid_custom_reminder: state.labels.custom_invalid,
+      custom_reminder_past: state.labels.custom_past,
+      custom_reminder_limit: state.labels.custom_limit,
+    };
+    showToast(messages[error.code] || state.labels.temporary_failure, true);
+  }
+
+  async function saveCustomReminder(event) {
+    event.preventDefault();
+    if (offlineMode) return;
+    const button = byId("custom-save");
+    button.disabled = true;
+    try {
+      const next = await request("/api/miniapp/custom-reminders", "PUT", collectCustomReminder());
+      customEditing = 0;
+      applyState(next);
+      void cacheState(next);
+      showToast(next.labels.saved);
+    } catch (error) {
+      customReminderError(error);
+    } finally {
+      button.disabled = offlineMode;
+    }
+  }
+
+  async function deleteCustomReminder(id, button) {
+    if (offlineMode) return;
+    button.disabled = true;
+    try {
+      const next = await request(`/api/miniapp/custom-reminders?id=${id}`, "DELETE");
+      applyState(next);
+      void cacheState(next);
+    } catch (error) {
+      button.disabled = false;
+      customReminderError(error);
+    }
+  }
+
   function sourceLink(source) {
     let url;
     try {
@@ -432,8 +747,15 @@
       title.textContent = occasion.title;
       const dates = document.createElement("p");
       dates.className = "occasion-dates";
-      dates.textContent = `${occasion.hijri} · ${occasion.gregorian}`;
-      heading.append(category, title, dates);
+      dates.textContent = [occasion.day, occasion.hijri, occasion.gregorian].filter(Boolean).join(" · ");
+      heading.append(category);
+      if (occasion.no_fasting) {
+        const noFasting = document.createElement("span");
+        noFasting.className = "occasion-category occasion-no-fasting";
+        noFasting.textContent = state.labels.occasion_no_fasting;
+        heading.append(noFasting);
+      }
+      heading.append(title, dates);
       header.append(emoji, heading);
 
       const summary = document.createElement("p");
@@ -454,11 +776,167 @@
       if (sources.childElementCount > 0) {
         sources.setAttribute("aria-label", state.labels.occasion_sources);
       }
-      article.append(header, summary, recommendation, sources);
+      // Personal dates carry no recommendation.
+      article.append(header, summary);
+      if (occasion.action) article.append(recommendation);
+      article.append(sources);
       list.append(article);
     });
   }
 
+  // Ramadan is fetched when the dates view opens, like the fasting month,
+  // because finding it can take the server a scan of most of a year.
+  async function loadRamadan() {
+    if (offlineMode) return;
+    try {
+      renderRamadan(await request("/api/miniapp/ramadan/days", "POST", {}));
+      ramadanLoaded = true;
+    } catch (_) {
+      showToast(state.labels.temporary_failure, true);
+    }
+  }
+
+  function renderRamadan(ramadan = { days: [] }) {
+    setText("ramadan-title", ramadan.title || state.labels.ramadan_title);
+    setText("ramadan-subtitle", ramadan.subtitle || "");
+    const body = byId("ramadan-days");
+    body.replaceChildren();
+    ramadan.days.forEach((day) => {
+      const row = document.createElement("tr");
+      row.classList.toggle("today", day.today);
+      const date = document.createElement("th");
+      date.scope = "row";
+      date.textContent = `${day.hijri_day} · ${day.weekday} ${day.gregorian}`;
+      row.append(date);
+      [day.imsak, day.suhoor, day.iftar].forEach((time) => {
+        const cell = document.createElement("td");
+        cell.textContent = time || "—";
+        row.append(cell);
+      });
+      body.append(row);
+    });
+  }
+
+  // The fasting month is fetched when the dates view opens, and again after
+  // a bootstrap, since settings move the Hijri calendar.
+  async function loadFastingMonth(month) {
+    if (offlineMode) return;
+    const buttons = ["fasting-previous", "fasting-next"].map(byId);
+    buttons.forEach((button) => { button.disabled = true; });
+    try {
+      renderFastingMonth(await request("/api/miniapp/fasting", "POST", { month: month || fastingMonth }));
+      fastingLoaded = true;
+    } catch (_) {
+      buttons.forEach((button) => { button.disabled = !button.dataset.month; });
+      showToast(state.labels.temporary_failure, true);
+    }
+  }
+
+  const fastLogMarks = { fasted: "✅", makeup: "🔁", missed: "✖️" };
+
+  function renderFastingMonth(month) {
+    fastingMonth = month.month;
+    fastingDays = month.days;
+    setText("fasting-month", month.title);
+    setText("fasting-note", month.note);
+    [["fasting-previous", month.previous], ["fasting-next", month.next]].forEach(([id, target]) => {
+      byId(id).dataset.month = target || "";
+      byId(id).disabled = !target;
+    });
+    const grid = byId("fasting-grid");
+    grid.replaceChildren();
+    month.weekdays.forEach((name) => {
+      const heading = document.createElement("span");
+      heading.className = "fasting-weekday";
+      heading.textContent = name;
+      grid.append(heading);
+    });
+    for (let blank = 0; blank < month.days[0].weekday; blank += 1) {
+      grid.append(document.createElement("span"));
+    }
+    month.days.forEach((day) => {
+      const cell = document.createElement("button");
+      cell.type = "button";
+      cell.className = `fasting-day ${day.status || ""}`.trim();
+      cell.classList.toggle("today", day.today);
+      cell.classList.toggle("selected", day.date === fastingSelected);
+      const number = document.createElement("strong");
+      number.textContent = day.day;
+      const hijri = document.createElement("small");
+      hijri.textContent = day.hijri;
+      cell.append(number, hijri);
+      const marks = [day.emoji, fastLogMarks[day.logged]].filter(Boolean).join("");
+      if (marks) {
+        const emoji = document.createElement("span");
+        emoji.textContent = marks;
+        emoji.setAttribute("aria-hidden", "true");
+        cell.append(emoji);
+      }
+      const detail = [day.status ? `${day.label} · ${day.reasons}` : "", day.logged_label].filter(Boolean).join(" · ");
+      cell.setAttribute("aria-label", [day.day, day.hijri, detail].filter(Boolean).join(", "));
+      cell.addEventListener("click", () => {
+        fastingSelected = day.date;
+        grid.querySelectorAll(".fasting-day.selected").forEach((other) => other.classList.remove("selected"));
+        cell.classList.add("selected");
+        renderFastingSelection();
+      });
+      grid.append(cell);
+    });
+    renderFastingSelection();
+    renderFastingBalance(month.balance);
+  }
+
+  // The selected day keeps its details and, up to tomorrow, the buttons that
+  // log it. A Ramadan day cannot be a make-up fast, and a prohibited day can
+  // only be logged as not fasted.
+  function renderFastingSelection() {
+    const day = fastingDays.find((item) => item.date === fastingSelected);
+    byId("fasting-selected").classList.toggle("hidden", !day);
+    byId("fasting-log-actions").classList.toggle("hidden", !day || !day.loggable || offlineMode);
+    if (!day) return;
+    const detail = day.status ? `${day.label} · ${day.reasons}` : "";
+    setText("fasting-selected", [day.hijri, detail, day.logged_label].filter(Boolean).join(" · "));
+    document.querySelectorAll("[data-fast-status]").forEach((button) => {
+      const status = button.dataset.fastStatus;
+      const hidden = day.status === "prohibited" && (status === "fasted" || status === "makeup") ||
+        day.status === "obligatory" && status === "makeup" || status === "" && !day.logged;
+      button.classList.toggle("hidden", hidden);
+      button.classList.toggle("selected", status !== "" && status === day.logged);
+    });
+  }
+
+  function renderFastingBalance(balance) {
+    [["fasting-kept", balance.kept], ["fasting-made-up", balance.made_up], ["fasting-missed", balance.missed],
+      ["fasting-owed", balance.owed]].forEach(([id, value]) => setText(id, String(value)));
+    byId("fasting-carried").value = balance.carried;
+    const list = byId("fasting-suggestions");
+    list.replaceChildren();
+    balance.suggestions.forEach((day) => {
+      const item = document.createElement("li");
+      const label = document.createElement("strong");
+      label.textContent = day.label;
+      item.append(label, ` · ${day.reasons}`);
+      list.append(item);
+    });
+    byId("fasting-suggestions-block").classList.toggle("hidden", balance.suggestions.length === 0);
+  }
+
+  async function saveFastingLog(path, body, button) {
+    if (offlineMode) return;
+    button.disabled = true;
+    try {
+      renderFastingMonth(await request(path, "PUT", { month: fastingMonth, ...body }));
+    } catch (error) {
+      const messages = {
+        fasting_prohibited: state.labels.fasts_prohibited,
+        ramadan_makeup: state.labels.fasts_ramadan_makeup,
+      };
+      showToast(messages[error.code] || state.labels.temporary_failure, true);
+    } finally {
+      button.disabled = offlineMode;
+    }
+  }
+
   function syncPreReminderAvailability() {
     byId("pre-prayer-minutes").disabled = !byId("prayer-reminders").checked;
   }
@@ -556,8 +1034,12 @@
     renderSchedule();
     renderTools();
     renderOccasions();
+    renderRamadan();
+    ramadanLoaded = false;
+    fastingLoaded = false;
     renderZakat();
     renderReminders();
+    renderCustomReminders();
     renderSettings();
     selectView(activeView);
     setDirty(false);
@@ -569,6 +1051,10 @@
     byId("location-secondary").disabled = value;
     setPreferencesDisabled(value);
     setCalendarButtonsDisabled(value);
+    document.querySelectorAll("[data-month-format], [data-ramadan-format]").forEach((button) => { button.disabled = value; });
+    byId("convert-submit").disabled = value;
+    document.querySelectorAll("[data-fast-status], #fasting-carried-save").forEach((button) => { button.disabled = value; });
+    document.querySelectorAll("[data-custom-delete], #custom-save").forEach((button) => { button.disabled = value; });
   }
 
   function showConnectionState(kind, savedAt) {
@@ -664,17 +1150,38 @@
     document.querySelectorAll("#adjustment-grid input").forEach((input) => {
       adjustments[input.dataset.prayer] = Number(input.value);
     });
+    const rounding = {};
+    document.querySelectorAll("#rounding-grid select").forEach((select) => {
+      rounding[select.dataset.prayer] = select.value;
+    });
     return {
       language: byId("language").value,
       method: byId("method").value,
       madhab: byId("madhab").value,
       high_latitude_rule: byId("highlat").value,
       hijri_adjustment: Number(byId("hijri-adjustment").value),
+      hijri_calendar: byId("hijri-calendar").value,
       adjustments,
+      custom: {
+        fajr_angle: Number(byId("custom-fajr-angle").value),
+        isha_angle: Number(byId("custom-isha-angle").value),
+        isha_interval_minutes: Number(byId("custom-isha-interval").value),
+      },
+      imsak_minutes: Number(byId("imsak-minutes").value),
+      show_extended_times: byId("show-extended-times").checked,
+      hide_makruh_times: !byId("show-makruh-times").checked,
+      moon_events: byId("moon-events").checked,
+      ignore_timetables: !byId("use-timetable").checked,
+      elevation_meters: Math.min(Math.max(Math.round(Number(byId("elevation-meters").value) || 0), 0), 9000),
+      precaution: { ihtiyat_minutes: Number(byId("ihtiyat-minutes").value), rounding },
     };
   }
 
   function collectReminders() {
+    const extended = {};
+    document.querySelectorAll("#extended-reminders input").forEach((input) => {
+      extended[input.dataset.extended] = input.checked;
+    });
     return {
       prayer: byId("prayer-reminders").checked,
       pre_prayer_minutes: Number(byId("pre-prayer-minutes").value),
@@ -684,6 +1191,10 @@
       occasion_major: byId("occasion-major-reminders").checked,
       occasion_fasting: byId("occasion-fasting-reminders").checked,
       occasion_observed: byId("occasion-observed-reminders").checked,
+      personal_events: byId("personal-event-reminders").checked,
+      suhoor_minutes: Number(byId("suhoor-minutes").value),
+      iftar: byId("iftar-reminders").checked,
+      extended,
     };
   }
 
@@ -762,26 +1273,30 @@
   }
 
   function setCalendarButtonsDisabled(value) {
-    ["connect-calendar", "copy-calendar-link", "disconnect-calendar"].forEach((id) => {
-      byId(id).disabled = value;
+    Object.values(calendarFeeds).forEach(({ prefix }) => {
+      [`connect-${prefix}`, `copy-${prefix}-link`, `disconnect-${prefix}`].forEach((id) => {
+        byId(id).disabled = value;
+      });
     });
   }
 
-  async function ensureCalendarSubscription() {
-    const subscription = await request("/api/miniapp/calendar-subscription", "POST");
-    state.calendar = subscription;
-    calendarFeedURL = new URL(subscription.path, window.location.origin).href;
-    renderCalendarSubscription();
+  async function ensureCalendarSubscription(name) {
+    const feed = calendarFeeds[name];
+    const subscription = await request(`/api/miniapp/calendar-subscription?feed=${name}`, "POST");
+    state[feed.key] = subscription;
+    feed.url = new URL(subscription.path, window.location.origin).href;
+    renderCalendarSubscription(name);
     void cacheState(state);
-    return calendarFeedURL;
+    return feed.url;
   }
 
-  async function connectGoogleCalendar() {
+  async function connectGoogleCalendar(name) {
+    const { prefix } = calendarFeeds[name];
     setCalendarButtonsDisabled(true);
-    setText("calendar-status", state.labels.calendar_opening);
-    byId("calendar-status").classList.remove("hidden");
+    setText(`${prefix}-status`, state.labels.calendar_opening);
+    byId(`${prefix}-status`).classList.remove("hidden");
     try {
-      const feedURL = await ensureCalendarSubscription();
+      const feedURL = await ensureCalendarSubscription(name);
       // Google's add-by-URL flow requires a webcal:// URL inside cid. With an
       // https:// URL the calendar is added but its events are never fetched.
       const webcalURL = feedURL.replace(/^https:\/\//, "webcal://");
@@ -815,10 +1330,10 @@
     return copied ? Promise.resolve() : Promise.reject(new Error("copy_failed"));
   }
 
-  async function copyCalendarLink() {
+  async function copyCalendarLink(name) {
     setCalendarButtonsDisabled(true);
     try {
-      const feedURL = calendarFeedURL || await ensureCalendarSubscription();
+      const feedURL = calendarFeeds[name].url || await ensureCalendarSubscription(name);
       await copyText(feedURL);
       showToast(state.labels.calendar_copied);
     } catch (_) {
@@ -828,12 +1343,13 @@
     }
   }
 
-  async function disconnectCalendar() {
+  async function disconnectCalendar(name) {
+    const feed = calendarFeeds[name];
     setCalendarButtonsDisabled(true);
     try {
-      state.calendar = await request("/api/miniapp/calendar-subscription", "DELETE");
-      calendarFeedURL = "";
-      renderCalendarSubscription();
+      state[feed.key] = await request(`/api/miniapp/calendar-subscription?feed=${name}`, "DELETE");
+      feed.url = "";
+      renderCalendarSubscription(name);
       void cacheState(state);
       showToast(state.labels.calendar_disconnected);
     } catch (_) {
@@ -942,7 +1458,8 @@
     });
 
     const method = state.options.methods.find((item) => item.value === state.profile.method);
-    const footer = `${schedule.timezone} · ${method ? method.label : state.profile.method}`;
+    const source = schedule.timetable || (method ? method.label : state.profile.method);
+    const footer = `${schedule.timezone} · ${source}`;
     context.direction = rtl ? "rtl" : "ltr";
     context.textAlign = rtl ? "right" : "left";
     context.fillStyle = "rgba(255,253,242,.62)";
@@ -1021,6 +1538,51 @@
     }
   }
 
+  async function sendMonthTimetable(event) {
+    await sendTimetable("/api/miniapp/month", "[data-month-format]", event.currentTarget.dataset.monthFormat);
+  }
+
+  async function sendRamadanTimetable(event) {
+    await sendTimetable("/api/miniapp/ramadan", "[data-ramadan-format]", event.currentTarget.dataset.ramadanFormat);
+  }
+
+  async function sendTimetable(path, selector, format) {
+    const buttons = document.querySelectorAll(selector);
+    buttons.forEach((button) => { button.disabled = true; });
+    try {
+      await request(path, "POST", { format });
+      showToast(state.labels.month_sent);
+      if (telegram && telegram.HapticFeedback) telegram.HapticFeedback.notificationOccurred("success");
+    } catch (_) {
+      showToast(state.labels.month_failed, true);
+    } finally {
+      buttons.forEach((button) => { button.disabled = offlineMode; });
+    }
+  }
+
+  async function convertDate(event) {
+    event.preventDefault();
+    const date = byId("convert-date").value.trim();
+    if (!date) return;
+    const button = byId("convert-submit");
+    button.disabled = true;
+    try {
+      const result = await request("/api/miniapp/convert", "POST", { date });
+      const hijri = result.hijri;
+      const gregorian = result.gregorian.join(" / ");
+      setText("convert-first", result.hijri_first ? hijri : gregorian);
+      setText("convert-second", result.hijri_first ? gregorian : hijri);
+      setText("convert-note", result.note);
+      setText("convert-ambiguous", result.ambiguous);
+      byId("convert-result").classList.remove("hidden");
+    } catch (error) {
+      byId("convert-result").classList.add("hidden");
+      showToast(error.code === "invalid_date" ? state.labels.convert_invalid : state.labels.temporary_failure, true);
+    } finally {
+      button.disabled = offlineMode;
+    }
+  }
+
   function showLaunchError(kind) {
     const copy = launchCopy[launchLanguage()] || launchCopy.en;
     loading.classList.add("hidden");
@@ -1078,6 +1640,8 @@
     });
     window.scrollTo({ top: 0, behavior: "auto" });
     if (view === "places") ensurePlacesMap();
+    if (view === "dates" && !fastingLoaded) void loadFastingMonth("");
+    if (view === "dates" && !ramadanLoaded) void loadRamadan();
   }
 
   // --- "Prayer times anywhere" map lookup (dependency-free OSM slippy map) ---
@@ -1169,23 +1733,7 @@
     setText("places-gregorian", schedule.gregorian);
     setText("places-hijri", `☾ ${schedule.hijri}`);
     setText("places-timezone", schedule.timezone);
-    const grid = byId("places-grid");
-    grid.replaceChildren();
-    schedule.prayers.forEach((prayer) => {
-      const item = document.createElement("div");
-      item.className = "prayer";
-      const emoji = document.createElement("span");
-      emoji.className = "prayer-emoji";
-      emoji.textContent = prayer.emoji;
-      const name = document.createElement("span");
-      name.className = "prayer-name";
-      name.textContent = prayer.name;
-      const time = document.createElement("strong");
-      time.className = "prayer-time";
-      time.textContent = prayer.time;
-      item.append(emoji, name, time);
-      grid.append(item);
-    });
+    fillPrayerGrid(byId("places-grid"), schedule.prayers);
   }
 
   function selectPlacesDay(day) {
@@ -1247,19 +1795,47 @@
   byId("location-primary").addEventListener("click", (event) => updateLocation(event.currentTarget));
   byId("location-secondary").addEventListener("click", (event) => updateLocation(event.currentTarget));
   byId("start-compass").addEventListener("click", startCompass);
-  byId("connect-calendar").addEventListener("click", connectGoogleCalendar);
-  byId("copy-calendar-link").addEventListener("click", copyCalendarLink);
-  byId("disconnect-calendar").addEventListener("click", disconnectCalendar);
+  Object.entries(calendarFeeds).forEach(([name, { prefix }]) => {
+    byId(`connect-${prefix}`).addEventListener("click", () => connectGoogleCalendar(name));
+    byId(`copy-${prefix}-link`).addEventListener("click", () => copyCalendarLink(name));
+    byId(`disconnect-${prefix}`).addEventListener("click", () => disconnectCalendar(name));
+  });
+  byId("fasting-previous").addEventListener("click", () => loadFastingMonth(byId("fasting-previous").dataset.month));
+  byId("fasting-next").addEventListener("click", () => loadFastingMonth(byId("fasting-next").dataset.month));
+  document.querySelectorAll("[data-fast-status]").forEach((button) => {
+    button.addEventListener("click", () => saveFastingLog("/api/miniapp/fasting/log",
+      { date: fastingSelected, status: button.dataset.fastStatus }, button));
+  });
+  byId("fasting-carried-save").addEventListener("click", () => {
+    const carried = Math.min(1000, Math.max(0, Math.trunc(Number(byId("fasting-carried").value)) || 0));
+    void saveFastingLog("/api/miniapp/fasting/qada", { carried }, byId("fasting-carried-save"));
+  });
+  byId("custom-reminder-form").addEventListener("submit", saveCustomReminder);
+  byId("custom-cancel").addEventListener("click", cancelCustomEdit);
+  byId("custom-recurrence").addEventListener("change", syncCustomForm);
+  document.querySelectorAll("input[name='custom-time-kind']")
+    .forEach((input) => input.addEventListener("change", syncCustomForm));
   byId("add-home-screen").addEventListener("click", addToHomeScreen);
   byId("share-prayer-card").addEventListener("click", sharePrayerCard);
+  document.querySelectorAll("[data-month-format]")
+    .forEach((button) => button.addEventListener("click", sendMonthTimetable));
+  document.querySelectorAll("[data-ramadan-format]")
+    .forEach((button) => button.addEventListener("click", sendRamadanTimetable));
+  byId("convert-form").addEventListener("submit", convertDate);
   byId("save-preferences").addEventListener("click", savePreferences);
   byId("retry-app").addEventListener("click", bootstrapApp);
   ["prayer-reminders", "pre-prayer-minutes", "fasting-reminders", "white-days-reminders", "kahf-reminders",
     "occasion-major-reminders", "occasion-fasting-reminders", "occasion-observed-reminders",
-    "language", "method", "madhab", "highlat", "hijri-adjustment"]
+    "personal-event-reminders", "suhoor-minutes", "iftar-reminders",
+    "language", "method", "madhab", "highlat", "hijri-calendar", "hijri-adjustment", "show-extended-times", "imsak-minutes", "show-makruh-times",
+    "moon-events", "use-timetable", "elevation-meters", "ihtiyat-minutes"]
     .forEach((id) => byId(id).addEventListener("change", () => setDirty(true)));
   byId("prayer-reminders").addEventListener("change", syncPreReminderAvailability);
   byId("adjustment-grid").addEventListener("input", () => setDirty(true));
+  byId("rounding-grid").addEventListener("change", () => setDirty(true));
+  byId("method").addEventListener("change", syncCustomMethod);
+  byId("custom-method").addEventListener("input", () => setDirty(true));
+  byId("extended-reminders").addEventListener("change", () => setDirty(true));
   byId("zakat-currency").addEventListener("change", () => {
     zakatCurrency = byId("zakat-currency").value;
     void writeStoredValue(zakatCurrencyKey(), zakatCurrency);
@@ -1,6 +1,6 @@
 "use strict";
 
-const cacheName = "global-prayer-miniapp-shell-v11";
+const cacheName = "global-prayer-miniapp-shell-v29";
 const shellAssets = [
   "./",
   "./app.css",
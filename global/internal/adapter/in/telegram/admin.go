@@ -10,6 +10,7 @@ import (
 
 	"github.com/escalopa/prayer-bot/global/internal/core/i18n"
 	"github.com/escalopa/prayer-bot/global/internal/domain"
+	"github.com/escalopa/prayer-bot/global/internal/port"
 )
 
 type adminView string
@@ -22,6 +23,7 @@ const (
 	adminViewReminders adminView = "reminders"
 	adminViewHealth    adminView = "health"
 	adminViewFeedback  adminView = "feedback"
+	adminViewHijri     adminView = "hijri"
 )
 
 func (h *Handler) isOwner(chat models.Chat, user *models.User) bool {
@@ -32,25 +34,42 @@ func (h *Handler) isOwner(chat models.Chat, user *models.User) bool {
 }
 
 func (h *Handler) sendAdminDashboard(ctx context.Context, chatID int64, view adminView) error {
-	metrics, err := h.store.AdminMetrics(ctx)
+	text, err := h.adminDashboardText(ctx, view)
 	if err != nil {
-		return fmt.Errorf("load owner dashboard: %w", err)
+		return err
 	}
-	return h.send(ctx, chatID, formatAdminDashboard(metrics, view, h.now()), adminKeyboard(view))
+	return h.send(ctx, chatID, text, adminKeyboard(view))
 }
 
 func (h *Handler) editAdminDashboard(ctx context.Context, message *models.Message, view adminView) error {
+	text, err := h.adminDashboardText(ctx, view)
+	if err != nil {
+		return err
+	}
+	return h.edit(ctx, message.Chat.ID, message.ID, text, adminKeyboard(view))
+}
+
+// adminDashboardText renders a view. The Hijri view manages announcements
+// rather than reporting metrics, so it skips the aggregate queries.
+func (h *Handler) adminDashboardText(ctx context.Context, view adminView) (string, error) {
+	if view == adminViewHijri {
+		return h.hijriAnnouncementsText(ctx)
+	}
 	metrics, err := h.store.AdminMetrics(ctx)
 	if err != nil {
-		return fmt.Errorf("load owner dashboard: %w", err)
+		return "", fmt.Errorf("load owner dashboard: %w", err)
+	}
+	if reporter, ok := h.calculator.(port.CacheReporter); ok {
+		metrics.CalculatorCache = reporter.CacheStats()
 	}
-	return h.edit(ctx, message.Chat.ID, message.ID, formatAdminDashboard(metrics, view, h.now()), adminKeyboard(view))
+	return formatAdminDashboard(metrics, view, h.now()), nil
 }
 
 func parseAdminView(data string) (adminView, bool) {
 	view := adminView(strings.TrimPrefix(data, "admin:"))
 	switch view {
-	case adminViewOverview, adminViewActivity, adminViewLanguages, adminViewMethods, adminViewReminders, adminViewHealth, adminViewFeedback:
+	case adminViewOverview, adminViewActivity, adminViewLanguages, adminViewMethods, adminViewReminders, adminViewHealth, adminViewFeedback,
+		adminViewHijri:
 		return view, true
 	default:
 		return "", false
@@ -76,6 +95,9 @@ func adminKeyboard(current adminView) *models.InlineKeyboardMarkup {
 		},
 		[]models.InlineKeyboardButton{
 			button("💬 Feedback help", adminViewFeedback),
+			button("🌙 Hijri months", adminViewHijri),
+		},
+		[]models.InlineKeyboardButton{
 			{Text: "🔄 Refresh", CallbackData: "admin:" + string(current)},
 		},
 	)
@@ -207,7 +229,10 @@ func formatAdminReminders(metrics domain.AdminDashboard) string {
 			"📖 Friday Al-Kahf: <b>%d</b>\n"+
 			"🕋 Major Islamic occasions: <b>%d</b>\n"+
 			"🤲 Special fasting days: <b>%d</b>\n"+
-			"🌙 Commonly observed dates: <b>%d</b>\n\n"+
+			"🌙 Commonly observed dates: <b>%d</b>\n"+
+			"🌅 Ramadan suhoor &amp; iftar: <b>%d</b>\n"+
+			"🕌 Jumu'ah khutbah: <b>%d</b>\n"+
+			"⭐ Personal dates: <b>%d</b>\n\n"+
 			"Users with any reminder: %d · %.1f%%\n"+
 			"Enabled rules: %d\n"+
 			"Pending schedules: %d",
@@ -218,6 +243,9 @@ func formatAdminReminders(metrics domain.AdminDashboard) string {
 		counts["occasion_major"],
 		counts["occasion_fasting"],
 		counts["occasion_observed"],
+		counts["ramadan"],
+		counts["jumuah"],
+		counts["personal_event"],
 		metrics.ReminderUsers,
 		percentage(metrics.ReminderUsers, metrics.Users),
 		metrics.EnabledRules,
@@ -240,7 +268,12 @@ func formatAdminHealth(metrics domain.AdminDashboard) string {
 			"Processing deliveries: %d\n"+
 			"Task outbox: %d\n"+
 			"Pending schedules: %d\n\n"+
-			"⚠️ Failed bot updates (24h): %d",
+			"⚠️ Failed bot updates (24h): %d\n\n"+
+			"<b>Prayer-time cache</b> <i>(this instance)</i>\n"+
+			"Hits: %d · %.1f%%\n"+
+			"Misses: %d\n"+
+			"Evictions: %d\n"+
+			"Cached years: %d · %.1f MiB",
 		metrics.SentDeliveries24Hours,
 		metrics.FailedDeliveries24Hours,
 		metrics.StaleDeliveries24Hours,
@@ -249,6 +282,12 @@ func formatAdminHealth(metrics domain.AdminDashboard) string {
 		metrics.QueuedTasks,
 		metrics.PendingSchedules,
 		metrics.FailedUpdates24Hours,
+		metrics.CalculatorCache.Hits,
+		metrics.CalculatorCache.HitRate(),
+		metrics.CalculatorCache.Misses,
+		metrics.CalculatorCache.Evictions,
+		metrics.CalculatorCache.Entries,
+		float64(metrics.CalculatorCache.Bytes)/(1<<20),
 	)
 }
 
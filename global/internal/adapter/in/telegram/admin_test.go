@@ -47,6 +47,7 @@ func TestAdminKeyboardProvidesEveryDashboardView(t *testing.T) {
 		adminViewReminders,
 		adminViewHealth,
 		adminViewFeedback,
+		adminViewHijri,
 	} {
 		if !seen[view] {
 			t.Errorf("dashboard keyboard is missing %q", view)
@@ -81,6 +82,7 @@ func TestAdminDashboardFormatsAggregateViews(t *testing.T) {
 			{Key: "occasion_major", Count: 6}, {Key: "occasion_fasting", Count: 5},
 			{Key: "occasion_observed", Count: 3},
 		},
+		CalculatorCache: domain.CacheStats{Hits: 90, Misses: 10, Entries: 12, Bytes: 3 << 20},
 	}
 	now := time.Date(2026, time.July, 17, 12, 30, 0, 0, time.FixedZone("EET", 2*60*60))
 	expectations := map[adminView]string{
@@ -92,6 +94,10 @@ func TestAdminDashboardFormatsAggregateViews(t *testing.T) {
 		adminViewHealth:    "Failed bot updates",
 		adminViewFeedback:  "Contact user",
 	}
+	if formatted := formatAdminDashboard(metrics, adminViewHealth, now); !strings.Contains(formatted, "Hits: 90 · 90.0%") ||
+		!strings.Contains(formatted, "Cached years: 12 · 3.0 MiB") {
+		t.Errorf("health dashboard does not report the calculator cache: %s", formatted)
+	}
 	for view, expected := range expectations {
 		formatted := formatAdminDashboard(metrics, view, now)
 		if !strings.Contains(formatted, expected) {
@@ -108,3 +114,57 @@ func TestPercentageHandlesEmptyDashboard(t *testing.T) {
 		t.Fatalf("percentage(0, 0) = %v, want 0", got)
 	}
 }
+
+func TestParseTimetableCaptionReadsPlaceAndCountryBindings(t *testing.T) {
+	place, err := parseTimetableCaption("place 55.796,49.106 40 Europe/Moscow Kazan Muftiate")
+	if err != nil {
+		t.Fatalf("parse place caption: %v", err)
+	}
+	if place.Name != "Kazan Muftiate" || place.RadiusKm != 40 || place.Latitude != 55.796 || place.Longitude != 49.106 || place.Timezone != "Europe/Moscow" {
+		t.Fatalf("unexpected place timetable: %+v", place)
+	}
+	country, err := parseTimetableCaption("country ru Europe/Moscow Russian Muftiate")
+	if err != nil {
+		t.Fatalf("parse country caption: %v", err)
+	}
+	if country.CountryCode != "RU" || country.PlaceBound() || country.Name != "Russian Muftiate" {
+		t.Fatalf("unexpected country timetable: %+v", country)
+	}
+	for _, caption := range []string{
+		"",
+		"place 55.796 40 Europe/Moscow Kazan",
+		"place 55.796,49.106 150 Europe/Moscow Kazan",
+		"place 55.796,49.106 40 Mars/Olympus Kazan",
+		"country RUS Europe/Moscow Russia",
+	} {
+		if _, err := parseTimetableCaption(caption); err == nil {
+			t.Errorf("caption %q should be rejected", caption)
+		}
+	}
+}
+
+func TestParseHijriAnnouncementReadsCountriesAndNotify(t *testing.T) {
+	announcements, notify, err := parseHijriAnnouncement("1448-09 2027-02-08 sa,AE,SA notify")
+	if err != nil {
+		t.Fatalf("parse announcement: %v", err)
+	}
+	if !notify || len(announcements) != 2 || announcements[0].CountryCode != "SA" || announcements[1].CountryCode != "AE" ||
+		announcements[0].Month != 9 || announcements[0].Year != 1448 || announcements[0].Start.Day() != 8 {
+		t.Fatalf("unexpected announcements: %+v notify=%v", announcements, notify)
+	}
+	if _, notify, err = parseHijriAnnouncement("1448-09 2027-02-09 TR"); err != nil || notify {
+		t.Fatalf("a silent announcement a day later should parse: notify=%v err=%v", notify, err)
+	}
+	for _, argument := range []string{
+		"",
+		"1448-13 2027-02-08 SA",
+		"1448-09 08/02/2027 SA",
+		"1448-09 2027-02-08 SAU",
+		"1448-09 2027-03-08 SA",
+		"1448-09 2027-02-08 SA loudly",
+	} {
+		if _, _, err := parseHijriAnnouncement(argument); err == nil {
+			t.Errorf("argument %q should be rejected", argument)
+		}
+	}
+}
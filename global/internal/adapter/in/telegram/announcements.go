@@ -0,0 +1,152 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/escalopa/prayer-bot/global/internal/core/hijri"
+	"github.com/escalopa/prayer-bot/global/internal/core/i18n"
+	"github.com/escalopa/prayer-bot/global/internal/domain"
+)
+
+const hijriAnnouncementUsage = "Publish the day a month began in one or more countries:\n" +
+	"<code>/hijri_announce 1448-09 2027-02-08 SA,AE</code>\n" +
+	"Add <code>notify</code> at the end to also tell every chat there.\n\n" +
+	"From that day, chats in those countries reckon Hijri dates from the announcement instead of their own correction, " +
+	"until the country's next announcement. Their reminders are re-planned in the background.\n\n" +
+	"/hijri_announcements lists them · <code>/hijri_announcement_delete SA 1448-09</code> withdraws one"
+
+// publishHijriAnnouncement saves the owner's announcement for every listed
+// country and reports how many chats were queued for re-planning. A command
+// the bot cannot use is answered with the reason and is not an error.
+func (h *Handler) publishHijriAnnouncement(ctx context.Context, chatID int64, argument string) error {
+	announcements, notify, err := parseHijriAnnouncement(argument)
+	if err != nil {
+		return h.send(ctx, chatID, "⚠️ "+escape(err.Error())+"\n\n"+hijriAnnouncementUsage, nil)
+	}
+	queued := 0
+	countries := make([]string, 0, len(announcements))
+	for _, announcement := range announcements {
+		count, err := h.store.PublishHijriAnnouncement(ctx, announcement, notify)
+		if err != nil {
+			return fmt.Errorf("publish Hijri announcement: %w", err)
+		}
+		queued += count
+		countries = append(countries, announcement.CountryCode)
+	}
+	action := "re-planning"
+	if notify {
+		action = "re-planning and a notice"
+	}
+	return h.send(ctx, chatID, fmt.Sprintf("✅ %s\n🌍 %s\n🔄 %d chats queued for %s",
+		formatHijriAnnouncement(announcements[0]), strings.Join(countries, ", "), queued, action), nil)
+}
+
+func (h *Handler) sendHijriAnnouncements(ctx context.Context, chatID int64) error {
+	text, err := h.hijriAnnouncementsText(ctx)
+	if err != nil {
+		return err
+	}
+	return h.send(ctx, chatID, text, nil)
+}
+
+func (h *Handler) hijriAnnouncementsText(ctx context.Context) (string, error) {
+	announcements, err := h.store.HijriAnnouncements(ctx)
+	if err != nil {
+		return "", fmt.Errorf("load Hijri announcements: %w", err)
+	}
+	var builder strings.Builder
+	builder.WriteString("<b>Hijri announcements</b> 🌙\n\n")
+	for _, announcement := range announcements {
+		builder.WriteString(fmt.Sprintf("%s · %s\n", announcement.CountryCode, formatHijriAnnouncement(announcement)))
+	}
+	if len(announcements) > 0 {
+		builder.WriteString("\n")
+	}
+	builder.WriteString(hijriAnnouncementUsage)
+	return builder.String(), nil
+}
+
+func (h *Handler) deleteHijriAnnouncement(ctx context.Context, chatID int64, argument string) error {
+	fields := strings.Fields(argument)
+	if len(fields) != 2 {
+		return h.send(ctx, chatID, "Usage: <code>/hijri_announcement_delete SA 1448-09</code>", nil)
+	}
+	countryCode := strings.ToUpper(fields[0])
+	year, month, err := parseHijriMonth(fields[1])
+	if err != nil {
+		return h.send(ctx, chatID, "Usage: <code>/hijri_announcement_delete SA 1448-09</code>", nil)
+	}
+	queued, err := h.store.DeleteHijriAnnouncement(ctx, countryCode, year, month)
+	if domain.IsNotFound(err) {
+		return h.send(ctx, chatID, fmt.Sprintf("No announcement for %s %d-%02d.", escape(countryCode), year, month), nil)
+	}
+	if err != nil {
+		return fmt.Errorf("delete Hijri announcement: %w", err)
+	}
+	return h.send(ctx, chatID, fmt.Sprintf("🗑 Withdrawn for %s.\n🔄 %d chats queued for re-planning",
+		escape(countryCode), queued), nil)
+}
+
+// parseHijriAnnouncement reads "<year>-<month> <YYYY-MM-DD> <CC,CC…> [notify]".
+func parseHijriAnnouncement(argument string) ([]domain.HijriAnnouncement, bool, error) {
+	fields := strings.Fields(argument)
+	notify := len(fields) == 4 && strings.EqualFold(fields[3], "notify")
+	if len(fields) != 3 && !notify {
+		return nil, false, errors.New("the announcement needs a Hijri month, the day it began, and the countries")
+	}
+	year, month, err := parseHijriMonth(fields[0])
+	if err != nil {
+		return nil, false, err
+	}
+	start, err := time.Parse(time.DateOnly, fields[1])
+	if err != nil {
+		return nil, false, errors.New("the day the month began must be written as YYYY-MM-DD")
+	}
+	var announcements []domain.HijriAnnouncement
+	seen := map[string]bool{}
+	for _, country := range strings.Split(fields[2], ",") {
+		country = strings.ToUpper(strings.TrimSpace(country))
+		if country == "" || seen[country] {
+			continue
+		}
+		seen[country] = true
+		announcement := domain.HijriAnnouncement{CountryCode: country, Year: year, Month: month, Start: start}
+		if err := announcement.Validate(); err != nil {
+			return nil, false, err
+		}
+		announcements = append(announcements, announcement)
+	}
+	if len(announcements) == 0 {
+		return nil, false, errors.New("list at least one two-letter country code")
+	}
+	if !hijri.Plausible(announcements[0]) {
+		return nil, false, fmt.Errorf("%s is more than two days from the calculated start of %s",
+			fields[1], hijriMonthName(year, month))
+	}
+	return announcements, notify, nil
+}
+
+// parseHijriMonth reads "1448-09".
+func parseHijriMonth(value string) (int, int, error) {
+	yearText, monthText, ok := strings.Cut(value, "-")
+	year, yearErr := strconv.Atoi(yearText)
+	month, monthErr := strconv.Atoi(monthText)
+	if !ok || yearErr != nil || monthErr != nil || month < 1 || month > 12 {
+		return 0, 0, errors.New("the Hijri month must be written as YEAR-MONTH, such as 1448-09")
+	}
+	return year, month, nil
+}
+
+func hijriMonthName(year, month int) string {
+	return fmt.Sprintf("%s %d", i18n.Resolve("en").HijriMonth(month), year)
+}
+
+func formatHijriAnnouncement(announcement domain.HijriAnnouncement) string {
+	return fmt.Sprintf("<b>%s</b> began on %s", escape(hijriMonthName(announcement.Year, announcement.Month)),
+		announcement.Start.Format(time.DateOnly))
+}
@@ -3,6 +3,7 @@ package telegram
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -10,7 +11,10 @@ import (
 	"github.com/go-telegram/bot/models"
 
 	"github.com/escalopa/prayer-bot/global/internal/core/i18n"
+	"github.com/escalopa/prayer-bot/global/internal/core/prayertime"
+	"github.com/escalopa/prayer-bot/global/internal/core/reminders"
 	"github.com/escalopa/prayer-bot/global/internal/domain"
+	"github.com/escalopa/prayer-bot/global/internal/port"
 )
 
 func (h *Handler) handleCallback(ctx context.Context, query *models.CallbackQuery) error {
@@ -66,6 +70,17 @@ func (h *Handler) handleCallback(ctx context.Context, query *models.CallbackQuer
 		return h.saveLocation(ctx, message.Chat.ID, latitude, longitude, locale)
 	}
 
+	if strings.HasPrefix(query.Data, "month:") {
+		return h.handleMonthCallback(ctx, message, query.Data, locale)
+	}
+	if strings.HasPrefix(query.Data, "ramadan:") {
+		return h.handleRamadanCallback(ctx, message, query.Data, locale)
+	}
+
+	if strings.HasPrefix(query.Data, reminders.FastCallbackPrefix) {
+		return h.handleFastCallback(ctx, message, query.Data, locale)
+	}
+
 	if strings.HasPrefix(query.Data, "language:") {
 		if ok, err := h.canConfigureActor(ctx, message.Chat, &query.From, locale); err != nil || !ok {
 			return err
@@ -93,7 +108,9 @@ func (h *Handler) handleCallback(ctx context.Context, query *models.CallbackQuer
 			return err
 		}
 		return h.edit(ctx, message.Chat.ID, message.ID, formatSettings(profile, locale), settingsKeyboard(locale))
-	case "settings:method", "settings:madhab", "settings:highlat", "settings:adjustments", "settings:hijri":
+	case "settings:method", "settings:madhab", "settings:highlat", "settings:adjustments", "settings:hijri", "settings:custom",
+		"settings:extended", "settings:makruh", "settings:elevation", "settings:precaution", "settings:jumuah",
+		"settings:timetable":
 		profile, ok, err := h.profileOrPrompt(ctx, message.Chat.ID, locale)
 		if err != nil || !ok {
 			return err
@@ -106,7 +123,28 @@ func (h *Handler) handleCallback(ctx context.Context, query *models.CallbackQuer
 		case "settings:highlat":
 			return h.edit(ctx, message.Chat.ID, message.ID, locale.Message("choose_highlat"), highLatitudeKeyboard(profile.HighLatitudeRule, locale))
 		case "settings:hijri":
-			return h.edit(ctx, message.Chat.ID, message.ID, locale.Message("choose_hijri"), hijriKeyboard(profile.HijriAdjustment, locale))
+			return h.edit(ctx, message.Chat.ID, message.ID, locale.Message("choose_hijri"), hijriKeyboard(profile, locale))
+		case "settings:custom":
+			if profile.Method != domain.MethodCustom {
+				return h.edit(ctx, message.Chat.ID, message.ID, locale.Message("choose_method"), methodKeyboard(profile.Method, locale))
+			}
+			return h.edit(ctx, message.Chat.ID, message.ID, formatCustomMethod(profile.Custom, locale), customMethodKeyboard(profile.Custom, locale))
+		case "settings:extended":
+			return h.edit(ctx, message.Chat.ID, message.ID, formatExtendedTimes(profile, locale), extendedTimesKeyboard(profile, locale))
+		case "settings:elevation":
+			return h.edit(ctx, message.Chat.ID, message.ID, formatElevation(profile, locale), h.elevationKeyboard(locale))
+		case "settings:precaution":
+			return h.edit(ctx, message.Chat.ID, message.ID, formatPrecaution(profile, locale), precautionKeyboard(profile.Precaution, locale))
+		case "settings:makruh":
+			return h.edit(ctx, message.Chat.ID, message.ID, locale.Message("choose_makruh"), makruhKeyboard(profile, locale))
+		case "settings:timetable":
+			return h.editTimetableSetting(ctx, message, profile, locale)
+		case "settings:jumuah":
+			minutes, err := h.jumuahMinutes(ctx, message.Chat.ID)
+			if err != nil {
+				return err
+			}
+			return h.edit(ctx, message.Chat.ID, message.ID, formatJumuah(profile.Jumuah, minutes, locale), jumuahKeyboard(profile.Jumuah, minutes, locale))
 		default:
 			return h.edit(ctx, message.Chat.ID, message.ID, locale.Message("choose_adjustment"), adjustmentKeyboard(profile, locale))
 		}
@@ -121,11 +159,46 @@ func (h *Handler) handleCallback(ctx context.Context, query *models.CallbackQuer
 		if !method.Valid() {
 			return nil
 		}
-		profile, ok, err := h.updateProfile(ctx, message.Chat.ID, locale, func(profile *domain.PrayerProfile) { profile.Method = method })
+		profile, ok, err := h.updateProfile(ctx, message.Chat.ID, locale, func(profile *domain.PrayerProfile) { selectMethod(profile, method) })
 		if err != nil || !ok {
 			return err
 		}
+		if method == domain.MethodCustom {
+			return h.edit(ctx, message.Chat.ID, message.ID, formatCustomMethod(profile.Custom, locale), customMethodKeyboard(profile.Custom, locale))
+		}
 		return h.edit(ctx, message.Chat.ID, message.ID, formatSettings(profile, locale), settingsKeyboard(locale))
+	case strings.HasPrefix(query.Data, "custom:"):
+		return h.handleCustomMethodCallback(ctx, message, query.Data, locale)
+	case strings.HasPrefix(query.Data, "extended:"):
+		return h.handleExtendedTimesCallback(ctx, message, query.Data, locale)
+	case strings.HasPrefix(query.Data, "elevation:"):
+		return h.handleElevationCallback(ctx, message, query.Data, locale)
+	case strings.HasPrefix(query.Data, "precaution:"):
+		return h.handlePrecautionCallback(ctx, message, query.Data, locale)
+	case strings.HasPrefix(query.Data, "jumuah:"):
+		return h.handleJumuahCallback(ctx, message, query.Data, locale)
+	case strings.HasPrefix(query.Data, "events:"):
+		return h.handleEventsCallback(ctx, message, query.Data, locale)
+	case strings.HasPrefix(query.Data, "custom_reminder:"):
+		return h.handleCustomReminderCallback(ctx, message, query.Data, locale)
+	case query.Data == "makruh:show:on" || query.Data == "makruh:show:off":
+		hide := query.Data == "makruh:show:off"
+		profile, ok, err := h.updateProfile(ctx, message.Chat.ID, locale, func(profile *domain.PrayerProfile) {
+			profile.HideMakruhTimes = hide
+		})
+		if err != nil || !ok {
+			return err
+		}
+		return h.edit(ctx, message.Chat.ID, message.ID, locale.Message("choose_makruh"), makruhKeyboard(profile, locale))
+	case query.Data == "timetable:use:on" || query.Data == "timetable:use:off":
+		ignore := query.Data == "timetable:use:off"
+		profile, ok, err := h.updateProfile(ctx, message.Chat.ID, locale, func(profile *domain.PrayerProfile) {
+			profile.IgnoreTimetables = ignore
+		})
+		if err != nil || !ok {
+			return err
+		}
+		return h.editTimetableSetting(ctx, message, profile, locale)
 	case strings.HasPrefix(query.Data, "madhab:"):
 		madhab := domain.Madhab(strings.TrimPrefix(query.Data, "madhab:"))
 		if !madhab.Valid() {
@@ -172,6 +245,18 @@ func (h *Handler) handleCallback(ctx context.Context, query *models.CallbackQuer
 			return err
 		}
 		return h.edit(ctx, message.Chat.ID, message.ID, formatSettings(profile, locale), settingsKeyboard(locale))
+	case strings.HasPrefix(query.Data, "hijrical:"):
+		system := domain.HijriCalendar(strings.TrimPrefix(query.Data, "hijrical:"))
+		if !system.Valid() {
+			return nil
+		}
+		profile, ok, err := h.updateProfile(ctx, message.Chat.ID, locale, func(profile *domain.PrayerProfile) {
+			profile.HijriCalendar = system
+		})
+		if err != nil || !ok {
+			return err
+		}
+		return h.edit(ctx, message.Chat.ID, message.ID, formatSettings(profile, locale), settingsKeyboard(locale))
 	case strings.HasPrefix(query.Data, "reminders:"):
 		return h.handleReminderCallback(ctx, message, query.Data, locale)
 	default:
@@ -217,6 +302,12 @@ func (h *Handler) handleReminderCallback(ctx context.Context, message *models.Me
 		return h.edit(ctx, message.Chat.ID, message.ID,
 			formatReminders(state, locale), remindersKeyboard(state, locale))
 	}
+	if len(parts) >= 3 && parts[1] == "extended" {
+		return h.handleExtendedReminderCallback(ctx, message, parts[2:], locale)
+	}
+	if len(parts) >= 3 && parts[1] == "ramadan" {
+		return h.handleRamadanReminderCallback(ctx, message, parts[2:], locale)
+	}
 	if len(parts) != 3 || (parts[2] != "on" && parts[2] != "off") {
 		return nil
 	}
@@ -283,6 +374,87 @@ func (h *Handler) handleReminderCallback(ctx context.Context, message *models.Me
 	return h.edit(ctx, message.Chat.ID, message.ID, formatReminders(state, locale), remindersKeyboard(state, locale))
 }
 
+// handleRamadanReminderCallback serves the Ramadan reminder submenu: "choose"
+// and "back" navigate, "suhoor:<minutes|off>" sets the suhoor lead time and
+// "iftar:on|off" toggles the iftar reminder.
+func (h *Handler) handleRamadanReminderCallback(ctx context.Context, message *models.Message, parts []string, locale i18n.Locale) error {
+	if len(parts) == 2 {
+		kind, minutes, enabled := domain.ReminderIftar, 0, parts[1] == "on"
+		switch {
+		case parts[0] == "suhoor" && parts[1] == "off":
+			kind, enabled = domain.ReminderSuhoor, false
+		case parts[0] == "suhoor":
+			value, err := strconv.Atoi(parts[1])
+			if err != nil || !domain.ValidSuhoorMinutes(value) {
+				return nil
+			}
+			kind, minutes, enabled = domain.ReminderSuhoor, value, true
+		case parts[0] != "iftar" || (parts[1] != "on" && parts[1] != "off"):
+			return nil
+		}
+		if enabled {
+			if _, ok, err := h.profileOrPrompt(ctx, message.Chat.ID, locale); err != nil || !ok {
+				return err
+			}
+		}
+		if err := h.store.SetRamadanRule(ctx, message.Chat.ID, kind, minutes, enabled); err != nil {
+			return err
+		}
+		if enabled {
+			if err := h.planner.RebuildChat(ctx, message.Chat.ID, h.now()); err != nil {
+				return err
+			}
+		}
+	} else if len(parts) != 1 || (parts[0] != "choose" && parts[0] != "back") {
+		return nil
+	}
+	state, err := h.loadReminderState(ctx, message.Chat.ID)
+	if err != nil {
+		return err
+	}
+	if len(parts) == 1 && parts[0] == "back" {
+		return h.edit(ctx, message.Chat.ID, message.ID, formatReminders(state, locale), remindersKeyboard(state, locale))
+	}
+	return h.edit(ctx, message.Chat.ID, message.ID,
+		locale.Message("choose_ramadan_reminders"), ramadanRemindersKeyboard(state, locale))
+}
+
+// handleExtendedReminderCallback serves the extended time reminder submenu:
+// "choose" and "back" navigate, "<time>:on|off" toggles one reminder.
+func (h *Handler) handleExtendedReminderCallback(ctx context.Context, message *models.Message, parts []string, locale i18n.Locale) error {
+	if len(parts) == 2 {
+		prayer := domain.Prayer(parts[0])
+		if !prayer.Extended() || (parts[1] != "on" && parts[1] != "off") {
+			return nil
+		}
+		enabled := parts[1] == "on"
+		if enabled {
+			if _, ok, err := h.profileOrPrompt(ctx, message.Chat.ID, locale); err != nil || !ok {
+				return err
+			}
+		}
+		if err := h.store.SetExtendedTimeRule(ctx, message.Chat.ID, prayer, enabled); err != nil {
+			return err
+		}
+		if enabled {
+			if err := h.planner.RebuildChat(ctx, message.Chat.ID, h.now()); err != nil {
+				return err
+			}
+		}
+	} else if len(parts) != 1 || (parts[0] != "choose" && parts[0] != "back") {
+		return nil
+	}
+	state, err := h.loadReminderState(ctx, message.Chat.ID)
+	if err != nil {
+		return err
+	}
+	if len(parts) == 1 && parts[0] == "back" {
+		return h.edit(ctx, message.Chat.ID, message.ID, formatReminders(state, locale), remindersKeyboard(state, locale))
+	}
+	return h.edit(ctx, message.Chat.ID, message.ID,
+		locale.Message("choose_extended_reminders"), extendedRemindersKeyboard(state, locale))
+}
+
 func (h *Handler) handleAdjustmentCallback(ctx context.Context, message *models.Message, data string, locale i18n.Locale) error {
 	parts := strings.Split(data, ":")
 	if len(parts) != 3 {
@@ -316,3 +488,168 @@ func (h *Handler) handleAdjustmentCallback(ctx context.Context, message *models.
 		locale.Message("adjust_prayer"), escape(locale.Prayer(prayer)), adjustmentValue(profile.Adjustments, prayer),
 	), adjustmentDetailKeyboard(prayer, locale))
 }
+
+func (h *Handler) handleCustomMethodCallback(ctx context.Context, message *models.Message, data string, locale i18n.Locale) error {
+	parts := strings.Split(data, ":")
+	if len(parts) != 3 {
+		return nil
+	}
+	profile, ok, err := h.profileOrPrompt(ctx, message.Chat.ID, locale)
+	if err != nil || !ok {
+		return err
+	}
+	// A stale keyboard must not silently switch a preset back to custom.
+	if profile.Method != domain.MethodCustom {
+		return h.edit(ctx, message.Chat.ID, message.ID, locale.Message("choose_method"), methodKeyboard(profile.Method, locale))
+	}
+	custom := profile.Custom
+	switch parts[1] {
+	case string(domain.PrayerFajr), string(domain.PrayerIsha):
+		delta, err := strconv.ParseFloat(parts[2], 64)
+		if err != nil || (delta != 0.5 && delta != -0.5) {
+			return nil
+		}
+		if parts[1] == string(domain.PrayerFajr) {
+			custom.FajrAngle = clampAngle(custom.FajrAngle + delta)
+		} else {
+			custom.IshaAngle = clampAngle(custom.IshaAngle + delta)
+		}
+	case "interval":
+		minutes, err := strconv.Atoi(parts[2])
+		if err != nil || minutes < 0 || minutes > domain.MaxCustomIshaInterval {
+			return nil
+		}
+		custom.IshaIntervalMinutes = minutes
+	default:
+		return nil
+	}
+	if custom == profile.Custom {
+		return nil
+	}
+	profile, ok, err = h.updateProfile(ctx, message.Chat.ID, locale, func(profile *domain.PrayerProfile) { profile.Custom = custom })
+	if err != nil || !ok {
+		return err
+	}
+	return h.edit(ctx, message.Chat.ID, message.ID, formatCustomMethod(profile.Custom, locale), customMethodKeyboard(profile.Custom, locale))
+}
+
+func (h *Handler) handleExtendedTimesCallback(ctx context.Context, message *models.Message, data string, locale i18n.Locale) error {
+	parts := strings.Split(data, ":")
+	if len(parts) != 3 {
+		return nil
+	}
+	var update func(*domain.PrayerProfile)
+	switch parts[1] {
+	case "show":
+		if parts[2] != "on" && parts[2] != "off" {
+			return nil
+		}
+		show := parts[2] == "on"
+		update = func(profile *domain.PrayerProfile) { profile.ShowExtendedTimes = show }
+	case "imsak":
+		minutes, err := strconv.Atoi(parts[2])
+		if err != nil || minutes < 0 || minutes > domain.MaxImsakMinutes {
+			return nil
+		}
+		update = func(profile *domain.PrayerProfile) { profile.ImsakMinutes = minutes }
+	default:
+		return nil
+	}
+	profile, ok, err := h.updateProfile(ctx, message.Chat.ID, locale, update)
+	if err != nil || !ok {
+		return err
+	}
+	return h.edit(ctx, message.Chat.ID, message.ID, formatExtendedTimes(profile, locale), extendedTimesKeyboard(profile, locale))
+}
+
+func (h *Handler) handlePrecautionCallback(ctx context.Context, message *models.Message, data string, locale i18n.Locale) error {
+	parts := strings.Split(data, ":")
+	if len(parts) != 3 {
+		return nil
+	}
+	var update func(*domain.PrayerProfile)
+	switch parts[1] {
+	case "ihtiyat":
+		minutes, err := strconv.Atoi(parts[2])
+		if err != nil || minutes < 0 || minutes > domain.MaxIhtiyatMinutes {
+			return nil
+		}
+		update = func(profile *domain.PrayerProfile) { profile.Precaution.IhtiyatMinutes = minutes }
+	case "round":
+		prayer := domain.Prayer(parts[2])
+		if !slices.Contains(allPrayers(), prayer) {
+			return nil
+		}
+		update = func(profile *domain.PrayerProfile) {
+			profile.Precaution.SetRounding(prayer, nextRounding(profile.Precaution.Rounding(prayer)))
+		}
+	default:
+		return nil
+	}
+	profile, ok, err := h.updateProfile(ctx, message.Chat.ID, locale, update)
+	if err != nil || !ok {
+		return err
+	}
+	return h.edit(ctx, message.Chat.ID, message.ID, formatPrecaution(profile, locale), precautionKeyboard(profile.Precaution, locale))
+}
+
+// nextRounding cycles nearest, up, down.
+func nextRounding(current domain.Rounding) domain.Rounding {
+	roundings := domain.SupportedRoundings()
+	index := slices.Index(roundings, current)
+	return roundings[(index+1)%len(roundings)]
+}
+
+func (h *Handler) handleElevationCallback(ctx context.Context, message *models.Message, data string, locale i18n.Locale) error {
+	profile, ok, err := h.profileOrPrompt(ctx, message.Chat.ID, locale)
+	if err != nil || !ok {
+		return err
+	}
+	meters := profile.ElevationMeters
+	parts := strings.Split(data, ":")
+	switch {
+	case len(parts) == 3 && parts[1] == "add":
+		step, err := strconv.Atoi(parts[2])
+		if err != nil || !slices.Contains(elevationSteps, step) {
+			return nil
+		}
+		meters = min(max(meters+step, 0), domain.MaxElevationMeters)
+	case data == "elevation:set:0":
+		meters = 0
+	case data == "elevation:detect":
+		detected, ok := h.elevation(ctx, profile.Latitude, profile.Longitude)
+		if !ok {
+			return nil
+		}
+		meters = detected
+	default:
+		return nil
+	}
+	if meters == profile.ElevationMeters {
+		return nil
+	}
+	profile, ok, err = h.updateProfile(ctx, message.Chat.ID, locale, func(profile *domain.PrayerProfile) { profile.ElevationMeters = meters })
+	if err != nil || !ok {
+		return err
+	}
+	return h.edit(ctx, message.Chat.ID, message.ID, formatElevation(profile, locale), h.elevationKeyboard(locale))
+}
+
+func (h *Handler) elevationKeyboard(locale i18n.Locale) *models.InlineKeyboardMarkup {
+	_, detect := h.resolver.(port.ElevationResolver)
+	return elevationKeyboard(detect, locale)
+}
+
+func clampAngle(value float64) float64 {
+	return min(max(value, domain.MinCustomAngle), domain.MaxCustomAngle)
+}
+
+// editTimetableSetting shows which official timetable covers the profile, even
+// while the profile ignores it, with the toggle to use it.
+func (h *Handler) editTimetableSetting(ctx context.Context, message *models.Message, profile domain.PrayerProfile, locale i18n.Locale) error {
+	name, err := prayertime.CoveringTimetable(ctx, h.calculator, h.now(), profile)
+	if err != nil {
+		return err
+	}
+	return h.edit(ctx, message.Chat.ID, message.ID, formatTimetableSetting(name, locale), timetableKeyboard(profile, locale))
+}
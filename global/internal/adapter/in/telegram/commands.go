@@ -11,7 +11,9 @@ import (
 
 	"github.com/escalopa/prayer-bot/global/internal/core/hijri"
 	"github.com/escalopa/prayer-bot/global/internal/core/i18n"
+	"github.com/escalopa/prayer-bot/global/internal/core/moon"
 	"github.com/escalopa/prayer-bot/global/internal/domain"
+	"github.com/escalopa/prayer-bot/global/internal/port"
 )
 
 func (h *Handler) handleLocation(ctx context.Context, message *models.Message, locale i18n.Locale) error {
@@ -38,14 +40,11 @@ func (h *Handler) saveLocation(ctx context.Context, chatID int64, latitude, long
 		ChatID: chatID, Latitude: latitude, Longitude: longitude,
 		Timezone: resolved.Timezone, PlaceID: resolved.PlaceID, CountryCode: resolved.CountryCode,
 		Method: domain.RecommendedMethod(resolved.CountryCode), Madhab: domain.MadhabShafii,
-		HighLatitudeRule: domain.HighLatitudeAngleBased,
+		HighLatitudeRule: domain.HighLatitudeAngleBased, ImsakMinutes: domain.DefaultImsakMinutes,
 	}
+	profile.ElevationMeters, _ = h.elevation(ctx, latitude, longitude)
 	if current, err := h.store.Profile(ctx, chatID); err == nil {
-		profile.Method = current.Method
-		profile.Madhab = current.Madhab
-		profile.HighLatitudeRule = current.HighLatitudeRule
-		profile.Adjustments = current.Adjustments
-		profile.HijriAdjustment = current.HijriAdjustment
+		profile.CopyPreferences(current)
 		profile.LocationLabel = current.LocationLabel
 	} else if !domain.IsNotFound(err) {
 		return fmt.Errorf("load current profile: %w", err)
@@ -66,6 +65,20 @@ func (h *Handler) saveLocation(ctx context.Context, chatID int64, latitude, long
 	), mainKeyboard(locale))
 }
 
+// elevation looks up the ground elevation when the resolver supports it. A
+// failed lookup reports false so a location still saves, at sea level.
+func (h *Handler) elevation(ctx context.Context, latitude, longitude float64) (int, bool) {
+	resolver, ok := h.resolver.(port.ElevationResolver)
+	if !ok {
+		return 0, false
+	}
+	meters, err := resolver.Elevation(ctx, latitude, longitude)
+	if err != nil {
+		return 0, false
+	}
+	return domain.ClampElevation(meters), true
+}
+
 // searchCity handles /city <name>: forward-geocode the query and offer the
 // matches as inline buttons. This is the only location path that works in
 // group chats and on Telegram Desktop, where the share-location button does
@@ -169,10 +182,13 @@ func (h *Handler) setMethod(ctx context.Context, chatID int64, argument string,
 		}
 		return h.send(ctx, chatID, locale.Message("choose_method"), methodKeyboard(profile.Method, locale))
 	}
-	profile, ok, err := h.updateProfile(ctx, chatID, locale, func(profile *domain.PrayerProfile) { profile.Method = method })
+	profile, ok, err := h.updateProfile(ctx, chatID, locale, func(profile *domain.PrayerProfile) { selectMethod(profile, method) })
 	if err != nil || !ok {
 		return err
 	}
+	if method == domain.MethodCustom {
+		return h.send(ctx, chatID, formatCustomMethod(profile.Custom, locale), customMethodKeyboard(profile.Custom, locale))
+	}
 	return h.send(ctx, chatID, fmt.Sprintf(locale.Message("method_saved"), escape(locale.Method(profile.Method))), settingsKeyboard(locale))
 }
 
@@ -305,34 +321,193 @@ func (h *Handler) updateProfile(ctx context.Context, chatID int64, locale i18n.L
 	return profile, true, nil
 }
 
+// selectMethod switches the calculation method, seeding the custom parameters
+// the first time a chat picks MethodCustom so the profile stays valid.
+func selectMethod(profile *domain.PrayerProfile, method domain.Method) {
+	profile.Method = method
+	if method == domain.MethodCustom && profile.Custom.IsZero() {
+		profile.Custom = domain.DefaultCustomMethod()
+	}
+}
+
+func hijriDateOf(date time.Time, profile domain.PrayerProfile) (hijri.Date, error) {
+	calendar, err := hijri.ForProfile(profile)
+	if err != nil {
+		return hijri.Date{}, err
+	}
+	return calendar.Date(date)
+}
+
 func formatSchedule(heading string, schedule domain.DaySchedule, profile domain.PrayerProfile, locale i18n.Locale) string {
 	var builder strings.Builder
 	fmt.Fprintf(&builder, "<b>%s</b> 🕌\n📅 %s", escape(heading), localizedDate(schedule.Date, locale))
-	if hijriDate, err := hijri.FromGregorian(schedule.Date, profile.HijriAdjustment); err == nil {
+	if hijriDate, err := hijriDateOf(schedule.Date, profile); err == nil {
+		note := "hijri_note"
+		if _, announced := hijri.Announcement(profile, schedule.Date); announced {
+			note = "hijri_note_announced"
+		}
 		fmt.Fprintf(&builder, "\n🌙 %d %s %d %s <i>(%s)</i>", hijriDate.Day,
-			escape(locale.HijriMonth(hijriDate.Month)), hijriDate.Year, escape(locale.Message("hijri_era")), escape(locale.Message("hijri_note")))
+			escape(locale.HijriMonth(hijriDate.Month)), hijriDate.Year, escape(locale.Message("hijri_era")),
+			escape(fmt.Sprintf(locale.Message(note), locale.HijriCalendar(profile.HijriCalendar))))
 	}
+	builder.WriteString(formatMoon(schedule.Date, profile, locale))
 	builder.WriteString("\n")
 	for _, prayer := range allPrayers() {
+		if khutbah, iqamah, ok := profile.Jumuah.On(schedule.Date); ok && prayer == domain.PrayerDhuhr {
+			// The mosque's own times replace calculated Dhuhr on Fridays.
+			fmt.Fprintf(&builder, "\n🕌 %s  <code>%s</code>", escape(locale.Message("jumuah")), khutbah.Format("15:04"))
+			if !iqamah.IsZero() {
+				fmt.Fprintf(&builder, " · %s <code>%s</code>", escape(locale.Message("jumuah_iqamah")), iqamah.Format("15:04"))
+			}
+			continue
+		}
 		if at, ok := schedule.At(prayer); ok {
 			fmt.Fprintf(&builder, "\n%s %s  <code>%s</code>", prayerEmoji(prayer), escape(locale.Prayer(prayer)), at.Format("15:04"))
 		}
 	}
+	if profile.ShowExtendedTimes {
+		fmt.Fprintf(&builder, "\n\n<b>%s</b> ✨", escape(locale.Message("extended_times_title")))
+		for _, prayer := range domain.ExtendedTimes() {
+			if at, ok := schedule.At(prayer); ok {
+				fmt.Fprintf(&builder, "\n%s %s  <code>%s</code>", prayerEmoji(prayer), escape(locale.Prayer(prayer)), at.Format("15:04"))
+			}
+		}
+	}
+	if !profile.HideMakruhTimes && len(schedule.Makruh) > 0 {
+		fmt.Fprintf(&builder, "\n\n<b>%s</b> 🚫", escape(locale.Message("makruh_title")))
+		for _, window := range domain.MakruhWindows() {
+			if span, ok := schedule.MakruhWindow(window); ok {
+				fmt.Fprintf(&builder, "\n• %s  <code>%s–%s</code>", escape(locale.MakruhWindow(window)),
+					span.Start.Format("15:04"), span.End.Format("15:04"))
+			}
+		}
+	}
+	if schedule.Timetable != "" {
+		fmt.Fprintf(&builder, "\n\n🧭 %s · %s", escape(profile.Timezone),
+			escape(fmt.Sprintf(locale.Message("official_timetable"), schedule.Timetable)))
+		return builder.String()
+	}
 	fmt.Fprintf(&builder, "\n\n🧭 %s · %s", escape(profile.Timezone), escape(locale.Method(profile.Method)))
 	return builder.String()
 }
 
+// formatMoon is the moon's phase on date and, from a few days before a
+// new moon until its crescent is expected, when to look for it.
+func formatMoon(date time.Time, profile domain.PrayerProfile, locale i18n.Locale) string {
+	state, err := moon.OnDay(date, profile.Latitude, profile.Longitude)
+	if err != nil {
+		return ""
+	}
+	text := fmt.Sprintf("\n%s %s", state.Phase.Emoji(), escape(locale.MoonSummary(state)))
+	if crescent, ok, err := moon.Watch(date, profile.Latitude, profile.Longitude); err == nil && ok {
+		text += "\n🔭 " + escape(locale.CrescentWatch(crescent, date.Location()))
+	}
+	return text
+}
+
 func formatSettings(profile domain.PrayerProfile, locale i18n.Locale) string {
-	return fmt.Sprintf("%s\n\n🌍 <b>%s:</b> %s\n🧭 <b>%s:</b> %s\n🕌 <b>%s:</b> %s\n🌙 <b>%s:</b> %s\n⏱ <b>%s:</b> %s\n🌙 <b>%s:</b> %s",
+	return fmt.Sprintf("%s\n\n🌍 <b>%s:</b> %s\n🧭 <b>%s:</b> %s\n📋 <b>%s:</b> %s\n🕌 <b>%s:</b> %s\n🌙 <b>%s:</b> %s\n⏱ <b>%s:</b> %s\n🌙 <b>%s:</b> %s\n✨ <b>%s:</b> %s\n🚫 <b>%s:</b> %s\n⛰ <b>%s:</b> %s\n⚖️ <b>%s:</b> %s\n🕌 <b>%s:</b> %s",
 		locale.Message("settings_title"), escape(locale.Message("timezone")), escape(profile.Timezone),
-		escape(locale.Message("method")), escape(locale.Method(profile.Method)),
+		escape(locale.Message("method")), escape(methodSummary(profile, locale)),
+		escape(locale.Message("timetable_title")), escape(timetableSummary(profile, locale)),
 		escape(locale.Message("madhab")), escape(locale.Madhab(profile.Madhab)),
 		escape(locale.Message("highlat")), escape(locale.HighLatitudeRule(profile.HighLatitudeRule)),
 		escape(locale.Message("adjustments")), formatAdjustmentSummary(profile.Adjustments, locale),
-		escape(locale.Message("hijri_date")), fmt.Sprintf(locale.Message("hijri_setting"), profile.HijriAdjustment),
+		escape(locale.Message("hijri_date")), escape(fmt.Sprintf(locale.Message("hijri_setting"), locale.HijriCalendar(profile.HijriCalendar), profile.HijriAdjustment)),
+		escape(locale.Message("extended_times_title")), escape(extendedTimesSummary(profile, locale)),
+		escape(locale.Message("makruh_title")), escape(makruhSummary(profile, locale)),
+		escape(locale.Message("elevation")), escape(fmt.Sprintf(locale.Message("elevation_value"), profile.ElevationMeters)),
+		escape(locale.Message("precaution")), escape(locale.PrecautionSummary(profile.Precaution)),
+		escape(locale.Message("jumuah")), jumuahSummary(profile.Jumuah, locale),
+	)
+}
+
+func formatPrecaution(profile domain.PrayerProfile, locale i18n.Locale) string {
+	return fmt.Sprintf("%s\n\n⚖️ %s", locale.Message("choose_precaution"), escape(locale.PrecautionSummary(profile.Precaution)))
+}
+
+// extendedTimesSummary reports whether the extended times are shown, followed
+// by the Imsak margin, which also drives Imsak reminders when they are hidden.
+func extendedTimesSummary(profile domain.PrayerProfile, locale i18n.Locale) string {
+	status := locale.Message("disabled")
+	if profile.ShowExtendedTimes {
+		status = locale.Message("enabled")
+	}
+	return status + " · " + fmt.Sprintf(locale.Message("imsak_minutes"), profile.ImsakMinutes)
+}
+
+// timetableSummary reports whether an official timetable covering the profile
+// is used; which one covers it is shown on its own screen.
+func timetableSummary(profile domain.PrayerProfile, locale i18n.Locale) string {
+	if profile.IgnoreTimetables {
+		return locale.Message("disabled")
+	}
+	return locale.Message("enabled")
+}
+
+// formatTimetableSetting names the official timetable that covers the profile and
+// what it replaces while it is used.
+func formatTimetableSetting(name string, locale i18n.Locale) string {
+	text := locale.Message("timetable_none")
+	if name != "" {
+		text = fmt.Sprintf(locale.Message("timetable_covers"), name)
+	}
+	return fmt.Sprintf("<b>%s</b> 📋\n\n%s", escape(locale.Message("timetable_title")), escape(text))
+}
+
+func makruhSummary(profile domain.PrayerProfile, locale i18n.Locale) string {
+	if profile.HideMakruhTimes {
+		return locale.Message("disabled")
+	}
+	return locale.Message("enabled")
+}
+
+func formatElevation(profile domain.PrayerProfile, locale i18n.Locale) string {
+	return fmt.Sprintf("%s\n\n⛰ %s", locale.Message("choose_elevation"),
+		escape(fmt.Sprintf(locale.Message("elevation_value"), profile.ElevationMeters)))
+}
+
+func formatExtendedTimes(profile domain.PrayerProfile, locale i18n.Locale) string {
+	return fmt.Sprintf("%s\n\n⏳ %s", locale.Message("choose_extended_times"),
+		escape(fmt.Sprintf(locale.Message("imsak_minutes"), profile.ImsakMinutes)))
+}
+
+// methodSummary names the method and, for MethodCustom, the parameters in
+// effect, since "Custom angles" alone does not tell the chat what it uses.
+func methodSummary(profile domain.PrayerProfile, locale i18n.Locale) string {
+	name := locale.Method(profile.Method)
+	if profile.Method != domain.MethodCustom {
+		return name
+	}
+	isha := formatAngle(profile.Custom.IshaAngle)
+	if profile.Custom.IshaIntervalMinutes > 0 {
+		isha = "+" + customIntervalLabel(profile.Custom.IshaIntervalMinutes, locale)
+	}
+	return fmt.Sprintf("%s (%s %s · %s %s)", name,
+		locale.Prayer(domain.PrayerFajr), formatAngle(profile.Custom.FajrAngle),
+		locale.Prayer(domain.PrayerIsha), isha)
+}
+
+func formatCustomMethod(custom domain.CustomMethod, locale i18n.Locale) string {
+	return fmt.Sprintf("%s\n\n🌙 <b>%s:</b> %s\n🌌 <b>%s:</b> %s\n⏱ <b>%s:</b> %s",
+		locale.Message("choose_custom_method"),
+		escape(locale.Message("custom_fajr_angle")), formatAngle(custom.FajrAngle),
+		escape(locale.Message("custom_isha_angle")), formatAngle(custom.IshaAngle),
+		escape(locale.Message("custom_isha_interval")), escape(customIntervalLabel(custom.IshaIntervalMinutes, locale)),
 	)
 }
 
+func customIntervalLabel(minutes int, locale i18n.Locale) string {
+	if minutes <= 0 {
+		return locale.Message("custom_isha_by_angle")
+	}
+	return fmt.Sprintf(locale.Message("custom_interval_minutes"), minutes)
+}
+
+func formatAngle(degrees float64) string {
+	return strconv.FormatFloat(degrees, 'f', -1, 64) + "°"
+}
+
 func formatAdjustmentSummary(adjustments domain.Adjustments, locale i18n.Locale) string {
 	parts := make([]string, 0, len(allPrayers()))
 	for _, prayer := range allPrayers() {
@@ -354,6 +529,11 @@ type reminderState struct {
 	// group-only delivery mode, not a schedule rule.
 	IsGroup    bool
 	JamaatPoll bool
+	// Extended holds the extended times that have a reminder.
+	Extended map[domain.Prayer]bool
+	// SuhoorMinutes is the suhoor lead time before Fajr, or 0 when off.
+	SuhoorMinutes int
+	Iftar         bool
 }
 
 func (h *Handler) loadReminderState(ctx context.Context, chatID int64) (reminderState, error) {
@@ -361,7 +541,7 @@ func (h *Handler) loadReminderState(ctx context.Context, chatID int64) (reminder
 	if err != nil {
 		return reminderState{}, err
 	}
-	var state reminderState
+	state := reminderState{Extended: make(map[domain.Prayer]bool)}
 	if chat, err := h.store.Chat(ctx, chatID); err == nil {
 		state.IsGroup = chat.IsGroup()
 		state.JamaatPoll = chat.JamaatPoll
@@ -386,11 +566,33 @@ func (h *Handler) loadReminderState(ctx context.Context, chatID int64) (reminder
 			state.Prayer = true
 		case domain.ReminderBefore:
 			state.PrePrayerMinutes = rule.OffsetMinutes
+		case domain.ReminderExtendedTime:
+			state.Extended[rule.Prayer] = true
+		case domain.ReminderSuhoor:
+			state.SuhoorMinutes = rule.OffsetMinutes
+		case domain.ReminderIftar:
+			state.Iftar = true
 		}
 	}
 	return state, nil
 }
 
+// formatRamadanReminders summarizes the suhoor and iftar choices, falling back
+// to when they are sent while both are off.
+func formatRamadanReminders(state reminderState, locale i18n.Locale) string {
+	var parts []string
+	if state.SuhoorMinutes > 0 {
+		parts = append(parts, fmt.Sprintf(locale.Message("suhoor_minutes"), state.SuhoorMinutes))
+	}
+	if state.Iftar {
+		parts = append(parts, locale.Message("iftar_reminder"))
+	}
+	if len(parts) == 0 {
+		return locale.Message("ramadan_schedule")
+	}
+	return strings.Join(parts, " · ")
+}
+
 func formatReminders(state reminderState, locale i18n.Locale) string {
 	status := func(enabled bool) string {
 		if enabled {
@@ -411,6 +613,21 @@ func formatReminders(state reminderState, locale i18n.Locale) string {
 		escape(locale.OccasionUI("major_reminders")), status(state.OccasionMajor), escape(locale.OccasionUI("schedule")),
 		escape(locale.OccasionUI("fasting_reminders")), status(state.OccasionFasting), escape(locale.OccasionUI("schedule")),
 		escape(locale.OccasionUI("observed_reminders")), status(state.OccasionObserved), escape(locale.OccasionUI("schedule")))
+	var extended []string
+	for _, prayer := range domain.ExtendedTimes() {
+		if state.Extended[prayer] {
+			extended = append(extended, escape(locale.Prayer(prayer)))
+		}
+	}
+	if len(extended) == 0 {
+		text += fmt.Sprintf("\n\n✨ <b>%s</b> · %s", escape(locale.Message("extended_times_title")), status(false))
+	} else {
+		text += fmt.Sprintf("\n\n✨ <b>%s</b> · %s\n   %s",
+			escape(locale.Message("extended_times_title")), status(true), strings.Join(extended, ", "))
+	}
+	text += fmt.Sprintf("\n\n🌅 <b>%s</b> · %s\n   %s",
+		escape(locale.Button("ramadan_reminders")),
+		status(state.SuhoorMinutes > 0 || state.Iftar), escape(formatRamadanReminders(state, locale)))
 	if state.IsGroup {
 		text += fmt.Sprintf("\n\n🗳 <b>%s</b> · %s\n   %s",
 			escape(locale.Button("jamaat_poll_reminders")), status(state.JamaatPoll), escape(locale.Message("jamaat_schedule")))
@@ -442,6 +659,16 @@ func prayerEmoji(prayer domain.Prayer) string {
 		return "🌤"
 	case domain.PrayerMaghrib:
 		return "🌇"
+	case domain.PrayerImsak:
+		return "⏳"
+	case domain.PrayerIshraq:
+		return "🌄"
+	case domain.PrayerDuha:
+		return "🌞"
+	case domain.PrayerMidnight:
+		return "🕛"
+	case domain.PrayerLastThird:
+		return "🤲"
 	default:
 		return "🌌"
 	}
@@ -0,0 +1,70 @@
+package telegram
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/escalopa/prayer-bot/global/internal/core/dateconvert"
+	"github.com/escalopa/prayer-bot/global/internal/core/hijri"
+	"github.com/escalopa/prayer-bot/global/internal/core/i18n"
+	"github.com/escalopa/prayer-bot/global/internal/domain"
+)
+
+// handleConvertCommand converts /convert 27 Rajab 1447 or /convert 16.03.2026
+// to the other calendar. It works before a location is shared, in the default
+// calendar; afterwards it follows the chat's calendar and correction.
+func (h *Handler) handleConvertCommand(ctx context.Context, chatID int64, argument string, locale i18n.Locale) error {
+	title := fmt.Sprintf("<b>%s</b> 🔄\n\n", escape(locale.Message("convert_title")))
+	if strings.TrimSpace(argument) == "" {
+		return h.send(ctx, chatID, title+locale.Message("convert_help"), nil)
+	}
+	query, err := dateconvert.Parse(argument)
+	if err != nil {
+		return h.send(ctx, chatID, "⚠️ "+escape(locale.Message("convert_invalid"))+"\n\n"+locale.Message("convert_help"), nil)
+	}
+	profile, err := h.store.Profile(ctx, chatID)
+	if err != nil && !domain.IsNotFound(err) {
+		return err
+	}
+	location := time.UTC
+	if profile.Timezone != "" {
+		if location, err = domain.LoadLocation(profile.Timezone); err != nil {
+			return err
+		}
+	}
+	calendar, err := hijri.ForProfile(profile)
+	if err != nil {
+		return err
+	}
+	result, err := dateconvert.Convert(query, calendar, h.now().In(location))
+	if err != nil {
+		return h.send(ctx, chatID, "⚠️ "+escape(locale.Message("convert_invalid"))+"\n\n"+locale.Message("convert_help"), nil)
+	}
+	return h.send(ctx, chatID, title+formatConversion(query, result, profile, locale), nil)
+}
+
+// formatConversion lists the typed calendar first, then the converted one.
+func formatConversion(query dateconvert.Query, result dateconvert.Result, profile domain.PrayerProfile, locale i18n.Locale) string {
+	note := "hijri_note"
+	if _, announced := hijri.Announcement(profile, result.Gregorian[0]); announced {
+		note = "hijri_note_announced"
+	}
+	hijriLine := fmt.Sprintf("🌙 <b>%d %s %d %s</b> <i>(%s)</i>", result.Hijri.Day,
+		escape(locale.HijriMonth(result.Hijri.Month)), result.Hijri.Year, escape(locale.Message("hijri_era")),
+		escape(fmt.Sprintf(locale.Message(note), locale.HijriCalendar(profile.HijriCalendar))))
+	days := make([]string, 0, len(result.Gregorian))
+	for _, day := range result.Gregorian {
+		days = append(days, localizedDate(day, locale))
+	}
+	gregorianLine := "📅 <b>" + strings.Join(days, " / ") + "</b>"
+	lines := []string{gregorianLine, hijriLine}
+	if query.Hijri {
+		lines = []string{hijriLine, gregorianLine}
+	}
+	if result.Ambiguous() {
+		lines = append(lines, "\n⚖️ "+fmt.Sprintf(locale.Message("convert_ambiguous"), days[0], days[1]))
+	}
+	return strings.Join(lines, "\n")
+}
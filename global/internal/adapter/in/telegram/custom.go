@@ -0,0 +1,118 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/go-telegram/bot/models"
+
+	"github.com/escalopa/prayer-bot/global/internal/core/i18n"
+	"github.com/escalopa/prayer-bot/global/internal/core/reminders"
+	"github.com/escalopa/prayer-bot/global/internal/domain"
+)
+
+// handleCustomCommand lists the chat's own reminders, adds one such as
+// /custom daily 21:30 Witr, or replaces the nth listed one when the command
+// starts with its number, such as /custom 2 mon,thu fajr+20 Adhkar.
+func (h *Handler) handleCustomCommand(ctx context.Context, message *models.Message, argument string, locale i18n.Locale) error {
+	chatID := message.Chat.ID
+	argument = strings.TrimSpace(argument)
+	if argument == "" {
+		return h.sendCustomReminders(ctx, chatID, locale)
+	}
+	if ok, err := h.canConfigure(ctx, message, locale); err != nil || !ok {
+		return err
+	}
+	var position int
+	if first, rest, ok := strings.Cut(argument, " "); ok {
+		if number, err := strconv.Atoi(first); err == nil {
+			position, argument = number, rest
+		}
+	}
+	reminder, err := domain.ParseCustomReminder(argument)
+	if err != nil {
+		return h.send(ctx, chatID, "⚠️ "+escape(locale.Message("custom_invalid"))+"\n\n"+locale.Message("custom_help"), nil)
+	}
+	profile, ok, err := h.profileOrPrompt(ctx, chatID, locale)
+	if err != nil || !ok {
+		return err
+	}
+	reminder.ChatID = chatID
+	if upcoming, err := reminders.CustomUpcoming(ctx, h.calculator, profile, reminder, h.now()); err != nil {
+		return err
+	} else if !upcoming {
+		return h.send(ctx, chatID, escape(locale.Message("custom_past")), nil)
+	}
+	if position > 0 {
+		existing, err := h.store.CustomReminders(ctx, chatID)
+		if err != nil {
+			return err
+		}
+		if position > len(existing) {
+			return h.send(ctx, chatID, "⚠️ "+escape(locale.Message("custom_invalid"))+"\n\n"+locale.Message("custom_help"), nil)
+		}
+		reminder.RuleID = existing[position-1].RuleID
+		err = h.store.UpdateCustomReminder(ctx, reminder)
+	} else {
+		reminder, err = h.store.AddCustomReminder(ctx, reminder)
+	}
+	if errors.Is(err, domain.ErrCustomReminderLimit) {
+		return h.send(ctx, chatID, escape(fmt.Sprintf(locale.Message("custom_limit"), domain.MaxCustomReminders)), nil)
+	}
+	if err != nil {
+		return err
+	}
+	if err := h.planner.RebuildChat(ctx, chatID, h.now()); err != nil {
+		return err
+	}
+	if err := h.send(ctx, chatID, fmt.Sprintf(escape(locale.Message("custom_saved")), escape(reminder.Text)), nil); err != nil {
+		return err
+	}
+	return h.sendCustomReminders(ctx, chatID, locale)
+}
+
+func (h *Handler) sendCustomReminders(ctx context.Context, chatID int64, locale i18n.Locale) error {
+	customs, err := h.store.CustomReminders(ctx, chatID)
+	if err != nil {
+		return err
+	}
+	return h.send(ctx, chatID, formatCustomReminders(customs, locale), customRemindersKeyboard(customs, locale))
+}
+
+// handleCustomReminderCallback answers custom_reminder:delete:<rule id> from
+// the /custom view. The custom: prefix already belongs to the custom
+// calculation method.
+func (h *Handler) handleCustomReminderCallback(ctx context.Context, message *models.Message, data string, locale i18n.Locale) error {
+	chatID := message.Chat.ID
+	ruleID, err := strconv.ParseInt(strings.TrimPrefix(data, "custom_reminder:delete:"), 10, 64)
+	if err != nil {
+		return nil
+	}
+	switch err := h.store.DeleteCustomReminder(ctx, chatID, ruleID); {
+	case domain.IsNotFound(err):
+		// A second tap on a stale keyboard finds nothing to delete.
+	case err != nil:
+		return err
+	}
+	customs, err := h.store.CustomReminders(ctx, chatID)
+	if err != nil {
+		return err
+	}
+	return h.edit(ctx, chatID, message.ID, formatCustomReminders(customs, locale), customRemindersKeyboard(customs, locale))
+}
+
+// formatCustomReminders numbers the reminders, so /custom <n> can edit one.
+func formatCustomReminders(customs []domain.CustomReminder, locale i18n.Locale) string {
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "<b>%s</b> 🔔\n\n%s\n", escape(locale.Message("custom_title")), locale.Message("custom_help"))
+	if len(customs) == 0 {
+		fmt.Fprintf(&builder, "\n<i>%s</i>", escape(locale.Message("custom_empty")))
+	}
+	for index, custom := range customs {
+		fmt.Fprintf(&builder, "\n%d. <b>%s</b>\n%s", index+1, escape(custom.Text), escape(locale.CustomReminderSchedule(custom)))
+	}
+	return builder.String()
+}
@@ -0,0 +1,165 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/go-telegram/bot/models"
+
+	"github.com/escalopa/prayer-bot/global/internal/core/dateconvert"
+	"github.com/escalopa/prayer-bot/global/internal/core/i18n"
+	"github.com/escalopa/prayer-bot/global/internal/domain"
+)
+
+// handleEventsCommand lists the chat's personal events, or adds one when the
+// command names a date and a title, such as /events 12 Rajab Grandfather.
+func (h *Handler) handleEventsCommand(ctx context.Context, message *models.Message, argument string, locale i18n.Locale) error {
+	chatID := message.Chat.ID
+	if strings.TrimSpace(argument) == "" {
+		return h.sendEvents(ctx, chatID, locale)
+	}
+	if ok, err := h.canConfigure(ctx, message, locale); err != nil || !ok {
+		return err
+	}
+	event, ok := parsePersonalEvent(argument)
+	if !ok {
+		return h.send(ctx, chatID, "⚠️ "+escape(locale.Message("events_invalid"))+"\n\n"+locale.Message("events_help"), nil)
+	}
+	if _, ok, err := h.profileOrPrompt(ctx, chatID, locale); err != nil || !ok {
+		return err
+	}
+	event.ChatID = chatID
+	event, err := h.store.AddPersonalEvent(ctx, event)
+	if errors.Is(err, domain.ErrPersonalEventLimit) {
+		return h.send(ctx, chatID, escape(fmt.Sprintf(locale.Message("events_limit"), domain.MaxPersonalEvents)), nil)
+	}
+	if err != nil {
+		return err
+	}
+	// Saving bumped the profile version, so every reminder is planned again.
+	if err := h.planner.RebuildChat(ctx, chatID, h.now()); err != nil {
+		return err
+	}
+	if err := h.send(ctx, chatID, fmt.Sprintf(escape(locale.Message("events_saved")), escape(event.Title)), nil); err != nil {
+		return err
+	}
+	return h.sendEvents(ctx, chatID, locale)
+}
+
+func (h *Handler) sendEvents(ctx context.Context, chatID int64, locale i18n.Locale) error {
+	profile, ok, err := h.profileOrPrompt(ctx, chatID, locale)
+	if err != nil || !ok {
+		return err
+	}
+	reminder, err := h.eventsReminder(ctx, chatID)
+	if err != nil {
+		return err
+	}
+	return h.send(ctx, chatID, formatPersonalEvents(profile.PersonalEvents, locale), eventsKeyboard(profile.PersonalEvents, reminder, locale))
+}
+
+// handleEventsCallback answers events:remind:on|off and events:delete:<id>
+// from the personal events view.
+func (h *Handler) handleEventsCallback(ctx context.Context, message *models.Message, data string, locale i18n.Locale) error {
+	chatID := message.Chat.ID
+	parts := strings.Split(strings.TrimPrefix(data, "events:"), ":")
+	if len(parts) != 2 {
+		return nil
+	}
+	switch parts[0] {
+	case "remind":
+		if parts[1] != "on" && parts[1] != "off" {
+			return nil
+		}
+		enabled := parts[1] == "on"
+		if err := h.store.SetOccasionRule(ctx, chatID, domain.ReminderPersonalEvents, enabled); err != nil {
+			return err
+		}
+		if enabled {
+			if err := h.planner.RebuildChat(ctx, chatID, h.now()); err != nil {
+				return err
+			}
+		}
+	case "delete":
+		eventID, err := strconv.ParseInt(parts[1], 10, 64)
+		if err != nil {
+			return nil
+		}
+		switch err := h.store.DeletePersonalEvent(ctx, chatID, eventID); {
+		case domain.IsNotFound(err):
+			// A second tap on a stale keyboard finds nothing to delete.
+		case err != nil:
+			return err
+		default:
+			if err := h.planner.RebuildChat(ctx, chatID, h.now()); err != nil {
+				return err
+			}
+		}
+	default:
+		return nil
+	}
+	profile, ok, err := h.profileOrPrompt(ctx, chatID, locale)
+	if err != nil || !ok {
+		return err
+	}
+	reminder, err := h.eventsReminder(ctx, chatID)
+	if err != nil {
+		return err
+	}
+	return h.edit(ctx, chatID, message.ID, formatPersonalEvents(profile.PersonalEvents, locale), eventsKeyboard(profile.PersonalEvents, reminder, locale))
+}
+
+// eventsReminder reports whether the personal events reminder is on.
+func (h *Handler) eventsReminder(ctx context.Context, chatID int64) (bool, error) {
+	rules, err := h.store.EnabledRules(ctx, chatID)
+	if err != nil {
+		return false, err
+	}
+	for _, rule := range rules {
+		if rule.Kind == domain.ReminderPersonalEvents {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// parsePersonalEvent reads "<date> <title>". The date is the longest run of
+// leading words dateconvert can read; a year in it is ignored, because the
+// event recurs every year.
+func parsePersonalEvent(argument string) (domain.PersonalEvent, bool) {
+	words := strings.Fields(argument)
+	for count := min(len(words)-1, 4); count >= 1; count-- {
+		query, err := dateconvert.Parse(strings.Join(words[:count], " "))
+		if err != nil {
+			continue
+		}
+		event := domain.PersonalEvent{
+			Title: strings.Join(words[count:], " "), Hijri: query.Hijri, Month: query.Month, Day: query.Day,
+		}
+		return event, event.Validate() == nil
+	}
+	return domain.PersonalEvent{}, false
+}
+
+func formatPersonalEvents(events []domain.PersonalEvent, locale i18n.Locale) string {
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "<b>%s</b> ⭐\n\n%s\n", escape(locale.Message("events_title")), locale.Message("events_help"))
+	if len(events) == 0 {
+		fmt.Fprintf(&builder, "\n<i>%s</i>", escape(locale.Message("events_empty")))
+	}
+	for _, event := range events {
+		fmt.Fprintf(&builder, "\n%s · <b>%s</b>", escape(personalEventDate(event, locale)), escape(event.Title))
+	}
+	return builder.String()
+}
+
+// personalEventDate is "🌙 12 Rajab" or "📅 3 May", as plain text.
+func personalEventDate(event domain.PersonalEvent, locale i18n.Locale) string {
+	if event.Hijri {
+		return fmt.Sprintf("🌙 %d %s", event.Day, locale.HijriMonth(event.Month))
+	}
+	return fmt.Sprintf("📅 %d %s", event.Day, locale.Month(event.Month))
+}
@@ -0,0 +1,68 @@
+package telegram
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/escalopa/prayer-bot/global/internal/core/fasting"
+	"github.com/escalopa/prayer-bot/global/internal/core/hijri"
+	"github.com/escalopa/prayer-bot/global/internal/core/i18n"
+	"github.com/escalopa/prayer-bot/global/internal/domain"
+)
+
+const fastingCommandDays = 30
+
+// handleFastingCommand lists the marked days of the next 30 in the chat's
+// Hijri calendar.
+func (h *Handler) handleFastingCommand(ctx context.Context, chatID int64, locale i18n.Locale) error {
+	profile, ok, err := h.profileOrPrompt(ctx, chatID, locale)
+	if err != nil || !ok {
+		return err
+	}
+	location, err := domain.LoadLocation(profile.Timezone)
+	if err != nil {
+		return err
+	}
+	calendar, err := hijri.ForProfile(profile)
+	if err != nil {
+		return err
+	}
+	days, err := fasting.Between(h.now().In(location), fastingCommandDays, calendar, profile.CountryCode)
+	if err != nil {
+		return err
+	}
+	return h.send(ctx, chatID, formatFasting(days, locale), nil)
+}
+
+// formatFasting writes a run of days with the same status and reasons, such as
+// Ramadan, as one line from its first day to its last.
+func formatFasting(days []fasting.Day, locale i18n.Locale) string {
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "<b>%s</b> 🤲\n<i>%s</i>\n", escape(locale.Message("fasting_title")), escape(locale.Message("fasting_help")))
+	for start := 0; start < len(days); {
+		first := days[start]
+		end := start + 1
+		for end < len(days) && days[end].Status == first.Status && slices.Equal(days[end].Reasons, first.Reasons) {
+			end++
+		}
+		if first.Status != "" {
+			dates := fastingDate(first, locale)
+			if end-start > 1 {
+				dates += " – " + fastingDate(days[end-1], locale)
+				// A run is named by its observance, not by its first day.
+				first.Occasion = nil
+			}
+			fmt.Fprintf(&builder, "\n%s <b>%s</b> · %s · %s", first.Status.Emoji(), dates,
+				escape(locale.FastingStatus(first.Status)), escape(locale.FastingReasons(first)))
+		}
+		start = end
+	}
+	builder.WriteString("\n\n<i>" + escape(locale.Message("fasting_note")) + "</i>")
+	return builder.String()
+}
+
+func fastingDate(day fasting.Day, locale i18n.Locale) string {
+	return fmt.Sprintf("%s %d %s", escape(locale.Weekday(day.Date.Weekday())), day.Date.Day(), escape(locale.Month(int(day.Date.Month()))))
+}
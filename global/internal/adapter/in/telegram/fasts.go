@@ -0,0 +1,169 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	botapi "github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+
+	"github.com/escalopa/prayer-bot/global/internal/core/fasting"
+	"github.com/escalopa/prayer-bot/global/internal/core/hijri"
+	"github.com/escalopa/prayer-bot/global/internal/core/i18n"
+	"github.com/escalopa/prayer-bot/global/internal/core/reminders"
+	"github.com/escalopa/prayer-bot/global/internal/domain"
+)
+
+const fastsSuggestions = 3
+
+// handleFastsCommand shows the chat's fasting log, or first records the
+// make-up fasts it owed before the log when the command names a number, such
+// as /fasts 5.
+func (h *Handler) handleFastsCommand(ctx context.Context, message *models.Message, argument string, locale i18n.Locale) error {
+	chatID := message.Chat.ID
+	if message.Chat.Type != models.ChatTypePrivate {
+		return h.send(ctx, chatID, escape(locale.Message("fasts_private")), nil)
+	}
+	profile, ok, err := h.profileOrPrompt(ctx, chatID, locale)
+	if err != nil || !ok {
+		return err
+	}
+	if argument = strings.TrimSpace(argument); argument != "" {
+		days, err := strconv.Atoi(argument)
+		if err != nil || days < 0 || days > domain.MaxQadaCarried {
+			return h.send(ctx, chatID, "⚠️ "+escape(locale.Message("fasts_carried_invalid")), nil)
+		}
+		if err := h.store.SetQadaCarried(ctx, chatID, days); err != nil {
+			return err
+		}
+		if err := h.send(ctx, chatID, escape(fmt.Sprintf(locale.Message("fasts_carried_saved"), days)), nil); err != nil {
+			return err
+		}
+	}
+	location, err := domain.LoadLocation(profile.Timezone)
+	if err != nil {
+		return err
+	}
+	calendar, err := hijri.ForProfile(profile)
+	if err != nil {
+		return err
+	}
+	now := h.now().In(location)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	balance, err := h.store.FastingBalance(ctx, chatID)
+	if err != nil {
+		return err
+	}
+	var suggestions []fasting.Day
+	if balance.Owed() > 0 {
+		if suggestions, err = fasting.MakeUpDays(today.AddDate(0, 0, 1), fastsSuggestions, calendar, profile.CountryCode); err != nil {
+			return err
+		}
+	}
+	day, err := fasting.On(today, calendar, profile.CountryCode)
+	if err != nil {
+		return err
+	}
+	if day.Status == fasting.StatusProhibited {
+		return h.send(ctx, chatID, formatFasts(balance, suggestions, nil, locale), nil)
+	}
+	logged, err := h.store.FastLog(ctx, chatID, today, today)
+	if err != nil {
+		return err
+	}
+	var chosen domain.FastStatus
+	if len(logged) > 0 {
+		chosen = logged[0].Status
+	}
+	return h.send(ctx, chatID, formatFasts(balance, suggestions, &day, locale),
+		reminders.FastLogKeyboard(today, day.Status == fasting.StatusObligatory, chosen, locale))
+}
+
+// handleFastCallback answers the fast:<status>:<date> buttons under fasting
+// reminders and /fasts: it logs the day and marks the choice on the keyboard,
+// so a second tap can change it.
+func (h *Handler) handleFastCallback(ctx context.Context, message *models.Message, data string, locale i18n.Locale) error {
+	chatID := message.Chat.ID
+	if message.Chat.Type != models.ChatTypePrivate {
+		return nil
+	}
+	parts := strings.Split(strings.TrimPrefix(data, reminders.FastCallbackPrefix), ":")
+	if len(parts) != 2 {
+		return nil
+	}
+	status := domain.FastStatus(parts[0])
+	date, err := time.Parse(time.DateOnly, parts[1])
+	if err != nil || !status.Valid() {
+		return nil
+	}
+	profile, ok, err := h.profileOrPrompt(ctx, chatID, locale)
+	if err != nil || !ok {
+		return err
+	}
+	location, err := domain.LoadLocation(profile.Timezone)
+	if err != nil {
+		return err
+	}
+	if !fasting.Loggable(date, h.now().In(location)) {
+		return nil
+	}
+	calendar, err := hijri.ForProfile(profile)
+	if err != nil {
+		return err
+	}
+	entry, err := fasting.Log(chatID, date, status, calendar, profile.CountryCode)
+	switch {
+	case errors.Is(err, domain.ErrFastProhibited):
+		return h.send(ctx, chatID, escape(locale.Message("fasts_prohibited")), nil)
+	case errors.Is(err, domain.ErrMakeUpInRamadan):
+		return h.send(ctx, chatID, escape(locale.Message("fasts_ramadan_makeup")), nil)
+	case err != nil:
+		return err
+	}
+	if err := h.store.LogFast(ctx, entry); err != nil {
+		return err
+	}
+	if _, err := h.bot.EditMessageReplyMarkup(ctx, &botapi.EditMessageReplyMarkupParams{
+		ChatID: chatID, MessageID: message.ID,
+		ReplyMarkup: reminders.FastLogKeyboard(date, entry.Ramadan, status, locale),
+	}); err != nil {
+		return fmt.Errorf("Telegram keyboard edit failed")
+	}
+	return nil
+}
+
+// formatFasts is the balance, the days suggested for making up, and, when
+// today can be fasted, the line the log buttons answer for.
+func formatFasts(balance domain.FastingBalance, suggestions []fasting.Day, today *fasting.Day, locale i18n.Locale) string {
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "<b>%s</b> 📿\n<i>%s</i>\n", escape(locale.Message("fasts_title")), escape(locale.Message("fasts_help")))
+	for _, line := range []struct {
+		emoji, key string
+		count      int
+	}{
+		{"🤲", "fasts_kept", balance.Kept},
+		{"🔁", "fasts_made_up", balance.MadeUp},
+		{"✖️", "fasts_missed", balance.Missed},
+		{"📌", "fasts_carried", balance.Carried},
+	} {
+		fmt.Fprintf(&builder, "\n%s %s: %d", line.emoji, escape(locale.Message(line.key)), line.count)
+	}
+	fmt.Fprintf(&builder, "\n<b>⚖️ %s: %d</b>", escape(locale.Message("fasts_owed")), balance.Owed())
+	if len(suggestions) > 0 {
+		fmt.Fprintf(&builder, "\n\n<b>%s</b>", escape(locale.Message("fasts_suggestions")))
+		for _, day := range suggestions {
+			fmt.Fprintf(&builder, "\n%s %s · %s", day.Status.Emoji(), fastingDate(day, locale), escape(locale.FastingReasons(day)))
+		}
+	}
+	if today != nil {
+		fmt.Fprintf(&builder, "\n\n<b>%s</b> · %s", escape(locale.Message("fasts_today")), fastingDate(*today, locale))
+		if today.Status != "" {
+			fmt.Fprintf(&builder, " · %s %s", today.Status.Emoji(), escape(locale.FastingStatus(today.Status)))
+		}
+	}
+	return builder.String()
+}
@@ -21,10 +21,14 @@ import (
 type Bot interface {
 	SendMessage(context.Context, *botapi.SendMessageParams) (*models.Message, error)
 	SendPhoto(context.Context, *botapi.SendPhotoParams) (*models.Message, error)
+	SendDocument(context.Context, *botapi.SendDocumentParams) (*models.Message, error)
 	EditMessageText(context.Context, *botapi.EditMessageTextParams) (*models.Message, error)
+	EditMessageReplyMarkup(context.Context, *botapi.EditMessageReplyMarkupParams) (*models.Message, error)
 	CopyMessage(context.Context, *botapi.CopyMessageParams) (*models.MessageID, error)
 	AnswerCallbackQuery(context.Context, *botapi.AnswerCallbackQueryParams) (bool, error)
 	GetChatMember(context.Context, *botapi.GetChatMemberParams) (*models.ChatMember, error)
+	GetFile(context.Context, *botapi.GetFileParams) (*models.File, error)
+	FileDownloadLink(*models.File) string
 }
 
 type Handler struct {
@@ -71,6 +75,11 @@ func (h *Handler) Handle(ctx context.Context, update models.Update) error {
 	if message.Location != nil {
 		return h.handleLocation(ctx, message, locale)
 	}
+	if message.Document != nil {
+		if command, argument := parseCommand(message.Caption); command == "timetable" && h.isOwner(message.Chat, message.From) {
+			return h.uploadTimetable(ctx, message.Chat.ID, message.Document, argument)
+		}
+	}
 	command, argument := parseCommand(message.Text)
 	if command == "" {
 		command = i18n.ActionForText(message.Text)
@@ -111,6 +120,22 @@ func (h *Handler) Handle(ctx context.Context, update models.Update) error {
 		return h.sendSchedule(ctx, message.Chat.ID, h.now().AddDate(0, 0, 1), locale.Message("tomorrow_title"), locale)
 	case i18n.ActionNext:
 		return h.sendNext(ctx, message.Chat.ID, locale)
+	case "month":
+		return h.handleMonthCommand(ctx, message.Chat.ID, argument, locale)
+	case "ramadan":
+		return h.handleRamadanCommand(ctx, message.Chat.ID, argument, locale)
+	case "jumuah":
+		return h.handleJumuahCommand(ctx, message, argument, locale)
+	case "convert":
+		return h.handleConvertCommand(ctx, message.Chat.ID, argument, locale)
+	case "events":
+		return h.handleEventsCommand(ctx, message, argument, locale)
+	case "fasting":
+		return h.handleFastingCommand(ctx, message.Chat.ID, locale)
+	case "fasts":
+		return h.handleFastsCommand(ctx, message, argument, locale)
+	case "custom":
+		return h.handleCustomCommand(ctx, message, argument, locale)
 	case i18n.ActionSettings:
 		return h.sendSettings(ctx, message.Chat.ID, locale)
 	case i18n.ActionReminders, "remind":
@@ -151,6 +176,30 @@ func (h *Handler) Handle(ctx context.Context, update models.Update) error {
 			return nil
 		}
 		return h.sendAdminDashboard(ctx, message.Chat.ID, adminViewOverview)
+	case "timetable", "timetables", "timetable_delete":
+		if !h.isOwner(message.Chat, message.From) {
+			return nil
+		}
+		switch command {
+		case "timetables":
+			return h.sendTimetables(ctx, message.Chat.ID)
+		case "timetable_delete":
+			return h.deleteTimetable(ctx, message.Chat.ID, argument)
+		default:
+			return h.send(ctx, message.Chat.ID, timetableUsage, nil)
+		}
+	case "hijri_announce", "hijri_announcements", "hijri_announcement_delete":
+		if !h.isOwner(message.Chat, message.From) {
+			return nil
+		}
+		switch command {
+		case "hijri_announce":
+			return h.publishHijriAnnouncement(ctx, message.Chat.ID, argument)
+		case "hijri_announcement_delete":
+			return h.deleteHijriAnnouncement(ctx, message.Chat.ID, argument)
+		default:
+			return h.sendHijriAnnouncements(ctx, message.Chat.ID)
+		}
 	default:
 		return h.send(ctx, message.Chat.ID, locale.Message("unknown"), mainKeyboard(locale))
 	}
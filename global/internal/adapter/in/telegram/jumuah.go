@@ -0,0 +1,144 @@
+package telegram
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/go-telegram/bot/models"
+
+	"github.com/escalopa/prayer-bot/global/internal/core/i18n"
+	"github.com/escalopa/prayer-bot/global/internal/domain"
+)
+
+// handleJumuahCommand shows the Jumu'ah view, or sets the times when the
+// command names them, such as /jumuah 13:15 13:45, and clears them on
+// /jumuah off.
+func (h *Handler) handleJumuahCommand(ctx context.Context, message *models.Message, argument string, locale i18n.Locale) error {
+	chatID := message.Chat.ID
+	argument = strings.TrimSpace(argument)
+	if argument == "" {
+		return h.sendJumuah(ctx, chatID, locale)
+	}
+	if ok, err := h.canConfigure(ctx, message, locale); err != nil || !ok {
+		return err
+	}
+	if strings.EqualFold(argument, "off") {
+		if _, err := h.clearJumuah(ctx, chatID, locale); err != nil {
+			return err
+		}
+		return h.send(ctx, chatID, locale.Message("jumuah_cleared"), nil)
+	}
+	jumuah, err := domain.ParseJumuah(argument)
+	if err != nil {
+		return h.send(ctx, chatID, locale.Message("jumuah_invalid"), nil)
+	}
+	if _, ok, err := h.updateProfile(ctx, chatID, locale, func(profile *domain.PrayerProfile) { profile.Jumuah = jumuah }); err != nil || !ok {
+		return err
+	}
+	if err := h.send(ctx, chatID, fmt.Sprintf(locale.Message("jumuah_saved"), jumuah.Khutbah), nil); err != nil {
+		return err
+	}
+	return h.sendJumuah(ctx, chatID, locale)
+}
+
+func (h *Handler) sendJumuah(ctx context.Context, chatID int64, locale i18n.Locale) error {
+	profile, ok, err := h.profileOrPrompt(ctx, chatID, locale)
+	if err != nil || !ok {
+		return err
+	}
+	minutes, err := h.jumuahMinutes(ctx, chatID)
+	if err != nil {
+		return err
+	}
+	return h.send(ctx, chatID, formatJumuah(profile.Jumuah, minutes, locale), jumuahKeyboard(profile.Jumuah, minutes, locale))
+}
+
+// handleJumuahCallback answers jumuah:remind:<minutes|off> and jumuah:clear
+// from the Jumu'ah view.
+func (h *Handler) handleJumuahCallback(ctx context.Context, message *models.Message, data string, locale i18n.Locale) error {
+	chatID := message.Chat.ID
+	var profile domain.PrayerProfile
+	switch parts := strings.Split(strings.TrimPrefix(data, "jumuah:"), ":"); {
+	case len(parts) == 1 && parts[0] == "clear":
+		cleared, err := h.clearJumuah(ctx, chatID, locale)
+		if err != nil {
+			return err
+		}
+		profile = cleared
+	case len(parts) == 2 && parts[0] == "remind":
+		current, ok, err := h.profileOrPrompt(ctx, chatID, locale)
+		if err != nil || !ok {
+			return err
+		}
+		profile = current
+		enabled, minutes := parts[1] != "off", 0
+		if enabled {
+			value, err := strconv.Atoi(parts[1])
+			if err != nil || !domain.ValidJumuahMinutes(value) || !profile.Jumuah.Enabled() {
+				return nil
+			}
+			minutes = value
+		}
+		if err := h.store.SetJumuahRule(ctx, chatID, minutes, enabled); err != nil {
+			return err
+		}
+		if enabled {
+			if err := h.planner.RebuildChat(ctx, chatID, h.now()); err != nil {
+				return err
+			}
+		}
+	default:
+		return nil
+	}
+	minutes, err := h.jumuahMinutes(ctx, chatID)
+	if err != nil {
+		return err
+	}
+	return h.edit(ctx, chatID, message.ID, formatJumuah(profile.Jumuah, minutes, locale), jumuahKeyboard(profile.Jumuah, minutes, locale))
+}
+
+// clearJumuah turns the Friday reminder off before clearing the times, so the
+// planner never meets a Jumu'ah rule without a khutbah to count from.
+func (h *Handler) clearJumuah(ctx context.Context, chatID int64, locale i18n.Locale) (domain.PrayerProfile, error) {
+	if err := h.store.SetJumuahRule(ctx, chatID, 0, false); err != nil {
+		return domain.PrayerProfile{}, err
+	}
+	profile, _, err := h.updateProfile(ctx, chatID, locale, func(profile *domain.PrayerProfile) { profile.Jumuah = domain.Jumuah{} })
+	return profile, err
+}
+
+// jumuahMinutes is the Friday reminder's lead time, or 0 when it is off.
+func (h *Handler) jumuahMinutes(ctx context.Context, chatID int64) (int, error) {
+	rules, err := h.store.EnabledRules(ctx, chatID)
+	if err != nil {
+		return 0, err
+	}
+	for _, rule := range rules {
+		if rule.Kind == domain.ReminderJumuah {
+			return rule.OffsetMinutes, nil
+		}
+	}
+	return 0, nil
+}
+
+func formatJumuah(jumuah domain.Jumuah, minutes int, locale i18n.Locale) string {
+	reminder := locale.Message("jumuah_reminder_off")
+	if minutes > 0 {
+		reminder = fmt.Sprintf(locale.Message("jumuah_minutes"), minutes)
+	}
+	return fmt.Sprintf("%s\n\n🕌 %s\n🔔 %s", locale.Message("choose_jumuah"), jumuahSummary(jumuah, locale), escape(reminder))
+}
+
+// jumuahSummary is "Khutbah 13:15 · Iqamah 13:45", or "not set".
+func jumuahSummary(jumuah domain.Jumuah, locale i18n.Locale) string {
+	if !jumuah.Enabled() {
+		return escape(locale.Message("jumuah_not_set"))
+	}
+	summary := fmt.Sprintf("%s <code>%s</code>", escape(locale.Message("jumuah_khutbah")), jumuah.Khutbah)
+	if jumuah.Iqamah != "" {
+		summary += fmt.Sprintf(" · %s <code>%s</code>", escape(locale.Message("jumuah_iqamah")), jumuah.Iqamah)
+	}
+	return summary
+}
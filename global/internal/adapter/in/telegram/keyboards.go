@@ -2,10 +2,13 @@ package telegram
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/go-telegram/bot/models"
 
 	"github.com/escalopa/prayer-bot/global/internal/core/i18n"
+	"github.com/escalopa/prayer-bot/global/internal/core/monthsheet"
 	"github.com/escalopa/prayer-bot/global/internal/domain"
 )
 
@@ -26,10 +29,16 @@ func mainKeyboard(locale i18n.Locale) *models.ReplyKeyboardMarkup {
 func settingsKeyboard(locale i18n.Locale) *models.InlineKeyboardMarkup {
 	return inlineKeyboard(
 		[]models.InlineKeyboardButton{callbackButton(locale.Button("method"), "settings:method")},
+		[]models.InlineKeyboardButton{callbackButton(locale.Button("official_timetable"), "settings:timetable")},
 		[]models.InlineKeyboardButton{callbackButton(locale.Button("madhab"), "settings:madhab")},
 		[]models.InlineKeyboardButton{callbackButton(locale.Button("highlat"), "settings:highlat")},
 		[]models.InlineKeyboardButton{callbackButton(locale.Button("adjustments"), "settings:adjustments")},
+		[]models.InlineKeyboardButton{callbackButton(locale.Button("precaution"), "settings:precaution")},
 		[]models.InlineKeyboardButton{callbackButton(locale.Button("hijri"), "settings:hijri")},
+		[]models.InlineKeyboardButton{callbackButton(locale.Button("extended_times"), "settings:extended")},
+		[]models.InlineKeyboardButton{callbackButton(locale.Button("makruh_times"), "settings:makruh")},
+		[]models.InlineKeyboardButton{callbackButton(locale.Button("elevation"), "settings:elevation")},
+		[]models.InlineKeyboardButton{callbackButton(locale.Button("jumuah"), "settings:jumuah")},
 		[]models.InlineKeyboardButton{callbackButton(locale.Button("close"), "close")},
 	)
 }
@@ -41,6 +50,9 @@ func methodKeyboard(current domain.Method, locale i18n.Locale) *models.InlineKey
 			selectedLabel(locale.Method(method), method == current), "method:"+string(method),
 		)})
 	}
+	if current == domain.MethodCustom {
+		rows = append(rows, []models.InlineKeyboardButton{callbackButton(locale.Button("custom_method"), "settings:custom")})
+	}
 	rows = append(rows, []models.InlineKeyboardButton{callbackButton(locale.Button("back"), "settings")})
 	return inlineKeyboard(rows...)
 }
@@ -54,9 +66,7 @@ func madhabKeyboard(current domain.Madhab, locale i18n.Locale) *models.InlineKey
 }
 
 func highLatitudeKeyboard(current domain.HighLatitudeRule, locale i18n.Locale) *models.InlineKeyboardMarkup {
-	rules := []domain.HighLatitudeRule{
-		domain.HighLatitudeAngleBased, domain.HighLatitudeMiddleNight, domain.HighLatitudeSeventhNight,
-	}
+	rules := domain.SupportedHighLatitudeRules()
 	rows := make([][]models.InlineKeyboardButton, 0, len(rules)+1)
 	for _, rule := range rules {
 		rows = append(rows, []models.InlineKeyboardButton{callbackButton(
@@ -100,18 +110,165 @@ func adjustmentDetailKeyboard(prayer domain.Prayer, locale i18n.Locale) *models.
 	)
 }
 
-func hijriKeyboard(current int, locale i18n.Locale) *models.InlineKeyboardMarkup {
-	row := make([]models.InlineKeyboardButton, 0, 5)
-	for value := -2; value <= 2; value++ {
-		label := fmt.Sprintf("%+d", value)
-		row = append(row, callbackButton(selectedLabel(label, value == current), fmt.Sprintf("hijri:%d", value)))
+// precautionKeyboard picks the ihtiyat and cycles each prayer's rounding
+// through nearest, up, and down.
+func precautionKeyboard(precaution domain.Precaution, locale i18n.Locale) *models.InlineKeyboardMarkup {
+	margins := make([]models.InlineKeyboardButton, 0, len(domain.SupportedIhtiyatMinutes()))
+	for _, minutes := range domain.SupportedIhtiyatMinutes() {
+		margins = append(margins, callbackButton(
+			selectedLabel(fmt.Sprintf("+%d", minutes), minutes == precaution.IhtiyatMinutes), fmt.Sprintf("precaution:ihtiyat:%d", minutes),
+		))
+	}
+	rows := [][]models.InlineKeyboardButton{margins}
+	prayers := allPrayers()
+	for index := 0; index < len(prayers); index += 2 {
+		row := make([]models.InlineKeyboardButton, 0, 2)
+		for _, prayer := range prayers[index:min(index+2, len(prayers))] {
+			row = append(row, callbackButton(
+				locale.Prayer(prayer)+" "+i18n.RoundingSymbol(precaution.Rounding(prayer)), "precaution:round:"+string(prayer),
+			))
+		}
+		rows = append(rows, row)
+	}
+	rows = append(rows, []models.InlineKeyboardButton{callbackButton(locale.Button("back"), "settings")})
+	return inlineKeyboard(rows...)
+}
+
+// monthKeyboard offers every export format for month and steps to the
+// neighbouring months while they stay within reach of now.
+func monthKeyboard(month, now time.Time, locale i18n.Locale) *models.InlineKeyboardMarkup {
+	formats := make([]models.InlineKeyboardButton, 0, len(monthsheet.Formats()))
+	for _, format := range monthsheet.Formats() {
+		formats = append(formats, callbackButton(strings.ToUpper(string(format)), "month:"+string(format)+":"+month.Format("2006-01")))
+	}
+	var steps []models.InlineKeyboardButton
+	for _, step := range []struct {
+		label  string
+		months int
+	}{{"◀", -1}, {"▶", 1}} {
+		target := month.AddDate(0, step.months, 0)
+		if monthsheet.WithinReach(target, now) {
+			steps = append(steps, callbackButton(step.label, "month:show:"+target.Format("2006-01")))
+		}
+	}
+	return inlineKeyboard(formats, steps, []models.InlineKeyboardButton{callbackButton(locale.Button("close"), "close")})
+}
+
+func ramadanKeyboard(locale i18n.Locale) *models.InlineKeyboardMarkup {
+	formats := make([]models.InlineKeyboardButton, 0, len(monthsheet.Formats()))
+	for _, format := range monthsheet.Formats() {
+		formats = append(formats, callbackButton(strings.ToUpper(string(format)), "ramadan:"+string(format)))
+	}
+	return inlineKeyboard(formats, []models.InlineKeyboardButton{callbackButton(locale.Button("close"), "close")})
+}
+
+// customMethodKeyboard nudges the custom angles in half-degree steps and picks
+// the Isha interval from the values councils commonly publish; 0 means Isha is
+// calculated from its angle.
+func customMethodKeyboard(custom domain.CustomMethod, locale i18n.Locale) *models.InlineKeyboardMarkup {
+	angleRow := func(prayer domain.Prayer) []models.InlineKeyboardButton {
+		name := locale.Prayer(prayer)
+		return []models.InlineKeyboardButton{
+			callbackButton(name+" −0.5°", "custom:"+string(prayer)+":-0.5"),
+			callbackButton(name+" +0.5°", "custom:"+string(prayer)+":0.5"),
+		}
+	}
+	intervals := make([]models.InlineKeyboardButton, 0, len(customIshaIntervals))
+	for _, minutes := range customIshaIntervals {
+		intervals = append(intervals, callbackButton(
+			selectedLabel(customIntervalLabel(minutes, locale), minutes == custom.IshaIntervalMinutes),
+			fmt.Sprintf("custom:interval:%d", minutes),
+		))
+	}
+	return inlineKeyboard(
+		angleRow(domain.PrayerFajr),
+		angleRow(domain.PrayerIsha),
+		intervals,
+		[]models.InlineKeyboardButton{callbackButton(locale.Button("back"), "settings:method")},
+	)
+}
+
+var customIshaIntervals = []int{0, 60, 75, 90, 120}
+
+// extendedTimesKeyboard toggles the extended times in schedules and picks the
+// Imsak margin before Fajr.
+func extendedTimesKeyboard(profile domain.PrayerProfile, locale i18n.Locale) *models.InlineKeyboardMarkup {
+	show := callbackButton("○ "+locale.Message("show_extended_times"), "extended:show:on")
+	if profile.ShowExtendedTimes {
+		show = callbackButton("✓ "+locale.Message("show_extended_times"), "extended:show:off")
+	}
+	margins := make([]models.InlineKeyboardButton, 0, len(domain.SupportedImsakMinutes()))
+	for _, minutes := range domain.SupportedImsakMinutes() {
+		margins = append(margins, callbackButton(
+			selectedLabel(fmt.Sprint(minutes), minutes == profile.ImsakMinutes), fmt.Sprintf("extended:imsak:%d", minutes),
+		))
+	}
+	return inlineKeyboard(
+		[]models.InlineKeyboardButton{show},
+		margins,
+		[]models.InlineKeyboardButton{callbackButton(locale.Button("back"), "settings")},
+	)
+}
+
+func makruhKeyboard(profile domain.PrayerProfile, locale i18n.Locale) *models.InlineKeyboardMarkup {
+	show := callbackButton("✓ "+locale.Message("show_makruh"), "makruh:show:off")
+	if profile.HideMakruhTimes {
+		show = callbackButton("○ "+locale.Message("show_makruh"), "makruh:show:on")
+	}
+	return inlineKeyboard(
+		[]models.InlineKeyboardButton{show},
+		[]models.InlineKeyboardButton{callbackButton(locale.Button("back"), "settings")},
+	)
+}
+
+func timetableKeyboard(profile domain.PrayerProfile, locale i18n.Locale) *models.InlineKeyboardMarkup {
+	use := callbackButton("✓ "+locale.Message("use_timetable"), "timetable:use:off")
+	if profile.IgnoreTimetables {
+		use = callbackButton("○ "+locale.Message("use_timetable"), "timetable:use:on")
 	}
 	return inlineKeyboard(
-		row,
+		[]models.InlineKeyboardButton{use},
 		[]models.InlineKeyboardButton{callbackButton(locale.Button("back"), "settings")},
 	)
 }
 
+// elevationKeyboard steps the elevation and, when the resolver can look it
+// up, offers to detect it again from the saved coordinates.
+func elevationKeyboard(detect bool, locale i18n.Locale) *models.InlineKeyboardMarkup {
+	steps := make([]models.InlineKeyboardButton, 0, len(elevationSteps))
+	for _, step := range elevationSteps {
+		steps = append(steps, callbackButton(fmt.Sprintf("%+d", step), fmt.Sprintf("elevation:add:%d", step)))
+	}
+	reset := []models.InlineKeyboardButton{callbackButton(locale.Button("elevation_sea_level"), "elevation:set:0")}
+	if detect {
+		reset = append(reset, callbackButton(locale.Button("elevation_detect"), "elevation:detect"))
+	}
+	return inlineKeyboard(
+		steps,
+		reset,
+		[]models.InlineKeyboardButton{callbackButton(locale.Button("back"), "settings")},
+	)
+}
+
+var elevationSteps = []int{-100, -10, 10, 100}
+
+func hijriKeyboard(profile domain.PrayerProfile, locale i18n.Locale) *models.InlineKeyboardMarkup {
+	rows := make([][]models.InlineKeyboardButton, 0, len(domain.SupportedHijriCalendars())+2)
+	for _, system := range domain.SupportedHijriCalendars() {
+		selected := system == profile.HijriCalendar.OrDefault()
+		rows = append(rows, []models.InlineKeyboardButton{
+			callbackButton(selectedLabel(locale.HijriCalendar(system), selected), "hijrical:"+string(system)),
+		})
+	}
+	row := make([]models.InlineKeyboardButton, 0, 5)
+	for value := -2; value <= 2; value++ {
+		label := fmt.Sprintf("%+d", value)
+		row = append(row, callbackButton(selectedLabel(label, value == profile.HijriAdjustment), fmt.Sprintf("hijri:%d", value)))
+	}
+	rows = append(rows, row, []models.InlineKeyboardButton{callbackButton(locale.Button("back"), "settings")})
+	return inlineKeyboard(rows...)
+}
+
 func remindersKeyboard(state reminderState, locale i18n.Locale) *models.InlineKeyboardMarkup {
 	toggle := func(label, kind string, enabled bool) models.InlineKeyboardButton {
 		action, prefix := "on", "○ "
@@ -133,6 +290,8 @@ func remindersKeyboard(state reminderState, locale i18n.Locale) *models.InlineKe
 		{toggle(locale.OccasionUI("major_reminders"), "occasion_major", state.OccasionMajor)},
 		{toggle(locale.OccasionUI("fasting_reminders"), "occasion_fasting", state.OccasionFasting)},
 		{toggle(locale.OccasionUI("observed_reminders"), "occasion_observed", state.OccasionObserved)},
+		{callbackButton(locale.Button("extended_reminders"), "reminders:extended:choose")},
+		{callbackButton("🌅 "+locale.Button("ramadan_reminders"), "reminders:ramadan:choose")},
 	}
 	if state.IsGroup {
 		// The jamaa'ah poll changes how a group receives its pre-prayer
@@ -146,6 +305,107 @@ func remindersKeyboard(state reminderState, locale i18n.Locale) *models.InlineKe
 	return inlineKeyboard(rows...)
 }
 
+func extendedRemindersKeyboard(state reminderState, locale i18n.Locale) *models.InlineKeyboardMarkup {
+	rows := make([][]models.InlineKeyboardButton, 0, len(domain.ExtendedTimes())+1)
+	for _, prayer := range domain.ExtendedTimes() {
+		action, prefix := "on", "○ "
+		if state.Extended[prayer] {
+			action, prefix = "off", "✓ "
+		}
+		rows = append(rows, []models.InlineKeyboardButton{callbackButton(
+			prefix+prayerEmoji(prayer)+" "+locale.Prayer(prayer), "reminders:extended:"+string(prayer)+":"+action,
+		)})
+	}
+	rows = append(rows, []models.InlineKeyboardButton{callbackButton(locale.Button("back"), "reminders:extended:back")})
+	return inlineKeyboard(rows...)
+}
+
+// ramadanRemindersKeyboard picks the suhoor lead time, or none, and toggles
+// the iftar reminder.
+func ramadanRemindersKeyboard(state reminderState, locale i18n.Locale) *models.InlineKeyboardMarkup {
+	iftarAction, iftarPrefix := "on", "○ "
+	if state.Iftar {
+		iftarAction, iftarPrefix = "off", "✓ "
+	}
+	rows := [][]models.InlineKeyboardButton{
+		{callbackButton(selectedLabel(locale.Message("suhoor_off"), state.SuhoorMinutes == 0), "reminders:ramadan:suhoor:off")},
+	}
+	values := domain.SupportedSuhoorMinutes()
+	for index := 0; index < len(values); index += 2 {
+		row := make([]models.InlineKeyboardButton, 0, 2)
+		for _, minutes := range values[index:min(index+2, len(values))] {
+			row = append(row, callbackButton(
+				selectedLabel(fmt.Sprintf(locale.Message("suhoor_minutes"), minutes), state.SuhoorMinutes == minutes),
+				fmt.Sprintf("reminders:ramadan:suhoor:%d", minutes),
+			))
+		}
+		rows = append(rows, row)
+	}
+	rows = append(rows,
+		[]models.InlineKeyboardButton{callbackButton(iftarPrefix+"🌅 "+locale.Message("iftar_reminder"), "reminders:ramadan:iftar:"+iftarAction)},
+		[]models.InlineKeyboardButton{callbackButton(locale.Button("back"), "reminders:ramadan:back")},
+	)
+	return inlineKeyboard(rows...)
+}
+
+// jumuahKeyboard picks the reminder lead time before the khutbah once the
+// times are set; setting them takes the /jumuah command.
+func jumuahKeyboard(jumuah domain.Jumuah, current int, locale i18n.Locale) *models.InlineKeyboardMarkup {
+	if !jumuah.Enabled() {
+		return inlineKeyboard([]models.InlineKeyboardButton{callbackButton(locale.Button("back"), "settings")})
+	}
+	rows := [][]models.InlineKeyboardButton{
+		{callbackButton(selectedLabel(locale.Message("jumuah_reminder_off"), current == 0), "jumuah:remind:off")},
+	}
+	values := domain.SupportedJumuahMinutes()
+	for index := 0; index < len(values); index += 2 {
+		row := make([]models.InlineKeyboardButton, 0, 2)
+		for _, minutes := range values[index:min(index+2, len(values))] {
+			row = append(row, callbackButton(
+				selectedLabel(fmt.Sprintf(locale.Message("jumuah_minutes"), minutes), current == minutes),
+				fmt.Sprintf("jumuah:remind:%d", minutes),
+			))
+		}
+		rows = append(rows, row)
+	}
+	rows = append(rows,
+		[]models.InlineKeyboardButton{callbackButton(locale.Button("jumuah_clear"), "jumuah:clear")},
+		[]models.InlineKeyboardButton{callbackButton(locale.Button("back"), "settings")},
+	)
+	return inlineKeyboard(rows...)
+}
+
+// eventsKeyboard toggles the personal events reminder and offers one delete
+// button per event.
+func eventsKeyboard(events []domain.PersonalEvent, reminder bool, locale i18n.Locale) *models.InlineKeyboardMarkup {
+	action, prefix := "on", "○ "
+	if reminder {
+		action, prefix = "off", "✓ "
+	}
+	rows := [][]models.InlineKeyboardButton{
+		{callbackButton(prefix+locale.OccasionUI("personal_reminders"), "events:remind:"+action)},
+	}
+	for _, event := range events {
+		rows = append(rows, []models.InlineKeyboardButton{callbackButton(
+			"🗑 "+personalEventDate(event, locale)+" · "+event.Title, fmt.Sprintf("events:delete:%d", event.ID),
+		)})
+	}
+	rows = append(rows, []models.InlineKeyboardButton{callbackButton(locale.Button("close"), "close")})
+	return inlineKeyboard(rows...)
+}
+
+// customRemindersKeyboard offers one delete button per custom reminder.
+func customRemindersKeyboard(customs []domain.CustomReminder, locale i18n.Locale) *models.InlineKeyboardMarkup {
+	rows := make([][]models.InlineKeyboardButton, 0, len(customs)+1)
+	for index, custom := range customs {
+		rows = append(rows, []models.InlineKeyboardButton{callbackButton(
+			truncateLabel(fmt.Sprintf("🗑 %d. %s", index+1, custom.Text), 60), fmt.Sprintf("custom_reminder:delete:%d", custom.RuleID),
+		)})
+	}
+	rows = append(rows, []models.InlineKeyboardButton{callbackButton(locale.Button("close"), "close")})
+	return inlineKeyboard(rows...)
+}
+
 func preReminderKeyboard(current int, locale i18n.Locale) *models.InlineKeyboardMarkup {
 	values := domain.SupportedPreReminderMinutes()
 	rows := make([][]models.InlineKeyboardButton, 0, (len(values)+1)/2+1)
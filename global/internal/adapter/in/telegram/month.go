@@ -0,0 +1,112 @@
+package telegram
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	botapi "github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+
+	"github.com/escalopa/prayer-bot/global/internal/core/i18n"
+	"github.com/escalopa/prayer-bot/global/internal/core/monthsheet"
+	"github.com/escalopa/prayer-bot/global/internal/domain"
+)
+
+// handleMonthCommand sends the timetable directly when the command names a
+// format, such as /month csv 2026-04, and the format picker otherwise.
+func (h *Handler) handleMonthCommand(ctx context.Context, chatID int64, argument string, locale i18n.Locale) error {
+	profile, ok, err := h.profileOrPrompt(ctx, chatID, locale)
+	if err != nil || !ok {
+		return err
+	}
+	location, err := domain.LoadLocation(profile.Timezone)
+	if err != nil {
+		return err
+	}
+	month := monthsheet.FirstOfMonth(h.now().In(location))
+	format, named := monthsheet.Format(""), false
+	for _, field := range strings.Fields(strings.ToLower(argument)) {
+		if parsed, ok := monthsheet.ParseFormat(field); ok {
+			format, named = parsed, true
+			continue
+		}
+		parsed, err := monthsheet.ParseMonth(field, h.now(), profile.Timezone)
+		if err != nil {
+			return h.send(ctx, chatID, locale.Message("month_invalid"), nil)
+		}
+		month = parsed
+	}
+	if !named {
+		return h.send(ctx, chatID, formatMonthPicker(month, locale), monthKeyboard(month, h.now(), locale))
+	}
+	return h.sendMonthFile(ctx, chatID, profile, month, format, locale)
+}
+
+// handleMonthCallback answers month:<format>:<YYYY-MM> with the file and
+// month:show:<YYYY-MM> by moving the picker to another month.
+func (h *Handler) handleMonthCallback(ctx context.Context, message *models.Message, data string, locale i18n.Locale) error {
+	parts := strings.Split(data, ":")
+	if len(parts) != 3 {
+		return nil
+	}
+	profile, ok, err := h.profileOrPrompt(ctx, message.Chat.ID, locale)
+	if err != nil || !ok {
+		return err
+	}
+	month, err := monthsheet.ParseMonth(parts[2], h.now(), profile.Timezone)
+	if err != nil {
+		return nil
+	}
+	if parts[1] == "show" {
+		return h.edit(ctx, message.Chat.ID, message.ID, formatMonthPicker(month, locale), monthKeyboard(month, h.now(), locale))
+	}
+	format, ok := monthsheet.ParseFormat(parts[1])
+	if !ok {
+		return nil
+	}
+	return h.sendMonthFile(ctx, message.Chat.ID, profile, month, format, locale)
+}
+
+func (h *Handler) sendMonthFile(
+	ctx context.Context,
+	chatID int64,
+	profile domain.PrayerProfile,
+	month time.Time,
+	format monthsheet.Format,
+	locale i18n.Locale,
+) error {
+	sheet, err := monthsheet.Build(ctx, h.calculator, profile, month)
+	if err != nil {
+		return err
+	}
+	return h.sendSheet(ctx, chatID, sheet, format, locale)
+}
+
+// sendSheet uploads a rendered timetable, monthly or Ramadan, as a document.
+func (h *Handler) sendSheet(ctx context.Context, chatID int64, sheet monthsheet.Month, format monthsheet.Format, locale i18n.Locale) error {
+	file, err := monthsheet.Render(sheet, locale, format)
+	if err != nil {
+		return err
+	}
+	title, subtitle := monthsheet.Heading(sheet, locale)
+	_, err = h.bot.SendDocument(ctx, &botapi.SendDocumentParams{
+		ChatID: chatID,
+		Document: &models.InputFileUpload{
+			Filename: sheet.Filename(format),
+			Data:     bytes.NewReader(file),
+		},
+		Caption:   fmt.Sprintf("<b>%s</b> 🗓\n%s", escape(title), escape(subtitle)),
+		ParseMode: models.ParseModeHTML,
+	})
+	if err != nil {
+		return fmt.Errorf("Telegram document send failed")
+	}
+	return nil
+}
+
+func formatMonthPicker(month time.Time, locale i18n.Locale) string {
+	return fmt.Sprintf(locale.Message("choose_month"), escape(monthsheet.Title(month, locale)))
+}
@@ -0,0 +1,77 @@
+package telegram
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-telegram/bot/models"
+
+	"github.com/escalopa/prayer-bot/global/internal/core/i18n"
+	"github.com/escalopa/prayer-bot/global/internal/core/monthsheet"
+	"github.com/escalopa/prayer-bot/global/internal/domain"
+)
+
+// handleRamadanCommand shows the current or next Ramadan, or sends it as a
+// file when the command names a format, such as /ramadan pdf.
+func (h *Handler) handleRamadanCommand(ctx context.Context, chatID int64, argument string, locale i18n.Locale) error {
+	profile, ok, err := h.profileOrPrompt(ctx, chatID, locale)
+	if err != nil || !ok {
+		return err
+	}
+	sheet, err := monthsheet.BuildRamadan(ctx, h.calculator, profile, h.now())
+	if err != nil {
+		return err
+	}
+	if format, ok := monthsheet.ParseFormat(strings.ToLower(strings.TrimSpace(argument))); ok {
+		return h.sendSheet(ctx, chatID, sheet, format, locale)
+	}
+	return h.send(ctx, chatID, formatRamadan(sheet, h.now(), locale), ramadanKeyboard(locale))
+}
+
+// handleRamadanCallback answers ramadan:<format> with the file.
+func (h *Handler) handleRamadanCallback(ctx context.Context, message *models.Message, data string, locale i18n.Locale) error {
+	format, ok := monthsheet.ParseFormat(strings.TrimPrefix(data, "ramadan:"))
+	if !ok {
+		return nil
+	}
+	profile, ok, err := h.profileOrPrompt(ctx, message.Chat.ID, locale)
+	if err != nil || !ok {
+		return err
+	}
+	sheet, err := monthsheet.BuildRamadan(ctx, h.calculator, profile, h.now())
+	if err != nil {
+		return err
+	}
+	return h.sendSheet(ctx, message.Chat.ID, sheet, format, locale)
+}
+
+// formatRamadan lists every day as Imsak, the end of suhoor and iftar, with
+// today in bold.
+func formatRamadan(sheet monthsheet.Month, now time.Time, locale i18n.Locale) string {
+	title, subtitle := monthsheet.Heading(sheet, locale)
+	today := now.In(sheet.Start.Location()).Format("2006-01-02")
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "<b>%s</b> 🌙\n%s\n\n%s · %s · %s\n",
+		escape(title), escape(subtitle),
+		escape(locale.Prayer(domain.PrayerImsak)), escape(locale.Message("ramadan_suhoor")), escape(locale.Message("ramadan_iftar")))
+	for _, day := range sheet.Days {
+		line := fmt.Sprintf("<code>%2d</code> %s %d · %s · %s · %s",
+			day.Hijri.Day, escape(locale.Weekday(day.Date.Weekday())), day.Date.Day(),
+			clockOrDash(day, domain.PrayerImsak), clockOrDash(day, domain.PrayerFajr), clockOrDash(day, domain.PrayerMaghrib))
+		if day.Date.Format("2006-01-02") == today {
+			line = "<b>" + line + "</b>"
+		}
+		builder.WriteString("\n" + line)
+	}
+	builder.WriteString("\n\n" + escape(locale.Message("ramadan_download")))
+	return builder.String()
+}
+
+func clockOrDash(day monthsheet.Day, prayer domain.Prayer) string {
+	if at, ok := day.Times[prayer]; ok && !at.IsZero() {
+		return at.Format("15:04")
+	}
+	return "—"
+}
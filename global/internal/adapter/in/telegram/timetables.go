@@ -0,0 +1,164 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	botapi "github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+
+	"github.com/escalopa/prayer-bot/global/internal/core/prayertime"
+	"github.com/escalopa/prayer-bot/global/internal/domain"
+)
+
+// maxTimetableFileBytes comfortably fits MaxTimetableDays rows.
+const maxTimetableFileBytes = 1 << 20
+
+const timetableUsage = "<b>Official timetables</b> 📋\n\n" +
+	"Send the CSV as a document with one of these captions:\n" +
+	"<code>/timetable place 55.796,49.106 40 Europe/Moscow Kazan Muftiate</code>\n" +
+	"<code>/timetable country RU Europe/Moscow Russian Muftiate</code>\n\n" +
+	"A place timetable covers a circle of up to 100 km; a country timetable covers every user in the country and timezone. " +
+	"Rows are <code>D/M/YYYY,Fajr,Sunrise,Dhuhr,Asr,Maghrib,Isha</code> after a header row.\n\n" +
+	"/timetables lists uploads · <code>/timetable_delete ID</code> removes one"
+
+// uploadTimetable stores an official timetable the owner sent as a document.
+// A file or caption the bot cannot use is answered with the reason and is not
+// an error.
+func (h *Handler) uploadTimetable(ctx context.Context, chatID int64, document *models.Document, caption string) error {
+	timetable, err := parseTimetableCaption(caption)
+	if err != nil {
+		return h.send(ctx, chatID, "⚠️ "+escape(err.Error())+"\n\n"+timetableUsage, nil)
+	}
+	if document.FileSize > maxTimetableFileBytes {
+		return h.send(ctx, chatID, "⚠️ The timetable file is larger than 1 MiB.", nil)
+	}
+	file, err := h.downloadDocument(ctx, document)
+	if err != nil {
+		return err
+	}
+	days, err := prayertime.ParseTimetable(strings.NewReader(file))
+	if err != nil {
+		return h.send(ctx, chatID, "⚠️ The timetable was not saved: "+escape(err.Error()), nil)
+	}
+	created, err := h.store.CreateTimetable(ctx, timetable, days)
+	if err != nil {
+		return fmt.Errorf("save official timetable: %w", err)
+	}
+	h.invalidateTimetables()
+	return h.send(ctx, chatID, "✅ Saved\n\n"+formatTimetable(created), nil)
+}
+
+func (h *Handler) downloadDocument(ctx context.Context, document *models.Document) (string, error) {
+	file, err := h.bot.GetFile(ctx, &botapi.GetFileParams{FileID: document.FileID})
+	if err != nil {
+		return "", fmt.Errorf("Telegram file lookup failed")
+	}
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, h.bot.FileDownloadLink(file), nil)
+	if err != nil {
+		return "", fmt.Errorf("build Telegram file request: %w", err)
+	}
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return "", fmt.Errorf("Telegram file download failed")
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("Telegram file download returned %d", response.StatusCode)
+	}
+	body, err := io.ReadAll(io.LimitReader(response.Body, maxTimetableFileBytes))
+	if err != nil {
+		return "", fmt.Errorf("Telegram file download failed")
+	}
+	return string(body), nil
+}
+
+func (h *Handler) sendTimetables(ctx context.Context, chatID int64) error {
+	timetables, err := h.store.Timetables(ctx)
+	if err != nil {
+		return fmt.Errorf("load official timetables: %w", err)
+	}
+	if len(timetables) == 0 {
+		return h.send(ctx, chatID, timetableUsage, nil)
+	}
+	var builder strings.Builder
+	builder.WriteString("<b>Official timetables</b> 📋")
+	for _, timetable := range timetables {
+		builder.WriteString("\n\n" + formatTimetable(timetable))
+	}
+	return h.send(ctx, chatID, builder.String(), nil)
+}
+
+func (h *Handler) deleteTimetable(ctx context.Context, chatID int64, argument string) error {
+	id, err := strconv.ParseInt(strings.TrimPrefix(strings.TrimSpace(argument), "#"), 10, 64)
+	if err != nil {
+		return h.send(ctx, chatID, "Usage: <code>/timetable_delete ID</code>", nil)
+	}
+	err = h.store.DeleteTimetable(ctx, id)
+	if domain.IsNotFound(err) {
+		return h.send(ctx, chatID, fmt.Sprintf("Timetable #%d does not exist.", id), nil)
+	}
+	if err != nil {
+		return fmt.Errorf("delete official timetable: %w", err)
+	}
+	h.invalidateTimetables()
+	return h.send(ctx, chatID, fmt.Sprintf("🗑 Timetable #%d deleted.", id), nil)
+}
+
+// invalidateTimetables makes this process serve a change at once; the other
+// services pick it up on their next refresh.
+func (h *Handler) invalidateTimetables() {
+	if official, ok := h.calculator.(interface{ Invalidate() }); ok {
+		official.Invalidate()
+	}
+}
+
+// parseTimetableCaption reads "place <lat>,<lng> <radius km> <timezone> <name>"
+// or "country <code> <timezone> <name>".
+func parseTimetableCaption(caption string) (domain.Timetable, error) {
+	fields := strings.Fields(caption)
+	var timetable domain.Timetable
+	switch {
+	case len(fields) >= 5 && strings.EqualFold(fields[0], "place"):
+		latitude, longitude, ok := strings.Cut(fields[1], ",")
+		if !ok {
+			return domain.Timetable{}, errors.New("coordinates must be written as latitude,longitude")
+		}
+		var err error
+		if timetable.Latitude, err = strconv.ParseFloat(latitude, 64); err != nil {
+			return domain.Timetable{}, errors.New("the latitude is not a number")
+		}
+		if timetable.Longitude, err = strconv.ParseFloat(longitude, 64); err != nil {
+			return domain.Timetable{}, errors.New("the longitude is not a number")
+		}
+		if timetable.RadiusKm, err = strconv.Atoi(fields[2]); err != nil || timetable.RadiusKm <= 0 {
+			return domain.Timetable{}, errors.New("the radius must be a whole number of kilometres")
+		}
+		timetable.Timezone, timetable.Name = fields[3], strings.Join(fields[4:], " ")
+	case len(fields) >= 4 && strings.EqualFold(fields[0], "country"):
+		timetable.CountryCode = strings.ToUpper(fields[1])
+		timetable.Timezone, timetable.Name = fields[2], strings.Join(fields[3:], " ")
+	default:
+		return domain.Timetable{}, errors.New("the caption does not describe where the timetable applies")
+	}
+	if err := timetable.Validate(); err != nil {
+		return domain.Timetable{}, err
+	}
+	return timetable, nil
+}
+
+func formatTimetable(timetable domain.Timetable) string {
+	coverage := "🌍 " + timetable.CountryCode
+	if timetable.PlaceBound() {
+		coverage = fmt.Sprintf("📍 %.3f, %.3f · %d km", timetable.Latitude, timetable.Longitude, timetable.RadiusKm)
+	}
+	return fmt.Sprintf("<b>#%d %s</b>\n%s · %s\n📅 %s – %s · %d days",
+		timetable.ID, escape(timetable.Name), coverage, escape(timetable.Timezone),
+		timetable.First.Format(time.DateOnly), timetable.Last.Format(time.DateOnly), timetable.Days)
+}
@@ -1,11 +1,18 @@
 package telegram
 
 import (
+	"slices"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/go-telegram/bot/models"
+
+	"github.com/escalopa/prayer-bot/global/internal/core/dateconvert"
+	"github.com/escalopa/prayer-bot/global/internal/core/fasting"
+	"github.com/escalopa/prayer-bot/global/internal/core/hijri"
 	"github.com/escalopa/prayer-bot/global/internal/core/i18n"
+	"github.com/escalopa/prayer-bot/global/internal/core/reminders"
 	"github.com/escalopa/prayer-bot/global/internal/domain"
 )
 
@@ -56,12 +63,23 @@ func TestFormatScheduleUsesLocalizedNamesAndHTMLTimes(t *testing.T) {
 	}
 }
 
-func TestHijriKeyboardOffersSafeRegionalCorrections(t *testing.T) {
-	keyboard := hijriKeyboard(1, i18n.Resolve("en"))
-	if len(keyboard.InlineKeyboard) != 2 || len(keyboard.InlineKeyboard[0]) != 5 {
+func TestHijriKeyboardOffersCalendarsAndSafeRegionalCorrections(t *testing.T) {
+	profile := domain.PrayerProfile{HijriCalendar: domain.HijriDiyanet, HijriAdjustment: 1}
+	keyboard := hijriKeyboard(profile, i18n.Resolve("en"))
+	calendars := len(domain.SupportedHijriCalendars())
+	if len(keyboard.InlineKeyboard) != calendars+2 || len(keyboard.InlineKeyboard[calendars]) != 5 {
 		t.Fatalf("unexpected Hijri keyboard shape: %+v", keyboard.InlineKeyboard)
 	}
-	for _, button := range keyboard.InlineKeyboard[0] {
+	for index, system := range domain.SupportedHijriCalendars() {
+		button := keyboard.InlineKeyboard[index][0]
+		if button.CallbackData != "hijrical:"+string(system) {
+			t.Errorf("unexpected calendar callback %q", button.CallbackData)
+		}
+		if selected := strings.HasPrefix(button.Text, "✓ "); selected != (system == domain.HijriDiyanet) {
+			t.Errorf("calendar %s selected = %v", system, selected)
+		}
+	}
+	for _, button := range keyboard.InlineKeyboard[calendars] {
 		if !strings.HasPrefix(button.CallbackData, "hijri:") {
 			t.Errorf("unexpected Hijri callback %q", button.CallbackData)
 		}
@@ -96,3 +114,416 @@ func TestUntilNextRendersLocalizedCountdown(t *testing.T) {
 		}
 	}
 }
+
+func TestCustomMethodKeyboardAndSettingsSummary(t *testing.T) {
+	locale := i18n.Resolve("en")
+	custom := domain.CustomMethod{FajrAngle: 18.5, IshaAngle: 17, IshaIntervalMinutes: 90}
+	keyboard := customMethodKeyboard(custom, locale)
+	var selected []string
+	for _, row := range keyboard.InlineKeyboard {
+		for _, button := range row {
+			if len(button.CallbackData) > 64 {
+				t.Errorf("callback data is %d bytes: %q", len(button.CallbackData), button.CallbackData)
+			}
+			if strings.HasPrefix(button.Text, "✓ ") {
+				selected = append(selected, button.CallbackData)
+			}
+		}
+	}
+	if len(selected) != 1 || selected[0] != "custom:interval:90" {
+		t.Fatalf("expected only the 90-minute interval selected, got %v", selected)
+	}
+
+	profile := domain.PrayerProfile{Timezone: "Europe/Paris", Method: domain.MethodCustom, Custom: custom}
+	if got, want := methodSummary(profile, locale), "Custom angles (Fajr 18.5° · Isha +90 min)"; got != want {
+		t.Fatalf("methodSummary = %q, want %q", got, want)
+	}
+	profile.Method = domain.MethodMWL
+	if got := methodSummary(profile, locale); got != "Muslim World League" {
+		t.Fatalf("preset summary should not mention custom parameters, got %q", got)
+	}
+}
+
+func TestFormatScheduleListsExtendedTimesOnlyWhenShown(t *testing.T) {
+	location := time.FixedZone("test", 3*60*60)
+	date := time.Date(2026, time.July, 17, 0, 0, 0, 0, location)
+	schedule := domain.DaySchedule{Date: date, Times: map[domain.Prayer]time.Time{
+		domain.PrayerFajr:      time.Date(2026, time.July, 17, 3, 40, 0, 0, location),
+		domain.PrayerImsak:     time.Date(2026, time.July, 17, 3, 30, 0, 0, location),
+		domain.PrayerLastThird: time.Date(2026, time.July, 18, 1, 52, 0, 0, location),
+	}}
+	profile := domain.PrayerProfile{Timezone: "Europe/Istanbul", Method: domain.MethodDiyanet}
+	locale := i18n.Resolve("en")
+	if text := formatSchedule("Today", schedule, profile, locale); strings.Contains(text, "Imsak") {
+		t.Fatalf("extended times must be hidden by default:\n%s", text)
+	}
+	profile.ShowExtendedTimes = true
+	text := formatSchedule("Today", schedule, profile, locale)
+	for _, expected := range []string{"<b>Extended times</b>", "Imsak  <code>03:30</code>", "Last third of the night  <code>01:52</code>"} {
+		if !strings.Contains(text, expected) {
+			t.Errorf("formatted schedule missing %q:\n%s", expected, text)
+		}
+	}
+}
+
+func TestFormatScheduleListsMakruhWindowsUnlessHidden(t *testing.T) {
+	location := time.FixedZone("test", 3*60*60)
+	at := func(hour, minute int) time.Time { return time.Date(2026, time.March, 20, hour, minute, 0, 0, location) }
+	schedule := domain.DaySchedule{Date: at(0, 0), Makruh: map[domain.MakruhWindow]domain.TimeWindow{
+		domain.MakruhSunrise: {Start: at(6, 14), End: at(6, 29)},
+		domain.MakruhZenith:  {Start: at(12, 9), End: at(12, 19)},
+		domain.MakruhSunset:  {Start: at(18, 4), End: at(18, 24)},
+	}}
+	profile := domain.PrayerProfile{Timezone: "Asia/Riyadh", Method: domain.MethodUmmAlQura}
+	locale := i18n.Resolve("en")
+	text := formatSchedule("Today", schedule, profile, locale)
+	for _, expected := range []string{"<b>Makruh times</b>", "Sunrise  <code>06:14–06:29</code>", "Zenith  <code>12:09–12:19</code>", "Sunset  <code>18:04–18:24</code>"} {
+		if !strings.Contains(text, expected) {
+			t.Errorf("formatted schedule missing %q:\n%s", expected, text)
+		}
+	}
+	profile.HideMakruhTimes = true
+	if text := formatSchedule("Today", schedule, profile, locale); strings.Contains(text, "Makruh") {
+		t.Fatalf("hidden makruh times must not be listed:\n%s", text)
+	}
+}
+
+func TestFormatScheduleNamesOfficialTimetable(t *testing.T) {
+	schedule := domain.DaySchedule{Date: time.Date(2026, time.June, 21, 0, 0, 0, 0, time.UTC), Timetable: "Kazan Muftiate"}
+	profile := domain.PrayerProfile{Timezone: "Europe/Moscow", Method: domain.MethodRussia}
+	text := formatSchedule("Today", schedule, profile, i18n.Resolve("en"))
+	if !strings.Contains(text, "Official timetable: Kazan Muftiate") || strings.Contains(text, "Russia") {
+		t.Fatalf("schedule should name the timetable instead of the method:\n%s", text)
+	}
+}
+
+func TestTimetableSettingNamesTheTimetableAndTogglesIt(t *testing.T) {
+	locale := i18n.Resolve("en")
+	text := formatTimetableSetting("Kazan Muftiate", locale)
+	if !strings.Contains(text, "Kazan Muftiate covers your location") || !strings.Contains(text, "replaces the calculation method") {
+		t.Fatalf("the setting should say what the timetable replaces:\n%s", text)
+	}
+	if text := formatTimetableSetting("", locale); !strings.Contains(text, "No official timetable covers your location") {
+		t.Fatalf("an uncovered profile should be told so:\n%s", text)
+	}
+	profile := domain.PrayerProfile{Timezone: "Europe/Moscow", Method: domain.MethodRussia}
+	if data := timetableKeyboard(profile, locale).InlineKeyboard[0][0].CallbackData; data != "timetable:use:off" {
+		t.Fatalf("a profile using timetables should be offered to ignore them, got %q", data)
+	}
+	profile.IgnoreTimetables = true
+	if data := timetableKeyboard(profile, locale).InlineKeyboard[0][0].CallbackData; data != "timetable:use:on" {
+		t.Fatalf("a profile ignoring timetables should be offered to use them, got %q", data)
+	}
+	if text := formatSettings(profile, locale); !strings.Contains(text, "<b>Official timetable:</b> disabled") {
+		t.Fatalf("settings should show that timetables are ignored:\n%s", text)
+	}
+}
+
+func TestFormatScheduleShowsTheMoonAndWhenToLookForTheCrescent(t *testing.T) {
+	riyadh := time.FixedZone("Asia/Riyadh", 3*60*60)
+	profile := domain.PrayerProfile{Latitude: 21.42, Longitude: 39.83, Timezone: "Asia/Riyadh", Method: domain.MethodUmmAlQura}
+	locale := i18n.Resolve("en")
+	text := formatSchedule("Today", domain.DaySchedule{Date: time.Date(2025, time.February, 27, 0, 0, 0, 0, riyadh)}, profile, locale)
+	for _, expected := range []string{"🌘 Waning crescent", "% lit", "🔭 New moon 28 February 03:44", "look for the crescent after sunset on 1 March"} {
+		if !strings.Contains(text, expected) {
+			t.Errorf("formatted schedule missing %q:\n%s", expected, text)
+		}
+	}
+	text = formatSchedule("Today", domain.DaySchedule{Date: time.Date(2025, time.March, 14, 0, 0, 0, 0, riyadh)}, profile, locale)
+	if !strings.Contains(text, "🌕 Full moon") || strings.Contains(text, "🔭") {
+		t.Fatalf("a full moon needs no crescent watch:\n%s", text)
+	}
+}
+
+func TestExtendedTimeKeyboardsStayWithinTelegramLimit(t *testing.T) {
+	locale := i18n.Resolve("tt")
+	state := reminderState{Extended: map[domain.Prayer]bool{domain.PrayerLastThird: true}}
+	keyboards := []*models.InlineKeyboardMarkup{
+		extendedTimesKeyboard(domain.PrayerProfile{ImsakMinutes: 10}, locale),
+		extendedRemindersKeyboard(state, locale),
+	}
+	var toggledOff []string
+	for _, keyboard := range keyboards {
+		for _, row := range keyboard.InlineKeyboard {
+			for _, button := range row {
+				if len(button.CallbackData) > 64 {
+					t.Errorf("callback data is %d bytes: %q", len(button.CallbackData), button.CallbackData)
+				}
+				if strings.HasSuffix(button.CallbackData, ":off") {
+					toggledOff = append(toggledOff, button.CallbackData)
+				}
+			}
+		}
+	}
+	if len(toggledOff) != 1 || toggledOff[0] != "reminders:extended:last_third:off" {
+		t.Fatalf("only the enabled last-third reminder should offer to switch off, got %v", toggledOff)
+	}
+}
+
+func TestElevationKeyboardOffersDetectionOnlyWhenSupported(t *testing.T) {
+	locale := i18n.Resolve("en")
+	callbacks := func(keyboard *models.InlineKeyboardMarkup) []string {
+		var data []string
+		for _, row := range keyboard.InlineKeyboard {
+			for _, button := range row {
+				data = append(data, button.CallbackData)
+			}
+		}
+		return data
+	}
+	expected := []string{"elevation:add:-100", "elevation:add:-10", "elevation:add:10", "elevation:add:100", "elevation:set:0", "settings"}
+	if got := callbacks(elevationKeyboard(false, locale)); !slices.Equal(got, expected) {
+		t.Fatalf("callbacks = %v, want %v", got, expected)
+	}
+	if got := callbacks(elevationKeyboard(true, locale)); !slices.Contains(got, "elevation:detect") {
+		t.Fatalf("detect button missing from %v", got)
+	}
+	text := formatElevation(domain.PrayerProfile{ElevationMeters: 2240}, locale)
+	if !strings.Contains(text, "2240 m") {
+		t.Fatalf("elevation text = %q", text)
+	}
+}
+
+func TestPrecautionKeyboardCyclesRoundingAndMarksIhtiyat(t *testing.T) {
+	locale := i18n.Resolve("en")
+	precaution := domain.Precaution{IhtiyatMinutes: 2, Maghrib: domain.RoundUp}
+	keyboard := precautionKeyboard(precaution, locale)
+	var labels []string
+	for _, row := range keyboard.InlineKeyboard {
+		for _, button := range row {
+			if len(button.CallbackData) > 64 {
+				t.Errorf("callback data is %d bytes: %q", len(button.CallbackData), button.CallbackData)
+			}
+			labels = append(labels, button.Text)
+		}
+	}
+	if !slices.Contains(labels, selectedLabel("+2", true)) || !slices.Contains(labels, "Maghrib ↑") || !slices.Contains(labels, "Fajr ≈") {
+		t.Fatalf("labels = %v", labels)
+	}
+	for current, want := range map[domain.Rounding]domain.Rounding{
+		domain.RoundNearest: domain.RoundUp, domain.RoundUp: domain.RoundDown, domain.RoundDown: domain.RoundNearest,
+	} {
+		if got := nextRounding(current); got != want {
+			t.Errorf("nextRounding(%q) = %q, want %q", current, got, want)
+		}
+	}
+	if got := formatSettings(domain.PrayerProfile{Timezone: "UTC", Precaution: precaution}, locale); !strings.Contains(got, "ihtiyat +2 min · Maghrib ↑") {
+		t.Fatalf("settings summary = %q", got)
+	}
+}
+
+func TestMonthKeyboardOffersEveryFormatAndStopsAYearAway(t *testing.T) {
+	locale := i18n.Resolve("en")
+	now := time.Date(2026, time.October, 17, 12, 0, 0, 0, time.UTC)
+	callbacks := func(keyboard *models.InlineKeyboardMarkup) []string {
+		var data []string
+		for _, row := range keyboard.InlineKeyboard {
+			for _, button := range row {
+				data = append(data, button.CallbackData)
+			}
+		}
+		return data
+	}
+	expected := []string{"month:pdf:2026-10", "month:png:2026-10", "month:csv:2026-10", "month:show:2026-09", "month:show:2026-11", "close"}
+	if got := callbacks(monthKeyboard(time.Date(2026, time.October, 1, 0, 0, 0, 0, time.UTC), now, locale)); !slices.Equal(got, expected) {
+		t.Fatalf("callbacks = %v, want %v", got, expected)
+	}
+	if got := callbacks(monthKeyboard(time.Date(2027, time.October, 1, 0, 0, 0, 0, time.UTC), now, locale)); slices.Contains(got, "month:show:2027-11") {
+		t.Fatalf("the picker should not step past a year away: %v", got)
+	}
+	if got := formatMonthPicker(time.Date(2026, time.February, 1, 0, 0, 0, 0, time.UTC), locale); !strings.Contains(got, "1–28 February 2026") {
+		t.Fatalf("picker text = %q", got)
+	}
+}
+
+func TestRamadanRemindersKeyboardMarksTheLeadTimeAndTogglesIftar(t *testing.T) {
+	locale := i18n.Resolve("en")
+	keyboard := ramadanRemindersKeyboard(reminderState{SuhoorMinutes: 30, Iftar: true}, locale)
+	var data, selected []string
+	for _, row := range keyboard.InlineKeyboard {
+		for _, button := range row {
+			data = append(data, button.CallbackData)
+			if strings.HasPrefix(button.Text, "✓ ") {
+				selected = append(selected, button.CallbackData)
+			}
+		}
+	}
+	expected := []string{
+		"reminders:ramadan:suhoor:off", "reminders:ramadan:suhoor:15", "reminders:ramadan:suhoor:30",
+		"reminders:ramadan:suhoor:45", "reminders:ramadan:suhoor:60", "reminders:ramadan:iftar:off", "reminders:ramadan:back",
+	}
+	if !slices.Equal(data, expected) {
+		t.Fatalf("callbacks = %v, want %v", data, expected)
+	}
+	if want := []string{"reminders:ramadan:suhoor:30", "reminders:ramadan:iftar:off"}; !slices.Equal(selected, want) {
+		t.Fatalf("selected = %v, want %v", selected, want)
+	}
+}
+
+func TestJumuahReplacesFridayDhuhrInTheSchedule(t *testing.T) {
+	locale := i18n.Resolve("en")
+	friday := time.Date(2026, time.July, 17, 0, 0, 0, 0, time.UTC)
+	schedule := domain.DaySchedule{Date: friday, Timezone: "UTC", Times: map[domain.Prayer]time.Time{
+		domain.PrayerDhuhr: friday.Add(12*time.Hour + 58*time.Minute),
+	}}
+	profile := domain.PrayerProfile{Timezone: "UTC", Jumuah: domain.Jumuah{Khutbah: "13:15", Iqamah: "13:45"}}
+	text := formatSchedule("Today", schedule, profile, locale)
+	if strings.Contains(text, "12:58") || !strings.Contains(text, "Jumu&#39;ah  <code>13:15</code> · Iqamah <code>13:45</code>") {
+		t.Fatalf("Friday schedule should show Jumu'ah instead of Dhuhr:\n%s", text)
+	}
+	schedule.Date = friday.AddDate(0, 0, 1)
+	if text := formatSchedule("Tomorrow", schedule, profile, locale); !strings.Contains(text, "12:58") {
+		t.Fatalf("Saturday schedule should keep Dhuhr:\n%s", text)
+	}
+
+	keyboard := jumuahKeyboard(profile.Jumuah, 30, locale)
+	var data, selected []string
+	for _, row := range keyboard.InlineKeyboard {
+		for _, button := range row {
+			data = append(data, button.CallbackData)
+			if strings.HasPrefix(button.Text, "✓ ") {
+				selected = append(selected, button.CallbackData)
+			}
+		}
+	}
+	expected := []string{
+		"jumuah:remind:off", "jumuah:remind:15", "jumuah:remind:30", "jumuah:remind:45",
+		"jumuah:remind:60", "jumuah:remind:90", "jumuah:clear", "settings",
+	}
+	if !slices.Equal(data, expected) || !slices.Equal(selected, []string{"jumuah:remind:30"}) {
+		t.Fatalf("callbacks = %v selected %v", data, selected)
+	}
+}
+
+func TestFormatConversionListsTheTypedCalendarFirstAndBothCandidates(t *testing.T) {
+	locale := i18n.Resolve("en")
+	profile := domain.PrayerProfile{Timezone: "UTC", HijriCalendar: domain.HijriUmmAlQura}
+	day := func(date int) time.Time { return time.Date(2025, time.March, date, 0, 0, 0, 0, time.UTC) }
+
+	text := formatConversion(dateconvert.Query{Hijri: true, Day: 30, Month: 9},
+		dateconvert.Result{Hijri: hijri.Date{Day: 30, Month: 9, Year: 1446}, Gregorian: []time.Time{day(29), day(30)}},
+		profile, locale)
+	if !strings.HasPrefix(text, "🌙 <b>30 Ramadan 1446 AH</b>") ||
+		!strings.Contains(text, "📅 <b>29 March 2025 / 30 March 2025</b>") ||
+		!strings.Contains(text, "the day is 29 March 2025 or 30 March 2025") {
+		t.Fatalf("ambiguous Hijri conversion:\n%s", text)
+	}
+
+	text = formatConversion(dateconvert.Query{Day: 1, Month: 3, Year: 2025},
+		dateconvert.Result{Hijri: hijri.Date{Day: 1, Month: 9, Year: 1446}, Gregorian: []time.Time{day(1)}},
+		profile, locale)
+	if !strings.HasPrefix(text, "📅 <b>1 March 2025</b>\n🌙 <b>1 Ramadan 1446 AH</b>") || strings.Contains(text, "⚖️") {
+		t.Fatalf("Gregorian conversion:\n%s", text)
+	}
+}
+
+func TestFormatFastingJoinsRunsAndSkipsPlainDays(t *testing.T) {
+	calendar, err := hijri.New(domain.HijriUmmAlQura, 0, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Ramadan 1447 begins on Wednesday 18 February 2026.
+	days, err := fasting.Between(time.Date(2026, time.February, 14, 12, 0, 0, 0, time.UTC), 10, calendar, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	text := formatFasting(days, i18n.Resolve("en"))
+	if !strings.Contains(text, "\n🤲 <b>Mon 16 February</b> · Recommended · Monday\n") ||
+		!strings.Contains(text, "\n🌙 <b>Wed 18 February – Mon 23 February</b> · Obligatory · Ramadan\n") ||
+		strings.Contains(text, "Sat 14 February") {
+		t.Fatalf("fasting calendar:\n%s", text)
+	}
+}
+
+func TestFormatFastsOwesMissedDaysAndSuggestsWhenToMakeThemUp(t *testing.T) {
+	calendar, err := hijri.New(domain.HijriUmmAlQura, 0, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	locale := i18n.Resolve("en")
+	suggestions, err := fasting.MakeUpDays(time.Date(2026, time.April, 5, 0, 0, 0, 0, time.UTC), 2, calendar, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	today, err := fasting.On(time.Date(2026, time.April, 4, 0, 0, 0, 0, time.UTC), calendar, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	text := formatFasts(domain.FastingBalance{Carried: 2, Missed: 3, MadeUp: 1, Kept: 6}, suggestions, &today, locale)
+	for _, want := range []string{
+		"🤲 Fasted: 6", "✖️ Missed in Ramadan: 3", "<b>⚖️ Make-up fasts owed: 4</b>",
+		"\n🤲 Mon 6 April · Monday", "<b>Today</b> · Sat 4 April",
+	} {
+		if !strings.Contains(text, want) {
+			t.Fatalf("fasting log is missing %q:\n%s", want, text)
+		}
+	}
+	markup := reminders.FastLogKeyboard(today.Date, false, domain.FastMakeUp, locale)
+	if row := markup.InlineKeyboard[0]; len(row) != 3 || row[1].Text != "✅ Make-up fast" || row[1].CallbackData != "fast:makeup:2026-04-04" {
+		t.Fatalf("fasting log keyboard = %+v", row)
+	}
+}
+
+func TestParsePersonalEventSplitsTheDateFromTheTitle(t *testing.T) {
+	for argument, want := range map[string]domain.PersonalEvent{
+		"12 Rajab Grandfather's passing":      {Title: "Grandfather's passing", Hijri: true, Month: 7, Day: 12},
+		"1 Rabi al-Awwal 1447 Hijri birthday": {Title: "Hijri birthday", Hijri: true, Month: 3, Day: 1},
+		"3 May Wedding anniversary":           {Title: "Wedding anniversary", Month: 5, Day: 3},
+		"29 February 2000 Leap day":           {Title: "Leap day", Month: 2, Day: 29},
+	} {
+		if got, ok := parsePersonalEvent(argument); !ok || got != want {
+			t.Errorf("parsePersonalEvent(%q) = %+v, %v; want %+v", argument, got, ok, want)
+		}
+	}
+	for _, argument := range []string{"12 Rajab", "Grandfather's passing", "31 April Nothing", "12 Rajab " + strings.Repeat("x", 65)} {
+		if got, ok := parsePersonalEvent(argument); ok {
+			t.Errorf("parsePersonalEvent(%q) = %+v, want a rejection", argument, got)
+		}
+	}
+}
+
+func TestEventsKeyboardTogglesTheReminderAndDeletesEachEvent(t *testing.T) {
+	locale := i18n.Resolve("en")
+	events := []domain.PersonalEvent{
+		{ID: 7, Title: "Hijri birthday", Hijri: true, Month: 8, Day: 14},
+		{ID: 9, Title: "Wedding", Month: 5, Day: 3},
+	}
+	keyboard := eventsKeyboard(events, true, locale)
+	var data, text []string
+	for _, row := range keyboard.InlineKeyboard {
+		for _, button := range row {
+			data = append(data, button.CallbackData)
+			text = append(text, button.Text)
+		}
+	}
+	if want := []string{"events:remind:off", "events:delete:7", "events:delete:9", "close"}; !slices.Equal(data, want) {
+		t.Fatalf("callbacks = %v, want %v", data, want)
+	}
+	if text[1] != "🗑 🌙 14 Sha'ban · Hijri birthday" || text[2] != "🗑 📅 3 May · Wedding" {
+		t.Fatalf("delete buttons = %q", text[1:3])
+	}
+}
+
+func TestCustomRemindersAreNumberedForEditingAndDeletedByRule(t *testing.T) {
+	locale := i18n.Resolve("en")
+	customs := []domain.CustomReminder{
+		{RuleID: 11, Text: "Witr & <b>", LocalTime: "21:30", Recurrence: domain.RecurrenceDaily},
+		{RuleID: 12, Text: "Adhkar", Anchor: domain.PrayerFajr, OffsetMinutes: 20, Recurrence: domain.RecurrenceWeekly, Weekdays: domain.WeekdaysOf(time.Monday)},
+	}
+	text := formatCustomReminders(customs, locale)
+	if !strings.Contains(text, "1. <b>Witr &amp; &lt;b&gt;</b>\nEvery day · 21:30") || !strings.Contains(text, "2. <b>Adhkar</b>") {
+		t.Fatalf("unexpected list:\n%s", text)
+	}
+	var data []string
+	for _, row := range customRemindersKeyboard(customs, locale).InlineKeyboard {
+		for _, button := range row {
+			data = append(data, button.CallbackData)
+		}
+	}
+	if want := []string{"custom_reminder:delete:11", "custom_reminder:delete:12", "close"}; !slices.Equal(data, want) {
+		t.Fatalf("callbacks = %v, want %v", data, want)
+	}
+	if empty := formatCustomReminders(nil, locale); !strings.Contains(empty, locale.Message("custom_empty")) {
+		t.Fatalf("an empty list must say so:\n%s", empty)
+	}
+}
@@ -19,6 +19,7 @@ type GoogleMaps struct {
 	client       *http.Client
 	timezoneURL  string
 	geocodingURL string
+	elevationURL string
 }
 
 func NewGoogleMaps(apiKey string, timeout time.Duration) *GoogleMaps {
@@ -27,6 +28,7 @@ func NewGoogleMaps(apiKey string, timeout time.Duration) *GoogleMaps {
 		client:       &http.Client{Timeout: timeout},
 		timezoneURL:  "https://maps.googleapis.com/maps/api/timezone/json",
 		geocodingURL: "https://maps.googleapis.com/maps/api/geocode/json",
+		elevationURL: "https://maps.googleapis.com/maps/api/elevation/json",
 	}
 }
 
@@ -109,6 +111,28 @@ func (g *GoogleMaps) reverseGeocode(ctx context.Context, latitude, longitude flo
 	return result.PlaceID, city, country, nil
 }
 
+// Elevation returns the ground elevation of the coordinates in metres.
+func (g *GoogleMaps) Elevation(ctx context.Context, latitude, longitude float64) (float64, error) {
+	values := url.Values{
+		"locations": {coordinates(latitude, longitude)},
+		"key":       {g.apiKey},
+	}
+	var response struct {
+		Status       string `json:"status"`
+		ErrorMessage string `json:"error_message"`
+		Results      []struct {
+			Elevation float64 `json:"elevation"`
+		} `json:"results"`
+	}
+	if err := g.getJSON(ctx, g.elevationURL, values, &response); err != nil {
+		return 0, fmt.Errorf("resolve elevation: %w", err)
+	}
+	if response.Status != "OK" || len(response.Results) == 0 {
+		return 0, fmt.Errorf("resolve elevation: Google status %s: %s", response.Status, response.ErrorMessage)
+	}
+	return response.Results[0].Elevation, nil
+}
+
 // Search forward-geocodes a typed place name. ZERO_RESULTS is an empty
 // slice, not an error, so callers can render a "nothing found" reply.
 func (g *GoogleMaps) Search(ctx context.Context, query, language string) ([]domain.LocationCandidate, error) {
@@ -182,4 +206,7 @@ func coordinates(latitude, longitude float64) string {
 	return strconv.FormatFloat(latitude, 'f', 6, 64) + "," + strconv.FormatFloat(longitude, 'f', 6, 64)
 }
 
-var _ port.LocationResolver = (*GoogleMaps)(nil)
+var (
+	_ port.LocationResolver  = (*GoogleMaps)(nil)
+	_ port.ElevationResolver = (*GoogleMaps)(nil)
+)
@@ -81,3 +81,23 @@ func TestSearchZeroResults(t *testing.T) {
 		t.Fatalf("expected no candidates, got %+v", candidates)
 	}
 }
+
+func TestElevation(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("locations"); got != "21.423000,39.826000" {
+			t.Errorf("locations = %q", got)
+		}
+		_, _ = w.Write([]byte(`{"status":"OK","results":[{"elevation":277.4,"resolution":4.7}]}`))
+	}))
+	defer server.Close()
+
+	client := NewGoogleMaps("key", time.Second)
+	client.elevationURL = server.URL + "/elevation"
+	elevation, err := client.Elevation(context.Background(), 21.423, 39.826)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if elevation != 277.4 {
+		t.Fatalf("elevation = %v, want 277.4", elevation)
+	}
+}
@@ -3,6 +3,7 @@ package store
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"os"
 	"path/filepath"
 	"sort"
@@ -137,6 +138,9 @@ func TestIntegrationProfileRoundTripPreservesJSONBAdjustments(t *testing.T) {
 		Method: domain.MethodEgyptian, Madhab: domain.MadhabShafii,
 		HighLatitudeRule: domain.HighLatitudeAngleBased,
 		Adjustments:      domain.Adjustments{Fajr: 2, Dhuhr: 3, Isha: -1},
+		Precaution:       domain.Precaution{IhtiyatMinutes: 2, Fajr: domain.RoundDown, Maghrib: domain.RoundUp},
+		Jumuah:           domain.Jumuah{Khutbah: "13:15", Iqamah: "13:45"},
+		HijriCalendar:    domain.HijriDiyanet,
 		HijriAdjustment:  1,
 	}
 	saved, err := storage.UpsertProfile(ctx, profile)
@@ -154,11 +158,349 @@ func TestIntegrationProfileRoundTripPreservesJSONBAdjustments(t *testing.T) {
 	if got.Adjustments != profile.Adjustments {
 		t.Fatalf("adjustments round-trip mismatch: got %+v want %+v", got.Adjustments, profile.Adjustments)
 	}
-	if got.Method != domain.MethodEgyptian || got.Timezone != "Africa/Cairo" || got.HijriAdjustment != 1 {
+	if got.Precaution != profile.Precaution {
+		t.Fatalf("precaution round-trip mismatch: got %+v want %+v", got.Precaution, profile.Precaution)
+	}
+	if got.Jumuah != profile.Jumuah {
+		t.Fatalf("Jumu'ah round-trip mismatch: got %+v want %+v", got.Jumuah, profile.Jumuah)
+	}
+	if got.Method != domain.MethodEgyptian || got.Timezone != "Africa/Cairo" ||
+		got.HijriCalendar != domain.HijriDiyanet || got.HijriAdjustment != 1 {
 		t.Fatalf("profile fields did not round-trip: %+v", got)
 	}
 }
 
+func TestIntegrationProfileRoundTripPreservesCustomMethod(t *testing.T) {
+	storage := openTestStore(t)
+	ctx := context.Background()
+	seedChat(t, storage, 4)
+
+	custom := domain.CustomMethod{FajrAngle: 18.5, IshaAngle: 17, IshaIntervalMinutes: 90}
+	first, err := storage.UpsertProfile(ctx, domain.PrayerProfile{
+		ChatID: 4, Latitude: 55.796, Longitude: 49.106, Timezone: "Europe/Moscow",
+		Method: domain.MethodCustom, Custom: custom, Madhab: domain.MadhabHanafi,
+		HighLatitudeRule: domain.HighLatitudeSeventhNight,
+	})
+	if err != nil {
+		t.Fatalf("upsert custom profile: %v", err)
+	}
+	got, err := storage.Profile(ctx, 4)
+	if err != nil {
+		t.Fatalf("read profile: %v", err)
+	}
+	if got.Method != domain.MethodCustom || got.Custom != custom {
+		t.Fatalf("custom method did not round-trip: %+v", got)
+	}
+
+	got.Custom.FajrAngle = 16
+	second, err := storage.UpsertProfile(ctx, got)
+	if err != nil {
+		t.Fatalf("update custom angle: %v", err)
+	}
+	if second.Version <= first.Version {
+		t.Fatalf("changing a custom angle must bump the version: %d -> %d", first.Version, second.Version)
+	}
+}
+
+func TestIntegrationDisplaySettingsAndElevationRoundTrip(t *testing.T) {
+	storage := openTestStore(t)
+	ctx := context.Background()
+	seedChat(t, storage, 6)
+
+	profile := domain.PrayerProfile{
+		ChatID: 6, Latitude: 21.423, Longitude: 39.826, Timezone: "Asia/Riyadh",
+		Method: domain.MethodUmmAlQura, Madhab: domain.MadhabShafii,
+		HighLatitudeRule: domain.HighLatitudeAngleBased, HideMakruhTimes: true, ElevationMeters: 277,
+		MoonEvents: true, IgnoreTimetables: true,
+	}
+	if _, err := storage.UpsertProfile(ctx, profile); err != nil {
+		t.Fatalf("upsert profile: %v", err)
+	}
+	got, err := storage.Profile(ctx, 6)
+	if err != nil {
+		t.Fatalf("read profile: %v", err)
+	}
+	if !got.HideMakruhTimes || got.ElevationMeters != 277 || !got.MoonEvents || !got.IgnoreTimetables {
+		t.Fatalf("display and elevation settings did not round-trip: %+v", got)
+	}
+}
+
+func TestIntegrationExtendedTimesRoundTripAndToggle(t *testing.T) {
+	storage := openTestStore(t)
+	ctx := context.Background()
+	seedChat(t, storage, 5)
+
+	if _, err := storage.UpsertProfile(ctx, domain.PrayerProfile{
+		ChatID: 5, Latitude: 41.009, Longitude: 28.978, Timezone: "Europe/Istanbul",
+		Method: domain.MethodDiyanet, Madhab: domain.MadhabHanafi,
+		HighLatitudeRule: domain.HighLatitudeAngleBased, ImsakMinutes: 20, ShowExtendedTimes: true,
+	}); err != nil {
+		t.Fatalf("upsert profile: %v", err)
+	}
+	got, err := storage.Profile(ctx, 5)
+	if err != nil {
+		t.Fatalf("read profile: %v", err)
+	}
+	if got.ImsakMinutes != 20 || !got.ShowExtendedTimes {
+		t.Fatalf("extended time settings did not round-trip: %+v", got)
+	}
+
+	if err := storage.SetExtendedTimeRule(ctx, 5, domain.PrayerLastThird, true); err != nil {
+		t.Fatalf("enable last third reminder: %v", err)
+	}
+	rules, err := storage.EnabledRules(ctx, 5)
+	if err != nil {
+		t.Fatalf("read rules: %v", err)
+	}
+	if len(rules) != 1 || rules[0].Kind != domain.ReminderExtendedTime || rules[0].Prayer != domain.PrayerLastThird {
+		t.Fatalf("unexpected rules after enabling: %+v", rules)
+	}
+	if err := storage.SetExtendedTimeRule(ctx, 5, domain.PrayerLastThird, false); err != nil {
+		t.Fatalf("disable last third reminder: %v", err)
+	}
+	if rules, err = storage.EnabledRules(ctx, 5); err != nil || len(rules) != 0 {
+		t.Fatalf("expected no enabled rules, got %+v (%v)", rules, err)
+	}
+	if err := storage.SetExtendedTimeRule(ctx, 5, domain.PrayerFajr, true); err == nil {
+		t.Fatal("an obligatory prayer must not be accepted as an extended time")
+	}
+}
+
+func TestIntegrationRamadanRulesKeepOneSuhoorLeadTime(t *testing.T) {
+	storage := openTestStore(t)
+	ctx := context.Background()
+	seedChat(t, storage, 6)
+
+	for _, minutes := range []int{30, 45} {
+		if err := storage.SetRamadanRule(ctx, 6, domain.ReminderSuhoor, minutes, true); err != nil {
+			t.Fatalf("enable suhoor reminder %d minutes before Fajr: %v", minutes, err)
+		}
+	}
+	if err := storage.SetRamadanRule(ctx, 6, domain.ReminderIftar, 0, true); err != nil {
+		t.Fatalf("enable iftar reminder: %v", err)
+	}
+	rules, err := storage.EnabledRules(ctx, 6)
+	if err != nil {
+		t.Fatalf("read rules: %v", err)
+	}
+	if len(rules) != 2 ||
+		rules[0].Kind != domain.ReminderSuhoor || rules[0].Prayer != domain.PrayerFajr || rules[0].OffsetMinutes != 45 ||
+		rules[1].Kind != domain.ReminderIftar || rules[1].Prayer != domain.PrayerMaghrib {
+		t.Fatalf("unexpected Ramadan rules: %+v", rules)
+	}
+	if err := storage.SetRamadanRule(ctx, 6, domain.ReminderSuhoor, 0, false); err != nil {
+		t.Fatalf("disable suhoor reminder: %v", err)
+	}
+	if rules, err = storage.EnabledRules(ctx, 6); err != nil || len(rules) != 1 || rules[0].Kind != domain.ReminderIftar {
+		t.Fatalf("expected only the iftar rule, got %+v (%v)", rules, err)
+	}
+	if err := storage.SetRamadanRule(ctx, 6, domain.ReminderSuhoor, 7, true); err == nil {
+		t.Fatal("an unsupported suhoor lead time must be rejected")
+	}
+}
+
+func TestIntegrationJumuahRuleKeepsOneLeadTime(t *testing.T) {
+	storage := openTestStore(t)
+	ctx := context.Background()
+	seedChat(t, storage, 7)
+
+	for _, minutes := range []int{30, 60} {
+		if err := storage.SetJumuahRule(ctx, 7, minutes, true); err != nil {
+			t.Fatalf("enable Jumu'ah reminder %d minutes before the khutbah: %v", minutes, err)
+		}
+	}
+	rules, err := storage.EnabledRules(ctx, 7)
+	if err != nil {
+		t.Fatalf("read rules: %v", err)
+	}
+	if len(rules) != 1 || rules[0].Kind != domain.ReminderJumuah || rules[0].Prayer != domain.PrayerDhuhr || rules[0].OffsetMinutes != 60 {
+		t.Fatalf("unexpected Jumu'ah rules: %+v", rules)
+	}
+	if err := storage.SetJumuahRule(ctx, 7, 0, false); err != nil {
+		t.Fatalf("disable Jumu'ah reminder: %v", err)
+	}
+	if rules, err = storage.EnabledRules(ctx, 7); err != nil || len(rules) != 0 {
+		t.Fatalf("expected no enabled rules, got %+v (%v)", rules, err)
+	}
+	if err := storage.SetJumuahRule(ctx, 7, 7, true); err == nil {
+		t.Fatal("an unsupported Jumu'ah lead time must be rejected")
+	}
+}
+
+func TestIntegrationCustomRemindersRoundTripAndCap(t *testing.T) {
+	storage := openTestStore(t)
+	ctx := context.Background()
+	seedChat(t, storage, 9)
+
+	added, err := storage.AddCustomReminder(ctx, domain.CustomReminder{
+		ChatID: 9, Text: "  Adhkar  ", Anchor: domain.PrayerFajr, OffsetMinutes: 20,
+		Recurrence: domain.RecurrenceWeekly, Weekdays: domain.WeekdaysOf(time.Monday, time.Thursday),
+	})
+	if err != nil {
+		t.Fatalf("add custom reminder: %v", err)
+	}
+	if added.RuleID == 0 || added.Text != "Adhkar" || added.CreatedAt.IsZero() {
+		t.Fatalf("unexpected added reminder: %+v", added)
+	}
+	rules, err := storage.EnabledRules(ctx, 9)
+	if err != nil {
+		t.Fatalf("read rules: %v", err)
+	}
+	if len(rules) != 1 || rules[0].Kind != domain.ReminderCustom || rules[0].Custom == nil || rules[0].Custom.Weekdays != added.Weekdays {
+		t.Fatalf("the custom rule must carry its reminder: %+v", rules)
+	}
+
+	once := domain.CustomReminder{RuleID: added.RuleID, ChatID: 9, Text: "Call", LocalTime: "09:00", Recurrence: domain.RecurrenceOnce, Date: "2026-11-02"}
+	if err := storage.UpdateCustomReminder(ctx, once); err != nil {
+		t.Fatalf("update custom reminder: %v", err)
+	}
+	rule, err := storage.Rule(ctx, added.RuleID)
+	if err != nil {
+		t.Fatalf("read rule: %v", err)
+	}
+	if custom := rule.Custom; custom == nil || custom.Anchor != "" || custom.Weekdays != 0 || custom.Date != "2026-11-02" || custom.LocalTime != "09:00" {
+		t.Fatalf("the update must replace every field: %+v", rule.Custom)
+	}
+	once.ChatID = 8
+	if err := storage.UpdateCustomReminder(ctx, once); !domain.IsNotFound(err) {
+		t.Fatalf("another chat's reminder must not be updated, got %v", err)
+	}
+
+	for count := 1; count < domain.MaxCustomReminders; count++ {
+		if _, err := storage.AddCustomReminder(ctx, domain.CustomReminder{ChatID: 9, Text: "Daily", LocalTime: "21:30", Recurrence: domain.RecurrenceDaily}); err != nil {
+			t.Fatalf("add reminder %d: %v", count+1, err)
+		}
+	}
+	if _, err := storage.AddCustomReminder(ctx, domain.CustomReminder{ChatID: 9, Text: "One more", LocalTime: "21:30", Recurrence: domain.RecurrenceDaily}); !errors.Is(err, domain.ErrCustomReminderLimit) {
+		t.Fatalf("expected the limit, got %v", err)
+	}
+	if err := storage.DeleteCustomReminder(ctx, 9, added.RuleID); err != nil {
+		t.Fatalf("delete custom reminder: %v", err)
+	}
+	if err := storage.DeleteCustomReminder(ctx, 9, added.RuleID); !domain.IsNotFound(err) {
+		t.Fatalf("a deleted reminder must be gone, got %v", err)
+	}
+	customs, err := storage.CustomReminders(ctx, 9)
+	if err != nil || len(customs) != domain.MaxCustomReminders-1 {
+		t.Fatalf("expected %d reminders, got %d (%v)", domain.MaxCustomReminders-1, len(customs), err)
+	}
+}
+
+func TestIntegrationTimetableRoundTripAndDelete(t *testing.T) {
+	storage := openTestStore(t)
+	ctx := context.Background()
+
+	day := func(date time.Time, isha int) domain.TimetableDay {
+		return domain.TimetableDay{Date: date, Minutes: map[domain.Prayer]int{
+			domain.PrayerFajr: 120, domain.PrayerSunrise: 240, domain.PrayerDhuhr: 720,
+			domain.PrayerAsr: 1000, domain.PrayerMaghrib: 1320, domain.PrayerIsha: isha,
+		}}
+	}
+	first := time.Date(2026, time.June, 20, 0, 0, 0, 0, time.UTC)
+	created, err := storage.CreateTimetable(ctx, domain.Timetable{
+		Name: "Kazan Muftiate", Timezone: "Europe/Moscow", Latitude: 55.796, Longitude: 49.106, RadiusKm: 40,
+	}, []domain.TimetableDay{day(first, 1450), day(first.AddDate(0, 0, 1), 1445)})
+	if err != nil {
+		t.Fatalf("create timetable: %v", err)
+	}
+	timetables, err := storage.Timetables(ctx)
+	if err != nil {
+		t.Fatalf("list timetables: %v", err)
+	}
+	if len(timetables) != 1 || timetables[0].ID != created.ID || timetables[0].Days != 2 ||
+		!timetables[0].First.Equal(first) || timetables[0].RadiusKm != 40 {
+		t.Fatalf("unexpected timetables: %+v", timetables)
+	}
+	days, err := storage.TimetableDays(ctx, created.ID, first.AddDate(0, 0, 1), first.AddDate(0, 0, 5))
+	if err != nil {
+		t.Fatalf("read timetable days: %v", err)
+	}
+	if len(days) != 1 || days[0].Minutes[domain.PrayerIsha] != 1445 || !days[0].Date.Equal(first.AddDate(0, 0, 1)) {
+		t.Fatalf("unexpected timetable days: %+v", days)
+	}
+	if err := storage.DeleteTimetable(ctx, created.ID); err != nil {
+		t.Fatalf("delete timetable: %v", err)
+	}
+	if err := storage.DeleteTimetable(ctx, created.ID); !domain.IsNotFound(err) {
+		t.Fatalf("deleting twice should report not found, got %v", err)
+	}
+}
+
+func TestIntegrationHijriAnnouncementReachesProfilesAndQueuesReplans(t *testing.T) {
+	storage := openTestStore(t)
+	ctx := context.Background()
+	seedChat(t, storage, 7)
+	seedChat(t, storage, 8)
+	for chatID, country := range map[int64]string{7: "GB", 8: "FR"} {
+		if _, err := storage.UpsertProfile(ctx, domain.PrayerProfile{
+			ChatID: chatID, Latitude: 51.507, Longitude: -0.128, Timezone: "Europe/London", CountryCode: country,
+			Method: domain.MethodMWL, Madhab: domain.MadhabShafii, HighLatitudeRule: domain.HighLatitudeMiddleNight,
+		}); err != nil {
+			t.Fatalf("upsert profile: %v", err)
+		}
+	}
+	start := time.Date(2027, time.February, 8, 0, 0, 0, 0, time.UTC)
+	queued, err := storage.PublishHijriAnnouncement(ctx, domain.HijriAnnouncement{
+		CountryCode: "GB", Year: 1448, Month: 9, Start: start,
+	}, true)
+	if err != nil || queued != 1 {
+		t.Fatalf("publish announcement: queued %d (%v)", queued, err)
+	}
+	profile, err := storage.Profile(ctx, 7)
+	if err != nil {
+		t.Fatalf("load profile: %v", err)
+	}
+	if len(profile.HijriAnnouncements) != 1 || !profile.HijriAnnouncements[0].Start.Equal(start) ||
+		profile.HijriAnnouncements[0].Month != 9 {
+		t.Fatalf("unexpected profile announcements: %+v", profile.HijriAnnouncements)
+	}
+	if other, err := storage.Profile(ctx, 8); err != nil || len(other.HijriAnnouncements) != 0 {
+		t.Fatalf("another country must not see the announcement: %+v (%v)", other.HijriAnnouncements, err)
+	}
+	items, err := storage.PendingOutbox(ctx, 10)
+	if err != nil || len(items) != 1 || items[0].Endpoint != "/tasks/announce" {
+		t.Fatalf("expected one announcement task, got %+v (%v)", items, err)
+	}
+	var task domain.HijriAnnouncementTask
+	if err := json.Unmarshal(items[0].Payload, &task); err != nil {
+		t.Fatalf("announcement payload is not valid JSON: %v", err)
+	}
+	if task.ChatID != 7 || !task.Notify || task.StartsOn != "2027-02-08" || task.TaskKey != items[0].DeliveryKey {
+		t.Fatalf("unexpected announcement task: %+v", task)
+	}
+	if queued, err = storage.DeleteHijriAnnouncement(ctx, "GB", 1448, 9); err != nil || queued != 1 {
+		t.Fatalf("delete announcement: queued %d (%v)", queued, err)
+	}
+	if _, err := storage.DeleteHijriAnnouncement(ctx, "GB", 1448, 9); !domain.IsNotFound(err) {
+		t.Fatalf("deleting twice should report not found, got %v", err)
+	}
+}
+
+func TestIntegrationAnnouncementNoticeIsRecordedOnce(t *testing.T) {
+	storage := openTestStore(t)
+	ctx := context.Background()
+	seedChat(t, storage, 11)
+	key := "announce:publish:GB:1448-09:1:11"
+	if acquired, err := storage.AcquireAnnouncement(ctx, key, 11); err != nil || !acquired {
+		t.Fatalf("first AcquireAnnouncement = (%v, %v), want (true, nil)", acquired, err)
+	}
+	if held, err := storage.AcquireAnnouncement(ctx, key, 11); err != nil || held {
+		t.Fatalf("a leased notice must not be acquired twice: (%v, %v)", held, err)
+	}
+	if err := storage.FailAnnouncement(ctx, key, errors.New("telegram down")); err != nil {
+		t.Fatalf("fail announcement: %v", err)
+	}
+	if retried, err := storage.AcquireAnnouncement(ctx, key, 11); err != nil || !retried {
+		t.Fatalf("a failed notice must be retried: (%v, %v)", retried, err)
+	}
+	if err := storage.CompleteAnnouncement(ctx, key, 42); err != nil {
+		t.Fatalf("complete announcement: %v", err)
+	}
+	if again, err := storage.AcquireAnnouncement(ctx, key, 11); err != nil || again {
+		t.Fatalf("a sent notice must not be acquired again: (%v, %v)", again, err)
+	}
+}
+
 // TestIntegrationClaimDueWritesOutboxWithJSONPayload verifies the transactional
 // outbox: a due schedule is claimed, a JSON-text delivery payload is written and
 // decodes cleanly, and the delivery lease is single-owner.
@@ -227,3 +569,76 @@ func TestIntegrationClaimDueWritesOutboxWithJSONPayload(t *testing.T) {
 		t.Fatal("a held delivery lease must not be acquired twice")
 	}
 }
+
+// TestIntegrationOneOffReminderFinishesWithoutLosingItsDelivery guards the
+// dedup record: completing the last run of a one-off reminder must keep the
+// schedule the sent delivery cascades from, so a redelivered task is refused.
+func TestIntegrationOneOffReminderFinishesWithoutLosingItsDelivery(t *testing.T) {
+	storage := openTestStore(t)
+	ctx := context.Background()
+	seedChat(t, storage, 10)
+
+	profile, err := storage.UpsertProfile(ctx, domain.PrayerProfile{
+		ChatID: 10, Latitude: 51.507, Longitude: -0.128, Timezone: "Europe/London",
+		Method: domain.MethodMWL, Madhab: domain.MadhabShafii,
+		HighLatitudeRule: domain.HighLatitudeMiddleNight,
+	})
+	if err != nil {
+		t.Fatalf("upsert profile: %v", err)
+	}
+	reminder, err := storage.AddCustomReminder(ctx, domain.CustomReminder{
+		ChatID: 10, Text: "Call", LocalTime: "09:00", Recurrence: domain.RecurrenceOnce, Date: "2026-07-24",
+	})
+	if err != nil {
+		t.Fatalf("add custom reminder: %v", err)
+	}
+	due := time.Now().Add(-time.Minute)
+	schedule, err := storage.UpsertSchedule(ctx, domain.ReminderSchedule{
+		RuleID: reminder.RuleID, ChatID: 10, ProfileVersion: profile.Version,
+		LocalDate: "2026-07-24", PrayerAt: due, NextRunAt: due,
+	})
+	if err != nil {
+		t.Fatalf("upsert schedule: %v", err)
+	}
+	if _, err := storage.ClaimDue(ctx, time.Now(), 10); err != nil {
+		t.Fatalf("claim due: %v", err)
+	}
+	items, err := storage.PendingOutbox(ctx, 10)
+	if err != nil || len(items) != 1 {
+		t.Fatalf("expected one outbox item, got %d (%v)", len(items), err)
+	}
+	var task domain.DeliveryTask
+	if err := json.Unmarshal(items[0].Payload, &task); err != nil {
+		t.Fatalf("outbox payload is not valid JSON: %v", err)
+	}
+
+	if acquired, err := storage.AcquireDelivery(ctx, task); err != nil || !acquired {
+		t.Fatalf("AcquireDelivery = (%v, %v), want (true, nil)", acquired, err)
+	}
+	if _, err := storage.CompleteDelivery(ctx, task, 501, domain.ReminderSchedule{}, "custom", time.Now().Add(36*time.Hour)); err != nil {
+		t.Fatalf("complete delivery: %v", err)
+	}
+	finished, err := storage.Schedule(ctx, schedule.ID)
+	if err != nil || finished.State != "done" {
+		t.Fatalf("the finished schedule must be kept as done, got %+v (%v)", finished, err)
+	}
+	if again, err := storage.AcquireDelivery(ctx, task); err != nil || again {
+		t.Fatalf("a redelivered task must find its sent delivery, got (%v, %v)", again, err)
+	}
+	if count, err := storage.ClaimDue(ctx, time.Now(), 10); err != nil || count != 0 {
+		t.Fatalf("a finished schedule must not be claimed, got %d (%v)", count, err)
+	}
+
+	// Editing the reminder to a later date plans the same schedule again.
+	later := time.Now().Add(time.Hour)
+	replanned, err := storage.UpsertSchedule(ctx, domain.ReminderSchedule{
+		RuleID: reminder.RuleID, ChatID: 10, ProfileVersion: profile.Version,
+		LocalDate: "2026-07-25", PrayerAt: later, NextRunAt: later,
+	})
+	if err != nil || replanned.ID != schedule.ID {
+		t.Fatalf("replan = %+v (%v), want schedule %d", replanned, err, schedule.ID)
+	}
+	if pending, err := storage.Schedule(ctx, schedule.ID); err != nil || pending.State != "pending" {
+		t.Fatalf("a replanned schedule must be pending, got %+v (%v)", pending, err)
+	}
+}
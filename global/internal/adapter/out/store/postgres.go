@@ -274,6 +274,9 @@ func (s *Store) AdminMetrics(ctx context.Context) (domain.AdminDashboard, error)
 				WHEN kind = 'occasion_major' THEN 'occasion_major'
 				WHEN kind = 'occasion_fasting' THEN 'occasion_fasting'
 				WHEN kind = 'occasion_observed' THEN 'occasion_observed'
+				WHEN kind IN ('suhoor', 'iftar') THEN 'ramadan'
+				WHEN kind = 'jumuah' THEN 'jumuah'
+				WHEN kind = 'personal_event' THEN 'personal_event'
 			END AS category
 			FROM global_bot.reminder_rules r
 			JOIN global_bot.chats c ON c.telegram_chat_id = r.chat_id
@@ -307,16 +310,28 @@ func (s *Store) metricCounts(ctx context.Context, query string) ([]domain.Metric
 
 func (s *Store) Profile(ctx context.Context, chatID int64) (domain.PrayerProfile, error) {
 	var profile domain.PrayerProfile
-	var method, madhab, highLatitude string
-	var adjustments []byte
+	var method, madhab, highLatitude, hijriCalendar string
+	var adjustments, precaution, jumuah, custom, announcements, events []byte
 	err := s.pool.QueryRow(ctx, `
 		SELECT chat_id, latitude::float8, longitude::float8, timezone_id,
-		       google_place_id, user_location_label, country_code, method, madhab,
-		       high_latitude_rule, adjustments, hijri_adjustment, version, updated_at
-		FROM global_bot.prayer_profiles WHERE chat_id = $1`, chatID).Scan(
+		       google_place_id, user_location_label, country_code, method, custom_method, madhab,
+		       high_latitude_rule, adjustments, precaution, jumuah, hijri_calendar, hijri_adjustment, imsak_minutes, show_extended_times,
+		       hide_makruh_times, moon_events, ignore_timetables, elevation_meters, version, updated_at,
+		       COALESCE((SELECT json_agg(json_build_object(
+		                    'year', a.hijri_year, 'month', a.hijri_month, 'starts_on', a.starts_on, 'created_at', a.created_at)
+		                    ORDER BY a.starts_on)
+		                 FROM global_bot.hijri_announcements a WHERE a.country_code = p.country_code), '[]'),
+		       COALESCE((SELECT json_agg(json_build_object(
+		                    'id', e.id, 'title', e.title, 'calendar', e.calendar, 'month', e.event_month,
+		                    'day', e.event_day, 'created_at', e.created_at)
+		                    ORDER BY e.id)
+		                 FROM global_bot.personal_events e WHERE e.chat_id = p.chat_id), '[]')
+		FROM global_bot.prayer_profiles p WHERE chat_id = $1`, chatID).Scan(
 		&profile.ChatID, &profile.Latitude, &profile.Longitude, &profile.Timezone,
-		&profile.PlaceID, &profile.LocationLabel, &profile.CountryCode, &method, &madhab,
-		&highLatitude, &adjustments, &profile.HijriAdjustment, &profile.Version, &profile.UpdatedAt,
+		&profile.PlaceID, &profile.LocationLabel, &profile.CountryCode, &method, &custom, &madhab,
+		&highLatitude, &adjustments, &precaution, &jumuah, &hijriCalendar, &profile.HijriAdjustment, &profile.ImsakMinutes, &profile.ShowExtendedTimes,
+		&profile.HideMakruhTimes, &profile.MoonEvents, &profile.IgnoreTimetables, &profile.ElevationMeters, &profile.Version, &profile.UpdatedAt,
+		&announcements, &events,
 	)
 	if err != nil {
 		return domain.PrayerProfile{}, notFound(err)
@@ -324,12 +339,77 @@ func (s *Store) Profile(ctx context.Context, chatID int64) (domain.PrayerProfile
 	profile.Method = domain.Method(method)
 	profile.Madhab = domain.Madhab(madhab)
 	profile.HighLatitudeRule = domain.HighLatitudeRule(highLatitude)
+	profile.HijriCalendar = domain.HijriCalendar(hijriCalendar)
 	if err := json.Unmarshal(adjustments, &profile.Adjustments); err != nil {
 		return domain.PrayerProfile{}, fmt.Errorf("decode adjustments: %w", err)
 	}
+	if err := json.Unmarshal(precaution, &profile.Precaution); err != nil {
+		return domain.PrayerProfile{}, fmt.Errorf("decode precaution: %w", err)
+	}
+	if err := json.Unmarshal(jumuah, &profile.Jumuah); err != nil {
+		return domain.PrayerProfile{}, fmt.Errorf("decode jumuah: %w", err)
+	}
+	if err := json.Unmarshal(custom, &profile.Custom); err != nil {
+		return domain.PrayerProfile{}, fmt.Errorf("decode custom method: %w", err)
+	}
+	if profile.HijriAnnouncements, err = decodeAnnouncements(profile.CountryCode, announcements); err != nil {
+		return domain.PrayerProfile{}, err
+	}
+	if profile.PersonalEvents, err = decodePersonalEvents(profile.ChatID, events); err != nil {
+		return domain.PrayerProfile{}, err
+	}
 	return profile, nil
 }
 
+// decodePersonalEvents reads the personal events Profile aggregates for the
+// chat.
+func decodePersonalEvents(chatID int64, encoded []byte) ([]domain.PersonalEvent, error) {
+	var rows []struct {
+		ID        int64     `json:"id"`
+		Title     string    `json:"title"`
+		Calendar  string    `json:"calendar"`
+		Month     int       `json:"month"`
+		Day       int       `json:"day"`
+		CreatedAt time.Time `json:"created_at"`
+	}
+	if err := json.Unmarshal(encoded, &rows); err != nil {
+		return nil, fmt.Errorf("decode personal events: %w", err)
+	}
+	var events []domain.PersonalEvent
+	for _, row := range rows {
+		events = append(events, domain.PersonalEvent{
+			ID: row.ID, ChatID: chatID, Title: row.Title, Hijri: row.Calendar == "hijri",
+			Month: row.Month, Day: row.Day, CreatedAt: row.CreatedAt,
+		})
+	}
+	return events, nil
+}
+
+// decodeAnnouncements reads the announcements Profile aggregates for the
+// profile's country.
+func decodeAnnouncements(countryCode string, encoded []byte) ([]domain.HijriAnnouncement, error) {
+	var rows []struct {
+		Year      int       `json:"year"`
+		Month     int       `json:"month"`
+		StartsOn  string    `json:"starts_on"`
+		CreatedAt time.Time `json:"created_at"`
+	}
+	if err := json.Unmarshal(encoded, &rows); err != nil {
+		return nil, fmt.Errorf("decode Hijri announcements: %w", err)
+	}
+	var announcements []domain.HijriAnnouncement
+	for _, row := range rows {
+		start, err := time.Parse(time.DateOnly, row.StartsOn)
+		if err != nil {
+			return nil, fmt.Errorf("decode Hijri announcement date: %w", err)
+		}
+		announcements = append(announcements, domain.HijriAnnouncement{
+			CountryCode: countryCode, Year: row.Year, Month: row.Month, Start: start, CreatedAt: row.CreatedAt,
+		})
+	}
+	return announcements, nil
+}
+
 func (s *Store) UpsertProfile(ctx context.Context, profile domain.PrayerProfile) (domain.PrayerProfile, error) {
 	if err := profile.Validate(); err != nil {
 		return domain.PrayerProfile{}, err
@@ -338,22 +418,43 @@ func (s *Store) UpsertProfile(ctx context.Context, profile domain.PrayerProfile)
 	if err != nil {
 		return domain.PrayerProfile{}, err
 	}
+	precaution, err := marshalJSONText(profile.Precaution)
+	if err != nil {
+		return domain.PrayerProfile{}, err
+	}
+	jumuah, err := marshalJSONText(profile.Jumuah)
+	if err != nil {
+		return domain.PrayerProfile{}, err
+	}
+	custom, err := marshalJSONText(profile.Custom)
+	if err != nil {
+		return domain.PrayerProfile{}, err
+	}
 	err = s.pool.QueryRow(ctx, `
 		INSERT INTO global_bot.prayer_profiles
 			(chat_id, latitude, longitude, timezone_id, google_place_id, user_location_label,
-			 country_code, method, madhab, high_latitude_rule, adjustments, hijri_adjustment)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+			 country_code, method, madhab, high_latitude_rule, adjustments, hijri_adjustment, custom_method,
+			 imsak_minutes, show_extended_times, hide_makruh_times, elevation_meters, precaution, jumuah,
+			 hijri_calendar, moon_events, ignore_timetables)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22)
 		ON CONFLICT (chat_id) DO UPDATE SET
 			latitude = excluded.latitude, longitude = excluded.longitude,
 			timezone_id = excluded.timezone_id, google_place_id = excluded.google_place_id,
 			user_location_label = excluded.user_location_label, country_code = excluded.country_code,
-			method = excluded.method, madhab = excluded.madhab,
+			method = excluded.method, custom_method = excluded.custom_method, madhab = excluded.madhab,
 			high_latitude_rule = excluded.high_latitude_rule,
-			adjustments = excluded.adjustments, hijri_adjustment = excluded.hijri_adjustment,
+			adjustments = excluded.adjustments, precaution = excluded.precaution, jumuah = excluded.jumuah,
+			hijri_calendar = excluded.hijri_calendar, hijri_adjustment = excluded.hijri_adjustment,
+			imsak_minutes = excluded.imsak_minutes, show_extended_times = excluded.show_extended_times,
+			hide_makruh_times = excluded.hide_makruh_times, elevation_meters = excluded.elevation_meters,
+			moon_events = excluded.moon_events, ignore_timetables = excluded.ignore_timetables,
 			version = global_bot.prayer_profiles.version + 1, updated_at = now()
 		RETURNING version, updated_at`, profile.ChatID, profile.Latitude, profile.Longitude,
 		profile.Timezone, profile.PlaceID, profile.LocationLabel, profile.CountryCode, profile.Method,
-		profile.Madhab, profile.HighLatitudeRule, adjustments, profile.HijriAdjustment).Scan(&profile.Version, &profile.UpdatedAt)
+		profile.Madhab, profile.HighLatitudeRule, adjustments, profile.HijriAdjustment, custom,
+		profile.ImsakMinutes, profile.ShowExtendedTimes, profile.HideMakruhTimes,
+		profile.ElevationMeters, precaution, jumuah, profile.HijriCalendar.OrDefault(),
+		profile.MoonEvents, profile.IgnoreTimetables).Scan(&profile.Version, &profile.UpdatedAt)
 	if err != nil {
 		return domain.PrayerProfile{}, err
 	}
@@ -393,7 +494,7 @@ func (s *Store) ConfigurePrayerRules(ctx context.Context, chatID int64, enabled
 		_, err = tx.Exec(ctx, `
 			INSERT INTO global_bot.reminder_rules (chat_id, kind, prayer, enabled)
 			VALUES ($1, 'at', $2, true)
-			ON CONFLICT (chat_id, kind, prayer, offset_minutes) DO UPDATE SET enabled = true, updated_at = now()`,
+			ON CONFLICT (chat_id, kind, prayer, offset_minutes) WHERE kind <> 'custom' DO UPDATE SET enabled = true, updated_at = now()`,
 			chatID, prayer)
 		if err != nil {
 			return err
@@ -402,7 +503,7 @@ func (s *Store) ConfigurePrayerRules(ctx context.Context, chatID int64, enabled
 			_, err = tx.Exec(ctx, `
 				INSERT INTO global_bot.reminder_rules (chat_id, kind, prayer, offset_minutes, enabled)
 				VALUES ($1, 'before', $2, $3, true)
-				ON CONFLICT (chat_id, kind, prayer, offset_minutes) DO UPDATE SET enabled = true, updated_at = now()`,
+				ON CONFLICT (chat_id, kind, prayer, offset_minutes) WHERE kind <> 'custom' DO UPDATE SET enabled = true, updated_at = now()`,
 				chatID, prayer, beforeMinutes)
 			if err != nil {
 				return err
@@ -443,7 +544,7 @@ func (s *Store) setRecurringRule(ctx context.Context, chatID int64, kind domain.
 		if _, err = tx.Exec(ctx, `
 			INSERT INTO global_bot.reminder_rules (chat_id, kind, prayer, local_time, enabled)
 			VALUES ($1, $2, 'fajr', $3, true)
-			ON CONFLICT (chat_id, kind, prayer, offset_minutes) DO UPDATE SET
+			ON CONFLICT (chat_id, kind, prayer, offset_minutes) WHERE kind <> 'custom' DO UPDATE SET
 				local_time = excluded.local_time, enabled = true, updated_at = now()`,
 			chatID, kind, localTime); err != nil {
 			return err
@@ -462,6 +563,118 @@ func (s *Store) setRecurringRule(ctx context.Context, chatID int64, kind domain.
 	return tx.Commit(ctx)
 }
 
+// SetExtendedTimeRule toggles the reminder at one extended time, such as
+// Imsak or the start of the last third of the night.
+func (s *Store) SetExtendedTimeRule(ctx context.Context, chatID int64, prayer domain.Prayer, enabled bool) error {
+	if !prayer.Extended() {
+		return fmt.Errorf("unsupported extended time %q", prayer)
+	}
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck
+	if enabled {
+		if _, err = tx.Exec(ctx, `
+			INSERT INTO global_bot.reminder_rules (chat_id, kind, prayer, enabled)
+			VALUES ($1, 'extended_time', $2, true)
+			ON CONFLICT (chat_id, kind, prayer, offset_minutes) WHERE kind <> 'custom' DO UPDATE SET enabled = true, updated_at = now()`,
+			chatID, prayer); err != nil {
+			return err
+		}
+	} else {
+		if _, err = tx.Exec(ctx, `UPDATE global_bot.reminder_rules SET enabled = false, updated_at = now()
+			WHERE chat_id = $1 AND kind = 'extended_time' AND prayer = $2`, chatID, prayer); err != nil {
+			return err
+		}
+		if _, err = tx.Exec(ctx, `DELETE FROM global_bot.reminder_schedules s
+			USING global_bot.reminder_rules r
+			WHERE s.rule_id = r.id AND r.chat_id = $1 AND r.kind = 'extended_time' AND r.prayer = $2`,
+			chatID, prayer); err != nil {
+			return err
+		}
+	}
+	return tx.Commit(ctx)
+}
+
+// SetRamadanRule toggles the suhoor or iftar reminder. Suhoor keeps a single
+// rule whose offset_minutes is the lead time before Fajr, so choosing another
+// lead time replaces the previous rule.
+func (s *Store) SetRamadanRule(ctx context.Context, chatID int64, kind domain.ReminderKind, minutes int, enabled bool) error {
+	prayer := domain.PrayerMaghrib
+	switch {
+	case kind == domain.ReminderSuhoor:
+		prayer = domain.PrayerFajr
+		if enabled && !domain.ValidSuhoorMinutes(minutes) {
+			return fmt.Errorf("unsupported suhoor reminder lead time %d", minutes)
+		}
+	case kind == domain.ReminderIftar:
+		minutes = 0
+	default:
+		return fmt.Errorf("unsupported Ramadan reminder kind %q", kind)
+	}
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck
+	if _, err = tx.Exec(ctx, `UPDATE global_bot.reminder_rules SET enabled = false, updated_at = now()
+		WHERE chat_id = $1 AND kind = $2 AND NOT ($3 AND offset_minutes = $4)`,
+		chatID, kind, enabled, minutes); err != nil {
+		return err
+	}
+	if _, err = tx.Exec(ctx, `DELETE FROM global_bot.reminder_schedules s
+		USING global_bot.reminder_rules r
+		WHERE s.rule_id = r.id AND r.chat_id = $1 AND r.kind = $2 AND NOT r.enabled`,
+		chatID, kind); err != nil {
+		return err
+	}
+	if enabled {
+		if _, err = tx.Exec(ctx, `
+			INSERT INTO global_bot.reminder_rules (chat_id, kind, prayer, offset_minutes, enabled)
+			VALUES ($1, $2, $3, $4, true)
+			ON CONFLICT (chat_id, kind, prayer, offset_minutes) WHERE kind <> 'custom' DO UPDATE SET enabled = true, updated_at = now()`,
+			chatID, kind, prayer, minutes); err != nil {
+			return err
+		}
+	}
+	return tx.Commit(ctx)
+}
+
+// SetJumuahRule toggles the Friday reminder, keeping a single rule whose
+// offset_minutes is the lead time before the khutbah.
+func (s *Store) SetJumuahRule(ctx context.Context, chatID int64, minutes int, enabled bool) error {
+	if enabled && !domain.ValidJumuahMinutes(minutes) {
+		return fmt.Errorf("unsupported Jumu'ah reminder lead time %d", minutes)
+	}
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck
+	if _, err = tx.Exec(ctx, `UPDATE global_bot.reminder_rules SET enabled = false, updated_at = now()
+		WHERE chat_id = $1 AND kind = 'jumuah' AND NOT ($2 AND offset_minutes = $3)`,
+		chatID, enabled, minutes); err != nil {
+		return err
+	}
+	if _, err = tx.Exec(ctx, `DELETE FROM global_bot.reminder_schedules s
+		USING global_bot.reminder_rules r
+		WHERE s.rule_id = r.id AND r.chat_id = $1 AND r.kind = 'jumuah' AND NOT r.enabled`,
+		chatID); err != nil {
+		return err
+	}
+	if enabled {
+		if _, err = tx.Exec(ctx, `
+			INSERT INTO global_bot.reminder_rules (chat_id, kind, prayer, offset_minutes, enabled)
+			VALUES ($1, 'jumuah', 'dhuhr', $2, true)
+			ON CONFLICT (chat_id, kind, prayer, offset_minutes) WHERE kind <> 'custom' DO UPDATE SET enabled = true, updated_at = now()`,
+			chatID, minutes); err != nil {
+			return err
+		}
+	}
+	return tx.Commit(ctx)
+}
+
 func (s *Store) SetOccasionRule(ctx context.Context, chatID int64, kind domain.ReminderKind, enabled bool) error {
 	if !kind.Occasion() {
 		return fmt.Errorf("unsupported occasion reminder kind %q", kind)
@@ -475,7 +688,7 @@ func (s *Store) SetOccasionRule(ctx context.Context, chatID int64, kind domain.R
 		if _, err = tx.Exec(ctx, `
 			INSERT INTO global_bot.reminder_rules (chat_id, kind, prayer, local_time, enabled)
 			VALUES ($1, $2, 'fajr', '20:00', true)
-			ON CONFLICT (chat_id, kind, prayer, offset_minutes) DO UPDATE SET
+			ON CONFLICT (chat_id, kind, prayer, offset_minutes) WHERE kind <> 'custom' DO UPDATE SET
 				local_time = excluded.local_time, enabled = true, updated_at = now()`,
 			chatID, kind); err != nil {
 			return err
@@ -494,19 +707,44 @@ func (s *Store) SetOccasionRule(ctx context.Context, chatID int64, kind domain.R
 	return tx.Commit(ctx)
 }
 
+// ruleColumns selects a rule together with the reminder a custom rule owns;
+// the reminder columns are blank for every other kind.
+const ruleColumns = `r.id, r.chat_id, r.kind, r.prayer, r.offset_minutes, r.local_time, r.enabled,
+	COALESCE(c.text, ''), COALESCE(c.local_time, ''), COALESCE(c.anchor, ''), COALESCE(c.offset_minutes, 0),
+	COALESCE(c.recurrence, ''), COALESCE(c.weekdays, 0)::int, COALESCE(c.hijri_day, 0),
+	COALESCE(c.on_date::text, ''), COALESCE(c.created_at, r.created_at)`
+
+func scanRule(row pgx.Row) (domain.ReminderRule, error) {
+	var rule domain.ReminderRule
+	var custom domain.CustomReminder
+	var weekdays int
+	if err := row.Scan(&rule.ID, &rule.ChatID, &rule.Kind, &rule.Prayer,
+		&rule.OffsetMinutes, &rule.LocalTime, &rule.Enabled,
+		&custom.Text, &custom.LocalTime, &custom.Anchor, &custom.OffsetMinutes,
+		&custom.Recurrence, &weekdays, &custom.HijriDay, &custom.Date, &custom.CreatedAt); err != nil {
+		return domain.ReminderRule{}, err
+	}
+	if rule.Kind == domain.ReminderCustom {
+		custom.RuleID, custom.ChatID, custom.Weekdays = rule.ID, rule.ChatID, domain.WeekdaySet(weekdays)
+		rule.Custom = &custom
+	}
+	return rule, nil
+}
+
 func (s *Store) EnabledRules(ctx context.Context, chatID int64) ([]domain.ReminderRule, error) {
 	rows, err := s.pool.Query(ctx, `
-		SELECT id, chat_id, kind, prayer, offset_minutes, local_time, enabled
-		FROM global_bot.reminder_rules WHERE chat_id = $1 AND enabled ORDER BY id`, chatID)
+		SELECT `+ruleColumns+`
+		FROM global_bot.reminder_rules r
+		LEFT JOIN global_bot.custom_reminders c ON c.rule_id = r.id
+		WHERE r.chat_id = $1 AND r.enabled ORDER BY r.id`, chatID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 	var rules []domain.ReminderRule
 	for rows.Next() {
-		var rule domain.ReminderRule
-		if err := rows.Scan(&rule.ID, &rule.ChatID, &rule.Kind, &rule.Prayer,
-			&rule.OffsetMinutes, &rule.LocalTime, &rule.Enabled); err != nil {
+		rule, err := scanRule(rows)
+		if err != nil {
 			return nil, err
 		}
 		rules = append(rules, rule)
@@ -515,12 +753,11 @@ func (s *Store) EnabledRules(ctx context.Context, chatID int64) ([]domain.Remind
 }
 
 func (s *Store) Rule(ctx context.Context, ruleID int64) (domain.ReminderRule, error) {
-	var rule domain.ReminderRule
-	err := s.pool.QueryRow(ctx, `
-		SELECT id, chat_id, kind, prayer, offset_minutes, local_time, enabled
-		FROM global_bot.reminder_rules WHERE id = $1`, ruleID).Scan(
-		&rule.ID, &rule.ChatID, &rule.Kind, &rule.Prayer,
-		&rule.OffsetMinutes, &rule.LocalTime, &rule.Enabled)
+	rule, err := scanRule(s.pool.QueryRow(ctx, `
+		SELECT `+ruleColumns+`
+		FROM global_bot.reminder_rules r
+		LEFT JOIN global_bot.custom_reminders c ON c.rule_id = r.id
+		WHERE r.id = $1`, ruleID))
 	return rule, notFound(err)
 }
 
@@ -638,7 +875,15 @@ func (s *Store) Cleanup(ctx context.Context, now time.Time, limit int) (int64, e
 	if err != nil {
 		return updates.RowsAffected(), err
 	}
-	return updates.RowsAffected() + deliveries.RowsAffected(), nil
+	announcements, err := s.pool.Exec(ctx, `WITH doomed AS (
+		SELECT delivery_key FROM global_bot.announcement_deliveries
+		WHERE status IN ('sent', 'failed') AND updated_at < $1 - interval '30 days'
+		ORDER BY updated_at LIMIT $2
+	) DELETE FROM global_bot.announcement_deliveries a USING doomed d WHERE a.delivery_key = d.delivery_key`, now, limit)
+	if err != nil {
+		return updates.RowsAffected() + deliveries.RowsAffected(), err
+	}
+	return updates.RowsAffected() + deliveries.RowsAffected() + announcements.RowsAffected(), nil
 }
 
 // MetalPrices returns the single cached precious-metal price row. It returns
@@ -716,10 +961,20 @@ func (s *Store) CompleteDelivery(
 		WHERE delivery_key = $1`, task.DeliveryKey, messageID); err != nil {
 		return 0, err
 	}
-	if _, err = tx.Exec(ctx, `UPDATE global_bot.reminder_schedules SET
-		profile_version = $2, local_date = $3, prayer_at = $4, next_run_at = $5,
-		state = 'pending', updated_at = now() WHERE id = $1`, task.ScheduleID,
-		next.ProfileVersion, next.LocalDate, next.PrayerAt, next.NextRunAt); err != nil {
+	if next.NextRunAt.IsZero() {
+		// The rule has nothing left to remind of, as after a one-off custom
+		// reminder. The schedule is kept, finished, because this delivery
+		// cascades from it and stops a retried task from sending again; a
+		// rebuild that plans the rule again makes it pending.
+		_, err = tx.Exec(ctx, `UPDATE global_bot.reminder_schedules
+			SET state = 'done', updated_at = now() WHERE id = $1`, task.ScheduleID)
+	} else {
+		_, err = tx.Exec(ctx, `UPDATE global_bot.reminder_schedules SET
+			profile_version = $2, local_date = $3, prayer_at = $4, next_run_at = $5,
+			state = 'pending', updated_at = now() WHERE id = $1`, task.ScheduleID,
+			next.ProfileVersion, next.LocalDate, next.PrayerAt, next.NextRunAt)
+	}
+	if err != nil {
 		return 0, err
 	}
 	var previousMessageID int64
@@ -795,11 +1050,48 @@ func (s *Store) FailDelivery(ctx context.Context, deliveryKey string, cause erro
 	return err
 }
 
-func (s *Store) CalendarSubscription(ctx context.Context, chatID int64) (domain.CalendarSubscription, error) {
+// AcquireAnnouncement takes the two-minute lease on a Hijri announcement
+// notice, like AcquireDelivery. It reports false when the notice was already
+// sent or another attempt holds the lease.
+func (s *Store) AcquireAnnouncement(ctx context.Context, deliveryKey string, chatID int64) (bool, error) {
+	var key string
+	err := s.pool.QueryRow(ctx, `
+		INSERT INTO global_bot.announcement_deliveries
+			(delivery_key, chat_id, status, lease_until)
+		VALUES ($1, $2, 'processing', now() + interval '2 minutes')
+		ON CONFLICT (delivery_key) DO UPDATE SET
+			status = 'processing', attempts = global_bot.announcement_deliveries.attempts + 1,
+			lease_until = now() + interval '2 minutes', updated_at = now(), last_error = ''
+		WHERE global_bot.announcement_deliveries.status = 'failed'
+		   OR (global_bot.announcement_deliveries.status = 'processing'
+		       AND global_bot.announcement_deliveries.lease_until < now())
+		RETURNING delivery_key`, deliveryKey, chatID).Scan(&key)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (s *Store) CompleteAnnouncement(ctx context.Context, deliveryKey string, messageID int64) error {
+	_, err := s.pool.Exec(ctx, `UPDATE global_bot.announcement_deliveries
+		SET status = 'sent', telegram_message_id = $2, lease_until = NULL, updated_at = now()
+		WHERE delivery_key = $1`, deliveryKey, messageID)
+	return err
+}
+
+func (s *Store) FailAnnouncement(ctx context.Context, deliveryKey string, cause error) error {
+	_, err := s.pool.Exec(ctx, `UPDATE global_bot.announcement_deliveries
+		SET status = 'failed', lease_until = NULL, last_error = left($2, 500), updated_at = now()
+		WHERE delivery_key = $1`, deliveryKey, errorText(cause))
+	return err
+}
+
+func (s *Store) CalendarSubscription(ctx context.Context, chatID int64, feed domain.CalendarFeed) (domain.CalendarSubscription, error) {
 	var subscription domain.CalendarSubscription
-	err := s.pool.QueryRow(ctx, `SELECT chat_id, feed_token, uid_namespace, enabled
-		FROM global_bot.calendar_subscriptions WHERE chat_id = $1`, chatID).Scan(
+	err := s.pool.QueryRow(ctx, `SELECT chat_id, feed, feed_token, uid_namespace, enabled
+		FROM global_bot.calendar_subscriptions WHERE chat_id = $1 AND feed = $2`, chatID, feed).Scan(
 		&subscription.ChatID,
+		&subscription.Feed,
 		&subscription.FeedToken,
 		&subscription.UIDNamespace,
 		&subscription.Enabled,
@@ -812,9 +1104,10 @@ func (s *Store) CalendarSubscriptionByToken(
 	feedToken string,
 ) (domain.CalendarSubscription, error) {
 	var subscription domain.CalendarSubscription
-	err := s.pool.QueryRow(ctx, `SELECT chat_id, feed_token, uid_namespace, enabled
+	err := s.pool.QueryRow(ctx, `SELECT chat_id, feed, feed_token, uid_namespace, enabled
 		FROM global_bot.calendar_subscriptions WHERE feed_token = $1`, feedToken).Scan(
 		&subscription.ChatID,
+		&subscription.Feed,
 		&subscription.FeedToken,
 		&subscription.UIDNamespace,
 		&subscription.Enabled,
@@ -825,15 +1118,16 @@ func (s *Store) CalendarSubscriptionByToken(
 func (s *Store) EnableCalendarSubscription(
 	ctx context.Context,
 	chatID int64,
+	feed domain.CalendarFeed,
 	feedToken string,
 	uidNamespace string,
 ) (domain.CalendarSubscription, error) {
 	var subscription domain.CalendarSubscription
 	err := s.pool.QueryRow(ctx, `
 		INSERT INTO global_bot.calendar_subscriptions AS current_subscription
-			(chat_id, feed_token, uid_namespace, enabled)
-		VALUES ($1, $2, $3, true)
-		ON CONFLICT (chat_id) DO UPDATE SET
+			(chat_id, feed, feed_token, uid_namespace, enabled)
+		VALUES ($1, $2, $3, $4, true)
+		ON CONFLICT (chat_id, feed) DO UPDATE SET
 			feed_token = CASE
 				WHEN current_subscription.enabled
 				THEN current_subscription.feed_token
@@ -841,10 +1135,11 @@ func (s *Store) EnableCalendarSubscription(
 			END,
 			enabled = true,
 			updated_at = now()
-		RETURNING chat_id, feed_token, uid_namespace, enabled`,
-		chatID, feedToken, uidNamespace,
+		RETURNING chat_id, feed, feed_token, uid_namespace, enabled`,
+		chatID, feed, feedToken, uidNamespace,
 	).Scan(
 		&subscription.ChatID,
+		&subscription.Feed,
 		&subscription.FeedToken,
 		&subscription.UIDNamespace,
 		&subscription.Enabled,
@@ -852,13 +1147,539 @@ func (s *Store) EnableCalendarSubscription(
 	return subscription, notFound(err)
 }
 
-func (s *Store) DisableCalendarSubscription(ctx context.Context, chatID int64) error {
+func (s *Store) DisableCalendarSubscription(ctx context.Context, chatID int64, feed domain.CalendarFeed) error {
 	_, err := s.pool.Exec(ctx, `UPDATE global_bot.calendar_subscriptions
 		SET enabled = false, updated_at = now()
-		WHERE chat_id = $1 AND enabled`, chatID)
+		WHERE chat_id = $1 AND feed = $2 AND enabled`, chatID, feed)
 	return err
 }
 
+// Timetables lists every official timetable with the range of dates it
+// covers.
+func (s *Store) Timetables(ctx context.Context) ([]domain.Timetable, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT t.id, t.name, t.timezone_id, t.country_code, t.latitude::float8, t.longitude::float8,
+			t.radius_km, t.created_at, min(d.local_date), max(d.local_date), count(d.local_date)
+		FROM global_bot.timetables t
+		JOIN global_bot.timetable_days d ON d.timetable_id = t.id
+		GROUP BY t.id
+		ORDER BY t.id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var timetables []domain.Timetable
+	for rows.Next() {
+		var timetable domain.Timetable
+		if err := rows.Scan(&timetable.ID, &timetable.Name, &timetable.Timezone, &timetable.CountryCode,
+			&timetable.Latitude, &timetable.Longitude, &timetable.RadiusKm, &timetable.CreatedAt,
+			&timetable.First, &timetable.Last, &timetable.Days); err != nil {
+			return nil, err
+		}
+		timetables = append(timetables, timetable)
+	}
+	return timetables, rows.Err()
+}
+
+// TimetableDays returns a timetable's rows from one local date to another,
+// both included.
+func (s *Store) TimetableDays(ctx context.Context, timetableID int64, from, to time.Time) ([]domain.TimetableDay, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT local_date, fajr, sunrise, dhuhr, asr, maghrib, isha
+		FROM global_bot.timetable_days
+		WHERE timetable_id = $1 AND local_date BETWEEN $2::date AND $3::date
+		ORDER BY local_date`,
+		timetableID, from.Format(time.DateOnly), to.Format(time.DateOnly))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var days []domain.TimetableDay
+	for rows.Next() {
+		var date time.Time
+		var fajr, sunrise, dhuhr, asr, maghrib, isha int
+		if err := rows.Scan(&date, &fajr, &sunrise, &dhuhr, &asr, &maghrib, &isha); err != nil {
+			return nil, err
+		}
+		days = append(days, domain.TimetableDay{
+			Date: time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC),
+			Minutes: map[domain.Prayer]int{
+				domain.PrayerFajr: fajr, domain.PrayerSunrise: sunrise, domain.PrayerDhuhr: dhuhr,
+				domain.PrayerAsr: asr, domain.PrayerMaghrib: maghrib, domain.PrayerIsha: isha,
+			},
+		})
+	}
+	return days, rows.Err()
+}
+
+// CreateTimetable stores a timetable and all its rows in one transaction. The
+// rows travel as parallel arrays so an upload is a single statement.
+func (s *Store) CreateTimetable(ctx context.Context, timetable domain.Timetable, days []domain.TimetableDay) (domain.Timetable, error) {
+	if err := timetable.Validate(); err != nil {
+		return domain.Timetable{}, err
+	}
+	if len(days) == 0 {
+		return domain.Timetable{}, fmt.Errorf("a timetable needs at least one day")
+	}
+	dates := make([]string, len(days))
+	minutes := make(map[domain.Prayer][]int32, 6)
+	for i, day := range days {
+		dates[i] = day.Date.Format(time.DateOnly)
+		for _, prayer := range []domain.Prayer{
+			domain.PrayerFajr, domain.PrayerSunrise, domain.PrayerDhuhr,
+			domain.PrayerAsr, domain.PrayerMaghrib, domain.PrayerIsha,
+		} {
+			value, ok := day.Minutes[prayer]
+			if !ok {
+				return domain.Timetable{}, fmt.Errorf("%s has no %s time", dates[i], prayer)
+			}
+			minutes[prayer] = append(minutes[prayer], int32(value))
+		}
+	}
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return domain.Timetable{}, err
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck
+	err = tx.QueryRow(ctx, `
+		INSERT INTO global_bot.timetables (name, timezone_id, country_code, latitude, longitude, radius_km)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_at`,
+		timetable.Name, timetable.Timezone, timetable.CountryCode,
+		timetable.Latitude, timetable.Longitude, timetable.RadiusKm,
+	).Scan(&timetable.ID, &timetable.CreatedAt)
+	if err != nil {
+		return domain.Timetable{}, err
+	}
+	if _, err = tx.Exec(ctx, `
+		INSERT INTO global_bot.timetable_days
+			(timetable_id, local_date, fajr, sunrise, dhuhr, asr, maghrib, isha)
+		SELECT $1, *
+		FROM unnest($2::date[], $3::int[], $4::int[], $5::int[], $6::int[], $7::int[], $8::int[])`,
+		timetable.ID, dates,
+		minutes[domain.PrayerFajr], minutes[domain.PrayerSunrise], minutes[domain.PrayerDhuhr],
+		minutes[domain.PrayerAsr], minutes[domain.PrayerMaghrib], minutes[domain.PrayerIsha],
+	); err != nil {
+		return domain.Timetable{}, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return domain.Timetable{}, err
+	}
+	timetable.First, timetable.Last = days[0].Date, days[0].Date
+	for _, day := range days {
+		if day.Date.Before(timetable.First) {
+			timetable.First = day.Date
+		}
+		if day.Date.After(timetable.Last) {
+			timetable.Last = day.Date
+		}
+	}
+	timetable.Days = len(days)
+	return timetable, nil
+}
+
+// DeleteTimetable removes a timetable and its rows. An unknown ID is reported
+// as domain.ErrNotFound.
+func (s *Store) DeleteTimetable(ctx context.Context, timetableID int64) error {
+	tag, err := s.pool.Exec(ctx, `DELETE FROM global_bot.timetables WHERE id = $1`, timetableID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+// AddPersonalEvent saves a personal event for the chat and returns it with
+// its ID. A chat that already keeps domain.MaxPersonalEvents gets
+// domain.ErrPersonalEventLimit. The profile version is bumped in the same
+// transaction, so reminders planned before the change go stale.
+func (s *Store) AddPersonalEvent(ctx context.Context, event domain.PersonalEvent) (domain.PersonalEvent, error) {
+	event.Title = strings.TrimSpace(event.Title)
+	if err := event.Validate(); err != nil {
+		return domain.PersonalEvent{}, err
+	}
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return domain.PersonalEvent{}, err
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck
+	// Locking the profile serializes concurrent adds, so the limit holds.
+	var count int
+	if err = tx.QueryRow(ctx, `
+		UPDATE global_bot.prayer_profiles SET version = version + 1, updated_at = now()
+		WHERE chat_id = $1
+		RETURNING (SELECT count(*) FROM global_bot.personal_events WHERE chat_id = $1)`,
+		event.ChatID).Scan(&count); err != nil {
+		return domain.PersonalEvent{}, notFound(err)
+	}
+	if count >= domain.MaxPersonalEvents {
+		return domain.PersonalEvent{}, domain.ErrPersonalEventLimit
+	}
+	calendar := "gregorian"
+	if event.Hijri {
+		calendar = "hijri"
+	}
+	if err = tx.QueryRow(ctx, `
+		INSERT INTO global_bot.personal_events (chat_id, title, calendar, event_month, event_day)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, created_at`,
+		event.ChatID, event.Title, calendar, event.Month, event.Day,
+	).Scan(&event.ID, &event.CreatedAt); err != nil {
+		return domain.PersonalEvent{}, err
+	}
+	return event, tx.Commit(ctx)
+}
+
+// DeletePersonalEvent removes one of the chat's personal events and bumps the
+// profile version. An unknown event, or one of another chat, is reported as
+// domain.ErrNotFound.
+func (s *Store) DeletePersonalEvent(ctx context.Context, chatID, eventID int64) error {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck
+	tag, err := tx.Exec(ctx, `DELETE FROM global_bot.personal_events WHERE id = $1 AND chat_id = $2`, eventID, chatID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	if _, err = tx.Exec(ctx, `
+		UPDATE global_bot.prayer_profiles SET version = version + 1, updated_at = now()
+		WHERE chat_id = $1`, chatID); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// CustomReminders lists the chat's own reminders, oldest first.
+func (s *Store) CustomReminders(ctx context.Context, chatID int64) ([]domain.CustomReminder, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT `+ruleColumns+`
+		FROM global_bot.reminder_rules r
+		JOIN global_bot.custom_reminders c ON c.rule_id = r.id
+		WHERE r.chat_id = $1 ORDER BY r.id`, chatID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var reminders []domain.CustomReminder
+	for rows.Next() {
+		rule, err := scanRule(rows)
+		if err != nil {
+			return nil, err
+		}
+		reminders = append(reminders, *rule.Custom)
+	}
+	return reminders, rows.Err()
+}
+
+// AddCustomReminder saves a reminder for the chat with the custom rule that
+// schedules it, and returns it with the rule's ID. A chat that already keeps
+// domain.MaxCustomReminders gets domain.ErrCustomReminderLimit.
+func (s *Store) AddCustomReminder(ctx context.Context, reminder domain.CustomReminder) (domain.CustomReminder, error) {
+	reminder.Text = strings.TrimSpace(reminder.Text)
+	if err := reminder.Validate(); err != nil {
+		return domain.CustomReminder{}, err
+	}
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return domain.CustomReminder{}, err
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck
+	// Locking the chat serializes concurrent adds, so the limit holds.
+	var count int
+	if err = tx.QueryRow(ctx, `
+		SELECT (SELECT count(*) FROM global_bot.custom_reminders WHERE chat_id = $1)
+		FROM global_bot.chats WHERE telegram_chat_id = $1 FOR UPDATE`,
+		reminder.ChatID).Scan(&count); err != nil {
+		return domain.CustomReminder{}, notFound(err)
+	}
+	if count >= domain.MaxCustomReminders {
+		return domain.CustomReminder{}, domain.ErrCustomReminderLimit
+	}
+	if err = tx.QueryRow(ctx, `
+		INSERT INTO global_bot.reminder_rules (chat_id, kind, prayer, enabled)
+		VALUES ($1, 'custom', 'fajr', true)
+		RETURNING id`, reminder.ChatID).Scan(&reminder.RuleID); err != nil {
+		return domain.CustomReminder{}, err
+	}
+	if err = tx.QueryRow(ctx, `
+		INSERT INTO global_bot.custom_reminders
+			(rule_id, chat_id, text, local_time, anchor, offset_minutes, recurrence, weekdays, hijri_day, on_date)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, NULLIF($10, '')::date)
+		RETURNING created_at`,
+		reminder.RuleID, reminder.ChatID, reminder.Text, reminder.LocalTime, reminder.Anchor, reminder.OffsetMinutes,
+		reminder.Recurrence, int(reminder.Weekdays), reminder.HijriDay, reminder.Date,
+	).Scan(&reminder.CreatedAt); err != nil {
+		return domain.CustomReminder{}, err
+	}
+	return reminder, tx.Commit(ctx)
+}
+
+// UpdateCustomReminder replaces the text, time and recurrence of one of the
+// chat's reminders. An unknown reminder, or one of another chat, is reported
+// as domain.ErrNotFound. The caller re-plans the chat, so a queued delivery
+// whose time moved goes stale.
+func (s *Store) UpdateCustomReminder(ctx context.Context, reminder domain.CustomReminder) error {
+	reminder.Text = strings.TrimSpace(reminder.Text)
+	if err := reminder.Validate(); err != nil {
+		return err
+	}
+	tag, err := s.pool.Exec(ctx, `
+		UPDATE global_bot.custom_reminders SET
+			text = $3, local_time = $4, anchor = $5, offset_minutes = $6, recurrence = $7,
+			weekdays = $8, hijri_day = $9, on_date = NULLIF($10, '')::date
+		WHERE rule_id = $1 AND chat_id = $2`,
+		reminder.RuleID, reminder.ChatID, reminder.Text, reminder.LocalTime, reminder.Anchor, reminder.OffsetMinutes,
+		reminder.Recurrence, int(reminder.Weekdays), reminder.HijriDay, reminder.Date)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+// DeleteCustomReminder removes one of the chat's reminders with its rule and
+// schedule. An unknown reminder, or one of another chat, is reported as
+// domain.ErrNotFound.
+func (s *Store) DeleteCustomReminder(ctx context.Context, chatID, ruleID int64) error {
+	tag, err := s.pool.Exec(ctx, `DELETE FROM global_bot.reminder_rules
+		WHERE id = $1 AND chat_id = $2 AND kind = 'custom'`, ruleID, chatID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+// LogFast saves what the chat logged for a day, replacing an earlier entry
+// for the same day.
+func (s *Store) LogFast(ctx context.Context, entry domain.FastLogEntry) error {
+	if !entry.Status.Valid() {
+		return fmt.Errorf("invalid fast status %q", entry.Status)
+	}
+	_, err := s.pool.Exec(ctx, `
+		INSERT INTO global_bot.fasting_log (chat_id, fast_date, status, ramadan)
+		VALUES ($1, $2::date, $3, $4)
+		ON CONFLICT (chat_id, fast_date) DO UPDATE
+		SET status = EXCLUDED.status, ramadan = EXCLUDED.ramadan, updated_at = now()`,
+		entry.ChatID, entry.Date.Format(time.DateOnly), string(entry.Status), entry.Ramadan)
+	return err
+}
+
+// ClearFast removes the chat's entry for a day. A day without one is left as
+// it is.
+func (s *Store) ClearFast(ctx context.Context, chatID int64, date time.Time) error {
+	_, err := s.pool.Exec(ctx, `
+		DELETE FROM global_bot.fasting_log WHERE chat_id = $1 AND fast_date = $2::date`,
+		chatID, date.Format(time.DateOnly))
+	return err
+}
+
+// FastLog lists the chat's entries from one civil date to another,
+// inclusive, oldest first.
+func (s *Store) FastLog(ctx context.Context, chatID int64, from, to time.Time) ([]domain.FastLogEntry, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT fast_date, status, ramadan
+		FROM global_bot.fasting_log
+		WHERE chat_id = $1 AND fast_date BETWEEN $2::date AND $3::date
+		ORDER BY fast_date`,
+		chatID, from.Format(time.DateOnly), to.Format(time.DateOnly))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var entries []domain.FastLogEntry
+	for rows.Next() {
+		entry := domain.FastLogEntry{ChatID: chatID}
+		var date time.Time
+		var status string
+		if err := rows.Scan(&date, &status, &entry.Ramadan); err != nil {
+			return nil, err
+		}
+		entry.Date = time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+		entry.Status = domain.FastStatus(status)
+		entries = append(entries, entry)
+	}
+	return entries, rows.Err()
+}
+
+// FastingBalance counts the chat's whole log together with the make-up fasts
+// it carried from before.
+func (s *Store) FastingBalance(ctx context.Context, chatID int64) (domain.FastingBalance, error) {
+	var balance domain.FastingBalance
+	err := s.pool.QueryRow(ctx, `
+		SELECT
+			COALESCE((SELECT carried_days FROM global_bot.fasting_qada WHERE chat_id = $1), 0),
+			count(*) FILTER (WHERE status = 'missed' AND ramadan),
+			count(*) FILTER (WHERE status = 'makeup'),
+			count(*) FILTER (WHERE status = 'fasted')
+		FROM global_bot.fasting_log WHERE chat_id = $1`, chatID).Scan(
+		&balance.Carried, &balance.Missed, &balance.MadeUp, &balance.Kept,
+	)
+	return balance, err
+}
+
+// SetQadaCarried records the make-up fasts the chat owed before it kept a
+// log.
+func (s *Store) SetQadaCarried(ctx context.Context, chatID int64, days int) error {
+	if days < 0 || days > domain.MaxQadaCarried {
+		return fmt.Errorf("carried make-up fasts must be between 0 and %d", domain.MaxQadaCarried)
+	}
+	_, err := s.pool.Exec(ctx, `
+		INSERT INTO global_bot.fasting_qada (chat_id, carried_days) VALUES ($1, $2)
+		ON CONFLICT (chat_id) DO UPDATE
+		SET carried_days = EXCLUDED.carried_days, updated_at = now()`, chatID, days)
+	return err
+}
+
+// HijriAnnouncements lists every published announcement, newest month start
+// first.
+func (s *Store) HijriAnnouncements(ctx context.Context) ([]domain.HijriAnnouncement, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT country_code, hijri_year, hijri_month, starts_on, created_at
+		FROM global_bot.hijri_announcements
+		ORDER BY starts_on DESC, country_code`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var announcements []domain.HijriAnnouncement
+	for rows.Next() {
+		var announcement domain.HijriAnnouncement
+		if err := rows.Scan(&announcement.CountryCode, &announcement.Year, &announcement.Month,
+			&announcement.Start, &announcement.CreatedAt); err != nil {
+			return nil, err
+		}
+		announcement.Start = time.Date(announcement.Start.Year(), announcement.Start.Month(), announcement.Start.Day(), 0, 0, 0, 0, time.UTC)
+		announcements = append(announcements, announcement)
+	}
+	return announcements, rows.Err()
+}
+
+// PublishHijriAnnouncement saves an announcement, replacing an earlier one for
+// the same country and month, and queues a re-plan of every chat in the
+// country in the same transaction. It returns how many chats were queued.
+func (s *Store) PublishHijriAnnouncement(ctx context.Context, announcement domain.HijriAnnouncement, notify bool) (int, error) {
+	if err := announcement.Validate(); err != nil {
+		return 0, err
+	}
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck
+	if err = tx.QueryRow(ctx, `
+		INSERT INTO global_bot.hijri_announcements (country_code, hijri_year, hijri_month, starts_on)
+		VALUES ($1, $2, $3, $4::date)
+		ON CONFLICT (country_code, hijri_year, hijri_month) DO UPDATE SET
+			starts_on = excluded.starts_on, created_at = now()
+		RETURNING created_at`,
+		announcement.CountryCode, announcement.Year, announcement.Month, announcement.Start.Format(time.DateOnly),
+	).Scan(&announcement.CreatedAt); err != nil {
+		return 0, err
+	}
+	queued, err := enqueueAnnouncementTasks(ctx, tx, announcement, "publish", notify)
+	if err != nil {
+		return 0, err
+	}
+	return queued, tx.Commit(ctx)
+}
+
+// DeleteHijriAnnouncement withdraws an announcement and queues a silent
+// re-plan of every chat in its country. An unknown announcement is reported
+// as domain.ErrNotFound.
+func (s *Store) DeleteHijriAnnouncement(ctx context.Context, countryCode string, year, month int) (int, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck
+	announcement := domain.HijriAnnouncement{CountryCode: countryCode, Year: year, Month: month}
+	err = tx.QueryRow(ctx, `
+		DELETE FROM global_bot.hijri_announcements
+		WHERE country_code = $1 AND hijri_year = $2 AND hijri_month = $3
+		RETURNING starts_on, now()`, countryCode, year, month).Scan(&announcement.Start, &announcement.CreatedAt)
+	if err != nil {
+		return 0, notFound(err)
+	}
+	queued, err := enqueueAnnouncementTasks(ctx, tx, announcement, "withdraw", false)
+	if err != nil {
+		return 0, err
+	}
+	return queued, tx.Commit(ctx)
+}
+
+// enqueueAnnouncementTasks queues one announcement task per unblocked chat
+// in the announcement's country. The change instant is part of the key, so
+// publishing a correction re-plans the country again.
+func enqueueAnnouncementTasks(
+	ctx context.Context,
+	tx *schemaTx,
+	announcement domain.HijriAnnouncement,
+	action string,
+	notify bool,
+) (int, error) {
+	rows, err := tx.Query(ctx, `
+		SELECT p.chat_id
+		FROM global_bot.prayer_profiles p
+		JOIN global_bot.chats c ON c.telegram_chat_id = p.chat_id
+		WHERE p.country_code = $1 AND c.blocked_at IS NULL
+		ORDER BY p.chat_id`, announcement.CountryCode)
+	if err != nil {
+		return 0, err
+	}
+	var chatIDs []int64
+	for rows.Next() {
+		var chatID int64
+		if err := rows.Scan(&chatID); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		chatIDs = append(chatIDs, chatID)
+	}
+	if err := rows.Err(); err != nil {
+		rows.Close()
+		return 0, err
+	}
+	rows.Close()
+	if len(chatIDs) == 0 {
+		return 0, nil
+	}
+	keys := make([]string, len(chatIDs))
+	payloads := make([]string, len(chatIDs))
+	for i, chatID := range chatIDs {
+		keys[i] = fmt.Sprintf("announce:%s:%s:%d-%02d:%d:%d", action, announcement.CountryCode,
+			announcement.Year, announcement.Month, announcement.CreatedAt.UnixMicro(), chatID)
+		payloads[i], err = marshalJSONText(domain.HijriAnnouncementTask{
+			TaskKey: keys[i], ChatID: chatID, CountryCode: announcement.CountryCode,
+			HijriYear: announcement.Year, HijriMonth: announcement.Month,
+			StartsOn: announcement.Start.Format(time.DateOnly), Notify: notify,
+		})
+		if err != nil {
+			return 0, err
+		}
+	}
+	_, err = tx.Exec(ctx, `INSERT INTO global_bot.task_outbox
+		(schedule_id, delivery_key, endpoint, run_at, payload)
+		SELECT NULL, key, '/tasks/announce', now(), payload
+		FROM unnest($1::text[], $2::jsonb[]) AS task(key, payload)
+		ON CONFLICT (delivery_key) DO NOTHING`, keys, payloads)
+	if err != nil {
+		return 0, err
+	}
+	return len(chatIDs), nil
+}
+
 func errorText(err error) string {
 	if err == nil {
 		return ""
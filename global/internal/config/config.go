@@ -27,6 +27,9 @@ type Config struct {
 	TaskCallerServiceAccount string
 	DispatchBatchSize        int
 	HTTPTimeout              time.Duration
+	// CalculatorComparison, when positive, runs the astronomical engine next
+	// to go-prayer and logs prayers that differ by more than this.
+	CalculatorComparison time.Duration
 }
 
 func Load(service string) (Config, error) {
@@ -45,6 +48,7 @@ func Load(service string) (Config, error) {
 		TaskCallerServiceAccount: strings.TrimSpace(os.Getenv("TASK_CALLER_SERVICE_ACCOUNT")),
 		DispatchBatchSize:        envInt("DISPATCH_BATCH_SIZE", 100),
 		HTTPTimeout:              time.Duration(envInt("HTTP_TIMEOUT_SECONDS", 10)) * time.Second,
+		CalculatorComparison:     time.Duration(envInt("CALCULATOR_COMPARISON_MINUTES", 0)) * time.Minute,
 	}
 
 	if raw := strings.TrimSpace(os.Getenv("GLOBAL_OWNER_ID")); raw != "" {
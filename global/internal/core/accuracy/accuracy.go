@@ -0,0 +1,203 @@
+// Package accuracy measures a prayer-time calculator against reference
+// timetables: one year of published times per city and calculation profile.
+// No authority timetables are embedded; the embedded snapshots are the
+// calculator's own 2026 output, which only catches regressions.
+package accuracy
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/escalopa/prayer-bot/global/internal/core/prayertime"
+	"github.com/escalopa/prayer-bot/global/internal/domain"
+	"github.com/escalopa/prayer-bot/global/internal/port"
+)
+
+//go:embed snapshots
+var snapshots embed.FS
+
+// dailyPrayers are the columns of a timetable, in order.
+var dailyPrayers = []domain.Prayer{
+	domain.PrayerFajr, domain.PrayerSunrise, domain.PrayerDhuhr,
+	domain.PrayerAsr, domain.PrayerMaghrib, domain.PrayerIsha,
+}
+
+// Snapshots is the embedded regression set: go-prayer's times for the cities
+// the rollout gates name, each with the method its local authority follows.
+// Checking go-prayer against it says nothing about agreement with an
+// authority, only that the times have not moved.
+func Snapshots() fs.FS {
+	sub, _ := fs.Sub(snapshots, "snapshots")
+	return sub
+}
+
+// Fixture is one entry of manifest.json. File names a timetable in the format
+// prayertime.ParseTimetable reads, next to the manifest.
+type Fixture struct {
+	Name             string                  `json:"name"`
+	Source           string                  `json:"source"`
+	File             string                  `json:"file"`
+	Latitude         float64                 `json:"latitude"`
+	Longitude        float64                 `json:"longitude"`
+	Timezone         string                  `json:"timezone"`
+	Method           domain.Method           `json:"method"`
+	Madhab           domain.Madhab           `json:"madhab"`
+	HighLatitudeRule domain.HighLatitudeRule `json:"high_latitude_rule"`
+}
+
+func (f Fixture) Profile() domain.PrayerProfile {
+	return domain.PrayerProfile{
+		Latitude: f.Latitude, Longitude: f.Longitude, Timezone: f.Timezone,
+		Method: f.Method, Madhab: f.Madhab, HighLatitudeRule: f.HighLatitudeRule,
+	}
+}
+
+// Manifest reads manifest.json from fsys and checks every profile.
+func Manifest(fsys fs.FS) ([]Fixture, error) {
+	file, err := fs.ReadFile(fsys, "manifest.json")
+	if err != nil {
+		return nil, fmt.Errorf("read manifest: %w", err)
+	}
+	var manifest []Fixture
+	if err := json.Unmarshal(file, &manifest); err != nil {
+		return nil, fmt.Errorf("decode manifest: %w", err)
+	}
+	for _, fixture := range manifest {
+		if err := fixture.Profile().Validate(); err != nil {
+			return nil, fmt.Errorf("fixture %s: %w", fixture.Name, err)
+		}
+	}
+	return manifest, nil
+}
+
+// Stats summarises how far one prayer's calculated times fall from the
+// reference. Deviations are calculated minus published, so a positive mean is
+// a calculator that runs late. Days counts the days compared and Missing the
+// days either side had no time.
+type Stats struct {
+	Prayer  domain.Prayer
+	Days    int
+	Missing int
+	Mean    time.Duration
+	MeanAbs time.Duration
+	Max     time.Duration
+	MaxDate time.Time
+}
+
+// Report is the result of one fixture.
+type Report struct {
+	Fixture Fixture
+	Prayers []Stats
+}
+
+// Worst is the largest absolute deviation of any prayer. A day the calculator
+// leaves without a time, as in a polar night, counts as unbounded.
+func (r Report) Worst() time.Duration {
+	var worst time.Duration
+	for _, stats := range r.Prayers {
+		if stats.Missing > 0 {
+			return time.Duration(math.MaxInt64)
+		}
+		worst = max(worst, stats.Max)
+	}
+	return worst
+}
+
+// Check runs calculator over every day of the fixture's timetable.
+func Check(ctx context.Context, calculator port.Calculator, fsys fs.FS, fixture Fixture) (Report, error) {
+	file, err := fsys.Open(fixture.File)
+	if err != nil {
+		return Report{}, fmt.Errorf("open %s: %w", fixture.File, err)
+	}
+	defer file.Close()
+	days, err := prayertime.ParseTimetable(file)
+	if err != nil {
+		return Report{}, fmt.Errorf("parse %s: %w", fixture.File, err)
+	}
+	location, err := domain.LoadLocation(fixture.Timezone)
+	if err != nil {
+		return Report{}, fmt.Errorf("load timezone: %w", err)
+	}
+	profile := fixture.Profile()
+	report := Report{Fixture: fixture, Prayers: make([]Stats, len(dailyPrayers))}
+	sums := make([]time.Duration, len(dailyPrayers))
+	sumsAbs := make([]time.Duration, len(dailyPrayers))
+	for index, prayer := range dailyPrayers {
+		report.Prayers[index].Prayer = prayer
+	}
+	for _, day := range days {
+		noon := time.Date(day.Date.Year(), day.Date.Month(), day.Date.Day(), 12, 0, 0, 0, location)
+		schedule, err := calculator.Day(ctx, noon, profile)
+		if err != nil {
+			return Report{}, fmt.Errorf("calculate %s: %w", day.Date.Format(time.DateOnly), err)
+		}
+		for index, prayer := range dailyPrayers {
+			stats := &report.Prayers[index]
+			published, ok := day.At(prayer, location)
+			calculated, found := schedule.At(prayer)
+			if !ok || !found {
+				stats.Missing++
+				continue
+			}
+			deviation := calculated.Sub(published)
+			stats.Days++
+			sums[index] += deviation
+			sumsAbs[index] += deviation.Abs()
+			if deviation.Abs() > stats.Max || stats.MaxDate.IsZero() {
+				stats.Max, stats.MaxDate = deviation.Abs(), day.Date
+			}
+		}
+	}
+	for index := range report.Prayers {
+		if days := time.Duration(report.Prayers[index].Days); days > 0 {
+			report.Prayers[index].Mean = sums[index] / days
+			report.Prayers[index].MeanAbs = sumsAbs[index] / days
+		}
+	}
+	return report, nil
+}
+
+// Record writes calculator's times for every day of year in the timetable
+// format, for adding a city to the snapshots.
+func Record(ctx context.Context, calculator port.Calculator, fixture Fixture, year int, w io.Writer) error {
+	location, err := domain.LoadLocation(fixture.Timezone)
+	if err != nil {
+		return fmt.Errorf("load timezone: %w", err)
+	}
+	if _, err := io.WriteString(w, "date,fajr,sunrise,dhuhr,asr,maghrib,isha\n"); err != nil {
+		return err
+	}
+	for day := time.Date(year, time.January, 1, 12, 0, 0, 0, location); day.Year() == year; day = day.AddDate(0, 0, 1) {
+		schedule, err := calculator.Day(ctx, day, fixture.Profile())
+		if err != nil {
+			return fmt.Errorf("calculate %s: %w", day.Format(time.DateOnly), err)
+		}
+		fields := []string{day.Format("2/1/2006")}
+		for _, prayer := range dailyPrayers {
+			at, ok := schedule.At(prayer)
+			if !ok {
+				return fmt.Errorf("%s has no %s", day.Format(time.DateOnly), prayer)
+			}
+			fields = append(fields, at.In(location).Format("15:04"))
+		}
+		if _, err := io.WriteString(w, strings.Join(fields, ",")+"\n"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Exceeding lists the reports whose worst deviation is above tolerance.
+func Exceeding(reports []Report, tolerance time.Duration) []Report {
+	return slices.DeleteFunc(slices.Clone(reports), func(report Report) bool {
+		return report.Worst() <= tolerance
+	})
+}
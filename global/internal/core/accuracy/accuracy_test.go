@@ -0,0 +1,75 @@
+package accuracy
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/escalopa/prayer-bot/global/internal/core/prayertime"
+	"github.com/escalopa/prayer-bot/global/internal/domain"
+)
+
+// A regression check, not an accuracy one: a library change that moves any
+// snapshot time by more than a minute fails here.
+func TestSnapshotsStayWithinAMinute(t *testing.T) {
+	manifest, err := Manifest(Snapshots())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(manifest) < 8 {
+		t.Fatalf("only %d snapshots, want every rollout-gate city", len(manifest))
+	}
+	calculator := prayertime.New()
+	for _, fixture := range manifest {
+		report, err := Check(context.Background(), calculator, Snapshots(), fixture)
+		if err != nil {
+			t.Fatalf("%s: %v", fixture.Name, err)
+		}
+		for _, stats := range report.Prayers {
+			if stats.Days < 365 || stats.Max > time.Minute {
+				t.Errorf("%s %s: %d days, up to %s off on %s", fixture.Name, stats.Prayer, stats.Days, stats.Max, stats.MaxDate.Format(time.DateOnly))
+			}
+		}
+	}
+}
+
+func TestCheckReportsSignedDeviationsAndTheWorstDay(t *testing.T) {
+	fixture := Fixture{
+		Name: "cairo", File: "cairo.csv", Latitude: 30.0444, Longitude: 31.2357, Timezone: "Africa/Cairo",
+		Method: domain.MethodEgyptian, Madhab: domain.MadhabShafii, HighLatitudeRule: domain.HighLatitudeAngleBased,
+	}
+	var recorded strings.Builder
+	if err := Record(context.Background(), prayertime.New(), fixture, 2026, &recorded); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(recorded.String()), "\n")
+	if len(lines) != 366 {
+		t.Fatalf("recorded %d lines, want a header and 365 days", len(lines))
+	}
+	// Publish Fajr on 1 January three minutes later than calculated.
+	fields := strings.Split(lines[1], ",")
+	fajr, err := time.Parse("15:04", fields[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	fields[1] = fajr.Add(3 * time.Minute).Format("15:04")
+	lines[1] = strings.Join(fields, ",")
+	fsys := fstest.MapFS{"cairo.csv": {Data: []byte(strings.Join(lines, "\n") + "\n")}}
+
+	report, err := Check(context.Background(), prayertime.New(), fsys, fixture)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stats := report.Prayers[0]
+	if stats.Prayer != domain.PrayerFajr || stats.Max != 3*time.Minute || stats.MaxDate.Format(time.DateOnly) != "2026-01-01" {
+		t.Fatalf("unexpected Fajr stats: %+v", stats)
+	}
+	if stats.Mean >= 0 {
+		t.Fatalf("a calculator earlier than the timetable must have a negative mean, got %s", stats.Mean)
+	}
+	if report.Worst() != 3*time.Minute || len(Exceeding([]Report{report}, 2*time.Minute)) != 1 || len(Exceeding([]Report{report}, 3*time.Minute)) != 0 {
+		t.Fatalf("worst = %s", report.Worst())
+	}
+}
@@ -8,7 +8,9 @@ import (
 	"time"
 	"unicode/utf8"
 
+	"github.com/escalopa/prayer-bot/global/internal/core/hijri"
 	"github.com/escalopa/prayer-bot/global/internal/core/i18n"
+	"github.com/escalopa/prayer-bot/global/internal/core/moon"
 	"github.com/escalopa/prayer-bot/global/internal/core/occasions"
 	"github.com/escalopa/prayer-bot/global/internal/domain"
 	"github.com/escalopa/prayer-bot/global/internal/port"
@@ -41,7 +43,7 @@ func Generate(
 	if !validUIDNamespace(uidNamespace) {
 		return nil, fmt.Errorf("invalid calendar UID namespace")
 	}
-	location, err := time.LoadLocation(profile.Timezone)
+	location, err := domain.LoadLocation(profile.Timezone)
 	if err != nil {
 		return nil, fmt.Errorf("load timezone: %w", err)
 	}
@@ -63,41 +65,104 @@ func Generate(
 		if err != nil {
 			return nil, fmt.Errorf("calculate day %d: %w", day+1, err)
 		}
-		for _, prayer := range calendarPrayers {
+		prayers := calendarPrayers
+		if profile.ShowExtendedTimes {
+			prayers = append(append([]domain.Prayer{}, calendarPrayers...), domain.ExtendedTimes()...)
+		}
+		for _, prayer := range prayers {
+			if khutbah, iqamah, ok := profile.Jumuah.On(schedule.Date.In(location)); ok && prayer == domain.PrayerDhuhr {
+				writeJumuahEvent(&calendar, profile, locale, khutbah, iqamah, createdAt, uidNamespace)
+				continue
+			}
 			at, ok := schedule.At(prayer)
 			if !ok {
 				continue
 			}
-			writeEvent(&calendar, profile, locale, prayer, at, createdAt, uidNamespace)
+			writeEvent(&calendar, profile, locale, prayer, schedule.Date, at, createdAt, uidNamespace)
+		}
+		if profile.HideMakruhTimes {
+			continue
+		}
+		for _, window := range domain.MakruhWindows() {
+			if span, ok := schedule.MakruhWindow(window); ok {
+				writeMakruhEvent(&calendar, profile, locale, window, span, createdAt, uidNamespace)
+			}
 		}
 	}
-	upcoming, err := occasions.Between(start, days, profile.HijriAdjustment)
+	hijriCalendar, err := hijri.ForProfile(profile)
+	if err != nil {
+		return nil, err
+	}
+	upcoming, err := occasions.Between(start, days, hijriCalendar, profile.CountryCode, occasions.Personal(profile.PersonalEvents)...)
 	if err != nil {
 		return nil, fmt.Errorf("calculate Islamic occasions: %w", err)
 	}
+	// An observance not kept day by day is one event from its first day to
+	// its last in the range.
+	lastDays := map[string]time.Time{}
+	for _, occurrence := range upcoming {
+		if !occurrence.Definition.EachDay {
+			lastDays[spanKey(occurrence)] = occurrence.Date
+		}
+	}
 	for _, occurrence := range upcoming {
-		writeOccasionEvent(&calendar, locale, occurrence, createdAt, uidNamespace)
+		last := occurrence.Date
+		if !occurrence.Definition.EachDay {
+			key := spanKey(occurrence)
+			if last = lastDays[key]; last.IsZero() {
+				continue
+			}
+			delete(lastDays, key)
+		}
+		writeOccasionEvent(&calendar, locale, occurrence, last, createdAt, uidNamespace)
+	}
+	if profile.MoonEvents {
+		if err := writeMoonEvents(&calendar, profile, locale, start, days, createdAt, uidNamespace); err != nil {
+			return nil, fmt.Errorf("calculate moon events: %w", err)
+		}
 	}
 	writeLine(&calendar, "END:VCALENDAR")
 	return calendar.Bytes(), nil
 }
 
+func spanKey(occurrence occasions.Occurrence) string {
+	return occurrence.Definition.ID + occurrence.First().Format("20060102")
+}
+
+// writeOccasionEvent writes the occurrence as an all-day event through last.
+// A span starts on its first day even when the range starts later, so its UID
+// stays the same from one feed to the next.
 func writeOccasionEvent(
 	calendar *bytes.Buffer,
 	locale i18n.Locale,
 	occurrence occasions.Occurrence,
+	last time.Time,
 	createdAt time.Time,
 	uidNamespace string,
 ) {
-	copy := locale.Occasion(occurrence.Definition.ID)
-	date := occurrence.Date
+	copy, category := locale.Occasion(occurrence.Definition.ID), "Islamic Occasions"
+	if occurrence.Definition.Category == occasions.CategoryPersonal {
+		copy, category = locale.PersonalOccasion(occurrence.Definition.Title, !occurrence.Definition.Gregorian), "Personal Dates"
+	}
+	title, date := copy.Title, occurrence.First()
+	if occurrence.Definition.EachDay {
+		date = occurrence.Date
+		if day := locale.OccasionDay(occurrence); day != "" {
+			title += " · " + day
+		}
+	}
+	// Personal event IDs are their database IDs, so the UID survives edits to
+	// other events and disappears with the event.
 	uid := fmt.Sprintf(
 		"%s-%s-%s@global-prayer-bot",
 		uidNamespace,
 		date.Format("20060102"),
 		occurrence.Definition.ID,
 	)
-	description := copy.Summary + "\n\n" + copy.Action
+	description := copy.Summary
+	if copy.Action != "" {
+		description += "\n\n" + copy.Action
+	}
 	if len(occurrence.Definition.Sources) > 0 {
 		description += "\n\n"
 		for index, source := range occurrence.Definition.Sources {
@@ -108,14 +173,62 @@ func writeOccasionEvent(
 		}
 	}
 
+	writeLine(calendar, "BEGIN:VEVENT")
+	writeLine(calendar, "UID:"+uid)
+	writeLine(calendar, "DTSTAMP:"+createdAt.UTC().Format("20060102T150405Z"))
+	writeLine(calendar, "DTSTART;VALUE=DATE:"+date.Format("20060102"))
+	writeLine(calendar, "DTEND;VALUE=DATE:"+last.AddDate(0, 0, 1).Format("20060102"))
+	writeLine(calendar, "SUMMARY:"+escapeText(occurrence.Definition.Emoji+" "+title))
+	writeLine(calendar, "DESCRIPTION:"+escapeText(description))
+	writeLine(calendar, "CATEGORIES:"+category)
+	writeLine(calendar, "END:VEVENT")
+}
+
+// writeMoonEvents adds an all-day event on the local date of each new moon in
+// the range and on the evening its crescent is expected, including the
+// crescent of a new moon just before the range.
+func writeMoonEvents(
+	calendar *bytes.Buffer,
+	profile domain.PrayerProfile,
+	locale i18n.Locale,
+	start time.Time,
+	days int,
+	createdAt time.Time,
+	uidNamespace string,
+) error {
+	location := start.Location()
+	first := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, location)
+	end := first.AddDate(0, 0, days)
+	within := func(date time.Time) bool { return !date.Before(first) && date.Before(end) }
+	for newMoon := moon.NewMoonBefore(first); newMoon.Before(end); newMoon = moon.NewMoonAfter(newMoon) {
+		local := newMoon.In(location)
+		if date := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, location); within(date) {
+			writeMoonEvent(calendar, fmt.Sprintf("%s-%s-new-moon@global-prayer-bot", uidNamespace, date.Format("20060102")),
+				date, moon.PhaseNew.Emoji()+" "+locale.Message("moon_new_event"),
+				fmt.Sprintf(locale.Message("moon_new_note"), local.Format("15:04")), createdAt)
+		}
+		crescent, err := moon.FirstCrescent(newMoon, profile.Latitude, profile.Longitude, location)
+		if err != nil {
+			return err
+		}
+		if crescent.Seen() && within(crescent.Evening) {
+			writeMoonEvent(calendar, fmt.Sprintf("%s-%s-crescent@global-prayer-bot", uidNamespace, crescent.Evening.Format("20060102")),
+				crescent.Evening, moon.PhaseWaxingCrescent.Emoji()+" "+locale.Message("moon_crescent_event"),
+				locale.Message("moon_crescent_note"), createdAt)
+		}
+	}
+	return nil
+}
+
+func writeMoonEvent(calendar *bytes.Buffer, uid string, date time.Time, summary, description string, createdAt time.Time) {
 	writeLine(calendar, "BEGIN:VEVENT")
 	writeLine(calendar, "UID:"+uid)
 	writeLine(calendar, "DTSTAMP:"+createdAt.UTC().Format("20060102T150405Z"))
 	writeLine(calendar, "DTSTART;VALUE=DATE:"+date.Format("20060102"))
 	writeLine(calendar, "DTEND;VALUE=DATE:"+date.AddDate(0, 0, 1).Format("20060102"))
-	writeLine(calendar, "SUMMARY:"+escapeText(occurrence.Definition.Emoji+" "+copy.Title))
+	writeLine(calendar, "SUMMARY:"+escapeText(summary))
 	writeLine(calendar, "DESCRIPTION:"+escapeText(description))
-	writeLine(calendar, "CATEGORIES:Islamic Occasions")
+	writeLine(calendar, "CATEGORIES:Moon")
 	writeLine(calendar, "END:VEVENT")
 }
 
@@ -124,17 +237,24 @@ func writeEvent(
 	profile domain.PrayerProfile,
 	locale i18n.Locale,
 	prayer domain.Prayer,
+	day time.Time,
 	at time.Time,
 	createdAt time.Time,
 	uidNamespace string,
 ) {
-	description := fmt.Sprintf("%s · %s · %s", locale.BotName, locale.Method(profile.Method), profile.Timezone)
+	description := eventDescription(profile, locale)
+	// The UID uses the schedule's day rather than the event's, because
+	// Islamic midnight and the last third can fall after 00:00.
 	uid := fmt.Sprintf(
 		"%s-%s-%s@global-prayer-bot",
 		uidNamespace,
-		at.In(mustLocation(profile.Timezone)).Format("20060102"),
+		day.In(mustLocation(profile.Timezone)).Format("20060102"),
 		prayer,
 	)
+	category := "Prayer Times"
+	if prayer.Extended() {
+		category = "Extended Times"
+	}
 
 	writeLine(calendar, "BEGIN:VEVENT")
 	writeLine(calendar, "UID:"+uid)
@@ -143,10 +263,85 @@ func writeEvent(
 	writeLine(calendar, "DTEND:"+at.Add(eventDuration).UTC().Format("20060102T150405Z"))
 	writeLine(calendar, "SUMMARY:"+escapeText(locale.Prayer(prayer)))
 	writeLine(calendar, "DESCRIPTION:"+escapeText(description))
+	writeLine(calendar, "CATEGORIES:"+category)
+	writeLine(calendar, "END:VEVENT")
+}
+
+// writeJumuahEvent stands in for Friday's Dhuhr and keeps its UID, so a
+// subscribed calendar moves the event rather than showing both. It lasts
+// until shortly after the iqamah when one is set.
+func writeJumuahEvent(
+	calendar *bytes.Buffer,
+	profile domain.PrayerProfile,
+	locale i18n.Locale,
+	khutbah time.Time,
+	iqamah time.Time,
+	createdAt time.Time,
+	uidNamespace string,
+) {
+	times := locale.Message("jumuah_khutbah") + " " + khutbah.Format("15:04")
+	end := khutbah.Add(eventDuration)
+	if !iqamah.IsZero() {
+		times += " · " + locale.Message("jumuah_iqamah") + " " + iqamah.Format("15:04")
+		end = iqamah.Add(eventDuration)
+	}
+	uid := fmt.Sprintf(
+		"%s-%s-%s@global-prayer-bot",
+		uidNamespace,
+		khutbah.Format("20060102"),
+		domain.PrayerDhuhr,
+	)
+
+	writeLine(calendar, "BEGIN:VEVENT")
+	writeLine(calendar, "UID:"+uid)
+	writeLine(calendar, "DTSTAMP:"+createdAt.UTC().Format("20060102T150405Z"))
+	writeLine(calendar, "DTSTART:"+khutbah.UTC().Format("20060102T150405Z"))
+	writeLine(calendar, "DTEND:"+end.UTC().Format("20060102T150405Z"))
+	writeLine(calendar, "SUMMARY:"+escapeText("🕌 "+locale.Message("jumuah")))
+	writeLine(calendar, "DESCRIPTION:"+escapeText(times+"\n\n"+eventDescription(profile, locale)))
 	writeLine(calendar, "CATEGORIES:Prayer Times")
 	writeLine(calendar, "END:VEVENT")
 }
 
+func writeMakruhEvent(
+	calendar *bytes.Buffer,
+	profile domain.PrayerProfile,
+	locale i18n.Locale,
+	window domain.MakruhWindow,
+	span domain.TimeWindow,
+	createdAt time.Time,
+	uidNamespace string,
+) {
+	description := eventDescription(profile, locale)
+	uid := fmt.Sprintf(
+		"%s-%s-makruh-%s@global-prayer-bot",
+		uidNamespace,
+		span.Start.In(mustLocation(profile.Timezone)).Format("20060102"),
+		window,
+	)
+
+	writeLine(calendar, "BEGIN:VEVENT")
+	writeLine(calendar, "UID:"+uid)
+	writeLine(calendar, "DTSTAMP:"+createdAt.UTC().Format("20060102T150405Z"))
+	writeLine(calendar, "DTSTART:"+span.Start.UTC().Format("20060102T150405Z"))
+	writeLine(calendar, "DTEND:"+span.End.UTC().Format("20060102T150405Z"))
+	writeLine(calendar, "SUMMARY:"+escapeText("🚫 "+locale.Message("makruh_title")+" · "+locale.MakruhWindow(window)))
+	writeLine(calendar, "DESCRIPTION:"+escapeText(description))
+	writeLine(calendar, "CATEGORIES:Makruh Times")
+	writeLine(calendar, "TRANSP:TRANSPARENT")
+	writeLine(calendar, "END:VEVENT")
+}
+
+// eventDescription names what the times were calculated with, including a
+// rounding or ihtiyat policy that moves them off the plain calculation.
+func eventDescription(profile domain.PrayerProfile, locale i18n.Locale) string {
+	description := fmt.Sprintf("%s · %s · %s", locale.BotName, locale.Method(profile.Method), profile.Timezone)
+	if !profile.Precaution.IsDefault() {
+		description += " · " + locale.PrecautionSummary(profile.Precaution)
+	}
+	return description
+}
+
 func validUIDNamespace(value string) bool {
 	if len(value) != 32 {
 		return false
@@ -160,7 +355,7 @@ func validUIDNamespace(value string) bool {
 }
 
 func mustLocation(name string) *time.Location {
-	location, err := time.LoadLocation(name)
+	location, err := domain.LoadLocation(name)
 	if err != nil {
 		return time.UTC
 	}
@@ -3,13 +3,16 @@ package calendarfile
 import (
 	"bytes"
 	"context"
+	"fmt"
 	"strings"
 	"testing"
 	"time"
 	"unicode/utf8"
 
+	"github.com/escalopa/prayer-bot/global/internal/core/hijri"
 	"github.com/escalopa/prayer-bot/global/internal/core/i18n"
 	"github.com/escalopa/prayer-bot/global/internal/core/occasions"
+	"github.com/escalopa/prayer-bot/global/internal/core/prayertime"
 	"github.com/escalopa/prayer-bot/global/internal/domain"
 )
 
@@ -61,6 +64,229 @@ func TestGenerateCreatesPortableLocalizedCalendar(t *testing.T) {
 	}
 }
 
+type extendedCalculator struct{ fakeCalculator }
+
+func (c extendedCalculator) Day(ctx context.Context, date time.Time, profile domain.PrayerProfile) (domain.DaySchedule, error) {
+	schedule, err := c.fakeCalculator.Day(ctx, date, profile)
+	if err != nil {
+		return schedule, err
+	}
+	schedule.Times[domain.PrayerImsak] = schedule.Times[domain.PrayerFajr].Add(-10 * time.Minute)
+	schedule.Times[domain.PrayerMidnight] = schedule.Date.Add(24*time.Hour + 30*time.Minute)
+	return schedule, nil
+}
+
+func TestGenerateAddsExtendedTimesOnlyWhenShown(t *testing.T) {
+	profile := domain.PrayerProfile{
+		Timezone: "UTC", Method: domain.MethodMWL, Madhab: domain.MadhabShafii,
+		HighLatitudeRule: domain.HighLatitudeAngleBased,
+	}
+	start := time.Date(2026, time.July, 17, 12, 0, 0, 0, time.UTC)
+	generate := func() string {
+		t.Helper()
+		data, err := Generate(
+			context.Background(), extendedCalculator{}, profile, i18n.Resolve("en"),
+			start, 1, start, "0123456789abcdef0123456789abcdef",
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(data)
+	}
+	if content := generate(); strings.Contains(content, "Extended Times") {
+		t.Fatalf("extended times must be opt-in:\n%s", content)
+	}
+	profile.ShowExtendedTimes = true
+	content := generate()
+	for _, expected := range []string{
+		"SUMMARY:Imsak\r\n",
+		"CATEGORIES:Extended Times\r\n",
+		// Midnight after 00:00 keeps the UID of the day its night began.
+		"UID:0123456789abcdef0123456789abcdef-20260717-midnight@global-prayer-bot\r\n",
+		"DTSTART:20260718T003000Z\r\n",
+	} {
+		if !strings.Contains(content, expected) {
+			t.Errorf("calendar is missing %q:\n%s", expected, content)
+		}
+	}
+}
+
+type makruhCalculator struct{ fakeCalculator }
+
+func (c makruhCalculator) Day(ctx context.Context, date time.Time, profile domain.PrayerProfile) (domain.DaySchedule, error) {
+	schedule, err := c.fakeCalculator.Day(ctx, date, profile)
+	if err != nil {
+		return schedule, err
+	}
+	maghrib := schedule.Times[domain.PrayerMaghrib]
+	schedule.Makruh = map[domain.MakruhWindow]domain.TimeWindow{
+		domain.MakruhSunset: {Start: maghrib.Add(-20 * time.Minute), End: maghrib},
+	}
+	return schedule, nil
+}
+
+func TestGenerateAddsMakruhWindowsUnlessHidden(t *testing.T) {
+	profile := domain.PrayerProfile{
+		Timezone: "UTC", Method: domain.MethodMWL, Madhab: domain.MadhabShafii,
+		HighLatitudeRule: domain.HighLatitudeAngleBased,
+	}
+	start := time.Date(2026, time.July, 17, 12, 0, 0, 0, time.UTC)
+	generate := func() string {
+		t.Helper()
+		data, err := Generate(
+			context.Background(), makruhCalculator{}, profile, i18n.Resolve("en"),
+			start, 1, start, "0123456789abcdef0123456789abcdef",
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(data)
+	}
+	content := generate()
+	for _, expected := range []string{
+		"UID:0123456789abcdef0123456789abcdef-20260717-makruh-sunset@",
+		"DTSTART:20260717T114000Z\r\n",
+		"DTEND:20260717T120000Z\r\n",
+		"CATEGORIES:Makruh Times\r\n",
+	} {
+		if !strings.Contains(content, expected) {
+			t.Errorf("calendar is missing %q:\n%s", expected, content)
+		}
+	}
+	profile.HideMakruhTimes = true
+	if content := generate(); strings.Contains(content, "Makruh") {
+		t.Fatalf("hidden makruh windows must not be published:\n%s", content)
+	}
+}
+
+func TestGenerateAddsNewMoonAndFirstCrescentWhenChosen(t *testing.T) {
+	profile := domain.PrayerProfile{
+		Latitude: 21.42, Longitude: 39.83, Timezone: "Asia/Riyadh",
+		Method: domain.MethodUmmAlQura, Madhab: domain.MadhabShafii,
+		HighLatitudeRule: domain.HighLatitudeAngleBased, MoonEvents: true,
+	}
+	riyadh, err := time.LoadLocation("Asia/Riyadh")
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Date(2025, time.February, 26, 12, 0, 0, 0, riyadh)
+	generate := func() string {
+		t.Helper()
+		data, err := Generate(
+			context.Background(), fakeCalculator{}, profile, i18n.Resolve("en"),
+			start, 5, start, "0123456789abcdef0123456789abcdef",
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return strings.ReplaceAll(string(data), "\r\n ", "")
+	}
+	content := generate()
+	for _, expected := range []string{
+		"UID:0123456789abcdef0123456789abcdef-20250228-new-moon@global-prayer-bot\r\n",
+		"SUMMARY:🌑 New moon\r\n",
+		"The astronomical new moon is at 03:44.",
+		// The crescent was too young for Mecca on the evening of the new moon.
+		"UID:0123456789abcdef0123456789abcdef-20250301-crescent@global-prayer-bot\r\n",
+		"DTSTART;VALUE=DATE:20250301\r\n",
+		"CATEGORIES:Moon\r\n",
+	} {
+		if !strings.Contains(content, expected) {
+			t.Errorf("calendar is missing %q:\n%s", expected, content)
+		}
+	}
+	profile.MoonEvents = false
+	if content := generate(); strings.Contains(content, "CATEGORIES:Moon") {
+		t.Fatalf("moon events must be opt-in:\n%s", content)
+	}
+}
+
+func TestGenerateAppliesAndNamesThePrecautionPolicy(t *testing.T) {
+	profile := domain.PrayerProfile{
+		Latitude: 30.044, Longitude: 31.236, Timezone: "Africa/Cairo",
+		Method: domain.MethodEgyptian, Madhab: domain.MadhabShafii,
+		HighLatitudeRule: domain.HighLatitudeAngleBased, HideMakruhTimes: true,
+	}
+	start := time.Date(2026, time.July, 17, 12, 0, 0, 0, time.UTC)
+	calculator := prayertime.New()
+	generate := func() string {
+		t.Helper()
+		data, err := Generate(
+			context.Background(), calculator, profile, i18n.Resolve("en"),
+			start, 1, start, "0123456789abcdef0123456789abcdef",
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// Undo line folding so long descriptions can be matched.
+		return strings.ReplaceAll(string(data), "\r\n ", "")
+	}
+	dhuhr := func(content string) time.Time {
+		t.Helper()
+		_, event, _ := strings.Cut(content, "-dhuhr@")
+		_, value, _ := strings.Cut(event, "DTSTART:")
+		at, err := time.Parse("20060102T150405Z", value[:16])
+		if err != nil {
+			t.Fatal(err)
+		}
+		return at
+	}
+	plain := generate()
+	if strings.Contains(plain, "ihtiyat") {
+		t.Fatalf("the default policy should not be described:\n%s", plain)
+	}
+
+	profile.Precaution = domain.Precaution{IhtiyatMinutes: 2, Fajr: domain.RoundDown, Maghrib: domain.RoundUp}
+	content := generate()
+	if !strings.Contains(content, "ihtiyat +2 min · Fajr ↓ · Maghrib ↑") {
+		t.Fatalf("event descriptions should name the policy:\n%s", content)
+	}
+	if got, want := dhuhr(content), dhuhr(plain).Add(2*time.Minute); !got.Equal(want) {
+		t.Fatalf("Dhuhr = %s, want %s with the ihtiyat", got, want)
+	}
+	for _, line := range strings.Split(content, "\r\n") {
+		if strings.HasPrefix(line, "DTSTART:") && !strings.HasSuffix(line, "00Z") {
+			t.Errorf("event starts off the minute: %s", line)
+		}
+	}
+}
+
+func TestGenerateReplacesFridayDhuhrWithJumuah(t *testing.T) {
+	profile := domain.PrayerProfile{
+		Latitude: 30.044, Longitude: 31.236, Timezone: "Africa/Cairo",
+		Method: domain.MethodEgyptian, Madhab: domain.MadhabShafii,
+		HighLatitudeRule: domain.HighLatitudeAngleBased, HideMakruhTimes: true,
+		Jumuah: domain.Jumuah{Khutbah: "13:15", Iqamah: "13:45"},
+	}
+	// 17 July 2026 is a Friday in Cairo, at UTC+3.
+	start := time.Date(2026, time.July, 17, 12, 0, 0, 0, time.UTC)
+	data, err := Generate(
+		context.Background(), fakeCalculator{}, profile, i18n.Resolve("en"),
+		start, 2, start, "0123456789abcdef0123456789abcdef",
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	content := strings.ReplaceAll(string(data), "\r\n ", "")
+	if count := strings.Count(content, "BEGIN:VEVENT\r\n"); count != 12 {
+		t.Fatalf("event count = %d, want 12", count)
+	}
+	_, friday, _ := strings.Cut(content, "UID:0123456789abcdef0123456789abcdef-20260717-dhuhr@global-prayer-bot\r\n")
+	friday, _, _ = strings.Cut(friday, "END:VEVENT")
+	for _, want := range []string{
+		"DTSTART:20260717T101500Z\r\n", "DTEND:20260717T110000Z\r\n",
+		"SUMMARY:🕌 Jumu'ah\r\n", "DESCRIPTION:Khutbah 13:15 · Iqamah 13:45\\n",
+	} {
+		if !strings.Contains(friday, want) {
+			t.Fatalf("Friday's Dhuhr event is missing %q:\n%s", want, friday)
+		}
+	}
+	_, saturday, _ := strings.Cut(content, "-20260718-dhuhr@global-prayer-bot\r\n")
+	if !strings.Contains(saturday, "SUMMARY:Dhuhr\r\n") {
+		t.Fatalf("Saturday should keep calculated Dhuhr:\n%s", saturday)
+	}
+}
+
 func TestGenerateValidatesRange(t *testing.T) {
 	profile := domain.PrayerProfile{Timezone: "UTC"}
 	if _, err := Generate(
@@ -83,7 +309,11 @@ func TestGenerateIncludesLocalizedIslamicOccasionWithStableSource(t *testing.T)
 		Method: domain.MethodMWL, Madhab: domain.MadhabShafii,
 		HighLatitudeRule: domain.HighLatitudeAngleBased,
 	}
-	occurrence, err := occasions.Next(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), 1, occasions.CategoryMajor)
+	calendar, err := hijri.ForProfile(profile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	occurrence, err := occasions.Next(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), calendar, "", occasions.CategoryMajor)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -113,6 +343,56 @@ func TestGenerateIncludesLocalizedIslamicOccasionWithStableSource(t *testing.T)
 	}
 }
 
+func TestGenerateWritesSpansOnceAndDailyObservancesPerDay(t *testing.T) {
+	profile := domain.PrayerProfile{
+		Timezone: "UTC", Method: domain.MethodMWL, Madhab: domain.MadhabShafii,
+		HighLatitudeRule: domain.HighLatitudeAngleBased,
+	}
+	calendar, err := hijri.ForProfile(profile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	upcoming, err := occasions.Between(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), 354, calendar, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var tenDays, tashreeq []time.Time
+	for _, occurrence := range upcoming {
+		switch occurrence.Definition.ID {
+		case "dhul_hijjah_start":
+			tenDays = append(tenDays, occurrence.Date)
+		case "tashreeq":
+			tashreeq = append(tashreeq, occurrence.Date)
+		}
+	}
+	// The range starts on the fifth of the ten days.
+	start := tenDays[4]
+	data, err := Generate(
+		context.Background(), fakeCalculator{}, profile, i18n.Resolve("en"),
+		start, 14, start, "0123456789abcdef0123456789abcdef",
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	content := strings.ReplaceAll(string(data), "\r\n ", "")
+	span := "UID:0123456789abcdef0123456789abcdef-" + tenDays[0].Format("20060102") + "-dhul_hijjah_start@global-prayer-bot\r\n"
+	if strings.Count(content, "-dhul_hijjah_start@") != 1 || !strings.Contains(content, span) ||
+		!strings.Contains(content, span+"DTSTAMP:"+start.Format("20060102T150405Z")+"\r\nDTSTART;VALUE=DATE:"+tenDays[0].Format("20060102")+
+			"\r\nDTEND;VALUE=DATE:"+tenDays[9].AddDate(0, 0, 1).Format("20060102")+"\r\n") {
+		t.Fatalf("the first ten days must be one event from their first day:\n%s", content)
+	}
+	for index, date := range tashreeq {
+		for _, expected := range []string{
+			"UID:0123456789abcdef0123456789abcdef-" + date.Format("20060102") + "-tashreeq@global-prayer-bot\r\n",
+			fmt.Sprintf("SUMMARY:📿 Days of Tashreeq · day %d of 3\r\n", index+1),
+		} {
+			if !strings.Contains(content, expected) {
+				t.Errorf("calendar is missing %q:\n%s", expected, content)
+			}
+		}
+	}
+}
+
 func TestWriteLineFoldsUTF8WithoutSplittingRunes(t *testing.T) {
 	var buffer bytes.Buffer
 	writeLine(&buffer, "SUMMARY:"+strings.Repeat("الفجر", 20))
@@ -125,3 +405,30 @@ func TestWriteLineFoldsUTF8WithoutSplittingRunes(t *testing.T) {
 		}
 	}
 }
+
+// BenchmarkGenerateFeeds renders the 30-day feed for many subscribers with the
+// production calculator, as the calendar endpoint does.
+func BenchmarkGenerateFeeds(b *testing.B) {
+	calculator := prayertime.New()
+	locale := i18n.Resolve("en")
+	start := time.Date(2026, time.July, 17, 12, 0, 0, 0, time.UTC)
+	profiles := make([]domain.PrayerProfile, 100)
+	for i := range profiles {
+		profiles[i] = domain.PrayerProfile{
+			Latitude: 30 + float64(i)/100, Longitude: 31.236, Timezone: "Africa/Cairo",
+			Method: domain.MethodEgyptian, Madhab: domain.MadhabShafii, HighLatitudeRule: domain.HighLatitudeAngleBased,
+		}
+	}
+	for _, profile := range profiles {
+		if _, err := Generate(context.Background(), calculator, profile, locale, start, 30, start, "0123456789abcdef0123456789abcdef"); err != nil {
+			b.Fatal(err)
+		}
+	}
+	for i := 0; b.Loop(); i++ {
+		_, err := Generate(context.Background(), calculator, profiles[i%len(profiles)], locale,
+			start, 30, start, "0123456789abcdef0123456789abcdef")
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
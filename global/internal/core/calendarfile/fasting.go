@@ -0,0 +1,78 @@
+package calendarfile
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"github.com/escalopa/prayer-bot/global/internal/core/fasting"
+	"github.com/escalopa/prayer-bot/global/internal/core/hijri"
+	"github.com/escalopa/prayer-bot/global/internal/core/i18n"
+	"github.com/escalopa/prayer-bot/global/internal/domain"
+)
+
+// GenerateFasting writes the fasting calendar as its own feed: an all-day
+// event for every obligatory, recommended or prohibited day in the range.
+// Days without a status are left out.
+func GenerateFasting(
+	profile domain.PrayerProfile,
+	locale i18n.Locale,
+	start time.Time,
+	days int,
+	createdAt time.Time,
+	uidNamespace string,
+) ([]byte, error) {
+	if !validUIDNamespace(uidNamespace) {
+		return nil, fmt.Errorf("invalid calendar UID namespace")
+	}
+	location, err := domain.LoadLocation(profile.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("load timezone: %w", err)
+	}
+	start = start.In(location)
+	hijriCalendar, err := hijri.ForProfile(profile)
+	if err != nil {
+		return nil, err
+	}
+	fastingDays, err := fasting.Between(start, days, hijriCalendar, profile.CountryCode)
+	if err != nil {
+		return nil, fmt.Errorf("calculate fasting days: %w", err)
+	}
+
+	var calendar bytes.Buffer
+	writeLine(&calendar, "BEGIN:VCALENDAR")
+	writeLine(&calendar, "VERSION:2.0")
+	writeLine(&calendar, "PRODID:-//Global Prayer Times//Fasting Calendar//EN")
+	writeLine(&calendar, "CALSCALE:GREGORIAN")
+	writeLine(&calendar, "METHOD:PUBLISH")
+	writeLine(&calendar, "X-WR-CALNAME:"+escapeText(locale.Message("fasting_calendar_title")))
+	writeLine(&calendar, "X-WR-TIMEZONE:"+escapeText(profile.Timezone))
+	writeLine(&calendar, "X-PUBLISHED-TTL:PT12H")
+	writeLine(&calendar, "REFRESH-INTERVAL;VALUE=DURATION:PT12H")
+	for _, day := range fastingDays {
+		if day.Status != "" {
+			writeFastingEvent(&calendar, locale, day, createdAt, uidNamespace)
+		}
+	}
+	writeLine(&calendar, "END:VCALENDAR")
+	return calendar.Bytes(), nil
+}
+
+func writeFastingEvent(calendar *bytes.Buffer, locale i18n.Locale, day fasting.Day, createdAt time.Time, uidNamespace string) {
+	date := day.Date
+	description := fmt.Sprintf("%d %s %d %s", day.Hijri.Day, locale.HijriMonth(day.Hijri.Month), day.Hijri.Year, locale.Message("hijri_era"))
+	if day.Status == fasting.StatusProhibited {
+		description += "\n\n" + locale.Message("fasting_note")
+	}
+
+	writeLine(calendar, "BEGIN:VEVENT")
+	writeLine(calendar, fmt.Sprintf("UID:%s-%s-fasting@global-prayer-bot", uidNamespace, date.Format("20060102")))
+	writeLine(calendar, "DTSTAMP:"+createdAt.UTC().Format("20060102T150405Z"))
+	writeLine(calendar, "DTSTART;VALUE=DATE:"+date.Format("20060102"))
+	writeLine(calendar, "DTEND;VALUE=DATE:"+date.AddDate(0, 0, 1).Format("20060102"))
+	writeLine(calendar, "SUMMARY:"+escapeText(fmt.Sprintf("%s %s · %s", day.Status.Emoji(), locale.FastingStatus(day.Status), locale.FastingReasons(day))))
+	writeLine(calendar, "DESCRIPTION:"+escapeText(description))
+	writeLine(calendar, "CATEGORIES:Fasting")
+	writeLine(calendar, "TRANSP:TRANSPARENT")
+	writeLine(calendar, "END:VEVENT")
+}
@@ -0,0 +1,40 @@
+package calendarfile
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/escalopa/prayer-bot/global/internal/core/i18n"
+	"github.com/escalopa/prayer-bot/global/internal/domain"
+)
+
+func TestGenerateFastingListsEveryMarkedDay(t *testing.T) {
+	profile := domain.PrayerProfile{Latitude: 30.044, Longitude: 31.236, Timezone: "Africa/Cairo"}
+	// Monday 25 May 2026 is 8 Dhu al-Hijjah 1447; Arafah follows, then Eid
+	// al-Adha, the days of Tashreeq and the white day of 31 May.
+	start := time.Date(2026, time.May, 25, 12, 0, 0, 0, time.UTC)
+	data, err := GenerateFasting(profile, i18n.Resolve("en"), start, 7, start, "0123456789abcdef0123456789abcdef")
+	if err != nil {
+		t.Fatal(err)
+	}
+	content := string(data)
+	if count := strings.Count(content, "BEGIN:VEVENT\r\n"); count != 7 {
+		t.Fatalf("event count = %d, want 7:\n%s", count, content)
+	}
+	for _, want := range []string{
+		"X-WR-CALNAME:Fasting calendar\r\n",
+		"UID:0123456789abcdef0123456789abcdef-20260526-fasting@global-prayer-bot\r\n",
+		"SUMMARY:🤲 Recommended · Monday\r\n",
+		"SUMMARY:⛔ Do not fast · Eid al-Adha\r\n",
+		"SUMMARY:🤲 Recommended · White day\r\n",
+		"CATEGORIES:Fasting\r\n",
+	} {
+		if !strings.Contains(content, want) {
+			t.Fatalf("fasting calendar is missing %q:\n%s", want, content)
+		}
+	}
+	if _, err := GenerateFasting(profile, i18n.Resolve("en"), start, 7, start, "not-a-namespace"); err == nil {
+		t.Fatal("an invalid UID namespace was accepted")
+	}
+}
@@ -0,0 +1,286 @@
+// Package dateconvert reads a date a user typed in either calendar and
+// converts it to the other in the chat's Hijri calendar.
+package dateconvert
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/escalopa/prayer-bot/global/internal/core/hijri"
+	"github.com/escalopa/prayer-bot/global/internal/core/i18n"
+)
+
+// The years a query may name. They keep conversions within what the
+// calendars can date and make a bare year tell the calendars apart.
+const (
+	minHijriYear     = 1300
+	maxHijriYear     = 1700
+	minGregorianYear = 1880
+	maxGregorianYear = 2270
+)
+
+// ErrUnreadable is returned for text that is not a date in either calendar.
+var ErrUnreadable = errors.New("the date could not be read")
+
+// Query is a typed date. Year is zero when it was left out.
+type Query struct {
+	Hijri bool
+	Day   int
+	Month int
+	Year  int
+}
+
+// Result is a converted query. A Hijri query may fall on two Gregorian days;
+// see hijri.Gregorian.
+type Result struct {
+	Hijri     hijri.Date
+	Gregorian []time.Time
+}
+
+// Ambiguous reports whether the Hijri date has two candidate days.
+func (r Result) Ambiguous() bool { return len(r.Gregorian) > 1 }
+
+// Parse reads "27 Rajab", "Rajab 27 1447", "1447-07-27", "16.01.2026",
+// "16/01/2026" or "16 January 2026". Month names are matched in every
+// supported language. Numeric dates are day first unless they start with the
+// year, and their year decides the calendar.
+func Parse(text string) (Query, error) {
+	var numbers []int
+	var words []string
+	var number, word strings.Builder
+	flush := func() {
+		if number.Len() > 0 {
+			value := 0
+			for _, digit := range number.String() {
+				value = value*10 + int(digit-'0')
+			}
+			numbers = append(numbers, value)
+			number.Reset()
+		}
+		if word.Len() > 0 {
+			words = append(words, word.String())
+			word.Reset()
+		}
+	}
+	for _, r := range strings.ToLower(text) {
+		switch {
+		case r >= '0' && r <= '9':
+			if word.Len() > 0 {
+				flush()
+			}
+			number.WriteRune(r)
+		case r >= '٠' && r <= '٩':
+			if word.Len() > 0 {
+				flush()
+			}
+			number.WriteRune('0' + r - '٠')
+		case unicode.IsLetter(r) || r == '\'' || r == '’' || r == 'ʿ' || r == '-' && word.Len() > 0:
+			if number.Len() > 0 {
+				flush()
+			}
+			word.WriteRune(r)
+		default:
+			flush()
+		}
+	}
+	flush()
+	// Month names may run over several words, so the words are joined.
+	var name string
+	for _, value := range words {
+		if key := normalize(value); !eraMarkers[key] {
+			name += key
+		}
+	}
+	if name == "" {
+		return fromNumbers(numbers)
+	}
+	month, ok := matchMonth(name)
+	if !ok || len(numbers) == 0 || len(numbers) > 2 {
+		return Query{}, ErrUnreadable
+	}
+	query := Query{Hijri: month.hijri, Month: month.number}
+	for _, value := range numbers {
+		switch {
+		case value > 31 && query.Year == 0:
+			query.Year = value
+		case value >= 1 && value <= 31 && query.Day == 0:
+			query.Day = value
+		default:
+			return Query{}, ErrUnreadable
+		}
+	}
+	return query, query.validate()
+}
+
+func fromNumbers(numbers []int) (Query, error) {
+	if len(numbers) != 3 {
+		return Query{}, ErrUnreadable
+	}
+	query := Query{Day: numbers[0], Month: numbers[1], Year: numbers[2]}
+	if numbers[0] > 31 {
+		query = Query{Year: numbers[0], Month: numbers[1], Day: numbers[2]}
+	}
+	query.Hijri = query.Year <= maxHijriYear
+	return query, query.validate()
+}
+
+func (q Query) validate() error {
+	if q.Month < 1 || q.Month > 12 || q.Day < 1 {
+		return ErrUnreadable
+	}
+	if q.Hijri {
+		if q.Day > 30 || q.Year != 0 && (q.Year < minHijriYear || q.Year > maxHijriYear) {
+			return fmt.Errorf("Hijri years run from %d to %d here", minHijriYear, maxHijriYear)
+		}
+		return nil
+	}
+	if q.Year != 0 && (q.Year < minGregorianYear || q.Year > maxGregorianYear) {
+		return fmt.Errorf("Gregorian years run from %d to %d here", minGregorianYear, maxGregorianYear)
+	}
+	year := q.Year
+	if year == 0 {
+		year = 2000 // A leap year, so 29 February is accepted without one.
+	}
+	if gregorianDay(year, q).Day() != q.Day {
+		return ErrUnreadable
+	}
+	return nil
+}
+
+// Convert converts the query in calendar. A query without a year means its
+// next occurrence on or after today, a civil date in the chat's timezone.
+func Convert(query Query, calendar hijri.Calendar, today time.Time) (Result, error) {
+	today = time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.UTC)
+	if !query.Hijri {
+		year := query.Year
+		if year == 0 {
+			year = today.Year()
+			// The next 29 February may be years away.
+			for day := gregorianDay(year, query); day.Before(today) || day.Day() != query.Day; day = gregorianDay(year, query) {
+				year++
+			}
+		}
+		day := gregorianDay(year, query)
+		if day.Day() != query.Day {
+			return Result{}, fmt.Errorf("%d has no 29 February", year)
+		}
+		date, err := calendar.Date(day)
+		if err != nil {
+			return Result{}, err
+		}
+		return Result{Hijri: date, Gregorian: []time.Time{day}}, nil
+	}
+	date := hijri.Date{Day: query.Day, Month: query.Month, Year: query.Year}
+	if query.Year == 0 {
+		current, err := calendar.Date(today)
+		if err != nil {
+			return Result{}, err
+		}
+		date.Year = current.Year
+		days, err := hijri.Gregorian(calendar, date)
+		if err != nil {
+			return Result{}, err
+		}
+		if !days[len(days)-1].Before(today) {
+			return Result{Hijri: date, Gregorian: days}, nil
+		}
+		date.Year++
+	}
+	days, err := hijri.Gregorian(calendar, date)
+	if err != nil {
+		return Result{}, err
+	}
+	return Result{Hijri: date, Gregorian: days}, nil
+}
+
+func gregorianDay(year int, query Query) time.Time {
+	return time.Date(year, time.Month(query.Month), query.Day, 0, 0, 0, 0, time.UTC)
+}
+
+// eraMarkers are the era abbreviations people add to a date. They carry no
+// information the month name or year does not.
+var eraMarkers = map[string]bool{"ah": true, "h": true, "ad": true, "ce": true, "г": true, "х": true, "һ": true, "ه": true}
+
+type month struct {
+	hijri  bool
+	number int
+}
+
+// spellings maps normalized month names to months. It is filled from every
+// locale's names plus common English transliterations of the Hijri months.
+var spellings = map[string]month{}
+
+func init() {
+	transliterations := [][]string{
+		{"muharram", "muharam"},
+		{"safar"},
+		{"rabialawwal", "rabiulawwal", "rabiulawal", "rabialawal"},
+		{"rabialthani", "rabiulakhir", "rabialakhir", "rabiussani", "rabiuthani", "rabiulthani"},
+		{"jumadaalawwal", "jumadaalula", "jumadalula", "jumadiulawwal", "jumadalawwal", "jumadaula"},
+		{"jumadaalthani", "jumadaalakhirah", "jumadalakhira", "jumadiulakhir", "jumadalthani", "jumadaakhira"},
+		{"rajab"},
+		{"shaban", "shaaban"},
+		{"ramadan", "ramadhan", "ramazan"},
+		{"shawwal", "shawal"},
+		{"dhualqidah", "dhulqadah", "dhulqidah", "zulqadah", "zulqida", "dhulqaada"},
+		{"dhualhijjah", "dhulhijjah", "dhulhijja", "zulhijjah", "zulhijja"},
+	}
+	for index, names := range transliterations {
+		for _, name := range names {
+			spellings[name] = month{hijri: true, number: index + 1}
+		}
+	}
+	for _, locale := range i18n.Supported() {
+		for number := 1; number <= 12; number++ {
+			spellings[normalize(locale.HijriMonth(number))] = month{hijri: true, number: number}
+			spellings[normalize(locale.Month(number))] = month{number: number}
+		}
+	}
+}
+
+// matchMonth finds a spelling by its full normalized name, by a prefix of at
+// least three letters that only one month shares, or by the name without its
+// last letter, which covers the case endings of Russian and Tatar months.
+func matchMonth(name string) (month, bool) {
+	if found, ok := spellings[name]; ok {
+		return found, true
+	}
+	var candidates []month
+	seen := map[month]bool{}
+	runes := []rune(name)
+	for spelling, candidate := range spellings {
+		known := []rune(spelling)
+		prefix := len(runes) >= 3 && strings.HasPrefix(spelling, name)
+		stem := len(runes) >= 5 && len(known) >= 5 &&
+			string(runes[:len(runes)-1]) == string(known[:len(known)-1])
+		if (prefix || stem) && !seen[candidate] {
+			seen[candidate] = true
+			candidates = append(candidates, candidate)
+		}
+	}
+	if len(candidates) != 1 {
+		return month{}, false
+	}
+	return candidates[0], true
+}
+
+var folding = strings.NewReplacer(
+	"ş", "s", "ç", "c", "ğ", "g", "ı", "i", "ö", "o", "ü", "u", "é", "e", "è", "e", "á", "a", "â", "a",
+	"û", "u", "î", "i", "ñ", "n", "ō", "o", "ā", "a", "ī", "i", "ū", "u",
+	"أ", "ا", "إ", "ا", "آ", "ا", "ة", "ه", "ى", "ي", "ـ", "",
+)
+
+// normalize lower-cases a name, folds the accents people often leave out,
+// and drops everything but letters.
+func normalize(name string) string {
+	var builder strings.Builder
+	for _, r := range folding.Replace(strings.ToLower(name)) {
+		if unicode.IsLetter(r) {
+			builder.WriteRune(r)
+		}
+	}
+	return builder.String()
+}
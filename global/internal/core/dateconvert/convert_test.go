@@ -0,0 +1,127 @@
+package dateconvert
+
+import (
+	"testing"
+	"time"
+
+	"github.com/escalopa/prayer-bot/global/internal/core/hijri"
+	"github.com/escalopa/prayer-bot/global/internal/domain"
+)
+
+func TestParseReadsEitherCalendarInEveryLanguage(t *testing.T) {
+	for text, want := range map[string]Query{
+		"27 Rajab 1447":       {Hijri: true, Day: 27, Month: 7, Year: 1447},
+		"Rajab 27":            {Hijri: true, Day: 27, Month: 7},
+		"1 ramadhan 1448 AH":  {Hijri: true, Day: 1, Month: 9, Year: 1448},
+		"10 Dhul-Hijjah":      {Hijri: true, Day: 10, Month: 12},
+		"12 Rabi' al-Awwal":   {Hijri: true, Day: 12, Month: 3},
+		"١ رمضان ١٤٤٨":        {Hijri: true, Day: 1, Month: 9, Year: 1448},
+		"15 Şaban":            {Hijri: true, Day: 15, Month: 8},
+		"15 saban 1447":       {Hijri: true, Day: 15, Month: 8, Year: 1447},
+		"1447-07-27":          {Hijri: true, Day: 27, Month: 7, Year: 1447},
+		"27.07.1447":          {Hijri: true, Day: 27, Month: 7, Year: 1447},
+		"16.01.2026":          {Day: 16, Month: 1, Year: 2026},
+		"16/01/2026":          {Day: 16, Month: 1, Year: 2026},
+		"2026-01-16":          {Day: 16, Month: 1, Year: 2026},
+		"16 January 2026":     {Day: 16, Month: 1, Year: 2026},
+		"Jan 16":              {Day: 16, Month: 1},
+		"16 января 2026":      {Day: 16, Month: 1, Year: 2026},
+		"16 январь":           {Day: 16, Month: 1},
+		"16 février":          {Day: 16, Month: 2},
+		"16 fevrier 2026":     {Day: 16, Month: 2, Year: 2026},
+		"29 February":         {Day: 29, Month: 2},
+		"1 Shawwal 1447 H":    {Hijri: true, Day: 1, Month: 10, Year: 1447},
+		"1 Zilhicce":          {Hijri: true, Day: 1, Month: 12},
+		"16 ocak 2026":        {Day: 16, Month: 1, Year: 2026},
+		"30 Ramadan 1446 AH.": {Hijri: true, Day: 30, Month: 9, Year: 1446},
+	} {
+		got, err := Parse(text)
+		if err != nil {
+			t.Errorf("Parse(%q): %v", text, err)
+			continue
+		}
+		if got != want {
+			t.Errorf("Parse(%q) = %+v, want %+v", text, got, want)
+		}
+	}
+}
+
+func TestParseRefusesWhatItCannotRead(t *testing.T) {
+	for _, text := range []string{
+		"", "tomorrow", "16", "12 Rabi", "31 Rajab", "30 February 2026",
+		"16 January 1700", "1 Ramadan 2026", "2026-13-01", "1 2 3 4",
+	} {
+		if _, err := Parse(text); err == nil {
+			t.Errorf("Parse(%q) succeeded", text)
+		}
+	}
+}
+
+func TestConvertPicksTheNextOccurrenceAndReportsAmbiguity(t *testing.T) {
+	calendar, err := hijri.New(domain.HijriUmmAlQura, 0, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	today := time.Date(2025, time.March, 15, 20, 0, 0, 0, time.FixedZone("MSK", 3*60*60))
+	day := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	}
+
+	result, err := Convert(Query{Hijri: true, Day: 1, Month: 10}, calendar, today)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Hijri.Year != 1446 || result.Ambiguous() || !result.Gregorian[0].Equal(day(2025, time.March, 30)) {
+		t.Fatalf("1 Shawwal = %+v", result)
+	}
+
+	// Umm al-Qura's Ramadan 1446 had 29 days, so its 30th is either day.
+	result, err = Convert(Query{Hijri: true, Day: 30, Month: 9}, calendar, today)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.Ambiguous() || !result.Gregorian[0].Equal(day(2025, time.March, 29)) || !result.Gregorian[1].Equal(day(2025, time.March, 30)) {
+		t.Fatalf("30 Ramadan = %+v", result)
+	}
+
+	// 1 Ramadan 1446 has passed, so the next one is in 1447.
+	result, err = Convert(Query{Hijri: true, Day: 1, Month: 9}, calendar, today)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Hijri.Year != 1447 || !result.Gregorian[0].Equal(day(2026, time.February, 18)) {
+		t.Fatalf("1 Ramadan = %+v", result)
+	}
+
+	result, err = Convert(Query{Day: 1, Month: 3}, calendar, today)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.Gregorian[0].Equal(day(2026, time.March, 1)) || result.Hijri.Month != 9 || result.Hijri.Year != 1447 {
+		t.Fatalf("1 March = %+v", result)
+	}
+
+	// Without a year, 29 February is the next one there is.
+	result, err = Convert(Query{Day: 29, Month: 2}, calendar, today)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.Gregorian[0].Equal(day(2028, time.February, 29)) {
+		t.Fatalf("29 February = %+v", result)
+	}
+}
+
+func TestConvertAppliesTheCorrection(t *testing.T) {
+	// A +1 correction advances every Hijri date, so months begin a day earlier.
+	calendar, err := hijri.New(domain.HijriUmmAlQura, 0, 0, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err := Convert(Query{Hijri: true, Day: 1, Month: 9, Year: 1446}, calendar, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := time.Date(2025, time.February, 28, 0, 0, 0, 0, time.UTC); !result.Gregorian[0].Equal(want) {
+		t.Fatalf("1 Ramadan 1446 with +1 = %v, want %s", result.Gregorian, want.Format(time.DateOnly))
+	}
+}
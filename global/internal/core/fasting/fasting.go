@@ -0,0 +1,179 @@
+// Package fasting is the fasting calendar: which days Ramadan makes
+// obligatory, which the Sunnah recommends, and which must not be fasted. It
+// merges the weekly, white-day and occasion fasts that reminders announce
+// separately with the Eids and the days of Tashreeq.
+package fasting
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/escalopa/prayer-bot/global/internal/core/hijri"
+	"github.com/escalopa/prayer-bot/global/internal/core/occasions"
+	"github.com/escalopa/prayer-bot/global/internal/domain"
+)
+
+// Status is what a day asks of the one fasting. Days the calendar says
+// nothing about have an empty status.
+type Status string
+
+const (
+	StatusObligatory  Status = "obligatory"
+	StatusRecommended Status = "recommended"
+	StatusProhibited  Status = "prohibited"
+)
+
+var statusEmoji = map[Status]string{
+	StatusObligatory: "🌙", StatusRecommended: "🤲", StatusProhibited: "⛔",
+}
+
+func (s Status) Emoji() string { return statusEmoji[s] }
+
+// Reason is why a day has its status. Occasions are their catalog IDs, such
+// as "arafah" or "eid_fitr".
+type Reason string
+
+const (
+	ReasonRamadan   Reason = "ramadan"
+	ReasonMonday    Reason = "monday"
+	ReasonThursday  Reason = "thursday"
+	ReasonWhiteDays Reason = "white_days"
+)
+
+// Day is one civil day of the fasting calendar. Occasion is the catalog
+// occurrence behind an occasion reason, for its day label.
+type Day struct {
+	Date     time.Time
+	Hijri    hijri.Date
+	Status   Status
+	Reasons  []Reason
+	Occasion *occasions.Occurrence
+}
+
+// Between lists the days from start as the country keeps them. A prohibited
+// day stays prohibited whatever else falls on it, and a Ramadan day is
+// obligatory; the voluntary fasts are then only reasons to prefer it.
+func Between(start time.Time, days int, calendar hijri.Calendar, country string) ([]Day, error) {
+	if days < 1 || days > 400 {
+		return nil, fmt.Errorf("fasting range must be between 1 and 400 days")
+	}
+	upcoming, err := occasions.Between(start, days, calendar, country)
+	if err != nil {
+		return nil, err
+	}
+	byDate := make(map[string][]occasions.Occurrence, len(upcoming))
+	for _, occurrence := range upcoming {
+		key := occurrence.Date.Format(time.DateOnly)
+		byDate[key] = append(byDate[key], occurrence)
+	}
+	result := make([]Day, 0, days)
+	for offset := range days {
+		date := start.AddDate(0, 0, offset)
+		hijriDate, err := calendar.Date(date)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, classify(date, hijriDate, byDate[date.Format(time.DateOnly)]))
+	}
+	return result, nil
+}
+
+// On returns the fasting day for date.
+func On(date time.Time, calendar hijri.Calendar, country string) (Day, error) {
+	days, err := Between(date, 1, calendar, country)
+	if err != nil {
+		return Day{}, err
+	}
+	return days[0], nil
+}
+
+func classify(date time.Time, hijriDate hijri.Date, kept []occasions.Occurrence) Day {
+	day := Day{Date: date, Hijri: hijriDate}
+	for _, occurrence := range kept {
+		if occurrence.Definition.NoFasting {
+			day.Status, day.Reasons = StatusProhibited, []Reason{Reason(occurrence.Definition.ID)}
+			day.Occasion = &occurrence
+			return day
+		}
+	}
+	if hijriDate.Month == hijri.Ramadan {
+		day.Status, day.Reasons = StatusObligatory, []Reason{ReasonRamadan}
+		return day
+	}
+	for _, occurrence := range kept {
+		if occurrence.Definition.Category == occasions.CategoryFasting {
+			day.Reasons = append(day.Reasons, Reason(occurrence.Definition.ID))
+			day.Occasion = &occurrence
+		}
+	}
+	if hijriDate.Day >= 13 && hijriDate.Day <= 15 {
+		day.Reasons = append(day.Reasons, ReasonWhiteDays)
+	}
+	switch date.Weekday() {
+	case time.Monday:
+		day.Reasons = append(day.Reasons, ReasonMonday)
+	case time.Thursday:
+		day.Reasons = append(day.Reasons, ReasonThursday)
+	}
+	if len(day.Reasons) > 0 {
+		day.Status = StatusRecommended
+	}
+	return day
+}
+
+// Log checks what a chat logs for a day against the calendar and fixes
+// whether the day is in Ramadan. A prohibited day cannot be logged as fasted,
+// and a Ramadan day cannot make up another.
+func Log(chatID int64, date time.Time, status domain.FastStatus, calendar hijri.Calendar, country string) (domain.FastLogEntry, error) {
+	if !status.Valid() {
+		return domain.FastLogEntry{}, fmt.Errorf("invalid fast status %q", status)
+	}
+	day, err := On(date, calendar, country)
+	if err != nil {
+		return domain.FastLogEntry{}, err
+	}
+	entry := domain.FastLogEntry{
+		ChatID:  chatID,
+		Date:    time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC),
+		Status:  status,
+		Ramadan: day.Status == StatusObligatory,
+	}
+	switch {
+	case day.Status == StatusProhibited && status != domain.FastMissed:
+		return domain.FastLogEntry{}, domain.ErrFastProhibited
+	case entry.Ramadan && status == domain.FastMakeUp:
+		return domain.FastLogEntry{}, domain.ErrMakeUpInRamadan
+	}
+	return entry, nil
+}
+
+// Loggable reports whether a day can be logged at now, a time in the chat's
+// timezone. Tomorrow already can, because fasting reminders are answered on
+// the evening before.
+func Loggable(date, now time.Time) bool {
+	tomorrow := time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, time.UTC)
+	return !time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC).After(tomorrow)
+}
+
+// makeUpHorizon is how far ahead MakeUpDays looks for recommended days.
+const makeUpHorizon = 120
+
+// MakeUpDays suggests up to count recommended days from start on which a
+// missed Ramadan fast can be made up, so the make-up also falls on a day the
+// Sunnah prefers.
+func MakeUpDays(start time.Time, count int, calendar hijri.Calendar, country string) ([]Day, error) {
+	days, err := Between(start, makeUpHorizon, calendar, country)
+	if err != nil {
+		return nil, err
+	}
+	var suggested []Day
+	for _, day := range days {
+		if len(suggested) == count {
+			break
+		}
+		if day.Status == StatusRecommended {
+			suggested = append(suggested, day)
+		}
+	}
+	return suggested, nil
+}
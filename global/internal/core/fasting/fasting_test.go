@@ -0,0 +1,110 @@
+package fasting
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/escalopa/prayer-bot/global/internal/core/hijri"
+	"github.com/escalopa/prayer-bot/global/internal/domain"
+)
+
+func TestBetweenMergesFastsAndProhibitions(t *testing.T) {
+	calendar, err := hijri.New(domain.HijriUmmAlQura, 0, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Date(2026, time.February, 1, 12, 0, 0, 0, time.UTC)
+	days, err := Between(start, 150, calendar, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	byDate := map[string]Day{}
+	for _, day := range days {
+		byDate[day.Date.Format(time.DateOnly)] = day
+	}
+	for _, tc := range []struct {
+		date    string
+		status  Status
+		reasons []Reason
+	}{
+		// 2 February is a Monday and 14 Shaban.
+		{"2026-02-02", StatusRecommended, []Reason{ReasonWhiteDays, ReasonMonday}},
+		{"2026-02-03", StatusRecommended, []Reason{ReasonWhiteDays}},
+		{"2026-02-07", "", nil},
+		// Mondays in Ramadan are only Ramadan.
+		{"2026-02-23", StatusObligatory, []Reason{ReasonRamadan}},
+		{"2026-03-20", StatusProhibited, []Reason{"eid_fitr"}},
+		{"2026-03-21", StatusRecommended, []Reason{"six_shawwal"}},
+		{"2026-05-26", StatusRecommended, []Reason{"arafah"}},
+		{"2026-05-27", StatusProhibited, []Reason{"eid_adha"}},
+		// 13 Dhu al-Hijjah is a white day and a Saturday, but one of Tashreeq.
+		{"2026-05-30", StatusProhibited, []Reason{"tashreeq"}},
+	} {
+		day, ok := byDate[tc.date]
+		if !ok {
+			t.Fatalf("%s is missing", tc.date)
+		}
+		if day.Status != tc.status || !slices.Equal(day.Reasons, tc.reasons) {
+			t.Errorf("%s (%d/%d): got %s %v, want %s %v", tc.date, day.Hijri.Day, day.Hijri.Month,
+				day.Status, day.Reasons, tc.status, tc.reasons)
+		}
+	}
+	if day := byDate["2026-03-21"]; day.Occasion == nil || day.Occasion.Day != 1 {
+		t.Fatalf("the first of the six days of Shawwal lost its occurrence: %+v", day.Occasion)
+	}
+}
+
+func TestLogFixesRamadanAndRefusesProhibitedFasts(t *testing.T) {
+	calendar, err := hijri.New(domain.HijriUmmAlQura, 0, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ramadan := time.Date(2026, time.February, 23, 0, 0, 0, 0, time.UTC)
+	entry, err := Log(42, ramadan, domain.FastMissed, calendar, "")
+	if err != nil || !entry.Ramadan || !entry.Owed() {
+		t.Fatalf("a missed Ramadan day = %+v, %v", entry, err)
+	}
+	if _, err := Log(42, ramadan, domain.FastMakeUp, calendar, ""); !errors.Is(err, domain.ErrMakeUpInRamadan) {
+		t.Fatalf("a Ramadan make-up = %v", err)
+	}
+	eid := time.Date(2026, time.March, 20, 0, 0, 0, 0, time.UTC)
+	if _, err := Log(42, eid, domain.FastKept, calendar, ""); !errors.Is(err, domain.ErrFastProhibited) {
+		t.Fatalf("a fast on Eid = %v", err)
+	}
+	if entry, err := Log(42, eid, domain.FastMissed, calendar, ""); err != nil || entry.Owed() {
+		t.Fatalf("not fasting on Eid = %+v, %v", entry, err)
+	}
+}
+
+func TestMakeUpDaysSuggestsRecommendedDaysAfterRamadan(t *testing.T) {
+	calendar, err := hijri.New(domain.HijriUmmAlQura, 0, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 19 March 2026 is the last day of Ramadan and 20 March is Eid al-Fitr.
+	days, err := MakeUpDays(time.Date(2026, time.March, 19, 0, 0, 0, 0, time.UTC), 3, calendar, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var dates []string
+	for _, day := range days {
+		dates = append(dates, day.Date.Format(time.DateOnly))
+	}
+	if want := []string{"2026-03-21", "2026-03-22", "2026-03-23"}; !slices.Equal(dates, want) {
+		t.Fatalf("MakeUpDays = %v, want %v", dates, want)
+	}
+}
+
+func TestLoggableReachesTomorrowInTheChatsTimezone(t *testing.T) {
+	location := time.FixedZone("UTC+5", 5*60*60)
+	// 23:30 on 5 April in UTC+5 is already the evening before 6 April.
+	now := time.Date(2026, time.April, 5, 23, 30, 0, 0, location)
+	for date, want := range map[string]bool{"2026-03-01": true, "2026-04-06": true, "2026-04-07": false} {
+		day, _ := time.Parse(time.DateOnly, date)
+		if got := Loggable(day, now); got != want {
+			t.Errorf("Loggable(%s) = %v, want %v", date, got, want)
+		}
+	}
+}
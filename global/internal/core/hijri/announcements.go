@@ -0,0 +1,69 @@
+package hijri
+
+import (
+	"sort"
+	"time"
+
+	"github.com/escalopa/prayer-bot/global/internal/domain"
+)
+
+// announcement is a regional announcement restated as the correction that
+// makes the underlying calendar begin the announced month on the announced
+// day. It governs from that day until the next announcement begins.
+type announcement struct {
+	domain.HijriAnnouncement
+	from time.Time
+	days int
+}
+
+// announced restates each announcement against convert. An announcement more
+// than two days from every month start of the calendar is ignored: it names
+// a month the calendar does not place near that day, which is a typo rather
+// than a sighting.
+func announced(convert func(time.Time) (Date, error), announcements []domain.HijriAnnouncement) []announcement {
+	var result []announcement
+	for _, published := range announcements {
+		from := civilDay(published.Start.Year(), published.Start.Month(), published.Start.Day())
+		for _, days := range []int{0, -1, 1, -2, 2} {
+			date, err := convert(from.AddDate(0, 0, days))
+			if err == nil && date == (Date{Day: 1, Month: published.Month, Year: published.Year}) {
+				result = append(result, announcement{HijriAnnouncement: published, from: from, days: days})
+				break
+			}
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool { return result[i].from.Before(result[j].from) })
+	return result
+}
+
+// governing is the latest announcement that began on or before the civil day.
+func (c corrected) governing(civil time.Time) (announcement, bool) {
+	var latest announcement
+	found := false
+	for _, candidate := range c.announced {
+		if candidate.from.After(civil) {
+			break
+		}
+		latest, found = candidate, true
+	}
+	return latest, found
+}
+
+// Announcement returns the regional announcement the profile reckons the
+// civil day of t from, so callers can say a date was announced rather than
+// calculated.
+func Announcement(profile domain.PrayerProfile, t time.Time) (domain.HijriAnnouncement, bool) {
+	calendar, err := ForProfile(profile)
+	if err != nil {
+		return domain.HijriAnnouncement{}, false
+	}
+	governing, ok := calendar.(corrected).governing(civilDay(t.Year(), t.Month(), t.Day()))
+	return governing.HijriAnnouncement, ok
+}
+
+// Plausible reports whether the announced day is within two days of the
+// month's start in the Umm al-Qura calendar. The owner's flow refuses
+// anything further off as a likely typo.
+func Plausible(announcement domain.HijriAnnouncement) bool {
+	return len(announced(ummAlQura, []domain.HijriAnnouncement{announcement})) == 1
+}
@@ -0,0 +1,65 @@
+package hijri
+
+import (
+	"testing"
+	"time"
+
+	"github.com/escalopa/prayer-bot/global/internal/domain"
+)
+
+func TestAnnouncementOverridesTheCorrectionFromItsStart(t *testing.T) {
+	// Umm al-Qura begins Ramadan 1446 on 1 March 2025; the announcement
+	// declares 2 March, which outranks the profile's own +1 correction.
+	profile := domain.PrayerProfile{
+		HijriCalendar: domain.HijriUmmAlQura, HijriAdjustment: 1, CountryCode: "GB",
+		HijriAnnouncements: []domain.HijriAnnouncement{{
+			CountryCode: "GB", Year: 1446, Month: 9,
+			Start: time.Date(2025, time.March, 2, 0, 0, 0, 0, time.UTC),
+		}},
+	}
+	calendar, err := ForProfile(profile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, check := range []struct {
+		day  int
+		want Date
+	}{
+		{1, Date{Day: 2, Month: Ramadan, Year: 1446}},
+		{2, Date{Day: 1, Month: Ramadan, Year: 1446}},
+		{30, Date{Day: 29, Month: Ramadan, Year: 1446}},
+		{31, Date{Day: 1, Month: Ramadan + 1, Year: 1446}},
+	} {
+		date, err := calendar.Date(time.Date(2025, time.March, check.day, 21, 0, 0, 0, time.UTC))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if date != check.want {
+			t.Errorf("%d March = %+v, want %+v", check.day, date, check.want)
+		}
+	}
+	if _, ok := Announcement(profile, time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC)); ok {
+		t.Fatal("1 March precedes the announcement")
+	}
+	if governing, ok := Announcement(profile, time.Date(2025, time.March, 20, 0, 0, 0, 0, time.UTC)); !ok || governing.Month != Ramadan {
+		t.Fatalf("20 March should follow the announcement, got %+v", governing)
+	}
+}
+
+func TestAnnouncementFarFromTheCalendarIsIgnored(t *testing.T) {
+	profile := domain.PrayerProfile{HijriAnnouncements: []domain.HijriAnnouncement{{
+		CountryCode: "GB", Year: 1446, Month: 10,
+		Start: time.Date(2025, time.March, 2, 0, 0, 0, 0, time.UTC),
+	}}}
+	calendar, err := ForProfile(profile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	date, err := calendar.Date(time.Date(2025, time.March, 2, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if date != (Date{Day: 2, Month: Ramadan, Year: 1446}) {
+		t.Fatalf("a Shawwal announcement in early Ramadan must be ignored: %+v", date)
+	}
+}
@@ -1,5 +1,7 @@
-// Package hijri converts local Gregorian dates to the calculated Umm al-Qura
-// calendar used by the bot.
+// Package hijri dates local days in the Hijri calendar systems a profile can
+// choose: the published Umm al-Qura table, the arithmetic tabular calendar,
+// and two astronomical calendars that begin each month with a predicted
+// crescent.
 package hijri
 
 import (
@@ -7,29 +9,105 @@ import (
 	"time"
 
 	gohijri "github.com/hablullah/go-hijri"
+
+	"github.com/escalopa/prayer-bot/global/internal/domain"
 )
 
+// Ramadan is the number of the month of fasting.
+const Ramadan = 9
+
 type Date struct {
 	Day   int
 	Month int
 	Year  int
 }
 
-// FromGregorian returns a calculated Umm al-Qura date. Adjustment is a user
-// supplied regional moon-sighting correction from -2 to +2 days. Dates outside
-// the published Umm al-Qura table fall back to the arithmetic Hijri calendar.
-func FromGregorian(date time.Time, adjustment int) (Date, error) {
+// Calendar dates local days in one Hijri calendar system.
+type Calendar interface {
+	// Date returns the Hijri date of the civil day t falls on in its own
+	// location, so callers pass times in the profile's timezone.
+	Date(t time.Time) (Date, error)
+}
+
+// New returns the calendar of system. Latitude and longitude are read only by
+// the crescent calendar, which predicts visibility from that place.
+// Adjustment is a user supplied regional moon-sighting correction from -2 to
+// +2 days, applied on top of any system.
+func New(system domain.HijriCalendar, latitude, longitude float64, adjustment int) (Calendar, error) {
+	calendar, err := newCorrected(system, latitude, longitude, adjustment)
+	if err != nil {
+		return nil, err
+	}
+	return calendar, nil
+}
+
+func newCorrected(system domain.HijriCalendar, latitude, longitude float64, adjustment int) (corrected, error) {
 	if adjustment < -2 || adjustment > 2 {
-		return Date{}, fmt.Errorf("hijri adjustment must be between -2 and 2")
+		return corrected{}, fmt.Errorf("hijri adjustment must be between -2 and 2")
+	}
+	var convert func(time.Time) (Date, error)
+	switch system.OrDefault() {
+	case domain.HijriUmmAlQura:
+		convert = ummAlQura
+	case domain.HijriTabular:
+		convert = tabular
+	case domain.HijriDiyanet:
+		convert = diyanet.date
+	case domain.HijriCrescent:
+		convert = crescentFrom(latitude, longitude).date
+	default:
+		return corrected{}, fmt.Errorf("unsupported Hijri calendar %q", system)
+	}
+	return corrected{convert: convert, days: adjustment}, nil
+}
+
+// ForProfile is the calendar the profile chose, with its correction. From
+// the first announcement for the profile's country onwards, the announcements
+// decide the correction instead.
+func ForProfile(profile domain.PrayerProfile) (Calendar, error) {
+	calendar, err := newCorrected(profile.HijriCalendar, profile.Latitude, profile.Longitude, profile.HijriAdjustment)
+	if err != nil {
+		return nil, err
 	}
-	adjusted := date.AddDate(0, 0, adjustment)
-	ummAlQura, err := gohijri.CreateUmmAlQuraDate(adjusted)
-	if err == nil {
-		return Date{Day: int(ummAlQura.Day), Month: int(ummAlQura.Month), Year: int(ummAlQura.Year)}, nil
+	calendar.announced = announced(calendar.convert, profile.HijriAnnouncements)
+	return calendar, nil
+}
+
+// corrected shifts the civil day by the moon-sighting correction before
+// converting it. Conversions take a civil day as noon UTC of the same date.
+type corrected struct {
+	convert   func(civil time.Time) (Date, error)
+	days      int
+	announced []announcement
+}
+
+func (c corrected) Date(t time.Time) (Date, error) {
+	civil := civilDay(t.Year(), t.Month(), t.Day())
+	days := c.days
+	if governing, ok := c.governing(civil); ok {
+		days = governing.days
 	}
-	arithmetic, arithmeticErr := gohijri.CreateHijriDate(adjusted, gohijri.Default)
-	if arithmeticErr != nil {
-		return Date{}, fmt.Errorf("convert Gregorian date to Hijri: %w", arithmeticErr)
+	return c.convert(civil.AddDate(0, 0, days))
+}
+
+func civilDay(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 12, 0, 0, 0, time.UTC)
+}
+
+// ummAlQura reads the published table, which covers 1937 to 2077, and falls
+// back to the tabular calendar outside it.
+func ummAlQura(civil time.Time) (Date, error) {
+	date, err := gohijri.CreateUmmAlQuraDate(civil)
+	if err != nil {
+		return tabular(civil)
+	}
+	return Date{Day: int(date.Day), Month: int(date.Month), Year: int(date.Year)}, nil
+}
+
+func tabular(civil time.Time) (Date, error) {
+	date, err := gohijri.CreateHijriDate(civil, gohijri.Default)
+	if err != nil {
+		return Date{}, fmt.Errorf("convert Gregorian date to Hijri: %w", err)
 	}
-	return Date{Day: int(arithmetic.Day), Month: int(arithmetic.Month), Year: int(arithmetic.Year)}, nil
+	return Date{Day: int(date.Day), Month: int(date.Month), Year: int(date.Year)}, nil
 }
@@ -3,10 +3,16 @@ package hijri
 import (
 	"testing"
 	"time"
+
+	"github.com/escalopa/prayer-bot/global/internal/domain"
 )
 
-func TestFromGregorianUsesUmmAlQura(t *testing.T) {
-	date, err := FromGregorian(time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC), 0)
+func TestUmmAlQuraIsTheDefaultCalendar(t *testing.T) {
+	calendar, err := New("", 0, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	date, err := calendar.Date(time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC))
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -15,9 +21,12 @@ func TestFromGregorianUsesUmmAlQura(t *testing.T) {
 	}
 }
 
-func TestFromGregorianAppliesRegionalAdjustment(t *testing.T) {
-	base := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
-	date, err := FromGregorian(base, -1)
+func TestCalendarAppliesRegionalAdjustment(t *testing.T) {
+	calendar, err := New(domain.HijriUmmAlQura, 0, 0, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	date, err := calendar.Date(time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC))
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -26,8 +35,41 @@ func TestFromGregorianAppliesRegionalAdjustment(t *testing.T) {
 	}
 }
 
-func TestFromGregorianRejectsUnsafeAdjustment(t *testing.T) {
-	if _, err := FromGregorian(time.Now(), 3); err == nil {
+func TestCalendarRejectsUnsafeAdjustmentAndUnknownSystems(t *testing.T) {
+	if _, err := New(domain.HijriUmmAlQura, 0, 0, 3); err == nil {
 		t.Fatal("expected adjustment validation error")
 	}
+	if _, err := New("julian", 0, 0, 0); err == nil {
+		t.Fatal("expected unsupported calendar error")
+	}
+}
+
+func TestCalendarDatesTheLocalCivilDay(t *testing.T) {
+	calendar, err := New(domain.HijriUmmAlQura, 0, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tokyo := time.FixedZone("JST", 9*60*60)
+	// Still 10 March in UTC, already the first of Ramadan in Tokyo.
+	date, err := calendar.Date(time.Date(2024, time.March, 11, 1, 0, 0, 0, tokyo))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if date != (Date{Day: 1, Month: Ramadan, Year: 1445}) {
+		t.Fatalf("unexpected local date: %+v", date)
+	}
+}
+
+func TestTabularCalendar(t *testing.T) {
+	calendar, err := New(domain.HijriTabular, 0, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	date, err := calendar.Date(time.Date(2024, time.March, 11, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if date.Month != Ramadan || date.Year != 1445 {
+		t.Fatalf("unexpected tabular date: %+v", date)
+	}
 }
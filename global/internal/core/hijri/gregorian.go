@@ -0,0 +1,44 @@
+package hijri
+
+import (
+	"fmt"
+	"time"
+
+	gohijri "github.com/hablullah/go-hijri"
+)
+
+// Gregorian returns the civil days, at midnight UTC, that calendar dates to
+// date. A date is found on one day except in two cases, which return two:
+// the 30th of a month the calendar ends after 29 days, answered with the 29th
+// and the first of the next month, since a local sighting may decide either;
+// and a day an announcement repeats.
+func Gregorian(calendar Calendar, date Date) ([]time.Time, error) {
+	if date.Year < 1 || date.Month < 1 || date.Month > 12 || date.Day < 1 || date.Day > 30 {
+		return nil, fmt.Errorf("invalid Hijri date %d-%02d-%02d", date.Year, date.Month, date.Day)
+	}
+	// The tabular month start is within a few days of every calendar's,
+	// corrections included, so a window around it holds the whole month.
+	start := gohijri.HijriDate{Day: 1, Month: int64(date.Month), Year: int64(date.Year)}.ToGregorian()
+	var days []time.Time
+	var last time.Time
+	for offset := -8; offset <= 38; offset++ {
+		day := time.Date(start.Year(), start.Month(), start.Day()+offset, 0, 0, 0, 0, time.UTC)
+		hijriDate, err := calendar.Date(day)
+		if err != nil {
+			return nil, err
+		}
+		if hijriDate == date {
+			days = append(days, day)
+		}
+		if hijriDate.Year == date.Year && hijriDate.Month == date.Month && hijriDate.Day == 29 {
+			last = day
+		}
+	}
+	if len(days) == 0 && date.Day == 30 && !last.IsZero() {
+		days = []time.Time{last, last.AddDate(0, 0, 1)}
+	}
+	if len(days) == 0 {
+		return nil, fmt.Errorf("the calendar has no day %d-%02d-%02d", date.Year, date.Month, date.Day)
+	}
+	return days, nil
+}
@@ -0,0 +1,50 @@
+package hijri
+
+import (
+	"testing"
+	"time"
+
+	"github.com/escalopa/prayer-bot/global/internal/domain"
+)
+
+func TestGregorianInvertsEveryCalendarWithItsCorrection(t *testing.T) {
+	for _, system := range domain.SupportedHijriCalendars() {
+		for _, adjustment := range []int{-1, 0, 2} {
+			calendar, err := New(system, 21.4, 39.8, adjustment)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for day := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC); day.Year() == 2025; day = day.AddDate(0, 0, 7) {
+				date, err := calendar.Date(day)
+				if err != nil {
+					t.Fatal(err)
+				}
+				days, err := Gregorian(calendar, date)
+				if err != nil {
+					t.Fatalf("%s%+d %+v: %v", system, adjustment, date, err)
+				}
+				if len(days) != 1 || !days[0].Equal(day) {
+					t.Fatalf("%s%+d %+v = %v, want %s", system, adjustment, date, days, day.Format(time.DateOnly))
+				}
+			}
+		}
+	}
+}
+
+func TestGregorianOffersBothDaysForAMissingThirtieth(t *testing.T) {
+	// Umm al-Qura's Ramadan 1446 ended after 29 days, on 29 March 2025.
+	calendar, err := New(domain.HijriUmmAlQura, 0, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	days, err := Gregorian(calendar, Date{Day: 30, Month: Ramadan, Year: 1446})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(days) != 2 || days[0].Format(time.DateOnly) != "2025-03-29" || days[1].Format(time.DateOnly) != "2025-03-30" {
+		t.Fatalf("unexpected candidates: %v", days)
+	}
+	if _, err := Gregorian(calendar, Date{Day: 31, Month: Ramadan, Year: 1446}); err == nil {
+		t.Fatal("a 31st must be rejected")
+	}
+}
@@ -0,0 +1,236 @@
+package hijri
+
+import (
+	"container/list"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/hablullah/go-sampa"
+
+	"github.com/escalopa/prayer-bot/global/internal/core/moon"
+)
+
+// lunarCalendar begins each month on the day after the first evening its
+// criterion sees the new crescent. Months take their number and year from
+// the tabular calendar in the middle of the lunation, which never drifts a
+// whole month away from the sky.
+type lunarCalendar struct {
+	key string
+	// sighted returns the civil day after the first evening following
+	// conjunction on which the crescent counts as seen.
+	sighted func(conjunction time.Time) (time.Time, error)
+}
+
+// maxCachedMonthStarts bounds the month start cache. An entry is a few
+// hundred bytes, and a year of one place's months is about a dozen, so the
+// cache holds the recent months of around a thousand places.
+const maxCachedMonthStarts = 16384
+
+// monthStarts caches sighted per calendar and conjunction: month starts
+// never change, and every day of a scan needs one. Places each add their own
+// entries, so the least recently used go first.
+var monthStarts = newMonthStartCache(maxCachedMonthStarts)
+
+type monthStartCache struct {
+	maxEntries int
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	order   *list.List // front is the most recently used
+}
+
+type monthStart struct {
+	key   string
+	start time.Time
+}
+
+func newMonthStartCache(maxEntries int) *monthStartCache {
+	return &monthStartCache{maxEntries: maxEntries, entries: make(map[string]*list.Element), order: list.New()}
+}
+
+func (c *monthStartCache) load(key string) (time.Time, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	element, ok := c.entries[key]
+	if !ok {
+		return time.Time{}, false
+	}
+	c.order.MoveToFront(element)
+	return element.Value.(*monthStart).start, true
+}
+
+func (c *monthStartCache) store(key string, start time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if element, ok := c.entries[key]; ok {
+		c.order.MoveToFront(element)
+		return
+	}
+	c.entries[key] = c.order.PushFront(&monthStart{key: key, start: start})
+	for c.order.Len() > c.maxEntries {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*monthStart).key)
+	}
+}
+
+func (c lunarCalendar) date(civil time.Time) (Date, error) {
+	conjunction := moon.NewMoonBefore(civil)
+	start, err := c.start(conjunction)
+	if err != nil {
+		return Date{}, err
+	}
+	if start.After(civil) {
+		// The crescent of the latest conjunction has not been seen yet, so
+		// the day still belongs to the month before.
+		if start, err = c.start(moon.NewMoonBefore(conjunction.Add(-time.Hour))); err != nil {
+			return Date{}, err
+		}
+	}
+	named, err := tabular(start.AddDate(0, 0, 14))
+	if err != nil {
+		return Date{}, err
+	}
+	return Date{Day: int(civil.Sub(start).Hours()/24) + 1, Month: named.Month, Year: named.Year}, nil
+}
+
+// start is the first day of the month of conjunction. A month never runs
+// past 30 days: when the crescent is missed for too long the month before is
+// completed, as a sighting committee would.
+func (c lunarCalendar) start(conjunction time.Time) (time.Time, error) {
+	start, err := c.cachedSighting(conjunction)
+	if err != nil {
+		return time.Time{}, err
+	}
+	previous, err := c.cachedSighting(moon.NewMoonBefore(conjunction.Add(-time.Hour)))
+	if err != nil {
+		return time.Time{}, err
+	}
+	if limit := previous.AddDate(0, 0, 30); start.After(limit) {
+		return limit, nil
+	}
+	return start, nil
+}
+
+func (c lunarCalendar) cachedSighting(conjunction time.Time) (time.Time, error) {
+	key := fmt.Sprintf("%s|%d", c.key, conjunction.Unix())
+	if start, ok := monthStarts.load(key); ok {
+		return start, nil
+	}
+	start, err := c.sighted(conjunction)
+	if err != nil {
+		return time.Time{}, err
+	}
+	monthStarts.store(key, start)
+	return start, nil
+}
+
+// meanTime is the local mean time of longitude, which keeps an evening's
+// sunset on the date it is asked for anywhere on Earth.
+func meanTime(longitude float64) *time.Location {
+	return time.FixedZone("LMT", int(longitude*240))
+}
+
+// crescentFrom is the calendar of the crescent seen from one place. Places
+// are rounded to the whole degree, well inside the criterion's own
+// uncertainty, so neighbouring profiles share their cached month starts.
+func crescentFrom(latitude, longitude float64) lunarCalendar {
+	latitude, longitude = math.Round(latitude), math.Round(longitude)
+	zone := meanTime(longitude)
+	return lunarCalendar{
+		key: fmt.Sprintf("crescent|%g|%g", latitude, longitude),
+		sighted: func(conjunction time.Time) (time.Time, error) {
+			crescent, err := moon.FirstCrescent(conjunction, latitude, longitude, zone)
+			if err != nil {
+				return time.Time{}, err
+			}
+			if crescent.Seen() {
+				return civilDay(crescent.Evening.Year(), crescent.Evening.Month(), crescent.Evening.Day()+1), nil
+			}
+			local := conjunction.In(zone)
+			return civilDay(local.Year(), local.Month(), local.Day()+moon.LastEvening+1), nil
+		},
+	}
+}
+
+// diyanet follows the criterion Turkey adopted at the 2016 Istanbul
+// congress: a month begins everywhere on the day after the crescent stands
+// at least 5° above the horizon and 8° from the sun at sunset anywhere
+// between 60°S and 60°N before midnight UTC. When the crescent is only seen
+// later in the evening over the Americas, the month still begins if the
+// conjunction came before dawn in New Zealand.
+var diyanet = lunarCalendar{
+	key: "diyanet",
+	sighted: func(conjunction time.Time) (time.Time, error) {
+		utc := conjunction.UTC()
+		for offset := 0; offset <= moon.LastEvening; offset++ {
+			evening := time.Date(utc.Year(), utc.Month(), utc.Day()+offset, 0, 0, 0, 0, time.UTC)
+			seen, err := seenOnGrid(evening, conjunction, -180, 180, true)
+			if err != nil {
+				return time.Time{}, err
+			}
+			if !seen {
+				dawn, err := newZealandDawn(evening.AddDate(0, 0, 1))
+				if err != nil {
+					return time.Time{}, err
+				}
+				if conjunction.Before(dawn) {
+					if seen, err = seenOnGrid(evening, conjunction, -170, -30, false); err != nil {
+						return time.Time{}, err
+					}
+				}
+			}
+			if seen {
+				return civilDay(evening.Year(), evening.Month(), evening.Day()+1), nil
+			}
+		}
+		return civilDay(utc.Year(), utc.Month(), utc.Day()+moon.LastEvening+1), nil
+	},
+}
+
+// seenOnGrid searches a 10° grid between the longitudes west and east for a
+// sunset that meets the Diyanet criterion, optionally only before midnight
+// UTC. Western longitudes go first, where the moon stays up longest.
+func seenOnGrid(evening, conjunction time.Time, west, east float64, beforeMidnight bool) (bool, error) {
+	midnight := evening.AddDate(0, 0, 1)
+	for longitude := west; longitude <= east; longitude += 10 {
+		zone := meanTime(longitude)
+		for latitude := -60.0; latitude <= 60; latitude += 10 {
+			place := sampa.Location{Latitude: latitude, Longitude: longitude}
+			sun, err := sampa.GetSunEvents(time.Date(evening.Year(), evening.Month(), evening.Day(), 0, 0, 0, 0, zone), place, nil)
+			if err != nil {
+				return false, fmt.Errorf("calculate sunset: %w", err)
+			}
+			sunset := sun.Sunset.DateTime
+			if sunset.IsZero() || !sunset.After(conjunction) || (beforeMidnight && !sunset.Before(midnight)) {
+				continue
+			}
+			position, err := sampa.GetMoonPosition(sunset, moon.Airless(place), nil)
+			if err != nil {
+				return false, fmt.Errorf("calculate moon position: %w", err)
+			}
+			if position.TopocentricElevationAngle >= 5 && position.Elongation >= 8 {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// newZealandDawn is true dawn, the sun 18° below the horizon, in Wellington
+// on the given date.
+func newZealandDawn(date time.Time) (time.Time, error) {
+	wellington := sampa.Location{Latitude: -41.3, Longitude: 174.8}
+	zone := meanTime(wellington.Longitude)
+	events, err := sampa.GetSunEvents(time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, zone), wellington, nil, sampa.CustomSunEvent{
+		Name:          "dawn",
+		BeforeTransit: true,
+		Elevation:     func(sampa.SunPosition) float64 { return -18 },
+	})
+	if err != nil {
+		return time.Time{}, fmt.Errorf("calculate dawn: %w", err)
+	}
+	return events.Others["dawn"].DateTime, nil
+}
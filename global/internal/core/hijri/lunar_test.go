@@ -0,0 +1,104 @@
+package hijri
+
+import (
+	"testing"
+	"time"
+
+	"github.com/escalopa/prayer-bot/global/internal/domain"
+)
+
+func TestDiyanetMatchesPublishedTurkishDates(t *testing.T) {
+	calendar, err := New(domain.HijriDiyanet, 0, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tc := range []struct {
+		day  time.Time
+		want Date
+	}{
+		{day: time.Date(2024, time.March, 11, 0, 0, 0, 0, time.UTC), want: Date{Day: 1, Month: Ramadan, Year: 1445}},
+		{day: time.Date(2024, time.April, 10, 0, 0, 0, 0, time.UTC), want: Date{Day: 1, Month: 10, Year: 1445}},
+		{day: time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC), want: Date{Day: 1, Month: Ramadan, Year: 1446}},
+		// Seen only over the Americas after midnight UTC on 29 March.
+		{day: time.Date(2025, time.March, 30, 0, 0, 0, 0, time.UTC), want: Date{Day: 1, Month: 10, Year: 1446}},
+	} {
+		got, err := calendar.Date(tc.day)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != tc.want {
+			t.Fatalf("%s: got %+v, want %+v", tc.day.Format(time.DateOnly), got, tc.want)
+		}
+	}
+}
+
+func TestCrescentDependsOnWhereItIsWatched(t *testing.T) {
+	day := time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC)
+	mecca, err := New(domain.HijriCrescent, 21.42, 39.83, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	newYork, err := New(domain.HijriCrescent, 40.71, -74.01, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	east, err := mecca.Date(day)
+	if err != nil {
+		t.Fatal(err)
+	}
+	west, err := newYork.Date(day)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The young crescent of 28 February was only visible from the Americas.
+	if east != (Date{Day: 30, Month: 8, Year: 1446}) || west != (Date{Day: 1, Month: Ramadan, Year: 1446}) {
+		t.Fatalf("unexpected crescent dates: Mecca %+v, New York %+v", east, west)
+	}
+}
+
+func TestLunarCalendarsCountDaysWithoutGaps(t *testing.T) {
+	for _, system := range []domain.HijriCalendar{domain.HijriDiyanet, domain.HijriCrescent} {
+		calendar, err := New(system, 59.94, 30.31, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		day := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+		previous, err := calendar.Date(day)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for range 365 {
+			day = day.AddDate(0, 0, 1)
+			current, err := calendar.Date(day)
+			if err != nil {
+				t.Fatal(err)
+			}
+			continues := current.Day == previous.Day+1 && current.Month == previous.Month
+			starts := current.Day == 1 && previous.Day >= 29 && current.Month == previous.Month%12+1
+			if !continues && !starts || current.Day > 30 {
+				t.Fatalf("%s: %s follows %+v with %+v", system, day.Format(time.DateOnly), previous, current)
+			}
+			previous = current
+		}
+	}
+}
+
+func TestMonthStartCacheEvictsTheLeastRecentlyUsed(t *testing.T) {
+	cache := newMonthStartCache(2)
+	first := time.Date(2026, time.February, 18, 0, 0, 0, 0, time.UTC)
+	cache.store("a", first)
+	cache.store("b", first.AddDate(0, 1, 0))
+	if start, ok := cache.load("a"); !ok || !start.Equal(first) {
+		t.Fatalf("a = %s, %v", start, ok)
+	}
+	cache.store("c", first.AddDate(0, 2, 0))
+	if _, ok := cache.load("b"); ok {
+		t.Fatal("the least recently used start must be evicted")
+	}
+	if _, ok := cache.load("a"); !ok {
+		t.Fatal("a start read since must stay")
+	}
+	if cache.order.Len() != 2 || len(cache.entries) != 2 {
+		t.Fatalf("cache holds %d entries, want 2", cache.order.Len())
+	}
+}
@@ -57,6 +57,10 @@ func (l Locale) Prayer(prayer domain.Prayer) string {
 	return locales["en"].Prayers[prayer]
 }
 
+func (l Locale) MakruhWindow(window domain.MakruhWindow) string {
+	return l.Message("makruh_" + string(window))
+}
+
 func (l Locale) Method(method domain.Method) string {
 	if value := l.Methods[method]; value != "" {
 		return value
@@ -1,17 +1,36 @@
 package i18n
 
-import "github.com/escalopa/prayer-bot/global/internal/domain"
+import (
+	"maps"
+
+	"github.com/escalopa/prayer-bot/global/internal/domain"
+)
 
 var methodNames = map[domain.Method]string{
-	domain.MethodMWL:       "Muslim World League",
-	domain.MethodEgyptian:  "Egyptian General Authority",
-	domain.MethodUmmAlQura: "Umm al-Qura University",
-	domain.MethodKarachi:   "University of Karachi",
-	domain.MethodISNA:      "ISNA",
-	domain.MethodDiyanet:   "Diyanet",
-	domain.MethodKemenag:   "Kemenag",
-	domain.MethodMUIS:      "MUIS",
-	domain.MethodJAKIM:     "JAKIM",
+	domain.MethodMWL:          "Muslim World League",
+	domain.MethodEgyptian:     "Egyptian General Authority",
+	domain.MethodUmmAlQura:    "Umm al-Qura University",
+	domain.MethodKarachi:      "University of Karachi",
+	domain.MethodISNA:         "ISNA",
+	domain.MethodDiyanet:      "Diyanet",
+	domain.MethodKemenag:      "Kemenag",
+	domain.MethodMUIS:         "MUIS",
+	domain.MethodJAKIM:        "JAKIM",
+	domain.MethodTehran:       "Institute of Geophysics, Tehran",
+	domain.MethodGulf:         "Gulf region",
+	domain.MethodKuwait:       "Kuwait",
+	domain.MethodQatar:        "Qatar",
+	domain.MethodUOIF:         "UOIF (France)",
+	domain.MethodRussia:       "Spiritual Administration of Muslims of Russia",
+	domain.MethodMoonsighting: "Moonsighting Committee",
+}
+
+// localizedMethods layers a locale's translated method names over the shared
+// methodNames, so each locale owns its map and untranslated names stay in English.
+func localizedMethods(names map[domain.Method]string) map[domain.Method]string {
+	result := maps.Clone(methodNames)
+	maps.Copy(result, names)
+	return result
 }
 
 var locales = map[string]Locale{
@@ -65,7 +84,7 @@ var locales = map[string]Locale{
 			"reminder_tomorrow": "Tomorrow's <b>%s</b> is at <code>%s</code>.",
 		},
 		Prayers:      map[domain.Prayer]string{domain.PrayerFajr: "Fajr", domain.PrayerSunrise: "Sunrise", domain.PrayerDhuhr: "Dhuhr", domain.PrayerAsr: "Asr", domain.PrayerMaghrib: "Maghrib", domain.PrayerIsha: "Isha"},
-		Methods:      methodNames,
+		Methods:      localizedMethods(nil),
 		Madhabs:      map[domain.Madhab]string{domain.MadhabShafii: "Shafi'i / Maliki / Hanbali", domain.MadhabHanafi: "Hanafi"},
 		HighLatitude: map[domain.HighLatitudeRule]string{domain.HighLatitudeAngleBased: "Angle based", domain.HighLatitudeMiddleNight: "Middle of the night", domain.HighLatitudeSeventhNight: "One seventh of the night"},
 		Months:       []string{"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"},
@@ -112,7 +131,7 @@ var locales = map[string]Locale{
 			"reminder_tomorrow": "موعد <b>%s</b> غدًا عند <code>%s</code>.",
 		},
 		Prayers:      map[domain.Prayer]string{domain.PrayerFajr: "الفجر", domain.PrayerSunrise: "الشروق", domain.PrayerDhuhr: "الظهر", domain.PrayerAsr: "العصر", domain.PrayerMaghrib: "المغرب", domain.PrayerIsha: "العشاء"},
-		Methods:      methodNames,
+		Methods:      localizedMethods(map[domain.Method]string{domain.MethodTehran: "معهد الجيوفيزياء، طهران", domain.MethodGulf: "منطقة الخليج", domain.MethodKuwait: "الكويت", domain.MethodQatar: "قطر", domain.MethodUOIF: "اتحاد المنظمات الإسلامية في فرنسا", domain.MethodRussia: "الإدارة الدينية لمسلمي روسيا", domain.MethodMoonsighting: "لجنة رؤية الهلال"}),
 		Madhabs:      map[domain.Madhab]string{domain.MadhabShafii: "شافعي / مالكي / حنبلي", domain.MadhabHanafi: "حنفي"},
 		HighLatitude: map[domain.HighLatitudeRule]string{domain.HighLatitudeAngleBased: "حسب الزاوية", domain.HighLatitudeMiddleNight: "منتصف الليل", domain.HighLatitudeSeventhNight: "سبع الليل"},
 		Months:       []string{"يناير", "فبراير", "مارس", "أبريل", "مايو", "يونيو", "يوليو", "أغسطس", "سبتمبر", "أكتوبر", "نوفمبر", "ديسمبر"},
@@ -137,7 +156,7 @@ var locales = map[string]Locale{
 			"privacy":     "<b>Privacidad</b> 🔒\n\nTu ubicación solo se usa para resolver la zona horaria y calcular las oraciones. Se guardan coordenadas redondeadas a tres decimales, zona horaria, Google Place ID y ajustes. No se guarda la dirección formateada de Google ni la actualización completa de Telegram. Usa /delete_me para borrar los datos de este chat.",
 			"reminder_at": "Es hora de <b>%s</b> 🕌", "reminder_before": "<b>%s</b> será dentro de %d minutos, a las <code>%s</code>.", "reminder_tomorrow": "Mañana <b>%s</b> será a las <code>%s</code>.",
 		},
-		Prayers: map[domain.Prayer]string{domain.PrayerFajr: "Fajr", domain.PrayerSunrise: "Amanecer", domain.PrayerDhuhr: "Dhuhr", domain.PrayerAsr: "Asr", domain.PrayerMaghrib: "Maghrib", domain.PrayerIsha: "Isha"}, Methods: methodNames,
+		Prayers: map[domain.Prayer]string{domain.PrayerFajr: "Fajr", domain.PrayerSunrise: "Amanecer", domain.PrayerDhuhr: "Dhuhr", domain.PrayerAsr: "Asr", domain.PrayerMaghrib: "Maghrib", domain.PrayerIsha: "Isha"}, Methods: localizedMethods(map[domain.Method]string{domain.MethodTehran: "Instituto de Geofísica, Teherán", domain.MethodGulf: "Región del Golfo", domain.MethodKuwait: "Kuwait", domain.MethodQatar: "Catar", domain.MethodUOIF: "UOIF (Francia)", domain.MethodRussia: "Administración Espiritual de los Musulmanes de Rusia", domain.MethodMoonsighting: "Comité de Avistamiento Lunar"}),
 		Madhabs: map[domain.Madhab]string{domain.MadhabShafii: "Shafi'i / Maliki / Hanbali", domain.MadhabHanafi: "Hanafi"}, HighLatitude: map[domain.HighLatitudeRule]string{domain.HighLatitudeAngleBased: "Basada en ángulo", domain.HighLatitudeMiddleNight: "Mitad de la noche", domain.HighLatitudeSeventhNight: "Un séptimo de la noche"},
 		Months: []string{"enero", "febrero", "marzo", "abril", "mayo", "junio", "julio", "agosto", "septiembre", "octubre", "noviembre", "diciembre"},
 	},
@@ -146,34 +165,34 @@ var locales = map[string]Locale{
 		Commands: map[string]string{"location": "Définir ou changer le lieu", "city": "Définir le lieu par nom de ville", "today": "Voir les horaires d'aujourd'hui", "tomorrow": "Voir les horaires de demain", "next": "Voir la prochaine prière", "settings": "Ouvrir les réglages de calcul", "remind": "Configurer les rappels", "language": "Choisir la langue du bot", "privacy": "Voir les données et les supprimer", "help": "Afficher l'aide et le menu"},
 		Buttons:  map[string]string{ActionToday: "🕌 Aujourd'hui", ActionTomorrow: "🌅 Demain", ActionNext: "⏳ Prochaine prière", ActionLocation: "📍 Lieu", ActionSettings: "⚙️ Réglages", ActionReminders: "🔔 Rappels", ActionLanguage: "🌐 Langue", ActionHelp: "ℹ️ Aide", "share_location": "📍 Partager ma position", "method": "🧭 Méthode de calcul", "madhab": "🕌 Madhab (Asr)", "highlat": "🌙 Hautes latitudes", "adjustments": "⏱ Ajuster les horaires", "back": "‹ Retour", "close": "✅ Terminé", "enable": "🔔 Activer", "disable": "🔕 Désactiver", "main_menu": "🏠 Menu principal"},
 		Text:     map[string]string{"welcome": "<b>Les horaires de prière, où que vous soyez</b> 🌍\n\n📍 Partagez votre position une fois et je calculerai des horaires locaux précis.\n\n⚙️ Choisissez ensuite la méthode et le madhab, ajustez chaque prière et activez les rappels.", "location_prompt": "<b>Définissez votre lieu de prière</b> 📍\n\nTouchez le bouton et partagez votre position. Je ne conserve que des coordonnées arrondies à environ un pâté de maisons.", "location_group": "Telegram ne propose le bouton de localisation que dans les discussions privées. Un administrateur du groupe peut joindre un lieu à un message ici ou le définir par son nom : <code>/city Paris</code>.", "location_set": "<b>Lieu enregistré</b> ✅\n%s · %s\nMéthode : %s\n\nTouchez <b>🕌 Aujourd'hui</b> pour voir les horaires.", "invalid_location": "Ce lieu n'est pas valide. Partagez-le à nouveau.", "need_location": "J'ai d'abord besoin de votre position. Touchez <b>📍 Lieu</b>.", "today_title": "Horaires de prière d'aujourd'hui", "tomorrow_title": "Horaires de prière de demain", "next_prayer": "<b>Prochaine prière</b> ⏳\n%s à <code>%s</code> · %s", "settings_title": "<b>Réglages de prière</b> ⚙️", "timezone": "Fuseau horaire", "method": "Méthode", "madhab": "Madhab", "highlat": "Règle de haute latitude", "adjustments": "Ajustements", "choose_method": "<b>Choisissez une méthode de calcul</b> 🧭\nLa méthode actuelle est marquée ✓.", "choose_madhab": "<b>Choisissez le madhab pour Asr</b> 🕌\nShafi'i est aussi utilisé par les écoles Maliki et Hanbali.", "choose_highlat": "<b>Choisissez une règle de haute latitude</b> 🌙\nElle contrôle Fajr et Isha lorsque le crépuscule ne disparaît pas normalement.", "choose_adjustment": "<b>Ajustez les horaires</b> ⏱\nChoisissez une prière puis ajoutez ou retirez des minutes.", "adjust_prayer": "<b>Ajustement de %s</b> ⏱\nValeur actuelle : <b>%+d minutes</b>\nChoisissez une modification :", "method_saved": "Méthode changée pour %s.", "madhab_saved": "Madhab changé pour %s.", "highlat_saved": "Règle de haute latitude changée pour %s.", "adjust_saved": "L'ajustement de %s est maintenant de %+d minutes.", "reminders_title": "<b>Rappels de prière</b> 🔔", "reminders_on": "État : <b>activés</b> ✅", "reminders_off": "État : <b>désactivés</b>", "reminders_enabled": "Rappels activés 🔔", "reminders_disabled": "Rappels désactivés 🔕", "choose_language": "<b>Choisissez votre langue</b> 🌐", "language_saved": "Langue changée en Français ✅", "admin_only": "Seul un administrateur peut modifier les réglages du groupe.", "unknown": "Je n'ai pas compris. Utilisez le menu ou touchez <b>ℹ️ Aide</b>.", "deleted": "Votre lieu, vos réglages, rappels et historique ont été supprimés.", "help": "<b>Comment utiliser le bot</b> ℹ️\n\n📍 Partagez un lieu une fois.\n🕌 Utilisez le menu pour aujourd'hui, demain ou la prochaine prière.\n⚙️ Personnalisez méthode, madhab, hautes latitudes et corrections.\n🔔 Activez ou désactivez les rappels.\n🌐 Changez de langue à tout moment.\n\nLes commandes restent disponibles dans le menu Telegram.", "privacy": "<b>Confidentialité</b> 🔒\n\nVotre position sert uniquement à déterminer le fuseau horaire et calculer les prières. Le bot conserve des coordonnées arrondies à trois décimales, le fuseau, un Google Place ID et vos réglages. Il ne conserve ni l'adresse formatée par Google ni la mise à jour Telegram complète. Utilisez /delete_me pour supprimer les données de ce chat.", "reminder_at": "C'est l'heure de <b>%s</b> 🕌", "reminder_before": "<b>%s</b> est dans %d minutes, à <code>%s</code>.", "reminder_tomorrow": "Demain, <b>%s</b> sera à <code>%s</code>."},
-		Prayers:  map[domain.Prayer]string{domain.PrayerFajr: "Fajr", domain.PrayerSunrise: "Lever du soleil", domain.PrayerDhuhr: "Dhuhr", domain.PrayerAsr: "Asr", domain.PrayerMaghrib: "Maghrib", domain.PrayerIsha: "Isha"}, Methods: methodNames, Madhabs: map[domain.Madhab]string{domain.MadhabShafii: "Shafi'i / Maliki / Hanbali", domain.MadhabHanafi: "Hanafi"}, HighLatitude: map[domain.HighLatitudeRule]string{domain.HighLatitudeAngleBased: "Selon l'angle", domain.HighLatitudeMiddleNight: "Milieu de la nuit", domain.HighLatitudeSeventhNight: "Un septième de la nuit"}, Months: []string{"janvier", "février", "mars", "avril", "mai", "juin", "juillet", "août", "septembre", "octobre", "novembre", "décembre"},
+		Prayers:  map[domain.Prayer]string{domain.PrayerFajr: "Fajr", domain.PrayerSunrise: "Lever du soleil", domain.PrayerDhuhr: "Dhuhr", domain.PrayerAsr: "Asr", domain.PrayerMaghrib: "Maghrib", domain.PrayerIsha: "Isha"}, Methods: localizedMethods(map[domain.Method]string{domain.MethodTehran: "Institut de géophysique, Téhéran", domain.MethodGulf: "Région du Golfe", domain.MethodKuwait: "Koweït", domain.MethodQatar: "Qatar", domain.MethodUOIF: "UOIF (France)", domain.MethodRussia: "Administration spirituelle des musulmans de Russie", domain.MethodMoonsighting: "Comité d'observation lunaire"}), Madhabs: map[domain.Madhab]string{domain.MadhabShafii: "Shafi'i / Maliki / Hanbali", domain.MadhabHanafi: "Hanafi"}, HighLatitude: map[domain.HighLatitudeRule]string{domain.HighLatitudeAngleBased: "Selon l'angle", domain.HighLatitudeMiddleNight: "Milieu de la nuit", domain.HighLatitudeSeventhNight: "Un septième de la nuit"}, Months: []string{"janvier", "février", "mars", "avril", "mai", "juin", "juillet", "août", "septembre", "octobre", "novembre", "décembre"},
 	},
 	"ru": {
 		Code: "ru", NativeName: "Русский", BotName: "Время намаза по миру", ShortDescription: "Точное местное время намаза, где бы вы ни были.", Description: "Поделитесь геопозицией, чтобы узнать время намаза в любой точке мира. Выберите метод расчёта, мазхаб и правило высоких широт, настройте поправки и напоминания.",
 		Commands: map[string]string{"location": "Установить или изменить местоположение", "city": "Задать местоположение по названию города", "today": "Показать время намаза сегодня", "tomorrow": "Показать время намаза завтра", "next": "Показать следующий намаз", "settings": "Открыть настройки расчёта", "remind": "Настроить напоминания", "language": "Выбрать язык бота", "privacy": "Данные и их удаление", "help": "Показать помощь и главное меню"},
 		Buttons:  map[string]string{ActionToday: "🕌 Сегодня", ActionTomorrow: "🌅 Завтра", ActionNext: "⏳ Следующий намаз", ActionLocation: "📍 Местоположение", ActionSettings: "⚙️ Настройки", ActionReminders: "🔔 Напоминания", ActionLanguage: "🌐 Язык", ActionHelp: "ℹ️ Помощь", "share_location": "📍 Поделиться геопозицией", "method": "🧭 Метод расчёта", "madhab": "🕌 Мазхаб (Аср)", "highlat": "🌙 Высокие широты", "adjustments": "⏱ Поправки времени", "back": "‹ Назад", "close": "✅ Готово", "enable": "🔔 Включить", "disable": "🔕 Выключить", "main_menu": "🏠 Главное меню"},
 		Text:     map[string]string{"welcome": "<b>Время намаза, где бы вы ни были</b> 🌍\n\n📍 Один раз поделитесь геопозицией, и я рассчитаю точное местное время намаза.\n\n⚙️ Затем выберите метод и мазхаб, настройте каждый намаз и включите напоминания.", "location_prompt": "<b>Укажите место для расчёта</b> 📍\n\nНажмите кнопку ниже и поделитесь геопозицией. Я храню только координаты, округлённые примерно до городского квартала.", "location_group": "Telegram даёт кнопку отправки геопозиции только в личных чатах. Администратор группы может прикрепить геопозицию к сообщению здесь или задать её по названию: <code>/city Казань</code>.", "location_set": "<b>Местоположение сохранено</b> ✅\n%s · %s\nМетод: %s\n\nНажмите <b>🕌 Сегодня</b>, чтобы увидеть время намаза.", "invalid_location": "Некорректная геопозиция. Отправьте её ещё раз.", "need_location": "Сначала нужна геопозиция. Нажмите <b>📍 Местоположение</b>.", "today_title": "Время намаза сегодня", "tomorrow_title": "Время намаза завтра", "next_prayer": "<b>Следующий намаз</b> ⏳\n%s в <code>%s</code> · %s", "settings_title": "<b>Настройки намаза</b> ⚙️", "timezone": "Часовой пояс", "method": "Метод", "madhab": "Мазхаб", "highlat": "Правило высоких широт", "adjustments": "Поправки", "choose_method": "<b>Выберите метод расчёта</b> 🧭\nТекущий метод отмечен ✓.", "choose_madhab": "<b>Выберите мазхаб для Асра</b> 🕌\nВремя Шафии также используется в маликитском и ханбалитском мазхабах.", "choose_highlat": "<b>Выберите правило высоких широт</b> 🌙\nОно определяет Фаджр и Иша, когда сумерки не исчезают обычным образом.", "choose_adjustment": "<b>Настройте время намаза</b> ⏱\nВыберите намаз, затем прибавьте или вычтите минуты.", "adjust_prayer": "<b>Поправка для %s</b> ⏱\nСейчас: <b>%+d мин.</b>\nВыберите изменение:", "method_saved": "Метод расчёта изменён на %s.", "madhab_saved": "Мазхаб изменён на %s.", "highlat_saved": "Правило высоких широт изменено на %s.", "adjust_saved": "Поправка для %s теперь %+d мин.", "reminders_title": "<b>Напоминания о намазе</b> 🔔", "reminders_on": "Статус: <b>включены</b> ✅", "reminders_off": "Статус: <b>выключены</b>", "reminders_enabled": "Напоминания включены 🔔", "reminders_disabled": "Напоминания выключены 🔕", "choose_language": "<b>Выберите язык</b> 🌐", "language_saved": "Язык изменён на Русский ✅", "admin_only": "Изменять настройки группы может только администратор.", "unknown": "Я не понял сообщение. Используйте меню или нажмите <b>ℹ️ Помощь</b>.", "deleted": "Геопозиция, настройки, напоминания и история отправки удалены.", "help": "<b>Как пользоваться ботом</b> ℹ️\n\n📍 Один раз поделитесь геопозицией.\n🕌 Выбирайте сегодня, завтра или следующий намаз в меню.\n⚙️ Настройте метод, мазхаб, высокие широты и поправки.\n🔔 Включайте и выключайте напоминания.\n🌐 Меняйте язык в любое время.\n\nКоманды также доступны в меню Telegram.", "privacy": "<b>Конфиденциальность</b> 🔒\n\nГеопозиция используется только для определения часового пояса и расчёта намаза. Хранятся координаты с точностью до трёх знаков, часовой пояс, Google Place ID и настройки. Форматированный адрес Google и полное обновление Telegram не сохраняются. Команда /delete_me удалит данные этого чата.", "reminder_at": "Время намаза <b>%s</b> 🕌", "reminder_before": "До <b>%s</b> %d мин., начало в <code>%s</code>.", "reminder_tomorrow": "Завтра <b>%s</b> в <code>%s</code>."},
-		Prayers:  map[domain.Prayer]string{domain.PrayerFajr: "Фаджр", domain.PrayerSunrise: "Восход", domain.PrayerDhuhr: "Зухр", domain.PrayerAsr: "Аср", domain.PrayerMaghrib: "Магриб", domain.PrayerIsha: "Иша"}, Methods: methodNames, Madhabs: map[domain.Madhab]string{domain.MadhabShafii: "Шафии / Малики / Ханбали", domain.MadhabHanafi: "Ханафи"}, HighLatitude: map[domain.HighLatitudeRule]string{domain.HighLatitudeAngleBased: "По углу", domain.HighLatitudeMiddleNight: "Середина ночи", domain.HighLatitudeSeventhNight: "Одна седьмая ночи"}, Months: []string{"января", "февраля", "марта", "апреля", "мая", "июня", "июля", "августа", "сентября", "октября", "ноября", "декабря"},
+		Prayers:  map[domain.Prayer]string{domain.PrayerFajr: "Фаджр", domain.PrayerSunrise: "Восход", domain.PrayerDhuhr: "Зухр", domain.PrayerAsr: "Аср", domain.PrayerMaghrib: "Магриб", domain.PrayerIsha: "Иша"}, Methods: localizedMethods(map[domain.Method]string{domain.MethodTehran: "Институт геофизики, Тегеран", domain.MethodGulf: "Страны Персидского залива", domain.MethodKuwait: "Кувейт", domain.MethodQatar: "Катар", domain.MethodUOIF: "UOIF (Франция)", domain.MethodRussia: "ДУМ России", domain.MethodMoonsighting: "Комитет по наблюдению луны"}), Madhabs: map[domain.Madhab]string{domain.MadhabShafii: "Шафии / Малики / Ханбали", domain.MadhabHanafi: "Ханафи"}, HighLatitude: map[domain.HighLatitudeRule]string{domain.HighLatitudeAngleBased: "По углу", domain.HighLatitudeMiddleNight: "Середина ночи", domain.HighLatitudeSeventhNight: "Одна седьмая ночи"}, Months: []string{"января", "февраля", "марта", "апреля", "мая", "июня", "июля", "августа", "сентября", "октября", "ноября", "декабря"},
 	},
 	"tr": {
 		Code: "tr", NativeName: "Türkçe", BotName: "Dünya Namaz Vakitleri", ShortDescription: "Nerede olursanız olun doğru yerel namaz vakitleri.", Description: "Dünyanın her yerinde günlük namaz vakitlerini almak için konum paylaşın. Hesaplama yöntemi, mezhep ve yüksek enlem kuralını seçin; vakitleri ayarlayın ve hatırlatıcıları açın.",
 		Commands: map[string]string{"location": "Namaz konumunu ayarla veya değiştir", "city": "Şehir adıyla konum belirle", "today": "Bugünün namaz vakitlerini göster", "tomorrow": "Yarının namaz vakitlerini göster", "next": "Sonraki namazı göster", "settings": "Hesaplama ayarlarını aç", "remind": "Namaz hatırlatıcılarını ayarla", "language": "Bot dilini seç", "privacy": "Saklanan verileri gör ve sil", "help": "Yardımı ve ana menüyü göster"},
 		Buttons:  map[string]string{ActionToday: "🕌 Bugün", ActionTomorrow: "🌅 Yarın", ActionNext: "⏳ Sonraki namaz", ActionLocation: "📍 Konum", ActionSettings: "⚙️ Ayarlar", ActionReminders: "🔔 Hatırlatıcılar", ActionLanguage: "🌐 Dil", ActionHelp: "ℹ️ Yardım", "share_location": "📍 Konumumu paylaş", "method": "🧭 Hesaplama yöntemi", "madhab": "🕌 Mezhep (İkindi)", "highlat": "🌙 Yüksek enlemler", "adjustments": "⏱ Vakit ayarları", "back": "‹ Geri", "close": "✅ Tamam", "enable": "🔔 Aç", "disable": "🔕 Kapat", "main_menu": "🏠 Ana menü"},
 		Text:     map[string]string{"welcome": "<b>Nerede olursanız olun namaz vakitleri</b> 🌍\n\n📍 Konumunuzu bir kez paylaşın, doğru yerel namaz vakitlerini hesaplayayım.\n\n⚙️ Ardından yöntemi ve mezhebi seçin, her vakti ayarlayın ve hatırlatıcıları açın.", "location_prompt": "<b>Namaz konumunuzu belirleyin</b> 📍\n\nAşağıdaki düğmeye dokunup konumunuzu paylaşın. Yalnızca yaklaşık bir şehir bloğuna yuvarlanmış koordinatları saklarım.", "location_group": "Telegram konum paylaşma düğmesini yalnızca özel sohbetlerde sunar. Bir grup yöneticisi buradaki mesaja konum ekleyebilir veya adla belirleyebilir: <code>/city İstanbul</code>.", "location_set": "<b>Konum kaydedildi</b> ✅\n%s · %s\nYöntem: %s\n\nVakitleri görmek için <b>🕌 Bugün</b> düğmesine dokunun.", "invalid_location": "Bu konum geçersiz. Lütfen tekrar paylaşın.", "need_location": "Önce konumunuza ihtiyacım var. <b>📍 Konum</b> düğmesine dokunun.", "today_title": "Bugünün namaz vakitleri", "tomorrow_title": "Yarının namaz vakitleri", "next_prayer": "<b>Sonraki namaz</b> ⏳\n%s <code>%s</code> · %s", "settings_title": "<b>Namaz ayarları</b> ⚙️", "timezone": "Saat dilimi", "method": "Yöntem", "madhab": "Mezhep", "highlat": "Yüksek enlem kuralı", "adjustments": "Ayarlar", "choose_method": "<b>Hesaplama yöntemi seçin</b> 🧭\nGeçerli yöntem ✓ ile işaretlidir.", "choose_madhab": "<b>İkindi mezhebini seçin</b> 🕌\nŞafii seçeneği Maliki ve Hanbeli mezheplerinde de kullanılır.", "choose_highlat": "<b>Yüksek enlem kuralını seçin</b> 🌙\nAlacakaranlığın normal biçimde bitmediği yerlerde İmsak ve Yatsıyı belirler.", "choose_adjustment": "<b>Namaz vakitlerini ayarlayın</b> ⏱\nBir namaz seçip dakika ekleyin veya çıkarın.", "adjust_prayer": "<b>%s ayarı</b> ⏱\nGeçerli değer: <b>%+d dakika</b>\nBir değişiklik seçin:", "method_saved": "Hesaplama yöntemi %s olarak değiştirildi.", "madhab_saved": "Mezhep %s olarak değiştirildi.", "highlat_saved": "Yüksek enlem kuralı %s olarak değiştirildi.", "adjust_saved": "%s ayarı artık %+d dakika.", "reminders_title": "<b>Namaz hatırlatıcıları</b> 🔔", "reminders_on": "Durum: <b>açık</b> ✅", "reminders_off": "Durum: <b>kapalı</b>", "reminders_enabled": "Namaz hatırlatıcıları açıldı 🔔", "reminders_disabled": "Namaz hatırlatıcıları kapatıldı 🔕", "choose_language": "<b>Dilinizi seçin</b> 🌐", "language_saved": "Dil Türkçe olarak değiştirildi ✅", "admin_only": "Grup ayarlarını yalnızca bir yönetici değiştirebilir.", "unknown": "Bunu anlayamadım. Menüyü kullanın veya <b>ℹ️ Yardım</b> düğmesine dokunun.", "deleted": "Konumunuz, ayarlarınız, hatırlatıcılarınız ve gönderim geçmişiniz silindi.", "help": "<b>Bot nasıl kullanılır?</b> ℹ️\n\n📍 Konumunuzu bir kez paylaşın.\n🕌 Bugün, yarın veya sonraki namaz için menüyü kullanın.\n⚙️ Yöntemi, mezhebi, yüksek enlemleri ve düzeltmeleri özelleştirin.\n🔔 Hatırlatıcıları açın veya kapatın.\n🌐 Dili istediğiniz zaman değiştirin.\n\nKomutlar Telegram menüsünde de kullanılabilir.", "privacy": "<b>Gizlilik</b> 🔒\n\nKonumunuz yalnızca saat dilimini bulmak ve namaz vakitlerini hesaplamak için kullanılır. Üç ondalığa yuvarlanmış koordinatlar, saat dilimi, Google Place ID ve ayarlarınız saklanır. Google'ın biçimlendirilmiş adresi ve Telegram güncellemesinin tamamı saklanmaz. Bu sohbetin verilerini /delete_me ile silebilirsiniz.", "reminder_at": "<b>%s</b> vakti 🕌", "reminder_before": "<b>%s</b> vaktine %d dakika kaldı; saat <code>%s</code>.", "reminder_tomorrow": "Yarın <b>%s</b> saat <code>%s</code>."},
-		Prayers:  map[domain.Prayer]string{domain.PrayerFajr: "İmsak", domain.PrayerSunrise: "Güneş", domain.PrayerDhuhr: "Öğle", domain.PrayerAsr: "İkindi", domain.PrayerMaghrib: "Akşam", domain.PrayerIsha: "Yatsı"}, Methods: methodNames, Madhabs: map[domain.Madhab]string{domain.MadhabShafii: "Şafii / Maliki / Hanbeli", domain.MadhabHanafi: "Hanefi"}, HighLatitude: map[domain.HighLatitudeRule]string{domain.HighLatitudeAngleBased: "Açı temelli", domain.HighLatitudeMiddleNight: "Gecenin ortası", domain.HighLatitudeSeventhNight: "Gecenin yedide biri"}, Months: []string{"Ocak", "Şubat", "Mart", "Nisan", "Mayıs", "Haziran", "Temmuz", "Ağustos", "Eylül", "Ekim", "Kasım", "Aralık"},
+		Prayers:  map[domain.Prayer]string{domain.PrayerFajr: "İmsak", domain.PrayerSunrise: "Güneş", domain.PrayerDhuhr: "Öğle", domain.PrayerAsr: "İkindi", domain.PrayerMaghrib: "Akşam", domain.PrayerIsha: "Yatsı"}, Methods: localizedMethods(map[domain.Method]string{domain.MethodTehran: "Tahran Jeofizik Enstitüsü", domain.MethodGulf: "Körfez bölgesi", domain.MethodKuwait: "Kuveyt", domain.MethodQatar: "Katar", domain.MethodUOIF: "UOIF (Fransa)", domain.MethodRussia: "Rusya Müslümanları Dini İdaresi", domain.MethodMoonsighting: "Hilal Gözlem Komitesi"}), Madhabs: map[domain.Madhab]string{domain.MadhabShafii: "Şafii / Maliki / Hanbeli", domain.MadhabHanafi: "Hanefi"}, HighLatitude: map[domain.HighLatitudeRule]string{domain.HighLatitudeAngleBased: "Açı temelli", domain.HighLatitudeMiddleNight: "Gecenin ortası", domain.HighLatitudeSeventhNight: "Gecenin yedide biri"}, Months: []string{"Ocak", "Şubat", "Mart", "Nisan", "Mayıs", "Haziran", "Temmuz", "Ağustos", "Eylül", "Ekim", "Kasım", "Aralık"},
 	},
 	"uz": {
 		Code: "uz", NativeName: "O‘zbekcha", BotName: "Jahon namoz vaqtlari", ShortDescription: "Qayerda bo‘lsangiz ham aniq mahalliy namoz vaqtlari.", Description: "Dunyoning istalgan joyida kunlik namoz vaqtlarini olish uchun joylashuvni yuboring. Hisoblash usuli, mazhab va yuqori kenglik qoidasini tanlang, vaqtlarni sozlang va eslatmalarni yoqing.",
 		Commands: map[string]string{"location": "Joylashuvni o‘rnatish yoki almashtirish", "city": "Joylashuvni shahar nomi bilan belgilash", "today": "Bugungi namoz vaqtlarini ko‘rsatish", "tomorrow": "Ertangi namoz vaqtlarini ko‘rsatish", "next": "Keyingi namozni ko‘rsatish", "settings": "Hisoblash sozlamalarini ochish", "remind": "Namoz eslatmalarini sozlash", "language": "Bot tilini tanlash", "privacy": "Saqlangan ma’lumotlar va o‘chirish", "help": "Yordam va bosh menyuni ko‘rsatish"},
 		Buttons:  map[string]string{ActionToday: "🕌 Bugun", ActionTomorrow: "🌅 Ertaga", ActionNext: "⏳ Keyingi namoz", ActionLocation: "📍 Joylashuv", ActionSettings: "⚙️ Sozlamalar", ActionReminders: "🔔 Eslatmalar", ActionLanguage: "🌐 Til", ActionHelp: "ℹ️ Yordam", "share_location": "📍 Joylashuvimni yuborish", "method": "🧭 Hisoblash usuli", "madhab": "🕌 Mazhab (Asr)", "highlat": "🌙 Yuqori kengliklar", "adjustments": "⏱ Vaqt tuzatishlari", "back": "‹ Orqaga", "close": "✅ Tayyor", "enable": "🔔 Yoqish", "disable": "🔕 O‘chirish", "main_menu": "🏠 Bosh menyu"},
 		Text:     map[string]string{"welcome": "<b>Qayerda bo‘lsangiz ham namoz vaqtlari</b> 🌍\n\n📍 Joylashuvingizni bir marta yuboring va men aniq mahalliy namoz vaqtlarini hisoblayman.\n\n⚙️ Keyin usul va mazhabni tanlang, har bir vaqtni sozlang va eslatmalarni yoqing.", "location_prompt": "<b>Namoz joylashuvini belgilang</b> 📍\n\nQuyidagi tugmani bosib joylashuvingizni yuboring. Faqat taxminan bir shahar mavzesigacha yaxlitlangan koordinatalarni saqlayman.", "location_group": "Telegram joylashuv tugmasini faqat shaxsiy chatlarda beradi. Guruh administratori bu yerda xabarga joylashuv biriktirishi yoki uni nom bilan belgilashi mumkin: <code>/city Toshkent</code>.", "location_set": "<b>Joylashuv saqlandi</b> ✅\n%s · %s\nUsul: %s\n\nVaqtlarni ko‘rish uchun <b>🕌 Bugun</b> tugmasini bosing.", "invalid_location": "Joylashuv noto‘g‘ri. Uni qayta yuboring.", "need_location": "Avval joylashuvingiz kerak. <b>📍 Joylashuv</b> tugmasini bosing.", "today_title": "Bugungi namoz vaqtlari", "tomorrow_title": "Ertangi namoz vaqtlari", "next_prayer": "<b>Keyingi namoz</b> ⏳\n%s <code>%s</code> da · %s", "settings_title": "<b>Namoz sozlamalari</b> ⚙️", "timezone": "Vaqt mintaqasi", "method": "Usul", "madhab": "Mazhab", "highlat": "Yuqori kenglik qoidasi", "adjustments": "Tuzatishlar", "choose_method": "<b>Hisoblash usulini tanlang</b> 🧭\nJoriy usul ✓ bilan belgilangan.", "choose_madhab": "<b>Asr mazhabini tanlang</b> 🕌\nShofi’iy vaqti Molikiy va Hanbaliy mazhablarida ham ishlatiladi.", "choose_highlat": "<b>Yuqori kenglik qoidasini tanlang</b> 🌙\nShafaq odatdagidek yo‘qolmaydigan joylarda Bomdod va Xuftonni belgilaydi.", "choose_adjustment": "<b>Namoz vaqtlarini sozlang</b> ⏱\nNamozni tanlab, daqiqa qo‘shing yoki ayiring.", "adjust_prayer": "<b>%s tuzatishi</b> ⏱\nJoriy qiymat: <b>%+d daqiqa</b>\nO‘zgarishni tanlang:", "method_saved": "Hisoblash usuli %s ga o‘zgartirildi.", "madhab_saved": "Mazhab %s ga o‘zgartirildi.", "highlat_saved": "Yuqori kenglik qoidasi %s ga o‘zgartirildi.", "adjust_saved": "%s tuzatishi endi %+d daqiqa.", "reminders_title": "<b>Namoz eslatmalari</b> 🔔", "reminders_on": "Holat: <b>yoqilgan</b> ✅", "reminders_off": "Holat: <b>o‘chirilgan</b>", "reminders_enabled": "Namoz eslatmalari yoqildi 🔔", "reminders_disabled": "Namoz eslatmalari o‘chirildi 🔕", "choose_language": "<b>Tilingizni tanlang</b> 🌐", "language_saved": "Til O‘zbekchaga o‘zgartirildi ✅", "admin_only": "Guruh sozlamalarini faqat administrator o‘zgartira oladi.", "unknown": "Buni tushunmadim. Menyudan foydalaning yoki <b>ℹ️ Yordam</b> tugmasini bosing.", "deleted": "Joylashuv, sozlamalar, eslatmalar va yuborish tarixi o‘chirildi.", "help": "<b>Botdan foydalanish</b> ℹ️\n\n📍 Joylashuvni bir marta yuboring.\n🕌 Bugun, ertaga yoki keyingi namoz uchun menyudan foydalaning.\n⚙️ Usul, mazhab, yuqori kenglik va tuzatishlarni sozlang.\n🔔 Eslatmalarni yoqing yoki o‘chiring.\n🌐 Tilni istalgan payt o‘zgartiring.\n\nBuyruqlar Telegram menyusida ham mavjud.", "privacy": "<b>Maxfiylik</b> 🔒\n\nJoylashuvingiz faqat vaqt mintaqasini aniqlash va namoz vaqtlarini hisoblash uchun ishlatiladi. Uch kasr xonasigacha yaxlitlangan koordinatalar, vaqt mintaqasi, Google Place ID va sozlamalar saqlanadi. Google manzili va Telegram yangilanishining to‘liq nusxasi saqlanmaydi. Bu chat ma’lumotlarini /delete_me bilan o‘chiring.", "reminder_at": "<b>%s</b> vaqti bo‘ldi 🕌", "reminder_before": "<b>%s</b> gacha %d daqiqa, vaqti <code>%s</code>.", "reminder_tomorrow": "Ertaga <b>%s</b> <code>%s</code> da."},
-		Prayers:  map[domain.Prayer]string{domain.PrayerFajr: "Bomdod", domain.PrayerSunrise: "Quyosh", domain.PrayerDhuhr: "Peshin", domain.PrayerAsr: "Asr", domain.PrayerMaghrib: "Shom", domain.PrayerIsha: "Xufton"}, Methods: methodNames, Madhabs: map[domain.Madhab]string{domain.MadhabShafii: "Shofi’iy / Molikiy / Hanbaliy", domain.MadhabHanafi: "Hanafiy"}, HighLatitude: map[domain.HighLatitudeRule]string{domain.HighLatitudeAngleBased: "Burchak asosida", domain.HighLatitudeMiddleNight: "Tun yarmi", domain.HighLatitudeSeventhNight: "Tunning yettidan biri"}, Months: []string{"yanvar", "fevral", "mart", "aprel", "may", "iyun", "iyul", "avgust", "sentabr", "oktabr", "noyabr", "dekabr"},
+		Prayers:  map[domain.Prayer]string{domain.PrayerFajr: "Bomdod", domain.PrayerSunrise: "Quyosh", domain.PrayerDhuhr: "Peshin", domain.PrayerAsr: "Asr", domain.PrayerMaghrib: "Shom", domain.PrayerIsha: "Xufton"}, Methods: localizedMethods(map[domain.Method]string{domain.MethodTehran: "Tehron Geofizika instituti", domain.MethodGulf: "Fors ko'rfazi mintaqasi", domain.MethodKuwait: "Quvayt", domain.MethodQatar: "Qatar", domain.MethodUOIF: "UOIF (Fransiya)", domain.MethodRussia: "Rossiya musulmonlari diniy boshqarmasi", domain.MethodMoonsighting: "Hilolni kuzatish qo'mitasi"}), Madhabs: map[domain.Madhab]string{domain.MadhabShafii: "Shofi’iy / Molikiy / Hanbaliy", domain.MadhabHanafi: "Hanafiy"}, HighLatitude: map[domain.HighLatitudeRule]string{domain.HighLatitudeAngleBased: "Burchak asosida", domain.HighLatitudeMiddleNight: "Tun yarmi", domain.HighLatitudeSeventhNight: "Tunning yettidan biri"}, Months: []string{"yanvar", "fevral", "mart", "aprel", "may", "iyun", "iyul", "avgust", "sentabr", "oktabr", "noyabr", "dekabr"},
 	},
 	"tt": {
 		Code: "tt", NativeName: "Татарча", BotName: "Дөнья намаз вакытлары", ShortDescription: "Кайда булсагыз да төгәл җирле намаз вакытлары.", Description: "Дөньяның теләсә кайсы урынында намаз вакытларын алу өчен урыныгызны җибәрегез. Исәпләү ысулын, мәзһәбне һәм югары киңлек кагыйдәсен сайлагыз, төзәтмәләр һәм искәртүләр көйләгез.",
 		Commands: map[string]string{"location": "Урынны билгеләү яки алыштыру", "city": "Урынны шәһәр исеме белән билгеләү", "today": "Бүгенге намаз вакытларын күрсәтү", "tomorrow": "Иртәгәге намаз вакытларын күрсәтү", "next": "Киләсе намазны күрсәтү", "settings": "Исәпләү көйләүләрен ачу", "remind": "Намаз искәртүләрен көйләү", "language": "Бот телен сайлау", "privacy": "Сакланган мәгълүмат һәм бетерү", "help": "Ярдәм һәм төп менюны күрсәтү"},
 		Buttons:  map[string]string{ActionToday: "🕌 Бүген", ActionTomorrow: "🌅 Иртәгә", ActionNext: "⏳ Киләсе намаз", ActionLocation: "📍 Урын", ActionSettings: "⚙️ Көйләүләр", ActionReminders: "🔔 Искәртүләр", ActionLanguage: "🌐 Тел", ActionHelp: "ℹ️ Ярдәм", "share_location": "📍 Урынымны җибәрү", "method": "🧭 Исәпләү ысулы", "madhab": "🕌 Мәзһәб (Әср)", "highlat": "🌙 Югары киңлекләр", "adjustments": "⏱ Вакыт төзәтмәләре", "back": "‹ Артка", "close": "✅ Әзер", "enable": "🔔 Кабызу", "disable": "🔕 Сүндерү", "main_menu": "🏠 Төп меню"},
 		Text:     map[string]string{"welcome": "<b>Кайда булсагыз да намаз вакытлары</b> 🌍\n\n📍 Урыныгызны бер тапкыр җибәрегез, һәм мин төгәл җирле намаз вакытларын исәпләрмен.\n\n⚙️ Аннары ысулны һәм мәзһәбне сайлагыз, һәр намазны көйләгез һәм искәртүләрне кабызыгыз.", "location_prompt": "<b>Намаз урынын билгеләгез</b> 📍\n\nТүбәндәге төймәгә басып урыныгызны җибәрегез. Мин якынча бер шәһәр кварталына кадәр түгәрәкләнгән координаталарны гына саклыйм.", "location_group": "Telegram урын җибәрү төймәсен шәхси чатларда гына бирә. Төркем администраторы мондагы хәбәргә урын беркетә ала яки аны исем белән билгели ала: <code>/city Казан</code>.", "location_set": "<b>Урын сакланды</b> ✅\n%s · %s\nЫсул: %s\n\nВакытларны карау өчен <b>🕌 Бүген</b> төймәсенә басыгыз.", "invalid_location": "Бу урын дөрес түгел. Аны яңадан җибәрегез.", "need_location": "Башта урыныгыз кирәк. <b>📍 Урын</b> төймәсенә басыгыз.", "today_title": "Бүгенге намаз вакытлары", "tomorrow_title": "Иртәгәге намаз вакытлары", "next_prayer": "<b>Киләсе намаз</b> ⏳\n%s <code>%s</code> сәгатьтә · %s", "settings_title": "<b>Намаз көйләүләре</b> ⚙️", "timezone": "Сәгать поясы", "method": "Ысул", "madhab": "Мәзһәб", "highlat": "Югары киңлек кагыйдәсе", "adjustments": "Төзәтмәләр", "choose_method": "<b>Исәпләү ысулын сайлагыз</b> 🧭\nХәзерге ысул ✓ белән билгеләнгән.", "choose_madhab": "<b>Әср мәзһәбен сайлагыз</b> 🕌\nШәфигый вакыты Мәлики һәм Хәнбәли мәзһәбләрендә дә кулланыла.", "choose_highlat": "<b>Югары киңлек кагыйдәсен сайлагыз</b> 🌙\nУл шәфәкъ гадәттәгечә бетмәгәндә Фәҗер һәм Ястүне билгели.", "choose_adjustment": "<b>Намаз вакытларын көйләгез</b> ⏱\nНамазны сайлап, минутлар өстәгез яки алыгыз.", "adjust_prayer": "<b>%s төзәтмәсе</b> ⏱\nХәзерге кыйммәт: <b>%+d минут</b>\nҮзгәрешне сайлагыз:", "method_saved": "Исәпләү ысулы %s итеп үзгәртелде.", "madhab_saved": "Мәзһәб %s итеп үзгәртелде.", "highlat_saved": "Югары киңлек кагыйдәсе %s итеп үзгәртелде.", "adjust_saved": "%s төзәтмәсе хәзер %+d минут.", "reminders_title": "<b>Намаз искәртүләре</b> 🔔", "reminders_on": "Хәл: <b>кабызылган</b> ✅", "reminders_off": "Хәл: <b>сүндерелгән</b>", "reminders_enabled": "Намаз искәртүләре кабызылды 🔔", "reminders_disabled": "Намаз искәртүләре сүндерелде 🔕", "choose_language": "<b>Телегезне сайлагыз</b> 🌐", "language_saved": "Тел Татарчага үзгәртелде ✅", "admin_only": "Төркем көйләүләрен администратор гына үзгәртә ала.", "unknown": "Мин моны аңламадым. Менюны кулланыгыз яки <b>ℹ️ Ярдәм</b> төймәсенә басыгыз.", "deleted": "Урын, көйләүләр, искәртүләр һәм җибәрү тарихы бетерелде.", "help": "<b>Ботны ничек кулланырга</b> ℹ️\n\n📍 Урыныгызны бер тапкыр җибәрегез.\n🕌 Бүген, иртәгә яки киләсе намаз өчен менюны кулланыгыз.\n⚙️ Ысулны, мәзһәбне, югары киңлекләрне һәм төзәтмәләрне көйләгез.\n🔔 Искәртүләрне кабызыгыз яки сүндерегез.\n🌐 Телне теләсә кайчан үзгәртегез.\n\nКомандалар Telegram менюсында да бар.", "privacy": "<b>Хосусыйлык</b> 🔒\n\nУрыныгыз сәгать поясын билгеләү һәм намаз вакытларын исәпләү өчен генә кулланыла. Өч унарлы билгегә түгәрәкләнгән координаталар, сәгать поясы, Google Place ID һәм көйләүләр саклана. Google адресы һәм Telegram яңартуының тулы күчермәсе сакланмый. Бу чат мәгълүматын /delete_me белән бетерегез.", "reminder_at": "<b>%s</b> вакыты җитте 🕌", "reminder_before": "<b>%s</b> га %d минут калды, вакыты <code>%s</code>.", "reminder_tomorrow": "Иртәгә <b>%s</b> <code>%s</code> сәгатьтә."},
-		Prayers:  map[domain.Prayer]string{domain.PrayerFajr: "Фәҗер", domain.PrayerSunrise: "Кояш чыгу", domain.PrayerDhuhr: "Өйлә", domain.PrayerAsr: "Әср", domain.PrayerMaghrib: "Ахшам", domain.PrayerIsha: "Ястү"}, Methods: methodNames, Madhabs: map[domain.Madhab]string{domain.MadhabShafii: "Шәфигый / Мәлики / Хәнбәли", domain.MadhabHanafi: "Хәнәфи"}, HighLatitude: map[domain.HighLatitudeRule]string{domain.HighLatitudeAngleBased: "Почмак буенча", domain.HighLatitudeMiddleNight: "Төн уртасы", domain.HighLatitudeSeventhNight: "Төннең җидедән бер өлеше"}, Months: []string{"гыйнвар", "февраль", "март", "апрель", "май", "июнь", "июль", "август", "сентябрь", "октябрь", "ноябрь", "декабрь"},
+		Prayers:  map[domain.Prayer]string{domain.PrayerFajr: "Фәҗер", domain.PrayerSunrise: "Кояш чыгу", domain.PrayerDhuhr: "Өйлә", domain.PrayerAsr: "Әср", domain.PrayerMaghrib: "Ахшам", domain.PrayerIsha: "Ястү"}, Methods: localizedMethods(map[domain.Method]string{domain.MethodTehran: "Тегеран геофизика институты", domain.MethodGulf: "Фарсы култыгы төбәге", domain.MethodKuwait: "Күвәйт", domain.MethodQatar: "Катар", domain.MethodUOIF: "UOIF (Франция)", domain.MethodRussia: "Россия мөселманнары Диния нәзарәте", domain.MethodMoonsighting: "Ай күзәтү комитеты"}), Madhabs: map[domain.Madhab]string{domain.MadhabShafii: "Шәфигый / Мәлики / Хәнбәли", domain.MadhabHanafi: "Хәнәфи"}, HighLatitude: map[domain.HighLatitudeRule]string{domain.HighLatitudeAngleBased: "Почмак буенча", domain.HighLatitudeMiddleNight: "Төн уртасы", domain.HighLatitudeSeventhNight: "Төннең җидедән бер өлеше"}, Months: []string{"гыйнвар", "февраль", "март", "апрель", "май", "июнь", "июль", "август", "сентябрь", "октябрь", "ноябрь", "декабрь"},
 	},
 }
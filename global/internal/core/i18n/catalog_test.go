@@ -6,6 +6,7 @@ import (
 	"testing"
 	"unicode/utf8"
 
+	"github.com/escalopa/prayer-bot/global/internal/core/moon"
 	"github.com/escalopa/prayer-bot/global/internal/domain"
 )
 
@@ -23,21 +24,59 @@ func TestResolveNormalizesTelegramLanguageTags(t *testing.T) {
 
 func TestLocalizedFormatStringsAcceptExpectedArguments(t *testing.T) {
 	samples := map[string][]any{
-		"location_set":      {"Cairo", "Africa/Cairo", "Egyptian"},
-		"next_prayer":       {"Fajr", "04:15", "in 2 h 15 min"},
-		"next_in_h":         {2},
-		"next_in_m":         {15},
-		"next_in_hm":        {2, 15},
-		"adjust_prayer":     {"Fajr", 2},
-		"method_saved":      {"Egyptian"},
-		"madhab_saved":      {"Hanafi"},
-		"highlat_saved":     {"Angle based"},
-		"adjust_saved":      {"Fajr", 2},
-		"reminder_at":       {"Fajr"},
-		"reminder_before":   {"Fajr", 10, "04:15"},
-		"reminder_tomorrow": {"Fajr", "04:15"},
-		"hijri_setting":     {1},
-		"minutes_before":    {20},
+		"location_set":              {"Cairo", "Africa/Cairo", "Egyptian"},
+		"next_prayer":               {"Fajr", "04:15", "in 2 h 15 min"},
+		"next_in_h":                 {2},
+		"next_in_m":                 {15},
+		"next_in_hm":                {2, 15},
+		"adjust_prayer":             {"Fajr", 2},
+		"method_saved":              {"Egyptian"},
+		"madhab_saved":              {"Hanafi"},
+		"highlat_saved":             {"Angle based"},
+		"adjust_saved":              {"Fajr", 2},
+		"reminder_at":               {"Fajr"},
+		"reminder_before":           {"Fajr", 10, "04:15"},
+		"reminder_tomorrow":         {"Fajr", "04:15"},
+		"hijri_setting":             {"Umm al-Qura", 1},
+		"hijri_note":                {"Umm al-Qura"},
+		"hijri_note_announced":      {"Umm al-Qura"},
+		"hijri_announcement_notice": {"Ramadan 1448", "8 February 2027"},
+		"minutes_before":            {20},
+		"custom_interval_minutes":   {90},
+		"imsak_minutes":             {10},
+		"reminder_extended":         {"Imsak", "04:05"},
+		"elevation_value":           {2240},
+		"official_timetable":        {"Kazan Muftiate"},
+		"timetable_covers":          {"Kazan Muftiate"},
+		"ihtiyat_value":             {2},
+		"month_range":               {1, 31, "March", 2026},
+		"choose_month":              {"1–31 March 2026"},
+		"suhoor_minutes":            {30},
+		"reminder_suhoor":           {30, "04:15"},
+		"reminder_iftar":            {"19:40"},
+		"jumuah_saved":              {"13:15"},
+		"jumuah_minutes":            {30},
+		"reminder_jumuah":           {30, "13:15"},
+		"convert_ambiguous":         {"29 March 2025", "30 March 2025"},
+		"events_saved":              {"Hijri birthday"},
+		"events_deleted":            {"Hijri birthday"},
+		"events_limit":              {20},
+		"reminder_personal_event":   {"16 January 2026 · 27 Rajab 1447 AH"},
+		"moon_lit":                  {12},
+		"moon_age":                  {3},
+		"moon_new":                  {"28 February 03:45"},
+		"moon_crescent":             {"1 March"},
+		"moon_no_crescent":          {"2 March"},
+		"moon_new_note":             {"03:45"},
+		"custom_saved":              {"Witr"},
+		"custom_deleted":            {"Witr"},
+		"custom_limit":              {10},
+		"reminder_custom":           {"Witr"},
+		"custom_weekly":             {"Mon, Thu"},
+		"custom_hijri_monthly":      {13},
+		"custom_once":               {"2 November 2026"},
+		"custom_before":             {10, "Maghrib"},
+		"custom_after":              {20, "Fajr"},
 	}
 	for _, locale := range Supported() {
 		for key, arguments := range samples {
@@ -52,7 +91,10 @@ func TestLocalizedFormatStringsAcceptExpectedArguments(t *testing.T) {
 func TestLocalesAreCompleteAndWithinTelegramLimits(t *testing.T) {
 	buttonKeys := append(append([]string{}, mainActions...),
 		"share_location", "method", "madhab", "highlat", "adjustments", "hijri", "back", "close", "enable", "disable", "main_menu",
-		"prayer_reminders", "fasting_reminders", "kahf_reminders")
+		"prayer_reminders", "fasting_reminders", "kahf_reminders", "custom_method",
+		"extended_times", "extended_reminders", "makruh_times", "official_timetable",
+		"elevation", "elevation_sea_level", "elevation_detect", "precaution", "ramadan_reminders",
+		"jumuah", "jumuah_clear")
 	textKeys := []string{
 		"welcome", "location_prompt", "location_group", "location_set", "invalid_location", "need_location",
 		"today_title", "tomorrow_title", "next_prayer", "next_in_h", "next_in_m", "next_in_hm",
@@ -66,9 +108,43 @@ func TestLocalesAreCompleteAndWithinTelegramLimits(t *testing.T) {
 		"city_usage", "city_no_results", "city_choose",
 		"hijri_date", "hijri_era", "hijri_setting", "hijri_note", "choose_hijri", "reminder_fasting", "reminder_kahf",
 		"feedback_prompt", "feedback_placeholder", "feedback_sent", "feedback_private",
+		"choose_custom_method", "custom_fajr_angle", "custom_isha_angle", "custom_isha_interval",
+		"custom_isha_by_angle", "custom_interval_minutes",
+		"extended_times_title", "choose_extended_times", "show_extended_times", "imsak_minutes",
+		"choose_extended_reminders", "reminder_extended",
+		"makruh_title", "choose_makruh", "show_makruh",
+		"elevation", "elevation_value", "choose_elevation",
+		"official_timetable", "timetable_title", "timetable_covers", "timetable_none", "use_timetable",
+		"precaution", "choose_precaution", "ihtiyat_value", "precaution_default",
+		"month_title", "month_range", "month_date", "month_weekday", "month_hijri", "month_occasions",
+		"choose_month", "month_invalid", "month_help", "month_sent", "month_failed",
+		"ramadan_title", "ramadan_suhoor", "ramadan_iftar", "ramadan_download", "ramadan_help", "ramadan_schedule",
+		"choose_ramadan_reminders", "suhoor_minutes", "suhoor_off", "iftar_reminder", "reminder_suhoor", "reminder_iftar",
+		"jumuah", "jumuah_khutbah", "jumuah_iqamah", "jumuah_not_set", "choose_jumuah", "jumuah_saved",
+		"jumuah_cleared", "jumuah_invalid", "jumuah_minutes", "jumuah_reminder_off", "reminder_jumuah",
+		"hijri_calendar", "hijri_calendar_umm_al_qura", "hijri_calendar_tabular", "hijri_calendar_diyanet",
+		"hijri_calendar_crescent", "hijri_note_announced", "hijri_announcement_notice",
+		"convert_title", "convert_help", "convert_invalid", "convert_ambiguous",
+		"events_title", "events_help", "events_empty", "events_invalid",
+		"moon_new_event", "moon_crescent_event", "moon_crescent_note", "moon_calendar_events", "moon_calendar_help",
+		"fasting_title", "fasting_help", "fasting_note", "fasting_obligatory", "fasting_recommended", "fasting_prohibited",
+		"fasting_reason_ramadan", "fasting_reason_monday", "fasting_reason_thursday", "fasting_reason_white_days",
+		"fasting_calendar_title", "fasting_calendar_help", "fasting_previous", "fasting_next",
+		"fasts_title", "fasts_help", "fasts_fasting", "fasts_makeup", "fasts_not_today", "fasts_kept", "fasts_made_up",
+		"fasts_missed", "fasts_not_fasted", "fasts_carried", "fasts_owed", "fasts_suggestions", "fasts_today",
+		"fasts_prohibited", "fasts_ramadan_makeup", "fasts_carried_saved", "fasts_carried_invalid", "fasts_clear",
+		"fasts_private",
+		"custom_title", "custom_help", "custom_empty", "custom_saved", "custom_deleted", "custom_invalid",
+		"custom_limit", "custom_past", "reminder_custom", "custom_daily", "custom_weekly", "custom_hijri_monthly",
+		"custom_once", "custom_before", "custom_after", "custom_weekly_option", "custom_hijri_option",
+		"custom_once_option", "custom_text", "custom_repeat", "custom_clock", "custom_prayer", "custom_offset",
+		"custom_weekdays", "custom_hijri_day", "custom_date", "custom_add", "custom_edit", "custom_delete",
+		"custom_cancel",
 	}
-	commandKeys := []string{"location", "city", "today", "tomorrow", "next", "settings", "remind", "language", "feedback", "privacy", "help"}
-	prayers := []domain.Prayer{domain.PrayerFajr, domain.PrayerSunrise, domain.PrayerDhuhr, domain.PrayerAsr, domain.PrayerMaghrib, domain.PrayerIsha}
+	commandKeys := []string{"location", "city", "today", "tomorrow", "next", "settings", "remind", "language", "feedback", "privacy", "help", "month", "ramadan", "jumuah", "convert", "events", "fasting", "fasts", "custom"}
+	prayers := append([]domain.Prayer{
+		domain.PrayerFajr, domain.PrayerSunrise, domain.PrayerDhuhr, domain.PrayerAsr, domain.PrayerMaghrib, domain.PrayerIsha,
+	}, domain.ExtendedTimes()...)
 
 	seen := make(map[string]bool)
 	for _, locale := range Supported() {
@@ -114,6 +190,26 @@ func TestLocalesAreCompleteAndWithinTelegramLimits(t *testing.T) {
 				t.Errorf("%s missing prayer %q", locale.Code, prayer)
 			}
 		}
+		for _, window := range domain.MakruhWindows() {
+			if locale.Text["makruh_"+string(window)] == "" {
+				t.Errorf("%s missing makruh window %q", locale.Code, window)
+			}
+		}
+		for _, phase := range moon.Phases() {
+			if locale.MoonPhase(phase) == "" {
+				t.Errorf("%s missing moon phase %q", locale.Code, phase)
+			}
+		}
+		for _, method := range domain.SupportedMethods() {
+			if locale.Methods[method] == "" {
+				t.Errorf("%s missing method %q", locale.Code, method)
+			}
+		}
+		for _, rule := range domain.SupportedHighLatitudeRules() {
+			if locale.HighLatitude[rule] == "" {
+				t.Errorf("%s missing high-latitude rule %q", locale.Code, rule)
+			}
+		}
 	}
 	if len(seen) != 8 {
 		t.Fatalf("got %d supported locales, want 8", len(seen))
@@ -0,0 +1,77 @@
+package i18n
+
+// convertCopy is the /convert date converter. Ambiguous takes the two
+// Gregorian days a Hijri 30th may fall on.
+type convertCopy struct {
+	Command, Title, Help, Invalid, Ambiguous string
+}
+
+var convertCopies = map[string]convertCopy{
+	"en": {
+		"Convert a date between Hijri and Gregorian",
+		"Date converter",
+		"Send a date in either calendar, such as <code>/convert 27 Rajab 1447</code>, <code>/convert 1 Ramadan</code> or <code>/convert 16.03.2026</code>. Without a year, the next occurrence is shown. Hijri dates follow your calendar and correction.",
+		"That date could not be read.",
+		"This month may end after 29 days, so depending on the moon sighting the day is %s or %s.",
+	},
+	"ar": {
+		"تحويل التاريخ بين الهجري والميلادي",
+		"محوّل التاريخ",
+		"أرسل تاريخًا بأي من التقويمين، مثل <code>/convert 27 رجب 1447</code> أو <code>/convert 1 رمضان</code> أو <code>/convert 16.03.2026</code>. إن لم تذكر السنة يُعرض أقرب موعد قادم. تتبع التواريخ الهجرية تقويمك وتصحيحك.",
+		"تعذّرت قراءة هذا التاريخ.",
+		"قد ينتهي هذا الشهر بعد 29 يومًا، فيكون اليوم بحسب رؤية الهلال %s أو %s.",
+	},
+	"es": {
+		"Convertir una fecha entre hiyri y gregoriano",
+		"Conversor de fechas",
+		"Envía una fecha en cualquiera de los dos calendarios, por ejemplo <code>/convert 27 Rayab 1447</code>, <code>/convert 1 Ramadán</code> o <code>/convert 16.03.2026</code>. Sin año se muestra la próxima fecha. Las fechas hiyri siguen tu calendario y tu corrección.",
+		"No se pudo leer esa fecha.",
+		"Este mes puede terminar a los 29 días, así que según la observación lunar el día es el %s o el %s.",
+	},
+	"fr": {
+		"Convertir une date entre hégire et grégorien",
+		"Convertisseur de dates",
+		"Envoyez une date dans l'un ou l'autre calendrier, par exemple <code>/convert 27 Rajab 1447</code>, <code>/convert 1 Ramadan</code> ou <code>/convert 16.03.2026</code>. Sans année, la prochaine occurrence est affichée. Les dates hégiriennes suivent votre calendrier et votre correction.",
+		"Cette date n'a pas pu être lue.",
+		"Ce mois peut se terminer après 29 jours : selon l'observation de la lune, le jour est le %s ou le %s.",
+	},
+	"ru": {
+		"Перевести дату между Хиджрой и григорианским календарём",
+		"Конвертер дат",
+		"Отправьте дату в любом из календарей, например <code>/convert 27 Раджаб 1447</code>, <code>/convert 1 Рамадан</code> или <code>/convert 16.03.2026</code>. Без года показывается ближайшая дата. Даты Хиджры следуют вашему календарю и поправке.",
+		"Не удалось прочитать эту дату.",
+		"Этот месяц может закончиться через 29 дней, поэтому в зависимости от наблюдения луны это %s или %s.",
+	},
+	"tr": {
+		"Bir tarihi Hicri ve Miladi arasında çevir",
+		"Tarih çevirici",
+		"İki takvimden birinde bir tarih gönderin, örneğin <code>/convert 27 Recep 1447</code>, <code>/convert 1 Ramazan</code> veya <code>/convert 16.03.2026</code>. Yıl yazılmazsa bir sonraki tarih gösterilir. Hicri tarihler takviminize ve düzeltmenize göredir.",
+		"Bu tarih okunamadı.",
+		"Bu ay 29 günde bitebilir; hilalin görülmesine göre gün %s ya da %s olur.",
+	},
+	"uz": {
+		"Sanani hijriy va milodiy taqvim o‘rtasida o‘girish",
+		"Sana o‘girgich",
+		"Istalgan taqvimdagi sanani yuboring, masalan <code>/convert 27 Rajab 1447</code>, <code>/convert 1 Ramazon</code> yoki <code>/convert 16.03.2026</code>. Yil yozilmasa, eng yaqin sana ko‘rsatiladi. Hijriy sanalar taqvimingiz va tuzatishingizga amal qiladi.",
+		"Bu sanani o‘qib bo‘lmadi.",
+		"Bu oy 29 kunda tugashi mumkin, shuning uchun hilol ko‘rinishiga qarab kun %s yoki %s bo‘ladi.",
+	},
+	"tt": {
+		"Датаны һиҗри һәм григориан календарьлары арасында күчерү",
+		"Дата күчергеч",
+		"Теләсә кайсы календарьда дата җибәрегез, мәсәлән <code>/convert 27 Рәҗәб 1447</code>, <code>/convert 1 Рамазан</code> яки <code>/convert 16.03.2026</code>. Ел язылмаса, иң якын дата күрсәтелә. Һиҗри даталар календарегезгә һәм төзәтмәгезгә иярә.",
+		"Бу датаны укып булмады.",
+		"Бу ай 29 көннән тәмамланырга мөмкин, шуңа күрә яңа ай күренүгә карап көн %s яки %s була.",
+	},
+}
+
+func init() {
+	for code, copy := range convertCopies {
+		locale := locales[code]
+		locale.Commands["convert"] = copy.Command
+		locale.Text["convert_title"] = copy.Title
+		locale.Text["convert_help"] = copy.Help
+		locale.Text["convert_invalid"] = copy.Invalid
+		locale.Text["convert_ambiguous"] = copy.Ambiguous
+	}
+}
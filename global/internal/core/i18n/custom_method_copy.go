@@ -0,0 +1,64 @@
+package i18n
+
+import "github.com/escalopa/prayer-bot/global/internal/domain"
+
+type customMethodCopy struct {
+	Name, Button, Choose, Fajr, Isha, Interval, IntervalOff, Minutes string
+}
+
+var customMethodCopies = map[string]customMethodCopy{
+	"en": {
+		"Custom angles", "⚙️ Custom angles",
+		"<b>Custom calculation</b> ⚙️\n\nSet the Fajr and Isha angles published by your local council. Isha can instead follow Maghrib by a fixed interval.",
+		"Fajr angle", "Isha angle", "Isha after Maghrib", "By angle", "%d min",
+	},
+	"ar": {
+		"زوايا مخصصة", "⚙️ زوايا مخصصة",
+		"<b>حساب مخصص</b> ⚙️\n\nحدد زاويتي الفجر والعشاء كما تعتمدها الجهة المحلية. ويمكن بدلًا من ذلك جعل العشاء بعد المغرب بمدة ثابتة.",
+		"زاوية الفجر", "زاوية العشاء", "العشاء بعد المغرب", "حسب الزاوية", "%d دقيقة",
+	},
+	"es": {
+		"Ángulos personalizados", "⚙️ Ángulos personalizados",
+		"<b>Cálculo personalizado</b> ⚙️\n\nIndica los ángulos de Fajr e Isha que publica tu consejo local. Isha también puede fijarse a un intervalo después de Maghrib.",
+		"Ángulo de Fajr", "Ángulo de Isha", "Isha tras Maghrib", "Por ángulo", "%d min",
+	},
+	"fr": {
+		"Angles personnalisés", "⚙️ Angles personnalisés",
+		"<b>Calcul personnalisé</b> ⚙️\n\nIndiquez les angles de Fajr et d'Isha publiés par votre conseil local. Isha peut aussi suivre Maghrib après un intervalle fixe.",
+		"Angle de Fajr", "Angle d'Isha", "Isha après Maghrib", "Selon l'angle", "%d min",
+	},
+	"ru": {
+		"Свои углы", "⚙️ Свои углы",
+		"<b>Собственный расчёт</b> ⚙️\n\nУкажите углы Фаджра и Иши, которые публикует ваш местный совет. Ишу также можно задать фиксированным интервалом после Магриба.",
+		"Угол Фаджра", "Угол Иши", "Иша после Магриба", "По углу", "%d мин",
+	},
+	"tr": {
+		"Özel açılar", "⚙️ Özel açılar",
+		"<b>Özel hesaplama</b> ⚙️\n\nYerel kurulunuzun yayımladığı İmsak ve Yatsı açılarını girin. Yatsı, akşamdan sabit bir süre sonra da ayarlanabilir.",
+		"İmsak açısı", "Yatsı açısı", "Akşamdan sonra Yatsı", "Açıya göre", "%d dk",
+	},
+	"uz": {
+		"Maxsus burchaklar", "⚙️ Maxsus burchaklar",
+		"<b>Maxsus hisob</b> ⚙️\n\nMahalliy kengash e'lon qilgan Bomdod va Xufton burchaklarini kiriting. Xuftonni shomdan keyin belgilangan vaqtga ham qo'yish mumkin.",
+		"Bomdod burchagi", "Xufton burchagi", "Shomdan keyin Xufton", "Burchak bo'yicha", "%d daq",
+	},
+	"tt": {
+		"Үз почмаклар", "⚙️ Үз почмаклар",
+		"<b>Үз исәпләү</b> ⚙️\n\nҖирле шура игълан иткән Фәҗер һәм Ястү почмакларын күрсәтегез. Ястүне ахшамнан соң билгеле вакытка да куеп була.",
+		"Фәҗер почмагы", "Ястү почмагы", "Ахшамнан соң Ястү", "Почмак буенча", "%d мин",
+	},
+}
+
+func init() {
+	for code, copy := range customMethodCopies {
+		locale := locales[code]
+		locale.Methods[domain.MethodCustom] = copy.Name
+		locale.Buttons["custom_method"] = copy.Button
+		locale.Text["choose_custom_method"] = copy.Choose
+		locale.Text["custom_fajr_angle"] = copy.Fajr
+		locale.Text["custom_isha_angle"] = copy.Isha
+		locale.Text["custom_isha_interval"] = copy.Interval
+		locale.Text["custom_isha_by_angle"] = copy.IntervalOff
+		locale.Text["custom_interval_minutes"] = copy.Minutes
+	}
+}
@@ -0,0 +1,219 @@
+package i18n
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/escalopa/prayer-bot/global/internal/domain"
+)
+
+// customReminderCopy is the /custom view of the reminders a chat writes for
+// itself and the Mini App form that edits them. Saved and Deleted take the
+// reminder text, Limit the most reminders a chat may keep, and Reminder the
+// text when it is sent. Weekly takes the weekdays, HijriMonthly the day and
+// Once the date; Before and After take the minutes and the prayer.
+type customReminderCopy struct {
+	Command, Title, Help, Empty           string
+	Saved, Deleted, Invalid, Limit, Past  string
+	Reminder                              string
+	Daily, Weekly, HijriMonthly, Once     string
+	Before, After                         string
+	WeeklyOption, HijriOption, OnceOption string
+	Text, Repeat, Clock, Prayer, Offset   string
+	Weekdays, HijriDay, Date              string
+	Add, Edit, Delete, Cancel             string
+}
+
+var customReminderCopies = map[string]customReminderCopy{
+	"en": {
+		"Your own reminders at a time you choose", "Your reminders",
+		"Send a repeat, a time and the text, such as <code>/custom daily 21:30 Read Surah al-Mulk</code>, <code>/custom mon,thu fajr+20 Morning adhkar</code>, <code>/custom hijri:13 maghrib-10 White days tomorrow</code> or <code>/custom 2026-11-02 09:00 Doctor</code>. Start with the number of a listed reminder to change it, such as <code>/custom 2 daily isha Witr</code>. Tap a reminder below to remove it.",
+		"You have not added any reminders yet.",
+		"Saved: %s.", "Removed: %s.",
+		"Send a repeat (daily, weekdays such as mon,thu, hijri:13 or a date), a time (21:30, or a prayer such as fajr+20) and a text of up to 200 characters.",
+		"You can keep up to %d reminders. Remove one to add another.",
+		"That date and time have already passed.",
+		"🔔 %s",
+		"Every day", "Every %s", "Day %d of every Hijri month", "On %s",
+		"%d min before %s", "%d min after %s",
+		"On chosen weekdays", "Monthly by Hijri day", "Once",
+		"Text", "Repeat", "At a clock time", "Around a prayer", "Minutes after the prayer (negative for before)",
+		"Weekdays", "Hijri day", "Date",
+		"Add reminder", "Edit", "Delete", "Cancel",
+	},
+	"ar": {
+		"تذكيراتك الخاصة في الوقت الذي تختاره", "تذكيراتي",
+		"أرسل التكرار ثم الوقت ثم النص، مثل <code>/custom daily 21:30 قراءة سورة الملك</code> أو <code>/custom mon,thu fajr+20 أذكار الصباح</code> أو <code>/custom hijri:13 maghrib-10 الأيام البيض غدًا</code> أو <code>/custom 2026-11-02 09:00 الطبيب</code>. ابدأ برقم تذكير من القائمة لتعديله، مثل <code>/custom 2 daily isha الوتر</code>. اضغط على تذكير أدناه لحذفه.",
+		"لم تضف أي تذكير بعد.",
+		"تم الحفظ: %s.", "تم الحذف: %s.",
+		"أرسل التكرار (daily أو أيامًا مثل mon,thu أو hijri:13 أو تاريخًا) ثم الوقت (21:30 أو صلاة مثل fajr+20) ثم نصًا لا يتجاوز 200 حرف.",
+		"يمكنك حفظ %d تذكيرات كحد أقصى. احذف واحدًا لإضافة غيره.",
+		"هذا التاريخ والوقت قد مضيا.",
+		"🔔 %s",
+		"كل يوم", "كل %s", "اليوم %d من كل شهر هجري", "في %s",
+		"قبل %[2]s بـ %[1]d دقيقة", "بعد %[2]s بـ %[1]d دقيقة",
+		"في أيام محددة", "شهريًا حسب اليوم الهجري", "مرة واحدة",
+		"النص", "التكرار", "في وقت محدد", "حول صلاة", "الدقائق بعد الصلاة (بالسالب لما قبلها)",
+		"أيام الأسبوع", "اليوم الهجري", "التاريخ",
+		"إضافة تذكير", "تعديل", "حذف", "إلغاء",
+	},
+	"es": {
+		"Tus propios recordatorios a la hora que elijas", "Tus recordatorios",
+		"Envía una repetición, una hora y el texto, como <code>/custom daily 21:30 Leer la sura al-Mulk</code>, <code>/custom mon,thu fajr+20 Adhkar de la mañana</code>, <code>/custom hijri:13 maghrib-10 Mañana días blancos</code> o <code>/custom 2026-11-02 09:00 Médico</code>. Empieza con el número de un recordatorio de la lista para cambiarlo, como <code>/custom 2 daily isha Witr</code>. Pulsa un recordatorio abajo para quitarlo.",
+		"Aún no has añadido recordatorios.",
+		"Guardado: %s.", "Eliminado: %s.",
+		"Envía una repetición (daily, días como mon,thu, hijri:13 o una fecha), una hora (21:30, o una oración como fajr+20) y un texto de hasta 200 caracteres.",
+		"Puedes guardar hasta %d recordatorios. Quita uno para añadir otro.",
+		"Esa fecha y hora ya han pasado.",
+		"🔔 %s",
+		"Cada día", "Cada %s", "Día %d de cada mes hiyri", "El %s",
+		"%d min antes de %s", "%d min después de %s",
+		"Días elegidos", "Mensual por día hiyri", "Una vez",
+		"Texto", "Repetir", "A una hora fija", "Según una oración", "Minutos tras la oración (negativo para antes)",
+		"Días de la semana", "Día hiyri", "Fecha",
+		"Añadir recordatorio", "Editar", "Eliminar", "Cancelar",
+	},
+	"fr": {
+		"Vos propres rappels à l'heure choisie", "Vos rappels",
+		"Envoyez une répétition, une heure et le texte, par exemple <code>/custom daily 21:30 Lire la sourate al-Mulk</code>, <code>/custom mon,thu fajr+20 Adhkar du matin</code>, <code>/custom hijri:13 maghrib-10 Jours blancs demain</code> ou <code>/custom 2026-11-02 09:00 Médecin</code>. Commencez par le numéro d'un rappel de la liste pour le modifier, par exemple <code>/custom 2 daily isha Witr</code>. Touchez un rappel ci-dessous pour le supprimer.",
+		"Vous n'avez encore ajouté aucun rappel.",
+		"Enregistré : %s.", "Supprimé : %s.",
+		"Envoyez une répétition (daily, des jours comme mon,thu, hijri:13 ou une date), une heure (21:30, ou une prière comme fajr+20) et un texte de 200 caractères au plus.",
+		"Vous pouvez garder jusqu'à %d rappels. Supprimez-en un pour en ajouter un autre.",
+		"Cette date et cette heure sont déjà passées.",
+		"🔔 %s",
+		"Chaque jour", "Chaque %s", "Le %d de chaque mois hégirien", "Le %s",
+		"%d min avant %s", "%d min après %s",
+		"Jours choisis", "Mensuel selon le jour hégirien", "Une fois",
+		"Texte", "Répétition", "À une heure fixe", "Autour d'une prière", "Minutes après la prière (négatif pour avant)",
+		"Jours de la semaine", "Jour hégirien", "Date",
+		"Ajouter un rappel", "Modifier", "Supprimer", "Annuler",
+	},
+	"ru": {
+		"Свои напоминания в выбранное время", "Мои напоминания",
+		"Отправьте повтор, время и текст, например <code>/custom daily 21:30 Прочитать суру аль-Мульк</code>, <code>/custom mon,thu fajr+20 Утренние азкары</code>, <code>/custom hijri:13 maghrib-10 Завтра белые дни</code> или <code>/custom 2026-11-02 09:00 Врач</code>. Начните с номера напоминания из списка, чтобы изменить его, например <code>/custom 2 daily isha Витр</code>. Нажмите на напоминание ниже, чтобы удалить его.",
+		"Вы ещё не добавили напоминаний.",
+		"Сохранено: %s.", "Удалено: %s.",
+		"Отправьте повтор (daily, дни вроде mon,thu, hijri:13 или дату), время (21:30 или намаз, например fajr+20) и текст до 200 символов.",
+		"Можно хранить до %d напоминаний. Удалите одно, чтобы добавить другое.",
+		"Эти дата и время уже прошли.",
+		"🔔 %s",
+		"Каждый день", "По дням: %s", "%d-й день каждого месяца по хиджре", "%s",
+		"За %d мин. до %s", "%d мин. после %s",
+		"По выбранным дням", "Ежемесячно по дню хиджры", "Один раз",
+		"Текст", "Повтор", "В заданное время", "Относительно намаза", "Минуты после намаза (отрицательные — до)",
+		"Дни недели", "День хиджры", "Дата",
+		"Добавить напоминание", "Изменить", "Удалить", "Отмена",
+	},
+	"tr": {
+		"Seçtiğiniz saatte kendi hatırlatıcılarınız", "Hatırlatıcılarım",
+		"Bir tekrar, bir saat ve metni gönderin; örneğin <code>/custom daily 21:30 Mülk suresini oku</code>, <code>/custom mon,thu fajr+20 Sabah zikirleri</code>, <code>/custom hijri:13 maghrib-10 Yarın eyyam-ı biyz</code> veya <code>/custom 2026-11-02 09:00 Doktor</code>. Değiştirmek için listedeki hatırlatıcının numarasıyla başlayın, örneğin <code>/custom 2 daily isha Vitir</code>. Silmek için aşağıdaki hatırlatıcıya dokunun.",
+		"Henüz hatırlatıcı eklemediniz.",
+		"Kaydedildi: %s.", "Silindi: %s.",
+		"Bir tekrar (daily, mon,thu gibi günler, hijri:13 veya bir tarih), bir saat (21:30 veya fajr+20 gibi bir namaz) ve en fazla 200 karakterlik bir metin gönderin.",
+		"En fazla %d hatırlatıcı saklayabilirsiniz. Yenisini eklemek için birini silin.",
+		"Bu tarih ve saat geçti.",
+		"🔔 %s",
+		"Her gün", "Her %s", "Her hicri ayın %d. günü", "%s",
+		"%[2]s vaktinden %[1]d dk önce", "%[2]s vaktinden %[1]d dk sonra",
+		"Seçilen günlerde", "Hicri güne göre aylık", "Bir kez",
+		"Metin", "Tekrar", "Belirli bir saatte", "Bir namaza göre", "Namazdan sonraki dakikalar (önce için eksi)",
+		"Haftanın günleri", "Hicri gün", "Tarih",
+		"Hatırlatıcı ekle", "Düzenle", "Sil", "İptal",
+	},
+	"uz": {
+		"O‘zingiz tanlagan vaqtdagi eslatmalar", "Mening eslatmalarim",
+		"Takror, vaqt va matnni yuboring, masalan <code>/custom daily 21:30 Mulk surasini o‘qish</code>, <code>/custom mon,thu fajr+20 Tong zikrlari</code>, <code>/custom hijri:13 maghrib-10 Ertaga oq kunlar</code> yoki <code>/custom 2026-11-02 09:00 Shifokor</code>. O‘zgartirish uchun ro‘yxatdagi eslatma raqami bilan boshlang, masalan <code>/custom 2 daily isha Vitr</code>. O‘chirish uchun quyidagi eslatmani bosing.",
+		"Hali eslatma qo‘shmadingiz.",
+		"Saqlandi: %s.", "O‘chirildi: %s.",
+		"Takror (daily, mon,thu kabi kunlar, hijri:13 yoki sana), vaqt (21:30 yoki fajr+20 kabi namoz) va 200 belgigacha matn yuboring.",
+		"Ko‘pi bilan %d ta eslatma saqlash mumkin. Yangisini qo‘shish uchun birini o‘chiring.",
+		"Bu sana va vaqt o‘tib ketgan.",
+		"🔔 %s",
+		"Har kuni", "Har %s", "Har hijriy oyning %d-kuni", "%s",
+		"%[2]s dan %[1]d daqiqa oldin", "%[2]s dan %[1]d daqiqa keyin",
+		"Tanlangan kunlarda", "Hijriy kun bo‘yicha har oy", "Bir marta",
+		"Matn", "Takror", "Belgilangan vaqtda", "Namozga nisbatan", "Namozdan keyingi daqiqalar (oldin uchun manfiy)",
+		"Hafta kunlari", "Hijriy kun", "Sana",
+		"Eslatma qo‘shish", "Tahrirlash", "O‘chirish", "Bekor qilish",
+	},
+	"tt": {
+		"Үзегез сайлаган вакыттагы искәртүләр", "Минем искәртүләр",
+		"Кабатлау, вакыт һәм текст җибәрегез, мәсәлән <code>/custom daily 21:30 Мөлек сүрәсен уку</code>, <code>/custom mon,thu fajr+20 Иртәнге зикерләр</code>, <code>/custom hijri:13 maghrib-10 Иртәгә ак көннәр</code> яки <code>/custom 2026-11-02 09:00 Табиб</code>. Үзгәртү өчен исемлектәге искәртү саны белән башлагыз, мәсәлән <code>/custom 2 daily isha Витр</code>. Бетерү өчен түбәндәге искәртүгә басыгыз.",
+		"Сез әле искәртү өстәмәдегез.",
+		"Сакланды: %s.", "Бетерелде: %s.",
+		"Кабатлау (daily, mon,thu кебек көннәр, hijri:13 яки дата), вакыт (21:30 яки fajr+20 кебек намаз) һәм 200 билгегә кадәр текст җибәрегез.",
+		"Иң күбе %d искәртү саклап була. Яңасын өстәр өчен берсен бетерегез.",
+		"Бу дата һәм вакыт узып киткән.",
+		"🔔 %s",
+		"Һәр көн", "Һәр %s", "Һәр һиҗри айның %d көне", "%s",
+		"%[2]s алдыннан %[1]d минут", "%[2]s артыннан %[1]d минут",
+		"Сайланган көннәрдә", "Һиҗри көн буенча айлык", "Бер тапкыр",
+		"Текст", "Кабатлау", "Билгеле вакытта", "Намазга карата", "Намаздан соңгы минутлар (алдан өчен тискәре)",
+		"Атна көннәре", "Һиҗри көн", "Дата",
+		"Искәртү өстәү", "Үзгәртү", "Бетерү", "Баш тарту",
+	},
+}
+
+func init() {
+	for code, copy := range customReminderCopies {
+		locale := locales[code]
+		locale.Commands["custom"] = copy.Command
+		for key, value := range map[string]string{
+			"custom_title": copy.Title, "custom_help": copy.Help, "custom_empty": copy.Empty,
+			"custom_saved": copy.Saved, "custom_deleted": copy.Deleted, "custom_invalid": copy.Invalid,
+			"custom_limit": copy.Limit, "custom_past": copy.Past, "reminder_custom": copy.Reminder,
+			"custom_daily": copy.Daily, "custom_weekly": copy.Weekly,
+			"custom_hijri_monthly": copy.HijriMonthly, "custom_once": copy.Once,
+			"custom_before": copy.Before, "custom_after": copy.After,
+			"custom_weekly_option": copy.WeeklyOption, "custom_hijri_option": copy.HijriOption,
+			"custom_once_option": copy.OnceOption,
+			"custom_text":        copy.Text, "custom_repeat": copy.Repeat, "custom_clock": copy.Clock,
+			"custom_prayer": copy.Prayer, "custom_offset": copy.Offset, "custom_weekdays": copy.Weekdays,
+			"custom_hijri_day": copy.HijriDay, "custom_date": copy.Date,
+			"custom_add": copy.Add, "custom_edit": copy.Edit, "custom_delete": copy.Delete, "custom_cancel": copy.Cancel,
+		} {
+			locale.Text[key] = value
+		}
+	}
+}
+
+// CustomReminderSchedule is "Every Mon, Thu · 20 min after Fajr" or
+// "On 2 November 2026 · 09:00".
+func (l Locale) CustomReminderSchedule(reminder domain.CustomReminder) string {
+	var repeat string
+	switch reminder.Recurrence {
+	case domain.RecurrenceWeekly:
+		var days []string
+		for _, day := range reminder.Weekdays.Days() {
+			days = append(days, l.Weekday(day))
+		}
+		repeat = fmt.Sprintf(l.Message("custom_weekly"), strings.Join(days, ", "))
+	case domain.RecurrenceHijriMonthly:
+		repeat = fmt.Sprintf(l.Message("custom_hijri_monthly"), reminder.HijriDay)
+	case domain.RecurrenceOnce:
+		repeat = reminder.Date
+		if date, err := time.Parse(time.DateOnly, reminder.Date); err == nil {
+			repeat = fmt.Sprintf(l.Message("custom_once"), fmt.Sprintf("%s %d", l.dayMonth(date), date.Year()))
+		}
+	default:
+		repeat = l.Message("custom_daily")
+	}
+	return repeat + " · " + l.CustomReminderTime(reminder)
+}
+
+// CustomReminderTime is the clock time of the reminder or where it falls
+// around its prayer, such as "10 min before Maghrib".
+func (l Locale) CustomReminderTime(reminder domain.CustomReminder) string {
+	switch {
+	case !reminder.Anchored():
+		return reminder.LocalTime
+	case reminder.OffsetMinutes < 0:
+		return fmt.Sprintf(l.Message("custom_before"), -reminder.OffsetMinutes, l.Prayer(reminder.Anchor))
+	case reminder.OffsetMinutes > 0:
+		return fmt.Sprintf(l.Message("custom_after"), reminder.OffsetMinutes, l.Prayer(reminder.Anchor))
+	default:
+		return l.Prayer(reminder.Anchor)
+	}
+}
@@ -0,0 +1,60 @@
+package i18n
+
+type elevationCopy struct {
+	Button, Label, Value, Choose, SeaLevel, Detect string
+}
+
+var elevationCopies = map[string]elevationCopy{
+	"en": {
+		"⛰ Elevation", "Elevation", "%d m",
+		"<b>Elevation</b> ⛰\n\nFrom higher ground the sun rises earlier and sets later. The bot looks up the elevation when you share a location; correct it here if you are on a mountain or high in a building.",
+		"🌊 Sea level", "📍 Detect again",
+	},
+	"ar": {
+		"⛰ الارتفاع", "الارتفاع", "%d م",
+		"<b>الارتفاع</b> ⛰\n\nمن المكان المرتفع تشرق الشمس أبكر وتغرب متأخرة. يبحث البوت عن الارتفاع عند مشاركة موقعك؛ عدّله هنا إن كنت على جبل أو في طابق عالٍ.",
+		"🌊 مستوى البحر", "📍 اكتشاف من جديد",
+	},
+	"es": {
+		"⛰ Altitud", "Altitud", "%d m",
+		"<b>Altitud</b> ⛰\n\nDesde un lugar elevado el sol sale antes y se pone más tarde. El bot consulta la altitud cuando compartes tu ubicación; corrígela aquí si estás en una montaña o en un piso alto.",
+		"🌊 Nivel del mar", "📍 Detectar de nuevo",
+	},
+	"fr": {
+		"⛰ Altitude", "Altitude", "%d m",
+		"<b>Altitude</b> ⛰\n\nEn hauteur, le soleil se lève plus tôt et se couche plus tard. Le bot recherche l'altitude lorsque vous partagez votre position ; corrigez-la ici si vous êtes en montagne ou dans un étage élevé.",
+		"🌊 Niveau de la mer", "📍 Détecter à nouveau",
+	},
+	"ru": {
+		"⛰ Высота", "Высота", "%d м",
+		"<b>Высота над уровнем моря</b> ⛰\n\nС возвышенности солнце восходит раньше и заходит позже. Бот определяет высоту, когда вы отправляете местоположение; исправьте её здесь, если вы в горах или на верхнем этаже.",
+		"🌊 Уровень моря", "📍 Определить заново",
+	},
+	"tr": {
+		"⛰ Rakım", "Rakım", "%d m",
+		"<b>Rakım</b> ⛰\n\nYüksek bir yerden güneş daha erken doğar ve daha geç batar. Bot, konum paylaştığınızda rakımı bulur; dağdaysanız veya yüksek bir kattaysanız burada düzeltin.",
+		"🌊 Deniz seviyesi", "📍 Yeniden bul",
+	},
+	"uz": {
+		"⛰ Balandlik", "Balandlik", "%d m",
+		"<b>Balandlik</b> ⛰\n\nBaland joydan quyosh ertaroq chiqadi va kechroq botadi. Bot joylashuvni yuborganingizda balandlikni aniqlaydi; tog'da yoki baland qavatda bo'lsangiz, shu yerda tuzating.",
+		"🌊 Dengiz sathi", "📍 Qayta aniqlash",
+	},
+	"tt": {
+		"⛰ Биеклек", "Биеклек", "%d м",
+		"<b>Биеклек</b> ⛰\n\nБиек урыннан кояш иртәрәк чыга һәм соңрак бата. Бот урыныгызны җибәргәндә биеклекне билгели; тауда яки биек катта булсагыз, монда төзәтегез.",
+		"🌊 Диңгез дәрәҗәсе", "📍 Яңадан билгеләү",
+	},
+}
+
+func init() {
+	for code, copy := range elevationCopies {
+		locale := locales[code]
+		locale.Buttons["elevation"] = copy.Button
+		locale.Buttons["elevation_sea_level"] = copy.SeaLevel
+		locale.Buttons["elevation_detect"] = copy.Detect
+		locale.Text["elevation"] = copy.Label
+		locale.Text["elevation_value"] = copy.Value
+		locale.Text["choose_elevation"] = copy.Choose
+	}
+}
@@ -0,0 +1,96 @@
+package i18n
+
+import "github.com/escalopa/prayer-bot/global/internal/domain"
+
+type extendedTimesCopy struct {
+	Imsak, Ishraq, Duha, Midnight, LastThird                                        string
+	Title, Button, Choose, Show, ImsakMinutes, Reminders, ChooseReminders, Reminder string
+}
+
+var extendedTimesCopies = map[string]extendedTimesCopy{
+	"en": {
+		"Imsak", "Ishraq", "Duha", "Islamic midnight", "Last third of the night",
+		"Extended times", "✨ Extended times",
+		"<b>Extended times</b> ✨\n\nImsak, Ishraq, Duha, Islamic midnight, and the last third of the night are derived from the calculated prayers. Show them in the daily schedule and calendar, and choose how long before Fajr Imsak falls.",
+		"Show in schedule", "Imsak %d min before Fajr", "✨ Extended time reminders",
+		"<b>Extended time reminders</b> ✨\nChoose which extended times send a notification.",
+		"<b>%s</b> has begun · <code>%s</code> ✨",
+	},
+	"ar": {
+		"الإمساك", "الإشراق", "الضحى", "منتصف الليل الشرعي", "الثلث الأخير من الليل",
+		"أوقات إضافية", "✨ أوقات إضافية",
+		"<b>أوقات إضافية</b> ✨\n\nتُشتق أوقات الإمساك والإشراق والضحى ومنتصف الليل الشرعي والثلث الأخير من الليل من أوقات الصلاة المحسوبة. اعرضها في جدول اليوم والتقويم، واختر كم دقيقة يسبق الإمساكُ الفجر.",
+		"عرضها في الجدول", "الإمساك قبل الفجر بـ %d دقيقة", "✨ تذكيرات الأوقات الإضافية",
+		"<b>تذكيرات الأوقات الإضافية</b> ✨\nاختر الأوقات التي تريد التنبيه عندها.",
+		"دخل وقت <b>%s</b> · <code>%s</code> ✨",
+	},
+	"es": {
+		"Imsak", "Ishraq", "Duha", "Medianoche islámica", "Último tercio de la noche",
+		"Horarios adicionales", "✨ Horarios adicionales",
+		"<b>Horarios adicionales</b> ✨\n\nImsak, Ishraq, Duha, la medianoche islámica y el último tercio de la noche se derivan de las oraciones calculadas. Muéstralos en el horario diario y el calendario, y elige cuántos minutos antes de Fajr cae el Imsak.",
+		"Mostrar en el horario", "Imsak %d min antes de Fajr", "✨ Avisos de horarios adicionales",
+		"<b>Avisos de horarios adicionales</b> ✨\nElige qué horarios envían una notificación.",
+		"Ha comenzado <b>%s</b> · <code>%s</code> ✨",
+	},
+	"fr": {
+		"Imsak", "Ishraq", "Duha", "Minuit islamique", "Dernier tiers de la nuit",
+		"Horaires complémentaires", "✨ Horaires complémentaires",
+		"<b>Horaires complémentaires</b> ✨\n\nImsak, Ishraq, Duha, le minuit islamique et le dernier tiers de la nuit sont déduits des prières calculées. Affichez-les dans l'horaire du jour et le calendrier, et choisissez combien de minutes avant Fajr tombe l'Imsak.",
+		"Afficher dans l'horaire", "Imsak %d min avant Fajr", "✨ Rappels des horaires complémentaires",
+		"<b>Rappels des horaires complémentaires</b> ✨\nChoisissez les horaires qui envoient une notification.",
+		"<b>%s</b> a commencé · <code>%s</code> ✨",
+	},
+	"ru": {
+		"Имсак", "Ишрак", "Духа", "Исламская полночь", "Последняя треть ночи",
+		"Дополнительное время", "✨ Дополнительное время",
+		"<b>Дополнительное время</b> ✨\n\nИмсак, Ишрак, Духа, исламская полночь и последняя треть ночи выводятся из рассчитанного времени намаза. Показывайте их в расписании и календаре и выберите, за сколько минут до Фаджра наступает имсак.",
+		"Показывать в расписании", "Имсак за %d мин. до Фаджра", "✨ Напоминания о доп. времени",
+		"<b>Напоминания о дополнительном времени</b> ✨\nВыберите, о каком времени присылать уведомление.",
+		"Наступило время: <b>%s</b> · <code>%s</code> ✨",
+	},
+	"tr": {
+		// Turkish timetables already call Fajr "İmsak", so the precautionary
+		// margin is named after the end of suhoor instead.
+		"Sahur bitişi", "İşrak", "Kuşluk", "Şer'i gece yarısı", "Gecenin son üçte biri",
+		"Ek vakitler", "✨ Ek vakitler",
+		"<b>Ek vakitler</b> ✨\n\nSahur bitişi, İşrak, Kuşluk, şer'i gece yarısı ve gecenin son üçte biri hesaplanan vakitlerden türetilir. Bunları günlük vakitlerde ve takvimde gösterin, sahurun İmsak'tan kaç dakika önce bittiğini seçin.",
+		"Vakitlerde göster", "Sahur bitişi İmsak'tan %d dk önce", "✨ Ek vakit hatırlatıcıları",
+		"<b>Ek vakit hatırlatıcıları</b> ✨\nHangi vakitlerde bildirim almak istediğinizi seçin.",
+		"<b>%s</b> vakti girdi · <code>%s</code> ✨",
+	},
+	"uz": {
+		"Imsak", "Ishroq", "Zuho", "Shar'iy yarim tun", "Tunning oxirgi uchdan biri",
+		"Qo'shimcha vaqtlar", "✨ Qo'shimcha vaqtlar",
+		"<b>Qo'shimcha vaqtlar</b> ✨\n\nImsak, Ishroq, Zuho, shar'iy yarim tun va tunning oxirgi uchdan biri hisoblangan namoz vaqtlaridan olinadi. Ularni kunlik jadval va taqvimda ko'rsating hamda Imsak Bomdoddan necha daqiqa oldin bo'lishini tanlang.",
+		"Jadvalda ko'rsatish", "Imsak Bomdoddan %d daq oldin", "✨ Qo'shimcha vaqt eslatmalari",
+		"<b>Qo'shimcha vaqt eslatmalari</b> ✨\nQaysi vaqtlarda bildirishnoma kelishini tanlang.",
+		"<b>%s</b> vaqti kirdi · <code>%s</code> ✨",
+	},
+	"tt": {
+		"Имсак", "Ишрак", "Духа", "Шәригый төн уртасы", "Төннең соңгы өчтән бере",
+		"Өстәмә вакытлар", "✨ Өстәмә вакытлар",
+		"<b>Өстәмә вакытлар</b> ✨\n\nИмсак, Ишрак, Духа, шәригый төн уртасы һәм төннең соңгы өчтән бере исәпләнгән намаз вакытларыннан чыгарыла. Аларны көндәлек расписаниедә һәм календарьда күрсәтегез, һәм имсак Фәҗердән ничә минут алда булуын сайлагыз.",
+		"Расписаниедә күрсәтү", "Имсак Фәҗердән %d мин алда", "✨ Өстәмә вакыт искәртүләре",
+		"<b>Өстәмә вакыт искәртүләре</b> ✨\nКайсы вакытлар өчен хәбәр җибәрергә икәнен сайлагыз.",
+		"<b>%s</b> вакыты җитте · <code>%s</code> ✨",
+	},
+}
+
+func init() {
+	for code, copy := range extendedTimesCopies {
+		locale := locales[code]
+		locale.Prayers[domain.PrayerImsak] = copy.Imsak
+		locale.Prayers[domain.PrayerIshraq] = copy.Ishraq
+		locale.Prayers[domain.PrayerDuha] = copy.Duha
+		locale.Prayers[domain.PrayerMidnight] = copy.Midnight
+		locale.Prayers[domain.PrayerLastThird] = copy.LastThird
+		locale.Buttons["extended_times"] = copy.Button
+		locale.Buttons["extended_reminders"] = copy.Reminders
+		locale.Text["extended_times_title"] = copy.Title
+		locale.Text["choose_extended_times"] = copy.Choose
+		locale.Text["show_extended_times"] = copy.Show
+		locale.Text["imsak_minutes"] = copy.ImsakMinutes
+		locale.Text["choose_extended_reminders"] = copy.ChooseReminders
+		locale.Text["reminder_extended"] = copy.Reminder
+	}
+}
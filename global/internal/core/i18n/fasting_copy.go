@@ -0,0 +1,141 @@
+package i18n
+
+import (
+	"strings"
+
+	"github.com/escalopa/prayer-bot/global/internal/core/fasting"
+	"github.com/escalopa/prayer-bot/global/internal/core/occasions"
+)
+
+// fastingCopy is the /fasting command, the Mini App fasting month and the
+// fasting calendar feed. The statuses and reasons follow fasting.Status and
+// fasting.Reason; occasions are named by their catalog titles.
+type fastingCopy struct {
+	Command, Title, Help, Note          string
+	Obligatory, Recommended, Prohibited string
+	Ramadan, Monday, Thursday, White    string
+	CalendarTitle, CalendarHelp         string
+	Previous, Next                      string
+}
+
+var fastingCopies = map[string]fastingCopy{
+	"en": {
+		"Show the fasting calendar", "Fasting calendar",
+		"The next 30 days by your Hijri calendar and correction.",
+		"Fasting is prohibited on both Eids and the three days of Tashreeq. A local moon sighting may move these days.",
+		"Obligatory", "Recommended", "Do not fast",
+		"Ramadan", "Monday", "Thursday", "White day",
+		"Fasting calendar", "Subscribe to the recommended and prohibited fasting days as a separate calendar.",
+		"Previous month", "Next month",
+	},
+	"ar": {
+		"عرض تقويم الصيام", "تقويم الصيام",
+		"الأيام الثلاثون القادمة وفق تقويمك الهجري وتصحيحك.",
+		"يحرم صيام يومي العيدين وأيام التشريق الثلاثة. قد تنقل رؤية الهلال المحلية هذه الأيام.",
+		"واجب", "مستحب", "لا تصم",
+		"رمضان", "الاثنين", "الخميس", "من الأيام البيض",
+		"تقويم الصيام", "اشترك في أيام الصيام المستحبة والمحرمة كتقويم مستقل.",
+		"الشهر السابق", "الشهر التالي",
+	},
+	"es": {
+		"Ver el calendario de ayuno", "Calendario de ayuno",
+		"Los próximos 30 días según tu calendario hiyri y tu corrección.",
+		"Está prohibido ayunar en las dos Eid y en los tres días de Tashriq. El avistamiento lunar local puede mover estos días.",
+		"Obligatorio", "Recomendado", "No ayunar",
+		"Ramadán", "Lunes", "Jueves", "Día blanco",
+		"Calendario de ayuno", "Suscríbete a los días de ayuno recomendados y prohibidos como un calendario aparte.",
+		"Mes anterior", "Mes siguiente",
+	},
+	"fr": {
+		"Voir le calendrier du jeûne", "Calendrier du jeûne",
+		"Les 30 prochains jours selon votre calendrier hégirien et votre correction.",
+		"Le jeûne est interdit les deux jours de l'Aïd et les trois jours de Tachrik. L'observation locale de la lune peut déplacer ces jours.",
+		"Obligatoire", "Recommandé", "Ne pas jeûner",
+		"Ramadan", "Lundi", "Jeudi", "Jour blanc",
+		"Calendrier du jeûne", "Abonnez-vous aux jours de jeûne recommandés et interdits dans un calendrier séparé.",
+		"Mois précédent", "Mois suivant",
+	},
+	"ru": {
+		"Показать календарь поста", "Календарь поста",
+		"Ближайшие 30 дней по вашему календарю Хиджры и поправке.",
+		"Поститься запрещено в оба праздника Ид и в три дня ташрика. Местное наблюдение луны может сдвинуть эти дни.",
+		"Обязательный", "Желательный", "Не поститься",
+		"Рамадан", "Понедельник", "Четверг", "Белый день",
+		"Календарь поста", "Подпишитесь на желательные и запретные дни поста отдельным календарём.",
+		"Предыдущий месяц", "Следующий месяц",
+	},
+	"tr": {
+		"Oruç takvimini göster", "Oruç takvimi",
+		"Hicri takviminize ve düzeltmenize göre önümüzdeki 30 gün.",
+		"İki bayram gününde ve üç teşrik gününde oruç tutmak haramdır. Yerel hilal gözlemi bu günleri değiştirebilir.",
+		"Farz", "Müstehap", "Oruç tutulmaz",
+		"Ramazan", "Pazartesi", "Perşembe", "Beyaz gün",
+		"Oruç takvimi", "Müstehap ve yasak oruç günlerine ayrı bir takvim olarak abone olun.",
+		"Önceki ay", "Sonraki ay",
+	},
+	"uz": {
+		"Ro‘za taqvimini ko‘rsatish", "Ro‘za taqvimi",
+		"Hijriy taqvimingiz va tuzatishingizga ko‘ra keyingi 30 kun.",
+		"Ikki hayit kuni va uch tashriq kunida ro‘za tutish harom. Mahalliy hilol ko‘rinishi bu kunlarni surishi mumkin.",
+		"Farz", "Mustahab", "Ro‘za tutilmaydi",
+		"Ramazon", "Dushanba", "Payshanba", "Oq kun",
+		"Ro‘za taqvimi", "Mustahab va man qilingan ro‘za kunlariga alohida taqvim sifatida obuna bo‘ling.",
+		"Oldingi oy", "Keyingi oy",
+	},
+	"tt": {
+		"Ураза календарен күрсәтү", "Ураза календаре",
+		"Һиҗри календарегез һәм төзәтмәгез буенча киләсе 30 көн.",
+		"Ике бәйрәм көнендә һәм өч ташрикъ көнендә ураза тоту тыела. Җирле яңа ай күзәтүе бу көннәрне күчерергә мөмкин.",
+		"Фарыз", "Мөстәхәб", "Ураза тотылмый",
+		"Рамазан", "Дүшәмбе", "Пәнҗешәмбе", "Ак көн",
+		"Ураза календаре", "Мөстәхәб һәм тыелган ураза көннәренә аерым календарь итеп язылыгыз.",
+		"Алдагы ай", "Киләсе ай",
+	},
+}
+
+func init() {
+	for code, copy := range fastingCopies {
+		locale := locales[code]
+		locale.Commands["fasting"] = copy.Command
+		locale.Text["fasting_title"] = copy.Title
+		locale.Text["fasting_help"] = copy.Help
+		locale.Text["fasting_note"] = copy.Note
+		locale.Text["fasting_"+string(fasting.StatusObligatory)] = copy.Obligatory
+		locale.Text["fasting_"+string(fasting.StatusRecommended)] = copy.Recommended
+		locale.Text["fasting_"+string(fasting.StatusProhibited)] = copy.Prohibited
+		locale.Text["fasting_reason_"+string(fasting.ReasonRamadan)] = copy.Ramadan
+		locale.Text["fasting_reason_"+string(fasting.ReasonMonday)] = copy.Monday
+		locale.Text["fasting_reason_"+string(fasting.ReasonThursday)] = copy.Thursday
+		locale.Text["fasting_reason_"+string(fasting.ReasonWhiteDays)] = copy.White
+		locale.Text["fasting_calendar_title"] = copy.CalendarTitle
+		locale.Text["fasting_calendar_help"] = copy.CalendarHelp
+		locale.Text["fasting_previous"] = copy.Previous
+		locale.Text["fasting_next"] = copy.Next
+	}
+}
+
+func (l Locale) FastingStatus(status fasting.Status) string {
+	return l.Message("fasting_" + string(status))
+}
+
+// FastingReasons is "White day, Monday" or "Six days of Shawwal · day 2 of
+// 6", naming an occasion by its catalog title and, when the day carries its
+// occurrence, the day of the observance.
+func (l Locale) FastingReasons(day fasting.Day) string {
+	names := make([]string, 0, len(day.Reasons))
+	for _, reason := range day.Reasons {
+		copy, ok := occasions.Translate(string(reason), l.Code)
+		if !ok {
+			names = append(names, l.Message("fasting_reason_"+string(reason)))
+			continue
+		}
+		name := copy.Title
+		if day.Occasion != nil && string(reason) == day.Occasion.Definition.ID {
+			if label := l.OccasionDay(*day.Occasion); label != "" {
+				name += " · " + label
+			}
+		}
+		names = append(names, name)
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,30 @@
+package i18n
+
+import (
+	"testing"
+
+	"github.com/escalopa/prayer-bot/global/internal/core/fasting"
+	"github.com/escalopa/prayer-bot/global/internal/core/occasions"
+)
+
+func TestFastingReasonsNameOccasionsByTheirTitles(t *testing.T) {
+	var sixShawwal occasions.Definition
+	for _, definition := range occasions.Catalog() {
+		if definition.ID == "six_shawwal" {
+			sixShawwal = definition
+		}
+	}
+	occurrence := occasions.Occurrence{Definition: sixShawwal, Day: 2, Days: 6}
+	day := fasting.Day{
+		Status:   fasting.StatusRecommended,
+		Reasons:  []fasting.Reason{"six_shawwal", fasting.ReasonThursday},
+		Occasion: &occurrence,
+	}
+	english := Resolve("en")
+	if got, want := english.FastingReasons(day), "Six days of Shawwal · day 2 of 6, Thursday"; got != want {
+		t.Fatalf("FastingReasons = %q, want %q", got, want)
+	}
+	if got := english.FastingStatus(fasting.StatusProhibited); got != "Do not fast" {
+		t.Fatalf("FastingStatus = %q", got)
+	}
+}
@@ -0,0 +1,140 @@
+package i18n
+
+import "github.com/escalopa/prayer-bot/global/internal/domain"
+
+// fastingLogCopy is the /fasts command, the buttons under fasting reminders
+// and the Mini App log. Fasting, MakeUp and NotToday are the buttons; Kept,
+// MadeUp, Missed and NotFasted name what a day was logged as.
+type fastingLogCopy struct {
+	Command, Title, Help                string
+	Fasting, MakeUp, NotToday           string
+	Kept, MadeUp, Missed, NotFasted     string
+	Carried, Owed, Suggestions, Today   string
+	Prohibited, RamadanMakeUp           string
+	CarriedSaved, CarriedInvalid, Clear string
+	Private                             string
+}
+
+var fastingLogCopies = map[string]fastingLogCopy{
+	"en": {
+		"Log fasts and make-up days", "Fasting log",
+		"Tap the buttons under suhoor and fasting reminders, or mark days in the Mini App. Send /fasts 5 to add make-up fasts owed from before this log.",
+		"I'm fasting", "Make-up fast", "Not today",
+		"Fasted", "Made up", "Missed in Ramadan", "Not fasted",
+		"Owed from before", "Make-up fasts owed", "Recommended days to make up", "Today",
+		"Fasting is prohibited on this day, so it was not logged.", "A day of Ramadan cannot be a make-up fast.",
+		"Make-up fasts owed from before: %d.", "Send a number of days from 0 to 1000, such as /fasts 5.", "Clear",
+		"The fasting log is kept in a private chat with the bot.",
+	},
+	"ar": {
+		"سجل الصيام وأيام القضاء", "سجل الصيام",
+		"اضغط الأزرار تحت تذكيرات السحور والصيام، أو حدد الأيام في التطبيق المصغر. أرسل ‎/fasts 5 لإضافة أيام قضاء كانت عليك قبل هذا السجل.",
+		"أنا صائم", "صيام قضاء", "ليس اليوم",
+		"صمت", "قضيت", "أفطرت في رمضان", "لم أصم",
+		"عليك من قبل", "أيام القضاء المتبقية", "أيام مستحبة للقضاء", "اليوم",
+		"يحرم الصيام في هذا اليوم، لذلك لم يُسجل.", "لا يكون يوم من رمضان صيام قضاء.",
+		"أيام القضاء السابقة: %d.", "أرسل عدد الأيام من 0 إلى 1000، مثل ‎/fasts 5.", "مسح",
+		"يُحفظ سجل الصيام في محادثة خاصة مع البوت.",
+	},
+	"es": {
+		"Registrar ayunos y días de recuperación", "Registro de ayunos",
+		"Pulsa los botones bajo los recordatorios de suhur y ayuno, o marca los días en la Mini App. Envía /fasts 5 para añadir ayunos de recuperación que debías antes de este registro.",
+		"Estoy ayunando", "Ayuno de recuperación", "Hoy no",
+		"Ayunado", "Recuperado", "Perdido en Ramadán", "Sin ayunar",
+		"Pendientes de antes", "Ayunos de recuperación pendientes", "Días recomendados para recuperar", "Hoy",
+		"Está prohibido ayunar este día, así que no se registró.", "Un día de Ramadán no puede ser un ayuno de recuperación.",
+		"Ayunos de recuperación pendientes de antes: %d.", "Envía un número de días de 0 a 1000, como /fasts 5.", "Borrar",
+		"El registro de ayunos se lleva en un chat privado con el bot.",
+	},
+	"fr": {
+		"Noter ses jeûnes et rattrapages", "Journal du jeûne",
+		"Touchez les boutons sous les rappels du suhoor et du jeûne, ou marquez les jours dans la Mini App. Envoyez /fasts 5 pour ajouter les jours à rattraper d'avant ce journal.",
+		"Je jeûne", "Jeûne de rattrapage", "Pas aujourd'hui",
+		"Jeûné", "Rattrapé", "Manqué pendant le Ramadan", "Non jeûné",
+		"Dus d'avant", "Jours à rattraper", "Jours recommandés pour rattraper", "Aujourd'hui",
+		"Le jeûne est interdit ce jour-là, il n'a donc pas été noté.", "Un jour du Ramadan ne peut pas être un jeûne de rattrapage.",
+		"Jours à rattraper d'avant : %d.", "Envoyez un nombre de jours de 0 à 1000, par exemple /fasts 5.", "Effacer",
+		"Le journal du jeûne se tient dans une discussion privée avec le bot.",
+	},
+	"ru": {
+		"Отмечать посты и возмещение", "Дневник поста",
+		"Нажимайте кнопки под напоминаниями о сухуре и посте или отмечайте дни в Mini App. Отправьте /fasts 5, чтобы добавить дни возмещения, накопленные до этого дневника.",
+		"Я пощусь", "Возмещаю пост", "Не сегодня",
+		"Постился", "Возмещено", "Пропущено в Рамадан", "Без поста",
+		"Долг до дневника", "Осталось возместить", "Желательные дни для возмещения", "Сегодня",
+		"В этот день поститься запрещено, поэтому он не отмечен.", "День Рамадана не может быть возмещением.",
+		"Дни возмещения до дневника: %d.", "Отправьте число дней от 0 до 1000, например /fasts 5.", "Очистить",
+		"Дневник поста ведётся в личном чате с ботом.",
+	},
+	"tr": {
+		"Oruçları ve kazaları kaydet", "Oruç kaydı",
+		"Sahur ve oruç hatırlatmalarının altındaki düğmelere dokunun veya günleri Mini App'te işaretleyin. Bu kayıttan önceki kaza borcunu eklemek için /fasts 5 gönderin.",
+		"Oruçluyum", "Kaza orucu", "Bugün değil",
+		"Tutuldu", "Kaza edildi", "Ramazan'da tutulmadı", "Tutulmadı",
+		"Önceden kalan", "Kalan kaza orucu", "Kaza için müstehap günler", "Bugün",
+		"Bu gün oruç tutmak haramdır, bu yüzden kaydedilmedi.", "Ramazan'ın bir günü kaza orucu olamaz.",
+		"Önceden kalan kaza orucu: %d.", "0 ile 1000 arasında bir gün sayısı gönderin, örneğin /fasts 5.", "Temizle",
+		"Oruç kaydı bot ile özel sohbette tutulur.",
+	},
+	"uz": {
+		"Ro‘za va qazolarni belgilash", "Ro‘za daftari",
+		"Saharlik va ro‘za eslatmalari ostidagi tugmalarni bosing yoki kunlarni Mini App’da belgilang. Bu daftardan oldingi qazo ro‘zalarni qo‘shish uchun /fasts 5 yuboring.",
+		"Ro‘zadorman", "Qazo ro‘za", "Bugun emas",
+		"Tutildi", "Qazo qilindi", "Ramazonda qoldirilgan", "Tutilmadi",
+		"Avvaldan qolgan", "Qolgan qazo ro‘zalar", "Qazo uchun mustahab kunlar", "Bugun",
+		"Bu kuni ro‘za tutish harom, shuning uchun belgilanmadi.", "Ramazon kuni qazo ro‘za bo‘lolmaydi.",
+		"Avvaldan qolgan qazo ro‘zalar: %d.", "0 dan 1000 gacha kunlar sonini yuboring, masalan /fasts 5.", "Tozalash",
+		"Ro‘za daftari bot bilan shaxsiy suhbatda yuritiladi.",
+	},
+	"tt": {
+		"Ураза һәм каза көннәрен билгеләү", "Ураза дәфтәре",
+		"Сәхәр һәм ураза искәртүләре астындагы төймәләргә басыгыз яки көннәрне Mini App’та билгеләгез. Бу дәфтәргә кадәрге каза көннәрен өстәү өчен /fasts 5 җибәрегез.",
+		"Мин уразада", "Каза уразасы", "Бүген түгел",
+		"Тотылды", "Каза кылынды", "Рамазанда калдырылды", "Тотылмады",
+		"Элеккедән калган", "Калган каза уразалары", "Каза өчен мөстәхәб көннәр", "Бүген",
+		"Бу көнне ураза тоту тыела, шуңа күрә ул билгеләнмәде.", "Рамазан көне каза уразасы була алмый.",
+		"Элеккедән калган каза уразалары: %d.", "0 дән 1000 гә кадәр көннәр санын җибәрегез, мәсәлән /fasts 5.", "Чистарту",
+		"Ураза дәфтәре бот белән шәхси сөйләшүдә алып барыла.",
+	},
+}
+
+func init() {
+	for code, copy := range fastingLogCopies {
+		locale := locales[code]
+		locale.Commands["fasts"] = copy.Command
+		locale.Text["fasts_title"] = copy.Title
+		locale.Text["fasts_help"] = copy.Help
+		locale.Text["fasts_fasting"] = copy.Fasting
+		locale.Text["fasts_makeup"] = copy.MakeUp
+		locale.Text["fasts_not_today"] = copy.NotToday
+		locale.Text["fasts_kept"] = copy.Kept
+		locale.Text["fasts_made_up"] = copy.MadeUp
+		locale.Text["fasts_missed"] = copy.Missed
+		locale.Text["fasts_not_fasted"] = copy.NotFasted
+		locale.Text["fasts_carried"] = copy.Carried
+		locale.Text["fasts_owed"] = copy.Owed
+		locale.Text["fasts_suggestions"] = copy.Suggestions
+		locale.Text["fasts_today"] = copy.Today
+		locale.Text["fasts_prohibited"] = copy.Prohibited
+		locale.Text["fasts_ramadan_makeup"] = copy.RamadanMakeUp
+		locale.Text["fasts_carried_saved"] = copy.CarriedSaved
+		locale.Text["fasts_carried_invalid"] = copy.CarriedInvalid
+		locale.Text["fasts_clear"] = copy.Clear
+		locale.Text["fasts_private"] = copy.Private
+	}
+}
+
+// FastLogged names what a day was logged as. Not fasting on a Ramadan day is
+// "Missed in Ramadan"; on any other day it is only "Not fasted".
+func (l Locale) FastLogged(entry domain.FastLogEntry) string {
+	switch {
+	case entry.Status == domain.FastKept:
+		return l.Message("fasts_kept")
+	case entry.Status == domain.FastMakeUp:
+		return l.Message("fasts_made_up")
+	case entry.Owed():
+		return l.Message("fasts_missed")
+	default:
+		return l.Message("fasts_not_fasted")
+	}
+}
@@ -0,0 +1,23 @@
+package i18n
+
+import "github.com/escalopa/prayer-bot/global/internal/domain"
+
+var nearestHighLatitudeCopies = map[string]map[domain.HighLatitudeRule]string{
+	"en": {domain.HighLatitudeNearestLatitude: "Nearest latitude (48.5°)", domain.HighLatitudeNearestDay: "Nearest normal day"},
+	"ar": {domain.HighLatitudeNearestLatitude: "أقرب البلاد (48.5°)", domain.HighLatitudeNearestDay: "أقرب الأيام"},
+	"es": {domain.HighLatitudeNearestLatitude: "Latitud más cercana (48,5°)", domain.HighLatitudeNearestDay: "Día normal más cercano"},
+	"fr": {domain.HighLatitudeNearestLatitude: "Latitude la plus proche (48,5°)", domain.HighLatitudeNearestDay: "Jour normal le plus proche"},
+	"ru": {domain.HighLatitudeNearestLatitude: "Ближайшая широта (48,5°)", domain.HighLatitudeNearestDay: "Ближайший обычный день"},
+	"tr": {domain.HighLatitudeNearestLatitude: "En yakın enlem (48,5°)", domain.HighLatitudeNearestDay: "En yakın normal gün"},
+	"uz": {domain.HighLatitudeNearestLatitude: "Eng yaqin kenglik (48,5°)", domain.HighLatitudeNearestDay: "Eng yaqin oddiy kun"},
+	"tt": {domain.HighLatitudeNearestLatitude: "Иң якын киңлек (48,5°)", domain.HighLatitudeNearestDay: "Иң якын гадәти көн"},
+}
+
+func init() {
+	for code, labels := range nearestHighLatitudeCopies {
+		locale := locales[code]
+		for rule, label := range labels {
+			locale.HighLatitude[rule] = label
+		}
+	}
+}
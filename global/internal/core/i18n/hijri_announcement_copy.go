@@ -0,0 +1,52 @@
+package i18n
+
+// hijriAnnouncementCopy is what chats see of a regional month announcement:
+// the note under a Hijri date it decided, and the notice sent when the owner
+// publishes one. Notice takes the Hijri month with its year and the
+// Gregorian date the month began.
+type hijriAnnouncementCopy struct {
+	Note, Notice string
+}
+
+var hijriAnnouncementCopies = map[string]hijriAnnouncementCopy{
+	"en": {
+		"%s · announced locally",
+		"🌙 <b>%s</b> began on <b>%s</b> in your country, as announced by the local authorities. Hijri dates, Ramadan and occasion reminders now follow the announcement.",
+	},
+	"ar": {
+		"%s · بحسب الإعلان المحلي",
+		"🌙 بدأ <b>%s</b> يوم <b>%s</b> في بلدك بحسب إعلان الجهات المحلية. أصبحت التواريخ الهجرية وتذكيرات رمضان والمناسبات تتبع هذا الإعلان.",
+	},
+	"es": {
+		"%s · anunciada localmente",
+		"🌙 <b>%s</b> comenzó el <b>%s</b> en tu país, según anunciaron las autoridades locales. Las fechas hiyri y los recordatorios de Ramadán y de ocasiones siguen ahora el anuncio.",
+	},
+	"fr": {
+		"%s · annoncée localement",
+		"🌙 <b>%s</b> a commencé le <b>%s</b> dans votre pays, selon l'annonce des autorités locales. Les dates hégiriennes et les rappels du Ramadan et des occasions suivent désormais cette annonce.",
+	},
+	"ru": {
+		"%s · по местному объявлению",
+		"🌙 <b>%s</b> начался <b>%s</b> в вашей стране, как объявили местные власти. Даты Хиджры и напоминания о Рамадане и памятных днях теперь следуют этому объявлению.",
+	},
+	"tr": {
+		"%s · yerel duyuruya göre",
+		"🌙 <b>%s</b>, yerel yetkililerin duyurusuna göre ülkenizde <b>%s</b> tarihinde başladı. Hicri tarihler, Ramazan ve özel gün hatırlatıcıları artık bu duyuruya göre.",
+	},
+	"uz": {
+		"%s · mahalliy e’lon bo‘yicha",
+		"🌙 Mahalliy idoralar e’loniga ko‘ra, mamlakatingizda <b>%s</b> <b>%s</b> kuni boshlandi. Hijriy sanalar, Ramazon va muborak kunlar eslatmalari endi shu e’longa amal qiladi.",
+	},
+	"tt": {
+		"%s · җирле белдерү буенча",
+		"🌙 Җирле хакимият белдерүенчә, илегездә <b>%s</b> <b>%s</b> көнне башланды. Һиҗри даталар, Рамазан һәм истәлекле көннәр искәртүләре хәзер шул белдерүгә иярә.",
+	},
+}
+
+func init() {
+	for code, copy := range hijriAnnouncementCopies {
+		locale := locales[code]
+		locale.Text["hijri_note_announced"] = copy.Note
+		locale.Text["hijri_announcement_notice"] = copy.Notice
+	}
+}
@@ -0,0 +1,35 @@
+package i18n
+
+import "github.com/escalopa/prayer-bot/global/internal/domain"
+
+type hijriCalendarCopy struct {
+	Label                                 string
+	UmmAlQura, Tabular, Diyanet, Crescent string
+}
+
+var hijriCalendarCopies = map[string]hijriCalendarCopy{
+	"en": {"Calendar", "Umm al-Qura", "Tabular (arithmetic)", "Diyanet (Turkey)", "Crescent visibility"},
+	"ar": {"التقويم", "أم القرى", "الحسابي (الجدولي)", "ديانت (تركيا)", "رؤية الهلال"},
+	"es": {"Calendario", "Umm al-Qura", "Tabular (aritmético)", "Diyanet (Turquía)", "Visibilidad del creciente"},
+	"fr": {"Calendrier", "Umm al-Qura", "Tabulaire (arithmétique)", "Diyanet (Turquie)", "Visibilité du croissant"},
+	"ru": {"Календарь", "Умм аль-Кура", "Табличный (арифметический)", "Диянет (Турция)", "Видимость полумесяца"},
+	"tr": {"Takvim", "Ümmü'l-Kurâ", "Tablolu (aritmetik)", "Diyanet (Türkiye)", "Hilal görünürlüğü"},
+	"uz": {"Taqvim", "Umm al-Qura", "Jadvalli (arifmetik)", "Diyanet (Turkiya)", "Hilol ko‘rinishi"},
+	"tt": {"Календарь", "Умм әл-Кура", "Җәдвәлле (арифметик)", "Диянәт (Төркия)", "Яңа ай күренеше"},
+}
+
+// HijriCalendar names a Hijri calendar system, the default when unset.
+func (l Locale) HijriCalendar(system domain.HijriCalendar) string {
+	return l.Message("hijri_calendar_" + string(system.OrDefault()))
+}
+
+func init() {
+	for code, copy := range hijriCalendarCopies {
+		locale := locales[code]
+		locale.Text["hijri_calendar"] = copy.Label
+		locale.Text["hijri_calendar_"+string(domain.HijriUmmAlQura)] = copy.UmmAlQura
+		locale.Text["hijri_calendar_"+string(domain.HijriTabular)] = copy.Tabular
+		locale.Text["hijri_calendar_"+string(domain.HijriDiyanet)] = copy.Diyanet
+		locale.Text["hijri_calendar_"+string(domain.HijriCrescent)] = copy.Crescent
+	}
+}
@@ -0,0 +1,102 @@
+package i18n
+
+type jumuahCopy struct {
+	Command, Button, Name, Khutbah, Iqamah, NotSet string
+	Choose, Saved, Cleared, Invalid, Clear         string
+	Minutes, ReminderOff, Reminder                 string
+}
+
+var jumuahCopies = map[string]jumuahCopy{
+	"en": {
+		"Set your mosque's Jumu'ah khutbah time", "Jumu'ah", "Jumu'ah", "Khutbah", "Iqamah", "not set",
+		"<b>Jumu'ah</b> 🕌\nSend <code>/jumuah 13:15</code> with your mosque's khutbah time, or <code>/jumuah 13:15 13:45</code> to add the iqamah. On Fridays it replaces Dhuhr in the schedule, reminders and calendar feed.\n\nChoose how long before the khutbah to be reminded.",
+		"Jumu'ah saved: khutbah at %s.", "Jumu'ah time cleared; Fridays show Dhuhr again.",
+		"Send the khutbah time as HH:MM, optionally followed by an iqamah time up to 90 minutes later, such as <code>/jumuah 13:15 13:45</code>.",
+		"Clear Jumu'ah time",
+		"%d min before the khutbah", "No Jumu'ah reminder",
+		"🕌 <b>Jumu'ah</b> in %d min · khutbah at <code>%s</code>",
+	},
+	"ar": {
+		"اضبط وقت خطبة الجمعة في مسجدك", "الجمعة", "الجمعة", "الخطبة", "الإقامة", "غير محدد",
+		"<b>صلاة الجمعة</b> 🕌\nأرسل <code>/jumuah 13:15</code> بوقت الخطبة في مسجدك، أو <code>/jumuah 13:15 13:45</code> لإضافة الإقامة. يحل هذا الوقت محل الظهر يوم الجمعة في الجدول والتذكيرات والتقويم.\n\nاختر كم دقيقة قبل الخطبة تريد التذكير.",
+		"تم حفظ الجمعة: الخطبة الساعة %s.", "تم حذف وقت الجمعة، ويظهر الظهر يوم الجمعة مجددًا.",
+		"أرسل وقت الخطبة بصيغة HH:MM، ويمكن إضافة وقت الإقامة خلال 90 دقيقة بعدها، مثل <code>/jumuah 13:15 13:45</code>.",
+		"حذف وقت الجمعة",
+		"قبل الخطبة بـ %d دقيقة", "بدون تذكير بالجمعة",
+		"🕌 <b>الجمعة</b> بعد %d دقيقة · الخطبة <code>%s</code>",
+	},
+	"es": {
+		"Fija la hora del jutba del viernes de tu mezquita", "Yumu'a", "Yumu'a", "Jutba", "Iqama", "sin fijar",
+		"<b>Yumu'a</b> 🕌\nEnvía <code>/jumuah 13:15</code> con la hora del jutba de tu mezquita, o <code>/jumuah 13:15 13:45</code> para añadir la iqama. Los viernes sustituye a Dhuhr en el horario, los avisos y el calendario.\n\nElige con cuánta antelación al jutba quieres el aviso.",
+		"Yumu'a guardada: jutba a las %s.", "Hora de la Yumu'a borrada; los viernes vuelven a mostrar Dhuhr.",
+		"Envía la hora del jutba como HH:MM y, si quieres, una hora de iqama hasta 90 minutos después, por ejemplo <code>/jumuah 13:15 13:45</code>.",
+		"Borrar hora de la Yumu'a",
+		"%d min antes del jutba", "Sin aviso de Yumu'a",
+		"🕌 <b>Yumu'a</b> en %d min · jutba a las <code>%s</code>",
+	},
+	"fr": {
+		"Réglez l'heure du prêche du vendredi de votre mosquée", "Joumou'a", "Joumou'a", "Khoutba", "Iqama", "non défini",
+		"<b>Joumou'a</b> 🕌\nEnvoyez <code>/jumuah 13:15</code> avec l'heure de la khoutba de votre mosquée, ou <code>/jumuah 13:15 13:45</code> pour ajouter l'iqama. Le vendredi, elle remplace Dhuhr dans les horaires, les rappels et le calendrier.\n\nChoisissez combien de temps avant la khoutba être prévenu.",
+		"Joumou'a enregistrée : khoutba à %s.", "Heure de Joumou'a effacée ; le vendredi affiche de nouveau Dhuhr.",
+		"Envoyez l'heure de la khoutba au format HH:MM, éventuellement suivie d'une iqama au plus 90 minutes après, par exemple <code>/jumuah 13:15 13:45</code>.",
+		"Effacer l'heure de Joumou'a",
+		"%d min avant la khoutba", "Pas de rappel de Joumou'a",
+		"🕌 <b>Joumou'a</b> dans %d min · khoutba à <code>%s</code>",
+	},
+	"ru": {
+		"Время пятничной хутбы в вашей мечети", "Джума", "Джума", "Хутба", "Икама", "не задано",
+		"<b>Джума</b> 🕌\nОтправьте <code>/jumuah 13:15</code> со временем хутбы в вашей мечети или <code>/jumuah 13:15 13:45</code>, чтобы добавить икаму. По пятницам оно заменяет Зухр в расписании, напоминаниях и календаре.\n\nВыберите, за сколько минут до хутбы напомнить.",
+		"Джума сохранена: хутба в %s.", "Время джумы удалено; по пятницам снова показывается Зухр.",
+		"Отправьте время хутбы в формате ЧЧ:ММ и, при желании, время икамы не позже чем через 90 минут, например <code>/jumuah 13:15 13:45</code>.",
+		"Удалить время джумы",
+		"За %d мин до хутбы", "Без напоминания о джуме",
+		"🕌 <b>Джума</b> через %d мин · хутба в <code>%s</code>",
+	},
+	"tr": {
+		"Caminizin cuma hutbesi saatini ayarlayın", "Cuma", "Cuma", "Hutbe", "Kamet", "ayarlanmadı",
+		"<b>Cuma namazı</b> 🕌\nCaminizin hutbe saatiyle <code>/jumuah 13:15</code> ya da kameti eklemek için <code>/jumuah 13:15 13:45</code> gönderin. Cuma günleri vakit listesinde, hatırlatmalarda ve takvimde öğlenin yerini alır.\n\nHutbeden kaç dakika önce hatırlatılacağını seçin.",
+		"Cuma kaydedildi: hutbe %s.", "Cuma saati silindi; cuma günleri yeniden öğle gösteriliyor.",
+		"Hutbe saatini SS:DD olarak, isterseniz ardından en fazla 90 dakika sonraki kamet saatiyle gönderin, örneğin <code>/jumuah 13:15 13:45</code>.",
+		"Cuma saatini sil",
+		"Hutbeden %d dk önce", "Cuma hatırlatması yok",
+		"🕌 <b>Cuma</b> %d dk sonra · hutbe <code>%s</code>",
+	},
+	"uz": {
+		"Masjidingizdagi juma xutbasi vaqtini belgilang", "Juma", "Juma", "Xutba", "Iqoma", "belgilanmagan",
+		"<b>Juma namozi</b> 🕌\nMasjidingizdagi xutba vaqti bilan <code>/jumuah 13:15</code> yoki iqomani qoʻshish uchun <code>/jumuah 13:15 13:45</code> yuboring. Juma kunlari u jadvalda, eslatmalarda va taqvimda peshin oʻrnini egallaydi.\n\nXutbadan necha daqiqa oldin eslatilishini tanlang.",
+		"Juma saqlandi: xutba soat %s.", "Juma vaqti oʻchirildi; juma kunlari yana peshin koʻrsatiladi.",
+		"Xutba vaqtini SS:DD koʻrinishida, xohlasangiz undan keyin 90 daqiqa ichidagi iqoma vaqti bilan yuboring, masalan <code>/jumuah 13:15 13:45</code>.",
+		"Juma vaqtini oʻchirish",
+		"Xutbadan %d daq oldin", "Juma eslatmasi yoʻq",
+		"🕌 <b>Juma</b> %d daqiqadan soʻng · xutba <code>%s</code>",
+	},
+	"tt": {
+		"Мәчетегездәге җомга вәгазе вакытын көйләгез", "Җомга", "Җомга", "Хотбә", "Камәт", "көйләнмәгән",
+		"<b>Җомга намазы</b> 🕌\nМәчетегездәге хотбә вакыты белән <code>/jumuah 13:15</code> яки камәтне өстәү өчен <code>/jumuah 13:15 13:45</code> җибәрегез. Җомга көннәрендә ул вакытлар исемлегендә, искәртүләрдә һәм календарьда өйләне алыштыра.\n\nХотбәгә кадәр ничә минут алдан искәртергә икәнен сайлагыз.",
+		"Җомга сакланды: хотбә %s.", "Җомга вакыты бетерелде; җомга көннәрендә яңадан өйлә күрсәтелә.",
+		"Хотбә вакытын СС:ММ рәвешендә, теләсәгез аннан соң 90 минут эчендәге камәт вакыты белән җибәрегез, мәсәлән <code>/jumuah 13:15 13:45</code>.",
+		"Җомга вакытын бетерү",
+		"Хотбәгә %d мин кала", "Җомга искәртүе юк",
+		"🕌 <b>Җомга</b> %d минуттан · хотбә <code>%s</code>",
+	},
+}
+
+func init() {
+	for code, copy := range jumuahCopies {
+		locale := locales[code]
+		locale.Commands["jumuah"] = copy.Command
+		locale.Buttons["jumuah"] = copy.Button
+		locale.Buttons["jumuah_clear"] = copy.Clear
+		locale.Text["jumuah"] = copy.Name
+		locale.Text["jumuah_khutbah"] = copy.Khutbah
+		locale.Text["jumuah_iqamah"] = copy.Iqamah
+		locale.Text["jumuah_not_set"] = copy.NotSet
+		locale.Text["choose_jumuah"] = copy.Choose
+		locale.Text["jumuah_saved"] = copy.Saved
+		locale.Text["jumuah_cleared"] = copy.Cleared
+		locale.Text["jumuah_invalid"] = copy.Invalid
+		locale.Text["jumuah_minutes"] = copy.Minutes
+		locale.Text["jumuah_reminder_off"] = copy.ReminderOff
+		locale.Text["reminder_jumuah"] = copy.Reminder
+	}
+}
@@ -0,0 +1,72 @@
+package i18n
+
+import "github.com/escalopa/prayer-bot/global/internal/domain"
+
+type makruhCopy struct {
+	Sunrise, Zenith, Sunset     string
+	Title, Button, Choose, Show string
+}
+
+var makruhCopies = map[string]makruhCopy{
+	"en": {
+		"Sunrise", "Zenith", "Sunset",
+		"Makruh times", "🚫 Makruh times",
+		"<b>Makruh times</b> 🚫\n\nVoluntary prayers are disliked while the sun rises until it is a spear's length high, while it stands at its zenith before Dhuhr, and while it yellows before sunset. The windows are estimated from the calculated prayer times.",
+		"Show in schedule",
+	},
+	"ar": {
+		"الشروق", "الاستواء", "الغروب",
+		"أوقات الكراهة", "🚫 أوقات الكراهة",
+		"<b>أوقات الكراهة</b> 🚫\n\nتُكره صلاة النافلة من طلوع الشمس حتى ترتفع قيد رمح، وعند استوائها قبل الظهر، وعند اصفرارها قبل الغروب. تُقدَّر هذه الأوقات من مواقيت الصلاة المحسوبة.",
+		"عرضها في الجدول",
+	},
+	"es": {
+		"Amanecer", "Cenit", "Puesta del sol",
+		"Horas makruh", "🚫 Horas makruh",
+		"<b>Horas makruh</b> 🚫\n\nLas oraciones voluntarias son desaconsejadas mientras sale el sol hasta que alcanza la altura de una lanza, mientras está en su cenit antes de Dhuhr y mientras amarillea antes de ponerse. Los intervalos se estiman a partir de los horarios calculados.",
+		"Mostrar en el horario",
+	},
+	"fr": {
+		"Lever du soleil", "Zénith", "Coucher du soleil",
+		"Heures makruh", "🚫 Heures makruh",
+		"<b>Heures makruh</b> 🚫\n\nLes prières surérogatoires sont déconseillées pendant que le soleil se lève jusqu'à la hauteur d'une lance, lorsqu'il est au zénith avant Dhuhr et lorsqu'il jaunit avant de se coucher. Ces intervalles sont estimés à partir des horaires calculés.",
+		"Afficher dans l'horaire",
+	},
+	"ru": {
+		"Восход", "Зенит", "Закат",
+		"Нежелательное время", "🚫 Нежелательное время",
+		"<b>Нежелательное время</b> 🚫\n\nДобровольные намазы нежелательны во время восхода, пока солнце не поднимется на высоту копья, когда оно стоит в зените перед Зухром и когда желтеет перед закатом. Интервалы оцениваются по рассчитанному времени намаза.",
+		"Показывать в расписании",
+	},
+	"tr": {
+		"Güneş doğarken", "İstiva", "Güneş batarken",
+		"Kerahat vakitleri", "🚫 Kerahat vakitleri",
+		"<b>Kerahat vakitleri</b> 🚫\n\nGüneş doğarken bir mızrak boyu yükselinceye kadar, öğleden önce tepe noktasındayken ve batmadan önce sararırken nafile namaz mekruhtur. Bu aralıklar hesaplanan vakitlerden tahmin edilir.",
+		"Vakitlerde göster",
+	},
+	"uz": {
+		"Quyosh chiqishi", "Zavol", "Quyosh botishi",
+		"Makruh vaqtlar", "🚫 Makruh vaqtlar",
+		"<b>Makruh vaqtlar</b> 🚫\n\nQuyosh chiqayotganda u nayza bo'yi ko'tarilguncha, peshindan oldin tik turganda va botishdan oldin sarg'ayganda nafl namoz o'qish makruh. Bu oraliqlar hisoblangan namoz vaqtlaridan taxmin qilinadi.",
+		"Jadvalda ko'rsatish",
+	},
+	"tt": {
+		"Кояш чыгу", "Зәвәл", "Кояш бату",
+		"Мәкруһ вакытлар", "🚫 Мәкруһ вакытлар",
+		"<b>Мәкруһ вакытлар</b> 🚫\n\nКояш чыкканда ул сөңге биеклегенә күтәрелгәнче, өйләдән алда зенитта торганда һәм баер алдыннан саргайганда нәфел намаз мәкруһ. Бу аралыклар исәпләнгән намаз вакытларыннан чамаланып чыгарыла.",
+		"Расписаниедә күрсәтү",
+	},
+}
+
+func init() {
+	for code, copy := range makruhCopies {
+		locale := locales[code]
+		locale.Text["makruh_"+string(domain.MakruhSunrise)] = copy.Sunrise
+		locale.Text["makruh_"+string(domain.MakruhZenith)] = copy.Zenith
+		locale.Text["makruh_"+string(domain.MakruhSunset)] = copy.Sunset
+		locale.Buttons["makruh_times"] = copy.Button
+		locale.Text["makruh_title"] = copy.Title
+		locale.Text["choose_makruh"] = copy.Choose
+		locale.Text["show_makruh"] = copy.Show
+	}
+}
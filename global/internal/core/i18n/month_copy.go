@@ -0,0 +1,111 @@
+package i18n
+
+import "time"
+
+type monthCopy struct {
+	Command, Title, Range, Date, Weekday, Hijri, Occasions, Choose, Invalid string
+	// Help, Sent and Failed are the Mini App's download card.
+	Help, Sent, Failed string
+	Weekdays           [7]string
+}
+
+var monthCopies = map[string]monthCopy{
+	"en": {
+		"Monthly timetable as PDF, PNG or CSV", "Prayer timetable", "%d–%d %s %d",
+		"Date", "Weekday", "Hijri", "Occasions",
+		"<b>Prayer timetable</b> 🗓\n%s\n\nPDF prints on A4, PNG is easy to share and CSV opens in a spreadsheet.",
+		"Send /month, or add a format and month such as <code>/month csv 2026-04</code>. Months up to a year away are available.",
+		"PDF prints on A4, PNG is easy to share and CSV opens in a spreadsheet.",
+		"Timetable sent to this bot chat.", "The timetable could not be sent.",
+		[7]string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"},
+	},
+	"ar": {
+		"جدول الشهر بصيغة PDF أو PNG أو CSV", "جدول مواقيت الصلاة", "%d–%d %s %d",
+		"التاريخ", "اليوم", "هجري", "المناسبات",
+		"<b>جدول مواقيت الصلاة</b> 🗓\n%s\n\nملف PDF للطباعة على ورق A4، وPNG للمشاركة بسهولة، وCSV لجداول البيانات.",
+		"أرسل /month، أو أضف الصيغة والشهر مثل <code>/month csv 2026-04</code>. تتوفر الأشهر حتى عام من الآن.",
+		"ملف PDF للطباعة على ورق A4، وPNG للمشاركة بسهولة، وCSV لجداول البيانات.",
+		"تم إرسال الجدول إلى محادثة البوت.", "تعذر إرسال الجدول.",
+		[7]string{"أحد", "إثنين", "ثلاثاء", "أربعاء", "خميس", "جمعة", "سبت"},
+	},
+	"es": {
+		"Horario mensual en PDF, PNG o CSV", "Horario de oraciones", "%d–%d de %s de %d",
+		"Fecha", "Día", "Hégira", "Ocasiones",
+		"<b>Horario de oraciones</b> 🗓\n%s\n\nEl PDF se imprime en A4, el PNG es fácil de compartir y el CSV se abre en una hoja de cálculo.",
+		"Envía /month o añade un formato y un mes, por ejemplo <code>/month csv 2026-04</code>. Hay meses disponibles hasta un año de distancia.",
+		"El PDF se imprime en A4, el PNG es fácil de compartir y el CSV se abre en una hoja de cálculo.",
+		"El horario se envió al chat del bot.", "No se pudo enviar el horario.",
+		[7]string{"dom", "lun", "mar", "mié", "jue", "vie", "sáb"},
+	},
+	"fr": {
+		"Horaires du mois en PDF, PNG ou CSV", "Horaires des prières", "%d–%d %s %d",
+		"Date", "Jour", "Hégire", "Événements",
+		"<b>Horaires des prières</b> 🗓\n%s\n\nLe PDF s'imprime en A4, le PNG se partage facilement et le CSV s'ouvre dans un tableur.",
+		"Envoyez /month, ou ajoutez un format et un mois comme <code>/month csv 2026-04</code>. Les mois jusqu'à un an d'écart sont disponibles.",
+		"Le PDF s'imprime en A4, le PNG se partage facilement et le CSV s'ouvre dans un tableur.",
+		"Les horaires ont été envoyés dans le chat du bot.", "Impossible d'envoyer les horaires.",
+		[7]string{"dim.", "lun.", "mar.", "mer.", "jeu.", "ven.", "sam."},
+	},
+	"ru": {
+		"Расписание на месяц в PDF, PNG или CSV", "Расписание намазов", "%d–%d %s %d",
+		"Дата", "День недели", "Хиджра", "События",
+		"<b>Расписание намазов</b> 🗓\n%s\n\nPDF печатается на листе A4, PNG удобно отправить, а CSV открывается в таблице.",
+		"Отправьте /month или добавьте формат и месяц, например <code>/month csv 2026-04</code>. Доступны месяцы в пределах года.",
+		"PDF печатается на листе A4, PNG удобно отправить, а CSV открывается в таблице.",
+		"Расписание отправлено в чат с ботом.", "Не удалось отправить расписание.",
+		[7]string{"Вс", "Пн", "Вт", "Ср", "Чт", "Пт", "Сб"},
+	},
+	"tr": {
+		"PDF, PNG veya CSV olarak aylık vakitler", "Namaz vakitleri", "%d–%d %s %d",
+		"Tarih", "Gün", "Hicri", "Özel günler",
+		"<b>Namaz vakitleri</b> 🗓\n%s\n\nPDF A4 kâğıda basılır, PNG kolayca paylaşılır, CSV ise bir tabloda açılır.",
+		"/month gönderin ya da <code>/month csv 2026-04</code> gibi bir biçim ve ay ekleyin. Bir yıla kadar uzaktaki aylar kullanılabilir.",
+		"PDF A4 kâğıda basılır, PNG kolayca paylaşılır, CSV ise bir tabloda açılır.",
+		"Vakitler bot sohbetine gönderildi.", "Vakitler gönderilemedi.",
+		[7]string{"Paz", "Pzt", "Sal", "Çar", "Per", "Cum", "Cmt"},
+	},
+	"uz": {
+		"Oylik jadval: PDF, PNG yoki CSV", "Namoz vaqtlari jadvali", "%d–%d %s %d",
+		"Sana", "Hafta kuni", "Hijriy", "Muborak kunlar",
+		"<b>Namoz vaqtlari jadvali</b> 🗓\n%s\n\nPDF A4 varaqqa chop etiladi, PNG ulashish uchun qulay, CSV esa jadval dasturida ochiladi.",
+		"/month yuboring yoki format va oyni qoʻshing, masalan <code>/month csv 2026-04</code>. Bir yilgacha boʻlgan oylar mavjud.",
+		"PDF A4 varaqqa chop etiladi, PNG ulashish uchun qulay, CSV esa jadval dasturida ochiladi.",
+		"Jadval bot chatiga yuborildi.", "Jadvalni yuborib boʻlmadi.",
+		[7]string{"Yak", "Dush", "Sesh", "Chor", "Pay", "Jum", "Shan"},
+	},
+	"tt": {
+		"Айлык вакытлар: PDF, PNG яки CSV", "Намаз вакытлары", "%d–%d %s %d",
+		"Көн", "Атна көне", "Һиҗри", "Истәлекле көннәр",
+		"<b>Намаз вакытлары</b> 🗓\n%s\n\nPDF A4 битенә басыла, PNG белән бүлешү җиңел, ә CSV таблицада ачыла.",
+		"/month җибәрегез яки <code>/month csv 2026-04</code> кебек формат һәм ай өстәгез. Бер ел эчендәге айлар бар.",
+		"PDF A4 битенә басыла, PNG белән бүлешү җиңел, ә CSV таблицада ачыла.",
+		"Вакытлар бот чатына җибәрелде.", "Вакытларны җибәреп булмады.",
+		[7]string{"Якш", "Дүш", "Сиш", "Чәр", "Пәнҗ", "Җом", "Шим"},
+	},
+}
+
+func init() {
+	for code, copy := range monthCopies {
+		locale := locales[code]
+		locale.Commands["month"] = copy.Command
+		locale.Text["month_title"] = copy.Title
+		locale.Text["month_range"] = copy.Range
+		locale.Text["month_date"] = copy.Date
+		locale.Text["month_weekday"] = copy.Weekday
+		locale.Text["month_hijri"] = copy.Hijri
+		locale.Text["month_occasions"] = copy.Occasions
+		locale.Text["choose_month"] = copy.Choose
+		locale.Text["month_invalid"] = copy.Invalid
+		locale.Text["month_help"] = copy.Help
+		locale.Text["month_sent"] = copy.Sent
+		locale.Text["month_failed"] = copy.Failed
+	}
+}
+
+// Weekday returns the short weekday name used in monthly timetables.
+func (l Locale) Weekday(day time.Weekday) string {
+	if copy, ok := monthCopies[l.Code]; ok {
+		return copy.Weekdays[day]
+	}
+	return monthCopies["en"].Weekdays[day]
+}
@@ -0,0 +1,145 @@
+package i18n
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/escalopa/prayer-bot/global/internal/core/moon"
+)
+
+// moonCopy is the moon line of schedules and the moon events of calendar
+// feeds. Phases follow moon.Phases. Lit takes the lit percentage, Age the
+// day of the lunation, NewMoon the date and time of the conjunction,
+// Crescent and NoCrescent the evening's date, and NewMoonNote the time.
+type moonCopy struct {
+	Phases                        [8]string
+	Lit, Age                      string
+	NewMoon, Crescent, NoCrescent string
+	NewMoonEvent, CrescentEvent   string
+	NewMoonNote, CrescentNote     string
+	CalendarEvents, CalendarHelp  string
+}
+
+var moonCopies = map[string]moonCopy{
+	"en": {
+		[8]string{"New moon", "Waxing crescent", "First quarter", "Waxing gibbous", "Full moon", "Waning gibbous", "Last quarter", "Waning crescent"},
+		"%d%% lit", "moon day %d",
+		"New moon %s", "look for the crescent after sunset on %s", "the crescent is unlikely to be seen by %s",
+		"New moon", "First crescent",
+		"The astronomical new moon is at %s. The crescent cannot be seen yet.",
+		"The new crescent is expected to be visible to the naked eye after sunset from your location. The month begins by your calendar or the local sighting.",
+		"Moon in calendar", "Adds the new moon and the expected first crescent as all-day events.",
+	},
+	"ar": {
+		[8]string{"محاق", "هلال متزايد", "تربيع أول", "أحدب متزايد", "بدر", "أحدب متناقص", "تربيع أخير", "هلال متناقص"},
+		"مضاء %d%%", "اليوم %d من الشهر القمري",
+		"الاقتران %s", "تحرَّ الهلال بعد غروب %s", "يُستبعد رؤية الهلال حتى %s",
+		"الاقتران", "الهلال الأول",
+		"يقع الاقتران الفلكي في %s. لا يمكن رؤية الهلال بعد.",
+		"يُتوقع أن يُرى الهلال الجديد بالعين المجردة بعد الغروب من موقعك. يبدأ الشهر بحسب تقويمك أو الرؤية المحلية.",
+		"القمر في التقويم", "يضيف الاقتران وأول رؤية متوقعة للهلال كأحداث ليوم كامل.",
+	},
+	"es": {
+		[8]string{"Luna nueva", "Creciente", "Cuarto creciente", "Gibosa creciente", "Luna llena", "Gibosa menguante", "Cuarto menguante", "Menguante"},
+		"%d%% iluminada", "día lunar %d",
+		"Luna nueva %s", "busca el creciente tras la puesta del sol el %s", "es poco probable ver el creciente antes del %s",
+		"Luna nueva", "Primer creciente",
+		"La luna nueva astronómica es a las %s. El creciente aún no puede verse.",
+		"Se espera que el nuevo creciente sea visible a simple vista tras la puesta del sol desde tu ubicación. El mes empieza según tu calendario o el avistamiento local.",
+		"Luna en el calendario", "Añade la luna nueva y el primer creciente esperado como eventos de día completo.",
+	},
+	"fr": {
+		[8]string{"Nouvelle lune", "Premier croissant", "Premier quartier", "Gibbeuse croissante", "Pleine lune", "Gibbeuse décroissante", "Dernier quartier", "Dernier croissant"},
+		"éclairée à %d %%", "jour lunaire %d",
+		"Nouvelle lune %s", "cherchez le croissant après le coucher du soleil le %s", "le croissant ne devrait pas être visible avant le %s",
+		"Nouvelle lune", "Premier croissant visible",
+		"La nouvelle lune astronomique a lieu à %s. Le croissant n'est pas encore visible.",
+		"Le nouveau croissant devrait être visible à l'œil nu après le coucher du soleil depuis votre position. Le mois commence selon votre calendrier ou l'observation locale.",
+		"Lune dans le calendrier", "Ajoute la nouvelle lune et le premier croissant attendu en événements d'une journée.",
+	},
+	"ru": {
+		[8]string{"Новолуние", "Растущий серп", "Первая четверть", "Растущая луна", "Полнолуние", "Убывающая луна", "Последняя четверть", "Убывающий серп"},
+		"освещена на %d%%", "%d-й лунный день",
+		"Новолуние %s", "ищите полумесяц после заката %s", "полумесяц вряд ли будет виден до %s",
+		"Новолуние", "Первый полумесяц",
+		"Астрономическое новолуние в %s. Полумесяц ещё не виден.",
+		"Новый полумесяц, вероятно, будет виден невооружённым глазом после заката в вашем месте. Месяц начинается по вашему календарю или местному наблюдению.",
+		"Луна в календаре", "Добавляет новолуние и ожидаемый первый полумесяц как события на весь день.",
+	},
+	"tr": {
+		[8]string{"Yeni ay", "Büyüyen hilal", "İlk dördün", "Büyüyen şişkin ay", "Dolunay", "Küçülen şişkin ay", "Son dördün", "Küçülen hilal"},
+		"%%%d aydınlık", "kameri gün %d",
+		"Yeni ay %s", "hilali %s akşamı gün batımından sonra arayın", "hilalin %s tarihine kadar görülmesi beklenmiyor",
+		"Yeni ay", "İlk hilal",
+		"Astronomik yeni ay %s saatinde. Hilal henüz görülemez.",
+		"Yeni hilalin konumunuzdan gün batımından sonra çıplak gözle görülmesi bekleniyor. Ay, takviminize veya yerel rüyete göre başlar.",
+		"Takvimde ay evreleri", "Yeni ayı ve beklenen ilk hilali tüm gün etkinlikleri olarak ekler.",
+	},
+	"uz": {
+		[8]string{"Yangi oy", "O‘suvchi hilol", "Birinchi chorak", "O‘suvchi oy", "To‘lin oy", "Kamayuvchi oy", "Oxirgi chorak", "Kamayuvchi hilol"},
+		"%d%% yoritilgan", "oy kuni %d",
+		"Yangi oy %s", "hilolni %s kuni quyosh botgandan keyin qidiring", "hilol %s gacha ko‘rinishi dargumon",
+		"Yangi oy", "Birinchi hilol",
+		"Astronomik yangi oy soat %s da. Hilol hali ko‘rinmaydi.",
+		"Yangi hilol joylashuvingizdan quyosh botgandan keyin oddiy ko‘z bilan ko‘rinishi kutilmoqda. Oy taqvimingiz yoki mahalliy ru’yatga ko‘ra boshlanadi.",
+		"Taqvimda oy", "Yangi oy va kutilayotgan birinchi hilolni kun bo‘yi voqealar sifatida qo‘shadi.",
+	},
+	"tt": {
+		[8]string{"Яңа ай", "Үсүче урак", "Беренче чирек", "Үсүче ай", "Тулган ай", "Кимүче ай", "Соңгы чирек", "Кимүче урак"},
+		"%d%% яктыртылган", "%d нче ай көне",
+		"Яңа ай %s", "яңа айны %s кояш баегач эзләгез", "яңа ай %s кадәр күренмәс дип көтелә",
+		"Яңа ай", "Беренче яңа ай",
+		"Астрономик яңа ай %s вакытында. Яңа ай әле күренми.",
+		"Яңа ай сезнең урыннан кояш баегач гади күз белән күренер дип көтелә. Ай календарегез яки җирле күзәтү буенча башлана.",
+		"Календарьда ай", "Яңа айны һәм көтелгән беренче яңа ай күренешен тулы көнлек вакыйгалар итеп өсти.",
+	},
+}
+
+func init() {
+	for code, copy := range moonCopies {
+		locale := locales[code]
+		for index, phase := range moon.Phases() {
+			locale.Text["moon_"+string(phase)] = copy.Phases[index]
+		}
+		locale.Text["moon_lit"] = copy.Lit
+		locale.Text["moon_age"] = copy.Age
+		locale.Text["moon_new"] = copy.NewMoon
+		locale.Text["moon_crescent"] = copy.Crescent
+		locale.Text["moon_no_crescent"] = copy.NoCrescent
+		locale.Text["moon_new_event"] = copy.NewMoonEvent
+		locale.Text["moon_crescent_event"] = copy.CrescentEvent
+		locale.Text["moon_new_note"] = copy.NewMoonNote
+		locale.Text["moon_crescent_note"] = copy.CrescentNote
+		locale.Text["moon_calendar_events"] = copy.CalendarEvents
+		locale.Text["moon_calendar_help"] = copy.CalendarHelp
+	}
+}
+
+func (l Locale) MoonPhase(phase moon.Phase) string {
+	return l.Message("moon_" + string(phase))
+}
+
+// MoonSummary is "Waxing crescent · 12% lit · moon day 3". The lunar day
+// counts the day of the new moon as the first.
+func (l Locale) MoonSummary(state moon.State) string {
+	return fmt.Sprintf("%s · %s · %s", l.MoonPhase(state.Phase),
+		fmt.Sprintf(l.Message("moon_lit"), int(math.Round(state.Illumination*100))),
+		fmt.Sprintf(l.Message("moon_age"), state.AgeDays()+1))
+}
+
+// CrescentWatch is "New moon 28 February 03:45 · look for the crescent after
+// sunset on 1 March", with the new moon in zone.
+func (l Locale) CrescentWatch(crescent moon.Crescent, zone *time.Location) string {
+	newMoon := crescent.NewMoon.In(zone)
+	text := fmt.Sprintf(l.Message("moon_new"), fmt.Sprintf("%d %s %s", newMoon.Day(), l.Month(int(newMoon.Month())), newMoon.Format("15:04")))
+	if crescent.Seen() {
+		return text + " · " + fmt.Sprintf(l.Message("moon_crescent"), l.dayMonth(crescent.Evening))
+	}
+	last := time.Date(newMoon.Year(), newMoon.Month(), newMoon.Day()+moon.LastEvening, 0, 0, 0, 0, zone)
+	return text + " · " + fmt.Sprintf(l.Message("moon_no_crescent"), l.dayMonth(last))
+}
+
+func (l Locale) dayMonth(date time.Time) string {
+	return fmt.Sprintf("%d %s", date.Day(), l.Month(int(date.Month())))
+}
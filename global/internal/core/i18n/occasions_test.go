@@ -1,11 +1,23 @@
 package i18n
 
 import (
+	"strings"
 	"testing"
 
+	"github.com/escalopa/prayer-bot/global/internal/core/hijri"
 	"github.com/escalopa/prayer-bot/global/internal/core/occasions"
 )
 
+func TestOccasionCatalogIsTranslatedIntoEverySupportedLanguage(t *testing.T) {
+	var codes []string
+	for _, locale := range Supported() {
+		codes = append(codes, locale.Code)
+	}
+	if got := occasions.Locales(); strings.Join(got, ",") != strings.Join(codes, ",") {
+		t.Fatalf("occasions/catalog.json lists %v, want %v", got, codes)
+	}
+}
+
 func TestEveryOccasionIsLocalized(t *testing.T) {
 	for _, locale := range Supported() {
 		for _, definition := range occasions.Catalog() {
@@ -17,10 +29,44 @@ func TestEveryOccasionIsLocalized(t *testing.T) {
 				t.Errorf("%s is missing category %s", locale.Code, definition.Category)
 			}
 		}
-		for _, key := range []string{"title", "help", "disclaimer", "recommended", "sources", "major_reminders", "fasting_reminders", "observed_reminders", "schedule"} {
+		if locale.OccasionCategory(string(occasions.CategoryPersonal)) == "" {
+			t.Errorf("%s is missing category %s", locale.Code, occasions.CategoryPersonal)
+		}
+		for _, key := range []string{"title", "help", "disclaimer", "recommended", "sources", "major_reminders", "fasting_reminders", "observed_reminders", "schedule",
+			"personal_reminders", "personal_schedule", "personal_hijri", "personal_gregorian", "day_of", "night", "no_fasting"} {
 			if locale.OccasionUI(key) == "" {
 				t.Errorf("%s is missing occasion UI key %s", locale.Code, key)
 			}
 		}
 	}
 }
+
+func TestOccasionDayNamesDaysAndNights(t *testing.T) {
+	var sixShawwal, oddNights occasions.Definition
+	for _, definition := range occasions.Catalog() {
+		switch definition.ID {
+		case "six_shawwal":
+			sixShawwal = definition
+		case "odd_nights":
+			oddNights = definition
+		}
+	}
+	english := Resolve("en")
+	for _, tc := range []struct {
+		occurrence occasions.Occurrence
+		want       string
+	}{
+		{occasions.Occurrence{Definition: sixShawwal, Hijri: hijri.Date{Month: 10, Day: 4}, Day: 3, Days: 6}, "day 3 of 6"},
+		{occasions.Occurrence{Definition: oddNights, Hijri: hijri.Date{Month: 9, Day: 27}, Day: 4, Days: 5}, "night 27"},
+		{occasions.Occurrence{Hijri: hijri.Date{Month: 12, Day: 9}, Day: 1, Days: 1}, ""},
+	} {
+		if got := english.OccasionDay(tc.occurrence); got != tc.want {
+			t.Errorf("OccasionDay(%+v) = %q, want %q", tc.occurrence.Hijri, got, tc.want)
+		}
+	}
+	for _, locale := range Supported() {
+		if text := locale.OccasionDay(occasions.Occurrence{Definition: sixShawwal, Day: 3, Days: 6}); strings.Contains(text, "%!") {
+			t.Errorf("%s day label is malformed: %q", locale.Code, text)
+		}
+	}
+}
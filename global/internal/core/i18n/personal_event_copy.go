@@ -0,0 +1,140 @@
+package i18n
+
+// personalEventCopy is the /events view of a chat's own yearly dates. Saved
+// and Deleted take the event title, Limit the most events a chat may keep,
+// and Reminder the date line of the evening-before reminder.
+type personalEventCopy struct {
+	Command, Title, Help, Empty      string
+	Saved, Deleted, Invalid, Limit   string
+	Reminder, Reminders, Schedule    string
+	Category, HijriEvent, CivilEvent string
+}
+
+var personalEventCopies = map[string]personalEventCopy{
+	"en": {
+		"Your own yearly dates: anniversaries, birthdays, vows",
+		"Personal dates",
+		"Add a yearly date in either calendar followed by its title, such as <code>/events 12 Rajab Grandfather's passing</code> or <code>/events 3 May Wedding anniversary</code>. Hijri dates follow your calendar and correction; a 30th falls on the 29th when the month is short. Tap a date below to remove it.",
+		"You have not added any dates yet.",
+		"Saved: %s.", "Removed: %s.",
+		"Send a day and month in either calendar, then a title of up to 64 characters.",
+		"You can keep up to %d dates. Remove one to add another.",
+		"🗓 <b>Tomorrow</b> · %s",
+		"Personal dates", "Evening before · 20:00 · add dates with /events",
+		"Personal date", "Your yearly Hijri date.", "Your yearly date.",
+	},
+	"ar": {
+		"تواريخك السنوية الخاصة: ذكرى، ميلاد، نذر",
+		"تواريخي الخاصة",
+		"أضف تاريخًا سنويًا بأي من التقويمين يليه عنوانه، مثل <code>/events 12 رجب وفاة الجد</code> أو <code>/events 3 مايو ذكرى الزواج</code>. تتبع التواريخ الهجرية تقويمك وتصحيحك، ويقع اليوم الثلاثون في التاسع والعشرين إذا نقص الشهر. اضغط على تاريخ أدناه لحذفه.",
+		"لم تضف أي تاريخ بعد.",
+		"تم الحفظ: %s.", "تم الحذف: %s.",
+		"أرسل اليوم والشهر بأي من التقويمين، ثم عنوانًا لا يتجاوز 64 حرفًا.",
+		"يمكنك حفظ %d تاريخًا كحد أقصى. احذف واحدًا لإضافة غيره.",
+		"🗓 <b>غدًا</b> · %s",
+		"تواريخي الخاصة", "مساء اليوم السابق · 20:00 · أضف التواريخ عبر /events",
+		"تاريخ خاص", "تاريخك الهجري السنوي.", "تاريخك السنوي.",
+	},
+	"es": {
+		"Tus propias fechas anuales: aniversarios, cumpleaños, promesas",
+		"Fechas personales",
+		"Añade una fecha anual en cualquiera de los dos calendarios seguida de su título, por ejemplo <code>/events 12 Rayab Fallecimiento del abuelo</code> o <code>/events 3 mayo Aniversario de boda</code>. Las fechas hiyri siguen tu calendario y tu corrección; un día 30 cae el 29 cuando el mes es corto. Toca una fecha abajo para borrarla.",
+		"Aún no has añadido ninguna fecha.",
+		"Guardada: %s.", "Borrada: %s.",
+		"Envía un día y un mes en cualquiera de los dos calendarios y luego un título de hasta 64 caracteres.",
+		"Puedes guardar hasta %d fechas. Borra una para añadir otra.",
+		"🗓 <b>Mañana</b> · %s",
+		"Fechas personales", "Víspera · 20:00 · añade fechas con /events",
+		"Fecha personal", "Tu fecha hiyri anual.", "Tu fecha anual.",
+	},
+	"fr": {
+		"Vos propres dates annuelles : anniversaires, vœux",
+		"Dates personnelles",
+		"Ajoutez une date annuelle dans l'un ou l'autre calendrier suivie de son titre, par exemple <code>/events 12 Rajab Décès du grand-père</code> ou <code>/events 3 mai Anniversaire de mariage</code>. Les dates hégiriennes suivent votre calendrier et votre correction ; un 30 tombe le 29 quand le mois est court. Touchez une date ci-dessous pour la supprimer.",
+		"Vous n'avez encore ajouté aucune date.",
+		"Enregistrée : %s.", "Supprimée : %s.",
+		"Envoyez un jour et un mois dans l'un ou l'autre calendrier, puis un titre de 64 caractères au plus.",
+		"Vous pouvez garder jusqu'à %d dates. Supprimez-en une pour en ajouter une autre.",
+		"🗓 <b>Demain</b> · %s",
+		"Dates personnelles", "La veille · 20:00 · ajoutez des dates avec /events",
+		"Date personnelle", "Votre date hégirienne annuelle.", "Votre date annuelle.",
+	},
+	"ru": {
+		"Ваши ежегодные даты: годовщины, дни рождения, обеты",
+		"Личные даты",
+		"Добавьте ежегодную дату в любом из календарей и её название, например <code>/events 12 Раджаб Кончина дедушки</code> или <code>/events 3 мая Годовщина свадьбы</code>. Даты Хиджры следуют вашему календарю и поправке; 30-е число переносится на 29-е, если месяц короткий. Нажмите на дату ниже, чтобы удалить её.",
+		"Вы пока не добавили ни одной даты.",
+		"Сохранено: %s.", "Удалено: %s.",
+		"Отправьте день и месяц в любом из календарей, затем название до 64 символов.",
+		"Можно хранить до %d дат. Удалите одну, чтобы добавить другую.",
+		"🗓 <b>Завтра</b> · %s",
+		"Личные даты", "Накануне · 20:00 · даты добавляются через /events",
+		"Личная дата", "Ваша ежегодная дата по Хиджре.", "Ваша ежегодная дата.",
+	},
+	"tr": {
+		"Kendi yıllık tarihleriniz: anma, doğum günü, adak",
+		"Kişisel tarihler",
+		"İki takvimden birinde yıllık bir tarihi başlığıyla birlikte ekleyin, örneğin <code>/events 12 Recep Dedemin vefatı</code> veya <code>/events 3 Mayıs Evlilik yıldönümü</code>. Hicri tarihler takviminize ve düzeltmenize göredir; ay kısa olduğunda 30'u 29'una denk gelir. Silmek için aşağıdaki bir tarihe dokunun.",
+		"Henüz tarih eklemediniz.",
+		"Kaydedildi: %s.", "Silindi: %s.",
+		"İki takvimden birinde gün ve ay, ardından en fazla 64 karakterlik bir başlık gönderin.",
+		"En fazla %d tarih saklayabilirsiniz. Yenisini eklemek için birini silin.",
+		"🗓 <b>Yarın</b> · %s",
+		"Kişisel tarihler", "Önceki akşam · 20:00 · tarihleri /events ile ekleyin",
+		"Kişisel tarih", "Yıllık Hicri tarihiniz.", "Yıllık tarihiniz.",
+	},
+	"uz": {
+		"O‘zingizning yillik sanalaringiz: yod kunlari, tug‘ilgan kunlar, nazrlar",
+		"Shaxsiy sanalar",
+		"Istalgan taqvimdagi yillik sanani va uning nomini yuboring, masalan <code>/events 12 Rajab Bobomning vafoti</code> yoki <code>/events 3 may To‘y yilligi</code>. Hijriy sanalar taqvimingiz va tuzatishingizga amal qiladi; oy qisqa bo‘lsa, 30-kun 29-kunga to‘g‘ri keladi. O‘chirish uchun quyidagi sanaga bosing.",
+		"Hali birorta sana qo‘shmadingiz.",
+		"Saqlandi: %s.", "O‘chirildi: %s.",
+		"Istalgan taqvimda kun va oyni, so‘ng 64 belgigacha bo‘lgan nomni yuboring.",
+		"Ko‘pi bilan %d ta sana saqlash mumkin. Boshqasini qo‘shish uchun birini o‘chiring.",
+		"🗓 <b>Ertaga</b> · %s",
+		"Shaxsiy sanalar", "Oldingi oqshom · 20:00 · sanalar /events orqali qo‘shiladi",
+		"Shaxsiy sana", "Yillik hijriy sanangiz.", "Yillik sanangiz.",
+	},
+	"tt": {
+		"Үзегезнең еллык даталарыгыз: истәлек көннәре, туган көннәр, нәзерләр",
+		"Шәхси даталар",
+		"Теләсә кайсы календарьда еллык датаны һәм аның исемен җибәрегез, мәсәлән <code>/events 12 Рәҗәб Бабайның вафаты</code> яки <code>/events 3 май Туй еллыгы</code>. Һиҗри даталар календарегезгә һәм төзәтмәгезгә иярә; ай кыска булса, 30нчы көн 29нчы көнгә туры килә. Бетерү өчен түбәндәге датага басыгыз.",
+		"Сез әлегә бер дата да өстәмәдегез.",
+		"Сакланды: %s.", "Бетерелде: %s.",
+		"Теләсә кайсы календарьда көн һәм айны, аннары 64 символга кадәр исемне җибәрегез.",
+		"Иң күбе %d дата саклап була. Яңасын өстәр өчен берсен бетерегез.",
+		"🗓 <b>Иртәгә</b> · %s",
+		"Шәхси даталар", "Алдагы кич · 20:00 · даталар /events аша өстәлә",
+		"Шәхси дата", "Еллык һиҗри датагыз.", "Еллык датагыз.",
+	},
+}
+
+func init() {
+	for code, copy := range personalEventCopies {
+		locale := locales[code]
+		locale.Commands["events"] = copy.Command
+		locale.Text["events_title"] = copy.Title
+		locale.Text["events_help"] = copy.Help
+		locale.Text["events_empty"] = copy.Empty
+		locale.Text["events_saved"] = copy.Saved
+		locale.Text["events_deleted"] = copy.Deleted
+		locale.Text["events_invalid"] = copy.Invalid
+		locale.Text["events_limit"] = copy.Limit
+		locale.Text["reminder_personal_event"] = copy.Reminder
+		occasionUI[code]["personal_reminders"] = copy.Reminders
+		occasionUI[code]["personal_schedule"] = copy.Schedule
+		occasionUI[code]["personal_hijri"] = copy.HijriEvent
+		occasionUI[code]["personal_gregorian"] = copy.CivilEvent
+		occasionCategories[code]["personal"] = copy.Category
+	}
+}
+
+// PersonalOccasion is the copy for a personal event listed among the
+// occasions. It has no recommended action.
+func (l Locale) PersonalOccasion(title string, hijri bool) OccasionCopy {
+	summary := l.OccasionUI("personal_gregorian")
+	if hijri {
+		summary = l.OccasionUI("personal_hijri")
+	}
+	return OccasionCopy{Title: title, Summary: summary}
+}
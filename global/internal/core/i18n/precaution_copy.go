@@ -0,0 +1,98 @@
+package i18n
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/escalopa/prayer-bot/global/internal/domain"
+)
+
+type precautionCopy struct {
+	Button, Label, Choose, Ihtiyat, Default string
+}
+
+var precautionCopies = map[string]precautionCopy{
+	"en": {
+		"⚖️ Rounding & ihtiyat", "Rounding & ihtiyat",
+		"<b>Rounding & ihtiyat</b> ⚖️\n\nIhtiyat is a precautionary margin added to every prayer except Sunrise. Tap a prayer to round it to the nearest minute (≈), up (↑) or down (↓), as your local authority publishes it.",
+		"ihtiyat +%d min", "Nearest minute",
+	},
+	"ar": {
+		"⚖️ التقريب والاحتياط", "التقريب والاحتياط",
+		"<b>التقريب والاحتياط</b> ⚖️\n\nالاحتياط دقائق تضاف إلى كل صلاة عدا الشروق. اضغط على صلاة لتقريبها إلى أقرب دقيقة (≈) أو إلى الأعلى (↑) أو إلى الأدنى (↓) كما تنشرها الجهة المحلية.",
+		"احتياط +%d د", "أقرب دقيقة",
+	},
+	"es": {
+		"⚖️ Redondeo e ihtiyat", "Redondeo e ihtiyat",
+		"<b>Redondeo e ihtiyat</b> ⚖️\n\nEl ihtiyat es un margen de precaución que se suma a cada oración salvo la salida del sol. Toca una oración para redondearla al minuto más cercano (≈), hacia arriba (↑) o hacia abajo (↓), como lo publique tu autoridad local.",
+		"ihtiyat +%d min", "Minuto más cercano",
+	},
+	"fr": {
+		"⚖️ Arrondi et ihtiyat", "Arrondi et ihtiyat",
+		"<b>Arrondi et ihtiyat</b> ⚖️\n\nL'ihtiyat est une marge de précaution ajoutée à chaque prière sauf le lever du soleil. Touchez une prière pour l'arrondir à la minute la plus proche (≈), supérieure (↑) ou inférieure (↓), comme le publie votre autorité locale.",
+		"ihtiyat +%d min", "Minute la plus proche",
+	},
+	"ru": {
+		"⚖️ Округление и ихтият", "Округление и ихтият",
+		"<b>Округление и ихтият</b> ⚖️\n\nИхтият — запас минут, который прибавляется ко всем намазам, кроме восхода. Нажмите на намаз, чтобы округлять его до ближайшей минуты (≈), вверх (↑) или вниз (↓), как это делает ваше местное духовное управление.",
+		"ихтият +%d мин", "До ближайшей минуты",
+	},
+	"tr": {
+		"⚖️ Yuvarlama ve ihtiyat", "Yuvarlama ve ihtiyat",
+		"<b>Yuvarlama ve ihtiyat</b> ⚖️\n\nİhtiyat (temkin), Güneş dışındaki her vakte eklenen bir güvenlik payıdır. Bir vakte dokunarak onu en yakın dakikaya (≈), yukarı (↑) veya aşağı (↓) yuvarlayın; yerel kurumunuz nasıl yayımlıyorsa öyle.",
+		"ihtiyat +%d dk", "En yakın dakika",
+	},
+	"uz": {
+		"⚖️ Yaxlitlash va ehtiyot", "Yaxlitlash va ehtiyot",
+		"<b>Yaxlitlash va ehtiyot</b> ⚖️\n\nEhtiyot — quyosh chiqishidan boshqa har bir namozga qo'shiladigan zaxira daqiqalar. Namozni eng yaqin daqiqaga (≈), yuqoriga (↑) yoki pastga (↓) yaxlitlash uchun ustiga bosing, mahalliy idorangiz qanday e'lon qilsa.",
+		"ehtiyot +%d daq", "Eng yaqin daqiqa",
+	},
+	"tt": {
+		"⚖️ Түгәрәкләү һәм ихтыят", "Түгәрәкләү һәм ихтыят",
+		"<b>Түгәрәкләү һәм ихтыят</b> ⚖️\n\nИхтыят — кояш чыгуыннан башка һәр намазга өстәлә торган саклык минутлары. Намазны иң якын минутка (≈), өскә (↑) яки аска (↓) түгәрәкләү өчен аңа басыгыз, җирле Диния нәзарәте ничек бастырса, шулай.",
+		"ихтыят +%d мин", "Иң якын минутка",
+	},
+}
+
+func init() {
+	for code, copy := range precautionCopies {
+		locale := locales[code]
+		locale.Buttons["precaution"] = copy.Button
+		locale.Text["precaution"] = copy.Label
+		locale.Text["choose_precaution"] = copy.Choose
+		locale.Text["ihtiyat_value"] = copy.Ihtiyat
+		locale.Text["precaution_default"] = copy.Default
+	}
+}
+
+// RoundingSymbol marks a rounding direction in compact labels.
+func RoundingSymbol(rounding domain.Rounding) string {
+	switch rounding {
+	case domain.RoundUp:
+		return "↑"
+	case domain.RoundDown:
+		return "↓"
+	default:
+		return "≈"
+	}
+}
+
+// PrecautionSummary describes a rounding and ihtiyat policy in one line, such
+// as "ihtiyat +2 min · Fajr ↓ · Maghrib ↑".
+func (l Locale) PrecautionSummary(precaution domain.Precaution) string {
+	if precaution.IsDefault() {
+		return l.Message("precaution_default")
+	}
+	var parts []string
+	if precaution.IhtiyatMinutes > 0 {
+		parts = append(parts, fmt.Sprintf(l.Message("ihtiyat_value"), precaution.IhtiyatMinutes))
+	}
+	for _, prayer := range []domain.Prayer{
+		domain.PrayerFajr, domain.PrayerSunrise, domain.PrayerDhuhr, domain.PrayerAsr, domain.PrayerMaghrib, domain.PrayerIsha,
+	} {
+		if rounding := precaution.Rounding(prayer); rounding != domain.RoundNearest {
+			parts = append(parts, l.Prayer(prayer)+" "+RoundingSymbol(rounding))
+		}
+	}
+	return strings.Join(parts, " · ")
+}
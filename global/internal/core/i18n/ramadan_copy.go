@@ -0,0 +1,111 @@
+package i18n
+
+type ramadanCopy struct {
+	Command, Title, Suhoor, Iftar, Download, Help string
+	Reminders, Schedule, ChooseReminders          string
+	SuhoorMinutes, SuhoorOff, IftarToggle         string
+	ReminderSuhoor, ReminderIftar                 string
+}
+
+var ramadanCopies = map[string]ramadanCopy{
+	"en": {
+		"Ramadan timetable with suhoor and iftar", "Ramadan timetable", "Suhoor ends", "Iftar",
+		"Download the whole month as PDF, PNG or CSV below.",
+		"Imsak, the end of suhoor at Fajr and iftar at Maghrib for every day of Ramadan.",
+		"Ramadan reminders", "Suhoor before Fajr and iftar at Maghrib, on days of Ramadan only",
+		"<b>Ramadan reminders</b> 🌅\nChoose how long before Fajr to be woken for suhoor and whether to be told when it is time for iftar. Both are sent only during Ramadan.",
+		"Suhoor %d min before Fajr", "No suhoor reminder", "Iftar at Maghrib",
+		"🌙 <b>Suhoor</b> ends in %d min · Fajr at <code>%s</code>",
+		"🌅 <b>Iftar</b> · Maghrib at <code>%s</code>\nMay Allah accept your fast.",
+	},
+	"ar": {
+		"إمساكية رمضان مع السحور والإفطار", "إمساكية رمضان", "نهاية السحور", "الإفطار",
+		"نزّل الشهر كاملًا بصيغة PDF أو PNG أو CSV من الأسفل.",
+		"الإمساك ونهاية السحور عند الفجر والإفطار عند المغرب لكل يوم من رمضان.",
+		"تذكيرات رمضان", "السحور قبل الفجر والإفطار عند المغرب، في أيام رمضان فقط",
+		"<b>تذكيرات رمضان</b> 🌅\nاختر كم دقيقة قبل الفجر تريد التنبيه للسحور، وهل تريد التنبيه عند حلول الإفطار. تُرسل التذكيرات في رمضان فقط.",
+		"السحور قبل الفجر بـ %d دقيقة", "بدون تذكير بالسحور", "الإفطار عند المغرب",
+		"🌙 ينتهي <b>السحور</b> بعد %d دقيقة · الفجر <code>%s</code>",
+		"🌅 <b>الإفطار</b> · المغرب <code>%s</code>\nتقبّل الله صيامك.",
+	},
+	"es": {
+		"Horario de Ramadán con suhur e iftar", "Horario de Ramadán", "Fin del suhur", "Iftar",
+		"Descarga el mes completo en PDF, PNG o CSV aquí abajo.",
+		"Imsak, el fin del suhur en Fajr y el iftar en Maghrib para cada día de Ramadán.",
+		"Avisos de Ramadán", "Suhur antes de Fajr e iftar en Maghrib, solo en los días de Ramadán",
+		"<b>Avisos de Ramadán</b> 🌅\nElige con cuánta antelación a Fajr quieres el aviso del suhur y si quieres saber cuándo llega el iftar. Ambos se envían solo durante Ramadán.",
+		"Suhur %d min antes de Fajr", "Sin aviso de suhur", "Iftar en Maghrib",
+		"🌙 El <b>suhur</b> termina en %d min · Fajr a las <code>%s</code>",
+		"🌅 <b>Iftar</b> · Maghrib a las <code>%s</code>\nQue Allah acepte tu ayuno.",
+	},
+	"fr": {
+		"Horaires du Ramadan avec suhur et iftar", "Horaires du Ramadan", "Fin du suhur", "Iftar",
+		"Téléchargez le mois entier en PDF, PNG ou CSV ci-dessous.",
+		"L'imsak, la fin du suhur à Fajr et l'iftar à Maghrib pour chaque jour du Ramadan.",
+		"Rappels du Ramadan", "Suhur avant Fajr et iftar à Maghrib, uniquement pendant le Ramadan",
+		"<b>Rappels du Ramadan</b> 🌅\nChoisissez combien de temps avant Fajr être prévenu pour le suhur, et si vous voulez être averti de l'heure de l'iftar. Les deux ne sont envoyés que pendant le Ramadan.",
+		"Suhur %d min avant Fajr", "Pas de rappel de suhur", "Iftar à Maghrib",
+		"🌙 Le <b>suhur</b> se termine dans %d min · Fajr à <code>%s</code>",
+		"🌅 <b>Iftar</b> · Maghrib à <code>%s</code>\nQu'Allah accepte votre jeûne.",
+	},
+	"ru": {
+		"Расписание Рамадана: сухур и ифтар", "Расписание Рамадана", "Конец сухура", "Ифтар",
+		"Скачайте весь месяц в PDF, PNG или CSV ниже.",
+		"Имсак, конец сухура на Фаджр и ифтар на Магриб для каждого дня Рамадана.",
+		"Напоминания Рамадана", "Сухур до Фаджра и ифтар на Магриб, только в дни Рамадана",
+		"<b>Напоминания Рамадана</b> 🌅\nВыберите, за сколько минут до Фаджра напомнить о сухуре, и нужно ли сообщать о времени ифтара. Оба напоминания приходят только в Рамадан.",
+		"Сухур за %d мин до Фаджра", "Без напоминания о сухуре", "Ифтар на Магриб",
+		"🌙 <b>Сухур</b> заканчивается через %d мин · Фаджр в <code>%s</code>",
+		"🌅 <b>Ифтар</b> · Магриб в <code>%s</code>\nПусть Аллах примет ваш пост.",
+	},
+	"tr": {
+		"Sahur ve iftar ile Ramazan imsakiyesi", "Ramazan imsakiyesi", "Sahur bitişi", "İftar",
+		"Ayın tamamını aşağıdan PDF, PNG veya CSV olarak indirin.",
+		"Ramazan'ın her günü için imsak, sabahta sahurun bitişi ve akşamda iftar.",
+		"Ramazan hatırlatmaları", "Sabahtan önce sahur ve akşamda iftar, yalnızca Ramazan günlerinde",
+		"<b>Ramazan hatırlatmaları</b> 🌅\nSahur için sabahtan kaç dakika önce uyarılmak istediğinizi ve iftar vaktinin bildirilip bildirilmeyeceğini seçin. İkisi de yalnızca Ramazan'da gönderilir.",
+		"Sahur sabahtan %d dk önce", "Sahur hatırlatması yok", "Akşamda iftar",
+		"🌙 <b>Sahur</b> %d dk sonra bitiyor · Sabah <code>%s</code>",
+		"🌅 <b>İftar</b> · Akşam <code>%s</code>\nAllah orucunuzu kabul etsin.",
+	},
+	"uz": {
+		"Saharlik va iftor bilan Ramazon taqvimi", "Ramazon taqvimi", "Saharlik tugashi", "Iftor",
+		"Butun oyni quyida PDF, PNG yoki CSV sifatida yuklab oling.",
+		"Ramazonning har kuni uchun imsok, bomdodda saharlikning tugashi va shomda iftor.",
+		"Ramazon eslatmalari", "Bomdoddan oldin saharlik va shomda iftor, faqat Ramazon kunlarida",
+		"<b>Ramazon eslatmalari</b> 🌅\nSaharlik uchun bomdoddan necha daqiqa oldin eslatilishini va iftor vaqti xabar qilinishini tanlang. Ikkalasi ham faqat Ramazonda yuboriladi.",
+		"Saharlik bomdoddan %d daq oldin", "Saharlik eslatmasi yoʻq", "Shomda iftor",
+		"🌙 <b>Saharlik</b> %d daqiqadan soʻng tugaydi · Bomdod <code>%s</code>",
+		"🌅 <b>Iftor</b> · Shom <code>%s</code>\nAlloh roʻzangizni qabul qilsin.",
+	},
+	"tt": {
+		"Сәхәр һәм ифтар белән Рамазан календаре", "Рамазан календаре", "Сәхәр тәмамлана", "Ифтар",
+		"Бөтен айны түбәндә PDF, PNG яки CSV итеп йөкләгез.",
+		"Рамазанның һәр көне өчен имсак, иртәнге намазда сәхәрнең тәмамлануы һәм ахшамда ифтар.",
+		"Рамазан искәртүләре", "Иртәнге намазга кадәр сәхәр һәм ахшамда ифтар, Рамазан көннәрендә генә",
+		"<b>Рамазан искәртүләре</b> 🌅\nСәхәр өчен иртәнге намазга кадәр ничә минут алдан искәртергә һәм ифтар вакытын хәбәр итәргәме икәнен сайлагыз. Икесе дә Рамазанда гына җибәрелә.",
+		"Сәхәр иртәнге намазга %d мин кала", "Сәхәр искәртүе юк", "Ахшамда ифтар",
+		"🌙 <b>Сәхәр</b> %d минуттан тәмамлана · Иртәнге намаз <code>%s</code>",
+		"🌅 <b>Ифтар</b> · Ахшам <code>%s</code>\nАллаһ уразагызны кабул кылсын.",
+	},
+}
+
+func init() {
+	for code, copy := range ramadanCopies {
+		locale := locales[code]
+		locale.Commands["ramadan"] = copy.Command
+		locale.Buttons["ramadan_reminders"] = copy.Reminders
+		locale.Text["ramadan_title"] = copy.Title
+		locale.Text["ramadan_suhoor"] = copy.Suhoor
+		locale.Text["ramadan_iftar"] = copy.Iftar
+		locale.Text["ramadan_download"] = copy.Download
+		locale.Text["ramadan_help"] = copy.Help
+		locale.Text["ramadan_schedule"] = copy.Schedule
+		locale.Text["choose_ramadan_reminders"] = copy.ChooseReminders
+		locale.Text["suhoor_minutes"] = copy.SuhoorMinutes
+		locale.Text["suhoor_off"] = copy.SuhoorOff
+		locale.Text["iftar_reminder"] = copy.IftarToggle
+		locale.Text["reminder_suhoor"] = copy.ReminderSuhoor
+		locale.Text["reminder_iftar"] = copy.ReminderIftar
+	}
+}
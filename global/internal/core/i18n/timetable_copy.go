@@ -0,0 +1,71 @@
+package i18n
+
+// timetableCopy labels a schedule taken from an uploaded official timetable
+// instead of the calculation method, and the setting that lets a chat keep
+// its own calculation.
+type timetableCopy struct {
+	Label, Title, Covers, None, Use, Button string
+}
+
+var officialTimetableCopies = map[string]timetableCopy{
+	"en": {
+		"Official timetable: %s", "Official timetable",
+		"%s covers your location. While it is used, it replaces the calculation method, madhab, custom angles and high-latitude rule; your adjustments and precaution still apply.",
+		"No official timetable covers your location, so your calculation settings are used.",
+		"Use instead of the method", "📋 Official timetable",
+	},
+	"ar": {
+		"الجدول الرسمي: %s", "الجدول الرسمي",
+		"يغطي %s موقعك. ما دام مستخدمًا فهو يحل محل طريقة الحساب والمذهب والزوايا المخصصة وقاعدة خطوط العرض العليا، وتبقى تعديلاتك والاحتياط مطبقة.",
+		"لا يغطي أي جدول رسمي موقعك، لذا تُستخدم إعدادات الحساب الخاصة بك.",
+		"استخدامه بدل طريقة الحساب", "📋 الجدول الرسمي",
+	},
+	"es": {
+		"Horario oficial: %s", "Horario oficial",
+		"%s cubre tu ubicación. Mientras se usa, sustituye el método de cálculo, el madhab, los ángulos personalizados y la regla de latitudes altas; tus ajustes y la precaución se siguen aplicando.",
+		"Ningún horario oficial cubre tu ubicación, así que se usan tus ajustes de cálculo.",
+		"Usarlo en lugar del método", "📋 Horario oficial",
+	},
+	"fr": {
+		"Horaires officiels : %s", "Horaires officiels",
+		"%s couvre votre position. Tant qu’ils sont utilisés, ils remplacent la méthode de calcul, le madhab, les angles personnalisés et la règle des hautes latitudes ; vos ajustements et la précaution restent appliqués.",
+		"Aucun horaire officiel ne couvre votre position : vos réglages de calcul sont utilisés.",
+		"Les utiliser à la place de la méthode", "📋 Horaires officiels",
+	},
+	"ru": {
+		"Официальное расписание: %s", "Официальное расписание",
+		"Ваше местоположение покрывает расписание «%s». Пока оно используется, оно заменяет метод расчёта, мазхаб, свои углы и правило высоких широт; ваши поправки и предосторожность по-прежнему применяются.",
+		"Ни одно официальное расписание не покрывает ваше местоположение, поэтому используются ваши настройки расчёта.",
+		"Использовать вместо метода", "📋 Официальное расписание",
+	},
+	"tr": {
+		"Resmî vakitler: %s", "Resmî vakitler",
+		"%s konumunuzu kapsıyor. Kullanıldığı sürece hesaplama yönteminin, mezhebin, özel açıların ve yüksek enlem kuralının yerini alır; düzeltmeleriniz ve ihtiyat yine uygulanır.",
+		"Konumunuzu kapsayan resmî bir vakit cetveli yok; hesaplama ayarlarınız kullanılıyor.",
+		"Yöntem yerine kullan", "📋 Resmî vakitler",
+	},
+	"uz": {
+		"Rasmiy taqvim: %s", "Rasmiy taqvim",
+		"%s joylashuvingizni qamrab oladi. U ishlatilayotganda hisoblash usuli, mazhab, maxsus burchaklar va yuqori kenglik qoidasi o‘rnini bosadi; tuzatishlaringiz va ehtiyot baribir qo‘llanadi.",
+		"Joylashuvingizni hech bir rasmiy taqvim qamrab olmaydi, shuning uchun hisoblash sozlamalaringiz ishlatiladi.",
+		"Usul o‘rniga ishlatish", "📋 Rasmiy taqvim",
+	},
+	"tt": {
+		"Рәсми расписание: %s", "Рәсми расписание",
+		"Сезнең урынны «%s» расписаниесе колачлый. Ул кулланылганда исәпләү ысулын, мәзһәбне, үз почмакларыгызны һәм биек киңлекләр кагыйдәсен алмаштыра; төзәтмәләрегез һәм саклык кулланыла бирә.",
+		"Сезнең урынны бер рәсми расписание дә колачламый, шуңа күрә исәпләү көйләүләрегез кулланыла.",
+		"Ысул урынына куллану", "📋 Рәсми расписание",
+	},
+}
+
+func init() {
+	for code, copy := range officialTimetableCopies {
+		locale := locales[code]
+		locale.Text["official_timetable"] = copy.Label
+		locale.Text["timetable_title"] = copy.Title
+		locale.Text["timetable_covers"] = copy.Covers
+		locale.Text["timetable_none"] = copy.None
+		locale.Text["use_timetable"] = copy.Use
+		locale.Buttons["official_timetable"] = copy.Button
+	}
+}
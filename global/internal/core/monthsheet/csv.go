@@ -0,0 +1,51 @@
+package monthsheet
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strconv"
+	"strings"
+
+	"github.com/escalopa/prayer-bot/global/internal/core/i18n"
+)
+
+// writeCSV keeps every label in the chat's language. The byte-order mark
+// makes spreadsheet applications read the file as UTF-8 rather than the
+// system code page.
+func writeCSV(month Month, locale i18n.Locale) ([]byte, error) {
+	var output bytes.Buffer
+	output.WriteString("\ufeff")
+	writer := csv.NewWriter(&output)
+	header := []string{locale.Message("month_date"), locale.Message("month_weekday"), locale.Message("month_hijri")}
+	for _, prayer := range month.prayers() {
+		header = append(header, columnLabel(month, prayer, locale))
+	}
+	header = append(header, locale.Message("month_occasions"))
+	if err := writer.Write(header); err != nil {
+		return nil, err
+	}
+	for _, day := range month.Days {
+		row := []string{
+			day.Date.Format("2006-01-02"),
+			locale.Weekday(day.Date.Weekday()),
+			hijriLabel(day.Hijri, locale) + " " + strconv.Itoa(day.Hijri.Year),
+		}
+		for _, prayer := range month.prayers() {
+			row = append(row, clock(day, prayer))
+		}
+		row = append(row, strings.Join(occasionTitles(day, locale), "; "))
+		if err := writer.Write(row); err != nil {
+			return nil, err
+		}
+	}
+	writer.Flush()
+	return output.Bytes(), writer.Error()
+}
+
+func occasionTitles(day Day, locale i18n.Locale) []string {
+	titles := make([]string, 0, len(day.Occasions))
+	for _, id := range day.Occasions {
+		titles = append(titles, locale.Occasion(id).Title)
+	}
+	return titles
+}
@@ -0,0 +1,307 @@
+package monthsheet
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/font/sfnt"
+	"golang.org/x/image/math/fixed"
+
+	"github.com/escalopa/prayer-bot/global/internal/core/i18n"
+)
+
+// The page is A4 at 150 dpi, so the PDF can place it edge to edge.
+const (
+	pageWidth       = 1240
+	pageHeight      = 1754
+	pageMargin      = 60
+	tableTop        = 220
+	headerHeight    = 56
+	rowHeight       = 44
+	cellPadding     = 12
+	minOccasionSize = 150
+)
+
+var (
+	inkColor      = color.RGBA{33, 37, 41, 255}
+	mutedColor    = color.RGBA{96, 108, 102, 255}
+	headerColor   = color.RGBA{27, 94, 75, 255}
+	fridayColor   = color.RGBA{232, 244, 238, 255}
+	occasionColor = color.RGBA{255, 243, 214, 255}
+	gridColor     = color.RGBA{212, 217, 214, 255}
+)
+
+// The Go fonts cover Latin, Greek and Cyrillic but not Arabic script or the
+// letters Tatar adds to Cyrillic, and there is no shaping for right-to-left
+// text; a page those fonts cannot draw is drawn in English instead.
+var loadFonts = sync.OnceValues(func() ([2]*opentype.Font, error) {
+	regular, err := opentype.Parse(goregular.TTF)
+	if err != nil {
+		return [2]*opentype.Font{}, fmt.Errorf("parse regular font: %w", err)
+	}
+	bold, err := opentype.Parse(gobold.TTF)
+	if err != nil {
+		return [2]*opentype.Font{}, fmt.Errorf("parse bold font: %w", err)
+	}
+	return [2]*opentype.Font{regular, bold}, nil
+})
+
+// The Go fonts have no modifier letter apostrophe, which Uzbek Latin uses in
+// oʻ and gʻ.
+var typographic = strings.NewReplacer("ʻ", "'", "ʼ", "'")
+
+type page struct {
+	title, subtitle, footer string
+	header                  []string
+	rows                    []pageRow
+	// times counts the time columns between the Hijri date and the occasions.
+	times int
+}
+
+type pageRow struct {
+	cells               []string
+	friday, hasOccasion bool
+}
+
+func tabulate(month Month, locale i18n.Locale) page {
+	title, subtitle := Heading(month, locale)
+	result := page{
+		title:    typographic.Replace(title),
+		subtitle: typographic.Replace(subtitle),
+		footer:   typographic.Replace(footer(month, locale)),
+		header:   []string{typographic.Replace(locale.Message("month_date")), typographic.Replace(locale.Message("month_hijri"))},
+		times:    len(month.prayers()),
+	}
+	for _, prayer := range month.prayers() {
+		result.header = append(result.header, typographic.Replace(columnLabel(month, prayer, locale)))
+	}
+	result.header = append(result.header, typographic.Replace(locale.Message("month_occasions")))
+	for _, day := range month.Days {
+		cells := []string{
+			typographic.Replace(locale.Weekday(day.Date.Weekday())) + " " + strconv.Itoa(day.Date.Day()),
+			typographic.Replace(hijriLabel(day.Hijri, locale)),
+		}
+		for _, prayer := range month.prayers() {
+			if at := clock(day, prayer); at != "" {
+				cells = append(cells, at)
+			} else {
+				cells = append(cells, "—")
+			}
+		}
+		cells = append(cells, typographic.Replace(strings.Join(occasionTitles(day, locale), ", ")))
+		result.rows = append(result.rows, pageRow{
+			cells: cells, friday: day.Date.Weekday() == time.Friday, hasOccasion: len(day.Occasions) > 0,
+		})
+	}
+	return result
+}
+
+func (p page) drawableWith(typeface *opentype.Font) bool {
+	texts := append([]string{p.title, p.subtitle, p.footer}, p.header...)
+	for _, row := range p.rows {
+		texts = append(texts, row.cells...)
+	}
+	var buffer sfnt.Buffer
+	for _, text := range texts {
+		for _, character := range text {
+			if index, err := typeface.GlyphIndex(&buffer, character); err != nil || index == 0 {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func writePNG(month Month, locale i18n.Locale) ([]byte, error) {
+	canvas, err := drawPage(month, locale)
+	if err != nil {
+		return nil, err
+	}
+	var output bytes.Buffer
+	if err := png.Encode(&output, canvas); err != nil {
+		return nil, fmt.Errorf("encode timetable image: %w", err)
+	}
+	return output.Bytes(), nil
+}
+
+func drawPage(month Month, locale i18n.Locale) (*image.RGBA, error) {
+	fonts, err := loadFonts()
+	if err != nil {
+		return nil, err
+	}
+	regular, bold := fonts[0], fonts[1]
+	sheet := tabulate(month, locale)
+	if !sheet.drawableWith(regular) {
+		sheet = tabulate(month, i18n.Resolve("en"))
+	}
+	face := func(typeface *opentype.Font, size float64) (font.Face, error) {
+		return opentype.NewFace(typeface, &opentype.FaceOptions{Size: size, DPI: 72, Hinting: font.HintingFull})
+	}
+	titleFace, err := face(bold, 40)
+	if err != nil {
+		return nil, fmt.Errorf("load title font: %w", err)
+	}
+	subtitleFace, err := face(regular, 22)
+	if err != nil {
+		return nil, fmt.Errorf("load subtitle font: %w", err)
+	}
+	headerFace, err := face(bold, 17)
+	if err != nil {
+		return nil, fmt.Errorf("load header font: %w", err)
+	}
+	cellFace, err := face(regular, 18)
+	if err != nil {
+		return nil, fmt.Errorf("load cell font: %w", err)
+	}
+	footerFace, err := face(regular, 16)
+	if err != nil {
+		return nil, fmt.Errorf("load footer font: %w", err)
+	}
+
+	canvas := image.NewRGBA(image.Rect(0, 0, pageWidth, pageHeight))
+	draw.Draw(canvas, canvas.Bounds(), image.White, image.Point{}, draw.Src)
+	drawText(canvas, titleFace, inkColor, sheet.title, pageMargin, 110)
+	drawText(canvas, subtitleFace, mutedColor, sheet.subtitle, pageMargin, 160)
+
+	widths := columnWidths(sheet, headerFace, cellFace)
+	fill(canvas, headerColor, pageMargin, tableTop, pageWidth-pageMargin, tableTop+headerHeight)
+	x := pageMargin
+	for column, label := range sheet.header {
+		drawCell(canvas, headerFace, color.White, label, x, tableTop, widths[column], headerHeight, sheet.isTimeColumn(column))
+		x += widths[column]
+	}
+	top := tableTop + headerHeight
+	for _, row := range sheet.rows {
+		switch {
+		case row.hasOccasion:
+			fill(canvas, occasionColor, pageMargin, top, pageWidth-pageMargin, top+rowHeight)
+		case row.friday:
+			fill(canvas, fridayColor, pageMargin, top, pageWidth-pageMargin, top+rowHeight)
+		}
+		x = pageMargin
+		for column, value := range row.cells {
+			drawCell(canvas, cellFace, inkColor, value, x, top, widths[column], rowHeight, sheet.isTimeColumn(column))
+			x += widths[column]
+		}
+		top += rowHeight
+		fill(canvas, gridColor, pageMargin, top-1, pageWidth-pageMargin, top)
+	}
+	drawText(canvas, footerFace, mutedColor, fitText(footerFace, sheet.footer, pageWidth-2*pageMargin), pageMargin, pageHeight-pageMargin)
+	return canvas, nil
+}
+
+// columnWidths gives the date, Hijri and time columns what their widest value
+// needs and leaves the rest of the line to the occasions. Headers may wrap
+// onto a second line.
+func columnWidths(sheet page, headerFace, cellFace font.Face) []int {
+	widths := make([]int, len(sheet.header))
+	for column, label := range sheet.header[:len(sheet.header)-1] {
+		widths[column] = twoLineWidth(headerFace, label) + 2*cellPadding
+	}
+	for _, row := range sheet.rows {
+		for column, value := range row.cells[:len(row.cells)-1] {
+			widths[column] = max(widths[column], font.MeasureString(cellFace, value).Ceil()+2*cellPadding)
+		}
+	}
+	// The time columns share one width so that they line up as a block.
+	timeWidth := 0
+	for column := range sheet.times {
+		timeWidth = max(timeWidth, widths[column+2])
+	}
+	available := pageWidth - 2*pageMargin - widths[0] - widths[1] - minOccasionSize
+	timeWidth = min(timeWidth, available/sheet.times)
+	used := widths[0] + widths[1]
+	for column := range sheet.times {
+		widths[column+2] = timeWidth
+		used += timeWidth
+	}
+	widths[len(widths)-1] = pageWidth - 2*pageMargin - used
+	return widths
+}
+
+// twoLineWidth is the narrowest width text fits in on at most two lines.
+func twoLineWidth(face font.Face, text string) int {
+	best := font.MeasureString(face, text).Ceil()
+	words := strings.Fields(text)
+	for split := 1; split < len(words); split++ {
+		first := font.MeasureString(face, strings.Join(words[:split], " ")).Ceil()
+		second := font.MeasureString(face, strings.Join(words[split:], " ")).Ceil()
+		best = min(best, max(first, second))
+	}
+	return best
+}
+
+func (p page) isTimeColumn(column int) bool {
+	return column >= 2 && column < 2+p.times
+}
+
+func drawCell(canvas *image.RGBA, face font.Face, ink color.Color, text string, x, top, width, height int, centered bool) {
+	lines := []string{fitText(face, text, width-2*cellPadding)}
+	if lines[0] != text {
+		lines = wrapText(face, text, width-2*cellPadding)
+	}
+	metrics := face.Metrics()
+	lineHeight := metrics.Height.Ceil()
+	baseline := top + (height-lineHeight*len(lines))/2 + metrics.Ascent.Ceil()
+	for _, line := range lines {
+		left := x + cellPadding
+		if centered {
+			left = x + (width-font.MeasureString(face, line).Ceil())/2
+		}
+		drawText(canvas, face, ink, line, left, baseline)
+		baseline += lineHeight
+	}
+}
+
+// wrapText breaks text into at most two lines, preferring a break where both
+// fit and otherwise shortening the second.
+func wrapText(face font.Face, text string, width int) []string {
+	words := strings.Fields(text)
+	fits := func(line string) bool { return font.MeasureString(face, line).Ceil() <= width }
+	for split := 1; split < len(words); split++ {
+		first, second := strings.Join(words[:split], " "), strings.Join(words[split:], " ")
+		if fits(first) && fits(second) {
+			return []string{first, second}
+		}
+	}
+	if len(words) > 1 && fits(words[0]) {
+		return []string{words[0], fitText(face, strings.Join(words[1:], " "), width)}
+	}
+	return []string{fitText(face, text, width)}
+}
+
+// fitText shortens text with an ellipsis until it fits width pixels.
+func fitText(face font.Face, text string, width int) string {
+	if font.MeasureString(face, text).Ceil() <= width {
+		return text
+	}
+	runes := []rune(text)
+	for len(runes) > 0 {
+		runes = runes[:len(runes)-1]
+		if candidate := strings.TrimSpace(string(runes)) + "…"; font.MeasureString(face, candidate).Ceil() <= width {
+			return candidate
+		}
+	}
+	return ""
+}
+
+func drawText(canvas *image.RGBA, face font.Face, ink color.Color, text string, x, baseline int) {
+	drawer := font.Drawer{Dst: canvas, Src: image.NewUniform(ink), Face: face, Dot: fixed.P(x, baseline)}
+	drawer.DrawString(text)
+}
+
+func fill(canvas *image.RGBA, paint color.Color, x0, y0, x1, y1 int) {
+	draw.Draw(canvas, image.Rect(x0, y0, x1, y1), image.NewUniform(paint), image.Point{}, draw.Src)
+}
@@ -0,0 +1,82 @@
+package monthsheet
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"unicode/utf16"
+
+	"github.com/escalopa/prayer-bot/global/internal/core/i18n"
+)
+
+// A4 in PDF points.
+const (
+	pdfPageWidth  = 595
+	pdfPageHeight = 842
+)
+
+// writePDF places the rendered page on a single A4 sheet. Embedding the image
+// keeps the PDF identical to the PNG and avoids embedding fonts; the text is
+// not selectable, which a timetable meant for printing does not need.
+func writePDF(month Month, locale i18n.Locale) ([]byte, error) {
+	canvas, err := drawPage(month, locale)
+	if err != nil {
+		return nil, err
+	}
+	var pixels bytes.Buffer
+	compressor := zlib.NewWriter(&pixels)
+	row := make([]byte, 0, 3*pageWidth)
+	for y := 0; y < pageHeight; y++ {
+		row = row[:0]
+		line := canvas.Pix[y*canvas.Stride : y*canvas.Stride+4*pageWidth]
+		for x := 0; x < len(line); x += 4 {
+			row = append(row, line[x], line[x+1], line[x+2])
+		}
+		if _, err := compressor.Write(row); err != nil {
+			return nil, fmt.Errorf("compress timetable image: %w", err)
+		}
+	}
+	if err := compressor.Close(); err != nil {
+		return nil, fmt.Errorf("compress timetable image: %w", err)
+	}
+
+	content := fmt.Sprintf("q %d 0 0 %d 0 0 cm /Page Do Q\n", pdfPageWidth, pdfPageHeight)
+	title, subtitle := Heading(month, locale)
+	objects := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+		fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %d %d] /Resources << /XObject << /Page 4 0 R >> >> /Contents 5 0 R >>",
+			pdfPageWidth, pdfPageHeight),
+		fmt.Sprintf("<< /Type /XObject /Subtype /Image /Width %d /Height %d /ColorSpace /DeviceRGB /BitsPerComponent 8 /Filter /FlateDecode /Length %d >>\nstream\n%s\nendstream",
+			pageWidth, pageHeight, pixels.Len(), pixels.Bytes()),
+		fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", len(content), content),
+		fmt.Sprintf("<< /Title %s /Producer (%s) >>", pdfText(title+" · "+subtitle), "Global Prayer Times"),
+	}
+
+	var output bytes.Buffer
+	output.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+	offsets := make([]int, len(objects))
+	for index, object := range objects {
+		offsets[index] = output.Len()
+		fmt.Fprintf(&output, "%d 0 obj\n%s\nendobj\n", index+1, object)
+	}
+	xref := output.Len()
+	fmt.Fprintf(&output, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&output, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&output, "trailer\n<< /Size %d /Root 1 0 R /Info %d 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, len(objects), xref)
+	return output.Bytes(), nil
+}
+
+// pdfText encodes a document-information string as UTF-16BE, which PDF
+// readers accept for text in any script.
+func pdfText(value string) string {
+	var encoded bytes.Buffer
+	encoded.WriteString("<FEFF")
+	for _, unit := range utf16.Encode([]rune(value)) {
+		fmt.Fprintf(&encoded, "%04X", unit)
+	}
+	encoded.WriteString(">")
+	return encoded.String()
+}
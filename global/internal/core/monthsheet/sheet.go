@@ -0,0 +1,315 @@
+// Package monthsheet renders a month of prayer times as a printable timetable
+// in CSV, PNG or PDF, the way mosques pin one to the notice board.
+package monthsheet
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/escalopa/prayer-bot/global/internal/core/hijri"
+	"github.com/escalopa/prayer-bot/global/internal/core/i18n"
+	"github.com/escalopa/prayer-bot/global/internal/core/occasions"
+	"github.com/escalopa/prayer-bot/global/internal/domain"
+	"github.com/escalopa/prayer-bot/global/internal/port"
+)
+
+// MaxMonthsAway bounds how far from the current month a timetable may be
+// requested.
+const MaxMonthsAway = 12
+
+type Format string
+
+const (
+	FormatPDF Format = "pdf"
+	FormatPNG Format = "png"
+	FormatCSV Format = "csv"
+)
+
+func Formats() []Format {
+	return []Format{FormatPDF, FormatPNG, FormatCSV}
+}
+
+func ParseFormat(value string) (Format, bool) {
+	for _, format := range Formats() {
+		if string(format) == value {
+			return format, true
+		}
+	}
+	return "", false
+}
+
+var sheetPrayers = []domain.Prayer{
+	domain.PrayerFajr,
+	domain.PrayerSunrise,
+	domain.PrayerDhuhr,
+	domain.PrayerAsr,
+	domain.PrayerMaghrib,
+	domain.PrayerIsha,
+}
+
+// ramadanPrayers are the fasting times: Imsak, the end of suhoor at Fajr, and
+// iftar at Maghrib.
+var ramadanPrayers = []domain.Prayer{domain.PrayerImsak, domain.PrayerFajr, domain.PrayerMaghrib}
+
+type Day struct {
+	Date      time.Time
+	Hijri     hijri.Date
+	Times     map[domain.Prayer]time.Time
+	Occasions []string
+}
+
+// Month holds everything a timetable shows, calculated once so that every
+// format renders the same times.
+type Month struct {
+	Start     time.Time
+	Profile   domain.PrayerProfile
+	Days      []Day
+	Timetable string
+	// Ramadan marks a sheet of the days of Ramadan rather than a Gregorian
+	// month; it shows only the fasting times.
+	Ramadan bool
+}
+
+// Filename names the export after its month, such as prayer-times-2026-03.pdf,
+// or after the Hijri year for Ramadan, such as ramadan-1447.pdf.
+func (m Month) Filename(format Format) string {
+	if m.Ramadan {
+		return "ramadan-" + strconv.Itoa(m.Days[0].Hijri.Year) + "." + string(format)
+	}
+	return "prayer-times-" + m.Start.Format("2006-01") + "." + string(format)
+}
+
+func (m Month) prayers() []domain.Prayer {
+	if m.Ramadan {
+		return ramadanPrayers
+	}
+	return sheetPrayers
+}
+
+// ParseMonth reads a YYYY-MM month in the profile's timezone and rejects months
+// more than MaxMonthsAway from the one containing now.
+func ParseMonth(value string, now time.Time, timezone string) (time.Time, error) {
+	location, err := domain.LoadLocation(timezone)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("load timezone: %w", err)
+	}
+	parsed, err := time.ParseInLocation("2006-01", value, location)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("month must look like 2026-03")
+	}
+	if !WithinReach(parsed, now) {
+		return time.Time{}, fmt.Errorf("month must be within %d months of today", MaxMonthsAway)
+	}
+	return parsed, nil
+}
+
+// WithinReach reports whether month is at most MaxMonthsAway from the month
+// containing now in month's location.
+func WithinReach(month, now time.Time) bool {
+	current := now.In(month.Location())
+	away := (month.Year()-current.Year())*12 + int(month.Month()) - int(current.Month())
+	return away >= -MaxMonthsAway && away <= MaxMonthsAway
+}
+
+// FirstOfMonth returns midnight on the first day of date's month, in date's
+// location.
+func FirstOfMonth(date time.Time) time.Time {
+	return time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, date.Location())
+}
+
+// Build calculates every day of the month containing month, in the profile's
+// timezone.
+func Build(ctx context.Context, calculator port.Calculator, profile domain.PrayerProfile, month time.Time) (Month, error) {
+	location, err := domain.LoadLocation(profile.Timezone)
+	if err != nil {
+		return Month{}, fmt.Errorf("load timezone: %w", err)
+	}
+	start := FirstOfMonth(month.In(location))
+	return build(ctx, calculator, profile, start, start.AddDate(0, 1, -1).Day())
+}
+
+// BuildRamadan calculates the Ramadan under way at now, or the next one, using
+// the profile's Hijri calendar.
+func BuildRamadan(ctx context.Context, calculator port.Calculator, profile domain.PrayerProfile, now time.Time) (Month, error) {
+	location, err := domain.LoadLocation(profile.Timezone)
+	if err != nil {
+		return Month{}, fmt.Errorf("load timezone: %w", err)
+	}
+	calendar, err := hijri.ForProfile(profile)
+	if err != nil {
+		return Month{}, err
+	}
+	local := now.In(location)
+	start := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, location)
+	first, err := hijriAt(start, calendar)
+	if err != nil {
+		return Month{}, err
+	}
+	if first.Month == hijri.Ramadan {
+		start = start.AddDate(0, 0, 1-first.Day)
+	} else {
+		// Ramadan is at most one Hijri year, 355 days, away.
+		for offset := 1; ; offset++ {
+			if offset > 356 {
+				return Month{}, fmt.Errorf("no Ramadan found within a year")
+			}
+			date, err := hijriAt(start.AddDate(0, 0, offset), calendar)
+			if err != nil {
+				return Month{}, err
+			}
+			if date.Month == hijri.Ramadan {
+				start = start.AddDate(0, 0, offset)
+				break
+			}
+		}
+	}
+	days := 0
+	for ; days < 31; days++ {
+		date, err := hijriAt(start.AddDate(0, 0, days), calendar)
+		if err != nil {
+			return Month{}, err
+		}
+		if date.Month != hijri.Ramadan {
+			break
+		}
+	}
+	result, err := build(ctx, calculator, profile, start, days)
+	if err != nil {
+		return Month{}, err
+	}
+	result.Ramadan = true
+	return result, nil
+}
+
+// hijriAt converts the local day starting at midnight.
+func hijriAt(midnight time.Time, calendar hijri.Calendar) (hijri.Date, error) {
+	date, err := calendar.Date(midnight)
+	if err != nil {
+		return hijri.Date{}, fmt.Errorf("convert %s to Hijri: %w", midnight.Format("2006-01-02"), err)
+	}
+	return date, nil
+}
+
+func build(ctx context.Context, calculator port.Calculator, profile domain.PrayerProfile, start time.Time, days int) (Month, error) {
+	calendar, err := hijri.ForProfile(profile)
+	if err != nil {
+		return Month{}, err
+	}
+	upcoming, err := occasions.Between(start.Add(12*time.Hour), days, calendar, profile.CountryCode)
+	if err != nil {
+		return Month{}, fmt.Errorf("calculate Islamic occasions: %w", err)
+	}
+	byDate := make(map[string][]string, len(upcoming))
+	for _, occurrence := range upcoming {
+		key := occurrence.Date.Format("2006-01-02")
+		byDate[key] = append(byDate[key], occurrence.Definition.ID)
+	}
+
+	result := Month{Start: start, Profile: profile, Days: make([]Day, 0, days)}
+	for offset := 0; offset < days; offset++ {
+		date := time.Date(start.Year(), start.Month(), start.Day()+offset, 12, 0, 0, 0, start.Location())
+		schedule, err := calculator.Day(ctx, date, profile)
+		if err != nil {
+			return Month{}, fmt.Errorf("calculate %s: %w", date.Format("2006-01-02"), err)
+		}
+		hijriDate, err := calendar.Date(date)
+		if err != nil {
+			return Month{}, fmt.Errorf("convert %s to Hijri: %w", date.Format("2006-01-02"), err)
+		}
+		if schedule.Timetable != "" {
+			result.Timetable = schedule.Timetable
+		}
+		result.Days = append(result.Days, Day{
+			Date: date, Hijri: hijriDate, Times: schedule.Times, Occasions: byDate[date.Format("2006-01-02")],
+		})
+	}
+	return result, nil
+}
+
+// Render draws month in the requested format with locale's copy.
+func Render(month Month, locale i18n.Locale, format Format) ([]byte, error) {
+	switch format {
+	case FormatCSV:
+		return writeCSV(month, locale)
+	case FormatPNG:
+		return writePNG(month, locale)
+	case FormatPDF:
+		return writePDF(month, locale)
+	default:
+		return nil, fmt.Errorf("unsupported timetable format %q", format)
+	}
+}
+
+// Title names the range of the month starting at start, such as
+// "1–31 March 2026".
+func Title(start time.Time, locale i18n.Locale) string {
+	last := FirstOfMonth(start).AddDate(0, 1, -1)
+	return fmt.Sprintf(locale.Message("month_range"), 1, last.Day(), locale.Month(int(last.Month())), last.Year())
+}
+
+// Heading is the title and subtitle every format puts above the table: the
+// Gregorian range and the Hijri months for a month, and the other way round
+// for Ramadan.
+func Heading(month Month, locale i18n.Locale) (string, string) {
+	if !month.Ramadan {
+		return locale.Message("month_title"), Title(month.Start, locale) + " · " + hijriSpan(month, locale)
+	}
+	first, last := month.Days[0].Date, month.Days[len(month.Days)-1].Date
+	return locale.Message("ramadan_title"), fmt.Sprintf("%s · %d %s %d – %d %s %d",
+		hijriSpan(month, locale),
+		first.Day(), locale.Month(int(first.Month())), first.Year(),
+		last.Day(), locale.Month(int(last.Month())), last.Year())
+}
+
+// columnLabel names a time column; on a Ramadan sheet Fajr and Maghrib are
+// headed by what they mean for the fast.
+func columnLabel(month Month, prayer domain.Prayer, locale i18n.Locale) string {
+	switch {
+	case month.Ramadan && prayer == domain.PrayerFajr:
+		return locale.Message("ramadan_suhoor")
+	case month.Ramadan && prayer == domain.PrayerMaghrib:
+		return locale.Message("ramadan_iftar")
+	default:
+		return locale.Prayer(prayer)
+	}
+}
+
+// footer names what the times were calculated with.
+func footer(month Month, locale i18n.Locale) string {
+	source := locale.Method(month.Profile.Method)
+	if month.Timetable != "" {
+		source = fmt.Sprintf(locale.Message("official_timetable"), month.Timetable)
+	}
+	text := fmt.Sprintf("%s · %s · %s", locale.BotName, month.Profile.Timezone, source)
+	if !month.Profile.Precaution.IsDefault() {
+		text += " · " + locale.PrecautionSummary(month.Profile.Precaution)
+	}
+	return text
+}
+
+func hijriLabel(date hijri.Date, locale i18n.Locale) string {
+	return fmt.Sprintf("%d %s", date.Day, locale.HijriMonth(date.Month))
+}
+
+// hijriSpan names the Hijri months the Gregorian month overlaps, such as
+// "Ramadan – Shawwal 1447 AH".
+func hijriSpan(month Month, locale i18n.Locale) string {
+	first, last := month.Days[0].Hijri, month.Days[len(month.Days)-1].Hijri
+	era := locale.Message("hijri_era")
+	if first.Year != last.Year {
+		return fmt.Sprintf("%s %d – %s %d %s", locale.HijriMonth(first.Month), first.Year, locale.HijriMonth(last.Month), last.Year, era)
+	}
+	if first.Month != last.Month {
+		return fmt.Sprintf("%s – %s %d %s", locale.HijriMonth(first.Month), locale.HijriMonth(last.Month), last.Year, era)
+	}
+	return fmt.Sprintf("%s %d %s", locale.HijriMonth(first.Month), first.Year, era)
+}
+
+func clock(day Day, prayer domain.Prayer) string {
+	if at, ok := day.Times[prayer]; ok && !at.IsZero() {
+		return at.Format("15:04")
+	}
+	return ""
+}
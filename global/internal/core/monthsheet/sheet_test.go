@@ -0,0 +1,181 @@
+package monthsheet
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"image/png"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/escalopa/prayer-bot/global/internal/core/i18n"
+	"github.com/escalopa/prayer-bot/global/internal/core/prayertime"
+	"github.com/escalopa/prayer-bot/global/internal/domain"
+)
+
+func kazanProfile() domain.PrayerProfile {
+	return domain.PrayerProfile{
+		Latitude: 55.796, Longitude: 49.106, Timezone: "Europe/Moscow",
+		Method: domain.MethodRussia, Madhab: domain.MadhabHanafi, HighLatitudeRule: domain.HighLatitudeAngleBased,
+	}
+}
+
+func TestBuildCoversTheWholeMonthWithHijriDatesAndOccasions(t *testing.T) {
+	// March 2026 opens in Ramadan 1447 and has Eid al-Fitr on the 20th.
+	month, err := Build(context.Background(), prayertime.New(), kazanProfile(), time.Date(2026, time.March, 14, 23, 30, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(month.Days) != 31 || month.Days[0].Date.Day() != 1 || month.Days[30].Date.Day() != 31 {
+		t.Fatalf("got %d days from %s", len(month.Days), month.Days[0].Date)
+	}
+	if first := month.Days[0].Hijri; first.Month != 9 || first.Year != 1447 {
+		t.Fatalf("1 March 2026 = %+v, want Ramadan 1447", first)
+	}
+	eid := -1
+	for index, day := range month.Days {
+		for _, id := range day.Occasions {
+			if id == "eid_fitr" {
+				eid = index
+			}
+		}
+		for _, prayer := range sheetPrayers {
+			if clock(day, prayer) == "" {
+				t.Fatalf("%s has no %s", day.Date, prayer)
+			}
+		}
+	}
+	if eid < 0 || month.Days[eid].Hijri.Month != 10 || month.Days[eid].Hijri.Day != 1 {
+		t.Fatalf("Eid al-Fitr missing or misdated: day index %d", eid)
+	}
+}
+
+func TestBuildRamadanCoversTheCurrentOrNextRamadan(t *testing.T) {
+	for _, now := range []time.Time{
+		time.Date(2026, time.March, 5, 9, 0, 0, 0, time.UTC),    // inside Ramadan 1447
+		time.Date(2026, time.October, 17, 9, 0, 0, 0, time.UTC), // before Ramadan 1448
+	} {
+		month, err := BuildRamadan(context.Background(), prayertime.New(), kazanProfile(), now)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !month.Ramadan || len(month.Days) < 29 || len(month.Days) > 30 {
+			t.Fatalf("%s: got Ramadan=%t with %d days", now, month.Ramadan, len(month.Days))
+		}
+		for index, day := range month.Days {
+			if day.Hijri.Month != 9 || day.Hijri.Day != index+1 {
+				t.Fatalf("%s: day %d is Hijri %+v", now, index, day.Hijri)
+			}
+		}
+		if month.Days[len(month.Days)-1].Date.Before(now) {
+			t.Fatalf("%s: Ramadan already ended on %s", now, month.Days[len(month.Days)-1].Date)
+		}
+	}
+
+	month, err := BuildRamadan(context.Background(), prayertime.New(), kazanProfile(), time.Date(2026, time.October, 17, 9, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := month.Filename(FormatCSV); got != "ramadan-1448.csv" {
+		t.Fatalf("filename = %s", got)
+	}
+	file, err := Render(month, i18n.Resolve("en"), FormatCSV)
+	if err != nil {
+		t.Fatal(err)
+	}
+	header, err := csv.NewReader(bytes.NewReader(file)).Read()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(header, ","); !strings.Contains(got, "Suhoor ends") || !strings.Contains(got, "Iftar") || strings.Contains(got, "Dhuhr") {
+		t.Fatalf("Ramadan header = %s", got)
+	}
+}
+
+func TestRenderProducesEveryFormat(t *testing.T) {
+	profile := kazanProfile()
+	profile.Precaution = domain.Precaution{IhtiyatMinutes: 2}
+	month, err := Build(context.Background(), prayertime.New(), profile, time.Date(2026, time.March, 1, 12, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+	locale := i18n.Resolve("ru")
+
+	table, err := Render(month, locale, FormatCSV)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.HasPrefix(table, []byte("\ufeff")) {
+		t.Fatal("CSV should start with a byte-order mark")
+	}
+	records, err := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(table, []byte("\ufeff")))).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 32 || records[0][3] != "Фаджр" || records[1][0] != "2026-03-01" || records[1][1] != "Вс" {
+		t.Fatalf("unexpected CSV header or first row: %q, %q", records[0], records[1])
+	}
+	if !strings.HasSuffix(records[1][2], " 1447") {
+		t.Fatalf("Hijri cell = %q", records[1][2])
+	}
+	if dhuhr := records[1][5]; len(dhuhr) != 5 || dhuhr[2] != ':' {
+		t.Fatalf("Dhuhr cell = %q", dhuhr)
+	}
+
+	picture, err := Render(month, locale, FormatPNG)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := png.Decode(bytes.NewReader(picture))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bounds := decoded.Bounds(); bounds.Dx() != pageWidth || bounds.Dy() != pageHeight {
+		t.Fatalf("image is %v", bounds)
+	}
+
+	document, err := Render(month, locale, FormatPDF)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.HasPrefix(document, []byte("%PDF-1.4")) || !bytes.HasSuffix(document, []byte("%%EOF\n")) ||
+		!bytes.Contains(document, []byte("/Width 1240 /Height 1754")) {
+		t.Fatal("PDF is missing its header, trailer or page image")
+	}
+}
+
+func TestPagesTheGoFontCannotDrawFallBackToEnglish(t *testing.T) {
+	fonts, err := loadFonts()
+	if err != nil {
+		t.Fatal(err)
+	}
+	month, err := Build(context.Background(), prayertime.New(), kazanProfile(), time.Date(2026, time.March, 1, 12, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for code, drawable := range map[string]bool{"en": true, "ru": true, "tr": true, "uz": true, "ar": false, "tt": false} {
+		if got := tabulate(month, i18n.Resolve(code)).drawableWith(fonts[0]); got != drawable {
+			t.Errorf("%s drawable = %v, want %v", code, got, drawable)
+		}
+	}
+}
+
+func TestParseMonthStaysWithinAYear(t *testing.T) {
+	now := time.Date(2026, time.October, 17, 12, 0, 0, 0, time.UTC)
+	month, err := ParseMonth("2027-10", now, "Asia/Tokyo")
+	if err != nil || month.Month() != time.October || month.Location().String() != "Asia/Tokyo" {
+		t.Fatalf("ParseMonth = %s, %v", month, err)
+	}
+	for _, value := range []string{"2027-11", "2025-09", "2026-13", "October"} {
+		if _, err := ParseMonth(value, now, "Asia/Tokyo"); err == nil {
+			t.Errorf("ParseMonth(%q) should fail", value)
+		}
+	}
+	if format, ok := ParseFormat("png"); !ok || (Month{Start: month}).Filename(format) != "prayer-times-2027-10.png" {
+		t.Fatalf("ParseFormat(png) = %q, %v", format, ok)
+	}
+	if _, ok := ParseFormat("docx"); ok {
+		t.Fatal("docx is not a timetable format")
+	}
+}
@@ -0,0 +1,140 @@
+package moon
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/hablullah/go-sampa"
+)
+
+// NakedEye is the least Odeh visibility at which the crescent can be seen
+// without optical aid.
+const NakedEye = 5.65
+
+// LastEvening bounds how many evenings after the conjunction the crescent is
+// looked for. By then a month begins regardless, as when the crescent stays
+// below the horizon at high latitudes.
+const LastEvening = 2
+
+// Crescent is the outlook for the first crescent after a new moon.
+type Crescent struct {
+	NewMoon time.Time
+	// Evening is the local date of the first evening the crescent is expected
+	// to be seen with the naked eye, or zero when it is not expected within
+	// LastEvening evenings after the conjunction's.
+	Evening time.Time
+	// Visibility is Odeh's V on that evening.
+	Visibility float64
+}
+
+// Seen reports whether the crescent is expected within LastEvening evenings.
+func (c Crescent) Seen() bool { return !c.Evening.IsZero() }
+
+// FirstCrescent finds the first evening from the local date of newMoon on
+// which the crescent is expected to be seen from the place. Evenings are
+// local dates in zone.
+func FirstCrescent(newMoon time.Time, latitude, longitude float64, zone *time.Location) (Crescent, error) {
+	place := sampa.Location{Latitude: latitude, Longitude: longitude}
+	local := newMoon.In(zone)
+	for offset := 0; offset <= LastEvening; offset++ {
+		evening := time.Date(local.Year(), local.Month(), local.Day()+offset, 0, 0, 0, 0, zone)
+		visibility, ok, err := Odeh(evening, newMoon, place)
+		if err != nil {
+			return Crescent{}, err
+		}
+		if ok && visibility >= NakedEye {
+			return Crescent{NewMoon: newMoon, Evening: evening, Visibility: visibility}, nil
+		}
+	}
+	return Crescent{NewMoon: newMoon}, nil
+}
+
+// WatchDays is how many days before a new moon Watch starts reporting it.
+const WatchDays = 3
+
+// Watch returns the crescent a local date waits for: from WatchDays before a
+// new moon until the evening its crescent is expected, or the last evening it
+// is looked for when it is not. It reports false on other dates.
+func Watch(date time.Time, latitude, longitude float64) (Crescent, bool, error) {
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
+	newMoon := NewMoonBefore(day.AddDate(0, 0, WatchDays+1))
+	if newMoon.Before(day.AddDate(0, 0, -LastEvening-1)) {
+		return Crescent{}, false, nil
+	}
+	crescent, err := FirstCrescent(newMoon, latitude, longitude, date.Location())
+	if err != nil {
+		return Crescent{}, false, err
+	}
+	last := crescent.Evening
+	if !crescent.Seen() {
+		local := newMoon.In(date.Location())
+		last = time.Date(local.Year(), local.Month(), local.Day()+LastEvening, 0, 0, 0, 0, date.Location())
+	}
+	if day.After(last) {
+		return Crescent{}, false, nil
+	}
+	return crescent, true, nil
+}
+
+// Airless drops atmospheric refraction, which visibility criteria leave out
+// of their altitudes; sampa treats a zero pressure as the default.
+func Airless(place sampa.Location) sampa.Location {
+	place.Pressure = math.SmallestNonzeroFloat64
+	return place
+}
+
+// Odeh returns the visibility V of Odeh's criterion (2004) for the crescent
+// on the evening of the given date at place, taken at the best time, four
+// ninths of the lag between sunset and moonset. It reports false when there
+// is no crescent to see: the sun sets before the conjunction or the moon sets
+// first.
+func Odeh(evening, conjunction time.Time, place sampa.Location) (float64, bool, error) {
+	sun, err := sampa.GetSunEvents(evening, place, nil)
+	if err != nil {
+		return 0, false, fmt.Errorf("calculate sunset: %w", err)
+	}
+	sunset := sun.Sunset.DateTime
+	if sunset.IsZero() || !sunset.After(conjunction) {
+		return 0, false, nil
+	}
+	moonset, err := moonsetAfter(evening, sunset, place)
+	if err != nil || moonset.IsZero() {
+		return 0, false, err
+	}
+	best := sunset.Add(moonset.Sub(sunset) * 4 / 9)
+	airless := Airless(place)
+	moon, err := sampa.GetMoonPosition(best, airless, nil)
+	if err != nil {
+		return 0, false, fmt.Errorf("calculate moon position: %w", err)
+	}
+	sunAt, err := sampa.GetSunPosition(best, airless, nil)
+	if err != nil {
+		return 0, false, fmt.Errorf("calculate sun position: %w", err)
+	}
+	arcOfVision := moon.TopocentricElevationAngle - sunAt.TopocentricElevationAngle
+	// The crescent's width in arcminutes: the moon's semi-diameter, 0.2725 of
+	// its horizontal parallax, lit across the arc of light.
+	width := 0.2725 * moon.HorizontalParallax * 60 * (1 - math.Cos(moon.Elongation*math.Pi/180))
+	return arcOfVision - (-0.1018*width*width*width + 0.7319*width*width - 6.3226*width + 7.1651), true, nil
+}
+
+// moonsetAfter is the first moonset after sunset, which can fall after
+// midnight, or zero when the moon set first.
+func moonsetAfter(evening, sunset time.Time, place sampa.Location) (time.Time, error) {
+	for offset := range 2 {
+		events, err := sampa.GetMoonEvents(evening.AddDate(0, 0, offset), place, nil)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("calculate moonset: %w", err)
+		}
+		moonset := events.Moonset.DateTime
+		if moonset.IsZero() {
+			continue
+		}
+		if offset == 0 && !moonset.After(sunset) {
+			return time.Time{}, nil
+		}
+		return moonset, nil
+	}
+	return time.Time{}, nil
+}
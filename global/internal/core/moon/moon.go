@@ -0,0 +1,108 @@
+// Package moon describes the moon as a place sees it: its phase, how much of
+// it is lit, its age since the new moon, and the evening its first crescent
+// can be expected, which is when a Hijri month may begin.
+package moon
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/hablullah/go-sampa"
+)
+
+// Phase names the eight phases of a lunation. The four principal phases
+// cover the 15° of elongation around them, the rest the spans between.
+type Phase string
+
+const (
+	PhaseNew            Phase = "new"
+	PhaseWaxingCrescent Phase = "waxing_crescent"
+	PhaseFirstQuarter   Phase = "first_quarter"
+	PhaseWaxingGibbous  Phase = "waxing_gibbous"
+	PhaseFull           Phase = "full"
+	PhaseWaningGibbous  Phase = "waning_gibbous"
+	PhaseLastQuarter    Phase = "last_quarter"
+	PhaseWaningCrescent Phase = "waning_crescent"
+)
+
+// Phases lists the phases in the order a lunation passes through them.
+func Phases() []Phase {
+	return []Phase{
+		PhaseNew, PhaseWaxingCrescent, PhaseFirstQuarter, PhaseWaxingGibbous,
+		PhaseFull, PhaseWaningGibbous, PhaseLastQuarter, PhaseWaningCrescent,
+	}
+}
+
+var phaseEmoji = map[Phase]string{
+	PhaseNew: "🌑", PhaseWaxingCrescent: "🌒", PhaseFirstQuarter: "🌓", PhaseWaxingGibbous: "🌔",
+	PhaseFull: "🌕", PhaseWaningGibbous: "🌖", PhaseLastQuarter: "🌗", PhaseWaningCrescent: "🌘",
+}
+
+func (p Phase) Emoji() string { return phaseEmoji[p] }
+
+var phases = map[sampa.MoonPhase]Phase{
+	sampa.NewMoon: PhaseNew, sampa.WaxingCrescent: PhaseWaxingCrescent,
+	sampa.FirstQuarter: PhaseFirstQuarter, sampa.WaxingGibbous: PhaseWaxingGibbous,
+	sampa.FullMoon: PhaseFull, sampa.WaningGibbous: PhaseWaningGibbous,
+	sampa.LastQuarter: PhaseLastQuarter, sampa.WaningCrescent: PhaseWaningCrescent,
+}
+
+// State is the moon at one moment.
+type State struct {
+	Phase Phase
+	// Illumination is the lit fraction of the disc, from 0 to 1.
+	Illumination float64
+	// Age is the time since NewMoon, the last conjunction at or before the
+	// moment; NextNewMoon is the one after it.
+	Age         time.Duration
+	NewMoon     time.Time
+	NextNewMoon time.Time
+}
+
+// AgeDays is the age in whole days, counting the day of the new moon as 0.
+func (s State) AgeDays() int { return int(s.Age.Hours() / 24) }
+
+// At returns the moon at t seen from the place.
+func At(t time.Time, latitude, longitude float64) (State, error) {
+	position, err := sampa.GetMoonPosition(t.UTC(), sampa.Location{Latitude: latitude, Longitude: longitude}, nil)
+	if err != nil {
+		return State{}, fmt.Errorf("calculate moon position: %w", err)
+	}
+	newMoon := NewMoonBefore(t)
+	return State{
+		Phase:        phases[position.Phase],
+		Illumination: math.Max(0, math.Min(1, position.PercentIlluminated)),
+		Age:          t.Sub(newMoon),
+		NewMoon:      newMoon,
+		NextNewMoon:  NewMoonAfter(t),
+	}, nil
+}
+
+// OnDay returns the moon at local noon of date, which stands for the whole
+// day in schedules.
+func OnDay(date time.Time, latitude, longitude float64) (State, error) {
+	return At(time.Date(date.Year(), date.Month(), date.Day(), 12, 0, 0, 0, date.Location()), latitude, longitude)
+}
+
+// NewMoonBefore returns the last conjunction at or before t.
+func NewMoonBefore(t time.Time) time.Time {
+	phases := sampa.GetMoonPhases(t.UTC(), nil)
+	for range 3 {
+		switch {
+		case phases.NewMoon.After(t):
+			phases = sampa.GetMoonPhases(phases.NewMoon.AddDate(0, 0, -20), nil)
+		case !phases.NextNewMoon.After(t):
+			phases = sampa.GetMoonPhases(phases.NextNewMoon.AddDate(0, 0, 5), nil)
+		default:
+			return phases.NewMoon
+		}
+	}
+	return phases.NewMoon
+}
+
+// NewMoonAfter returns the first conjunction after t.
+func NewMoonAfter(t time.Time) time.Time {
+	// A lunation is never shorter than 29.2 days.
+	return NewMoonBefore(NewMoonBefore(t).Add(30 * 24 * time.Hour))
+}
@@ -0,0 +1,58 @@
+package moon
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAtDescribesFullAndNewMoon(t *testing.T) {
+	full, err := At(time.Date(2025, time.March, 14, 6, 55, 0, 0, time.UTC), 21.42, 39.83)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if full.Phase != PhaseFull || full.Illumination < 0.99 || full.AgeDays() != 14 {
+		t.Fatalf("unexpected full moon: %+v", full)
+	}
+	young, err := At(time.Date(2025, time.March, 29, 12, 0, 0, 0, time.UTC), 21.42, 39.83)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if young.Phase != PhaseNew || young.Illumination > 0.01 || young.AgeDays() != 0 {
+		t.Fatalf("unexpected new moon: %+v", young)
+	}
+	if got := young.NewMoon.Format(time.DateOnly); got != "2025-03-29" {
+		t.Fatalf("new moon on %s, want 2025-03-29", got)
+	}
+	if gap := young.NextNewMoon.Sub(young.NewMoon).Hours() / 24; gap < 29 || gap > 30 {
+		t.Fatalf("next new moon %.1f days after the last", gap)
+	}
+}
+
+func TestWatchFollowsTheCrescentUntilItIsExpected(t *testing.T) {
+	riyadh, err := time.LoadLocation("Asia/Riyadh")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tc := range []struct {
+		day      int
+		watching bool
+	}{
+		{day: 24}, {day: 25, watching: true}, {day: 28, watching: true}, {day: 29, watching: true},
+	} {
+		date := time.Date(2025, time.February, tc.day, 9, 0, 0, 0, riyadh)
+		crescent, watching, err := Watch(date, 21.42, 39.83)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if watching != tc.watching {
+			t.Fatalf("%s: watching %t, want %t", date.Format(time.DateOnly), watching, tc.watching)
+		}
+		// The crescent of 28 February was too young for Mecca that evening.
+		if watching && crescent.Evening.Format(time.DateOnly) != "2025-03-01" {
+			t.Fatalf("%s: crescent expected %+v", date.Format(time.DateOnly), crescent)
+		}
+	}
+	if _, watching, err := Watch(time.Date(2025, time.March, 2, 9, 0, 0, 0, riyadh), 21.42, 39.83); err != nil || watching {
+		t.Fatalf("still watching after the crescent was seen: %t, %v", watching, err)
+	}
+}
@@ -0,0 +1,164 @@
+package occasions
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"slices"
+)
+
+//go:embed catalog.json
+var catalogJSON []byte
+
+// catalog is read once at startup; a file that fails parse stops the process
+// before it serves anything.
+var catalog = mustParse(catalogJSON)
+
+// Translation is an occasion's copy in one language.
+type Translation struct {
+	Title   string `json:"title"`
+	Summary string `json:"summary"`
+	// Action is the recommended deed.
+	Action string `json:"action"`
+}
+
+// parsedCatalog is a checked catalog file.
+type parsedCatalog struct {
+	locales      []string
+	definitions  []Definition
+	translations map[string]map[string]Translation
+}
+
+type catalogEntry struct {
+	Definition
+	Copy map[string]Translation `json:"copy"`
+}
+
+var (
+	idPattern      = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+	countryPattern = regexp.MustCompile(`^[A-Z]{2}$`)
+)
+
+// parse reads a catalog file: the locales every occasion is translated into,
+// English first among them, and the occasions. An occasion needs a unique
+// snake_case ID, a major, fasting or observed category, an emoji, a Hijri
+// date whose span ends by the 30th, HTTPS sources, a title, summary and
+// action in every locale, and variants that name each country once.
+func parse(data []byte) (parsedCatalog, error) {
+	var file struct {
+		Locales   []string       `json:"locales"`
+		Occasions []catalogEntry `json:"occasions"`
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&file); err != nil {
+		return parsedCatalog{}, fmt.Errorf("decode occasion catalog: %w", err)
+	}
+	if len(file.Locales) == 0 || file.Locales[0] != "en" {
+		return parsedCatalog{}, fmt.Errorf("occasion catalog locales must start with en")
+	}
+	for index, code := range file.Locales {
+		if slices.Contains(file.Locales[:index], code) {
+			return parsedCatalog{}, fmt.Errorf("occasion catalog lists locale %q twice", code)
+		}
+	}
+	result := parsedCatalog{locales: file.Locales, translations: make(map[string]map[string]Translation, len(file.Occasions))}
+	for _, entry := range file.Occasions {
+		if err := entry.validate(file.Locales); err != nil {
+			return parsedCatalog{}, fmt.Errorf("occasion %q: %w", entry.ID, err)
+		}
+		if _, ok := result.translations[entry.ID]; ok {
+			return parsedCatalog{}, fmt.Errorf("occasion %q is listed twice", entry.ID)
+		}
+		result.definitions = append(result.definitions, entry.Definition)
+		result.translations[entry.ID] = entry.Copy
+	}
+	return result, nil
+}
+
+func mustParse(data []byte) parsedCatalog {
+	result, err := parse(data)
+	if err != nil {
+		panic(err)
+	}
+	return result
+}
+
+func (e catalogEntry) validate(locales []string) error {
+	if !idPattern.MatchString(e.ID) {
+		return fmt.Errorf("the ID must be snake_case")
+	}
+	if e.Category != CategoryMajor && e.Category != CategoryFasting && e.Category != CategoryObserved {
+		return fmt.Errorf("unknown category %q", e.Category)
+	}
+	if e.Emoji == "" {
+		return fmt.Errorf("the emoji is missing")
+	}
+	if err := validDate(e.Month, e.Day, e.Length, e.Step); err != nil {
+		return err
+	}
+	for _, source := range e.Sources {
+		parsed, err := url.Parse(source.URL)
+		if source.Label == "" || err != nil || parsed.Scheme != "https" || parsed.Host == "" {
+			return fmt.Errorf("source %q needs a label and an HTTPS URL", source.URL)
+		}
+	}
+	var countries []string
+	for _, variant := range e.Variants {
+		if len(variant.Countries) == 0 {
+			return fmt.Errorf("a variant names no country")
+		}
+		for _, country := range variant.Countries {
+			if !countryPattern.MatchString(country) || slices.Contains(countries, country) {
+				return fmt.Errorf("variant country %q is invalid or repeated", country)
+			}
+			countries = append(countries, country)
+		}
+		if err := validDate(variant.Month, variant.Day, variant.Length, variant.Step); err != nil {
+			return fmt.Errorf("variant for %v: %w", variant.Countries, err)
+		}
+	}
+	for _, code := range locales {
+		copy := e.Copy[code]
+		if copy.Title == "" || copy.Summary == "" || copy.Action == "" {
+			return fmt.Errorf("the %s copy needs a title, summary and action", code)
+		}
+	}
+	for code := range e.Copy {
+		if !slices.Contains(locales, code) {
+			return fmt.Errorf("copy for unlisted locale %q", code)
+		}
+	}
+	return nil
+}
+
+func validDate(month, day, length, step int) error {
+	if month < 1 || month > 12 || day < 1 || day > 30 {
+		return fmt.Errorf("invalid Hijri date %d/%d", day, month)
+	}
+	if length < 0 || step < 0 || day+max(length, 1) > 31 || max(step, 1) > max(length, 1) {
+		return fmt.Errorf("invalid span of %d days every %d from the %d", length, step, day)
+	}
+	return nil
+}
+
+// Locales lists the languages the catalog is translated into, English first.
+func Locales() []string {
+	return slices.Clone(catalog.locales)
+}
+
+// Translate returns the catalog occasion's copy in the locale, falling back to
+// English.
+func Translate(id, locale string) (Translation, bool) {
+	copies, ok := catalog.translations[id]
+	if !ok {
+		return Translation{}, false
+	}
+	if copy, ok := copies[locale]; ok {
+		return copy, true
+	}
+	return copies["en"], true
+}
@@ -0,0 +1,84 @@
+package occasions
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/escalopa/prayer-bot/global/internal/domain"
+)
+
+const validEntry = `{"id": "ashura", "category": "fasting", "emoji": "🌊", "month": 1, "day": 10,
+	"sources": [{"label": "Sahih Muslim 1162a", "url": "https://sunnah.com/muslim:1162a"}],
+	"copy": {"en": {"title": "Ashura", "summary": "The tenth of Muharram.", "action": "Fast."},
+		"ar": {"title": "عاشوراء", "summary": "العاشر من المحرم.", "action": "صم."}}}`
+
+func TestEmbeddedCatalogParses(t *testing.T) {
+	parsed, err := parse(catalogJSON)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(parsed.definitions) == 0 || parsed.locales[0] != "en" {
+		t.Fatalf("unexpected catalog: %d occasions in %v", len(parsed.definitions), parsed.locales)
+	}
+	if copy, ok := Translate("ashura", "xx"); !ok || copy.Title != "Day of Ashura" {
+		t.Fatalf("an unknown locale must fall back to English: %+v", copy)
+	}
+}
+
+func TestParseRejectsBrokenCatalogs(t *testing.T) {
+	file := func(locales, entries string) []byte {
+		return []byte(`{"locales": ` + locales + `, "occasions": [` + entries + `]}`)
+	}
+	if _, err := parse(file(`["en", "ar"]`, validEntry)); err != nil {
+		t.Fatalf("the valid entry was rejected: %v", err)
+	}
+	for _, tc := range []struct {
+		name    string
+		data    []byte
+		message string
+	}{
+		{"locales without English first", file(`["ar", "en"]`, validEntry), "start with en"},
+		{"duplicate ID", file(`["en", "ar"]`, validEntry+","+validEntry), "listed twice"},
+		{"unknown field", file(`["en", "ar"]`, strings.Replace(validEntry, `"month"`, `"hijri_month"`, 1)), "unknown field"},
+		{"bad ID", file(`["en", "ar"]`, strings.Replace(validEntry, `"ashura"`, `"Ashura Day"`, 1)), "snake_case"},
+		{"personal category", file(`["en", "ar"]`, strings.Replace(validEntry, `"fasting"`, `"personal"`, 1)), "unknown category"},
+		{"day 31", file(`["en", "ar"]`, strings.Replace(validEntry, `"day": 10`, `"day": 31`, 1)), "invalid Hijri date"},
+		{"span past the 30th", file(`["en", "ar"]`, strings.Replace(validEntry, `"day": 10`, `"day": 28, "length": 4`, 1)), "invalid span"},
+		{"plain HTTP source", file(`["en", "ar"]`, strings.Replace(validEntry, "https://", "http://", 1)), "HTTPS"},
+		{"missing translation", file(`["en", "ar", "fr"]`, validEntry), "the fr copy"},
+		{"unlisted translation", file(`["en"]`, validEntry), "unlisted locale"},
+		{"repeated country", file(`["en", "ar"]`, strings.Replace(validEntry, `"sources"`,
+			`"variants": [{"countries": ["IR"], "month": 1, "day": 9}, {"countries": ["IR"], "month": 1, "day": 11}], "sources"`, 1)), "repeated"},
+		{"variant without a country", file(`["en", "ar"]`, strings.Replace(validEntry, `"sources"`,
+			`"variants": [{"countries": [], "month": 1, "day": 9}], "sources"`, 1)), "no country"},
+	} {
+		if _, err := parse(tc.data); err == nil || !strings.Contains(err.Error(), tc.message) {
+			t.Errorf("%s: got %v, want an error about %q", tc.name, err, tc.message)
+		}
+	}
+}
+
+func TestBetweenKeepsCountryVariants(t *testing.T) {
+	hijriCalendar := calendar(t, domain.HijriUmmAlQura, 0)
+	start := time.Date(2026, time.January, 1, 12, 0, 0, 0, time.UTC)
+	mawlid := func(country string) int {
+		upcoming, err := Between(start, 354, hijriCalendar, country)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, occurrence := range upcoming {
+			if occurrence.Definition.ID == "mawlid" {
+				return occurrence.Hijri.Day
+			}
+		}
+		t.Fatalf("no Mawlid for %q", country)
+		return 0
+	}
+	if got := mawlid("EG"); got != 12 {
+		t.Fatalf("Mawlid in Egypt on the %d", got)
+	}
+	if got := mawlid("IR"); got != 17 {
+		t.Fatalf("Mawlid in Iran on the %d", got)
+	}
+}
@@ -1,12 +1,15 @@
 // Package occasions provides the curated Islamic-date catalog used by the
-// Mini App, calendar feed, and reminder planner.
+// Mini App, calendar feed, and reminder planner. The catalog and its
+// translations live in catalog.json, which is checked when the package loads.
 package occasions
 
 import (
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/escalopa/prayer-bot/global/internal/core/hijri"
+	"github.com/escalopa/prayer-bot/global/internal/domain"
 )
 
 type Category string
@@ -15,112 +18,206 @@ const (
 	CategoryMajor    Category = "major"
 	CategoryFasting  Category = "fasting"
 	CategoryObserved Category = "observed"
+	// CategoryPersonal holds a chat's own events; see Personal.
+	CategoryPersonal Category = "personal"
 )
 
 type Source struct {
-	Label string
-	URL   string
+	Label string `json:"label"`
+	URL   string `json:"url"`
 }
 
 type Definition struct {
-	ID       string
-	Month    int
-	Day      int
-	Category Category
-	Emoji    string
-	Sources  []Source
+	ID    string `json:"id"`
+	Month int    `json:"month"`
+	Day   int    `json:"day"`
+	// Length is how many days from Day the observance spans, one when zero.
+	// Step keeps every Step-th of them, as the odd nights of the last ten.
+	Length int `json:"length"`
+	Step   int `json:"step"`
+	// EachDay observances are reminded of on each of their days; others on
+	// the first only, and calendars show them as one span.
+	EachDay bool `json:"each_day"`
+	// Nights observances are kept on the nights that open their Hijri days at
+	// the previous sunset, so they are dated on that evening and their days are
+	// named by night.
+	Nights bool `json:"nights"`
+	// NoFasting marks days on which fasting is prohibited.
+	NoFasting bool     `json:"no_fasting"`
+	Category  Category `json:"category"`
+	Emoji     string   `json:"emoji"`
+	Sources   []Source `json:"sources"`
+	// Variants move the observance in the countries they name.
+	Variants []Variant `json:"variants"`
+	// Title names a personal event; catalog entries are named by Translation.
+	Title string `json:"-"`
+	// Gregorian definitions recur on Month and Day of the civil calendar.
+	Gregorian bool `json:"-"`
+}
+
+// Variant is where a country keeps an observance on other days, such as the
+// Mawlid on 17 Rabi al-Awwal in Iran. It replaces the definition's Month,
+// Day, Length and Step there.
+type Variant struct {
+	Countries []string `json:"countries"`
+	Month     int      `json:"month"`
+	Day       int      `json:"day"`
+	Length    int      `json:"length"`
+	Step      int      `json:"step"`
 }
 
 type Occurrence struct {
 	Definition Definition
-	Date       time.Time
-	Hijri      hijri.Date
+	// Date is the day the observance is kept, or the evening its night begins;
+	// Hijri is then the day that night opens.
+	Date  time.Time
+	Hijri hijri.Date
+	// Day counts the observance's days from 1, and Days is how many it has;
+	// a span through the 30th never reaches its last day in a 29-day month.
+	Day, Days int
 }
 
-var catalog = []Definition{
-	{
-		ID: "ashura", Month: 1, Day: 10, Category: CategoryFasting, Emoji: "🌊",
-		Sources: []Source{{Label: "Sahih Muslim 1162a", URL: "https://sunnah.com/muslim:1162a"}},
-	},
-	{
-		ID: "mawlid", Month: 3, Day: 12, Category: CategoryObserved, Emoji: "ﷺ",
-		Sources: []Source{
-			{Label: "Quran 33:56", URL: "https://quran.com/33/56"},
-			{Label: "Sahih Muslim 1162e", URL: "https://sunnah.com/muslim:1162e"},
-		},
-	},
-	{
-		ID: "isra_miraj", Month: 7, Day: 27, Category: CategoryObserved, Emoji: "✨",
-		Sources: []Source{{Label: "Quran 17:1", URL: "https://quran.com/17/1"}},
-	},
-	{
-		ID: "mid_shaban", Month: 8, Day: 15, Category: CategoryObserved, Emoji: "🌕",
-	},
-	{
-		ID: "ramadan_start", Month: 9, Day: 1, Category: CategoryMajor, Emoji: "🌙",
-		Sources: []Source{{Label: "Quran 2:185", URL: "https://quran.com/2/185"}},
-	},
-	{
-		ID: "last_ten_nights", Month: 9, Day: 21, Category: CategoryMajor, Emoji: "🤲",
-		Sources: []Source{
-			{Label: "Surah Al-Qadr", URL: "https://quran.com/al-qadr"},
-			{Label: "Sahih al-Bukhari 2017", URL: "https://sunnah.com/bukhari:2017"},
-			{Label: "Jami at-Tirmidhi 3513", URL: "https://sunnah.com/tirmidhi/48/144"},
-		},
-	},
-	{
-		ID: "eid_fitr", Month: 10, Day: 1, Category: CategoryMajor, Emoji: "🎉",
-		Sources: []Source{{Label: "Quran 2:185", URL: "https://quran.com/2/185"}},
-	},
-	{
-		ID: "dhul_hijjah_start", Month: 12, Day: 1, Category: CategoryMajor, Emoji: "🕋",
-		Sources: []Source{{Label: "Sahih al-Bukhari 969", URL: "https://sunnah.com/bukhari:969"}},
-	},
-	{
-		ID: "arafah", Month: 12, Day: 9, Category: CategoryFasting, Emoji: "🤍",
-		Sources: []Source{{Label: "Sahih Muslim 1162a", URL: "https://sunnah.com/muslim:1162a"}},
-	},
-	{
-		ID: "eid_adha", Month: 12, Day: 10, Category: CategoryMajor, Emoji: "🐑",
-		Sources: []Source{{Label: "Quran 22:36", URL: "https://quran.com/22/36"}},
-	},
+// Reminded reports whether the date gets its own reminder.
+func (o Occurrence) Reminded() bool { return o.Day == 1 || o.Definition.EachDay }
+
+// Eve is the evening the occurrence is reminded of: the evening before its day,
+// or the evening its night begins.
+func (o Occurrence) Eve() time.Time {
+	if o.Definition.Nights {
+		return o.Date
+	}
+	return o.Date.AddDate(0, 0, -1)
+}
+
+// First is the date of the observance's first day.
+func (o Occurrence) First() time.Time {
+	_, step := o.Definition.span()
+	return o.Date.AddDate(0, 0, -(o.Day-1)*step)
 }
 
 func Catalog() []Definition {
-	result := make([]Definition, len(catalog))
-	for index, definition := range catalog {
+	result := make([]Definition, len(catalog.definitions))
+	for index, definition := range catalog.definitions {
 		result[index] = definition
 		result[index].Sources = append([]Source(nil), definition.Sources...)
+		result[index].Variants = append([]Variant(nil), definition.Variants...)
 	}
 	return result
 }
 
-func Between(start time.Time, days, adjustment int) ([]Occurrence, error) {
+// ForCountry is the definition as the country keeps it. Country is an ISO
+// 3166-1 alpha-2 code; an empty one keeps the definition as it is.
+func (d Definition) ForCountry(country string) Definition {
+	for _, variant := range d.Variants {
+		if slices.Contains(variant.Countries, country) {
+			d.Month, d.Day, d.Length, d.Step = variant.Month, variant.Day, variant.Length, variant.Step
+			break
+		}
+	}
+	return d
+}
+
+// Personal turns a chat's events into definitions for Between. Their IDs,
+// "personal-<event id>", stay stable while the event exists.
+func Personal(events []domain.PersonalEvent) []Definition {
+	definitions := make([]Definition, 0, len(events))
+	for _, event := range events {
+		definitions = append(definitions, Definition{
+			ID: fmt.Sprintf("personal-%d", event.ID), Month: event.Month, Day: event.Day,
+			Category: CategoryPersonal, Emoji: "⭐", Title: event.Title, Gregorian: !event.Hijri,
+		})
+	}
+	return definitions
+}
+
+// Between lists the catalog occasions as the country keeps them, and any
+// personal definitions, in the days from start, with one occurrence for each
+// day of a span. A night is listed on the evening it begins, a day before the
+// Hijri day it opens. A single day on the 30th of a Hijri month falls on the 29th
+// in years the month has 29 days, and one on 29 February on 28 February
+// outside leap years, so a yearly event is never skipped.
+func Between(start time.Time, days int, calendar hijri.Calendar, country string, personal ...Definition) ([]Occurrence, error) {
 	if days < 1 || days > 400 {
 		return nil, fmt.Errorf("occasion range must be between 1 and 400 days")
 	}
+	definitions := make([]Definition, 0, len(catalog.definitions)+len(personal))
+	for _, definition := range catalog.definitions {
+		definitions = append(definitions, definition.ForCountry(country))
+	}
+	definitions = append(definitions, personal...)
 	var result []Occurrence
+	hijriDate, err := calendar.Date(start)
+	if err != nil {
+		return nil, err
+	}
+	tomorrow, err := calendar.Date(start.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, err
+	}
 	for offset := 0; offset < days; offset++ {
 		date := start.AddDate(0, 0, offset)
-		hijriDate, err := hijri.FromGregorian(date, adjustment)
+		// The next day tells whether today ends the Hijri month, and the one
+		// after it whether tonight's Hijri day does.
+		after, err := calendar.Date(date.AddDate(0, 0, 2))
 		if err != nil {
 			return nil, err
 		}
-		for _, definition := range catalog {
-			if definition.Month == hijriDate.Month && definition.Day == hijriDate.Day {
+		for _, definition := range definitions {
+			kept, monthEnds := hijriDate, tomorrow.Day == 1
+			if definition.Nights {
+				kept, monthEnds = tomorrow, after.Day == 1
+			}
+			if day, ok := definition.falls(date, kept, monthEnds); ok {
 				result = append(result, Occurrence{
 					Definition: definition,
 					Date:       date,
-					Hijri:      hijriDate,
+					Hijri:      kept,
+					Day:        day,
+					Days:       definition.Days(),
 				})
 			}
 		}
+		hijriDate, tomorrow = tomorrow, after
 	}
 	return result, nil
 }
 
-func Next(start time.Time, adjustment int, category Category) (Occurrence, error) {
-	upcoming, err := Between(start, 400, adjustment)
+// Days is how many days the observance has.
+func (d Definition) Days() int {
+	length, step := d.span()
+	return (length-1)/step + 1
+}
+
+func (d Definition) span() (length, step int) {
+	return max(d.Length, 1), max(d.Step, 1)
+}
+
+// falls reports whether the observance is kept on the date and, if so, which
+// of its days the date is.
+func (d Definition) falls(date time.Time, hijriDate hijri.Date, monthEnds bool) (int, bool) {
+	if d.Gregorian {
+		if int(date.Month()) != d.Month {
+			return 0, false
+		}
+		leap := time.Date(date.Year(), time.February, 29, 0, 0, 0, 0, time.UTC).Day() == 29
+		return 1, date.Day() == d.Day || d.Month == 2 && d.Day == 29 && date.Day() == 28 && !leap
+	}
+	if hijriDate.Month != d.Month {
+		return 0, false
+	}
+	length, step := d.span()
+	if length == 1 {
+		return 1, hijriDate.Day == d.Day || d.Day == 30 && hijriDate.Day == 29 && monthEnds
+	}
+	offset := hijriDate.Day - d.Day
+	if offset < 0 || offset >= length || offset%step != 0 {
+		return 0, false
+	}
+	return offset/step + 1, true
+}
+
+func Next(start time.Time, calendar hijri.Calendar, country string, category Category) (Occurrence, error) {
+	upcoming, err := Between(start, 400, calendar, country)
 	if err != nil {
 		return Occurrence{}, err
 	}
@@ -132,15 +229,20 @@ func Next(start time.Time, adjustment int, category Category) (Occurrence, error
 	return Occurrence{}, fmt.Errorf("no %s occasion found in the next 400 days", category)
 }
 
-func OnDate(date time.Time, adjustment int, category Category) (Occurrence, bool) {
-	upcoming, err := Between(date, 1, adjustment)
+// OnEve lists the catalog occasions of the category reminded of on the
+// evening: those kept the next day, such as Eid al-Adha within the first ten
+// days of Dhu al-Hijjah, and the nights that begin that evening.
+func OnEve(evening time.Time, calendar hijri.Calendar, country string, category Category) ([]Occurrence, error) {
+	upcoming, err := Between(evening, 2, calendar, country)
 	if err != nil {
-		return Occurrence{}, false
+		return nil, err
 	}
+	var result []Occurrence
 	for _, occurrence := range upcoming {
-		if occurrence.Definition.Category == category {
-			return occurrence, true
+		if occurrence.Definition.Category == category &&
+			occurrence.Eve().Format(time.DateOnly) == evening.Format(time.DateOnly) {
+			result = append(result, occurrence)
 		}
 	}
-	return Occurrence{}, false
+	return result, nil
 }
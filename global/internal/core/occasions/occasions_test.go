@@ -1,11 +1,25 @@
 package occasions
 
 import (
+	"fmt"
 	"net/url"
+	"slices"
 	"testing"
 	"time"
+
+	"github.com/escalopa/prayer-bot/global/internal/core/hijri"
+	"github.com/escalopa/prayer-bot/global/internal/domain"
 )
 
+func calendar(t *testing.T, system domain.HijriCalendar, adjustment int) hijri.Calendar {
+	t.Helper()
+	result, err := hijri.New(system, 0, 0, adjustment)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return result
+}
+
 func TestCatalogHasUniqueStableIDsAndValidSources(t *testing.T) {
 	seen := map[string]bool{}
 	for _, definition := range Catalog() {
@@ -17,6 +31,10 @@ func TestCatalogHasUniqueStableIDsAndValidSources(t *testing.T) {
 			definition.Day < 1 || definition.Day > 30 {
 			t.Fatalf("invalid Hijri date for %q", definition.ID)
 		}
+		if definition.Length < 0 || definition.Day+max(definition.Length, 1) > 31 ||
+			definition.Step < 0 || definition.Step > max(definition.Length, 1) {
+			t.Fatalf("invalid span for %q", definition.ID)
+		}
 		for _, source := range definition.Sources {
 			if source.Label == "" || source.URL == "" {
 				t.Fatalf("incomplete source for %q", definition.ID)
@@ -31,11 +49,11 @@ func TestCatalogHasUniqueStableIDsAndValidSources(t *testing.T) {
 
 func TestBetweenRespectsHijriAdjustment(t *testing.T) {
 	start := time.Date(2026, time.January, 1, 12, 0, 0, 0, time.UTC)
-	withoutAdjustment, err := Between(start, 400, 0)
+	withoutAdjustment, err := Between(start, 400, calendar(t, domain.HijriUmmAlQura, 0), "")
 	if err != nil {
 		t.Fatal(err)
 	}
-	withAdjustment, err := Between(start, 400, 1)
+	withAdjustment, err := Between(start, 400, calendar(t, domain.HijriUmmAlQura, 1), "")
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -56,7 +74,7 @@ func TestBetweenRespectsHijriAdjustment(t *testing.T) {
 
 func TestNextFiltersCategory(t *testing.T) {
 	start := time.Date(2026, time.January, 1, 12, 0, 0, 0, time.UTC)
-	occurrence, err := Next(start, 0, CategoryObserved)
+	occurrence, err := Next(start, calendar(t, domain.HijriUmmAlQura, 0), "", CategoryObserved)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -64,3 +82,185 @@ func TestNextFiltersCategory(t *testing.T) {
 		t.Fatalf("unexpected occurrence: %+v", occurrence)
 	}
 }
+
+func TestBetweenFollowsTheChosenCalendar(t *testing.T) {
+	start := time.Date(2025, time.March, 1, 12, 0, 0, 0, time.UTC)
+	first := func(system domain.HijriCalendar) time.Time {
+		upcoming, err := Between(start, 60, calendar(t, system, 0), "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, occurrence := range upcoming {
+			if occurrence.Definition.ID == "eid_fitr" {
+				return occurrence.Date
+			}
+		}
+		t.Fatalf("no Eid al-Fitr in the %s calendar", system)
+		return time.Time{}
+	}
+	tabular, ummAlQura := first(domain.HijriTabular), first(domain.HijriUmmAlQura)
+	if !tabular.Equal(ummAlQura.AddDate(0, 0, 1)) {
+		t.Fatalf("expected tabular Eid a day after Umm al-Qura: %v, %v", tabular, ummAlQura)
+	}
+}
+
+func TestPersonalEventsFallOnTheLastDayOfShortMonths(t *testing.T) {
+	var events []domain.PersonalEvent
+	for month := 1; month <= 12; month++ {
+		events = append(events, domain.PersonalEvent{ID: int64(month), Title: "Vow", Hijri: true, Month: month, Day: 30})
+	}
+	events = append(events, domain.PersonalEvent{ID: 13, Title: "Leap day", Month: 2, Day: 29})
+	hijriCalendar := calendar(t, domain.HijriUmmAlQura, 0)
+	start := time.Date(2026, time.January, 1, 12, 0, 0, 0, time.UTC)
+	upcoming, err := Between(start, 354, hijriCalendar, "", Personal(events)...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	seen := map[string]int{}
+	short := 0
+	for _, occurrence := range upcoming {
+		if occurrence.Definition.Category != CategoryPersonal {
+			continue
+		}
+		seen[occurrence.Definition.ID]++
+		if occurrence.Definition.Gregorian {
+			if occurrence.Date.Month() != time.February || occurrence.Date.Day() != 28 {
+				t.Fatalf("29 February fell on %v in a common year", occurrence.Date)
+			}
+			continue
+		}
+		if occurrence.Hijri.Day == 29 {
+			short++
+			next, err := hijriCalendar.Date(occurrence.Date.AddDate(0, 0, 1))
+			if err != nil || next.Day != 1 {
+				t.Fatalf("the 30th fell on a 29th that does not end the month: %+v", occurrence)
+			}
+		}
+	}
+	if len(seen) != 13 {
+		t.Fatalf("every event must recur once in a Hijri year: %v", seen)
+	}
+	for id, count := range seen {
+		if count != 1 {
+			t.Fatalf("%s fell %d times in a Hijri year", id, count)
+		}
+	}
+	if short == 0 {
+		t.Fatal("no 29-day month was exercised")
+	}
+}
+
+func TestBetweenListsEachDayOfMultiDayObservances(t *testing.T) {
+	hijriCalendar := calendar(t, domain.HijriUmmAlQura, 0)
+	start := time.Date(2026, time.January, 1, 12, 0, 0, 0, time.UTC)
+	upcoming, err := Between(start, 354, hijriCalendar, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	days := map[string][]Occurrence{}
+	for _, occurrence := range upcoming {
+		days[occurrence.Definition.ID] = append(days[occurrence.Definition.ID], occurrence)
+	}
+	for _, tc := range []struct {
+		id        string
+		hijriDays []int
+		reminded  int
+		noFasting bool
+	}{
+		{id: "six_shawwal", hijriDays: []int{2, 3, 4, 5, 6, 7}, reminded: 6},
+		{id: "odd_nights", hijriDays: []int{21, 23, 25, 27, 29}, reminded: 5},
+		{id: "dhul_hijjah_start", hijriDays: []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, reminded: 1},
+		{id: "tashreeq", hijriDays: []int{11, 12, 13}, reminded: 3, noFasting: true},
+		{id: "tasua", hijriDays: []int{9}, reminded: 1},
+	} {
+		occurrences := days[tc.id]
+		if len(occurrences) != len(tc.hijriDays) {
+			t.Fatalf("%s fell on %d days, want %d", tc.id, len(occurrences), len(tc.hijriDays))
+		}
+		reminded := 0
+		for index, occurrence := range occurrences {
+			if occurrence.Hijri.Day != tc.hijriDays[index] || occurrence.Day != index+1 || occurrence.Days != len(tc.hijriDays) {
+				t.Fatalf("%s day %d: %+v", tc.id, index+1, occurrence)
+			}
+			if !occurrence.First().Equal(occurrences[0].Date) {
+				t.Fatalf("%s day %d starts on %v, want %v", tc.id, index+1, occurrence.First(), occurrences[0].Date)
+			}
+			if occurrence.Definition.NoFasting != tc.noFasting {
+				t.Fatalf("%s: fasting prohibited %t", tc.id, occurrence.Definition.NoFasting)
+			}
+			if occurrence.Reminded() {
+				reminded++
+			}
+		}
+		if reminded != tc.reminded {
+			t.Fatalf("%s has %d reminded days, want %d", tc.id, reminded, tc.reminded)
+		}
+	}
+}
+
+func TestOnEveListsEveryOccasionOfTheCategory(t *testing.T) {
+	hijriCalendar := calendar(t, domain.HijriUmmAlQura, 0)
+	start := time.Date(2026, time.January, 1, 12, 0, 0, 0, time.UTC)
+	upcoming, err := Between(start, 354, hijriCalendar, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var eid time.Time
+	for _, occurrence := range upcoming {
+		if occurrence.Definition.ID == "eid_adha" {
+			eid = occurrence.Date
+		}
+	}
+	onEid, err := OnEve(eid.AddDate(0, 0, -1), hijriCalendar, "", CategoryMajor)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(onEid) != 2 || onEid[0].Definition.ID != "dhul_hijjah_start" || onEid[0].Day != 10 || onEid[1].Definition.ID != "eid_adha" {
+		t.Fatalf("unexpected major occasions on Eid al-Adha: %+v", onEid)
+	}
+}
+
+func TestNightsAreDatedOnTheEveningTheyBegin(t *testing.T) {
+	hijriCalendar := calendar(t, domain.HijriUmmAlQura, 0)
+	start := time.Date(2027, time.January, 15, 0, 0, 0, 0, time.UTC)
+	upcoming, err := Between(start, 90, hijriCalendar, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var night27, lastTen Occurrence
+	for _, occurrence := range upcoming {
+		switch {
+		case occurrence.Definition.ID == "odd_nights" && occurrence.Hijri.Day == 27:
+			night27 = occurrence
+		case occurrence.Definition.ID == "last_ten_nights" && occurrence.Day == 1:
+			lastTen = occurrence
+		}
+	}
+	ramadan27, err := hijriCalendar.Date(night27.Date.AddDate(0, 0, 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if night27.Definition.ID == "" || ramadan27.Month != hijri.Ramadan || ramadan27.Day != 27 || night27.Day != 4 {
+		t.Fatalf("night 27 must be dated on the evening before 27 Ramadan, got %s (%+v)", night27.Date.Format(time.DateOnly), night27.Hijri)
+	}
+	if !night27.Eve().Equal(night27.Date) {
+		t.Fatalf("a night is reminded of on the evening it begins, got %s", night27.Eve())
+	}
+	if ramadan21, err := hijriCalendar.Date(lastTen.Date.AddDate(0, 0, 1)); err != nil || ramadan21.Day != 21 || lastTen.Hijri.Day != 21 {
+		t.Fatalf("the last ten nights must begin on the evening before 21 Ramadan, got %s (%v)", lastTen.Date.Format(time.DateOnly), err)
+	}
+
+	onEve, err := OnEve(night27.Date, hijriCalendar, "", CategoryMajor)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var reminded []string
+	for _, occurrence := range onEve {
+		if occurrence.Reminded() {
+			reminded = append(reminded, fmt.Sprintf("%s %d", occurrence.Definition.ID, occurrence.Hijri.Day))
+		}
+	}
+	if !slices.Equal(reminded, []string{"odd_nights 27"}) {
+		t.Fatalf("the evening of night 27 must remind of it alone: %v", reminded)
+	}
+}
@@ -0,0 +1,190 @@
+package prayertime
+
+import (
+	"math"
+	"time"
+
+	prayer "github.com/hablullah/go-prayer"
+)
+
+// calculateAstronomical is a second engine with the contract of
+// prayer.Calculate but none of its astronomy. Solar coordinates come from the
+// Astronomical Almanac's low-precision formulas, good to about a minute of
+// time between 1950 and 2050. Each event is found from its hour angle around
+// the transit and refined three times with the sun's position at the previous
+// estimate. Twilight conventions, high-latitude adapters, corrections, and
+// rounding are applied as the library does, so the two engines differ only
+// where their astronomy does.
+func calculateAstronomical(cfg prayer.Config, year int) ([]prayer.Schedule, error) {
+	if cfg.Timezone == nil {
+		cfg.Timezone = time.UTC
+	}
+	if cfg.TwilightConvention == nil {
+		cfg.TwilightConvention = prayer.AstronomicalTwilight()
+	}
+	start := time.Date(year, time.January, 1, 0, 0, 0, 0, cfg.Timezone)
+	limit := start.AddDate(1, 0, 0)
+	var schedules []prayer.Schedule
+	abnormal := false
+	for date := start; date.Before(limit); date = date.AddDate(0, 0, 1) {
+		schedule := solarDay(cfg, date)
+		abnormal = abnormal || !schedule.IsNormal
+		schedules = append(schedules, schedule)
+	}
+	if abnormal && cfg.HighLatitudeAdapter != nil {
+		schedules = cfg.HighLatitudeAdapter(cfg, year, schedules)
+	}
+
+	interval := cfg.TwilightConvention.MaghribDuration
+	for i, schedule := range schedules {
+		if interval > 0 && !schedule.Maghrib.IsZero() {
+			schedule.Isha = schedule.Maghrib.Add(interval)
+		}
+		schedule.Fajr = correct(schedule.Fajr, cfg.Corrections.Fajr, cfg.PreciseToSeconds)
+		schedule.Sunrise = correct(schedule.Sunrise, cfg.Corrections.Sunrise, cfg.PreciseToSeconds)
+		schedule.Zuhr = correct(schedule.Zuhr, cfg.Corrections.Zuhr, cfg.PreciseToSeconds)
+		schedule.Asr = correct(schedule.Asr, cfg.Corrections.Asr, cfg.PreciseToSeconds)
+		schedule.Maghrib = correct(schedule.Maghrib, cfg.Corrections.Maghrib, cfg.PreciseToSeconds)
+		schedule.Isha = correct(schedule.Isha, cfg.Corrections.Isha, cfg.PreciseToSeconds)
+		schedules[i] = schedule
+	}
+	return schedules, nil
+}
+
+// solarDay calculates one local day before any adapter or correction. Like the
+// library, a day is normal when the sun both rises and sets and the sky gets
+// fully dark, i.e. reaches 18° below the horizon.
+func solarDay(cfg prayer.Config, date time.Time) prayer.Schedule {
+	sun := observer{latitude: cfg.Latitude, longitude: cfg.Longitude, date: date}
+	transit := sun.transit()
+	horizon := -(sunriseAltitude + horizonDip(cfg.Elevation))
+	fixed := func(altitude float64) func(float64) float64 {
+		return func(float64) float64 { return altitude }
+	}
+	shadow := 1.0
+	if cfg.AsrConvention == prayer.Hanafi {
+		shadow = 2
+	}
+	asr := func(declination float64) float64 {
+		return degrees(math.Atan(1 / (shadow + math.Tan(radians(math.Abs(cfg.Latitude-declination))))))
+	}
+
+	local := func(t time.Time) time.Time {
+		if t.IsZero() {
+			return t
+		}
+		return t.In(cfg.Timezone)
+	}
+	schedule := prayer.Schedule{
+		Date:    date.Format("2006-01-02"),
+		Fajr:    local(sun.crossing(transit, fixed(-cfg.TwilightConvention.FajrAngle), true)),
+		Sunrise: local(sun.crossing(transit, fixed(horizon), true)),
+		Zuhr:    local(transit),
+		Asr:     local(sun.crossing(transit, asr, false)),
+		Maghrib: local(sun.crossing(transit, fixed(horizon), false)),
+		Isha:    local(sun.crossing(transit, fixed(-cfg.TwilightConvention.IshaAngle), false)),
+	}
+	dawn := sun.crossing(transit, fixed(-18), true)
+	dusk := sun.crossing(transit, fixed(-18), false)
+	schedule.IsNormal = !schedule.Sunrise.IsZero() && !schedule.Maghrib.IsZero() && !dawn.IsZero() && !dusk.IsZero()
+	return schedule
+}
+
+// sunriseAltitude is the sun's centre at sunrise and sunset: 34′ of
+// refraction plus a 16′ semi-diameter below the horizon.
+const sunriseAltitude = 0.8333
+
+// horizonDip is how far the visible horizon lies below the astronomical one
+// for an observer elevation metres up, in degrees.
+func horizonDip(elevation float64) float64 {
+	if elevation <= 0 {
+		return 0
+	}
+	return 2.076 * math.Sqrt(elevation) / 60
+}
+
+// observer finds solar events for one civil date at one place.
+type observer struct {
+	latitude, longitude float64
+	date                time.Time
+}
+
+// meanNoon is when the mean sun crosses the observer's meridian on the date.
+func (o observer) meanNoon() time.Time {
+	noon := time.Date(o.date.Year(), o.date.Month(), o.date.Day(), 12, 0, 0, 0, time.UTC)
+	return noon.Add(-hours(o.longitude / 15))
+}
+
+// transit is the true solar noon: mean noon less the equation of time.
+func (o observer) transit() time.Time {
+	transit := o.meanNoon()
+	for range 3 {
+		_, equation := sunPosition(transit)
+		transit = o.meanNoon().Add(-hours(equation))
+	}
+	return transit
+}
+
+// crossing returns when the sun passes the altitude, in degrees, before or
+// after the transit. The altitude may depend on the sun's declination, as
+// Asr's does. A zero time means the sun never reaches it that day.
+func (o observer) crossing(transit time.Time, altitude func(declination float64) float64, morning bool) time.Time {
+	event := transit
+	for range 3 {
+		declination, equation := sunPosition(event)
+		lat, dec := radians(o.latitude), radians(declination)
+		cosH := (math.Sin(radians(altitude(declination))) - math.Sin(lat)*math.Sin(dec)) / (math.Cos(lat) * math.Cos(dec))
+		if cosH < -1 || cosH > 1 || math.IsNaN(cosH) {
+			return time.Time{}
+		}
+		angle := hours(degrees(math.Acos(cosH)) / 15)
+		noon := o.meanNoon().Add(-hours(equation))
+		if morning {
+			event = noon.Add(-angle)
+		} else {
+			event = noon.Add(angle)
+		}
+	}
+	return event
+}
+
+// sunPosition returns the sun's declination in degrees and the equation of
+// time in hours at an instant.
+func sunPosition(t time.Time) (declination, equation float64) {
+	days := julianDay(t) - 2451545.0
+	anomaly := radians(normalize(357.529+0.98560028*days, 360))
+	mean := normalize(280.459+0.98564736*days, 360)
+	longitude := radians(normalize(mean+1.915*math.Sin(anomaly)+0.020*math.Sin(2*anomaly), 360))
+	obliquity := radians(23.439 - 0.00000036*days)
+
+	declination = degrees(math.Asin(math.Sin(obliquity) * math.Sin(longitude)))
+	ascension := normalize(degrees(math.Atan2(math.Cos(obliquity)*math.Sin(longitude), math.Cos(longitude)))/15, 24)
+	equation = mean/15 - ascension
+	switch {
+	case equation > 12:
+		equation -= 24
+	case equation < -12:
+		equation += 24
+	}
+	return declination, equation
+}
+
+func julianDay(t time.Time) float64 {
+	return float64(t.UnixNano())/float64(24*time.Hour) + 2440587.5
+}
+
+func normalize(value, period float64) float64 {
+	value = math.Mod(value, period)
+	if value < 0 {
+		value += period
+	}
+	return value
+}
+
+func hours(value float64) time.Duration {
+	return time.Duration(value * float64(time.Hour))
+}
+
+func radians(value float64) float64 { return value * math.Pi / 180 }
+
+func degrees(value float64) float64 { return value * 180 / math.Pi }
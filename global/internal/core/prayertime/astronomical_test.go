@@ -0,0 +1,81 @@
+package prayertime
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/escalopa/prayer-bot/global/internal/domain"
+)
+
+func TestAstronomicalEngineAgreesWithTheLibrary(t *testing.T) {
+	profiles := map[string]domain.PrayerProfile{
+		"Makkah": {
+			Latitude: 21.422, Longitude: 39.826, Timezone: "Asia/Riyadh",
+			Method: domain.MethodUmmAlQura, Madhab: domain.MadhabShafii,
+			HighLatitudeRule: domain.HighLatitudeAngleBased,
+		},
+		"London": {
+			Latitude: 51.507, Longitude: -0.128, Timezone: "Europe/London",
+			Method: domain.MethodMWL, Madhab: domain.MadhabHanafi,
+			HighLatitudeRule: domain.HighLatitudeAngleBased,
+		},
+		"Sydney": {
+			Latitude: -33.869, Longitude: 151.209, Timezone: "Australia/Sydney",
+			Method: domain.MethodISNA, Madhab: domain.MadhabShafii,
+			HighLatitudeRule: domain.HighLatitudeAngleBased,
+		},
+		"Mexico City": {
+			Latitude: 19.433, Longitude: -99.133, Timezone: "America/Mexico_City",
+			Method: domain.MethodISNA, Madhab: domain.MadhabShafii,
+			HighLatitudeRule: domain.HighLatitudeAngleBased, ElevationMeters: 2240,
+		},
+	}
+	library, astronomical := New(), NewAstronomical()
+	for name, profile := range profiles {
+		for day := 0; day < 365; day += 7 {
+			date := time.Date(2026, time.January, 1, 12, 0, 0, 0, time.UTC).AddDate(0, 0, day)
+			want, err := library.Day(context.Background(), date, profile)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := astronomical.Day(context.Background(), date, profile)
+			if err != nil {
+				t.Fatal(err)
+			}
+			// Each engine rounds to the minute on its own, so a minute apart
+			// is agreement.
+			for _, divergence := range Compare(want, got, time.Minute) {
+				t.Errorf("%s %s %s: library %v, astronomical %v", name, date.Format(time.DateOnly),
+					divergence.Prayer, divergence.Primary, divergence.Secondary)
+			}
+		}
+	}
+}
+
+func TestAstronomicalEngineAppliesTheHighLatitudeRule(t *testing.T) {
+	// Oslo has no astronomical night in June, so Isha at 18° never comes and
+	// the angle-based rule has to supply it, as it does in the library.
+	profile := domain.PrayerProfile{
+		Latitude: 59.913, Longitude: 10.752, Timezone: "Europe/Oslo",
+		Method: domain.MethodMWL, Madhab: domain.MadhabShafii,
+		HighLatitudeRule: domain.HighLatitudeAngleBased,
+	}
+	date := time.Date(2026, time.June, 21, 12, 0, 0, 0, time.UTC)
+	want, err := New().Day(context.Background(), date, profile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := NewAstronomical().Day(context.Background(), date, profile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, prayer := range []domain.Prayer{domain.PrayerFajr, domain.PrayerIsha} {
+		if got.Times[prayer].IsZero() {
+			t.Fatalf("%s was not supplied by the high-latitude rule", prayer)
+		}
+	}
+	for _, divergence := range Compare(want, got, 3*time.Minute) {
+		t.Errorf("%s: library %v, astronomical %v", divergence.Prayer, divergence.Primary, divergence.Secondary)
+	}
+}
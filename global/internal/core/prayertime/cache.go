@@ -0,0 +1,171 @@
+package prayertime
+
+import (
+	"container/list"
+	"maps"
+	"sync"
+	"sync/atomic"
+	"time"
+	"unsafe"
+
+	prayer "github.com/hablullah/go-prayer"
+	"golang.org/x/sync/singleflight"
+
+	"github.com/escalopa/prayer-bot/global/internal/domain"
+	"github.com/escalopa/prayer-bot/global/internal/port"
+)
+
+const (
+	// maxCachedYears and maxCacheBytes bound the year cache. A calculated year
+	// is about 60 KiB and every memoized day adds about 1.5 KiB, so the byte
+	// budget is what normally binds; the entry limit stops a flood of profiles
+	// that never ask for a second day.
+	maxCachedYears = 1024
+	maxCacheBytes  = 64 << 20
+
+	// memoizedDayBytes estimates one memoized day: its two small maps of
+	// times and makruh windows.
+	memoizedDayBytes = 1536
+)
+
+// yearCache is a least-recently-used cache of calculated years keyed by the
+// profile fields that affect the calculation. Concurrent misses for the same
+// key share one calculation.
+type yearCache struct {
+	maxEntries int
+	maxBytes   int64
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	order   *list.List // front is the most recently used
+	bytes   int64
+
+	flight                  singleflight.Group
+	hits, misses, evictions atomic.Int64
+}
+
+// cachedYear is one calculated local year and the days already derived from
+// it, indexed like schedules.
+type cachedYear struct {
+	key       string
+	schedules []prayer.Schedule
+	days      []*memoizedDay
+	bytes     int64
+}
+
+// memoizedDay is what Day derives from a schedule. It is shared between
+// callers, so Day hands out copies of its maps. The extended times depend on
+// the profile's Imsak offset, which is not part of the cache key, so only the
+// next Fajr they need is kept.
+type memoizedDay struct {
+	times    map[domain.Prayer]time.Time
+	makruh   map[domain.MakruhWindow]domain.TimeWindow
+	nextFajr time.Time
+}
+
+func newYearCache(maxEntries int, maxBytes int64) *yearCache {
+	return &yearCache{maxEntries: maxEntries, maxBytes: maxBytes, entries: make(map[string]*list.Element), order: list.New()}
+}
+
+// year returns the cached year for key, calculating it on a miss.
+func (c *yearCache) year(key string, calculate func() ([]prayer.Schedule, error)) (*cachedYear, error) {
+	c.mu.Lock()
+	if element, ok := c.entries[key]; ok {
+		c.order.MoveToFront(element)
+		c.mu.Unlock()
+		c.hits.Add(1)
+		return element.Value.(*cachedYear), nil
+	}
+	c.mu.Unlock()
+	// Only the caller whose function runs has missed; the others waited for
+	// its result.
+	calculated := false
+	value, err, _ := c.flight.Do(key, func() (any, error) {
+		calculated = true
+		schedules, err := calculate()
+		if err != nil {
+			return nil, err
+		}
+		year := &cachedYear{
+			key:       key,
+			schedules: schedules,
+			days:      make([]*memoizedDay, len(schedules)),
+			bytes:     yearBytes(schedules),
+		}
+		c.mu.Lock()
+		c.entries[key] = c.order.PushFront(year)
+		c.bytes += year.bytes
+		c.evict()
+		c.mu.Unlock()
+		return year, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if calculated {
+		c.misses.Add(1)
+	} else {
+		c.hits.Add(1)
+	}
+	return value.(*cachedYear), nil
+}
+
+// day returns the memoized day at index, deriving it on first use.
+func (c *yearCache) day(year *cachedYear, index int, derive func() *memoizedDay) memoizedDay {
+	c.mu.Lock()
+	day := year.days[index]
+	c.mu.Unlock()
+	if day == nil {
+		day = derive()
+		c.mu.Lock()
+		if year.days[index] == nil {
+			year.days[index] = day
+			year.bytes += memoizedDayBytes
+			// A year evicted meanwhile no longer counts towards the budget.
+			if _, ok := c.entries[year.key]; ok {
+				c.bytes += memoizedDayBytes
+				c.evict()
+			}
+		}
+		c.mu.Unlock()
+	}
+	return memoizedDay{times: maps.Clone(day.times), makruh: maps.Clone(day.makruh), nextFajr: day.nextFajr}
+}
+
+// evict drops the least recently used years until the cache is within both
+// bounds, always keeping the most recent one. The caller holds mu.
+func (c *yearCache) evict() {
+	for c.order.Len() > 1 && (c.order.Len() > c.maxEntries || c.bytes > c.maxBytes) {
+		year := c.order.Remove(c.order.Back()).(*cachedYear)
+		delete(c.entries, year.key)
+		c.bytes -= year.bytes
+		c.evictions.Add(1)
+	}
+}
+
+func (c *yearCache) stats() domain.CacheStats {
+	c.mu.Lock()
+	entries, bytes := c.order.Len(), c.bytes
+	c.mu.Unlock()
+	return domain.CacheStats{
+		Hits: c.hits.Load(), Misses: c.misses.Load(), Evictions: c.evictions.Load(),
+		Entries: entries, Bytes: bytes,
+	}
+}
+
+// cacheStats reports the counters of a wrapped calculator's cache, or zeros
+// when it has none.
+func cacheStats(calculator port.Calculator) domain.CacheStats {
+	if reporter, ok := calculator.(port.CacheReporter); ok {
+		return reporter.CacheStats()
+	}
+	return domain.CacheStats{}
+}
+
+func yearBytes(schedules []prayer.Schedule) int64 {
+	size := int64(len(schedules)) * int64(unsafe.Sizeof(prayer.Schedule{}))
+	for _, schedule := range schedules {
+		size += int64(len(schedule.Date))
+	}
+	return size
+}
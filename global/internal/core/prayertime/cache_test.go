@@ -0,0 +1,153 @@
+package prayertime
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	prayer "github.com/hablullah/go-prayer"
+
+	"github.com/escalopa/prayer-bot/global/internal/domain"
+)
+
+func yearOf(days int) []prayer.Schedule {
+	return make([]prayer.Schedule, days)
+}
+
+func TestYearCacheEvictsTheLeastRecentlyUsedYear(t *testing.T) {
+	cache := newYearCache(2, 1<<30)
+	calculations := 0
+	calculate := func() ([]prayer.Schedule, error) { calculations++; return yearOf(365), nil }
+	for _, key := range []string{"a", "b", "a", "c", "a", "b"} {
+		if _, err := cache.year(key, calculate); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// "b" was the least recently used when "c" arrived and "c" when "b"
+	// returned, so only "a" survived both evictions.
+	stats := cache.stats()
+	if calculations != 4 || stats.Misses != 4 || stats.Hits != 2 || stats.Evictions != 2 || stats.Entries != 2 {
+		t.Fatalf("calculations = %d, stats = %+v", calculations, stats)
+	}
+}
+
+func TestYearCacheStaysWithinItsMemoryBudget(t *testing.T) {
+	budget := 3 * (yearBytes(yearOf(365)) + memoizedDayBytes)
+	cache := newYearCache(100, budget)
+	for key := range 10 {
+		year, err := cache.year(fmt.Sprint(key), func() ([]prayer.Schedule, error) { return yearOf(365), nil })
+		if err != nil {
+			t.Fatal(err)
+		}
+		cache.day(year, 0, func() *memoizedDay { return &memoizedDay{} })
+		if stats := cache.stats(); stats.Bytes > budget {
+			t.Fatalf("cache holds %d bytes, budget %d", stats.Bytes, budget)
+		}
+	}
+	if stats := cache.stats(); stats.Entries != 3 || stats.Evictions != 7 {
+		t.Fatalf("unexpected stats: %+v", stats)
+	}
+}
+
+func TestYearCacheCollapsesConcurrentMisses(t *testing.T) {
+	cache := newYearCache(10, 1<<30)
+	var calculations atomic.Int32
+	release := make(chan struct{})
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Go(func() {
+			_, err := cache.year("same", func() ([]prayer.Schedule, error) {
+				calculations.Add(1)
+				<-release
+				return yearOf(365), nil
+			})
+			if err != nil {
+				t.Error(err)
+			}
+		})
+	}
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	if got := calculations.Load(); got != 1 {
+		t.Fatalf("calculated %d times, want 1", got)
+	}
+	if stats := cache.stats(); stats.Hits+stats.Misses != 8 || stats.Misses != 1 {
+		t.Fatalf("unexpected stats: %+v", stats)
+	}
+}
+
+func TestMemoizedDaysAreNotSharedWithCallers(t *testing.T) {
+	calculator := New()
+	profile := domain.PrayerProfile{
+		Latitude: 41.008, Longitude: 28.978, Timezone: "Europe/Istanbul",
+		Method: domain.MethodDiyanet, Madhab: domain.MadhabHanafi, HighLatitudeRule: domain.HighLatitudeAngleBased,
+	}
+	date := time.Date(2026, time.August, 1, 9, 0, 0, 0, time.UTC)
+	first, err := calculator.Day(context.Background(), date, profile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fajr := first.Times[domain.PrayerFajr]
+	delete(first.Times, domain.PrayerFajr)
+	second, err := calculator.Day(context.Background(), date.Add(2*time.Hour), profile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !second.Times[domain.PrayerFajr].Equal(fajr) {
+		t.Fatal("a caller's change leaked into the memoized day")
+	}
+	if !second.Date.Equal(date.Add(2 * time.Hour)) {
+		t.Fatalf("Date = %s, want the requested instant", second.Date)
+	}
+	if stats := calculator.CacheStats(); stats.Misses != 1 || stats.Hits != 1 {
+		t.Fatalf("unexpected stats: %+v", stats)
+	}
+}
+
+func TestMemoizedDaysHonourEachProfilesImsakOffset(t *testing.T) {
+	calculator := New()
+	profile := domain.PrayerProfile{
+		Latitude: 30.044, Longitude: 31.236, Timezone: "Africa/Cairo",
+		Method: domain.MethodEgyptian, Madhab: domain.MadhabShafii, HighLatitudeRule: domain.HighLatitudeAngleBased,
+	}
+	date := time.Date(2026, time.August, 1, 9, 0, 0, 0, time.UTC)
+	for _, minutes := range []int{10, 15} {
+		profile.ImsakMinutes = minutes
+		schedule, err := calculator.Day(context.Background(), date, profile)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if gap := schedule.Times[domain.PrayerFajr].Sub(schedule.Times[domain.PrayerImsak]); gap != time.Duration(minutes)*time.Minute {
+			t.Fatalf("Imsak is %s before Fajr, want %d minutes", gap, minutes)
+		}
+	}
+}
+
+// BenchmarkDayForManyProfiles models a sender or calendar feed serving more
+// distinct profiles than the old fixed-size map held, once their years are
+// cached.
+func BenchmarkDayForManyProfiles(b *testing.B) {
+	calculator := New()
+	profiles := make([]domain.PrayerProfile, 300)
+	for i := range profiles {
+		profiles[i] = domain.PrayerProfile{
+			Latitude: 30 + float64(i)/100, Longitude: 31, Timezone: "Africa/Cairo",
+			Method: domain.MethodEgyptian, Madhab: domain.MadhabShafii, HighLatitudeRule: domain.HighLatitudeAngleBased,
+		}
+	}
+	date := time.Date(2026, time.July, 16, 12, 0, 0, 0, time.UTC)
+	for _, profile := range profiles {
+		if _, err := calculator.Day(context.Background(), date, profile); err != nil {
+			b.Fatal(err)
+		}
+	}
+	for i := 0; b.Loop(); i++ {
+		if _, err := calculator.Day(context.Background(), date, profiles[i%len(profiles)]); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -3,83 +3,198 @@ package prayertime
 import (
 	"context"
 	"fmt"
-	"sync"
+	"slices"
 	"time"
 	_ "time/tzdata"
 
 	prayer "github.com/hablullah/go-prayer"
 
+	"github.com/escalopa/prayer-bot/global/internal/core/hijri"
 	"github.com/escalopa/prayer-bot/global/internal/domain"
 	"github.com/escalopa/prayer-bot/global/internal/port"
 )
 
+// dailyPrayers are the six times an engine calculates, or a timetable
+// publishes, in the order they fall.
+var dailyPrayers = []domain.Prayer{
+	domain.PrayerFajr, domain.PrayerSunrise, domain.PrayerDhuhr,
+	domain.PrayerAsr, domain.PrayerMaghrib, domain.PrayerIsha,
+}
+
+// engine calculates a whole local year with the contract of prayer.Calculate:
+// corrections applied, and times rounded to the minute unless the config asks
+// for seconds.
+type engine func(prayer.Config, int) ([]prayer.Schedule, error)
+
 type LocalCalculator struct {
-	mu    sync.Mutex
-	cache map[string][]prayer.Schedule
+	engine engine
+	cache  *yearCache
 }
 
+// New returns the production calculator, backed by hablullah/go-prayer.
 func New() *LocalCalculator {
-	return &LocalCalculator{cache: make(map[string][]prayer.Schedule)}
+	return newCalculator(prayer.Calculate)
+}
+
+// NewAstronomical returns a calculator backed by this package's own solar
+// model. It accepts the same profiles as New and exists to be compared with it.
+func NewAstronomical() *LocalCalculator {
+	return newCalculator(calculateAstronomical)
+}
+
+func newCalculator(engine engine) *LocalCalculator {
+	return &LocalCalculator{engine: engine, cache: newYearCache(maxCachedYears, maxCacheBytes)}
 }
 
 func (c *LocalCalculator) Day(_ context.Context, date time.Time, profile domain.PrayerProfile) (domain.DaySchedule, error) {
 	if err := profile.Validate(); err != nil {
 		return domain.DaySchedule{}, fmt.Errorf("validate profile: %w", err)
 	}
-	location, err := time.LoadLocation(profile.Timezone)
+	location, err := domain.LoadLocation(profile.Timezone)
 	if err != nil {
 		return domain.DaySchedule{}, fmt.Errorf("load timezone: %w", err)
 	}
 	localDate := date.In(location)
-	year := localDate.Year()
-	key := cacheKey(profile, year)
-
-	c.mu.Lock()
-	schedules, ok := c.cache[key]
-	c.mu.Unlock()
-	if !ok {
-		schedules, err = prayer.Calculate(toLibraryConfig(profile, location), year)
+	year, err := c.year(profile, location, localDate.Year())
+	if err != nil {
+		return domain.DaySchedule{}, err
+	}
+	wanted := localDate.Format("2006-01-02")
+	// Engines return the year from 1 January, so the day of the year is
+	// normally the index.
+	index := localDate.YearDay() - 1
+	if index >= len(year.schedules) || year.schedules[index].Date != wanted {
+		index = slices.IndexFunc(year.schedules, func(schedule prayer.Schedule) bool { return schedule.Date == wanted })
+	}
+	if index < 0 {
+		return domain.DaySchedule{}, fmt.Errorf("no prayer schedule for %s", wanted)
+	}
+	day := c.cache.day(year, index, func() *memoizedDay {
+		schedule := year.schedules[index]
+		// The night belongs to the day it starts on, so it ends at the next
+		// day's Fajr, which for 31 December is in the following year.
+		var next prayer.Schedule
+		if index+1 < len(year.schedules) {
+			next = year.schedules[index+1]
+		} else if following, err := c.year(profile, location, localDate.Year()+1); err == nil && len(following.schedules) > 0 {
+			next = following.schedules[0]
+		}
+		times := map[domain.Prayer]time.Time{
+			domain.PrayerFajr:    schedule.Fajr,
+			domain.PrayerSunrise: schedule.Sunrise,
+			domain.PrayerDhuhr:   schedule.Zuhr,
+			domain.PrayerAsr:     schedule.Asr,
+			domain.PrayerMaghrib: schedule.Maghrib,
+			domain.PrayerIsha:    schedule.Isha,
+		}
+		return &memoizedDay{times: times, makruh: makruhWindows(times), nextFajr: next.Fajr}
+	})
+	addExtendedTimes(day.times, day.nextFajr, profile.ImsakMinutes)
+	return domain.DaySchedule{Date: localDate, Timezone: profile.Timezone, Times: day.times, Makruh: day.makruh}, nil
+}
+
+// CacheStats reports the year cache's counters.
+func (c *LocalCalculator) CacheStats() domain.CacheStats {
+	return c.cache.stats()
+}
+
+// year returns a whole local year from the cache, calculating it on a miss.
+func (c *LocalCalculator) year(profile domain.PrayerProfile, location *time.Location, year int) (*cachedYear, error) {
+	cached, err := c.cache.year(cacheKey(profile, year), func() ([]prayer.Schedule, error) {
+		return c.calculate(profile, location, year)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("calculate prayer times: %w", err)
+	}
+	return cached, nil
+}
+
+// calculate runs the engine for a whole year. Methods whose rules the engine
+// cannot express get their own post-processing pass. The engine works to the
+// second, so the profile's precaution is the only place times are rounded.
+func (c *LocalCalculator) calculate(profile domain.PrayerProfile, location *time.Location, year int) ([]prayer.Schedule, error) {
+	cfg := toLibraryConfig(profile, location, c.engine)
+	var schedules []prayer.Schedule
+	var err error
+	if profile.Method == domain.MethodMoonsighting {
+		schedules, err = calculateMoonsighting(c.engine, cfg, year)
+	} else {
+		schedules, err = c.engine(cfg, year)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if rule, ok := hijriRules[profile.Method]; ok {
+		calendar, err := hijri.ForProfile(profile)
 		if err != nil {
-			return domain.DaySchedule{}, fmt.Errorf("calculate prayer times: %w", err)
+			return nil, err
 		}
-		c.mu.Lock()
-		if len(c.cache) >= 256 {
-			clear(c.cache)
+		if err := applyHijriRule(schedules, rule, calendar, location); err != nil {
+			return nil, err
 		}
-		c.cache[key] = schedules
-		c.mu.Unlock()
 	}
+	applyPrecaution(schedules, profile.Precaution)
+	return schedules, nil
+}
 
-	wanted := localDate.Format("2006-01-02")
-	for _, schedule := range schedules {
-		if schedule.Date != wanted {
-			continue
+// hijriRule changes one day of a method whose times depend on the Hijri month
+// that day falls in.
+type hijriRule func(schedule *prayer.Schedule, date hijri.Date)
+
+// hijriRules holds the methods whose convention varies through the Hijri
+// year. Their cache key includes the profile's Hijri calendar and correction,
+// because they decide which days the rule covers.
+var hijriRules = map[domain.Method]hijriRule{
+	// Umm al-Qura delays Isha to 120 minutes after Maghrib in Ramadan, 30
+	// minutes past its usual 90.
+	domain.MethodUmmAlQura: func(schedule *prayer.Schedule, date hijri.Date) {
+		if date.Month == hijri.Ramadan && !schedule.Isha.IsZero() {
+			schedule.Isha = schedule.Isha.Add(30 * time.Minute)
+		}
+	},
+}
+
+// applyHijriRule dates every day of a calculated year with the same Hijri
+// calendar the schedule, planner, and calendar feed show.
+func applyHijriRule(schedules []prayer.Schedule, rule hijriRule, calendar hijri.Calendar, location *time.Location) error {
+	for i := range schedules {
+		day, err := time.ParseInLocation(time.DateOnly, schedules[i].Date, location)
+		if err != nil {
+			return fmt.Errorf("parse schedule date: %w", err)
 		}
-		return domain.DaySchedule{
-			Date:     localDate,
-			Timezone: profile.Timezone,
-			Times: map[domain.Prayer]time.Time{
-				domain.PrayerFajr:    schedule.Fajr,
-				domain.PrayerSunrise: schedule.Sunrise,
-				domain.PrayerDhuhr:   schedule.Zuhr,
-				domain.PrayerAsr:     schedule.Asr,
-				domain.PrayerMaghrib: schedule.Maghrib,
-				domain.PrayerIsha:    schedule.Isha,
-			},
-		}, nil
-	}
-	return domain.DaySchedule{}, fmt.Errorf("no prayer schedule for %s", wanted)
+		date, err := calendar.Date(day)
+		if err != nil {
+			return err
+		}
+		rule(&schedules[i], date)
+	}
+	return nil
 }
 
-func toLibraryConfig(profile domain.PrayerProfile, location *time.Location) prayer.Config {
+// applyPrecaution adds the ihtiyat and rounds every time to the minute.
+func applyPrecaution(schedules []prayer.Schedule, precaution domain.Precaution) {
+	for i, schedule := range schedules {
+		schedule.Fajr = precaution.Apply(domain.PrayerFajr, schedule.Fajr)
+		schedule.Sunrise = precaution.Apply(domain.PrayerSunrise, schedule.Sunrise)
+		schedule.Zuhr = precaution.Apply(domain.PrayerDhuhr, schedule.Zuhr)
+		schedule.Asr = precaution.Apply(domain.PrayerAsr, schedule.Asr)
+		schedule.Maghrib = precaution.Apply(domain.PrayerMaghrib, schedule.Maghrib)
+		schedule.Isha = precaution.Apply(domain.PrayerIsha, schedule.Isha)
+		schedules[i] = schedule
+	}
+}
+
+func toLibraryConfig(profile domain.PrayerProfile, location *time.Location, calculate engine) prayer.Config {
 	minute := time.Minute
 	cfg := prayer.Config{
 		Latitude:            profile.Latitude,
 		Longitude:           profile.Longitude,
+		Elevation:           float64(profile.ElevationMeters),
 		Timezone:            location,
-		TwilightConvention:  convention(profile.Method),
+		TwilightConvention:  convention(profile),
 		AsrConvention:       prayer.Shafii,
-		HighLatitudeAdapter: highLatitudeAdapter(profile.HighLatitudeRule),
+		HighLatitudeAdapter: highLatitudeAdapter(profile.HighLatitudeRule, calculate),
+		PreciseToSeconds:    true,
 		Corrections: prayer.ScheduleCorrections{
 			Fajr:    time.Duration(profile.Adjustments.Fajr) * minute,
 			Sunrise: time.Duration(profile.Adjustments.Sunrise) * minute,
@@ -95,8 +210,14 @@ func toLibraryConfig(profile domain.PrayerProfile, location *time.Location) pray
 	return cfg
 }
 
-func convention(method domain.Method) *prayer.TwilightConvention {
-	switch method {
+func convention(profile domain.PrayerProfile) *prayer.TwilightConvention {
+	switch profile.Method {
+	case domain.MethodCustom:
+		return &prayer.TwilightConvention{
+			FajrAngle:       profile.Custom.FajrAngle,
+			IshaAngle:       profile.Custom.IshaAngle,
+			MaghribDuration: time.Duration(profile.Custom.IshaIntervalMinutes) * time.Minute,
+		}
 	case domain.MethodEgyptian:
 		return prayer.Egypt()
 	case domain.MethodUmmAlQura:
@@ -113,26 +234,39 @@ func convention(method domain.Method) *prayer.TwilightConvention {
 		return prayer.MUIS()
 	case domain.MethodJAKIM:
 		return prayer.JAKIM()
+	case domain.MethodTehran:
+		return prayer.Tehran()
+	case domain.MethodGulf:
+		return prayer.Gulf()
+	case domain.MethodKuwait:
+		return &prayer.TwilightConvention{FajrAngle: 18, IshaAngle: 17.5}
+	case domain.MethodQatar:
+		return &prayer.TwilightConvention{FajrAngle: 18, IshaAngle: 18, MaghribDuration: 90 * time.Minute}
+	case domain.MethodUOIF:
+		return prayer.UOIF()
+	case domain.MethodRussia:
+		return &prayer.TwilightConvention{FajrAngle: 16, IshaAngle: 15}
+	case domain.MethodMoonsighting:
+		return &prayer.TwilightConvention{FajrAngle: 18, IshaAngle: 18}
 	default:
 		return prayer.MWL()
 	}
 }
 
-func highLatitudeAdapter(rule domain.HighLatitudeRule) prayer.HighLatitudeAdapter {
-	switch rule {
-	case domain.HighLatitudeMiddleNight:
-		return prayer.MiddleNight()
-	case domain.HighLatitudeSeventhNight:
-		return prayer.OneSeventhNight()
-	default:
-		return prayer.AngleBased()
-	}
-}
-
 func cacheKey(profile domain.PrayerProfile, year int) string {
-	return fmt.Sprintf("%.3f|%.3f|%s|%s|%s|%s|%+v|%d",
-		profile.Latitude, profile.Longitude, profile.Timezone, profile.Method,
-		profile.Madhab, profile.HighLatitudeRule, profile.Adjustments, year)
+	custom := ""
+	if profile.Method == domain.MethodCustom {
+		custom = fmt.Sprintf("%+v", profile.Custom)
+	}
+	if _, ok := hijriRules[profile.Method]; ok {
+		custom = fmt.Sprintf("%s%+d", profile.HijriCalendar.OrDefault(), profile.HijriAdjustment)
+		for _, announcement := range profile.HijriAnnouncements {
+			custom += fmt.Sprintf(",%d-%d@%s", announcement.Year, announcement.Month, announcement.Start.Format(time.DateOnly))
+		}
+	}
+	return fmt.Sprintf("%.3f|%.3f|%d|%s|%s|%s|%s|%s|%+v|%+v|%d",
+		profile.Latitude, profile.Longitude, profile.ElevationMeters, profile.Timezone, profile.Method, custom,
+		profile.Madhab, profile.HighLatitudeRule, profile.Adjustments, profile.Precaution, year)
 }
 
 var _ port.Calculator = (*LocalCalculator)(nil)
@@ -34,3 +34,237 @@ func TestRoundedCoordinates(t *testing.T) {
 		t.Fatalf("got %f, %f", lat, lon)
 	}
 }
+
+func TestCustomMethodMatchesEquivalentPresetAndHonoursIshaInterval(t *testing.T) {
+	calculator := New()
+	date := time.Date(2026, 7, 16, 12, 0, 0, 0, time.UTC)
+	preset := domain.PrayerProfile{
+		Latitude: 30.044, Longitude: 31.236, Timezone: "Africa/Cairo",
+		Method: domain.MethodEgyptian, Madhab: domain.MadhabShafii,
+		HighLatitudeRule: domain.HighLatitudeAngleBased,
+	}
+	custom := preset
+	custom.Method = domain.MethodCustom
+	custom.Custom = domain.CustomMethod{FajrAngle: 19.5, IshaAngle: 17.5}
+
+	want, err := calculator.Day(context.Background(), date, preset)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := calculator.Day(context.Background(), date, custom)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, prayer := range []domain.Prayer{domain.PrayerFajr, domain.PrayerIsha} {
+		if !got.Times[prayer].Equal(want.Times[prayer]) {
+			t.Fatalf("custom %s = %v, want Egyptian %v", prayer, got.Times[prayer], want.Times[prayer])
+		}
+	}
+
+	// The cache must not serve the angle-based schedule once the interval changes.
+	custom.Custom.IshaIntervalMinutes = 90
+	fixed, err := calculator.Day(context.Background(), date, custom)
+	if err != nil {
+		t.Fatal(err)
+	}
+	maghrib, _ := fixed.At(domain.PrayerMaghrib)
+	isha, _ := fixed.At(domain.PrayerIsha)
+	if isha.Sub(maghrib) != 90*time.Minute {
+		t.Fatalf("Isha should be 90 minutes after Maghrib, got %v", isha.Sub(maghrib))
+	}
+}
+
+func TestUmmAlQuraIshaIsTwoHoursAfterMaghribInRamadan(t *testing.T) {
+	calculator := New()
+	location, _ := time.LoadLocation("Asia/Riyadh")
+	profile := domain.PrayerProfile{
+		Latitude: 21.4225, Longitude: 39.8262, Timezone: "Asia/Riyadh",
+		Method: domain.MethodUmmAlQura, Madhab: domain.MadhabShafii,
+		HighLatitudeRule: domain.HighLatitudeAngleBased,
+	}
+	interval := func(profile domain.PrayerProfile, month time.Month, day int) time.Duration {
+		t.Helper()
+		schedule, err := calculator.Day(context.Background(), time.Date(2026, month, day, 12, 0, 0, 0, location), profile)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return schedule.Times[domain.PrayerIsha].Sub(schedule.Times[domain.PrayerMaghrib])
+	}
+	// Ramadan 1447 runs from 18 February to 19 March 2026.
+	for _, check := range []struct {
+		adjustment int
+		month      time.Month
+		day        int
+		want       time.Duration
+	}{
+		{0, time.February, 17, 90 * time.Minute},
+		{0, time.February, 18, 120 * time.Minute},
+		{0, time.March, 19, 120 * time.Minute},
+		{0, time.March, 20, 90 * time.Minute},
+		// A chat a day behind the calendar starts and ends Ramadan a day later.
+		{-1, time.February, 18, 90 * time.Minute},
+		{-1, time.March, 20, 120 * time.Minute},
+	} {
+		profile.HijriAdjustment = check.adjustment
+		if got := interval(profile, check.month, check.day); got != check.want {
+			t.Errorf("adjustment %+d, %s %d: Isha %s after Maghrib, want %s", check.adjustment, check.month, check.day, got, check.want)
+		}
+	}
+}
+
+func TestExtendedTimesFollowTheDayAndTheNight(t *testing.T) {
+	calculator := New()
+	profile := domain.PrayerProfile{
+		Latitude: 30.044, Longitude: 31.236, Timezone: "Africa/Cairo",
+		Method: domain.MethodEgyptian, Madhab: domain.MadhabShafii,
+		HighLatitudeRule: domain.HighLatitudeAngleBased, ImsakMinutes: 10,
+	}
+	date := time.Date(2026, 7, 16, 12, 0, 0, 0, time.UTC)
+	today, err := calculator.Day(context.Background(), date, profile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tomorrow, err := calculator.Day(context.Background(), date.AddDate(0, 0, 1), profile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fajr, sunrise, maghrib := today.Times[domain.PrayerFajr], today.Times[domain.PrayerSunrise], today.Times[domain.PrayerMaghrib]
+	nextFajr := tomorrow.Times[domain.PrayerFajr]
+	night := nextFajr.Sub(maghrib)
+
+	if got := fajr.Sub(today.Times[domain.PrayerImsak]); got != 10*time.Minute {
+		t.Fatalf("Imsak should be 10 minutes before Fajr, got %v", got)
+	}
+	if got := today.Times[domain.PrayerIshraq].Sub(sunrise); got != 15*time.Minute {
+		t.Fatalf("Ishraq should be 15 minutes after sunrise, got %v", got)
+	}
+	within := func(name string, got, want time.Time) {
+		t.Helper()
+		if diff := got.Sub(want); diff < -time.Minute || diff > time.Minute {
+			t.Fatalf("%s = %v, want about %v", name, got, want)
+		}
+	}
+	within("Duha", today.Times[domain.PrayerDuha], sunrise.Add(maghrib.Sub(sunrise)/4))
+	within("midnight", today.Times[domain.PrayerMidnight], maghrib.Add(night/2))
+	within("last third", today.Times[domain.PrayerLastThird], maghrib.Add(night*2/3))
+	if !today.Times[domain.PrayerLastThird].After(today.Times[domain.PrayerMidnight]) {
+		t.Fatal("the last third must start after midnight")
+	}
+}
+
+func TestNightOfDecemberThirtyFirstEndsInTheNextYear(t *testing.T) {
+	profile := domain.PrayerProfile{
+		Latitude: 30.044, Longitude: 31.236, Timezone: "Africa/Cairo",
+		Method: domain.MethodEgyptian, Madhab: domain.MadhabShafii,
+		HighLatitudeRule: domain.HighLatitudeAngleBased,
+	}
+	schedule, err := New().Day(context.Background(), time.Date(2026, 12, 31, 12, 0, 0, 0, time.UTC), profile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	midnight, ok := schedule.At(domain.PrayerMidnight)
+	if !ok {
+		t.Fatal("midnight is missing on 31 December")
+	}
+	if midnight.Before(schedule.Times[domain.PrayerMaghrib]) {
+		t.Fatalf("midnight %v precedes Maghrib", midnight)
+	}
+	if imsak, _ := schedule.At(domain.PrayerImsak); !imsak.Equal(schedule.Times[domain.PrayerFajr]) {
+		t.Fatalf("a zero Imsak margin should match Fajr, got %v", imsak)
+	}
+}
+
+func TestMakruhWindowsSurroundSunriseZenithAndSunset(t *testing.T) {
+	profile := domain.PrayerProfile{
+		Latitude: 21.423, Longitude: 39.826, Timezone: "Asia/Riyadh",
+		Method: domain.MethodUmmAlQura, Madhab: domain.MadhabShafii,
+		HighLatitudeRule: domain.HighLatitudeAngleBased,
+	}
+	schedule, err := New().Day(context.Background(), time.Date(2026, 3, 20, 12, 0, 0, 0, time.UTC), profile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, test := range []struct {
+		window     domain.MakruhWindow
+		start, end time.Time
+	}{
+		{domain.MakruhSunrise, schedule.Times[domain.PrayerSunrise], schedule.Times[domain.PrayerIshraq]},
+		{domain.MakruhZenith, schedule.Times[domain.PrayerDhuhr].Add(-10 * time.Minute), schedule.Times[domain.PrayerDhuhr]},
+		{domain.MakruhSunset, schedule.Times[domain.PrayerMaghrib].Add(-20 * time.Minute), schedule.Times[domain.PrayerMaghrib]},
+	} {
+		window, ok := schedule.MakruhWindow(test.window)
+		if !ok {
+			t.Fatalf("%s window is missing", test.window)
+		}
+		if !window.Start.Equal(test.start) || !window.End.Equal(test.end) {
+			t.Errorf("%s window = %v–%v, want %v–%v", test.window, window.Start, window.End, test.start, test.end)
+		}
+	}
+}
+
+func TestElevationWidensTheDayThroughTheHorizonDip(t *testing.T) {
+	calculator := New()
+	profile := domain.PrayerProfile{
+		Latitude: 19.433, Longitude: -99.133, Timezone: "America/Mexico_City",
+		Method: domain.MethodISNA, Madhab: domain.MadhabShafii,
+		HighLatitudeRule: domain.HighLatitudeAngleBased,
+	}
+	date := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	seaLevel, err := calculator.Day(context.Background(), date, profile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	profile.ElevationMeters = 2240
+	elevated, err := calculator.Day(context.Background(), date, profile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// A 2,240 m horizon dip is about 1.5°, several minutes either side.
+	if earlier := seaLevel.Times[domain.PrayerSunrise].Sub(elevated.Times[domain.PrayerSunrise]); earlier < 4*time.Minute {
+		t.Fatalf("sunrise moved %v earlier, want at least 4m", earlier)
+	}
+	if later := elevated.Times[domain.PrayerMaghrib].Sub(seaLevel.Times[domain.PrayerMaghrib]); later < 4*time.Minute {
+		t.Fatalf("Maghrib moved %v later, want at least 4m", later)
+	}
+	if !elevated.Times[domain.PrayerDhuhr].Equal(seaLevel.Times[domain.PrayerDhuhr]) {
+		t.Fatal("elevation must not move Dhuhr")
+	}
+}
+
+func TestPrecautionGivesWholeMinutesForEveryEngine(t *testing.T) {
+	profile := domain.PrayerProfile{
+		Latitude: 55.796, Longitude: 49.106, Timezone: "Europe/Moscow",
+		Method: domain.MethodMWL, Madhab: domain.MadhabHanafi,
+		HighLatitudeRule: domain.HighLatitudeAngleBased,
+	}
+	date := time.Date(2026, 3, 20, 12, 0, 0, 0, time.UTC)
+	for engine, calculator := range map[string]*LocalCalculator{"library": New(), "astronomical": NewAstronomical()} {
+		profile.Precaution = domain.Precaution{}
+		nearest, err := calculator.Day(context.Background(), date, profile)
+		if err != nil {
+			t.Fatal(err)
+		}
+		profile.Precaution = domain.Precaution{IhtiyatMinutes: 3, Fajr: domain.RoundDown, Maghrib: domain.RoundUp}
+		cautious, err := calculator.Day(context.Background(), date, profile)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, prayer := range []domain.Prayer{
+			domain.PrayerFajr, domain.PrayerSunrise, domain.PrayerDhuhr, domain.PrayerAsr, domain.PrayerMaghrib, domain.PrayerIsha,
+			domain.PrayerImsak, domain.PrayerMidnight, domain.PrayerLastThird,
+		} {
+			if at := cautious.Times[prayer]; at.Second() != 0 || at.Nanosecond() != 0 {
+				t.Errorf("%s %s = %s, want whole minutes", engine, prayer, at)
+			}
+		}
+		if shift := cautious.Times[domain.PrayerSunrise].Sub(nearest.Times[domain.PrayerSunrise]); shift != 0 {
+			t.Errorf("%s: ihtiyat moved Sunrise by %v", engine, shift)
+		}
+		if shift := cautious.Times[domain.PrayerDhuhr].Sub(nearest.Times[domain.PrayerDhuhr]); shift != 3*time.Minute {
+			t.Errorf("%s: Dhuhr moved %v, want 3m", engine, shift)
+		}
+		if shift := cautious.Times[domain.PrayerMaghrib].Sub(nearest.Times[domain.PrayerMaghrib]); shift < 3*time.Minute || shift > 4*time.Minute {
+			t.Errorf("%s: Maghrib moved %v, want 3m plus rounding up", engine, shift)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package prayertime
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"math"
+	"time"
+
+	"github.com/escalopa/prayer-bot/global/internal/domain"
+	"github.com/escalopa/prayer-bot/global/internal/port"
+)
+
+// Divergence is a prayer whose time differs between two engines by more than
+// the comparison threshold. A side that has no time for the prayer, as in a
+// polar day, has a zero time and the difference is reported as zero.
+type Divergence struct {
+	Prayer     domain.Prayer
+	Primary    time.Time
+	Secondary  time.Time
+	Difference time.Duration
+}
+
+// Compare lists the prayers that differ by more than threshold between two
+// schedules of the same day, in the order they fall. Extended times and makruh
+// windows are derived from these, so they would only repeat a divergence.
+func Compare(primary, secondary domain.DaySchedule, threshold time.Duration) []Divergence {
+	var divergences []Divergence
+	for _, prayer := range dailyPrayers {
+		first, second := primary.Times[prayer], secondary.Times[prayer]
+		if first.IsZero() && second.IsZero() {
+			continue
+		}
+		divergence := Divergence{Prayer: prayer, Primary: first, Secondary: second}
+		if first.IsZero() || second.IsZero() {
+			divergences = append(divergences, divergence)
+			continue
+		}
+		divergence.Difference = second.Sub(first)
+		if divergence.Difference.Abs() > threshold {
+			divergences = append(divergences, divergence)
+		}
+	}
+	return divergences
+}
+
+// Comparison serves the primary calculator and runs the secondary one on the
+// same input, logging every prayer where the two disagree by more than the
+// threshold. The secondary result and its errors never reach the caller.
+type Comparison struct {
+	primary, secondary port.Calculator
+	threshold          time.Duration
+	logger             *slog.Logger
+}
+
+func NewComparison(primary, secondary port.Calculator, threshold time.Duration, logger *slog.Logger) *Comparison {
+	if logger == nil {
+		logger = slog.New(slog.NewTextHandler(io.Discard, nil))
+	}
+	return &Comparison{primary: primary, secondary: secondary, threshold: threshold, logger: logger}
+}
+
+func (c *Comparison) Day(ctx context.Context, date time.Time, profile domain.PrayerProfile) (domain.DaySchedule, error) {
+	schedule, err := c.primary.Day(ctx, date, profile)
+	if err != nil {
+		return domain.DaySchedule{}, err
+	}
+	other, err := c.secondary.Day(ctx, date, profile)
+	if err != nil {
+		c.logger.Warn("secondary prayer calculator failed", "error", err)
+		return schedule, nil
+	}
+	// Logs never carry coordinates. The timezone places a divergence in its
+	// region and the whole-degree latitude, which engines are sensitive to,
+	// is about 111 km wide.
+	latitude := int(math.Round(profile.Latitude))
+	for _, divergence := range Compare(schedule, other, c.threshold) {
+		c.logger.Warn("prayer calculators diverge",
+			"date", schedule.Date.Format(time.DateOnly), "prayer", divergence.Prayer,
+			"latitude_degrees", latitude, "timezone", profile.Timezone,
+			"method", profile.Method, "madhab", profile.Madhab, "high_latitude_rule", profile.HighLatitudeRule,
+			"primary", formatInstant(divergence.Primary), "secondary", formatInstant(divergence.Secondary),
+			"difference_seconds", int(divergence.Difference.Seconds()),
+		)
+	}
+	return schedule, nil
+}
+
+// CacheStats reports the served calculator's cache.
+func (c *Comparison) CacheStats() domain.CacheStats {
+	return cacheStats(c.primary)
+}
+
+func formatInstant(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+var _ port.Calculator = (*Comparison)(nil)
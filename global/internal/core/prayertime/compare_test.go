@@ -0,0 +1,77 @@
+package prayertime
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/escalopa/prayer-bot/global/internal/domain"
+)
+
+func TestCompareReportsPrayersBeyondTheThreshold(t *testing.T) {
+	at := func(hour, minute int) time.Time { return time.Date(2026, time.March, 20, hour, minute, 0, 0, time.UTC) }
+	primary := domain.DaySchedule{Times: map[domain.Prayer]time.Time{
+		domain.PrayerFajr: at(4, 30), domain.PrayerDhuhr: at(12, 5), domain.PrayerIsha: at(19, 40),
+	}}
+	secondary := domain.DaySchedule{Times: map[domain.Prayer]time.Time{
+		domain.PrayerFajr: at(4, 31), domain.PrayerDhuhr: at(12, 8),
+	}}
+	divergences := Compare(primary, secondary, time.Minute)
+	if len(divergences) != 2 {
+		t.Fatalf("divergences = %+v, want Dhuhr and Isha", divergences)
+	}
+	if divergence := divergences[0]; divergence.Prayer != domain.PrayerDhuhr || divergence.Difference != 3*time.Minute {
+		t.Fatalf("unexpected Dhuhr divergence: %+v", divergence)
+	}
+	if divergence := divergences[1]; divergence.Prayer != domain.PrayerIsha || !divergence.Secondary.IsZero() {
+		t.Fatalf("a prayer missing from one side must diverge: %+v", divergence)
+	}
+}
+
+func TestComparisonServesThePrimaryAndLogsDivergences(t *testing.T) {
+	date := time.Date(2026, time.March, 20, 12, 0, 0, 0, time.UTC)
+	primary := fixedCalculator{schedule: domain.DaySchedule{Date: date, Times: map[domain.Prayer]time.Time{
+		domain.PrayerAsr: date.Add(3 * time.Hour),
+	}}}
+	secondary := fixedCalculator{schedule: domain.DaySchedule{Date: date, Times: map[domain.Prayer]time.Time{
+		domain.PrayerAsr: date.Add(3*time.Hour + 5*time.Minute),
+	}}}
+	var logs bytes.Buffer
+	comparison := NewComparison(primary, secondary, 2*time.Minute, slog.New(slog.NewTextHandler(&logs, nil)))
+	profile := domain.PrayerProfile{Latitude: 30.0444, Longitude: 31.2357, Timezone: "Africa/Cairo"}
+
+	schedule, err := comparison.Day(context.Background(), date, profile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !schedule.Times[domain.PrayerAsr].Equal(date.Add(3 * time.Hour)) {
+		t.Fatalf("comparison must serve the primary schedule, got %v", schedule.Times)
+	}
+	for _, expected := range []string{"prayer=asr", "difference_seconds=300", "latitude_degrees=30", "timezone=Africa/Cairo"} {
+		if !strings.Contains(logs.String(), expected) {
+			t.Errorf("log missing %q:\n%s", expected, logs.String())
+		}
+	}
+
+	logs.Reset()
+	comparison = NewComparison(primary, fixedCalculator{err: errors.New("boom")}, 2*time.Minute, slog.New(slog.NewTextHandler(&logs, nil)))
+	if _, err := comparison.Day(context.Background(), date, profile); err != nil {
+		t.Fatalf("secondary failure must not reach the caller: %v", err)
+	}
+	if !strings.Contains(logs.String(), "secondary prayer calculator failed") {
+		t.Fatalf("secondary failure was not logged:\n%s", logs.String())
+	}
+}
+
+type fixedCalculator struct {
+	schedule domain.DaySchedule
+	err      error
+}
+
+func (c fixedCalculator) Day(context.Context, time.Time, domain.PrayerProfile) (domain.DaySchedule, error) {
+	return c.schedule, c.err
+}
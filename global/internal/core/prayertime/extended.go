@@ -0,0 +1,33 @@
+package prayertime
+
+import (
+	"time"
+
+	"github.com/escalopa/prayer-bot/global/internal/domain"
+)
+
+// ishraqDelay is the "length of a spear" after sunrise that most timetables
+// publish as fifteen minutes.
+const ishraqDelay = 15 * time.Minute
+
+// addExtendedTimes derives the extended times from the calculated prayers.
+// Duha starts a quarter of the way from sunrise to Maghrib. Midnight and the
+// last third divide the night between Maghrib and the next Fajr, and are left
+// out when either end is missing, as it is during polar days.
+func addExtendedTimes(times map[domain.Prayer]time.Time, nextFajr time.Time, imsakMinutes int) {
+	if fajr := times[domain.PrayerFajr]; !fajr.IsZero() {
+		times[domain.PrayerImsak] = fajr.Add(-time.Duration(imsakMinutes) * time.Minute)
+	}
+	sunrise, maghrib := times[domain.PrayerSunrise], times[domain.PrayerMaghrib]
+	if !sunrise.IsZero() {
+		times[domain.PrayerIshraq] = sunrise.Add(ishraqDelay)
+	}
+	if !sunrise.IsZero() && !maghrib.IsZero() {
+		times[domain.PrayerDuha] = sunrise.Add(maghrib.Sub(sunrise) / 4).Round(time.Minute)
+	}
+	if !maghrib.IsZero() && nextFajr.After(maghrib) {
+		night := nextFajr.Sub(maghrib)
+		times[domain.PrayerMidnight] = maghrib.Add(night / 2).Round(time.Minute)
+		times[domain.PrayerLastThird] = maghrib.Add(night * 2 / 3).Round(time.Minute)
+	}
+}
@@ -0,0 +1,132 @@
+package prayertime
+
+import (
+	"math"
+	"time"
+
+	prayer "github.com/hablullah/go-prayer"
+
+	"github.com/escalopa/prayer-bot/global/internal/domain"
+)
+
+func highLatitudeAdapter(rule domain.HighLatitudeRule, calculate engine) prayer.HighLatitudeAdapter {
+	switch rule {
+	case domain.HighLatitudeMiddleNight:
+		return prayer.MiddleNight()
+	case domain.HighLatitudeSeventhNight:
+		return prayer.OneSeventhNight()
+	case domain.HighLatitudeNearestLatitude:
+		return nearestLatitude(calculate)
+	case domain.HighLatitudeNearestDay:
+		return nearestDay
+	default:
+		return prayer.AngleBased()
+	}
+}
+
+// nearestLatitude fills the times a day is missing from the same day at
+// domain.NearestLatitudeDegrees on the same longitude. While the sun still
+// rises and sets, Fajr and Isha take the share of the night that twilight
+// takes there, so they stay inside the local night, and times the method's
+// own angles reach are kept. In a polar day or night every time but Dhuhr
+// is the reference one.
+//
+// The library's NearestLatitude works from 45° and rewrites the whole year,
+// which is why this adapter exists.
+func nearestLatitude(calculate engine) prayer.HighLatitudeAdapter {
+	return func(cfg prayer.Config, year int, schedules []prayer.Schedule) []prayer.Schedule {
+		if math.Abs(cfg.Latitude) <= domain.NearestLatitudeDegrees {
+			return schedules
+		}
+		reference := cfg
+		reference.Latitude = math.Copysign(domain.NearestLatitudeDegrees, cfg.Latitude)
+		reference.HighLatitudeAdapter = prayer.AngleBased()
+		reference.Corrections = prayer.ScheduleCorrections{}
+		reference.PreciseToSeconds = true
+		nearest, err := calculate(reference, year)
+		if err != nil || len(nearest) != len(schedules) {
+			return schedules
+		}
+		for i, schedule := range schedules {
+			if schedule.IsNormal {
+				continue
+			}
+			near := nearest[i]
+			if schedule.Sunrise.IsZero() || schedule.Maghrib.IsZero() {
+				near.Date, near.Zuhr = schedule.Date, schedule.Zuhr
+				schedules[i] = near
+				continue
+			}
+			if !near.Sunrise.IsZero() && !near.Maghrib.IsZero() {
+				night := 24*time.Hour - schedule.Maghrib.Sub(schedule.Sunrise)
+				nearNight := 24*time.Hour - near.Maghrib.Sub(near.Sunrise)
+				share := func(twilight time.Duration) time.Duration {
+					return time.Duration(float64(night) * float64(twilight) / float64(nearNight))
+				}
+				if schedule.Fajr.IsZero() && !near.Fajr.IsZero() {
+					schedule.Fajr = schedule.Sunrise.Add(-share(near.Sunrise.Sub(near.Fajr)))
+				}
+				if schedule.Isha.IsZero() && !near.Isha.IsZero() {
+					schedule.Isha = schedule.Maghrib.Add(share(near.Isha.Sub(near.Maghrib)))
+				}
+			}
+			schedule.Asr = orElse(schedule.Asr, near.Asr)
+			schedules[i] = schedule
+		}
+		return schedules
+	}
+}
+
+func orElse(value, fallback time.Time) time.Time {
+	if value.IsZero() {
+		return fallback
+	}
+	return value
+}
+
+// nearestDay takes what a day is missing from the last normal day before
+// it, going back into the end of the year for a period that starts on
+// 1 January. The repeated times keep their distance from that day's Dhuhr,
+// so they follow the local noon through clock changes. While the sun still
+// rises and sets, only the missing Fajr and Isha are repeated. Where that
+// would put Fajr after sunrise or Isha before Maghrib, as in the weeks around
+// a polar day, and in a polar day or night itself, the whole normal day is
+// repeated around the local Dhuhr.
+func nearestDay(_ prayer.Config, _ int, schedules []prayer.Schedule) []prayer.Schedule {
+	count := len(schedules)
+	for i, schedule := range schedules {
+		if schedule.IsNormal {
+			continue
+		}
+		source := -1
+		for back := 1; back < count && source < 0; back++ {
+			if j := (i - back + count) % count; schedules[j].IsNormal {
+				source = j
+			}
+		}
+		if source < 0 {
+			return schedules
+		}
+		normal := schedules[source]
+		shift := func(t time.Time) time.Time { return schedule.Zuhr.Add(t.Sub(normal.Zuhr)) }
+		repeated := prayer.Schedule{
+			Date:    schedule.Date,
+			Fajr:    shift(normal.Fajr),
+			Sunrise: shift(normal.Sunrise),
+			Zuhr:    schedule.Zuhr,
+			Asr:     shift(normal.Asr),
+			Maghrib: shift(normal.Maghrib),
+			Isha:    shift(normal.Isha),
+		}
+		if !schedule.Sunrise.IsZero() && !schedule.Maghrib.IsZero() {
+			schedule.Fajr = orElse(schedule.Fajr, repeated.Fajr)
+			schedule.Isha = orElse(schedule.Isha, repeated.Isha)
+			schedule.Asr = orElse(schedule.Asr, repeated.Asr)
+			if schedule.Fajr.Before(schedule.Sunrise) && schedule.Isha.After(schedule.Maghrib) {
+				repeated = schedule
+			}
+		}
+		schedules[i] = repeated
+	}
+	return schedules
+}
@@ -0,0 +1,79 @@
+package prayertime
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/escalopa/prayer-bot/global/internal/domain"
+)
+
+func TestNearestRulesGiveOrderedTimesAtTheSummerSolstice(t *testing.T) {
+	places := map[string]domain.PrayerProfile{
+		// The sun sets in Stockholm, but never 18° below the horizon.
+		"Stockholm": {Latitude: 59.329, Longitude: 18.069, Timezone: "Europe/Stockholm"},
+		// Tromsø is in its polar day.
+		"Tromsø": {Latitude: 69.649, Longitude: 18.956, Timezone: "Europe/Oslo"},
+	}
+	order := []domain.Prayer{
+		domain.PrayerFajr, domain.PrayerSunrise, domain.PrayerDhuhr, domain.PrayerAsr, domain.PrayerMaghrib, domain.PrayerIsha,
+	}
+	date := time.Date(2026, time.June, 21, 12, 0, 0, 0, time.UTC)
+	for name, profile := range places {
+		for _, rule := range []domain.HighLatitudeRule{domain.HighLatitudeNearestLatitude, domain.HighLatitudeNearestDay} {
+			for engine, calculator := range map[string]*LocalCalculator{"library": New(), "astronomical": NewAstronomical()} {
+				profile.Method, profile.Madhab, profile.HighLatitudeRule = domain.MethodMWL, domain.MadhabShafii, rule
+				schedule, err := calculator.Day(context.Background(), date, profile)
+				if err != nil {
+					t.Fatalf("%s %s %s: %v", name, rule, engine, err)
+				}
+				var previous time.Time
+				for _, prayer := range order {
+					at, ok := schedule.At(prayer)
+					if !ok || !at.After(previous) {
+						t.Fatalf("%s %s %s: %s = %s after %s", name, rule, engine, prayer, at, previous)
+					}
+					previous = at
+				}
+				if dhuhr, _ := schedule.At(domain.PrayerDhuhr); dhuhr.Day() != 21 || dhuhr.Month() != time.June {
+					t.Fatalf("%s %s %s: Dhuhr on %s, want the requested day", name, rule, engine, dhuhr)
+				}
+			}
+		}
+	}
+}
+
+func TestNearestLatitudeKeepsStockholmSunsetAndNearestDayRepeatsSpring(t *testing.T) {
+	date := time.Date(2026, time.June, 21, 12, 0, 0, 0, time.UTC)
+	profile := domain.PrayerProfile{
+		Latitude: 59.329, Longitude: 18.069, Timezone: "Europe/Stockholm",
+		Method: domain.MethodMWL, Madhab: domain.MadhabShafii, HighLatitudeRule: domain.HighLatitudeAngleBased,
+	}
+	calculator := New()
+	angle, err := calculator.Day(context.Background(), date, profile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	profile.HighLatitudeRule = domain.HighLatitudeNearestLatitude
+	nearest, err := calculator.Day(context.Background(), date, profile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !nearest.Times[domain.PrayerMaghrib].Equal(angle.Times[domain.PrayerMaghrib]) ||
+		!nearest.Times[domain.PrayerSunrise].Equal(angle.Times[domain.PrayerSunrise]) {
+		t.Fatal("nearest latitude should keep the local sunrise and sunset")
+	}
+
+	// Stockholm's last night with a full 18° twilight is in spring, and its
+	// Fajr, repeated at the same distance from Dhuhr, falls well before the
+	// 02:45 that one seventh of the short solstice night would give.
+	profile.HighLatitudeRule = domain.HighLatitudeNearestDay
+	day, err := calculator.Day(context.Background(), date, profile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fajr, isha := day.Times[domain.PrayerFajr], day.Times[domain.PrayerIsha]
+	if fajr.Hour() >= 3 || !isha.After(day.Times[domain.PrayerMaghrib]) {
+		t.Fatalf("Fajr %s, Isha %s", fajr, isha)
+	}
+}
@@ -0,0 +1,33 @@
+package prayertime
+
+import (
+	"time"
+
+	"github.com/escalopa/prayer-bot/global/internal/domain"
+)
+
+const (
+	// zawalMargin is how long before Dhuhr the sun is treated as standing at
+	// its zenith.
+	zawalMargin = 10 * time.Minute
+	// yellowingMargin is how long before Maghrib the sun is treated as
+	// yellowing.
+	yellowingMargin = 20 * time.Minute
+)
+
+// makruhWindows derives the three makruh windows from the calculated prayers.
+// The sunrise window ends when Ishraq begins. A window is left out when the
+// prayer it hangs on is missing.
+func makruhWindows(times map[domain.Prayer]time.Time) map[domain.MakruhWindow]domain.TimeWindow {
+	windows := make(map[domain.MakruhWindow]domain.TimeWindow, 3)
+	if sunrise := times[domain.PrayerSunrise]; !sunrise.IsZero() {
+		windows[domain.MakruhSunrise] = domain.TimeWindow{Start: sunrise, End: sunrise.Add(ishraqDelay)}
+	}
+	if dhuhr := times[domain.PrayerDhuhr]; !dhuhr.IsZero() {
+		windows[domain.MakruhZenith] = domain.TimeWindow{Start: dhuhr.Add(-zawalMargin), End: dhuhr}
+	}
+	if maghrib := times[domain.PrayerMaghrib]; !maghrib.IsZero() {
+		windows[domain.MakruhSunset] = domain.TimeWindow{Start: maghrib.Add(-yellowingMargin), End: maghrib}
+	}
+	return windows
+}
@@ -0,0 +1,125 @@
+package prayertime
+
+import (
+	"math"
+	"time"
+
+	prayer "github.com/hablullah/go-prayer"
+)
+
+// calculateMoonsighting applies the Moonsighting Committee Worldwide rules on
+// top of the 18° angle schedule. Fajr is never earlier, and Isha never later,
+// than Khalid Shaukat's seasonal minutes from sunrise and sunset. At 55° and
+// beyond, a seventh of the night replaces the angles. The committee also
+// publishes Dhuhr 5 minutes and Maghrib 3 minutes after the astronomical
+// events.
+//
+// The engine applies corrections and rounding before returning, so both are
+// turned off here and redone once the seasonal bounds are known.
+func calculateMoonsighting(calculate engine, cfg prayer.Config, year int) ([]prayer.Schedule, error) {
+	corrections := cfg.Corrections
+	precise := cfg.PreciseToSeconds
+	cfg.Corrections = prayer.ScheduleCorrections{}
+	cfg.PreciseToSeconds = true
+	schedules, err := calculate(cfg, year)
+	if err != nil {
+		return nil, err
+	}
+	corrections.Zuhr += 5 * time.Minute
+	corrections.Maghrib += 3 * time.Minute
+
+	latitude := math.Abs(cfg.Latitude)
+	for i, schedule := range schedules {
+		if !schedule.Sunrise.IsZero() && !schedule.Maghrib.IsZero() {
+			night := 24*time.Hour - schedule.Maghrib.Sub(schedule.Sunrise)
+			if latitude >= 55 {
+				schedule.Fajr = schedule.Sunrise.Add(-night / 7)
+				schedule.Isha = schedule.Maghrib.Add(night / 7)
+			}
+			days := daysSinceSolstice(schedule.Sunrise.YearDay(), year, cfg.Latitude)
+			fajr := schedule.Sunrise.Add(-seasonalMinutes(days, latitude, morningTwilight))
+			isha := schedule.Maghrib.Add(seasonalMinutes(days, latitude, eveningTwilight))
+			if schedule.Fajr.IsZero() || fajr.After(schedule.Fajr) {
+				schedule.Fajr = fajr
+			}
+			if schedule.Isha.IsZero() || isha.Before(schedule.Isha) {
+				schedule.Isha = isha
+			}
+		}
+		schedule.Fajr = correct(schedule.Fajr, corrections.Fajr, precise)
+		schedule.Sunrise = correct(schedule.Sunrise, corrections.Sunrise, precise)
+		schedule.Zuhr = correct(schedule.Zuhr, corrections.Zuhr, precise)
+		schedule.Asr = correct(schedule.Asr, corrections.Asr, precise)
+		schedule.Maghrib = correct(schedule.Maghrib, corrections.Maghrib, precise)
+		schedule.Isha = correct(schedule.Isha, corrections.Isha, precise)
+		schedules[i] = schedule
+	}
+	return schedules, nil
+}
+
+// Seasonal coefficients in minutes per 55° of latitude, at the solstice, 91,
+// 137 and 183 days after it. Each boundary value is 75 minutes plus
+// coefficient × |latitude| / 55.
+var (
+	morningTwilight = [4]float64{28.65, 19.44, 32.74, 48.10}
+	eveningTwilight = [4]float64{25.60, 2.050, -9.210, 6.140}
+)
+
+// seasonalMinutes interpolates linearly between the four boundary values over
+// the year. The curve is symmetric around the summer solstice (day 183).
+func seasonalMinutes(days int, latitude float64, coefficients [4]float64) time.Duration {
+	var boundary [4]float64
+	for i, coefficient := range coefficients {
+		boundary[i] = 75 + coefficient/55*latitude
+	}
+	a, b, c, d := boundary[0], boundary[1], boundary[2], boundary[3]
+	day := float64(days)
+	var minutes float64
+	switch {
+	case days < 91:
+		minutes = a + (b-a)/91*day
+	case days < 137:
+		minutes = b + (c-b)/46*(day-91)
+	case days < 183:
+		minutes = c + (d-c)/46*(day-137)
+	case days < 229:
+		minutes = d + (c-d)/46*(day-183)
+	case days < 275:
+		minutes = c + (b-c)/46*(day-229)
+	default:
+		minutes = b + (a-b)/91*(day-275)
+	}
+	return time.Duration(math.Round(minutes*60)) * time.Second
+}
+
+// daysSinceSolstice counts from the December solstice in the northern
+// hemisphere and from the June solstice in the southern one.
+func daysSinceSolstice(dayOfYear, year int, latitude float64) int {
+	daysInYear, juneSolstice := 365, 172
+	if time.Date(year, time.December, 31, 0, 0, 0, 0, time.UTC).YearDay() == 366 {
+		daysInYear, juneSolstice = 366, 173
+	}
+	if latitude >= 0 {
+		days := dayOfYear + 10
+		if days >= daysInYear {
+			days -= daysInYear
+		}
+		return days
+	}
+	days := dayOfYear - juneSolstice
+	if days < 0 {
+		days += daysInYear
+	}
+	return days
+}
+
+func correct(t time.Time, correction time.Duration, precise bool) time.Time {
+	if t.IsZero() {
+		return t
+	}
+	t = t.Add(correction)
+	if !precise {
+		t = t.Round(time.Minute)
+	}
+	return t
+}
@@ -0,0 +1,84 @@
+package prayertime
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/escalopa/prayer-bot/global/internal/domain"
+)
+
+func TestSeasonalMinutesFollowShaukatBoundaries(t *testing.T) {
+	cases := []struct {
+		days     int
+		latitude float64
+		curve    [4]float64
+		want     time.Duration
+	}{
+		{0, 0, morningTwilight, 75 * time.Minute},
+		{0, 55, morningTwilight, 6219 * time.Second},
+		{183, 55, morningTwilight, 7386 * time.Second},
+		{137, 55, eveningTwilight, 3947 * time.Second},
+	}
+	for _, c := range cases {
+		if got := seasonalMinutes(c.days, c.latitude, c.curve); got != c.want {
+			t.Errorf("seasonalMinutes(%d, %v) = %v, want %v", c.days, c.latitude, got, c.want)
+		}
+	}
+}
+
+func TestDaysSinceSolsticeWrapsPerHemisphere(t *testing.T) {
+	if got := daysSinceSolstice(360, 2026, 51.5); got != 5 {
+		t.Fatalf("northern day 360 = %d, want 5", got)
+	}
+	if got := daysSinceSolstice(1, 2026, 51.5); got != 11 {
+		t.Fatalf("northern day 1 = %d, want 11", got)
+	}
+	if got := daysSinceSolstice(172, 2026, -33.9); got != 0 {
+		t.Fatalf("southern June solstice = %d, want 0", got)
+	}
+	if got := daysSinceSolstice(1, 2028, -33.9); got != 194 {
+		t.Fatalf("southern day 1 of a leap year = %d, want 194", got)
+	}
+}
+
+func TestMoonsightingBoundsTwilightAndShiftsDhuhrAndMaghrib(t *testing.T) {
+	calculator := New()
+	london := domain.PrayerProfile{
+		Latitude: 51.507, Longitude: -0.128, Timezone: "Europe/London",
+		Method: domain.MethodMWL, Madhab: domain.MadhabShafii,
+		HighLatitudeRule: domain.HighLatitudeAngleBased,
+	}
+	moonsighting := london
+	moonsighting.Method = domain.MethodMoonsighting
+
+	for _, date := range []time.Time{
+		time.Date(2026, time.January, 15, 12, 0, 0, 0, time.UTC),
+		time.Date(2026, time.June, 21, 12, 0, 0, 0, time.UTC),
+	} {
+		base, err := calculator.Day(context.Background(), date, london)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := calculator.Day(context.Background(), date, moonsighting)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if shift := got.Times[domain.PrayerDhuhr].Sub(base.Times[domain.PrayerDhuhr]); shift != 5*time.Minute {
+			t.Errorf("%s: Dhuhr shifted by %v, want 5m", date.Format("Jan"), shift)
+		}
+		if shift := got.Times[domain.PrayerMaghrib].Sub(base.Times[domain.PrayerMaghrib]); shift != 3*time.Minute {
+			t.Errorf("%s: Maghrib shifted by %v, want 3m", date.Format("Jan"), shift)
+		}
+		sunrise, maghrib := got.Times[domain.PrayerSunrise], base.Times[domain.PrayerMaghrib]
+		days := daysSinceSolstice(date.YearDay(), 2026, london.Latitude)
+		earliestFajr := sunrise.Add(-seasonalMinutes(days, london.Latitude, morningTwilight))
+		latestIsha := maghrib.Add(seasonalMinutes(days, london.Latitude, eveningTwilight))
+		if fajr := got.Times[domain.PrayerFajr]; fajr.Before(earliestFajr.Add(-time.Minute)) || !fajr.Before(sunrise) {
+			t.Errorf("%s: Fajr %v outside [%v, %v)", date.Format("Jan"), fajr, earliestFajr, sunrise)
+		}
+		if isha := got.Times[domain.PrayerIsha]; isha.After(latestIsha.Add(time.Minute)) || !isha.After(maghrib) {
+			t.Errorf("%s: Isha %v outside (%v, %v]", date.Format("Jan"), isha, maghrib, latestIsha)
+		}
+	}
+}
@@ -0,0 +1,318 @@
+package prayertime
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+
+	"github.com/escalopa/prayer-bot/global/internal/domain"
+	"github.com/escalopa/prayer-bot/global/internal/port"
+)
+
+// MaxTimetableDays bounds one upload to a little over two years of rows.
+const MaxTimetableDays = 800
+
+// ParseTimetable reads an official timetable in the format the city bots'
+// loader ingests: a header row, then one row per day with the date as
+// D/M/YYYY and Fajr, sunrise, Dhuhr, Asr, Maghrib, and Isha as HH:MM. Isha may
+// fall after midnight, as it does in high-latitude summers; every other time
+// must follow the one before it.
+func ParseTimetable(file io.Reader) ([]domain.TimetableDay, error) {
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = 1 + len(dailyPrayers)
+	reader.TrimLeadingSpace = true
+	if _, err := reader.Read(); err != nil {
+		return nil, fmt.Errorf("read header: %w", err)
+	}
+	var days []domain.TimetableDay
+	seen := make(map[time.Time]bool)
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		day, err := parseTimetableRecord(record)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		if seen[day.Date] {
+			return nil, fmt.Errorf("line %d: %s appears twice", line, day.Date.Format(time.DateOnly))
+		}
+		seen[day.Date] = true
+		if days = append(days, day); len(days) > MaxTimetableDays {
+			return nil, fmt.Errorf("a timetable may have at most %d days", MaxTimetableDays)
+		}
+	}
+	if len(days) == 0 {
+		return nil, fmt.Errorf("the timetable has no days")
+	}
+	slices.SortFunc(days, func(a, b domain.TimetableDay) int { return a.Date.Compare(b.Date) })
+	return days, nil
+}
+
+func parseTimetableRecord(record []string) (domain.TimetableDay, error) {
+	date, err := time.Parse("2/1/2006", strings.TrimSpace(record[0]))
+	if err != nil {
+		return domain.TimetableDay{}, fmt.Errorf("date %q is not D/M/YYYY", record[0])
+	}
+	day := domain.TimetableDay{Date: date, Minutes: make(map[domain.Prayer]int, len(dailyPrayers))}
+	previous := -1
+	for i, prayer := range dailyPrayers {
+		clock, err := time.Parse("15:04", strings.TrimSpace(record[i+1]))
+		if err != nil {
+			return domain.TimetableDay{}, fmt.Errorf("%s time %q is not HH:MM", prayer, record[i+1])
+		}
+		minutes := clock.Hour()*60 + clock.Minute()
+		if prayer == domain.PrayerIsha && minutes <= previous {
+			minutes += 24 * 60
+		}
+		if minutes <= previous {
+			return domain.TimetableDay{}, fmt.Errorf("%s is not after the time before it", prayer)
+		}
+		day.Minutes[prayer], previous = minutes, minutes
+	}
+	return day, nil
+}
+
+// timetableRefresh is how long a process trusts its list of timetables. Other
+// services learn about an upload within this time.
+const timetableRefresh = 5 * time.Minute
+
+// Official serves official timetables to the profiles they cover and falls
+// back to the wrapped calculator everywhere else, including on dates a
+// timetable does not cover and for profiles that ignore timetables. A place-bound timetable wins over a country-wide
+// one, and the smaller circle or, between equals, the newer upload wins over
+// the rest. Published times are shifted by the profile's own adjustments, and
+// the extended times and makruh windows are derived from them as they are
+// from calculated times.
+type Official struct {
+	calculator port.Calculator
+	source     port.TimetableSource
+	now        func() time.Time
+
+	mu         sync.Mutex
+	timetables []domain.Timetable
+	loadedAt   time.Time
+	days       map[timetableYear]map[time.Time]domain.TimetableDay
+	// generation counts invalidations, so a load that began before one is
+	// neither joined nor kept.
+	generation uint64
+	flight     singleflight.Group
+}
+
+type timetableYear struct {
+	id   int64
+	year int
+}
+
+func NewOfficial(calculator port.Calculator, source port.TimetableSource) *Official {
+	return &Official{calculator: calculator, source: source, now: time.Now}
+}
+
+// Invalidate drops the cached timetables, so this process serves an upload or
+// deletion at once instead of after the next refresh.
+func (o *Official) Invalidate() {
+	o.mu.Lock()
+	o.timetables, o.days, o.loadedAt = nil, nil, time.Time{}
+	o.generation++
+	o.mu.Unlock()
+}
+
+func (o *Official) Day(ctx context.Context, date time.Time, profile domain.PrayerProfile) (domain.DaySchedule, error) {
+	if profile.IgnoreTimetables {
+		return o.calculator.Day(ctx, date, profile)
+	}
+	timetables, err := o.covering(ctx, profile)
+	if err != nil {
+		return domain.DaySchedule{}, err
+	}
+	if len(timetables) == 0 {
+		return o.calculator.Day(ctx, date, profile)
+	}
+	location, err := domain.LoadLocation(profile.Timezone)
+	if err != nil {
+		return domain.DaySchedule{}, fmt.Errorf("load timezone: %w", err)
+	}
+	localDate := date.In(location)
+	for _, timetable := range timetables {
+		row, ok, err := o.row(ctx, timetable.ID, localDate)
+		if err != nil {
+			return domain.DaySchedule{}, err
+		}
+		if ok {
+			return o.schedule(ctx, timetable, row, localDate, profile)
+		}
+	}
+	return o.calculator.Day(ctx, date, profile)
+}
+
+// CacheStats reports the fallback calculator's cache.
+func (o *Official) CacheStats() domain.CacheStats {
+	return cacheStats(o.calculator)
+}
+
+// schedule builds a day from a published row. The night ends at the next
+// row's Fajr, or at the calculated one when the timetable ends that night.
+func (o *Official) schedule(ctx context.Context, timetable domain.Timetable, row domain.TimetableDay, localDate time.Time, profile domain.PrayerProfile) (domain.DaySchedule, error) {
+	location := localDate.Location()
+	times := publishedTimes(row, location, profile)
+	var nextFajr time.Time
+	tomorrow := localDate.AddDate(0, 0, 1)
+	next, ok, err := o.row(ctx, timetable.ID, tomorrow)
+	if err != nil {
+		return domain.DaySchedule{}, err
+	}
+	if ok {
+		nextFajr = publishedTimes(next, location, profile)[domain.PrayerFajr]
+	} else if calculated, err := o.calculator.Day(ctx, tomorrow, profile); err == nil {
+		nextFajr = calculated.Times[domain.PrayerFajr]
+	}
+	makruh := makruhWindows(times)
+	addExtendedTimes(times, nextFajr, profile.ImsakMinutes)
+	return domain.DaySchedule{
+		Date: localDate, Timezone: profile.Timezone, Times: times, Makruh: makruh, Timetable: timetable.Name,
+	}, nil
+}
+
+// publishedTimes shifts a row by the profile's adjustments and ihtiyat; its
+// whole minutes are left as the authority published them.
+func publishedTimes(row domain.TimetableDay, location *time.Location, profile domain.PrayerProfile) map[domain.Prayer]time.Time {
+	times := make(map[domain.Prayer]time.Time, len(dailyPrayers))
+	for _, prayer := range dailyPrayers {
+		if at, ok := row.At(prayer, location); ok {
+			at = at.Add(time.Duration(adjustmentMinutes(profile.Adjustments, prayer)) * time.Minute)
+			times[prayer] = profile.Precaution.Apply(prayer, at)
+		}
+	}
+	return times
+}
+
+func adjustmentMinutes(adjustments domain.Adjustments, prayer domain.Prayer) int {
+	switch prayer {
+	case domain.PrayerFajr:
+		return adjustments.Fajr
+	case domain.PrayerSunrise:
+		return adjustments.Sunrise
+	case domain.PrayerDhuhr:
+		return adjustments.Dhuhr
+	case domain.PrayerAsr:
+		return adjustments.Asr
+	case domain.PrayerMaghrib:
+		return adjustments.Maghrib
+	case domain.PrayerIsha:
+		return adjustments.Isha
+	default:
+		return 0
+	}
+}
+
+// covering returns the timetables that cover the profile, best match first.
+func (o *Official) covering(ctx context.Context, profile domain.PrayerProfile) ([]domain.Timetable, error) {
+	timetables, err := o.loaded(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var covering []domain.Timetable
+	for _, timetable := range timetables {
+		if timetable.Covers(profile) {
+			covering = append(covering, timetable)
+		}
+	}
+	slices.SortStableFunc(covering, func(a, b domain.Timetable) int {
+		if a.PlaceBound() != b.PlaceBound() {
+			if a.PlaceBound() {
+				return -1
+			}
+			return 1
+		}
+		if a.RadiusKm != b.RadiusKm {
+			return a.RadiusKm - b.RadiusKm
+		}
+		return b.CreatedAt.Compare(a.CreatedAt)
+	})
+	return covering, nil
+}
+
+// loaded returns the timetables, reloading them once they are stale. The load
+// runs outside the lock, so a refresh never holds up a reader of the cached
+// list, and concurrent refreshes share one load. It is detached from the
+// caller's cancellation, which would otherwise fail every caller sharing it.
+func (o *Official) loaded(ctx context.Context) ([]domain.Timetable, error) {
+	o.mu.Lock()
+	timetables, loadedAt, generation := o.timetables, o.loadedAt, o.generation
+	o.mu.Unlock()
+	if !loadedAt.IsZero() && o.now().Sub(loadedAt) < timetableRefresh {
+		return timetables, nil
+	}
+	value, err, _ := o.flight.Do(strconv.FormatUint(generation, 10), func() (any, error) {
+		timetables, err := o.source.Timetables(context.WithoutCancel(ctx))
+		if err != nil {
+			return nil, fmt.Errorf("load official timetables: %w", err)
+		}
+		o.mu.Lock()
+		if o.generation == generation {
+			o.timetables, o.loadedAt = timetables, o.now()
+			o.days = make(map[timetableYear]map[time.Time]domain.TimetableDay)
+		}
+		o.mu.Unlock()
+		return timetables, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return value.([]domain.Timetable), nil
+}
+
+// row returns the timetable's row for a local date, loading the rows a year at
+// a time.
+func (o *Official) row(ctx context.Context, timetableID int64, localDate time.Time) (domain.TimetableDay, bool, error) {
+	date := time.Date(localDate.Year(), localDate.Month(), localDate.Day(), 0, 0, 0, 0, time.UTC)
+	key := timetableYear{id: timetableID, year: date.Year()}
+	o.mu.Lock()
+	days, ok := o.days[key]
+	o.mu.Unlock()
+	if !ok {
+		from := time.Date(key.year, time.January, 1, 0, 0, 0, 0, time.UTC)
+		rows, err := o.source.TimetableDays(ctx, timetableID, from, from.AddDate(1, 0, -1))
+		if err != nil {
+			return domain.TimetableDay{}, false, fmt.Errorf("load official timetable days: %w", err)
+		}
+		days = make(map[time.Time]domain.TimetableDay, len(rows))
+		for _, row := range rows {
+			days[row.Date] = row
+		}
+		o.mu.Lock()
+		if o.days != nil {
+			o.days[key] = days
+		}
+		o.mu.Unlock()
+	}
+	row, ok := days[date]
+	return row, ok, nil
+}
+
+// CoveringTimetable names the official timetable that serves the profile on
+// the date, or would serve it if the profile did not ignore timetables, and is
+// empty when none covers it.
+func CoveringTimetable(ctx context.Context, calculator port.Calculator, date time.Time, profile domain.PrayerProfile) (string, error) {
+	profile.IgnoreTimetables = false
+	schedule, err := calculator.Day(ctx, date, profile)
+	if err != nil {
+		return "", err
+	}
+	return schedule.Timetable, nil
+}
+
+var _ port.Calculator = (*Official)(nil)
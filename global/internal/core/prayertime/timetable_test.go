@@ -0,0 +1,203 @@
+package prayertime
+
+import (
+	"context"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/escalopa/prayer-bot/global/internal/domain"
+)
+
+func TestParseTimetableReadsRowsAndWrapsLateIsha(t *testing.T) {
+	days, err := ParseTimetable(strings.NewReader("date,fajr,sunrise,dhuhr,asr,maghrib,isha\n" +
+		"22/6/2026, 01:58, 03:40, 12:21, 17:20, 20:59, 00:41\n" +
+		"21/6/2026, 01:57, 03:39, 12:21, 17:20, 20:59, 22:41\n"))
+	if err != nil {
+		t.Fatalf("parse timetable: %v", err)
+	}
+	if len(days) != 2 || !days[0].Date.Equal(time.Date(2026, time.June, 21, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("rows should be sorted by date: %+v", days)
+	}
+	if got := days[1].Minutes[domain.PrayerIsha]; got != 24*60+41 {
+		t.Fatalf("Isha after midnight = %d minutes, want %d", got, 24*60+41)
+	}
+	isha, _ := days[1].At(domain.PrayerIsha, time.UTC)
+	if want := time.Date(2026, time.June, 23, 0, 41, 0, 0, time.UTC); !isha.Equal(want) {
+		t.Fatalf("Isha = %s, want %s", isha, want)
+	}
+}
+
+func TestParseTimetableRejectsMalformedRows(t *testing.T) {
+	const header = "date,fajr,sunrise,dhuhr,asr,maghrib,isha\n"
+	for name, file := range map[string]string{
+		"empty":          header,
+		"bad date":       header + "2026-06-21,01:57,03:39,12:21,17:20,20:59,22:41\n",
+		"bad time":       header + "21/6/2026,1.57,03:39,12:21,17:20,20:59,22:41\n",
+		"out of order":   header + "21/6/2026,03:57,03:39,12:21,17:20,20:59,22:41\n",
+		"missing column": header + "21/6/2026,01:57,03:39,12:21,17:20,20:59\n",
+		"duplicate date": header + "21/6/2026,01:57,03:39,12:21,17:20,20:59,22:41\n21/6/2026,01:57,03:39,12:21,17:20,20:59,22:41\n",
+	} {
+		if _, err := ParseTimetable(strings.NewReader(file)); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+type fakeTimetableSource struct {
+	timetables []domain.Timetable
+	days       map[int64][]domain.TimetableDay
+	loads      int
+}
+
+func (s *fakeTimetableSource) Timetables(context.Context) ([]domain.Timetable, error) {
+	s.loads++
+	return s.timetables, nil
+}
+
+func (s *fakeTimetableSource) TimetableDays(_ context.Context, timetableID int64, from, to time.Time) ([]domain.TimetableDay, error) {
+	var days []domain.TimetableDay
+	for _, day := range s.days[timetableID] {
+		if !day.Date.Before(from) && !day.Date.After(to) {
+			days = append(days, day)
+		}
+	}
+	return days, nil
+}
+
+// blockingTimetableSource holds every timetable load until release closes.
+type blockingTimetableSource struct {
+	fakeTimetableSource
+	started chan struct{}
+	release chan struct{}
+	count   atomic.Int64
+}
+
+func (s *blockingTimetableSource) Timetables(context.Context) ([]domain.Timetable, error) {
+	s.count.Add(1)
+	s.started <- struct{}{}
+	<-s.release
+	return s.timetables, nil
+}
+
+// cancellingTimetableSource fails a load whose context is cancelled.
+type cancellingTimetableSource struct{ fakeTimetableSource }
+
+func (s *cancellingTimetableSource) Timetables(ctx context.Context) ([]domain.Timetable, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return s.fakeTimetableSource.Timetables(ctx)
+}
+
+func timetableDay(date time.Time, fajr int) domain.TimetableDay {
+	return domain.TimetableDay{Date: date, Minutes: map[domain.Prayer]int{
+		domain.PrayerFajr: fajr, domain.PrayerSunrise: 220, domain.PrayerDhuhr: 741,
+		domain.PrayerAsr: 1040, domain.PrayerMaghrib: 1259, domain.PrayerIsha: 1361,
+	}}
+}
+
+func TestOfficialServesTheClosestCoveringTimetable(t *testing.T) {
+	date := time.Date(2026, time.June, 21, 0, 0, 0, 0, time.UTC)
+	source := &fakeTimetableSource{
+		timetables: []domain.Timetable{
+			{ID: 1, Name: "Russian Muftiate", Timezone: "Europe/Moscow", CountryCode: "RU"},
+			{ID: 2, Name: "Kazan Muftiate", Timezone: "Europe/Moscow", Latitude: 55.796, Longitude: 49.106, RadiusKm: 40},
+		},
+		days: map[int64][]domain.TimetableDay{
+			1: {timetableDay(date, 150), timetableDay(date.AddDate(0, 0, 1), 151)},
+			2: {timetableDay(date, 117), timetableDay(date.AddDate(0, 0, 1), 118)},
+		},
+	}
+	fallback := fixedCalculator{schedule: domain.DaySchedule{Timezone: "Europe/Moscow"}}
+	official := NewOfficial(fallback, source)
+	kazan := domain.PrayerProfile{
+		Latitude: 55.79, Longitude: 49.12, Timezone: "Europe/Moscow", CountryCode: "RU",
+		Adjustments: domain.Adjustments{Fajr: 2},
+	}
+
+	schedule, err := official.Day(context.Background(), date, kazan)
+	if err != nil {
+		t.Fatalf("official day: %v", err)
+	}
+	moscow, _ := time.LoadLocation("Europe/Moscow")
+	if schedule.Timetable != "Kazan Muftiate" {
+		t.Fatalf("timetable = %q, want the place-bound one", schedule.Timetable)
+	}
+	if fajr := schedule.Times[domain.PrayerFajr]; !fajr.Equal(time.Date(2026, time.June, 21, 1, 59, 0, 0, moscow)) {
+		t.Fatalf("Fajr = %s, want the published 01:57 plus the profile's 2 minutes", fajr)
+	}
+	if _, ok := schedule.At(domain.PrayerLastThird); !ok || len(schedule.Makruh) == 0 {
+		t.Fatalf("extended times and makruh windows should be derived from published times: %+v", schedule)
+	}
+
+	ufa := kazan
+	ufa.Latitude, ufa.Longitude = 54.735, 55.958
+	if schedule, err := official.Day(context.Background(), date, ufa); err != nil || schedule.Timetable != "Russian Muftiate" {
+		t.Fatalf("a profile outside the circle should get the country timetable, got %q (%v)", schedule.Timetable, err)
+	}
+	if schedule, err := official.Day(context.Background(), date.AddDate(0, 0, 5), kazan); err != nil || schedule.Timetable != "" {
+		t.Fatalf("dates past the timetable should be calculated, got %q (%v)", schedule.Timetable, err)
+	}
+	london := domain.PrayerProfile{Latitude: 51.5, Longitude: -0.12, Timezone: "Europe/London", CountryCode: "GB"}
+	if schedule, err := official.Day(context.Background(), date, london); err != nil || schedule.Timetable != "" {
+		t.Fatalf("uncovered profiles should be calculated, got %q (%v)", schedule.Timetable, err)
+	}
+	ignoring := kazan
+	ignoring.IgnoreTimetables = true
+	if schedule, err := official.Day(context.Background(), date, ignoring); err != nil || schedule.Timetable != "" {
+		t.Fatalf("a profile that ignores timetables should be calculated, got %q (%v)", schedule.Timetable, err)
+	}
+	if name, err := CoveringTimetable(context.Background(), official, date, ignoring); err != nil || name != "Kazan Muftiate" {
+		t.Fatalf("CoveringTimetable = %q (%v), want the timetable the profile ignores", name, err)
+	}
+	if source.loads != 1 {
+		t.Fatalf("timetables loaded %d times, want one load per refresh", source.loads)
+	}
+	official.Invalidate()
+	if _, err := official.Day(context.Background(), date, kazan); err != nil || source.loads != 2 {
+		t.Fatalf("Invalidate should reload the timetables, loads = %d (%v)", source.loads, err)
+	}
+}
+
+func TestOfficialLoadsTimetablesOutsideItsLock(t *testing.T) {
+	source := &blockingTimetableSource{started: make(chan struct{}, 2), release: make(chan struct{})}
+	official := NewOfficial(fixedCalculator{}, source)
+	london := domain.PrayerProfile{Latitude: 51.5, Longitude: -0.12, Timezone: "Europe/London", CountryCode: "GB"}
+	loaded := make(chan error, 1)
+	go func() {
+		_, err := official.covering(context.Background(), london)
+		loaded <- err
+	}()
+	<-source.started
+
+	invalidated := make(chan struct{})
+	go func() {
+		official.Invalidate()
+		close(invalidated)
+	}()
+	select {
+	case <-invalidated:
+	case <-time.After(time.Second):
+		t.Fatal("Invalidate waited for a timetable load")
+	}
+	close(source.release)
+	if err := <-loaded; err != nil {
+		t.Fatal(err)
+	}
+	if _, err := official.covering(context.Background(), london); err != nil || source.count.Load() != 2 {
+		t.Fatalf("a load begun before Invalidate must not be kept, loads = %d (%v)", source.count.Load(), err)
+	}
+}
+
+func TestOfficialLoadSurvivesTheCallerThatStartedIt(t *testing.T) {
+	source := &cancellingTimetableSource{}
+	official := NewOfficial(fixedCalculator{}, source)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	london := domain.PrayerProfile{Latitude: 51.5, Longitude: -0.12, Timezone: "Europe/London", CountryCode: "GB"}
+	if _, err := official.covering(ctx, london); err != nil {
+		t.Fatalf("a shared load must not fail with its first caller's context: %v", err)
+	}
+}
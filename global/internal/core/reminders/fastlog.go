@@ -0,0 +1,74 @@
+package reminders
+
+import (
+	"time"
+
+	"github.com/go-telegram/bot/models"
+
+	"github.com/escalopa/prayer-bot/global/internal/core/fasting"
+	"github.com/escalopa/prayer-bot/global/internal/core/hijri"
+	"github.com/escalopa/prayer-bot/global/internal/core/i18n"
+	"github.com/escalopa/prayer-bot/global/internal/domain"
+)
+
+// FastCallbackPrefix starts the data of the fasting log buttons,
+// fast:<status>:<YYYY-MM-DD>. The Telegram adapter answers them and redraws
+// the keyboard with FastLogKeyboard.
+const FastCallbackPrefix = "fast:"
+
+// FastLogKeyboard is the row of buttons that logs a day as fasted, made up or
+// not fasted, with chosen marking what is already logged. A Ramadan day has
+// no make-up button.
+func FastLogKeyboard(date time.Time, ramadan bool, chosen domain.FastStatus, locale i18n.Locale) *models.InlineKeyboardMarkup {
+	options := []struct {
+		status domain.FastStatus
+		emoji  string
+		label  string
+	}{
+		{domain.FastKept, "🤲", "fasts_fasting"},
+		{domain.FastMakeUp, "🔁", "fasts_makeup"},
+		{domain.FastMissed, "✖️", "fasts_not_today"},
+	}
+	var row []models.InlineKeyboardButton
+	for _, option := range options {
+		if ramadan && option.status == domain.FastMakeUp {
+			continue
+		}
+		emoji := option.emoji
+		if option.status == chosen {
+			emoji = "✅"
+		}
+		row = append(row, models.InlineKeyboardButton{
+			Text:         emoji + " " + locale.Message(option.label),
+			CallbackData: FastCallbackPrefix + string(option.status) + ":" + date.Format(time.DateOnly),
+		})
+	}
+	return &models.InlineKeyboardMarkup{InlineKeyboard: [][]models.InlineKeyboardButton{row}}
+}
+
+// fastLogMarkup returns the log buttons for the day a suhoor or fasting
+// reminder is about, or nil. Only private chats keep a log, and a day on
+// which fasting is prohibited gets no buttons.
+func fastLogMarkup(rule domain.ReminderRule, schedule domain.ReminderSchedule, profile domain.PrayerProfile, chat domain.Chat, locale i18n.Locale) models.ReplyMarkup {
+	switch rule.Kind {
+	case domain.ReminderSuhoor, domain.ReminderWeeklyFasting, domain.ReminderWhiteDays, domain.ReminderOccasionFasting:
+	default:
+		return nil
+	}
+	if chat.IsGroup() {
+		return nil
+	}
+	date, err := time.Parse(time.DateOnly, schedule.LocalDate)
+	if err != nil {
+		return nil
+	}
+	calendar, err := hijri.ForProfile(profile)
+	if err != nil {
+		return nil
+	}
+	day, err := fasting.On(date, calendar, profile.CountryCode)
+	if err != nil || day.Status == fasting.StatusProhibited {
+		return nil
+	}
+	return FastLogKeyboard(date, day.Status == fasting.StatusObligatory, "", locale)
+}
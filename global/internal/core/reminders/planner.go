@@ -2,17 +2,25 @@ package reminders
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/escalopa/prayer-bot/global/internal/core/fasting"
 	"github.com/escalopa/prayer-bot/global/internal/core/hijri"
 	"github.com/escalopa/prayer-bot/global/internal/core/occasions"
 	"github.com/escalopa/prayer-bot/global/internal/domain"
 	"github.com/escalopa/prayer-bot/global/internal/port"
 )
 
+// ErrNothingToPlan is returned for a rule that has nothing to remind of,
+// such as a personal events reminder in a chat without events or a Jumu'ah
+// reminder without a khutbah time. RebuildChat leaves such a rule
+// unscheduled until the chat adds one.
+var ErrNothingToPlan = errors.New("the rule has nothing to remind of")
+
 type PlanningStore interface {
 	Profile(context.Context, int64) (domain.PrayerProfile, error)
 	EnabledRules(context.Context, int64) ([]domain.ReminderRule, error)
@@ -39,6 +47,9 @@ func (p *Planner) RebuildChat(ctx context.Context, chatID int64, after time.Time
 	}
 	for _, rule := range rules {
 		next, err := p.Next(ctx, profile, rule, after)
+		if errors.Is(err, ErrNothingToPlan) {
+			continue
+		}
 		if err != nil {
 			return fmt.Errorf("plan rule %d: %w", rule.ID, err)
 		}
@@ -50,7 +61,7 @@ func (p *Planner) RebuildChat(ctx context.Context, chatID int64, after time.Time
 }
 
 func (p *Planner) Next(ctx context.Context, profile domain.PrayerProfile, rule domain.ReminderRule, after time.Time) (domain.ReminderSchedule, error) {
-	location, err := time.LoadLocation(profile.Timezone)
+	location, err := domain.LoadLocation(profile.Timezone)
 	if err != nil {
 		return domain.ReminderSchedule{}, err
 	}
@@ -63,8 +74,23 @@ func (p *Planner) Next(ctx context.Context, profile domain.PrayerProfile, rule d
 	if rule.Kind.Occasion() {
 		return nextOccasion(profile, rule, after, location)
 	}
+	if rule.Kind.Ramadan() {
+		return p.nextRamadan(ctx, profile, rule, after, location)
+	}
+	if rule.Kind == domain.ReminderJumuah {
+		return nextJumuah(profile, rule, after, location)
+	}
+	if rule.Kind == domain.ReminderCustom {
+		return p.nextCustom(ctx, profile, rule, after, location)
+	}
 	localAfter := after.In(location)
-	for dayOffset := 0; dayOffset < 8; dayOffset++ {
+	first := 0
+	if rule.Kind == domain.ReminderExtendedTime {
+		// Midnight and the last third belong to the day the night starts on,
+		// so after local midnight the pending one comes from yesterday.
+		first = -1
+	}
+	for dayOffset := first; dayOffset < 8; dayOffset++ {
 		date := localAfter.AddDate(0, 0, dayOffset)
 		schedule, err := p.calculator.Day(ctx, date, profile)
 		if err != nil {
@@ -100,6 +126,197 @@ func (p *Planner) Next(ctx context.Context, profile domain.PrayerProfile, rule d
 	return domain.ReminderSchedule{}, fmt.Errorf("no valid occurrence found in the next eight days")
 }
 
+// nextRamadan finds the next suhoor or iftar reminder. The Hijri month is
+// checked before any prayer time is calculated, so scanning the rest of the
+// year outside Ramadan stays cheap.
+func (p *Planner) nextRamadan(ctx context.Context, profile domain.PrayerProfile, rule domain.ReminderRule, after time.Time, location *time.Location) (domain.ReminderSchedule, error) {
+	calendar, err := hijri.ForProfile(profile)
+	if err != nil {
+		return domain.ReminderSchedule{}, err
+	}
+	localAfter := after.In(location)
+	for dayOffset := 0; dayOffset < 400; dayOffset++ {
+		candidate := localAfter.AddDate(0, 0, dayOffset)
+		noon := time.Date(candidate.Year(), candidate.Month(), candidate.Day(), 12, 0, 0, 0, location)
+		date, err := calendar.Date(noon)
+		if err != nil {
+			return domain.ReminderSchedule{}, err
+		}
+		if date.Month != hijri.Ramadan {
+			continue
+		}
+		schedule, err := p.calculator.Day(ctx, noon, profile)
+		if err != nil {
+			return domain.ReminderSchedule{}, err
+		}
+		prayerAt, ok := schedule.At(rule.Prayer)
+		if !ok {
+			continue
+		}
+		nextRun := prayerAt.Add(-time.Duration(rule.OffsetMinutes) * time.Minute)
+		if !nextRun.After(after) {
+			continue
+		}
+		return domain.ReminderSchedule{
+			RuleID: rule.ID, ChatID: rule.ChatID, ProfileVersion: profile.Version,
+			LocalDate: noon.Format("2006-01-02"), PrayerAt: prayerAt,
+			NextRunAt: nextRun.UTC(), State: "pending",
+		}, nil
+	}
+	return domain.ReminderSchedule{}, fmt.Errorf("no day of Ramadan found in the next 400 days")
+}
+
+// nextCustom finds the next day the reminder's recurrence names whose time is
+// still ahead. Days are matched before any prayer time is calculated, so a
+// monthly Hijri reminder scans cheaply. A one-off reminder whose time has
+// passed has nothing left to plan.
+func (p *Planner) nextCustom(ctx context.Context, profile domain.PrayerProfile, rule domain.ReminderRule, after time.Time, location *time.Location) (domain.ReminderSchedule, error) {
+	custom := rule.Custom
+	if custom == nil {
+		return domain.ReminderSchedule{}, fmt.Errorf("custom reminder rule %d has no reminder", rule.ID)
+	}
+	plan := func(day time.Time) (domain.ReminderSchedule, bool, error) {
+		prayerAt, nextRun, ok, err := p.customRun(ctx, profile, *custom, day, location)
+		if err != nil || !ok || !nextRun.After(after) {
+			return domain.ReminderSchedule{}, false, err
+		}
+		return domain.ReminderSchedule{
+			RuleID: rule.ID, ChatID: rule.ChatID, ProfileVersion: profile.Version,
+			LocalDate: day.Format("2006-01-02"), PrayerAt: prayerAt,
+			NextRunAt: nextRun.UTC(), State: "pending",
+		}, true, nil
+	}
+	if custom.Recurrence == domain.RecurrenceOnce {
+		day, err := time.ParseInLocation(time.DateOnly, custom.Date, location)
+		if err != nil {
+			return domain.ReminderSchedule{}, err
+		}
+		schedule, ok, err := plan(day)
+		if err == nil && !ok {
+			err = ErrNothingToPlan
+		}
+		return schedule, err
+	}
+	var calendar hijri.Calendar
+	if custom.Recurrence == domain.RecurrenceHijriMonthly {
+		var err error
+		if calendar, err = hijri.ForProfile(profile); err != nil {
+			return domain.ReminderSchedule{}, err
+		}
+	}
+	localAfter := after.In(location)
+	first := 0
+	if custom.Anchored() {
+		// An offset after a late prayer can run past midnight, so the
+		// pending reminder may still belong to yesterday.
+		first = -1
+	}
+	for dayOffset := first; dayOffset < 400; dayOffset++ {
+		candidate := localAfter.AddDate(0, 0, dayOffset)
+		day := time.Date(candidate.Year(), candidate.Month(), candidate.Day(), 0, 0, 0, 0, location)
+		matched, err := customDay(*custom, day, calendar)
+		if err != nil {
+			return domain.ReminderSchedule{}, err
+		}
+		if !matched {
+			continue
+		}
+		schedule, ok, err := plan(day)
+		if err != nil {
+			return domain.ReminderSchedule{}, err
+		}
+		if ok {
+			return schedule, nil
+		}
+	}
+	return domain.ReminderSchedule{}, fmt.Errorf("no day for the custom reminder found in the next 400 days")
+}
+
+// CustomUpcoming reports whether the reminder will still fire after now. A
+// one-off reminder whose time has passed will not, so it is not worth saving.
+func CustomUpcoming(ctx context.Context, calculator port.Calculator, profile domain.PrayerProfile, reminder domain.CustomReminder, now time.Time) (bool, error) {
+	planner := &Planner{calculator: calculator}
+	_, err := planner.Next(ctx, profile, domain.ReminderRule{
+		ChatID: reminder.ChatID, Kind: domain.ReminderCustom, Enabled: true, Custom: &reminder,
+	}, now)
+	if errors.Is(err, ErrNothingToPlan) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// customDay reports whether the reminder's recurrence names the local day.
+func customDay(custom domain.CustomReminder, day time.Time, calendar hijri.Calendar) (bool, error) {
+	switch custom.Recurrence {
+	case domain.RecurrenceDaily:
+		return true, nil
+	case domain.RecurrenceWeekly:
+		return custom.Weekdays.Has(day.Weekday()), nil
+	case domain.RecurrenceHijriMonthly:
+		noon := day.Add(12 * time.Hour)
+		date, err := calendar.Date(noon)
+		if err != nil || date.Day == custom.HijriDay {
+			return err == nil, err
+		}
+		if custom.HijriDay != 30 || date.Day != 29 {
+			return false, nil
+		}
+		// A month of 29 days keeps the 30th on its last day.
+		tomorrow, err := calendar.Date(noon.AddDate(0, 0, 1))
+		return err == nil && tomorrow.Day == 1, err
+	default:
+		return false, fmt.Errorf("unsupported recurrence %q", custom.Recurrence)
+	}
+}
+
+// customRun is when the reminder fires on the local day, with the prayer or
+// clock time it follows. A day without the anchor prayer has no reminder.
+func (p *Planner) customRun(ctx context.Context, profile domain.PrayerProfile, custom domain.CustomReminder, day time.Time, location *time.Location) (time.Time, time.Time, bool, error) {
+	if !custom.Anchored() {
+		hour, minute, err := parseLocalTime(custom.LocalTime)
+		if err != nil {
+			return time.Time{}, time.Time{}, false, err
+		}
+		at := time.Date(day.Year(), day.Month(), day.Day(), hour, minute, 0, 0, location)
+		return at, at, true, nil
+	}
+	schedule, err := p.calculator.Day(ctx, day.Add(12*time.Hour), profile)
+	if err != nil {
+		return time.Time{}, time.Time{}, false, err
+	}
+	prayerAt, ok := schedule.At(custom.Anchor)
+	if !ok {
+		return time.Time{}, time.Time{}, false, nil
+	}
+	return prayerAt, prayerAt.Add(time.Duration(custom.OffsetMinutes) * time.Minute), true, nil
+}
+
+// nextJumuah finds the next Friday whose khutbah is still more than the lead
+// time away. The times are the mosque's own, so no calculation is needed.
+func nextJumuah(profile domain.PrayerProfile, rule domain.ReminderRule, after time.Time, location *time.Location) (domain.ReminderSchedule, error) {
+	if !profile.Jumuah.Enabled() {
+		return domain.ReminderSchedule{}, ErrNothingToPlan
+	}
+	localAfter := after.In(location)
+	for dayOffset := 0; dayOffset < 15; dayOffset++ {
+		candidate := localAfter.AddDate(0, 0, dayOffset)
+		khutbah, _, ok := profile.Jumuah.On(candidate)
+		if !ok {
+			continue
+		}
+		nextRun := khutbah.Add(-time.Duration(rule.OffsetMinutes) * time.Minute)
+		if !nextRun.After(after) {
+			continue
+		}
+		return domain.ReminderSchedule{
+			RuleID: rule.ID, ChatID: rule.ChatID, ProfileVersion: profile.Version,
+			LocalDate: khutbah.Format("2006-01-02"), PrayerAt: khutbah,
+			NextRunAt: nextRun.UTC(), State: "pending",
+		}, nil
+	}
+	return domain.ReminderSchedule{}, fmt.Errorf("no Friday found in the next fifteen days")
+}
+
 func nextOccasion(profile domain.PrayerProfile, rule domain.ReminderRule, after time.Time, location *time.Location) (domain.ReminderSchedule, error) {
 	category, ok := occasionCategory(rule.Kind)
 	if !ok {
@@ -109,31 +326,50 @@ func nextOccasion(profile domain.PrayerProfile, rule domain.ReminderRule, after
 	if err != nil {
 		return domain.ReminderSchedule{}, err
 	}
+	calendar, err := hijri.ForProfile(profile)
+	if err != nil {
+		return domain.ReminderSchedule{}, err
+	}
+	var personal []occasions.Definition
+	if category == occasions.CategoryPersonal {
+		if len(profile.PersonalEvents) == 0 {
+			return domain.ReminderSchedule{}, ErrNothingToPlan
+		}
+		personal = occasions.Personal(profile.PersonalEvents)
+	}
 	localAfter := after.In(location)
-	upcoming, err := occasions.Between(localAfter, 400, profile.HijriAdjustment)
+	upcoming, err := occasions.Between(localAfter, 400, calendar, profile.CountryCode, personal...)
 	if err != nil {
 		return domain.ReminderSchedule{}, err
 	}
+	// A night is reminded of on the evening it begins and a day on the evening
+	// before, so a night can come up a day earlier than a day listed before it.
+	// The schedule's date is the day after the evening either way.
+	var schedule domain.ReminderSchedule
 	for _, occurrence := range upcoming {
-		if occurrence.Definition.Category != category {
+		if occurrence.Definition.Category != category || !occurrence.Reminded() {
 			continue
 		}
-		target := time.Date(
-			occurrence.Date.Year(), occurrence.Date.Month(), occurrence.Date.Day(),
-			0, 0, 0, 0, location,
-		)
-		previous := target.AddDate(0, 0, -1)
-		nextRun := time.Date(previous.Year(), previous.Month(), previous.Day(), hour, minute, 0, 0, location)
+		eve := occurrence.Eve()
+		nextRun := time.Date(eve.Year(), eve.Month(), eve.Day(), hour, minute, 0, 0, location)
 		if !nextRun.After(after) {
 			continue
 		}
-		return domain.ReminderSchedule{
+		if !schedule.NextRunAt.IsZero() && !nextRun.Before(schedule.NextRunAt) {
+			continue
+		}
+		target := time.Date(eve.Year(), eve.Month(), eve.Day()+1, 0, 0, 0, 0, location)
+		schedule = domain.ReminderSchedule{
 			RuleID: rule.ID, ChatID: rule.ChatID, ProfileVersion: profile.Version,
 			LocalDate: target.Format("2006-01-02"), PrayerAt: target,
-			NextRunAt: nextRun.UTC(), State: "pending",
-		}, nil
+			NextRunAt: nextRun, State: "pending",
+		}
+	}
+	if schedule.NextRunAt.IsZero() {
+		return domain.ReminderSchedule{}, fmt.Errorf("no valid occasion found in the next 400 days")
 	}
-	return domain.ReminderSchedule{}, fmt.Errorf("no valid occasion found in the next 400 days")
+	schedule.NextRunAt = schedule.NextRunAt.UTC()
+	return schedule, nil
 }
 
 func occasionCategory(kind domain.ReminderKind) (occasions.Category, bool) {
@@ -144,6 +380,8 @@ func occasionCategory(kind domain.ReminderKind) (occasions.Category, bool) {
 		return occasions.CategoryFasting, true
 	case domain.ReminderOccasionObserved:
 		return occasions.CategoryObserved, true
+	case domain.ReminderPersonalEvents:
+		return occasions.CategoryPersonal, true
 	default:
 		return "", false
 	}
@@ -154,6 +392,10 @@ func nextWeekly(profile domain.PrayerProfile, rule domain.ReminderRule, after ti
 	if err != nil {
 		return domain.ReminderSchedule{}, err
 	}
+	calendar, err := hijri.ForProfile(profile)
+	if err != nil {
+		return domain.ReminderSchedule{}, err
+	}
 	localAfter := after.In(location)
 	for dayOffset := 0; dayOffset < 15; dayOffset++ {
 		candidate := localAfter.AddDate(0, 0, dayOffset)
@@ -164,6 +406,11 @@ func nextWeekly(profile domain.PrayerProfile, rule domain.ReminderRule, after ti
 			if target.Weekday() != time.Monday && target.Weekday() != time.Thursday {
 				continue
 			}
+			if skip, err := prohibited(target, calendar, profile.CountryCode); err != nil {
+				return domain.ReminderSchedule{}, err
+			} else if skip {
+				continue
+			}
 			previous := target.AddDate(0, 0, -1)
 			nextRun = time.Date(previous.Year(), previous.Month(), previous.Day(), hour, minute, 0, 0, location)
 		case domain.ReminderWeeklyKahf:
@@ -191,25 +438,35 @@ func nextWeekly(profile domain.PrayerProfile, rule domain.ReminderRule, after ti
 }
 
 // nextWhiteDays finds the next 13th, 14th, or 15th Hijri day (Ayyam al-Bid)
-// using the profile's moon-sighting correction and schedules the reminder for
-// the rule's local time on the preceding evening, mirroring the weekly fasting
-// reminder. A 40-day scan safely covers any 29/30-day Hijri month plus margin.
+// in the profile's calendar and schedules the reminder for the rule's local
+// time on the preceding evening, mirroring the weekly fasting reminder. A
+// 40-day scan safely covers any 29/30-day Hijri month plus margin. The 13th
+// of Dhu al-Hijjah is a day of Tashreeq and is skipped.
 func nextWhiteDays(profile domain.PrayerProfile, rule domain.ReminderRule, after time.Time, location *time.Location) (domain.ReminderSchedule, error) {
 	hour, minute, err := parseLocalTime(rule.LocalTime)
 	if err != nil {
 		return domain.ReminderSchedule{}, err
 	}
+	calendar, err := hijri.ForProfile(profile)
+	if err != nil {
+		return domain.ReminderSchedule{}, err
+	}
 	localAfter := after.In(location)
 	for dayOffset := 0; dayOffset < 40; dayOffset++ {
 		candidate := localAfter.AddDate(0, 0, dayOffset)
 		target := time.Date(candidate.Year(), candidate.Month(), candidate.Day(), 0, 0, 0, 0, location)
-		date, err := hijri.FromGregorian(target, profile.HijriAdjustment)
+		date, err := calendar.Date(target)
 		if err != nil {
 			return domain.ReminderSchedule{}, err
 		}
 		if date.Day < 13 || date.Day > 15 {
 			continue
 		}
+		if skip, err := prohibited(target, calendar, profile.CountryCode); err != nil {
+			return domain.ReminderSchedule{}, err
+		} else if skip {
+			continue
+		}
 		previous := target.AddDate(0, 0, -1)
 		nextRun := time.Date(previous.Year(), previous.Month(), previous.Day(), hour, minute, 0, 0, location)
 		if !nextRun.After(after) {
@@ -224,6 +481,16 @@ func nextWhiteDays(profile domain.PrayerProfile, rule domain.ReminderRule, after
 	return domain.ReminderSchedule{}, fmt.Errorf("no white day found in the next forty days")
 }
 
+// prohibited reports whether the fasting calendar forbids fasting on the
+// date, so the fasting reminders pass over an Eid or the days of Tashreeq.
+func prohibited(date time.Time, calendar hijri.Calendar, country string) (bool, error) {
+	day, err := fasting.On(date, calendar, country)
+	if err != nil {
+		return false, err
+	}
+	return day.Status == fasting.StatusProhibited, nil
+}
+
 func parseLocalTime(value string) (int, int, error) {
 	parts := strings.Split(value, ":")
 	if len(parts) != 2 {
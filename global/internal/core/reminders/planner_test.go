@@ -2,11 +2,13 @@ package reminders
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
 	"github.com/escalopa/prayer-bot/global/internal/core/hijri"
 	"github.com/escalopa/prayer-bot/global/internal/core/occasions"
+	"github.com/escalopa/prayer-bot/global/internal/core/prayertime"
 	"github.com/escalopa/prayer-bot/global/internal/domain"
 )
 
@@ -78,7 +80,11 @@ func TestNextIslamicOccasionUsesCorrectedHijriDateAndPreviousEvening(t *testing.
 	rule := domain.ReminderRule{
 		ID: 10, ChatID: 20, Kind: domain.ReminderOccasionFasting, LocalTime: "20:00",
 	}
-	occurrence, err := occasions.Next(after, profile.HijriAdjustment, occasions.CategoryFasting)
+	calendar, err := hijri.ForProfile(profile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	occurrence, err := occasions.Next(after, calendar, "", occasions.CategoryFasting)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -99,6 +105,51 @@ func TestNextIslamicOccasionUsesCorrectedHijriDateAndPreviousEvening(t *testing.
 	}
 }
 
+func TestNextOccasionRemindsOfEachDayOnlyWhereTheObservanceAsks(t *testing.T) {
+	location, _ := time.LoadLocation("Africa/Cairo")
+	profile := domain.PrayerProfile{Timezone: "Africa/Cairo", Version: 5}
+	calendar, err := hijri.ForProfile(profile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	upcoming, err := occasions.Between(time.Date(2026, 1, 1, 12, 0, 0, 0, location), 354, calendar, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	first := map[string]time.Time{}
+	for _, occurrence := range upcoming {
+		if _, ok := first[occurrence.Definition.ID]; !ok {
+			first[occurrence.Definition.ID] = occurrence.Date
+		}
+	}
+	for _, tc := range []struct {
+		kind      domain.ReminderKind
+		id        string
+		wantAfter string
+	}{
+		// The second of the six days of Shawwal gets its own reminder.
+		{domain.ReminderOccasionFasting, "six_shawwal", "six_shawwal"},
+		// The first ten days of Dhu al-Hijjah are reminded of once, so the
+		// next major reminder is for Eid al-Adha.
+		{domain.ReminderOccasionMajor, "dhul_hijjah_start", "eid_adha"},
+	} {
+		start := first[tc.id]
+		eve := time.Date(start.Year(), start.Month(), start.Day()-1, 20, 1, 0, 0, location)
+		rule := domain.ReminderRule{ID: 10, ChatID: 20, Kind: tc.kind, LocalTime: "20:00"}
+		next, err := (&Planner{}).Next(context.Background(), profile, rule, eve)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := first[tc.wantAfter]
+		if tc.wantAfter == tc.id {
+			want = start.AddDate(0, 0, 1)
+		}
+		if next.LocalDate != want.Format("2006-01-02") {
+			t.Fatalf("after %s began, next %s reminder is for %s, want %s", tc.id, tc.kind, next.LocalDate, want.Format("2006-01-02"))
+		}
+	}
+}
+
 func TestNextWhiteDaysReminderUsesPreviousEveningOfHijri13to15(t *testing.T) {
 	location, _ := time.LoadLocation("Africa/Cairo")
 	after := time.Date(2026, 7, 17, 12, 0, 0, 0, location)
@@ -114,7 +165,11 @@ func TestNextWhiteDaysReminderUsesPreviousEveningOfHijri13to15(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	date, err := hijri.FromGregorian(target, 0)
+	calendar, err := hijri.ForProfile(profile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	date, err := calendar.Date(target)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -161,7 +216,11 @@ func TestNextWhiteDaysRespectsHijriAdjustment(t *testing.T) {
 		t.Fatalf("a +2 day Hijri correction must shift the white day, both were %s", base.LocalDate)
 	}
 	target, _ := time.ParseInLocation("2006-01-02", shifted.LocalDate, location)
-	date, err := hijri.FromGregorian(target, 2)
+	calendar, err := hijri.New(domain.HijriUmmAlQura, 0, 0, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	date, err := calendar.Date(target)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -169,3 +228,278 @@ func TestNextWhiteDaysRespectsHijriAdjustment(t *testing.T) {
 		t.Fatalf("corrected target %s is Hijri day %d, want 13-15", shifted.LocalDate, date.Day)
 	}
 }
+
+func TestFastingRemindersPassOverTheDaysOfTashreeq(t *testing.T) {
+	location, _ := time.LoadLocation("Africa/Cairo")
+	// 11 Dhu al-Hijjah 1447 is Thursday 28 May 2026 and the 13th is 30 May.
+	after := time.Date(2026, 5, 26, 12, 0, 0, 0, location)
+	planner := &Planner{}
+	profile := domain.PrayerProfile{Timezone: "Africa/Cairo"}
+	for _, tc := range []struct {
+		kind domain.ReminderKind
+		want string
+	}{
+		{domain.ReminderWeeklyFasting, "2026-06-01"},
+		{domain.ReminderWhiteDays, "2026-05-31"},
+	} {
+		rule := domain.ReminderRule{ID: 9, ChatID: 10, Kind: tc.kind, LocalTime: "20:00"}
+		next, err := planner.Next(context.Background(), profile, rule, after)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if next.LocalDate != tc.want {
+			t.Fatalf("%s reminds of %s, want %s", tc.kind, next.LocalDate, tc.want)
+		}
+	}
+}
+
+type lastThirdCalculator struct{}
+
+func (lastThirdCalculator) Day(_ context.Context, date time.Time, _ domain.PrayerProfile) (domain.DaySchedule, error) {
+	// The last third of each local day's night starts at 02:30 the next morning.
+	at := time.Date(date.Year(), date.Month(), date.Day()+1, 2, 30, 0, 0, date.Location())
+	return domain.DaySchedule{Times: map[domain.Prayer]time.Time{domain.PrayerLastThird: at}}, nil
+}
+
+func TestNextExtendedTimeAfterMidnightUsesTheNightThatAlreadyStarted(t *testing.T) {
+	location, _ := time.LoadLocation("Europe/Istanbul")
+	after := time.Date(2026, 7, 17, 1, 0, 0, 0, location)
+	planner := &Planner{calculator: lastThirdCalculator{}}
+	profile := domain.PrayerProfile{Timezone: "Europe/Istanbul"}
+	rule := domain.ReminderRule{ID: 4, ChatID: 10, Kind: domain.ReminderExtendedTime, Prayer: domain.PrayerLastThird}
+
+	next, err := planner.Next(context.Background(), profile, rule, after)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := time.Date(2026, 7, 17, 2, 30, 0, 0, location)
+	if !next.NextRunAt.Equal(want) || next.LocalDate != "2026-07-17" {
+		t.Fatalf("got %v on %s, want %v", next.NextRunAt.In(location), next.LocalDate, want)
+	}
+}
+
+// benchmarkProfiles spreads profiles a kilometre apart, so each needs its own
+// calculated year, as a busy sender's chats do. There are more of them than
+// the calculator's old fixed-size map held.
+func benchmarkProfiles(count int) []domain.PrayerProfile {
+	profiles := make([]domain.PrayerProfile, count)
+	for i := range profiles {
+		profiles[i] = domain.PrayerProfile{
+			ChatID: int64(i + 1), Latitude: 30 + float64(i)/100, Longitude: 31.236, Timezone: "Africa/Cairo",
+			Method: domain.MethodEgyptian, Madhab: domain.MadhabShafii, HighLatitudeRule: domain.HighLatitudeAngleBased,
+			Version: 1,
+		}
+	}
+	return profiles
+}
+
+func BenchmarkPlannerNextAcrossProfiles(b *testing.B) {
+	planner := NewPlanner(nil, prayertime.New())
+	profiles := benchmarkProfiles(300)
+	rule := domain.ReminderRule{ID: 1, Kind: domain.ReminderBefore, Prayer: domain.PrayerMaghrib, OffsetMinutes: 10, Enabled: true}
+	after := time.Date(2026, time.July, 16, 12, 0, 0, 0, time.UTC)
+	for _, profile := range profiles {
+		if _, err := planner.Next(context.Background(), profile, rule, after); err != nil {
+			b.Fatal(err)
+		}
+	}
+	for i := 0; b.Loop(); i++ {
+		if _, err := planner.Next(context.Background(), profiles[i%len(profiles)], rule, after); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestNextRunAtFollowsThePrecautionPolicyToTheMinute(t *testing.T) {
+	after := time.Date(2026, 3, 20, 0, 0, 0, 0, time.UTC)
+	planner := &Planner{calculator: prayertime.New()}
+	profile := domain.PrayerProfile{
+		Latitude: 55.796, Longitude: 49.106, Timezone: "Europe/Moscow",
+		Method: domain.MethodMWL, Madhab: domain.MadhabHanafi, HighLatitudeRule: domain.HighLatitudeAngleBased,
+		Precaution: domain.Precaution{IhtiyatMinutes: 2, Maghrib: domain.RoundUp},
+	}
+	rule := domain.ReminderRule{ID: 7, ChatID: 10, Kind: domain.ReminderAt, Prayer: domain.PrayerMaghrib}
+
+	next, err := planner.Next(context.Background(), profile, rule, after)
+	if err != nil {
+		t.Fatal(err)
+	}
+	schedule, err := prayertime.New().Day(context.Background(), after, profile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if next.NextRunAt.Second() != 0 || next.NextRunAt.Nanosecond() != 0 || !next.NextRunAt.Equal(schedule.Times[domain.PrayerMaghrib]) {
+		t.Fatalf("NextRunAt = %s, want the rounded Maghrib %s", next.NextRunAt, schedule.Times[domain.PrayerMaghrib])
+	}
+}
+
+func TestNextSuhoorWaitsForRamadanAndRunsBeforeFajr(t *testing.T) {
+	location, _ := time.LoadLocation("Africa/Cairo")
+	// Ramadan 1447 begins on 18 February 2026, so January has no suhoor.
+	after := time.Date(2026, 1, 10, 12, 0, 0, 0, location)
+	planner := &Planner{calculator: fixedCalculator{prayerAt: time.Date(2026, 1, 1, 4, 30, 0, 0, location)}}
+	profile := domain.PrayerProfile{Timezone: "Africa/Cairo", Version: 2}
+	rule := domain.ReminderRule{ID: 11, ChatID: 10, Kind: domain.ReminderSuhoor, Prayer: domain.PrayerFajr, OffsetMinutes: 45}
+
+	next, err := planner.Next(context.Background(), profile, rule, after)
+	if err != nil {
+		t.Fatal(err)
+	}
+	target, err := time.ParseInLocation("2006-01-02", next.LocalDate, location)
+	if err != nil {
+		t.Fatal(err)
+	}
+	calendar, err := hijri.ForProfile(profile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	date, err := calendar.Date(target)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if date.Month != hijri.Ramadan || date.Day != 1 {
+		t.Fatalf("first suhoor on %s is Hijri %d/%d, want 1 Ramadan", next.LocalDate, date.Day, date.Month)
+	}
+	if got := next.NextRunAt.In(location).Format("15:04"); got != "03:45" {
+		t.Fatalf("suhoor reminder at %s, want 03:45", got)
+	}
+}
+
+func TestNextJumuahRunsBeforeTheKhutbahOnFridaysOnly(t *testing.T) {
+	location, _ := time.LoadLocation("Europe/London")
+	profile := domain.PrayerProfile{Timezone: "Europe/London", Version: 4, Jumuah: domain.Jumuah{Khutbah: "13:15"}}
+	rule := domain.ReminderRule{ID: 12, ChatID: 10, Kind: domain.ReminderJumuah, Prayer: domain.PrayerDhuhr, OffsetMinutes: 30}
+	planner := &Planner{}
+
+	// Friday 17 July 2026 after the reminder went out moves to the next week.
+	after := time.Date(2026, 7, 17, 12, 50, 0, 0, location)
+	next, err := planner.Next(context.Background(), profile, rule, after)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if next.LocalDate != "2026-07-24" || !next.NextRunAt.Equal(time.Date(2026, 7, 24, 12, 45, 0, 0, location)) ||
+		!next.PrayerAt.Equal(time.Date(2026, 7, 24, 13, 15, 0, 0, location)) || next.ProfileVersion != 4 {
+		t.Fatalf("unexpected Jumu'ah schedule: %+v", next)
+	}
+
+	profile.Jumuah = domain.Jumuah{}
+	if _, err := planner.Next(context.Background(), profile, rule, after); !errors.Is(err, ErrNothingToPlan) {
+		t.Fatalf("a Jumu'ah rule without a khutbah time has nothing to plan, got %v", err)
+	}
+}
+
+func TestRebuildChatSkipsAJumuahRuleWhoseKhutbahWasCleared(t *testing.T) {
+	after := time.Date(2026, 7, 15, 12, 0, 0, 0, time.UTC)
+	store := &fakePlanningStore{
+		profile: domain.PrayerProfile{Timezone: "Europe/London", Version: 5},
+		rules: []domain.ReminderRule{
+			{ID: 1, ChatID: 20, Kind: domain.ReminderJumuah, Prayer: domain.PrayerDhuhr, OffsetMinutes: 30},
+			{ID: 2, ChatID: 20, Kind: domain.ReminderWeeklyKahf, LocalTime: "09:00"},
+		},
+	}
+	if err := NewPlanner(store, nil).RebuildChat(context.Background(), 20, after); err != nil {
+		t.Fatalf("a cleared khutbah time must not stop the chat's other rules: %v", err)
+	}
+	if len(store.saved) != 1 || store.saved[0].RuleID != 2 {
+		t.Fatalf("saved = %+v, want only the Al-Kahf rule", store.saved)
+	}
+}
+
+type fakePlanningStore struct {
+	profile domain.PrayerProfile
+	rules   []domain.ReminderRule
+	saved   []domain.ReminderSchedule
+}
+
+func (f *fakePlanningStore) Profile(context.Context, int64) (domain.PrayerProfile, error) {
+	return f.profile, nil
+}
+
+func (f *fakePlanningStore) EnabledRules(context.Context, int64) ([]domain.ReminderRule, error) {
+	return f.rules, nil
+}
+
+func (f *fakePlanningStore) UpsertSchedule(_ context.Context, schedule domain.ReminderSchedule) (domain.ReminderSchedule, error) {
+	f.saved = append(f.saved, schedule)
+	return schedule, nil
+}
+
+func TestPersonalEventsReminderRunsTheEveningBeforeAndWaitsForAnEvent(t *testing.T) {
+	location, _ := time.LoadLocation("Europe/Istanbul")
+	after := time.Date(2026, time.July, 17, 12, 0, 0, 0, location)
+	store := &fakePlanningStore{
+		profile: domain.PrayerProfile{Timezone: "Europe/Istanbul", Version: 3},
+		rules: []domain.ReminderRule{
+			{ID: 1, ChatID: 20, Kind: domain.ReminderWeeklyKahf, LocalTime: "08:00"},
+			{ID: 2, ChatID: 20, Kind: domain.ReminderPersonalEvents, LocalTime: "20:00"},
+		},
+	}
+	planner := NewPlanner(store, nil)
+	if err := planner.RebuildChat(context.Background(), 20, after); err != nil {
+		t.Fatalf("a chat without events must still plan its other rules: %v", err)
+	}
+	if len(store.saved) != 1 || store.saved[0].RuleID != 1 {
+		t.Fatalf("saved = %+v, want only the Al-Kahf rule", store.saved)
+	}
+
+	store.profile.PersonalEvents = []domain.PersonalEvent{
+		{ID: 4, ChatID: 20, Title: "Wedding", Month: 9, Day: 3},
+		{ID: 5, ChatID: 20, Title: "Hijri birthday", Hijri: true, Month: 3, Day: 12},
+	}
+	next, err := planner.Next(context.Background(), store.profile, store.rules[1], after)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 12 Rabi al-Awwal 1448 is 25 August 2026 in Umm al-Qura, before the
+	// wedding on 3 September.
+	if next.LocalDate != "2026-08-25" || !next.NextRunAt.Equal(time.Date(2026, time.August, 24, 20, 0, 0, 0, location).UTC()) {
+		t.Fatalf("next = %+v", next)
+	}
+}
+
+func TestNextCustomReminderFollowsItsRecurrence(t *testing.T) {
+	location, _ := time.LoadLocation("Africa/Cairo")
+	after := time.Date(2026, time.October, 16, 12, 0, 0, 0, location) // Friday
+	planner := &Planner{calculator: fixedCalculator{prayerAt: time.Date(2026, 10, 16, 5, 0, 0, 0, location)}}
+	profile := domain.PrayerProfile{Timezone: "Africa/Cairo", Version: 2}
+	cases := []struct {
+		name        string
+		custom      domain.CustomReminder
+		after       time.Time
+		date, runAt string
+	}{
+		{"daily clock", domain.CustomReminder{Text: "Witr", LocalTime: "21:30", Recurrence: domain.RecurrenceDaily},
+			after, "2026-10-16", "2026-10-16 21:30"},
+		{"weekdays after Fajr", domain.CustomReminder{
+			Text: "Adhkar", Anchor: domain.PrayerFajr, OffsetMinutes: 20,
+			Recurrence: domain.RecurrenceWeekly, Weekdays: domain.WeekdaysOf(time.Monday, time.Thursday),
+		}, after, "2026-10-19", "2026-10-19 05:20"},
+		// Jumada al-Akhirah 1448 has 29 days, so its 30th is 9 December.
+		{"30th of a short Hijri month", domain.CustomReminder{
+			Text: "Sadaqah", LocalTime: "09:00", Recurrence: domain.RecurrenceHijriMonthly, HijriDay: 30,
+		}, time.Date(2026, time.November, 11, 0, 0, 0, 0, location), "2026-12-09", "2026-12-09 09:00"},
+		{"one-off before Fajr", domain.CustomReminder{
+			Text: "Suhoor", Anchor: domain.PrayerFajr, OffsetMinutes: -45, Recurrence: domain.RecurrenceOnce, Date: "2027-01-02",
+		}, after, "2027-01-02", "2027-01-02 04:15"},
+	}
+	for _, test := range cases {
+		rule := domain.ReminderRule{ID: 9, ChatID: 10, Kind: domain.ReminderCustom, Custom: &test.custom}
+		next, err := planner.Next(context.Background(), profile, rule, test.after)
+		if err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+		if next.LocalDate != test.date || next.NextRunAt.In(location).Format("2006-01-02 15:04") != test.runAt {
+			t.Errorf("%s: next = %s at %s, want %s at %s", test.name,
+				next.LocalDate, next.NextRunAt.In(location).Format("2006-01-02 15:04"), test.date, test.runAt)
+		}
+	}
+
+	once := domain.CustomReminder{Text: "Doctor", LocalTime: "09:00", Recurrence: domain.RecurrenceOnce, Date: "2026-10-16"}
+	rule := domain.ReminderRule{ID: 9, ChatID: 10, Kind: domain.ReminderCustom, Custom: &once}
+	if _, err := planner.Next(context.Background(), profile, rule, after); !errors.Is(err, ErrNothingToPlan) {
+		t.Fatalf("a passed one-off reminder planned again: %v", err)
+	}
+	if upcoming, err := CustomUpcoming(context.Background(), planner.calculator, profile, once, after); err != nil || upcoming {
+		t.Fatalf("CustomUpcoming = %v, %v; want false", upcoming, err)
+	}
+}
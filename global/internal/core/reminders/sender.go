@@ -2,6 +2,7 @@ package reminders
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"html"
 	"strings"
@@ -35,12 +36,16 @@ type SenderStore interface {
 	Chat(context.Context, int64) (domain.Chat, error)
 	CompleteDelivery(context.Context, domain.DeliveryTask, int64, domain.ReminderSchedule, string, time.Time) (int64, error)
 	ClearNotificationMessage(context.Context, int64, int64) error
+	AcquireAnnouncement(context.Context, string, int64) (bool, error)
+	CompleteAnnouncement(context.Context, string, int64) error
+	FailAnnouncement(context.Context, string, error) error
 }
 
 // nextPlanner is satisfied by *Planner. It lets the Sender be tested without a
 // real prayer calculator or planning store.
 type nextPlanner interface {
 	Next(context.Context, domain.PrayerProfile, domain.ReminderRule, time.Time) (domain.ReminderSchedule, error)
+	RebuildChat(context.Context, int64, time.Time) error
 }
 
 const notificationLifetime = 36 * time.Hour
@@ -110,6 +115,7 @@ func (s *Sender) Process(ctx context.Context, task domain.DeliveryTask) error {
 		text := reminderText(rule, schedule, profile, locale)
 		message, err = s.bot.SendMessage(ctx, &botapi.SendMessageParams{
 			ChatID: task.ChatID, Text: text, ParseMode: models.ParseModeHTML,
+			ReplyMarkup: fastLogMarkup(rule, schedule, profile, chat, locale),
 		})
 	}
 	if err != nil {
@@ -126,6 +132,10 @@ func (s *Sender) Process(ctx context.Context, task domain.DeliveryTask) error {
 		return fail(cause)
 	}
 	next, err := s.planner.Next(ctx, profile, rule, task.ScheduledFor.Add(time.Second))
+	if errors.Is(err, ErrNothingToPlan) {
+		// A one-off reminder was the last; a zero next finishes its schedule.
+		next, err = domain.ReminderSchedule{}, nil
+	}
 	if err != nil {
 		return failAfterSend(fmt.Errorf("plan next reminder: %w", err))
 	}
@@ -166,6 +176,73 @@ func (s *Sender) Delete(ctx context.Context, task domain.MessageDeletionTask) er
 	return nil
 }
 
+// Announce re-plans a chat after the Hijri announcements for its country
+// changed and, when the task asks for it, tells the chat the month began. A
+// chat that has since moved to another country is re-planned but not told.
+func (s *Sender) Announce(ctx context.Context, task domain.HijriAnnouncementTask) error {
+	if task.TaskKey == "" || task.ChatID == 0 {
+		return fmt.Errorf("invalid Hijri announcement task")
+	}
+	err := s.planner.RebuildChat(ctx, task.ChatID, s.now())
+	if domain.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("re-plan after Hijri announcement: %w", err)
+	}
+	if !task.Notify {
+		return nil
+	}
+	start, err := time.Parse(time.DateOnly, task.StartsOn)
+	if err != nil {
+		return fmt.Errorf("invalid Hijri announcement date %q", task.StartsOn)
+	}
+	profile, err := s.store.Profile(ctx, task.ChatID)
+	if err != nil {
+		return fmt.Errorf("load profile: %w", err)
+	}
+	if profile.CountryCode != task.CountryCode {
+		return nil
+	}
+	chat, err := s.store.Chat(ctx, task.ChatID)
+	if err != nil {
+		return fmt.Errorf("load chat language: %w", err)
+	}
+	locale := i18n.Resolve(chat.LanguageCode)
+	// The notice is recorded like a reminder delivery, so a retried task
+	// whose notice already went out sends nothing.
+	acquired, err := s.store.AcquireAnnouncement(ctx, task.TaskKey, task.ChatID)
+	if err != nil {
+		return err
+	}
+	if !acquired {
+		return nil
+	}
+	fail := func(cause error) error {
+		_ = s.store.FailAnnouncement(ctx, task.TaskKey, cause)
+		return cause
+	}
+	month := fmt.Sprintf("%s %d", locale.HijriMonth(task.HijriMonth), task.HijriYear)
+	date := fmt.Sprintf("%d %s %d", start.Day(), locale.Month(int(start.Month())), start.Year())
+	message, err := s.bot.SendMessage(ctx, &botapi.SendMessageParams{
+		ChatID:    task.ChatID,
+		Text:      fmt.Sprintf(locale.Message("hijri_announcement_notice"), html.EscapeString(month), html.EscapeString(date)),
+		ParseMode: models.ParseModeHTML,
+	})
+	if err != nil {
+		return fail(fmt.Errorf("Telegram announcement send failed"))
+	}
+	if err := s.store.CompleteAnnouncement(ctx, task.TaskKey, int64(message.ID)); err != nil {
+		// As in Process, the retry cannot know this notice went out, so it is
+		// deleted before the retryable error.
+		_, _ = s.bot.DeleteMessages(ctx, &botapi.DeleteMessagesParams{
+			ChatID: task.ChatID, MessageIDs: []int{message.ID},
+		})
+		return fail(fmt.Errorf("complete announcement: %w", err))
+	}
+	return nil
+}
+
 func notificationCategory(kind domain.ReminderKind) string {
 	switch kind {
 	case domain.ReminderWeeklyFasting, domain.ReminderWhiteDays:
@@ -178,6 +255,16 @@ func notificationCategory(kind domain.ReminderKind) string {
 		return "tomorrow"
 	case domain.ReminderOccasionMajor, domain.ReminderOccasionFasting, domain.ReminderOccasionObserved:
 		return "islamic_occasion"
+	case domain.ReminderPersonalEvents:
+		return "personal_event"
+	case domain.ReminderExtendedTime:
+		return "extended_time"
+	case domain.ReminderCustom:
+		return "custom"
+	case domain.ReminderSuhoor, domain.ReminderIftar:
+		// The iftar notice replaces the morning's suhoor notice, and the next
+		// suhoor notice replaces iftar.
+		return "ramadan"
 	default:
 		// Before-prayer and at-prayer messages intentionally share a slot.
 		// A pre-reminder replaces the previous prayer, and the arrival message
@@ -200,13 +287,36 @@ func reminderText(rule domain.ReminderRule, schedule domain.ReminderSchedule, pr
 		return fmt.Sprintf(locale.Message("reminder_before"), name, rule.OffsetMinutes, timeText)
 	case domain.ReminderTomorrow:
 		return fmt.Sprintf(locale.Message("reminder_tomorrow"), name, timeText)
+	case domain.ReminderExtendedTime:
+		return fmt.Sprintf(locale.Message("reminder_extended"), name, timeText)
+	case domain.ReminderSuhoor:
+		return fmt.Sprintf(locale.Message("reminder_suhoor"), rule.OffsetMinutes, timeText)
+	case domain.ReminderIftar:
+		return fmt.Sprintf(locale.Message("reminder_iftar"), timeText)
+	case domain.ReminderJumuah:
+		text := fmt.Sprintf(locale.Message("reminder_jumuah"), rule.OffsetMinutes, timeText)
+		if profile.Jumuah.Iqamah != "" {
+			text += fmt.Sprintf("\n%s <code>%s</code>", html.EscapeString(locale.Message("jumuah_iqamah")), profile.Jumuah.Iqamah)
+		}
+		return text
 	case domain.ReminderOccasionMajor, domain.ReminderOccasionFasting, domain.ReminderOccasionObserved:
 		return occasionReminderText(rule, schedule, profile, locale)
+	case domain.ReminderPersonalEvents:
+		return personalEventReminderText(schedule, profile, locale)
+	case domain.ReminderCustom:
+		if rule.Custom == nil {
+			return ""
+		}
+		return fmt.Sprintf(locale.Message("reminder_custom"), html.EscapeString(rule.Custom.Text))
 	default:
 		return fmt.Sprintf(locale.Message("reminder_at"), name)
 	}
 }
 
+// occasionReminderText describes each occasion of the rule's category that is
+// reminded of on the evening before the schedule's date, such as Eid al-Adha
+// and the last of the first ten days of Dhu al-Hijjah, or a night that begins
+// that evening.
 func occasionReminderText(rule domain.ReminderRule, schedule domain.ReminderSchedule, profile domain.PrayerProfile, locale i18n.Locale) string {
 	category, ok := occasionCategory(rule.Kind)
 	if !ok {
@@ -217,32 +327,83 @@ func occasionReminderText(rule domain.ReminderRule, schedule domain.ReminderSche
 	if err != nil {
 		return ""
 	}
-	occurrence, ok := occasions.OnDate(date, profile.HijriAdjustment, category)
-	if !ok {
+	calendar, err := hijri.ForProfile(profile)
+	if err != nil {
+		return ""
+	}
+	onEve, err := occasions.OnEve(date.AddDate(0, 0, -1), calendar, profile.CountryCode, category)
+	if err != nil {
 		return ""
 	}
-	copy := locale.Occasion(occurrence.Definition.ID)
 	var builder strings.Builder
-	fmt.Fprintf(&builder, "<b>%s %s</b>\n📅 %d %s %d\n\n%s\n\n💡 %s",
-		html.EscapeString(occurrence.Definition.Emoji),
-		html.EscapeString(copy.Title),
-		date.Day(), html.EscapeString(locale.Month(int(date.Month()))), date.Year(),
-		html.EscapeString(copy.Summary),
-		html.EscapeString(copy.Action),
-	)
-	if len(occurrence.Definition.Sources) > 0 {
-		builder.WriteString("\n\n📚 ")
-		for index, source := range occurrence.Definition.Sources {
-			if index > 0 {
-				builder.WriteString(" · ")
+	for _, occurrence := range onEve {
+		if !occurrence.Reminded() {
+			continue
+		}
+		if builder.Len() > 0 {
+			builder.WriteString("\n\n")
+		}
+		copy := locale.Occasion(occurrence.Definition.ID)
+		title := copy.Title
+		if day := locale.OccasionDay(occurrence); day != "" {
+			title += " · " + day
+		}
+		fmt.Fprintf(&builder, "<b>%s %s</b>\n📅 %d %s %d\n\n%s\n\n💡 %s",
+			html.EscapeString(occurrence.Definition.Emoji),
+			html.EscapeString(title),
+			occurrence.Date.Day(), html.EscapeString(locale.Month(int(occurrence.Date.Month()))), occurrence.Date.Year(),
+			html.EscapeString(copy.Summary),
+			html.EscapeString(copy.Action),
+		)
+		if len(occurrence.Definition.Sources) > 0 {
+			builder.WriteString("\n\n📚 ")
+			for index, source := range occurrence.Definition.Sources {
+				if index > 0 {
+					builder.WriteString(" · ")
+				}
+				fmt.Fprintf(&builder, `<a href="%s">%s</a>`,
+					html.EscapeString(source.URL), html.EscapeString(source.Label))
 			}
-			fmt.Fprintf(&builder, `<a href="%s">%s</a>`,
-				html.EscapeString(source.URL), html.EscapeString(source.Label))
 		}
 	}
 	return builder.String()
 }
 
+// personalEventReminderText lists every personal event of the next day under
+// its Gregorian and Hijri dates.
+func personalEventReminderText(schedule domain.ReminderSchedule, profile domain.PrayerProfile, locale i18n.Locale) string {
+	location := mustLocation(profile.Timezone)
+	date, err := time.ParseInLocation("2006-01-02", schedule.LocalDate, location)
+	if err != nil {
+		return ""
+	}
+	calendar, err := hijri.ForProfile(profile)
+	if err != nil {
+		return ""
+	}
+	upcoming, err := occasions.Between(date, 1, calendar, profile.CountryCode, occasions.Personal(profile.PersonalEvents)...)
+	if err != nil {
+		return ""
+	}
+	var builder strings.Builder
+	for _, occurrence := range upcoming {
+		if occurrence.Definition.Category != occasions.CategoryPersonal {
+			continue
+		}
+		if builder.Len() == 0 {
+			dates := fmt.Sprintf("%d %s %d · %d %s %d %s",
+				date.Day(), locale.Month(int(date.Month())), date.Year(),
+				occurrence.Hijri.Day, locale.HijriMonth(occurrence.Hijri.Month), occurrence.Hijri.Year,
+				locale.Message("hijri_era"))
+			builder.WriteString(fmt.Sprintf(locale.Message("reminder_personal_event"), html.EscapeString(dates)))
+			builder.WriteString("\n")
+		}
+		fmt.Fprintf(&builder, "\n%s <b>%s</b>",
+			html.EscapeString(occurrence.Definition.Emoji), html.EscapeString(occurrence.Definition.Title))
+	}
+	return builder.String()
+}
+
 // jamaatPollParams builds the group pre-prayer poll. Poll questions cannot
 // carry HTML, so the question uses a plain-text template.
 func jamaatPollParams(chatID int64, rule domain.ReminderRule, schedule domain.ReminderSchedule, profile domain.PrayerProfile, locale i18n.Locale) *botapi.SendPollParams {
@@ -269,7 +430,11 @@ func whiteDaysReminderText(schedule domain.ReminderSchedule, profile domain.Pray
 	if err != nil {
 		return locale.Message("reminder_fasting")
 	}
-	hijriDate, err := hijri.FromGregorian(date, profile.HijriAdjustment)
+	calendar, err := hijri.ForProfile(profile)
+	if err != nil {
+		return locale.Message("reminder_fasting")
+	}
+	hijriDate, err := calendar.Date(date)
 	if err != nil {
 		return locale.Message("reminder_fasting")
 	}
@@ -277,7 +442,7 @@ func whiteDaysReminderText(schedule domain.ReminderSchedule, profile domain.Pray
 }
 
 func mustLocation(name string) *time.Location {
-	location, err := time.LoadLocation(name)
+	location, err := domain.LoadLocation(name)
 	if err != nil {
 		return time.UTC
 	}
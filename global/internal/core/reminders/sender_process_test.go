@@ -3,6 +3,7 @@ package reminders
 import (
 	"context"
 	"errors"
+	"slices"
 	"strings"
 	"testing"
 	"time"
@@ -10,6 +11,7 @@ import (
 	botapi "github.com/go-telegram/bot"
 	"github.com/go-telegram/bot/models"
 
+	"github.com/escalopa/prayer-bot/global/internal/core/prayertime"
 	"github.com/escalopa/prayer-bot/global/internal/domain"
 )
 
@@ -29,6 +31,7 @@ type fakeSenderStore struct {
 	completeCalls int
 	completeArgs  struct {
 		messageID int64
+		next      domain.ReminderSchedule
 		category  string
 		expiresAt time.Time
 	}
@@ -36,6 +39,9 @@ type fakeSenderStore struct {
 	failedKeys []string
 	staleKeys  []string
 	cleared    [][2]int64
+
+	announced           map[string]int64
+	completeAnnounceErr error
 }
 
 func (f *fakeSenderStore) Schedule(context.Context, int64) (domain.ReminderSchedule, error) {
@@ -68,14 +74,36 @@ func (f *fakeSenderStore) Chat(context.Context, int64) (domain.Chat, error) {
 	return f.chat, nil
 }
 
-func (f *fakeSenderStore) CompleteDelivery(_ context.Context, _ domain.DeliveryTask, messageID int64, _ domain.ReminderSchedule, category string, expiresAt time.Time) (int64, error) {
+func (f *fakeSenderStore) CompleteDelivery(_ context.Context, _ domain.DeliveryTask, messageID int64, next domain.ReminderSchedule, category string, expiresAt time.Time) (int64, error) {
 	f.completeCalls++
 	f.completeArgs.messageID = messageID
+	f.completeArgs.next = next
 	f.completeArgs.category = category
 	f.completeArgs.expiresAt = expiresAt
 	return f.completePrev, f.completeErr
 }
 
+func (f *fakeSenderStore) AcquireAnnouncement(_ context.Context, key string, _ int64) (bool, error) {
+	_, sent := f.announced[key]
+	return !sent, nil
+}
+
+func (f *fakeSenderStore) CompleteAnnouncement(_ context.Context, key string, messageID int64) error {
+	if f.completeAnnounceErr != nil {
+		return f.completeAnnounceErr
+	}
+	if f.announced == nil {
+		f.announced = map[string]int64{}
+	}
+	f.announced[key] = messageID
+	return nil
+}
+
+func (f *fakeSenderStore) FailAnnouncement(_ context.Context, key string, _ error) error {
+	f.failedKeys = append(f.failedKeys, key)
+	return nil
+}
+
 func (f *fakeSenderStore) ClearNotificationMessage(_ context.Context, chatID, messageID int64) error {
 	f.cleared = append(f.cleared, [2]int64{chatID, messageID})
 	return nil
@@ -85,6 +113,7 @@ type fakeBot struct {
 	sendID  int
 	sendErr error
 	sent    []string
+	markups []models.ReplyMarkup
 	polls   []*botapi.SendPollParams
 	deleted [][]int
 }
@@ -94,6 +123,7 @@ func (f *fakeBot) SendMessage(_ context.Context, params *botapi.SendMessageParam
 		return nil, f.sendErr
 	}
 	f.sent = append(f.sent, params.Text)
+	f.markups = append(f.markups, params.ReplyMarkup)
 	return &models.Message{ID: f.sendID}, nil
 }
 
@@ -119,6 +149,8 @@ func (f fakeNextPlanner) Next(context.Context, domain.PrayerProfile, domain.Remi
 	return f.schedule, f.err
 }
 
+func (f fakeNextPlanner) RebuildChat(context.Context, int64, time.Time) error { return f.err }
+
 // alignedFixture returns a task, store, and sender whose schedule/profile/rule
 // all agree, so Process proceeds to a real send instead of a staleness skip.
 func alignedFixture(t *testing.T) (domain.DeliveryTask, *fakeSenderStore, *fakeBot, *Sender) {
@@ -229,6 +261,26 @@ func TestProcessCompensatesWhenPlanningNextFailsAfterSend(t *testing.T) {
 	}
 }
 
+func TestProcessSendsTheCustomTextAndEndsAOneOffReminder(t *testing.T) {
+	task, store, bot, sender := alignedFixture(t)
+	store.rule = domain.ReminderRule{ID: 2, ChatID: 3, Kind: domain.ReminderCustom, Enabled: true,
+		Custom: &domain.CustomReminder{Text: "Call <mum>", LocalTime: "18:45", Recurrence: domain.RecurrenceOnce, Date: "2026-07-20"}}
+	sender.planner = fakeNextPlanner{err: ErrNothingToPlan}
+
+	if err := sender.Process(context.Background(), task); err != nil {
+		t.Fatal(err)
+	}
+	if len(bot.sent) != 1 || bot.sent[0] != "🔔 Call &lt;mum&gt;" {
+		t.Fatalf("sent = %q", bot.sent)
+	}
+	if store.completeCalls != 1 || !store.completeArgs.next.NextRunAt.IsZero() || store.completeArgs.category != "custom" {
+		t.Fatalf("a one-off reminder must complete with nothing next in its own slot: %+v", store.completeArgs)
+	}
+	if len(bot.deleted) != 0 {
+		t.Fatalf("the sent reminder was deleted: %v", bot.deleted)
+	}
+}
+
 func TestProcessMarksStaleOnProfileVersionMismatch(t *testing.T) {
 	task, store, bot, sender := alignedFixture(t)
 	store.profile.Version = 6 // profile changed after the task was queued
@@ -317,3 +369,125 @@ func TestGroupPreReminderStaysTextWhenPollDisabledOrPrivate(t *testing.T) {
 		}
 	}
 }
+
+func TestFastingRemindersCarryLogButtonsInPrivateChats(t *testing.T) {
+	for name, tc := range map[string]struct {
+		kind    domain.ReminderKind
+		date    string
+		chat    string
+		buttons []string
+	}{
+		// 23 February 2026 is a Monday in Ramadan, with nothing to make up.
+		"suhoor":          {domain.ReminderSuhoor, "2026-02-23", "private", []string{"fast:fasted:2026-02-23", "fast:missed:2026-02-23"}},
+		"weekly fasting":  {domain.ReminderWeeklyFasting, "2026-04-06", "private", []string{"fast:fasted:2026-04-06", "fast:makeup:2026-04-06", "fast:missed:2026-04-06"}},
+		"group":           {domain.ReminderWeeklyFasting, "2026-04-06", "supergroup", nil},
+		"prayer reminder": {domain.ReminderAt, "2026-04-06", "private", nil},
+	} {
+		task, senderStore, bot, sender := alignedFixture(t)
+		senderStore.rule.Kind = tc.kind
+		senderStore.schedule.LocalDate = tc.date
+		senderStore.chat.Type = tc.chat
+		if err := sender.Process(context.Background(), task); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		var data []string
+		if markup, ok := bot.markups[0].(*models.InlineKeyboardMarkup); ok {
+			for _, button := range markup.InlineKeyboard[0] {
+				data = append(data, button.CallbackData)
+			}
+		}
+		if !slices.Equal(data, tc.buttons) {
+			t.Fatalf("%s: buttons = %v, want %v", name, data, tc.buttons)
+		}
+	}
+}
+
+// BenchmarkSenderProcess sends one reminder per chat and plans each chat's
+// next one with the production calculator.
+func BenchmarkSenderProcess(b *testing.B) {
+	runAt := time.Date(2026, time.July, 16, 17, 45, 0, 0, time.UTC)
+	profiles := benchmarkProfiles(300)
+	rule := domain.ReminderRule{ID: 2, Kind: domain.ReminderAt, Prayer: domain.PrayerMaghrib, Enabled: true}
+	store := &fakeSenderStore{
+		acquired: true,
+		rule:     rule,
+		chat:     domain.Chat{LanguageCode: "en"},
+	}
+	sender := NewSender(store, NewPlanner(nil, prayertime.New()), &fakeBot{sendID: 1})
+	sender.now = func() time.Time { return runAt }
+	for _, profile := range profiles {
+		if _, err := sender.planner.Next(context.Background(), profile, rule, runAt); err != nil {
+			b.Fatal(err)
+		}
+	}
+	for i := 0; b.Loop(); i++ {
+		profile := profiles[i%len(profiles)]
+		store.profile = profile
+		store.rule.ChatID, store.chat.TelegramChatID = profile.ChatID, profile.ChatID
+		store.schedule = domain.ReminderSchedule{
+			ID: 1, RuleID: rule.ID, ChatID: profile.ChatID, ProfileVersion: profile.Version, PrayerAt: runAt, NextRunAt: runAt,
+		}
+		task := domain.DeliveryTask{
+			DeliveryKey: "benchmark", ScheduleID: 1, RuleID: rule.ID, ChatID: profile.ChatID,
+			ProfileVersion: profile.Version, ScheduledFor: runAt,
+		}
+		if err := sender.Process(context.Background(), task); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestAnnounceReplansAndNotifiesChatsStillInTheCountry(t *testing.T) {
+	_, store, bot, sender := alignedFixture(t)
+	store.profile.CountryCode = "GB"
+	task := domain.HijriAnnouncementTask{
+		TaskKey: "announce:publish:GB:1448-09:1:3", ChatID: 3, CountryCode: "GB",
+		HijriYear: 1448, HijriMonth: 9, StartsOn: "2027-02-08", Notify: true,
+	}
+	if err := sender.Announce(context.Background(), task); err != nil {
+		t.Fatal(err)
+	}
+	if len(bot.sent) != 1 || !strings.Contains(bot.sent[0], "Ramadan 1448") ||
+		!strings.Contains(bot.sent[0], "8 February 2027") {
+		t.Fatalf("unexpected notice: %+v", bot.sent)
+	}
+
+	store.profile.CountryCode = "FR"
+	if err := sender.Announce(context.Background(), task); err != nil {
+		t.Fatal(err)
+	}
+	task.Notify = false
+	store.profile.CountryCode = "GB"
+	if err := sender.Announce(context.Background(), task); err != nil {
+		t.Fatal(err)
+	}
+	if len(bot.sent) != 1 {
+		t.Fatalf("a moved chat or a withdrawal must not be told, got %d notices", len(bot.sent))
+	}
+}
+
+func TestAnnounceSendsEachNoticeOnce(t *testing.T) {
+	_, store, bot, sender := alignedFixture(t)
+	store.profile.CountryCode = "GB"
+	task := domain.HijriAnnouncementTask{
+		TaskKey: "announce:publish:GB:1448-09:1:3", ChatID: 3, CountryCode: "GB",
+		HijriYear: 1448, HijriMonth: 9, StartsOn: "2027-02-08", Notify: true,
+	}
+	store.completeAnnounceErr = errors.New("connection reset")
+	if err := sender.Announce(context.Background(), task); err == nil {
+		t.Fatal("a notice that could not be recorded must be retried")
+	}
+	if len(bot.deleted) != 1 || !slices.Equal(store.failedKeys, []string{task.TaskKey}) {
+		t.Fatalf("the unrecorded notice must be deleted and failed: deleted=%v failed=%v", bot.deleted, store.failedKeys)
+	}
+
+	store.completeAnnounceErr = nil
+	for range 2 {
+		if err := sender.Announce(context.Background(), task); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(bot.sent) != 2 || store.announced[task.TaskKey] == 0 {
+		t.Fatalf("a redelivered task must not send its notice again, sent %d", len(bot.sent))
+	}
+}
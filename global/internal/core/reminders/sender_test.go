@@ -1,10 +1,12 @@
 package reminders
 
 import (
+	"fmt"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/escalopa/prayer-bot/global/internal/core/hijri"
 	"github.com/escalopa/prayer-bot/global/internal/core/i18n"
 	"github.com/escalopa/prayer-bot/global/internal/core/occasions"
 	"github.com/escalopa/prayer-bot/global/internal/domain"
@@ -43,6 +45,46 @@ func TestPrayerNotificationsShareOneCleanupCategory(t *testing.T) {
 	}
 }
 
+func TestExtendedTimeReminderNamesTheTimeAndHasItsOwnCategory(t *testing.T) {
+	schedule := domain.ReminderSchedule{PrayerAt: time.Date(2026, time.July, 17, 1, 52, 0, 0, time.UTC)}
+	rule := domain.ReminderRule{Kind: domain.ReminderExtendedTime, Prayer: domain.PrayerLastThird}
+	text := reminderText(rule, schedule, domain.PrayerProfile{Timezone: "UTC"}, i18n.Resolve("en"))
+	if !strings.Contains(text, "Last third of the night") || !strings.Contains(text, "<code>01:52</code>") {
+		t.Fatalf("unexpected extended time reminder: %s", text)
+	}
+	if notificationCategory(domain.ReminderExtendedTime) == notificationCategory(domain.ReminderAt) {
+		t.Fatal("extended time notices must not replace prayer notices")
+	}
+}
+
+func TestRamadanRemindersNameTheirPrayerAndShareACategory(t *testing.T) {
+	profile := domain.PrayerProfile{Timezone: "UTC"}
+	locale := i18n.Resolve("en")
+	fajr := domain.ReminderSchedule{PrayerAt: time.Date(2027, time.February, 10, 5, 12, 0, 0, time.UTC)}
+	suhoor := reminderText(domain.ReminderRule{Kind: domain.ReminderSuhoor, Prayer: domain.PrayerFajr, OffsetMinutes: 30}, fajr, profile, locale)
+	if !strings.Contains(suhoor, "30 min") || !strings.Contains(suhoor, "<code>05:12</code>") {
+		t.Fatalf("unexpected suhoor reminder: %s", suhoor)
+	}
+	maghrib := domain.ReminderSchedule{PrayerAt: time.Date(2027, time.February, 10, 17, 40, 0, 0, time.UTC)}
+	iftar := reminderText(domain.ReminderRule{Kind: domain.ReminderIftar, Prayer: domain.PrayerMaghrib}, maghrib, profile, locale)
+	if !strings.Contains(iftar, "Iftar") || !strings.Contains(iftar, "<code>17:40</code>") {
+		t.Fatalf("unexpected iftar reminder: %s", iftar)
+	}
+	if notificationCategory(domain.ReminderSuhoor) != notificationCategory(domain.ReminderIftar) ||
+		notificationCategory(domain.ReminderIftar) == notificationCategory(domain.ReminderAt) {
+		t.Fatal("Ramadan reminders must replace each other but not prayer notices")
+	}
+}
+
+func TestJumuahReminderNamesTheKhutbahAndIqamah(t *testing.T) {
+	profile := domain.PrayerProfile{Timezone: "UTC", Jumuah: domain.Jumuah{Khutbah: "13:15", Iqamah: "13:45"}}
+	schedule := domain.ReminderSchedule{PrayerAt: time.Date(2026, time.July, 17, 13, 15, 0, 0, time.UTC)}
+	text := reminderText(domain.ReminderRule{Kind: domain.ReminderJumuah, Prayer: domain.PrayerDhuhr, OffsetMinutes: 45}, schedule, profile, i18n.Resolve("en"))
+	if !strings.Contains(text, "45 min") || !strings.Contains(text, "<code>13:15</code>") || !strings.Contains(text, "Iqamah <code>13:45</code>") {
+		t.Fatalf("unexpected Jumu'ah reminder: %s", text)
+	}
+}
+
 func TestNotificationLifetimeFitsTelegramDeletionWindow(t *testing.T) {
 	if notificationLifetime <= 0 || notificationLifetime >= 48*time.Hour {
 		t.Fatalf("notification lifetime %s must remain inside Telegram's 48-hour deletion window", notificationLifetime)
@@ -51,7 +93,11 @@ func TestNotificationLifetimeFitsTelegramDeletionWindow(t *testing.T) {
 
 func TestIslamicOccasionReminderIncludesLocalizedGuidanceAndSources(t *testing.T) {
 	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
-	occurrence, err := occasions.Next(start, 0, occasions.CategoryFasting)
+	calendar, err := hijri.New(domain.HijriUmmAlQura, 0, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	occurrence, err := occasions.Next(start, calendar, "", occasions.CategoryFasting)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -77,3 +123,54 @@ func TestIslamicOccasionReminderIncludesLocalizedGuidanceAndSources(t *testing.T
 		}
 	}
 }
+
+func TestOccasionReminderDescribesEveryOccasionOfTheDay(t *testing.T) {
+	calendar, err := hijri.New(domain.HijriUmmAlQura, 0, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	upcoming, err := occasions.Between(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), 354, calendar, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dates := map[string]time.Time{}
+	for _, occurrence := range upcoming {
+		if occurrence.Definition.ID == "eid_adha" || occurrence.Definition.ID == "odd_nights" && occurrence.Day == 4 {
+			dates[occurrence.Definition.ID] = occurrence.Date
+		}
+	}
+	english := i18n.Resolve("en")
+	eid := reminderText(domain.ReminderRule{Kind: domain.ReminderOccasionMajor},
+		domain.ReminderSchedule{LocalDate: dates["eid_adha"].Format("2006-01-02")}, domain.PrayerProfile{Timezone: "UTC"}, english)
+	// The tenth day of the first ten is not reminded of on its own.
+	if !strings.Contains(eid, english.Occasion("eid_adha").Title) || strings.Contains(eid, english.Occasion("dhul_hijjah_start").Title) {
+		t.Fatalf("Eid al-Adha reminder:\n%s", eid)
+	}
+	// A night's schedule is dated the day after the evening it begins, and its
+	// notice names that evening.
+	evening := dates["odd_nights"]
+	night := reminderText(domain.ReminderRule{Kind: domain.ReminderOccasionMajor},
+		domain.ReminderSchedule{LocalDate: evening.AddDate(0, 0, 1).Format("2006-01-02")}, domain.PrayerProfile{Timezone: "UTC"}, english)
+	if !strings.Contains(night, english.Occasion("odd_nights").Title+" · night 27") || strings.Contains(night, english.Occasion("last_ten_nights").Title) ||
+		!strings.Contains(night, fmt.Sprintf("📅 %d %s", evening.Day(), english.Month(int(evening.Month())))) {
+		t.Fatalf("odd night reminder:\n%s", night)
+	}
+}
+
+func TestPersonalEventReminderListsEveryEventOfTheDay(t *testing.T) {
+	profile := domain.PrayerProfile{Timezone: "UTC", PersonalEvents: []domain.PersonalEvent{
+		{ID: 1, Title: "Wedding <3", Month: 8, Day: 25},
+		{ID: 2, Title: "Hijri birthday", Hijri: true, Month: 3, Day: 12},
+		{ID: 3, Title: "Another day", Month: 1, Day: 1},
+	}}
+	rule := domain.ReminderRule{Kind: domain.ReminderPersonalEvents}
+	schedule := domain.ReminderSchedule{LocalDate: "2026-08-25"}
+	text := reminderText(rule, schedule, profile, i18n.Resolve("en"))
+	want := "🗓 <b>Tomorrow</b> · 25 August 2026 · 12 Rabi&#39; al-Awwal 1448 AH\n\n⭐ <b>Wedding &lt;3</b>\n⭐ <b>Hijri birthday</b>"
+	if text != want {
+		t.Fatalf("personal reminder:\n%s\nwant:\n%s", text, want)
+	}
+	if got := notificationCategory(domain.ReminderPersonalEvents); got != "personal_event" {
+		t.Fatalf("notificationCategory = %q", got)
+	}
+}
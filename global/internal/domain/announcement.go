@@ -0,0 +1,36 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// HijriAnnouncement is a regional authority's declaration that a Hijri month
+// began on a local date in one country, such as Ramadan 1448 beginning on
+// 8 February 2027 in Saudi Arabia. Profiles in the country reckon that month,
+// and the months after it, from the announced day until the country's next
+// announcement begins.
+type HijriAnnouncement struct {
+	CountryCode string
+	Year        int
+	Month       int
+	// Start is the local date of the month's first day, at midnight UTC.
+	Start     time.Time
+	CreatedAt time.Time
+}
+
+func (a HijriAnnouncement) Validate() error {
+	if !countryCodePattern.MatchString(a.CountryCode) {
+		return fmt.Errorf("an announcement needs a two-letter country code")
+	}
+	if a.Month < 1 || a.Month > 12 {
+		return fmt.Errorf("hijri month must be between 1 and 12")
+	}
+	if a.Year < 1300 || a.Year > 1700 {
+		return fmt.Errorf("hijri year must be between 1300 and 1700")
+	}
+	if a.Start.IsZero() {
+		return fmt.Errorf("an announcement needs the date the month began")
+	}
+	return nil
+}
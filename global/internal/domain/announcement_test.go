@@ -0,0 +1,24 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestHijriAnnouncementValidate(t *testing.T) {
+	start := time.Date(2027, time.February, 8, 0, 0, 0, 0, time.UTC)
+	valid := HijriAnnouncement{CountryCode: "SA", Year: 1448, Month: 9, Start: start}
+	if err := valid.Validate(); err != nil {
+		t.Fatalf("valid announcement rejected: %v", err)
+	}
+	for _, invalid := range []HijriAnnouncement{
+		{CountryCode: "sa", Year: 1448, Month: 9, Start: start},
+		{CountryCode: "SA", Year: 1448, Month: 13, Start: start},
+		{CountryCode: "SA", Year: 48, Month: 9, Start: start},
+		{CountryCode: "SA", Year: 1448, Month: 9},
+	} {
+		if invalid.Validate() == nil {
+			t.Errorf("%+v should be rejected", invalid)
+		}
+	}
+}
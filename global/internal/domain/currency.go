@@ -103,6 +103,18 @@ func RecommendedMethod(countryCode string) Method {
 		return MethodMUIS
 	case "MY":
 		return MethodJAKIM
+	case "IR":
+		return MethodTehran
+	case "AE", "OM", "BH":
+		return MethodGulf
+	case "KW":
+		return MethodKuwait
+	case "QA":
+		return MethodQatar
+	case "FR":
+		return MethodUOIF
+	case "RU":
+		return MethodRussia
 	case "US", "CA":
 		return MethodISNA
 	default:
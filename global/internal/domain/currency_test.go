@@ -31,3 +31,15 @@ func TestCurrencyForTimezone(t *testing.T) {
 		}
 	}
 }
+
+func TestRecommendedMethodUsesRegionalAuthorities(t *testing.T) {
+	cases := map[string]Method{
+		"eg": MethodEgyptian, "IR": MethodTehran, "AE": MethodGulf, "KW": MethodKuwait,
+		"QA": MethodQatar, "FR": MethodUOIF, "RU": MethodRussia, "US": MethodISNA, "": MethodMWL,
+	}
+	for country, want := range cases {
+		if got := RecommendedMethod(country); got != want {
+			t.Errorf("RecommendedMethod(%q) = %q, want %q", country, got, want)
+		}
+	}
+}
@@ -0,0 +1,223 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// MaxCustomReminders caps how many custom reminders one chat may keep.
+const MaxCustomReminders = 10
+
+// ErrCustomReminderLimit is returned when a chat already keeps
+// MaxCustomReminders reminders.
+var ErrCustomReminderLimit = errors.New("too many custom reminders")
+
+// MaxCustomReminderText is the longest text, in characters, a custom
+// reminder may carry.
+const MaxCustomReminderText = 200
+
+// MaxCustomReminderOffset bounds how many minutes before or after its prayer
+// an anchored reminder may fire.
+const MaxCustomReminderOffset = 180
+
+// Recurrence is the days a custom reminder fires on.
+type Recurrence string
+
+const (
+	RecurrenceDaily  Recurrence = "daily"
+	RecurrenceWeekly Recurrence = "weekly"
+	// RecurrenceHijriMonthly fires on the same day of every Hijri month in
+	// the chat's calendar. A 30th falls on the 29th when the month is short.
+	RecurrenceHijriMonthly Recurrence = "hijri_monthly"
+	RecurrenceOnce         Recurrence = "once"
+)
+
+func (r Recurrence) Valid() bool {
+	switch r {
+	case RecurrenceDaily, RecurrenceWeekly, RecurrenceHijriMonthly, RecurrenceOnce:
+		return true
+	default:
+		return false
+	}
+}
+
+// SupportedRecurrences lists the recurrences in the order pickers offer them.
+func SupportedRecurrences() []Recurrence {
+	return []Recurrence{RecurrenceDaily, RecurrenceWeekly, RecurrenceHijriMonthly, RecurrenceOnce}
+}
+
+// WeekdaySet is a set of weekdays; bit n stands for time.Weekday(n).
+type WeekdaySet uint8
+
+// allWeekdays has the bits of Sunday through Saturday set.
+const allWeekdays WeekdaySet = 1<<7 - 1
+
+func WeekdaysOf(days ...time.Weekday) WeekdaySet {
+	var set WeekdaySet
+	for _, day := range days {
+		set |= 1 << day
+	}
+	return set
+}
+
+func (s WeekdaySet) Has(day time.Weekday) bool { return s&(1<<day) != 0 }
+
+// Days lists the weekdays in the set from Monday to Sunday.
+func (s WeekdaySet) Days() []time.Weekday {
+	var days []time.Weekday
+	for _, day := range []time.Weekday{
+		time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday, time.Sunday,
+	} {
+		if s.Has(day) {
+			days = append(days, day)
+		}
+	}
+	return days
+}
+
+// CustomReminder is a reminder a chat wrote for itself. It fires either at
+// the local clock time LocalTime or OffsetMinutes from Anchor, a prayer of
+// the day, negative before it; and on the days its Recurrence names. RuleID
+// is the reminder rule that schedules it.
+type CustomReminder struct {
+	RuleID        int64
+	ChatID        int64
+	Text          string
+	LocalTime     string
+	Anchor        Prayer
+	OffsetMinutes int
+	Recurrence    Recurrence
+	Weekdays      WeekdaySet // Only used by RecurrenceWeekly.
+	HijriDay      int        // Only used by RecurrenceHijriMonthly.
+	Date          string     // YYYY-MM-DD; only used by RecurrenceOnce.
+	CreatedAt     time.Time
+}
+
+// Anchored reports whether the reminder follows a prayer rather than the
+// clock.
+func (c CustomReminder) Anchored() bool { return c.Anchor != "" }
+
+func (c CustomReminder) Validate() error {
+	text := strings.TrimSpace(c.Text)
+	if text == "" || utf8.RuneCountInString(text) > MaxCustomReminderText {
+		return fmt.Errorf("a reminder text must be 1 to %d characters", MaxCustomReminderText)
+	}
+	if c.Anchored() {
+		if !c.Anchor.Valid() {
+			return fmt.Errorf("unsupported reminder prayer %q", c.Anchor)
+		}
+		if c.LocalTime != "" {
+			return fmt.Errorf("a reminder follows either a prayer or the clock")
+		}
+		if c.OffsetMinutes < -MaxCustomReminderOffset || c.OffsetMinutes > MaxCustomReminderOffset {
+			return fmt.Errorf("a reminder must be within %d minutes of its prayer", MaxCustomReminderOffset)
+		}
+	} else {
+		if _, err := time.Parse("15:04", c.LocalTime); err != nil {
+			return fmt.Errorf("reminder time %q is not HH:MM", c.LocalTime)
+		}
+		if c.OffsetMinutes != 0 {
+			return fmt.Errorf("only a reminder that follows a prayer has an offset")
+		}
+	}
+	switch c.Recurrence {
+	case RecurrenceDaily:
+	case RecurrenceWeekly:
+		if c.Weekdays == 0 || c.Weekdays&^allWeekdays != 0 {
+			return fmt.Errorf("a weekly reminder needs at least one weekday")
+		}
+	case RecurrenceHijriMonthly:
+		if c.HijriDay < 1 || c.HijriDay > 30 {
+			return fmt.Errorf("a Hijri day must be between 1 and 30")
+		}
+	case RecurrenceOnce:
+		if _, err := time.Parse(time.DateOnly, c.Date); err != nil {
+			return fmt.Errorf("reminder date %q is not YYYY-MM-DD", c.Date)
+		}
+	default:
+		return fmt.Errorf("unsupported recurrence %q", c.Recurrence)
+	}
+	return nil
+}
+
+// weekdayNames are the English names ParseCustomReminder reads, by their
+// first three letters.
+var weekdayNames = map[string]time.Weekday{
+	"sun": time.Sunday, "mon": time.Monday, "tue": time.Tuesday, "wed": time.Wednesday,
+	"thu": time.Thursday, "fri": time.Friday, "sat": time.Saturday,
+}
+
+// ParseCustomReminder reads "<repeat> <time> <text>" as typed after /custom.
+// The repeat is daily, a list of weekdays such as mon,thu, hijri:13 for a day
+// of every Hijri month, or a YYYY-MM-DD date for a single reminder. The time
+// is a clock time such as 07:30, or a prayer with an optional offset in
+// minutes, such as fajr+20 or maghrib-10.
+func ParseCustomReminder(argument string) (CustomReminder, error) {
+	fields := strings.Fields(argument)
+	if len(fields) < 3 {
+		return CustomReminder{}, fmt.Errorf("expected a repeat, a time and a text")
+	}
+	var reminder CustomReminder
+	if err := reminder.parseRecurrence(strings.ToLower(fields[0])); err != nil {
+		return CustomReminder{}, err
+	}
+	if err := reminder.parseTime(strings.ToLower(fields[1])); err != nil {
+		return CustomReminder{}, err
+	}
+	// The text keeps its own spacing after the time.
+	text := strings.TrimSpace(argument)
+	for _, field := range fields[:2] {
+		text = strings.TrimSpace(strings.TrimPrefix(text, field))
+	}
+	reminder.Text = text
+	return reminder, reminder.Validate()
+}
+
+func (c *CustomReminder) parseRecurrence(value string) error {
+	switch {
+	case value == "daily":
+		c.Recurrence = RecurrenceDaily
+	case strings.HasPrefix(value, "hijri:"):
+		day, err := strconv.Atoi(strings.TrimPrefix(value, "hijri:"))
+		if err != nil {
+			return fmt.Errorf("invalid Hijri day %q", value)
+		}
+		c.Recurrence, c.HijriDay = RecurrenceHijriMonthly, day
+	case strings.Count(value, "-") == 2:
+		c.Recurrence, c.Date = RecurrenceOnce, value
+	default:
+		for _, name := range strings.Split(value, ",") {
+			day, ok := weekdayNames[name[:min(len(name), 3)]]
+			if !ok {
+				return fmt.Errorf("unknown repeat %q", value)
+			}
+			c.Weekdays |= WeekdaysOf(day)
+		}
+		c.Recurrence = RecurrenceWeekly
+	}
+	return nil
+}
+
+func (c *CustomReminder) parseTime(value string) error {
+	if clock := normalizeClock(value); strings.Contains(clock, ":") {
+		c.LocalTime = clock
+		return nil
+	}
+	anchor, offset := value, ""
+	if index := strings.IndexAny(value, "+-"); index >= 0 {
+		anchor, offset = value[:index], value[index:]
+	}
+	c.Anchor = Prayer(anchor)
+	if offset != "" {
+		minutes, err := strconv.Atoi(offset)
+		if err != nil {
+			return fmt.Errorf("invalid reminder offset %q", value)
+		}
+		c.OffsetMinutes = minutes
+	}
+	return nil
+}
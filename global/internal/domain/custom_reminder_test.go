@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseCustomReminderReadsEachRepeatAndTime(t *testing.T) {
+	cases := map[string]CustomReminder{
+		"daily 7.30 Drink  water": {
+			Text: "Drink  water", LocalTime: "07:30", Recurrence: RecurrenceDaily,
+		},
+		"Mon,thursday fajr+20 Morning adhkar": {
+			Text: "Morning adhkar", Anchor: PrayerFajr, OffsetMinutes: 20,
+			Recurrence: RecurrenceWeekly, Weekdays: WeekdaysOf(time.Monday, time.Thursday),
+		},
+		"hijri:13 maghrib-10 White days tomorrow": {
+			Text: "White days tomorrow", Anchor: PrayerMaghrib, OffsetMinutes: -10,
+			Recurrence: RecurrenceHijriMonthly, HijriDay: 13,
+		},
+		"2026-11-02 asr Visit the doctor": {
+			Text: "Visit the doctor", Anchor: PrayerAsr, Recurrence: RecurrenceOnce, Date: "2026-11-02",
+		},
+	}
+	for argument, want := range cases {
+		got, err := ParseCustomReminder(argument)
+		if err != nil || got != want {
+			t.Errorf("ParseCustomReminder(%q) = %+v, %v; want %+v", argument, got, err, want)
+		}
+	}
+	for _, argument := range []string{
+		"", "daily 07:30", "weekly 07:30 Text", "daily 25:00 Text", "daily noon Text",
+		"daily fajr+200 Text", "hijri:31 07:30 Text", "2026-02-30 07:30 Text", "mon,,thu 07:30 Text",
+	} {
+		if _, err := ParseCustomReminder(argument); err == nil {
+			t.Errorf("ParseCustomReminder(%q) should fail", argument)
+		}
+	}
+}
+
+func TestCustomReminderValidateKeepsClockAndPrayerApart(t *testing.T) {
+	base := CustomReminder{Text: "Witr", Recurrence: RecurrenceDaily}
+	for name, reminder := range map[string]CustomReminder{
+		"clock with an offset":   {Text: base.Text, Recurrence: base.Recurrence, LocalTime: "21:00", OffsetMinutes: 5},
+		"prayer and clock":       {Text: base.Text, Recurrence: base.Recurrence, LocalTime: "21:00", Anchor: PrayerIsha},
+		"extended time":          {Text: base.Text, Recurrence: base.Recurrence, Anchor: PrayerMidnight},
+		"no weekday":             {Text: base.Text, Recurrence: RecurrenceWeekly, LocalTime: "21:00"},
+		"blank text":             {Text: "  ", Recurrence: base.Recurrence, LocalTime: "21:00"},
+		"unsupported recurrence": {Text: base.Text, Recurrence: "yearly", LocalTime: "21:00"},
+	} {
+		if err := reminder.Validate(); err == nil {
+			t.Errorf("%s should be rejected", name)
+		}
+	}
+	if days := WeekdaysOf(time.Sunday, time.Friday, time.Monday).Days(); len(days) != 3 ||
+		days[0] != time.Monday || days[1] != time.Friday || days[2] != time.Sunday {
+		t.Fatalf("Days() = %v, want Monday, Friday, Sunday", days)
+	}
+}
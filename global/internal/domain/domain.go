@@ -32,12 +32,26 @@ const (
 	MethodKemenag   Method = "kemenag"
 	MethodMUIS      Method = "muis"
 	MethodJAKIM     Method = "jakim"
+	MethodTehran    Method = "tehran"
+	MethodGulf      Method = "gulf"
+	MethodKuwait    Method = "kuwait"
+	MethodQatar     Method = "qatar"
+	MethodUOIF      Method = "uoif"
+	MethodRussia    Method = "russia"
+	// MethodMoonsighting follows the Moonsighting Committee Worldwide, whose
+	// Fajr and Isha bounds vary with latitude and season.
+	MethodMoonsighting Method = "moonsighting"
+	// MethodCustom uses the twilight parameters stored on the profile, for
+	// communities that follow a local council's published angles.
+	MethodCustom Method = "custom"
 )
 
 func (m Method) Valid() bool {
 	switch m {
 	case MethodMWL, MethodEgyptian, MethodUmmAlQura, MethodKarachi,
-		MethodISNA, MethodDiyanet, MethodKemenag, MethodMUIS, MethodJAKIM:
+		MethodISNA, MethodDiyanet, MethodKemenag, MethodMUIS, MethodJAKIM,
+		MethodTehran, MethodGulf, MethodKuwait, MethodQatar, MethodUOIF, MethodRussia,
+		MethodMoonsighting, MethodCustom:
 		return true
 	default:
 		return false
@@ -48,9 +62,69 @@ func SupportedMethods() []Method {
 	return []Method{
 		MethodMWL, MethodEgyptian, MethodUmmAlQura, MethodKarachi,
 		MethodISNA, MethodDiyanet, MethodKemenag, MethodMUIS, MethodJAKIM,
+		MethodTehran, MethodGulf, MethodKuwait, MethodQatar, MethodUOIF, MethodRussia,
+		MethodMoonsighting, MethodCustom,
 	}
 }
 
+// Bounds for user-defined twilight parameters. Every published convention sits
+// between 12° and 20°; the margin leaves room for local councils without
+// accepting values that would put Fajr before true night.
+const (
+	MinCustomAngle        = 10.0
+	MaxCustomAngle        = 22.0
+	MaxCustomIshaInterval = 180
+)
+
+// CustomMethod holds the Fajr and Isha parameters used when the profile method
+// is MethodCustom. A positive IshaIntervalMinutes fixes Isha that many minutes
+// after Maghrib, as Umm al-Qura does, and IshaAngle is then ignored.
+type CustomMethod struct {
+	FajrAngle           float64 `json:"fajr_angle"`
+	IshaAngle           float64 `json:"isha_angle"`
+	IshaIntervalMinutes int     `json:"isha_interval_minutes"`
+}
+
+// DefaultCustomMethod seeds the custom parameters with the Muslim World League
+// angles the first time a chat switches to MethodCustom.
+func DefaultCustomMethod() CustomMethod {
+	return CustomMethod{FajrAngle: 18, IshaAngle: 17}
+}
+
+// IsZero reports whether the parameters were never set.
+func (c CustomMethod) IsZero() bool { return c == CustomMethod{} }
+
+func (c CustomMethod) Validate() error {
+	if c.FajrAngle < MinCustomAngle || c.FajrAngle > MaxCustomAngle {
+		return fmt.Errorf("custom fajr angle must be between %g and %g degrees", MinCustomAngle, MaxCustomAngle)
+	}
+	if c.IshaAngle < MinCustomAngle || c.IshaAngle > MaxCustomAngle {
+		return fmt.Errorf("custom isha angle must be between %g and %g degrees", MinCustomAngle, MaxCustomAngle)
+	}
+	if c.IshaIntervalMinutes < 0 || c.IshaIntervalMinutes > MaxCustomIshaInterval {
+		return fmt.Errorf("custom isha interval must be between 0 and %d minutes", MaxCustomIshaInterval)
+	}
+	return nil
+}
+
+// Imsak is a precautionary margin before Fajr; ten minutes is the common
+// timetable value.
+const (
+	DefaultImsakMinutes = 10
+	MaxImsakMinutes     = 60
+)
+
+// MaxElevationMeters bounds the elevation a profile may hold; it is a little
+// above the highest summit.
+const MaxElevationMeters = 9000
+
+// ClampElevation rounds a resolved elevation to whole metres within the
+// supported range. Places below sea level get no horizon dip, so they are
+// stored as zero.
+func ClampElevation(meters float64) int {
+	return int(math.Round(min(max(meters, 0), MaxElevationMeters)))
+}
+
 type Madhab string
 
 const (
@@ -66,10 +140,74 @@ const (
 	HighLatitudeAngleBased   HighLatitudeRule = "angle_based"
 	HighLatitudeMiddleNight  HighLatitudeRule = "middle_of_night"
 	HighLatitudeSeventhNight HighLatitudeRule = "one_seventh"
+	// HighLatitudeNearestLatitude ("aqrab al-bilad") takes the times that
+	// cannot be calculated from the same longitude at NearestLatitudeDegrees.
+	HighLatitudeNearestLatitude HighLatitudeRule = "nearest_latitude"
+	// HighLatitudeNearestDay ("aqrab al-ayyam") repeats each time that cannot
+	// be calculated from the last day on which it could.
+	HighLatitudeNearestDay HighLatitudeRule = "nearest_day"
 )
 
+// NearestLatitudeDegrees is the latitude the European Council for Fatwa and
+// Research falls back to: about the highest at which the sun still sinks 18°
+// below the horizon on the summer solstice.
+const NearestLatitudeDegrees = 48.5
+
 func (r HighLatitudeRule) Valid() bool {
-	return r == HighLatitudeAngleBased || r == HighLatitudeMiddleNight || r == HighLatitudeSeventhNight
+	switch r {
+	case HighLatitudeAngleBased, HighLatitudeMiddleNight, HighLatitudeSeventhNight,
+		HighLatitudeNearestLatitude, HighLatitudeNearestDay:
+		return true
+	default:
+		return false
+	}
+}
+
+// SupportedHighLatitudeRules lists the rules in the order pickers offer them.
+func SupportedHighLatitudeRules() []HighLatitudeRule {
+	return []HighLatitudeRule{
+		HighLatitudeAngleBased, HighLatitudeMiddleNight, HighLatitudeSeventhNight,
+		HighLatitudeNearestLatitude, HighLatitudeNearestDay,
+	}
+}
+
+// HijriCalendar is the system Hijri dates are reckoned in. The zero value is
+// Umm al-Qura, the calendar every profile used before the choice existed.
+type HijriCalendar string
+
+const (
+	HijriUmmAlQura HijriCalendar = "umm_al_qura"
+	// HijriTabular is the arithmetic 30-year cycle, the same everywhere.
+	HijriTabular HijriCalendar = "tabular"
+	// HijriDiyanet follows Turkey's Presidency of Religious Affairs: a month
+	// begins worldwide once the crescent is 5° high and 8° from the sun at
+	// sunset somewhere on Earth.
+	HijriDiyanet HijriCalendar = "diyanet"
+	// HijriCrescent begins a month after the first evening the crescent is
+	// predicted visible to the naked eye from the profile's own location.
+	HijriCrescent HijriCalendar = "crescent"
+)
+
+func (c HijriCalendar) Valid() bool {
+	switch c {
+	case HijriUmmAlQura, HijriTabular, HijriDiyanet, HijriCrescent:
+		return true
+	default:
+		return false
+	}
+}
+
+// OrDefault resolves the zero value to Umm al-Qura.
+func (c HijriCalendar) OrDefault() HijriCalendar {
+	if c == "" {
+		return HijriUmmAlQura
+	}
+	return c
+}
+
+// SupportedHijriCalendars lists the calendars in the order pickers offer them.
+func SupportedHijriCalendars() []HijriCalendar {
+	return []HijriCalendar{HijriUmmAlQura, HijriTabular, HijriDiyanet, HijriCrescent}
 }
 
 type Adjustments struct {
@@ -81,6 +219,138 @@ type Adjustments struct {
 	Isha    int `json:"isha"`
 }
 
+// Rounding is the direction a calculated time takes to the whole minute.
+// The empty value, as stored for profiles that never chose, is the nearest
+// minute.
+type Rounding string
+
+const (
+	RoundNearest Rounding = "nearest"
+	RoundUp      Rounding = "up"
+	RoundDown    Rounding = "down"
+)
+
+func (r Rounding) Valid() bool {
+	return r == "" || r == RoundNearest || r == RoundUp || r == RoundDown
+}
+
+// SupportedRoundings lists the rounding directions in the order pickers
+// offer them.
+func SupportedRoundings() []Rounding {
+	return []Rounding{RoundNearest, RoundUp, RoundDown}
+}
+
+// MaxIhtiyatMinutes bounds the precautionary margin a profile may add.
+const MaxIhtiyatMinutes = 5
+
+// SupportedIhtiyatMinutes lists the margins pickers offer.
+func SupportedIhtiyatMinutes() []int {
+	return []int{0, 1, 2, 3, 4, 5}
+}
+
+// Precaution is the rounding and ihtiyat policy some authorities publish with
+// their timetables, such as "Fajr rounded down, Maghrib up, and two minutes of
+// ihtiyat on every prayer but Sunrise". The margin is added first, after the
+// per-prayer adjustments; each time is then rounded its own way.
+type Precaution struct {
+	IhtiyatMinutes int      `json:"ihtiyat_minutes"`
+	Fajr           Rounding `json:"fajr,omitempty"`
+	Sunrise        Rounding `json:"sunrise,omitempty"`
+	Dhuhr          Rounding `json:"dhuhr,omitempty"`
+	Asr            Rounding `json:"asr,omitempty"`
+	Maghrib        Rounding `json:"maghrib,omitempty"`
+	Isha           Rounding `json:"isha,omitempty"`
+}
+
+// Rounding returns the direction for one of the six daily times.
+func (p Precaution) Rounding(prayer Prayer) Rounding {
+	var rounding Rounding
+	switch prayer {
+	case PrayerFajr:
+		rounding = p.Fajr
+	case PrayerSunrise:
+		rounding = p.Sunrise
+	case PrayerDhuhr:
+		rounding = p.Dhuhr
+	case PrayerAsr:
+		rounding = p.Asr
+	case PrayerMaghrib:
+		rounding = p.Maghrib
+	case PrayerIsha:
+		rounding = p.Isha
+	}
+	if rounding == "" {
+		return RoundNearest
+	}
+	return rounding
+}
+
+// SetRounding changes the direction for one of the six daily times.
+func (p *Precaution) SetRounding(prayer Prayer, rounding Rounding) {
+	switch prayer {
+	case PrayerFajr:
+		p.Fajr = rounding
+	case PrayerSunrise:
+		p.Sunrise = rounding
+	case PrayerDhuhr:
+		p.Dhuhr = rounding
+	case PrayerAsr:
+		p.Asr = rounding
+	case PrayerMaghrib:
+		p.Maghrib = rounding
+	case PrayerIsha:
+		p.Isha = rounding
+	}
+}
+
+// IsDefault reports whether the policy is plain rounding to the nearest
+// minute without a margin.
+func (p Precaution) IsDefault() bool {
+	if p.IhtiyatMinutes != 0 {
+		return false
+	}
+	for _, prayer := range []Prayer{PrayerFajr, PrayerSunrise, PrayerDhuhr, PrayerAsr, PrayerMaghrib, PrayerIsha} {
+		if p.Rounding(prayer) != RoundNearest {
+			return false
+		}
+	}
+	return true
+}
+
+func (p Precaution) Validate() error {
+	if p.IhtiyatMinutes < 0 || p.IhtiyatMinutes > MaxIhtiyatMinutes {
+		return fmt.Errorf("ihtiyat must be between 0 and %d minutes", MaxIhtiyatMinutes)
+	}
+	for _, rounding := range []Rounding{p.Fajr, p.Sunrise, p.Dhuhr, p.Asr, p.Maghrib, p.Isha} {
+		if !rounding.Valid() {
+			return fmt.Errorf("unsupported rounding %q", rounding)
+		}
+	}
+	return nil
+}
+
+// Apply adds the margin to a time and rounds it to the minute. A zero time,
+// one the calculation could not produce, stays zero.
+func (p Precaution) Apply(prayer Prayer, at time.Time) time.Time {
+	if at.IsZero() {
+		return at
+	}
+	if prayer != PrayerSunrise {
+		at = at.Add(time.Duration(p.IhtiyatMinutes) * time.Minute)
+	}
+	switch p.Rounding(prayer) {
+	case RoundDown:
+		return at.Truncate(time.Minute)
+	case RoundUp:
+		if down := at.Truncate(time.Minute); !down.Equal(at) {
+			return down.Add(time.Minute)
+		}
+		return at
+	default:
+		return at.Round(time.Minute)
+	}
+}
+
 type PrayerProfile struct {
 	ChatID           int64
 	Latitude         float64
@@ -88,16 +358,58 @@ type PrayerProfile struct {
 	Timezone         string
 	PlaceID          string
 	LocationLabel    string // Only a user-supplied label may be persisted here.
-	CountryCode      string // ISO 3166-1 alpha-2, resolved from the location; used only to default currency.
+	CountryCode      string // ISO 3166-1 alpha-2, resolved from the location.
+	ElevationMeters  int    // Height above sea level, used for the horizon dip at sunrise and Maghrib.
 	Method           Method
+	Custom           CustomMethod // Only used when Method is MethodCustom; kept otherwise so switching back restores it.
 	Madhab           Madhab
 	HighLatitudeRule HighLatitudeRule
 	Adjustments      Adjustments
+	Precaution       Precaution
+	Jumuah           Jumuah
+	HijriCalendar    HijriCalendar
 	HijriAdjustment  int
+	// HijriAnnouncements are the announcements published for CountryCode,
+	// oldest first. The store fills them when it loads a profile and never
+	// saves them with it.
+	HijriAnnouncements []HijriAnnouncement
+	// PersonalEvents are the chat's own yearly events, oldest first. Like the
+	// announcements they are loaded with the profile and saved on their own.
+	PersonalEvents []PersonalEvent
+	// ImsakMinutes is how long before Fajr Imsak falls; ShowExtendedTimes adds
+	// the extended times to schedules and calendar feeds.
+	ImsakMinutes      int
+	ShowExtendedTimes bool
+	HideMakruhTimes   bool // Hides the makruh windows from schedules and calendar feeds.
+	// MoonEvents adds the new moon and the expected first crescent to
+	// calendar feeds as all-day events.
+	MoonEvents bool
+	// IgnoreTimetables keeps the calculation method where an official
+	// timetable covers the profile and would otherwise replace it.
+	IgnoreTimetables bool
 	Version          int64
 	UpdatedAt        time.Time
 }
 
+// CopyPreferences carries the calculation and display settings of an existing
+// profile over to a profile built for a new location.
+func (p *PrayerProfile) CopyPreferences(current PrayerProfile) {
+	p.Method = current.Method
+	p.Custom = current.Custom
+	p.Madhab = current.Madhab
+	p.HighLatitudeRule = current.HighLatitudeRule
+	p.Adjustments = current.Adjustments
+	p.Precaution = current.Precaution
+	p.Jumuah = current.Jumuah
+	p.HijriCalendar = current.HijriCalendar
+	p.HijriAdjustment = current.HijriAdjustment
+	p.ImsakMinutes = current.ImsakMinutes
+	p.ShowExtendedTimes = current.ShowExtendedTimes
+	p.HideMakruhTimes = current.HideMakruhTimes
+	p.MoonEvents = current.MoonEvents
+	p.IgnoreTimetables = current.IgnoreTimetables
+}
+
 func (p PrayerProfile) Validate() error {
 	if p.Latitude < -90 || p.Latitude > 90 || p.Longitude < -180 || p.Longitude > 180 {
 		return fmt.Errorf("invalid coordinates")
@@ -108,16 +420,36 @@ func (p PrayerProfile) Validate() error {
 	if !p.Method.Valid() {
 		return fmt.Errorf("unsupported method %q", p.Method)
 	}
+	if p.Method == MethodCustom {
+		if err := p.Custom.Validate(); err != nil {
+			return err
+		}
+	}
 	if !p.Madhab.Valid() {
 		return fmt.Errorf("unsupported madhab %q", p.Madhab)
 	}
 	if !p.HighLatitudeRule.Valid() {
 		return fmt.Errorf("unsupported high-latitude rule %q", p.HighLatitudeRule)
 	}
+	if err := p.Precaution.Validate(); err != nil {
+		return err
+	}
+	if err := p.Jumuah.Validate(); err != nil {
+		return err
+	}
+	if !p.HijriCalendar.OrDefault().Valid() {
+		return fmt.Errorf("unsupported Hijri calendar %q", p.HijriCalendar)
+	}
 	if p.HijriAdjustment < -2 || p.HijriAdjustment > 2 {
 		return fmt.Errorf("hijri adjustment must be between -2 and 2")
 	}
-	_, err := time.LoadLocation(p.Timezone)
+	if p.ElevationMeters < 0 || p.ElevationMeters > MaxElevationMeters {
+		return fmt.Errorf("elevation must be between 0 and %d metres", MaxElevationMeters)
+	}
+	if p.ImsakMinutes < 0 || p.ImsakMinutes > MaxImsakMinutes {
+		return fmt.Errorf("imsak must be between 0 and %d minutes before fajr", MaxImsakMinutes)
+	}
+	_, err := LoadLocation(p.Timezone)
 	return err
 }
 
@@ -160,6 +492,17 @@ const (
 	PrayerIsha    Prayer = "isha"
 )
 
+// Extended times are derived from the prayers above rather than calculated by
+// their own convention. Midnight and the last third split the night from
+// Maghrib to the next Fajr.
+const (
+	PrayerImsak     Prayer = "imsak"
+	PrayerIshraq    Prayer = "ishraq"
+	PrayerDuha      Prayer = "duha"
+	PrayerMidnight  Prayer = "midnight"
+	PrayerLastThird Prayer = "last_third"
+)
+
 func (p Prayer) Valid() bool {
 	switch p {
 	case PrayerFajr, PrayerSunrise, PrayerDhuhr, PrayerAsr, PrayerMaghrib, PrayerIsha:
@@ -169,10 +512,47 @@ func (p Prayer) Valid() bool {
 	}
 }
 
+func (p Prayer) Extended() bool {
+	switch p {
+	case PrayerImsak, PrayerIshraq, PrayerDuha, PrayerMidnight, PrayerLastThird:
+		return true
+	default:
+		return false
+	}
+}
+
+// ExtendedTimes lists the extended times in the order they fall in a day.
+func ExtendedTimes() []Prayer {
+	return []Prayer{PrayerImsak, PrayerIshraq, PrayerDuha, PrayerMidnight, PrayerLastThird}
+}
+
+// MakruhWindow names an interval in which voluntary prayers are disliked.
+type MakruhWindow string
+
+const (
+	MakruhSunrise MakruhWindow = "sunrise"
+	MakruhZenith  MakruhWindow = "zenith"
+	MakruhSunset  MakruhWindow = "sunset"
+)
+
+// MakruhWindows lists the makruh windows in the order they fall in a day.
+func MakruhWindows() []MakruhWindow {
+	return []MakruhWindow{MakruhSunrise, MakruhZenith, MakruhSunset}
+}
+
+type TimeWindow struct {
+	Start time.Time
+	End   time.Time
+}
+
 type DaySchedule struct {
 	Date     time.Time
 	Timezone string
 	Times    map[Prayer]time.Time
+	Makruh   map[MakruhWindow]TimeWindow
+	// Timetable names the official timetable the times were taken from; it
+	// is empty when they were calculated.
+	Timetable string
 }
 
 func (s DaySchedule) At(prayer Prayer) (time.Time, bool) {
@@ -180,6 +560,11 @@ func (s DaySchedule) At(prayer Prayer) (time.Time, bool) {
 	return t, ok && !t.IsZero()
 }
 
+func (s DaySchedule) MakruhWindow(window MakruhWindow) (TimeWindow, bool) {
+	w, ok := s.Makruh[window]
+	return w, ok && !w.Start.IsZero() && !w.End.IsZero()
+}
+
 type ReminderKind string
 
 const (
@@ -194,16 +579,37 @@ const (
 	// ReminderWhiteDays reminds on the evening before each of the 13th, 14th,
 	// and 15th Hijri days (Ayyam al-Bid), when voluntary fasting is recommended.
 	ReminderWhiteDays ReminderKind = "white_days"
+	// ReminderExtendedTime fires at the extended time named by the rule's
+	// Prayer, such as Imsak or the last third of the night.
+	ReminderExtendedTime ReminderKind = "extended_time"
+	// ReminderSuhoor fires OffsetMinutes before Fajr, and ReminderIftar at
+	// Maghrib, on each day of Ramadan only.
+	ReminderSuhoor ReminderKind = "suhoor"
+	ReminderIftar  ReminderKind = "iftar"
+	// ReminderJumuah fires OffsetMinutes before the profile's Jumu'ah
+	// khutbah, on Fridays only.
+	ReminderJumuah ReminderKind = "jumuah"
+	// ReminderPersonalEvents reminds on the evening before each of the chat's
+	// personal events.
+	ReminderPersonalEvents ReminderKind = "personal_event"
+	// ReminderCustom fires at the time and on the days of one of the chat's
+	// own reminders, which the rule carries as Custom.
+	ReminderCustom ReminderKind = "custom"
 )
 
 func (kind ReminderKind) Weekly() bool {
 	return kind == ReminderWeeklyFasting || kind == ReminderWeeklyKahf
 }
 
+func (kind ReminderKind) Ramadan() bool {
+	return kind == ReminderSuhoor || kind == ReminderIftar
+}
+
 func (kind ReminderKind) Occasion() bool {
 	return kind == ReminderOccasionMajor ||
 		kind == ReminderOccasionFasting ||
-		kind == ReminderOccasionObserved
+		kind == ReminderOccasionObserved ||
+		kind == ReminderPersonalEvents
 }
 
 type ReminderRule struct {
@@ -214,12 +620,36 @@ type ReminderRule struct {
 	OffsetMinutes int
 	LocalTime     string
 	Enabled       bool
+	// Custom is the chat's own reminder of a ReminderCustom rule, and nil
+	// for every other kind.
+	Custom *CustomReminder
 }
 
 func SupportedPreReminderMinutes() []int {
 	return []int{0, 5, 10, 15, 20, 30, 45, 60}
 }
 
+// SupportedImsakMinutes lists the Imsak margins offered by the menus. Profiles
+// may hold any value up to MaxImsakMinutes.
+func SupportedImsakMinutes() []int {
+	return []int{0, 5, 10, 15, 20}
+}
+
+// SupportedSuhoorMinutes lists how long before Fajr the suhoor reminder may
+// fire.
+func SupportedSuhoorMinutes() []int {
+	return []int{15, 30, 45, 60}
+}
+
+func ValidSuhoorMinutes(value int) bool {
+	for _, candidate := range SupportedSuhoorMinutes() {
+		if value == candidate {
+			return true
+		}
+	}
+	return false
+}
+
 func ValidPreReminderMinutes(value int) bool {
 	for _, candidate := range SupportedPreReminderMinutes() {
 		if value == candidate {
@@ -255,8 +685,37 @@ type MessageDeletionTask struct {
 	MessageID   int64  `json:"message_id"`
 }
 
+// HijriAnnouncementTask re-plans one chat after the announcements for its
+// country changed. Notify also tells the chat that the month began; it is
+// false when an announcement is withdrawn.
+type HijriAnnouncementTask struct {
+	TaskKey     string `json:"task_key"`
+	ChatID      int64  `json:"chat_id"`
+	CountryCode string `json:"country_code"`
+	HijriYear   int    `json:"hijri_year"`
+	HijriMonth  int    `json:"hijri_month"`
+	StartsOn    string `json:"starts_on"` // YYYY-MM-DD
+	Notify      bool   `json:"notify"`
+}
+
+// CalendarFeed names a calendar a chat can subscribe to. Each feed has its
+// own private link.
+type CalendarFeed string
+
+const (
+	CalendarFeedPrayer CalendarFeed = "prayer"
+	// CalendarFeedFasting lists the obligatory, recommended and prohibited
+	// fasting days.
+	CalendarFeedFasting CalendarFeed = "fasting"
+)
+
+func (f CalendarFeed) Valid() bool {
+	return f == CalendarFeedPrayer || f == CalendarFeedFasting
+}
+
 type CalendarSubscription struct {
 	ChatID       int64
+	Feed         CalendarFeed
 	FeedToken    string
 	UIDNamespace string
 	Enabled      bool
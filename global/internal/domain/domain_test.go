@@ -36,6 +36,14 @@ func TestPrayerProfileValidateRejectsEachInvalidField(t *testing.T) {
 		"unsupported highlat":   func(p *PrayerProfile) { p.HighLatitudeRule = "made_up" },
 		"hijri below range":     func(p *PrayerProfile) { p.HijriAdjustment = -3 },
 		"hijri above range":     func(p *PrayerProfile) { p.HijriAdjustment = 3 },
+		"unsupported calendar":  func(p *PrayerProfile) { p.HijriCalendar = "julian" },
+		"negative imsak":        func(p *PrayerProfile) { p.ImsakMinutes = -1 },
+		"imsak above range":     func(p *PrayerProfile) { p.ImsakMinutes = MaxImsakMinutes + 1 },
+		"negative elevation":    func(p *PrayerProfile) { p.ElevationMeters = -1 },
+		"elevation above range": func(p *PrayerProfile) { p.ElevationMeters = MaxElevationMeters + 1 },
+		"negative ihtiyat":      func(p *PrayerProfile) { p.Precaution.IhtiyatMinutes = -1 },
+		"ihtiyat above range":   func(p *PrayerProfile) { p.Precaution.IhtiyatMinutes = MaxIhtiyatMinutes + 1 },
+		"unsupported rounding":  func(p *PrayerProfile) { p.Precaution.Asr = "sideways" },
 	}
 	for name, mutate := range tests {
 		t.Run(name, func(t *testing.T) {
@@ -56,6 +64,46 @@ func TestPrayerProfileValidateAcceptsHijriBoundaries(t *testing.T) {
 			t.Fatalf("hijri adjustment %d should be valid: %v", adjustment, err)
 		}
 	}
+	for _, system := range append(SupportedHijriCalendars(), "") {
+		profile := validProfile()
+		profile.HijriCalendar = system
+		if err := profile.Validate(); err != nil {
+			t.Fatalf("hijri calendar %q should be valid: %v", system, err)
+		}
+	}
+}
+
+func TestClampElevation(t *testing.T) {
+	for input, want := range map[float64]int{-28.4: 0, 0: 0, 2240.6: 2241, 12000: MaxElevationMeters} {
+		if got := ClampElevation(input); got != want {
+			t.Errorf("ClampElevation(%v) = %d, want %d", input, got, want)
+		}
+	}
+}
+
+func TestPrecautionAddsIhtiyatThenRoundsEachPrayerItsOwnWay(t *testing.T) {
+	precaution := Precaution{IhtiyatMinutes: 2, Fajr: RoundDown, Maghrib: RoundUp}
+	at := time.Date(2026, time.July, 17, 4, 10, 40, 0, time.UTC)
+	for prayer, want := range map[Prayer]string{
+		PrayerFajr:    "04:12", // 04:12:40 down
+		PrayerSunrise: "04:11", // no ihtiyat, 04:10:40 to the nearest minute
+		PrayerDhuhr:   "04:13", // 04:12:40 to the nearest minute
+		PrayerMaghrib: "04:13", // 04:12:40 up
+	} {
+		if got := precaution.Apply(prayer, at).Format("15:04:05"); got != want+":00" {
+			t.Errorf("%s = %s, want %s", prayer, got, want)
+		}
+	}
+	exact := time.Date(2026, time.July, 17, 19, 30, 0, 0, time.UTC)
+	if got := precaution.Apply(PrayerMaghrib, exact); !got.Equal(exact.Add(2 * time.Minute)) {
+		t.Errorf("rounding a whole minute up should keep it, got %s", got)
+	}
+	if got := precaution.Apply(PrayerIsha, time.Time{}); !got.IsZero() {
+		t.Errorf("a missing time should stay missing, got %s", got)
+	}
+	if !(Precaution{}).IsDefault() || !(Precaution{Asr: RoundNearest}).IsDefault() || precaution.IsDefault() {
+		t.Error("only a policy without a margin that rounds to the nearest minute is the default")
+	}
 }
 
 func TestRoundedCoordinatesLimitsToThreeDecimals(t *testing.T) {
@@ -85,8 +133,41 @@ func TestMethodValidCoversEverySupportedMethod(t *testing.T) {
 	if Method("nonsense").Valid() {
 		t.Fatal("unknown method reported valid")
 	}
-	if len(SupportedMethods()) != 9 {
-		t.Fatalf("expected 9 supported methods, got %d", len(SupportedMethods()))
+	if len(SupportedMethods()) != 17 {
+		t.Fatalf("expected 17 supported methods, got %d", len(SupportedMethods()))
+	}
+}
+
+func TestPrayerProfileValidateChecksCustomParametersOnlyForCustomMethod(t *testing.T) {
+	profile := validProfile()
+	profile.Custom = CustomMethod{FajrAngle: 40}
+	if err := profile.Validate(); err != nil {
+		t.Fatalf("custom parameters must be ignored for preset methods: %v", err)
+	}
+
+	profile.Method = MethodCustom
+	profile.Custom = DefaultCustomMethod()
+	if err := profile.Validate(); err != nil {
+		t.Fatalf("default custom parameters should be valid: %v", err)
+	}
+	profile.Custom.IshaIntervalMinutes = 90
+	if err := profile.Validate(); err != nil {
+		t.Fatalf("a fixed Isha interval should be valid: %v", err)
+	}
+
+	tests := map[string]CustomMethod{
+		"unset":             {},
+		"fajr too shallow":  {FajrAngle: 9.5, IshaAngle: 17},
+		"fajr too deep":     {FajrAngle: 22.5, IshaAngle: 17},
+		"isha too shallow":  {FajrAngle: 18, IshaAngle: 9},
+		"negative interval": {FajrAngle: 18, IshaAngle: 17, IshaIntervalMinutes: -1},
+		"interval too long": {FajrAngle: 18, IshaAngle: 17, IshaIntervalMinutes: MaxCustomIshaInterval + 1},
+	}
+	for name, custom := range tests {
+		profile.Custom = custom
+		if err := profile.Validate(); err == nil {
+			t.Errorf("expected %s custom parameters to be rejected", name)
+		}
 	}
 }
 
@@ -120,6 +201,17 @@ func TestPrayerValid(t *testing.T) {
 	}
 }
 
+func TestExtendedTimesAreNotPrayers(t *testing.T) {
+	for _, prayer := range ExtendedTimes() {
+		if !prayer.Extended() || prayer.Valid() {
+			t.Fatalf("%q should be an extended time only", prayer)
+		}
+	}
+	if PrayerFajr.Extended() {
+		t.Fatal("Fajr reported as an extended time")
+	}
+}
+
 func TestReminderKindClassifiers(t *testing.T) {
 	weekly := []ReminderKind{ReminderWeeklyFasting, ReminderWeeklyKahf}
 	occasion := []ReminderKind{ReminderOccasionMajor, ReminderOccasionFasting, ReminderOccasionObserved}
@@ -27,6 +27,24 @@ type MetricCount struct {
 	Count int64
 }
 
+// CacheStats are a process's prayer-time cache counters since it started.
+// Bytes is an estimate of the memory the cached years hold.
+type CacheStats struct {
+	Hits      int64
+	Misses    int64
+	Evictions int64
+	Entries   int
+	Bytes     int64
+}
+
+// HitRate is the share of lookups served from the cache, in percent.
+func (s CacheStats) HitRate() float64 {
+	if s.Hits+s.Misses == 0 {
+		return 0
+	}
+	return float64(s.Hits) * 100 / float64(s.Hits+s.Misses)
+}
+
 // AdminDashboard aggregates the owner dashboard metrics.
 type AdminDashboard struct {
 	Users                   int64
@@ -50,6 +68,8 @@ type AdminDashboard struct {
 	Languages               []MetricCount
 	Methods                 []MetricCount
 	ReminderKinds           []MetricCount
+	// CalculatorCache is filled by the serving process, not the store.
+	CalculatorCache CacheStats
 }
 
 // OutboxItem is one pending transactional-outbox row awaiting Cloud Tasks
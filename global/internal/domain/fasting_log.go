@@ -0,0 +1,61 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// FastStatus is what a chat logged for a day.
+type FastStatus string
+
+const (
+	FastKept FastStatus = "fasted"
+	// FastMakeUp is a fast kept outside Ramadan in place of a missed Ramadan
+	// day.
+	FastMakeUp FastStatus = "makeup"
+	FastMissed FastStatus = "missed"
+)
+
+func (s FastStatus) Valid() bool {
+	return s == FastKept || s == FastMakeUp || s == FastMissed
+}
+
+// MaxQadaCarried caps the make-up fasts a chat may carry from before it kept
+// a log.
+const MaxQadaCarried = 1000
+
+var (
+	// ErrFastProhibited is returned when a day on which fasting is
+	// prohibited, such as an Eid, is logged as fasted.
+	ErrFastProhibited = errors.New("fasting is prohibited on this day")
+	// ErrMakeUpInRamadan is returned when a Ramadan day is logged as a
+	// make-up fast.
+	ErrMakeUpInRamadan = errors.New("a Ramadan day cannot make up another")
+)
+
+// FastLogEntry is one logged day. Date is the civil date at UTC midnight.
+// Ramadan is fixed when the day is logged, by the chat's calendar then.
+type FastLogEntry struct {
+	ChatID  int64
+	Date    time.Time
+	Status  FastStatus
+	Ramadan bool
+}
+
+// Owed reports whether the entry adds a make-up fast to the balance.
+func (e FastLogEntry) Owed() bool { return e.Ramadan && e.Status == FastMissed }
+
+// FastingBalance counts a chat's log. Missed counts only Ramadan days, and
+// Kept only fasts that were not make-ups.
+type FastingBalance struct {
+	Carried int
+	Missed  int
+	MadeUp  int
+	Kept    int
+}
+
+// Owed is the number of make-up fasts still due. Making up more days than
+// were missed leaves nothing owed rather than a credit.
+func (b FastingBalance) Owed() int {
+	return max(0, b.Carried+b.Missed-b.MadeUp)
+}
@@ -0,0 +1,25 @@
+package domain
+
+import "testing"
+
+func TestFastingBalanceOwesMissedRamadanDaysUntilMadeUp(t *testing.T) {
+	cases := []struct {
+		balance FastingBalance
+		want    int
+	}{
+		{FastingBalance{}, 0},
+		{FastingBalance{Carried: 3, Missed: 2, MadeUp: 1, Kept: 9}, 4},
+		{FastingBalance{Missed: 2, MadeUp: 5}, 0},
+	}
+	for _, tc := range cases {
+		if got := tc.balance.Owed(); got != tc.want {
+			t.Fatalf("%+v owes %d, want %d", tc.balance, got, tc.want)
+		}
+	}
+	if (FastLogEntry{Status: FastMissed}).Owed() || !(FastLogEntry{Status: FastMissed, Ramadan: true}).Owed() {
+		t.Fatal("only a missed Ramadan day is owed")
+	}
+	if FastStatus("maybe").Valid() || !FastMakeUp.Valid() {
+		t.Fatal("FastStatus.Valid accepts the wrong statuses")
+	}
+}
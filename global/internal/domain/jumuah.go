@@ -0,0 +1,105 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Jumuah is the Friday prayer as the chat's mosque holds it, as local clock
+// times in HH:MM. On Fridays the khutbah takes Dhuhr's place in schedules,
+// reminders, and calendar feeds. The zero value means the chat has not set
+// it, and Iqamah is optional.
+type Jumuah struct {
+	Khutbah string `json:"khutbah,omitempty"`
+	Iqamah  string `json:"iqamah,omitempty"`
+}
+
+// maxJumuahIqamahDelay bounds how long after the khutbah starts the iqamah may
+// be called; real khutbahs take well under an hour.
+const maxJumuahIqamahDelay = 90 * time.Minute
+
+func (j Jumuah) Enabled() bool { return j.Khutbah != "" }
+
+func (j Jumuah) Validate() error {
+	if !j.Enabled() {
+		if j.Iqamah != "" {
+			return fmt.Errorf("Jumu'ah iqamah needs a khutbah time")
+		}
+		return nil
+	}
+	khutbah, err := time.Parse("15:04", j.Khutbah)
+	if err != nil {
+		return fmt.Errorf("Jumu'ah khutbah time %q is not HH:MM", j.Khutbah)
+	}
+	if j.Iqamah == "" {
+		return nil
+	}
+	iqamah, err := time.Parse("15:04", j.Iqamah)
+	if err != nil {
+		return fmt.Errorf("Jumu'ah iqamah time %q is not HH:MM", j.Iqamah)
+	}
+	if delay := iqamah.Sub(khutbah); delay <= 0 || delay > maxJumuahIqamahDelay {
+		return fmt.Errorf("Jumu'ah iqamah must follow the khutbah within %d minutes", int(maxJumuahIqamahDelay.Minutes()))
+	}
+	return nil
+}
+
+// On returns the khutbah and, when set, the iqamah instants of date's day in
+// date's location. It reports false on days other than Friday and when the
+// chat has no Jumu'ah time.
+func (j Jumuah) On(date time.Time) (khutbah, iqamah time.Time, ok bool) {
+	if !j.Enabled() || date.Weekday() != time.Friday {
+		return time.Time{}, time.Time{}, false
+	}
+	at := func(clock string) time.Time {
+		parsed, err := time.Parse("15:04", clock)
+		if err != nil {
+			return time.Time{}
+		}
+		return time.Date(date.Year(), date.Month(), date.Day(), parsed.Hour(), parsed.Minute(), 0, 0, date.Location())
+	}
+	khutbah = at(j.Khutbah)
+	if j.Iqamah != "" {
+		iqamah = at(j.Iqamah)
+	}
+	return khutbah, iqamah, !khutbah.IsZero()
+}
+
+// ParseJumuah reads "13:15" or "13:15 13:30", the khutbah followed by the
+// optional iqamah, as typed after /jumuah.
+func ParseJumuah(argument string) (Jumuah, error) {
+	fields := strings.Fields(argument)
+	if len(fields) == 0 || len(fields) > 2 {
+		return Jumuah{}, fmt.Errorf("expected a khutbah time and an optional iqamah time")
+	}
+	jumuah := Jumuah{Khutbah: normalizeClock(fields[0])}
+	if len(fields) == 2 {
+		jumuah.Iqamah = normalizeClock(fields[1])
+	}
+	return jumuah, jumuah.Validate()
+}
+
+// normalizeClock accepts 1:15 or 13.15 for 13:15 style times.
+func normalizeClock(value string) string {
+	value = strings.ReplaceAll(value, ".", ":")
+	if parsed, err := time.Parse("15:04", value); err == nil {
+		return parsed.Format("15:04")
+	}
+	return value
+}
+
+// SupportedJumuahMinutes are the lead times of the Friday reminder before the
+// khutbah.
+func SupportedJumuahMinutes() []int {
+	return []int{15, 30, 45, 60, 90}
+}
+
+func ValidJumuahMinutes(value int) bool {
+	for _, candidate := range SupportedJumuahMinutes() {
+		if value == candidate {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,34 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseJumuahReadsKhutbahAndOptionalIqamah(t *testing.T) {
+	jumuah, err := ParseJumuah(" 13.15  13:45 ")
+	if err != nil || jumuah != (Jumuah{Khutbah: "13:15", Iqamah: "13:45"}) {
+		t.Fatalf("ParseJumuah = %+v, %v", jumuah, err)
+	}
+	for _, argument := range []string{"", "13:15 13:00", "13:15 15:00", "25:00", "13:15 13:30 13:45"} {
+		if _, err := ParseJumuah(argument); err == nil {
+			t.Errorf("ParseJumuah(%q) should fail", argument)
+		}
+	}
+}
+
+func TestJumuahOnIsFridayOnly(t *testing.T) {
+	location := time.FixedZone("test", 3*60*60)
+	jumuah := Jumuah{Khutbah: "12:30", Iqamah: "13:00"}
+	khutbah, iqamah, ok := jumuah.On(time.Date(2026, time.July, 17, 8, 0, 0, 0, location))
+	if !ok || !khutbah.Equal(time.Date(2026, time.July, 17, 12, 30, 0, 0, location)) ||
+		!iqamah.Equal(time.Date(2026, time.July, 17, 13, 0, 0, 0, location)) {
+		t.Fatalf("On(Friday) = %s, %s, %v", khutbah, iqamah, ok)
+	}
+	if _, _, ok := jumuah.On(time.Date(2026, time.July, 18, 8, 0, 0, 0, location)); ok {
+		t.Fatal("On(Saturday) should report false")
+	}
+	if _, _, ok := (Jumuah{}).On(time.Date(2026, time.July, 17, 8, 0, 0, 0, location)); ok {
+		t.Fatal("an unset Jumu'ah should report false")
+	}
+}
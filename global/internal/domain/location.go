@@ -0,0 +1,23 @@
+package domain
+
+import (
+	"sync"
+	"time"
+)
+
+var locations sync.Map // name -> *time.Location
+
+// LoadLocation is time.LoadLocation with a process-wide cache. The standard
+// library reads and parses the zone file on every call, which costs more than
+// serving a cached prayer day. Unknown names are not cached.
+func LoadLocation(name string) (*time.Location, error) {
+	if location, ok := locations.Load(name); ok {
+		return location.(*time.Location), nil
+	}
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, err
+	}
+	locations.Store(name, location)
+	return location, nil
+}
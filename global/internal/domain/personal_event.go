@@ -0,0 +1,58 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// MaxPersonalEvents caps how many personal events one chat may keep.
+const MaxPersonalEvents = 20
+
+// ErrPersonalEventLimit is returned when a chat already keeps
+// MaxPersonalEvents events.
+var ErrPersonalEventLimit = errors.New("too many personal events")
+
+// MaxPersonalEventTitle is the longest title, in characters, an event may
+// have.
+const MaxPersonalEventTitle = 64
+
+// PersonalEvent is a yearly date a chat added for itself, such as a
+// relative's death anniversary or a Hijri birthday. Hijri events recur on
+// the same Hijri day in the chat's calendar, Gregorian ones on the same civil
+// day.
+type PersonalEvent struct {
+	ID        int64
+	ChatID    int64
+	Title     string
+	Hijri     bool
+	Month     int
+	Day       int
+	CreatedAt time.Time
+}
+
+func (e PersonalEvent) Validate() error {
+	title := strings.TrimSpace(e.Title)
+	if title == "" || utf8.RuneCountInString(title) > MaxPersonalEventTitle {
+		return fmt.Errorf("an event title must be 1 to %d characters", MaxPersonalEventTitle)
+	}
+	if strings.ContainsAny(title, "\r\n") {
+		return fmt.Errorf("an event title must fit on one line")
+	}
+	if e.Month < 1 || e.Month > 12 || e.Day < 1 {
+		return fmt.Errorf("invalid event date")
+	}
+	if e.Hijri {
+		if e.Day > 30 {
+			return fmt.Errorf("a Hijri month has at most 30 days")
+		}
+		return nil
+	}
+	// 2000 is a leap year, so 29 February is accepted.
+	if time.Date(2000, time.Month(e.Month), e.Day, 0, 0, 0, 0, time.UTC).Day() != e.Day {
+		return fmt.Errorf("invalid event date")
+	}
+	return nil
+}
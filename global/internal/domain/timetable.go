@@ -0,0 +1,99 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"time"
+)
+
+// MaxTimetableRadiusKm bounds a place-bound timetable to a metropolitan area.
+// Published times drift by about a minute every 20 km east or west, so a wider
+// circle would serve noticeably wrong times at its edge.
+const MaxTimetableRadiusKm = 100
+
+// Timetable is an official timetable that a mosque or muftiate publishes
+// instead of, or in spite of, any formula. A place-bound timetable covers a
+// circle of RadiusKm around its coordinates; a region-bound one (RadiusKm 0)
+// covers a whole country. Either way it only serves profiles in its timezone,
+// because its rows are local clock times.
+type Timetable struct {
+	ID          int64
+	Name        string
+	Timezone    string
+	CountryCode string
+	Latitude    float64
+	Longitude   float64
+	RadiusKm    int
+	// First, Last, and Days describe the uploaded rows; the store fills them.
+	First     time.Time
+	Last      time.Time
+	Days      int
+	CreatedAt time.Time
+}
+
+var countryCodePattern = regexp.MustCompile(`^[A-Z]{2}$`)
+
+func (t Timetable) Validate() error {
+	if t.Name == "" || len([]rune(t.Name)) > 100 {
+		return fmt.Errorf("timetable name must be 1-100 characters")
+	}
+	if _, err := LoadLocation(t.Timezone); err != nil || t.Timezone == "" {
+		return fmt.Errorf("unknown timetable timezone %q", t.Timezone)
+	}
+	if t.PlaceBound() {
+		if t.RadiusKm > MaxTimetableRadiusKm {
+			return fmt.Errorf("timetable radius must be between 1 and %d km", MaxTimetableRadiusKm)
+		}
+		if t.Latitude < -90 || t.Latitude > 90 || t.Longitude < -180 || t.Longitude > 180 {
+			return fmt.Errorf("timetable coordinates are out of range")
+		}
+		return nil
+	}
+	if t.RadiusKm < 0 || !countryCodePattern.MatchString(t.CountryCode) {
+		return fmt.Errorf("a region timetable needs a two-letter country code")
+	}
+	return nil
+}
+
+// PlaceBound reports whether the timetable covers a circle rather than a
+// country.
+func (t Timetable) PlaceBound() bool { return t.RadiusKm > 0 }
+
+// Covers reports whether the timetable applies to the profile's location.
+func (t Timetable) Covers(profile PrayerProfile) bool {
+	if t.Timezone != profile.Timezone {
+		return false
+	}
+	if t.PlaceBound() {
+		return DistanceKm(t.Latitude, t.Longitude, profile.Latitude, profile.Longitude) <= float64(t.RadiusKm)
+	}
+	return t.CountryCode == profile.CountryCode
+}
+
+// TimetableDay is one published row: the minutes after local midnight at
+// which each of the six daily times begins.
+type TimetableDay struct {
+	Date    time.Time
+	Minutes map[Prayer]int
+}
+
+// At returns the row's time for the prayer on its date in location.
+func (d TimetableDay) At(prayer Prayer, location *time.Location) (time.Time, bool) {
+	minutes, ok := d.Minutes[prayer]
+	if !ok {
+		return time.Time{}, false
+	}
+	return time.Date(d.Date.Year(), d.Date.Month(), d.Date.Day(), 0, minutes, 0, 0, location), true
+}
+
+// DistanceKm is the haversine great-circle distance between two coordinates.
+func DistanceKm(fromLatitude, fromLongitude, toLatitude, toLongitude float64) float64 {
+	const earthRadiusKm = 6371.0088
+	radians := func(degrees float64) float64 { return degrees * math.Pi / 180 }
+	fromRadians, toRadians := radians(fromLatitude), radians(toLatitude)
+	latitudeDelta, longitudeDelta := toRadians-fromRadians, radians(toLongitude-fromLongitude)
+	haversine := math.Sin(latitudeDelta/2)*math.Sin(latitudeDelta/2) +
+		math.Cos(fromRadians)*math.Cos(toRadians)*math.Sin(longitudeDelta/2)*math.Sin(longitudeDelta/2)
+	return 2 * earthRadiusKm * math.Asin(math.Sqrt(math.Max(0, math.Min(1, haversine))))
+}
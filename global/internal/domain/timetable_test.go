@@ -0,0 +1,37 @@
+package domain
+
+import (
+	"math"
+	"testing"
+)
+
+func TestTimetableCoversItsCircleOrCountryInItsTimezone(t *testing.T) {
+	kazan := PrayerProfile{Latitude: 55.79, Longitude: 49.12, Timezone: "Europe/Moscow", CountryCode: "RU"}
+	place := Timetable{Name: "Kazan", Timezone: "Europe/Moscow", Latitude: 55.796, Longitude: 49.106, RadiusKm: 40}
+	country := Timetable{Name: "Russia", Timezone: "Europe/Moscow", CountryCode: "RU"}
+	for _, timetable := range []Timetable{place, country} {
+		if err := timetable.Validate(); err != nil {
+			t.Fatalf("%s should be valid: %v", timetable.Name, err)
+		}
+		if !timetable.Covers(kazan) {
+			t.Fatalf("%s should cover Kazan", timetable.Name)
+		}
+	}
+	samara := PrayerProfile{Latitude: 53.2, Longitude: 50.15, Timezone: "Europe/Samara", CountryCode: "RU"}
+	if place.Covers(samara) || country.Covers(samara) {
+		t.Fatal("a timetable must not cover another timezone")
+	}
+	if distance := DistanceKm(55.7558, 37.6173, 55.796, 49.106); math.Abs(distance-719) > 5 {
+		t.Fatalf("Moscow to Kazan = %.0f km, want about 719", distance)
+	}
+	for _, invalid := range []Timetable{
+		{Timezone: "Europe/Moscow", CountryCode: "RU"},
+		{Name: "Kazan", Timezone: "Europe/Moscow", Latitude: 55.796, Longitude: 49.106, RadiusKm: 150},
+		{Name: "Russia", Timezone: "Europe/Moscow", CountryCode: "Russia"},
+		{Name: "Russia", CountryCode: "RU"},
+	} {
+		if invalid.Validate() == nil {
+			t.Errorf("%+v should be rejected", invalid)
+		}
+	}
+}
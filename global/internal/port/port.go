@@ -13,7 +13,8 @@ import (
 )
 
 // Calculator computes one local prayer day for a profile.
-// Implemented by core/prayertime.LocalCalculator.
+// Implemented by core/prayertime.LocalCalculator, for either engine, and by
+// prayertime.Comparison, which runs two of them side by side.
 type Calculator interface {
 	Day(context.Context, time.Time, domain.PrayerProfile) (domain.DaySchedule, error)
 }
@@ -27,6 +28,27 @@ type LocationResolver interface {
 	Search(ctx context.Context, query, language string) ([]domain.LocationCandidate, error)
 }
 
+// ElevationResolver is an optional LocationResolver capability that looks up
+// the ground elevation of coordinates in metres. Implemented by
+// adapter/out/location.GoogleMaps.
+type ElevationResolver interface {
+	Elevation(context.Context, float64, float64) (float64, error)
+}
+
+// CacheReporter is an optional Calculator capability that reports the
+// process's prayer-time cache counters for the owner dashboard. Implemented by
+// prayertime.LocalCalculator and forwarded by the calculators that wrap one.
+type CacheReporter interface {
+	CacheStats() domain.CacheStats
+}
+
+// TimetableSource lists the uploaded official timetables and their rows for
+// prayertime.Official. Implemented by adapter/out/store.Store.
+type TimetableSource interface {
+	Timetables(ctx context.Context) ([]domain.Timetable, error)
+	TimetableDays(ctx context.Context, timetableID int64, from, to time.Time) ([]domain.TimetableDay, error)
+}
+
 // MetalSource fetches the daily gold/silver spot prices and USD rate table
 // backing the Zakat niSab. Implemented by adapter/out/metals.Client.
 type MetalSource interface {
@@ -61,6 +83,9 @@ type Store interface {
 	SetWeeklyRule(ctx context.Context, chatID int64, kind domain.ReminderKind, enabled bool) error
 	SetWhiteDaysRule(ctx context.Context, chatID int64, enabled bool) error
 	SetOccasionRule(ctx context.Context, chatID int64, kind domain.ReminderKind, enabled bool) error
+	SetExtendedTimeRule(ctx context.Context, chatID int64, prayer domain.Prayer, enabled bool) error
+	SetRamadanRule(ctx context.Context, chatID int64, kind domain.ReminderKind, minutes int, enabled bool) error
+	SetJumuahRule(ctx context.Context, chatID int64, minutes int, enabled bool) error
 	EnabledRules(ctx context.Context, chatID int64) ([]domain.ReminderRule, error)
 	Rule(ctx context.Context, ruleID int64) (domain.ReminderRule, error)
 	UpsertSchedule(ctx context.Context, schedule domain.ReminderSchedule) (domain.ReminderSchedule, error)
@@ -76,12 +101,43 @@ type Store interface {
 	ClearNotificationMessage(ctx context.Context, chatID, messageID int64) error
 	MarkDeliveryStale(ctx context.Context, deliveryKey string) error
 	FailDelivery(ctx context.Context, deliveryKey string, cause error) error
+	AcquireAnnouncement(ctx context.Context, deliveryKey string, chatID int64) (bool, error)
+	CompleteAnnouncement(ctx context.Context, deliveryKey string, messageID int64) error
+	FailAnnouncement(ctx context.Context, deliveryKey string, cause error) error
 
-	// Calendar subscriptions.
-	CalendarSubscription(ctx context.Context, chatID int64) (domain.CalendarSubscription, error)
+	// Calendar subscriptions, one per chat and feed.
+	CalendarSubscription(ctx context.Context, chatID int64, feed domain.CalendarFeed) (domain.CalendarSubscription, error)
 	CalendarSubscriptionByToken(ctx context.Context, feedToken string) (domain.CalendarSubscription, error)
-	EnableCalendarSubscription(ctx context.Context, chatID int64, feedToken, uidNamespace string) (domain.CalendarSubscription, error)
-	DisableCalendarSubscription(ctx context.Context, chatID int64) error
+	EnableCalendarSubscription(ctx context.Context, chatID int64, feed domain.CalendarFeed, feedToken, uidNamespace string) (domain.CalendarSubscription, error)
+	DisableCalendarSubscription(ctx context.Context, chatID int64, feed domain.CalendarFeed) error
+
+	// Official timetables uploaded by the owner.
+	Timetables(ctx context.Context) ([]domain.Timetable, error)
+	TimetableDays(ctx context.Context, timetableID int64, from, to time.Time) ([]domain.TimetableDay, error)
+	CreateTimetable(ctx context.Context, timetable domain.Timetable, days []domain.TimetableDay) (domain.Timetable, error)
+	DeleteTimetable(ctx context.Context, timetableID int64) error
+
+	// Personal events; a profile carries the chat's events when it loads.
+	AddPersonalEvent(ctx context.Context, event domain.PersonalEvent) (domain.PersonalEvent, error)
+	DeletePersonalEvent(ctx context.Context, chatID, eventID int64) error
+
+	// The chat's own reminders; each owns a domain.ReminderCustom rule.
+	CustomReminders(ctx context.Context, chatID int64) ([]domain.CustomReminder, error)
+	AddCustomReminder(ctx context.Context, reminder domain.CustomReminder) (domain.CustomReminder, error)
+	UpdateCustomReminder(ctx context.Context, reminder domain.CustomReminder) error
+	DeleteCustomReminder(ctx context.Context, chatID, ruleID int64) error
+
+	// The chat's fasting log and the make-up fasts it carried from before.
+	LogFast(ctx context.Context, entry domain.FastLogEntry) error
+	ClearFast(ctx context.Context, chatID int64, date time.Time) error
+	FastLog(ctx context.Context, chatID int64, from, to time.Time) ([]domain.FastLogEntry, error)
+	FastingBalance(ctx context.Context, chatID int64) (domain.FastingBalance, error)
+	SetQadaCarried(ctx context.Context, chatID int64, days int) error
+
+	// Regional Hijri month announcements published by the owner.
+	HijriAnnouncements(ctx context.Context) ([]domain.HijriAnnouncement, error)
+	PublishHijriAnnouncement(ctx context.Context, announcement domain.HijriAnnouncement, notify bool) (int, error)
+	DeleteHijriAnnouncement(ctx context.Context, countryCode string, year, month int) (int, error)
 
 	// Cached market data and owner metrics.
 	MetalPrices(ctx context.Context) (domain.MetalPrices, error)
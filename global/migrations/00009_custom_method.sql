@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose ENVSUB ON
+-- The custom calculation method keeps its Fajr/Isha angles and the optional
+-- fixed Isha-after-Maghrib interval on the profile. They are stored as JSONB
+-- like adjustments and are kept when the chat switches to a preset, so going
+-- back to "custom" restores the council's values. The method column has no
+-- CHECK constraint, so 'custom' needs no constraint change.
+ALTER TABLE ${GLOBAL_DB_SCHEMA}.prayer_profiles
+    ADD COLUMN custom_method JSONB NOT NULL DEFAULT '{}'::jsonb;
+
+-- +goose Down
+UPDATE ${GLOBAL_DB_SCHEMA}.prayer_profiles SET method = 'mwl' WHERE method = 'custom';
+
+ALTER TABLE ${GLOBAL_DB_SCHEMA}.prayer_profiles
+    DROP COLUMN custom_method;
+-- +goose ENVSUB OFF
@@ -0,0 +1,33 @@
+-- +goose Up
+-- +goose ENVSUB ON
+-- Regional calculation methods: Tehran, Gulf, Kuwait, Qatar, UOIF (France),
+-- the Spiritual Administration of Muslims of Russia, and the Moonsighting
+-- Committee. The method column never had a CHECK constraint, so it now gets
+-- one listing every value the application accepts, like madhab and
+-- high_latitude_rule. A value a downgraded binary cannot read is then rejected
+-- at write time instead of failing later in the calculator.
+ALTER TABLE ${GLOBAL_DB_SCHEMA}.prayer_profiles
+    ADD CONSTRAINT prayer_profiles_method_check
+    CHECK (method IN (
+        'mwl', 'egyptian', 'umm_al_qura', 'karachi', 'isna', 'diyanet',
+        'kemenag', 'muis', 'jakim', 'tehran', 'gulf', 'kuwait', 'qatar',
+        'uoif', 'russia', 'moonsighting', 'custom'
+    ));
+
+-- +goose Down
+-- Map each regional method to the closest older preset so downgraded
+-- binaries keep calculating. Gulf and Qatar share Umm al-Qura's fixed
+-- 90-minute Isha.
+UPDATE ${GLOBAL_DB_SCHEMA}.prayer_profiles
+SET method = CASE method
+        WHEN 'gulf' THEN 'umm_al_qura'
+        WHEN 'qatar' THEN 'umm_al_qura'
+        ELSE 'mwl'
+    END,
+    version = version + 1,
+    updated_at = now()
+WHERE method IN ('tehran', 'gulf', 'kuwait', 'qatar', 'uoif', 'russia', 'moonsighting');
+
+ALTER TABLE ${GLOBAL_DB_SCHEMA}.prayer_profiles
+    DROP CONSTRAINT prayer_profiles_method_check;
+-- +goose ENVSUB OFF
@@ -0,0 +1,88 @@
+-- +goose Up
+-- +goose ENVSUB ON
+-- Extended daily times (Imsak, Ishraq, Duha, Islamic midnight, and the last
+-- third of the night) are derived from the calculated prayers. The profile
+-- keeps how many minutes Imsak precedes Fajr and whether the schedule and
+-- calendar show the extended times at all.
+ALTER TABLE ${GLOBAL_DB_SCHEMA}.prayer_profiles
+    ADD COLUMN imsak_minutes INTEGER NOT NULL DEFAULT 10
+        CHECK (imsak_minutes BETWEEN 0 AND 60),
+    ADD COLUMN show_extended_times BOOLEAN NOT NULL DEFAULT false;
+
+-- An extended_time rule fires at the extended time named in its prayer
+-- column. Its messages get their own cleanup slot so an Imsak or night notice
+-- never removes the preceding prayer message.
+ALTER TABLE ${GLOBAL_DB_SCHEMA}.reminder_rules
+    DROP CONSTRAINT reminder_rules_prayer_check;
+
+ALTER TABLE ${GLOBAL_DB_SCHEMA}.reminder_rules
+    ADD CONSTRAINT reminder_rules_prayer_check
+    CHECK (prayer IN (
+        'fajr', 'sunrise', 'dhuhr', 'asr', 'maghrib', 'isha',
+        'imsak', 'ishraq', 'duha', 'midnight', 'last_third'
+    ));
+
+ALTER TABLE ${GLOBAL_DB_SCHEMA}.reminder_rules
+    DROP CONSTRAINT reminder_rules_kind_check;
+
+ALTER TABLE ${GLOBAL_DB_SCHEMA}.reminder_rules
+    ADD CONSTRAINT reminder_rules_kind_check
+    CHECK (kind IN (
+        'before', 'at', 'tomorrow', 'weekly_fasting', 'weekly_kahf',
+        'occasion_major', 'occasion_fasting', 'occasion_observed',
+        'white_days', 'extended_time'
+    ));
+
+ALTER TABLE ${GLOBAL_DB_SCHEMA}.notification_message_slots
+    DROP CONSTRAINT notification_message_slots_category_check;
+
+ALTER TABLE ${GLOBAL_DB_SCHEMA}.notification_message_slots
+    ADD CONSTRAINT notification_message_slots_category_check
+    CHECK (category IN (
+        'prayer', 'tomorrow', 'weekly_fasting', 'weekly_kahf',
+        'islamic_occasion', 'extended_time'
+    ));
+
+-- +goose Down
+DELETE FROM ${GLOBAL_DB_SCHEMA}.notification_message_slots
+WHERE category = 'extended_time';
+
+ALTER TABLE ${GLOBAL_DB_SCHEMA}.notification_message_slots
+    DROP CONSTRAINT notification_message_slots_category_check;
+
+ALTER TABLE ${GLOBAL_DB_SCHEMA}.notification_message_slots
+    ADD CONSTRAINT notification_message_slots_category_check
+    CHECK (category IN (
+        'prayer', 'tomorrow', 'weekly_fasting', 'weekly_kahf',
+        'islamic_occasion'
+    ));
+
+DELETE FROM ${GLOBAL_DB_SCHEMA}.reminder_schedules s
+USING ${GLOBAL_DB_SCHEMA}.reminder_rules r
+WHERE s.rule_id = r.id AND r.kind = 'extended_time';
+
+DELETE FROM ${GLOBAL_DB_SCHEMA}.reminder_rules
+WHERE kind = 'extended_time';
+
+ALTER TABLE ${GLOBAL_DB_SCHEMA}.reminder_rules
+    DROP CONSTRAINT reminder_rules_kind_check;
+
+ALTER TABLE ${GLOBAL_DB_SCHEMA}.reminder_rules
+    ADD CONSTRAINT reminder_rules_kind_check
+    CHECK (kind IN (
+        'before', 'at', 'tomorrow', 'weekly_fasting', 'weekly_kahf',
+        'occasion_major', 'occasion_fasting', 'occasion_observed',
+        'white_days'
+    ));
+
+ALTER TABLE ${GLOBAL_DB_SCHEMA}.reminder_rules
+    DROP CONSTRAINT reminder_rules_prayer_check;
+
+ALTER TABLE ${GLOBAL_DB_SCHEMA}.reminder_rules
+    ADD CONSTRAINT reminder_rules_prayer_check
+    CHECK (prayer IN ('fajr', 'sunrise', 'dhuhr', 'asr', 'maghrib', 'isha'));
+
+ALTER TABLE ${GLOBAL_DB_SCHEMA}.prayer_profiles
+    DROP COLUMN show_extended_times,
+    DROP COLUMN imsak_minutes;
+-- +goose ENVSUB OFF
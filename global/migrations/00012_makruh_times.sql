@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose ENVSUB ON
+-- The makruh windows around sunrise, the zenith, and sunset are derived from
+-- the calculated prayers and shown by default; the profile only records a
+-- chat's choice to hide them.
+ALTER TABLE ${GLOBAL_DB_SCHEMA}.prayer_profiles
+    ADD COLUMN hide_makruh_times BOOLEAN NOT NULL DEFAULT false;
+
+-- +goose Down
+ALTER TABLE ${GLOBAL_DB_SCHEMA}.prayer_profiles
+    DROP COLUMN hide_makruh_times;
+-- +goose ENVSUB OFF
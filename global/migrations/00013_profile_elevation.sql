@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose ENVSUB ON
+-- Elevation in metres lets the calculator apply the horizon dip to sunrise and
+-- Maghrib. Existing profiles stay at sea level until their location is saved
+-- again or the chat enters a value.
+ALTER TABLE ${GLOBAL_DB_SCHEMA}.prayer_profiles
+    ADD COLUMN elevation_meters INTEGER NOT NULL DEFAULT 0
+        CHECK (elevation_meters BETWEEN 0 AND 9000);
+
+-- +goose Down
+ALTER TABLE ${GLOBAL_DB_SCHEMA}.prayer_profiles
+    DROP COLUMN elevation_meters;
+-- +goose ENVSUB OFF
@@ -0,0 +1,36 @@
+-- +goose Up
+-- +goose ENVSUB ON
+-- Official timetables published by mosques and muftiates, uploaded by the
+-- owner. A timetable with a radius covers that circle around its coordinates;
+-- one without covers its whole country. It is global data, not chat-owned.
+CREATE TABLE ${GLOBAL_DB_SCHEMA}.timetables (
+    id BIGSERIAL PRIMARY KEY,
+    name TEXT NOT NULL CHECK (char_length(name) BETWEEN 1 AND 100),
+    timezone_id TEXT NOT NULL,
+    country_code TEXT NOT NULL DEFAULT '',
+    latitude NUMERIC(6, 3) NOT NULL DEFAULT 0 CHECK (latitude BETWEEN -90 AND 90),
+    longitude NUMERIC(7, 3) NOT NULL DEFAULT 0 CHECK (longitude BETWEEN -180 AND 180),
+    radius_km INTEGER NOT NULL DEFAULT 0 CHECK (radius_km BETWEEN 0 AND 100),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    CHECK (radius_km > 0 OR country_code ~ '^[A-Z]{2}$')
+);
+
+-- Each row holds the minutes after local midnight at which a time begins.
+-- Isha may pass midnight, so it runs to the end of the following day.
+CREATE TABLE ${GLOBAL_DB_SCHEMA}.timetable_days (
+    timetable_id BIGINT NOT NULL
+        REFERENCES ${GLOBAL_DB_SCHEMA}.timetables(id) ON DELETE CASCADE,
+    local_date DATE NOT NULL,
+    fajr INTEGER NOT NULL CHECK (fajr BETWEEN 0 AND 1439),
+    sunrise INTEGER NOT NULL CHECK (sunrise BETWEEN 0 AND 1439),
+    dhuhr INTEGER NOT NULL CHECK (dhuhr BETWEEN 0 AND 1439),
+    asr INTEGER NOT NULL CHECK (asr BETWEEN 0 AND 1439),
+    maghrib INTEGER NOT NULL CHECK (maghrib BETWEEN 0 AND 1439),
+    isha INTEGER NOT NULL CHECK (isha BETWEEN 0 AND 2879),
+    PRIMARY KEY (timetable_id, local_date)
+);
+
+-- +goose Down
+DROP TABLE ${GLOBAL_DB_SCHEMA}.timetable_days;
+DROP TABLE ${GLOBAL_DB_SCHEMA}.timetables;
+-- +goose ENVSUB OFF
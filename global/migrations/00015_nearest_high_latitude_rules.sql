@@ -0,0 +1,30 @@
+-- +goose Up
+-- +goose ENVSUB ON
+-- Nearest-latitude ("aqrab al-bilad", 48.5°) and nearest-day ("aqrab
+-- al-ayyam") high-latitude rules. The inline CHECK from the initial migration
+-- is replaced with one listing every rule the application accepts.
+ALTER TABLE ${GLOBAL_DB_SCHEMA}.prayer_profiles
+    DROP CONSTRAINT prayer_profiles_high_latitude_rule_check;
+
+ALTER TABLE ${GLOBAL_DB_SCHEMA}.prayer_profiles
+    ADD CONSTRAINT prayer_profiles_high_latitude_rule_check
+    CHECK (high_latitude_rule IN (
+        'angle_based', 'middle_of_night', 'one_seventh', 'nearest_latitude', 'nearest_day'
+    ));
+
+-- +goose Down
+-- Downgraded binaries only know the original three rules, so profiles on the
+-- new ones fall back to the default angle-based rule.
+UPDATE ${GLOBAL_DB_SCHEMA}.prayer_profiles
+SET high_latitude_rule = 'angle_based',
+    version = version + 1,
+    updated_at = now()
+WHERE high_latitude_rule IN ('nearest_latitude', 'nearest_day');
+
+ALTER TABLE ${GLOBAL_DB_SCHEMA}.prayer_profiles
+    DROP CONSTRAINT prayer_profiles_high_latitude_rule_check;
+
+ALTER TABLE ${GLOBAL_DB_SCHEMA}.prayer_profiles
+    ADD CONSTRAINT prayer_profiles_high_latitude_rule_check
+    CHECK (high_latitude_rule IN ('angle_based', 'middle_of_night', 'one_seventh'));
+-- +goose ENVSUB OFF
@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose ENVSUB ON
+-- The rounding and ihtiyat (precautionary margin) policy is stored as JSONB
+-- like adjustments. The empty object rounds every time to the nearest minute
+-- without a margin, which is what calculations did before.
+ALTER TABLE ${GLOBAL_DB_SCHEMA}.prayer_profiles
+    ADD COLUMN precaution JSONB NOT NULL DEFAULT '{}'::jsonb
+        CHECK (COALESCE((precaution ->> 'ihtiyat_minutes')::integer, 0) BETWEEN 0 AND 5);
+
+-- +goose Down
+-- Times calculated without the policy differ, so queued reminders of affected
+-- profiles become stale instead of firing at the old times.
+UPDATE ${GLOBAL_DB_SCHEMA}.prayer_profiles
+SET version = version + 1,
+    updated_at = now()
+WHERE precaution <> '{}'::jsonb;
+
+ALTER TABLE ${GLOBAL_DB_SCHEMA}.prayer_profiles
+    DROP COLUMN precaution;
+-- +goose ENVSUB OFF
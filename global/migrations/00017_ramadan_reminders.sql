@@ -0,0 +1,58 @@
+-- +goose Up
+-- +goose ENVSUB ON
+-- Ramadan reminders fire only on days of Ramadan: suhoor some minutes before
+-- Fajr (held in offset_minutes) and iftar at Maghrib. Both share a cleanup
+-- slot, so the iftar notice replaces the morning's suhoor notice.
+ALTER TABLE ${GLOBAL_DB_SCHEMA}.reminder_rules
+    DROP CONSTRAINT reminder_rules_kind_check;
+
+ALTER TABLE ${GLOBAL_DB_SCHEMA}.reminder_rules
+    ADD CONSTRAINT reminder_rules_kind_check
+    CHECK (kind IN (
+        'before', 'at', 'tomorrow', 'weekly_fasting', 'weekly_kahf',
+        'occasion_major', 'occasion_fasting', 'occasion_observed',
+        'white_days', 'extended_time', 'suhoor', 'iftar'
+    ));
+
+ALTER TABLE ${GLOBAL_DB_SCHEMA}.notification_message_slots
+    DROP CONSTRAINT notification_message_slots_category_check;
+
+ALTER TABLE ${GLOBAL_DB_SCHEMA}.notification_message_slots
+    ADD CONSTRAINT notification_message_slots_category_check
+    CHECK (category IN (
+        'prayer', 'tomorrow', 'weekly_fasting', 'weekly_kahf',
+        'islamic_occasion', 'extended_time', 'ramadan'
+    ));
+
+-- +goose Down
+DELETE FROM ${GLOBAL_DB_SCHEMA}.notification_message_slots
+WHERE category = 'ramadan';
+
+ALTER TABLE ${GLOBAL_DB_SCHEMA}.notification_message_slots
+    DROP CONSTRAINT notification_message_slots_category_check;
+
+ALTER TABLE ${GLOBAL_DB_SCHEMA}.notification_message_slots
+    ADD CONSTRAINT notification_message_slots_category_check
+    CHECK (category IN (
+        'prayer', 'tomorrow', 'weekly_fasting', 'weekly_kahf',
+        'islamic_occasion', 'extended_time'
+    ));
+
+DELETE FROM ${GLOBAL_DB_SCHEMA}.reminder_schedules s
+USING ${GLOBAL_DB_SCHEMA}.reminder_rules r
+WHERE s.rule_id = r.id AND r.kind IN ('suhoor', 'iftar');
+
+DELETE FROM ${GLOBAL_DB_SCHEMA}.reminder_rules
+WHERE kind IN ('suhoor', 'iftar');
+
+ALTER TABLE ${GLOBAL_DB_SCHEMA}.reminder_rules
+    DROP CONSTRAINT reminder_rules_kind_check;
+
+ALTER TABLE ${GLOBAL_DB_SCHEMA}.reminder_rules
+    ADD CONSTRAINT reminder_rules_kind_check
+    CHECK (kind IN (
+        'before', 'at', 'tomorrow', 'weekly_fasting', 'weekly_kahf',
+        'occasion_major', 'occasion_fasting', 'occasion_observed',
+        'white_days', 'extended_time'
+    ));
+-- +goose ENVSUB OFF
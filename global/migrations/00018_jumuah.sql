@@ -0,0 +1,50 @@
+-- +goose Up
+-- +goose ENVSUB ON
+-- The Jumu'ah khutbah and optional iqamah times of the chat's mosque live on
+-- the profile as JSONB, because on Fridays they replace calculated Dhuhr
+-- everywhere a profile is rendered. The empty object means not set.
+ALTER TABLE ${GLOBAL_DB_SCHEMA}.prayer_profiles
+    ADD COLUMN jumuah JSONB NOT NULL DEFAULT '{}'::jsonb;
+
+-- The Friday reminder fires offset_minutes before the khutbah and shares the
+-- prayer cleanup slot, like other pre-prayer reminders.
+ALTER TABLE ${GLOBAL_DB_SCHEMA}.reminder_rules
+    DROP CONSTRAINT reminder_rules_kind_check;
+
+ALTER TABLE ${GLOBAL_DB_SCHEMA}.reminder_rules
+    ADD CONSTRAINT reminder_rules_kind_check
+    CHECK (kind IN (
+        'before', 'at', 'tomorrow', 'weekly_fasting', 'weekly_kahf',
+        'occasion_major', 'occasion_fasting', 'occasion_observed',
+        'white_days', 'extended_time', 'suhoor', 'iftar', 'jumuah'
+    ));
+
+-- +goose Down
+DELETE FROM ${GLOBAL_DB_SCHEMA}.reminder_schedules s
+USING ${GLOBAL_DB_SCHEMA}.reminder_rules r
+WHERE s.rule_id = r.id AND r.kind = 'jumuah';
+
+DELETE FROM ${GLOBAL_DB_SCHEMA}.reminder_rules
+WHERE kind = 'jumuah';
+
+ALTER TABLE ${GLOBAL_DB_SCHEMA}.reminder_rules
+    DROP CONSTRAINT reminder_rules_kind_check;
+
+ALTER TABLE ${GLOBAL_DB_SCHEMA}.reminder_rules
+    ADD CONSTRAINT reminder_rules_kind_check
+    CHECK (kind IN (
+        'before', 'at', 'tomorrow', 'weekly_fasting', 'weekly_kahf',
+        'occasion_major', 'occasion_fasting', 'occasion_observed',
+        'white_days', 'extended_time', 'suhoor', 'iftar'
+    ));
+
+-- Schedules rendered with the Jumu'ah times change, so queued reminders of
+-- affected profiles become stale instead of firing at the old times.
+UPDATE ${GLOBAL_DB_SCHEMA}.prayer_profiles
+SET version = version + 1,
+    updated_at = now()
+WHERE jumuah <> '{}'::jsonb;
+
+ALTER TABLE ${GLOBAL_DB_SCHEMA}.prayer_profiles
+    DROP COLUMN jumuah;
+-- +goose ENVSUB OFF
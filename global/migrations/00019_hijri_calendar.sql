@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose ENVSUB ON
+-- Each profile dates days in one Hijri calendar system. Existing profiles
+-- keep the Umm al-Qura table they have always used; the moon-sighting
+-- correction in hijri_adjustment applies on top of any of them.
+ALTER TABLE ${GLOBAL_DB_SCHEMA}.prayer_profiles
+    ADD COLUMN hijri_calendar TEXT NOT NULL DEFAULT 'umm_al_qura'
+    CHECK (hijri_calendar IN ('umm_al_qura', 'tabular', 'diyanet', 'crescent'));
+
+-- +goose Down
+-- Profiles on another calendar fall back to Umm al-Qura, which moves Ramadan
+-- and occasion reminders, so their queued schedules become stale.
+UPDATE ${GLOBAL_DB_SCHEMA}.prayer_profiles
+SET version = version + 1,
+    updated_at = now()
+WHERE hijri_calendar <> 'umm_al_qura';
+
+ALTER TABLE ${GLOBAL_DB_SCHEMA}.prayer_profiles
+    DROP COLUMN hijri_calendar;
+-- +goose ENVSUB OFF
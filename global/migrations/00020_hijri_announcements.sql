@@ -0,0 +1,40 @@
+-- +goose Up
+-- +goose ENVSUB ON
+-- Regional announcements of the day a Hijri month began, published by the
+-- owner. Profiles whose country_code matches reckon Hijri dates from the
+-- latest announcement that has begun. It is global data, not chat-owned.
+CREATE TABLE ${GLOBAL_DB_SCHEMA}.hijri_announcements (
+    country_code TEXT NOT NULL CHECK (country_code ~ '^[A-Z]{2}$'),
+    hijri_year INTEGER NOT NULL CHECK (hijri_year BETWEEN 1300 AND 1700),
+    hijri_month INTEGER NOT NULL CHECK (hijri_month BETWEEN 1 AND 12),
+    starts_on DATE NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (country_code, hijri_year, hijri_month)
+);
+
+-- Publishing or withdrawing one queues a re-plan of every chat in the
+-- country through the outbox.
+CREATE INDEX prayer_profiles_country_code_idx
+    ON ${GLOBAL_DB_SCHEMA}.prayer_profiles (country_code);
+
+ALTER TABLE ${GLOBAL_DB_SCHEMA}.task_outbox
+    DROP CONSTRAINT task_outbox_endpoint_check;
+
+ALTER TABLE ${GLOBAL_DB_SCHEMA}.task_outbox
+    ADD CONSTRAINT task_outbox_endpoint_check
+    CHECK (endpoint IN ('/tasks/send', '/tasks/delete', '/tasks/announce'));
+
+-- +goose Down
+DELETE FROM ${GLOBAL_DB_SCHEMA}.task_outbox
+WHERE endpoint = '/tasks/announce';
+
+ALTER TABLE ${GLOBAL_DB_SCHEMA}.task_outbox
+    DROP CONSTRAINT task_outbox_endpoint_check;
+
+ALTER TABLE ${GLOBAL_DB_SCHEMA}.task_outbox
+    ADD CONSTRAINT task_outbox_endpoint_check
+    CHECK (endpoint IN ('/tasks/send', '/tasks/delete'));
+
+DROP INDEX ${GLOBAL_DB_SCHEMA}.prayer_profiles_country_code_idx;
+DROP TABLE ${GLOBAL_DB_SCHEMA}.hijri_announcements;
+-- +goose ENVSUB OFF
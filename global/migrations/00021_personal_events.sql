@@ -0,0 +1,78 @@
+-- +goose Up
+-- +goose ENVSUB ON
+-- Yearly dates a chat adds for itself, such as a death anniversary or a Hijri
+-- birthday. They recur on the same day of the named calendar; Hijri ones
+-- follow the chat's Hijri calendar and correction.
+CREATE TABLE ${GLOBAL_DB_SCHEMA}.personal_events (
+    id BIGSERIAL PRIMARY KEY,
+    chat_id BIGINT NOT NULL
+        REFERENCES ${GLOBAL_DB_SCHEMA}.chats(telegram_chat_id) ON DELETE CASCADE,
+    title TEXT NOT NULL CHECK (char_length(title) BETWEEN 1 AND 64),
+    calendar TEXT NOT NULL CHECK (calendar IN ('hijri', 'gregorian')),
+    event_month INTEGER NOT NULL CHECK (event_month BETWEEN 1 AND 12),
+    event_day INTEGER NOT NULL CHECK (event_day BETWEEN 1 AND 31),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    CHECK (calendar = 'gregorian' OR event_day <= 30)
+);
+
+CREATE INDEX personal_events_chat_id_idx
+    ON ${GLOBAL_DB_SCHEMA}.personal_events (chat_id, id);
+
+-- One rule reminds the evening before any of the chat's events, in its own
+-- cleanup slot so it never replaces a reminder for a catalog occasion.
+ALTER TABLE ${GLOBAL_DB_SCHEMA}.reminder_rules
+    DROP CONSTRAINT reminder_rules_kind_check;
+
+ALTER TABLE ${GLOBAL_DB_SCHEMA}.reminder_rules
+    ADD CONSTRAINT reminder_rules_kind_check
+    CHECK (kind IN (
+        'before', 'at', 'tomorrow', 'weekly_fasting', 'weekly_kahf',
+        'occasion_major', 'occasion_fasting', 'occasion_observed',
+        'white_days', 'extended_time', 'suhoor', 'iftar', 'jumuah',
+        'personal_event'
+    ));
+
+ALTER TABLE ${GLOBAL_DB_SCHEMA}.notification_message_slots
+    DROP CONSTRAINT notification_message_slots_category_check;
+
+ALTER TABLE ${GLOBAL_DB_SCHEMA}.notification_message_slots
+    ADD CONSTRAINT notification_message_slots_category_check
+    CHECK (category IN (
+        'prayer', 'tomorrow', 'weekly_fasting', 'weekly_kahf',
+        'islamic_occasion', 'extended_time', 'ramadan', 'personal_event'
+    ));
+
+-- +goose Down
+DELETE FROM ${GLOBAL_DB_SCHEMA}.notification_message_slots
+WHERE category = 'personal_event';
+
+ALTER TABLE ${GLOBAL_DB_SCHEMA}.notification_message_slots
+    DROP CONSTRAINT notification_message_slots_category_check;
+
+ALTER TABLE ${GLOBAL_DB_SCHEMA}.notification_message_slots
+    ADD CONSTRAINT notification_message_slots_category_check
+    CHECK (category IN (
+        'prayer', 'tomorrow', 'weekly_fasting', 'weekly_kahf',
+        'islamic_occasion', 'extended_time', 'ramadan'
+    ));
+
+DELETE FROM ${GLOBAL_DB_SCHEMA}.reminder_schedules s
+USING ${GLOBAL_DB_SCHEMA}.reminder_rules r
+WHERE s.rule_id = r.id AND r.kind = 'personal_event';
+
+DELETE FROM ${GLOBAL_DB_SCHEMA}.reminder_rules
+WHERE kind = 'personal_event';
+
+ALTER TABLE ${GLOBAL_DB_SCHEMA}.reminder_rules
+    DROP CONSTRAINT reminder_rules_kind_check;
+
+ALTER TABLE ${GLOBAL_DB_SCHEMA}.reminder_rules
+    ADD CONSTRAINT reminder_rules_kind_check
+    CHECK (kind IN (
+        'before', 'at', 'tomorrow', 'weekly_fasting', 'weekly_kahf',
+        'occasion_major', 'occasion_fasting', 'occasion_observed',
+        'white_days', 'extended_time', 'suhoor', 'iftar', 'jumuah'
+    ));
+
+DROP TABLE ${GLOBAL_DB_SCHEMA}.personal_events;
+-- +goose ENVSUB OFF
@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose ENVSUB ON
+-- The moon's phase is shown with every schedule; the profile only records a
+-- chat's choice to add the new moon and the expected first crescent to its
+-- calendar feed.
+ALTER TABLE ${GLOBAL_DB_SCHEMA}.prayer_profiles
+    ADD COLUMN moon_events BOOLEAN NOT NULL DEFAULT false;
+
+-- +goose Down
+ALTER TABLE ${GLOBAL_DB_SCHEMA}.prayer_profiles
+    DROP COLUMN moon_events;
+-- +goose ENVSUB OFF
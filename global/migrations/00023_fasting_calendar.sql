@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose ENVSUB ON
+-- A chat may subscribe to the fasting calendar besides the prayer calendar,
+-- each with its own private link.
+ALTER TABLE ${GLOBAL_DB_SCHEMA}.calendar_subscriptions
+    ADD COLUMN feed TEXT NOT NULL DEFAULT 'prayer'
+        CHECK (feed IN ('prayer', 'fasting'));
+ALTER TABLE ${GLOBAL_DB_SCHEMA}.calendar_subscriptions
+    DROP CONSTRAINT calendar_subscriptions_pkey,
+    ADD PRIMARY KEY (chat_id, feed);
+
+-- +goose Down
+DELETE FROM ${GLOBAL_DB_SCHEMA}.calendar_subscriptions WHERE feed <> 'prayer';
+ALTER TABLE ${GLOBAL_DB_SCHEMA}.calendar_subscriptions
+    DROP CONSTRAINT calendar_subscriptions_pkey,
+    ADD PRIMARY KEY (chat_id);
+ALTER TABLE ${GLOBAL_DB_SCHEMA}.calendar_subscriptions
+    DROP COLUMN feed;
+-- +goose ENVSUB OFF
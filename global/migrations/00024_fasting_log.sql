@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose ENVSUB ON
+-- The days a chat logged as fasted, made up or missed. ramadan records whether
+-- the day was in Ramadan by the chat's calendar when it was logged, because
+-- only a missed Ramadan day is owed and a later change of calendar must not
+-- rewrite the balance.
+CREATE TABLE ${GLOBAL_DB_SCHEMA}.fasting_log (
+    chat_id BIGINT NOT NULL
+        REFERENCES ${GLOBAL_DB_SCHEMA}.chats(telegram_chat_id) ON DELETE CASCADE,
+    fast_date DATE NOT NULL,
+    status TEXT NOT NULL CHECK (status IN ('fasted', 'makeup', 'missed')),
+    ramadan BOOLEAN NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (chat_id, fast_date),
+    CHECK (NOT (ramadan AND status = 'makeup'))
+);
+
+-- Make-up fasts a chat owed before it kept a log.
+CREATE TABLE ${GLOBAL_DB_SCHEMA}.fasting_qada (
+    chat_id BIGINT PRIMARY KEY
+        REFERENCES ${GLOBAL_DB_SCHEMA}.chats(telegram_chat_id) ON DELETE CASCADE,
+    carried_days INTEGER NOT NULL CHECK (carried_days BETWEEN 0 AND 1000),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- +goose Down
+DROP TABLE ${GLOBAL_DB_SCHEMA}.fasting_qada;
+DROP TABLE ${GLOBAL_DB_SCHEMA}.fasting_log;
+-- +goose ENVSUB OFF
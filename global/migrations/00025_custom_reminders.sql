@@ -0,0 +1,99 @@
+-- +goose Up
+-- +goose ENVSUB ON
+-- Reminders a chat writes for itself. Each owns one reminder rule of kind
+-- 'custom', which schedules it like any other rule; deleting the rule deletes
+-- the reminder. It fires at local_time, or offset_minutes from the anchor
+-- prayer (negative before it), on the days its recurrence names.
+ALTER TABLE ${GLOBAL_DB_SCHEMA}.reminder_rules
+    DROP CONSTRAINT reminder_rules_kind_check;
+
+ALTER TABLE ${GLOBAL_DB_SCHEMA}.reminder_rules
+    ADD CONSTRAINT reminder_rules_kind_check
+    CHECK (kind IN (
+        'before', 'at', 'tomorrow', 'weekly_fasting', 'weekly_kahf',
+        'occasion_major', 'occasion_fasting', 'occasion_observed',
+        'white_days', 'extended_time', 'suhoor', 'iftar', 'jumuah',
+        'personal_event', 'custom'
+    ));
+
+-- A chat keeps several custom rules that differ only in their reminder, so
+-- the built-in rules alone stay unique per chat, kind, prayer and offset.
+ALTER TABLE ${GLOBAL_DB_SCHEMA}.reminder_rules
+    DROP CONSTRAINT reminder_rules_chat_id_kind_prayer_offset_minutes_key;
+
+CREATE UNIQUE INDEX reminder_rules_builtin_key
+    ON ${GLOBAL_DB_SCHEMA}.reminder_rules (chat_id, kind, prayer, offset_minutes)
+    WHERE kind <> 'custom';
+
+CREATE TABLE ${GLOBAL_DB_SCHEMA}.custom_reminders (
+    rule_id BIGINT PRIMARY KEY
+        REFERENCES ${GLOBAL_DB_SCHEMA}.reminder_rules(id) ON DELETE CASCADE,
+    chat_id BIGINT NOT NULL
+        REFERENCES ${GLOBAL_DB_SCHEMA}.chats(telegram_chat_id) ON DELETE CASCADE,
+    text TEXT NOT NULL CHECK (char_length(text) BETWEEN 1 AND 200),
+    local_time TEXT NOT NULL DEFAULT '',
+    anchor TEXT NOT NULL DEFAULT ''
+        CHECK (anchor IN ('', 'fajr', 'sunrise', 'dhuhr', 'asr', 'maghrib', 'isha')),
+    offset_minutes INTEGER NOT NULL DEFAULT 0 CHECK (offset_minutes BETWEEN -180 AND 180),
+    recurrence TEXT NOT NULL CHECK (recurrence IN ('daily', 'weekly', 'hijri_monthly', 'once')),
+    weekdays SMALLINT NOT NULL DEFAULT 0 CHECK (weekdays BETWEEN 0 AND 127),
+    hijri_day INTEGER NOT NULL DEFAULT 0 CHECK (hijri_day BETWEEN 0 AND 30),
+    on_date DATE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    CHECK ((anchor = '') <> (local_time = '')),
+    CHECK (recurrence <> 'weekly' OR weekdays > 0),
+    CHECK (recurrence <> 'hijri_monthly' OR hijri_day > 0),
+    CHECK (recurrence <> 'once' OR on_date IS NOT NULL)
+);
+
+CREATE INDEX custom_reminders_chat_id_idx
+    ON ${GLOBAL_DB_SCHEMA}.custom_reminders (chat_id, rule_id);
+
+-- All custom reminders share one cleanup slot, like the extended times.
+ALTER TABLE ${GLOBAL_DB_SCHEMA}.notification_message_slots
+    DROP CONSTRAINT notification_message_slots_category_check;
+
+ALTER TABLE ${GLOBAL_DB_SCHEMA}.notification_message_slots
+    ADD CONSTRAINT notification_message_slots_category_check
+    CHECK (category IN (
+        'prayer', 'tomorrow', 'weekly_fasting', 'weekly_kahf',
+        'islamic_occasion', 'extended_time', 'ramadan', 'personal_event', 'custom'
+    ));
+
+-- +goose Down
+DELETE FROM ${GLOBAL_DB_SCHEMA}.notification_message_slots
+WHERE category = 'custom';
+
+ALTER TABLE ${GLOBAL_DB_SCHEMA}.notification_message_slots
+    DROP CONSTRAINT notification_message_slots_category_check;
+
+ALTER TABLE ${GLOBAL_DB_SCHEMA}.notification_message_slots
+    ADD CONSTRAINT notification_message_slots_category_check
+    CHECK (category IN (
+        'prayer', 'tomorrow', 'weekly_fasting', 'weekly_kahf',
+        'islamic_occasion', 'extended_time', 'ramadan', 'personal_event'
+    ));
+
+DROP TABLE ${GLOBAL_DB_SCHEMA}.custom_reminders;
+
+DELETE FROM ${GLOBAL_DB_SCHEMA}.reminder_rules
+WHERE kind = 'custom';
+
+DROP INDEX ${GLOBAL_DB_SCHEMA}.reminder_rules_builtin_key;
+
+ALTER TABLE ${GLOBAL_DB_SCHEMA}.reminder_rules
+    ADD CONSTRAINT reminder_rules_chat_id_kind_prayer_offset_minutes_key
+    UNIQUE (chat_id, kind, prayer, offset_minutes);
+
+ALTER TABLE ${GLOBAL_DB_SCHEMA}.reminder_rules
+    DROP CONSTRAINT reminder_rules_kind_check;
+
+ALTER TABLE ${GLOBAL_DB_SCHEMA}.reminder_rules
+    ADD CONSTRAINT reminder_rules_kind_check
+    CHECK (kind IN (
+        'before', 'at', 'tomorrow', 'weekly_fasting', 'weekly_kahf',
+        'occasion_major', 'occasion_fasting', 'occasion_observed',
+        'white_days', 'extended_time', 'suhoor', 'iftar', 'jumuah',
+        'personal_event'
+    ));
+-- +goose ENVSUB OFF
@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose ENVSUB ON
+-- A schedule whose rule has nothing left to plan, as after a one-off custom
+-- reminder, is kept as 'done' rather than deleted: its deliveries cascade
+-- from it and are the record that stops a retried task sending again.
+ALTER TABLE ${GLOBAL_DB_SCHEMA}.reminder_schedules
+    DROP CONSTRAINT reminder_schedules_state_check;
+
+ALTER TABLE ${GLOBAL_DB_SCHEMA}.reminder_schedules
+    ADD CONSTRAINT reminder_schedules_state_check
+    CHECK (state IN ('pending', 'queued', 'processing', 'done'));
+
+-- +goose Down
+DELETE FROM ${GLOBAL_DB_SCHEMA}.reminder_schedules
+WHERE state = 'done';
+
+ALTER TABLE ${GLOBAL_DB_SCHEMA}.reminder_schedules
+    DROP CONSTRAINT reminder_schedules_state_check;
+
+ALTER TABLE ${GLOBAL_DB_SCHEMA}.reminder_schedules
+    ADD CONSTRAINT reminder_schedules_state_check
+    CHECK (state IN ('pending', 'queued', 'processing'));
+-- +goose ENVSUB OFF
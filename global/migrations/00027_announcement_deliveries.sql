@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose ENVSUB ON
+-- The idempotency record of Hijri announcement notices, which have no
+-- schedule to hang a notification delivery on. A retried task whose notice
+-- was already sent finds its row and sends nothing.
+CREATE TABLE ${GLOBAL_DB_SCHEMA}.announcement_deliveries (
+    delivery_key TEXT PRIMARY KEY,
+    chat_id BIGINT NOT NULL
+        REFERENCES ${GLOBAL_DB_SCHEMA}.chats(telegram_chat_id) ON DELETE CASCADE,
+    status TEXT NOT NULL CHECK (status IN ('processing', 'sent', 'failed')),
+    attempts INTEGER NOT NULL DEFAULT 1,
+    lease_until TIMESTAMPTZ,
+    telegram_message_id BIGINT,
+    last_error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX announcement_deliveries_retention_idx
+    ON ${GLOBAL_DB_SCHEMA}.announcement_deliveries (updated_at)
+    WHERE status IN ('sent', 'failed');
+
+-- +goose Down
+DROP TABLE ${GLOBAL_DB_SCHEMA}.announcement_deliveries;
+-- +goose ENVSUB OFF
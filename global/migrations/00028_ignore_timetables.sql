@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose ENVSUB ON
+-- An official timetable replaces the method, madhab and angles of every
+-- profile it covers; a chat can keep its own calculation instead.
+ALTER TABLE ${GLOBAL_DB_SCHEMA}.prayer_profiles
+    ADD COLUMN ignore_timetables BOOLEAN NOT NULL DEFAULT false;
+
+-- +goose Down
+ALTER TABLE ${GLOBAL_DB_SCHEMA}.prayer_profiles
+    DROP COLUMN ignore_timetables;
+-- +goose ENVSUB OFF